@@ -0,0 +1,81 @@
+package goop
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// AuditEvent records a request to a mutating operation: who called it, which resource it
+// targeted, and what it asked to change
+type AuditEvent struct {
+	OperationID string
+	Method      string
+	// Path is the operation's path template, e.g. /users/{id}
+	Path string
+	Time time.Time
+	// Actor is the authenticated principal, if any
+	Actor interface{}
+	// Tenant is the tenant the request acted for, if any
+	Tenant interface{}
+	// Target holds the validated path parameters identifying the resource, such as {"id": "usr_123"}
+	Target map[string]interface{}
+	// Changes are the fields set by the validated request body, with classified values masked
+	Changes    []AuditChange
+	StatusCode int
+}
+
+// Succeeded reports whether the audited request completed with a 2xx status
+func (e AuditEvent) Succeeded() bool {
+	return e.StatusCode >= 200 && e.StatusCode < 300
+}
+
+// AuditChange is a field set by a request body
+// Nested objects are flattened to dotted paths; arrays are recorded whole, and a nil Value clears the field.
+type AuditChange struct {
+	Field string      `json:"field"`
+	Value interface{} `json:"value"`
+}
+
+// AuditSink receives the audit events of mutating operations, such as a log or an audit table
+// Implementations must be safe for concurrent use
+type AuditSink interface {
+	Record(ctx context.Context, event AuditEvent) error
+}
+
+// IsAuditedMethod reports whether requests with method change state and are audited
+func IsAuditedMethod(method string) bool {
+	switch method {
+	case "POST", "PUT", "PATCH", "DELETE":
+		return true
+	}
+	return false
+}
+
+// AuditChanges returns the fields set by body, a decoded JSON document, sorted by field
+// A body that is not an object is recorded as a single change with an empty field.
+func AuditChanges(body interface{}) []AuditChange {
+	object, ok := body.(map[string]interface{})
+	if !ok {
+		if body == nil {
+			return nil
+		}
+		return []AuditChange{{Value: body}}
+	}
+	var changes []AuditChange
+	collectAuditChanges("", object, &changes)
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+	return changes
+}
+
+func collectAuditChanges(path string, object map[string]interface{}, changes *[]AuditChange) {
+	for name, value := range object {
+		field := joinFieldPath(path, name)
+		// Empty objects are recorded as values so setting one is not lost
+		if nested, ok := value.(map[string]interface{}); ok && len(nested) > 0 {
+			collectAuditChanges(field, nested, changes)
+			continue
+		}
+		*changes = append(*changes, AuditChange{Field: field, Value: value})
+	}
+}
@@ -0,0 +1,42 @@
+package goop
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestAuditChanges tests flattening request bodies into audited changes
+func TestAuditChanges(t *testing.T) {
+	body := map[string]interface{}{
+		"name":    "Ada",
+		"address": map[string]interface{}{"city": "London", "zip": nil},
+		"tags":    []interface{}{"admin"},
+		"meta":    map[string]interface{}{},
+	}
+	expected := []AuditChange{
+		{Field: "address.city", Value: "London"},
+		{Field: "address.zip", Value: nil},
+		{Field: "meta", Value: map[string]interface{}{}},
+		{Field: "name", Value: "Ada"},
+		{Field: "tags", Value: []interface{}{"admin"}},
+	}
+	if changes := AuditChanges(body); !reflect.DeepEqual(changes, expected) {
+		t.Errorf("Expected %v, got %v", expected, changes)
+	}
+
+	if changes := AuditChanges(nil); changes != nil {
+		t.Errorf("Expected no changes without a body, got %v", changes)
+	}
+	if changes := AuditChanges([]interface{}{"a"}); len(changes) != 1 || changes[0].Field != "" {
+		t.Errorf("Expected a single change for a non-object body, got %v", changes)
+	}
+}
+
+// TestIsAuditedMethod tests which methods are audited
+func TestIsAuditedMethod(t *testing.T) {
+	for method, audited := range map[string]bool{"POST": true, "PUT": true, "PATCH": true, "DELETE": true, "GET": false, "HEAD": false} {
+		if IsAuditedMethod(method) != audited {
+			t.Errorf("%s: expected audited %v", method, audited)
+		}
+	}
+}
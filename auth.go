@@ -0,0 +1,20 @@
+package goop
+
+import "context"
+
+// authContextKey is the unexported context key for the authenticated principal
+// Using a private type avoids collisions with keys set by other packages
+type authContextKey struct{}
+
+// WithAuth returns a copy of ctx carrying the authenticated principal
+// Framework adapters call this after security enforcement succeeds
+func WithAuth(ctx context.Context, principal interface{}) context.Context {
+	return context.WithValue(ctx, authContextKey{}, principal)
+}
+
+// AuthFromContext returns the authenticated principal stored in ctx as type T
+// The boolean is false when no principal is present or it is not of type T
+func AuthFromContext[T any](ctx context.Context) (T, bool) {
+	principal, ok := ctx.Value(authContextKey{}).(T)
+	return principal, ok
+}
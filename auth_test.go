@@ -0,0 +1,55 @@
+package goop
+
+import (
+	"context"
+	"testing"
+)
+
+type testPrincipal struct {
+	UserID string
+	Scopes []string
+}
+
+// TestAuthFromContext tests typed retrieval of the authenticated principal
+func TestAuthFromContext(t *testing.T) {
+	t.Run("Returns principal of the requested type", func(t *testing.T) {
+		ctx := WithAuth(context.Background(), &testPrincipal{UserID: "usr_123"})
+
+		principal, ok := AuthFromContext[*testPrincipal](ctx)
+		if !ok {
+			t.Fatal("Expected principal to be found")
+		}
+		if principal.UserID != "usr_123" {
+			t.Errorf("Expected user ID 'usr_123', got '%s'", principal.UserID)
+		}
+	})
+
+	t.Run("Reports missing principal", func(t *testing.T) {
+		principal, ok := AuthFromContext[*testPrincipal](context.Background())
+		if ok {
+			t.Error("Expected no principal in empty context")
+		}
+		if principal != nil {
+			t.Errorf("Expected nil principal, got %v", principal)
+		}
+	})
+
+	t.Run("Reports type mismatch", func(t *testing.T) {
+		ctx := WithAuth(context.Background(), testPrincipal{UserID: "usr_123"})
+
+		if _, ok := AuthFromContext[*testPrincipal](ctx); ok {
+			t.Error("Expected pointer lookup to fail for value principal")
+		}
+		if _, ok := AuthFromContext[testPrincipal](ctx); !ok {
+			t.Error("Expected value lookup to succeed")
+		}
+	})
+
+	t.Run("Does not collide with string keys", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), "auth", "not a principal") //nolint:staticcheck // SA1029: simulating adapter string keys
+
+		if _, ok := AuthFromContext[string](ctx); ok {
+			t.Error("Expected string-keyed value to be ignored")
+		}
+	})
+}
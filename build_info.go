@@ -0,0 +1,21 @@
+package goop
+
+import "time"
+
+// BuildInfoExtension is the info extension identifying the build that produced a spec
+const BuildInfoExtension = "x-build"
+
+// BuildInfo identifies the build of a service, so consumers can confirm which build produced
+// the spec they are reading
+type BuildInfo struct {
+	Version   string    `json:"version"`
+	Commit    string    `json:"commit,omitempty"`
+	BuildTime time.Time `json:"buildTime,omitzero"`
+}
+
+// MetaOperationProvider is implemented by generators that serve their build info from an
+// operation, such as GET /meta. Routers register the operation once, from their root, alongside
+// the first operation registered with them.
+type MetaOperationProvider interface {
+	MetaOperation() *CompiledOperation
+}
@@ -0,0 +1,29 @@
+package goop
+
+import (
+	"strconv"
+	"time"
+)
+
+// Response headers controlling HTTP caching, as defined in RFC 9111
+const (
+	CacheControlHeader = "Cache-Control"
+	ExpiresHeader      = "Expires"
+)
+
+// CachePolicy describes how long successful responses of an operation may be cached, and by whom
+type CachePolicy struct {
+	// MaxAge is how long a response stays fresh
+	MaxAge time.Duration
+	// Public allows shared caches such as CDNs to store responses; otherwise only the client may
+	Public bool
+}
+
+// CacheControl returns the Cache-Control header value of the policy, such as "public, max-age=300"
+func (p CachePolicy) CacheControl() string {
+	visibility := "private"
+	if p.Public {
+		visibility = "public"
+	}
+	return visibility + ", max-age=" + strconv.FormatInt(int64(p.MaxAge/time.Second), 10)
+}
@@ -0,0 +1,24 @@
+package goop
+
+import (
+	"testing"
+	"time"
+)
+
+// TestCachePolicy tests the Cache-Control values of cache policies
+func TestCachePolicy(t *testing.T) {
+	tests := []struct {
+		policy CachePolicy
+		want   string
+	}{
+		{CachePolicy{MaxAge: 5 * time.Minute, Public: true}, "public, max-age=300"},
+		{CachePolicy{MaxAge: time.Hour}, "private, max-age=3600"},
+		{CachePolicy{MaxAge: 1500 * time.Millisecond}, "private, max-age=1"},
+		{CachePolicy{}, "private, max-age=0"},
+	}
+	for _, tt := range tests {
+		if got := tt.policy.CacheControl(); got != tt.want {
+			t.Errorf("CacheControl() = %q, want %q", got, tt.want)
+		}
+	}
+}
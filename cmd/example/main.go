@@ -162,7 +162,7 @@ func main() {
 	fmt.Println("         Body: {\"name\": \"Jane Doe\", \"email\": \"jane@example.com\"}")
 
 	// Start server
-	if err := engine.Run(":8080"); err != nil {
-		panic(fmt.Sprintf("Failed to start server: %v", err))
+	if err := operations.Serve(context.Background(), engine, operations.ServeOptions{Addr: ":8080"}); err != nil {
+		panic(fmt.Sprintf("Server failed: %v", err))
 	}
 }
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/picogrid/go-op/internal/analyze"
+	"github.com/picogrid/go-op/internal/generator"
+	"github.com/picogrid/go-op/internal/scaffold"
+	"github.com/picogrid/go-op/operations"
+)
+
+var analyzeCmd = &cobra.Command{
+	Use:   "analyze",
+	Short: "Report the validation complexity of each operation's schemas",
+	Long: `Report the validation complexity of each operation's schemas and flag the hot spots
+that should be optimized.
+
+For the request and success response schemas of every operation, this command reports the
+nesting depth, the number of regular expressions, the largest oneOf or anyOf, and an
+estimated validation cost. It flags oneOf and anyOf compositions without a discriminator,
+which validate every branch, arrays of objects without maxItems, and operations above the
+thresholds. Operations are listed most expensive first.
+
+Examples:
+  # Analyze the operations of a service
+  go-op analyze -i ./service
+
+  # Analyze an existing spec
+  go-op analyze --spec openapi.yaml
+
+  # Fail in CI when a hot spot is found
+  go-op analyze -i ./service --max-cost 300 --fail`,
+	RunE: runAnalyze,
+}
+
+var (
+	analyzeInput      string
+	analyzeSpec       string
+	analyzeThresholds = analyze.DefaultThresholds
+	analyzeFail       bool
+)
+
+func init() {
+	rootCmd.AddCommand(analyzeCmd)
+
+	analyzeCmd.Flags().StringVarP(&analyzeInput, "input", "i", ".", "input directory to scan for Go files")
+	analyzeCmd.Flags().StringVar(&analyzeSpec, "spec", "", "analyze an OpenAPI spec file instead of Go source")
+	analyzeCmd.Flags().IntVar(&analyzeThresholds.MaxDepth, "max-depth", analyze.DefaultThresholds.MaxDepth, "deepest schema nesting before it is flagged")
+	analyzeCmd.Flags().IntVar(&analyzeThresholds.MaxBranches, "max-branches", analyze.DefaultThresholds.MaxBranches, "most oneOf or anyOf branches without a discriminator before they are flagged")
+	analyzeCmd.Flags().IntVar(&analyzeThresholds.MaxRegexes, "max-regexes", analyze.DefaultThresholds.MaxRegexes, "most regular expressions per operation before it is flagged")
+	analyzeCmd.Flags().Float64Var(&analyzeThresholds.MaxCost, "max-cost", analyze.DefaultThresholds.MaxCost, "highest estimated validation cost per operation before it is flagged")
+	analyzeCmd.Flags().BoolVar(&analyzeFail, "fail", false, "exit with an error when a hot spot is found")
+}
+
+func runAnalyze(cmd *cobra.Command, args []string) error {
+	var spec *operations.OpenAPISpec
+	if analyzeSpec != "" {
+		verbosePrint("Loading specification: %s", analyzeSpec)
+		loaded, err := scaffold.LoadSpec(analyzeSpec)
+		if err != nil {
+			return err
+		}
+		spec = loaded
+	} else {
+		absInputDir, err := filepath.Abs(analyzeInput)
+		if err != nil {
+			return fmt.Errorf("failed to resolve input directory: %w", err)
+		}
+		verbosePrint("Resolved input directory: %s", absInputDir)
+
+		gen := generator.New(&generator.Config{InputDir: absInputDir, Verbose: verbose})
+		if err := gen.ScanOperations(); err != nil {
+			return fmt.Errorf("failed to scan operations: %w", err)
+		}
+		if err := gen.GenerateSpec(); err != nil {
+			return fmt.Errorf("failed to generate specification: %w", err)
+		}
+		spec = gen.Spec()
+	}
+
+	report := analyze.Analyze(spec, analyzeThresholds)
+	if len(report.Operations) == 0 {
+		fmt.Println("No operations found")
+		return nil
+	}
+
+	table := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "OPERATION\tDEPTH\tREGEXES\tBRANCHES\tCOST")
+	for _, op := range report.Operations {
+		fmt.Fprintf(table, "%s\t%d\t%d\t%d\t%s\n", op.Operation, op.Depth, op.Regexes, op.Branches, analyze.FormatCost(op.Cost))
+	}
+	if err := table.Flush(); err != nil {
+		return err
+	}
+
+	hotSpots := report.HotSpots()
+	if hotSpots == 0 {
+		fmt.Println("\n✅ No hot spots found")
+		return nil
+	}
+	fmt.Println("\nHot spots:")
+	for _, op := range report.Operations {
+		for _, spot := range op.HotSpots {
+			fmt.Printf("  %s, %s: %s\n", op.Operation, spot.Location, spot.Message)
+		}
+	}
+
+	if analyzeFail {
+		return fmt.Errorf("%d hot spots found", hotSpots)
+	}
+	return nil
+}
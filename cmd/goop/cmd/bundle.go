@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/picogrid/go-op/internal/multifile"
+)
+
+var bundleCmd = &cobra.Command{
+	Use:   "bundle <root-file>",
+	Short: "Bundle a multi-file OpenAPI specification into a single file",
+	Long: `Bundle an OpenAPI specification split across files with relative $refs,
+such as one written by 'go-op generate --split', into a single file.
+
+Files under components/<kind>/ become entries of the components section and are
+referenced locally; other referenced files are inlined.
+
+Examples:
+  # Bundle a split spec into one YAML file
+  go-op bundle -o openapi.bundled.yaml ./spec/openapi.yaml
+
+  # Bundle into JSON
+  go-op bundle -o openapi.json -f json ./spec/openapi.yaml`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBundle,
+}
+
+var (
+	bundleOutput string
+	bundleFormat string
+)
+
+func init() {
+	rootCmd.AddCommand(bundleCmd)
+
+	bundleCmd.Flags().StringVarP(&bundleOutput, "output", "o", "openapi.bundled.yaml", "output file path")
+	bundleCmd.Flags().StringVarP(&bundleFormat, "format", "f", "yaml", "output format (yaml or json)")
+}
+
+func runBundle(cmd *cobra.Command, args []string) error {
+	absRootFile, err := filepath.Abs(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to resolve root file: %w", err)
+	}
+	absOutputFile, err := filepath.Abs(bundleOutput)
+	if err != nil {
+		return fmt.Errorf("failed to resolve output file: %w", err)
+	}
+
+	verbosePrint("Bundling specification: %s", absRootFile)
+	bundled, err := multifile.Bundle(absRootFile)
+	if err != nil {
+		return fmt.Errorf("failed to bundle specification: %w", err)
+	}
+
+	verbosePrint("Writing bundled specification to file...")
+	if err := multifile.WriteDocument(absOutputFile, bundled, bundleFormat); err != nil {
+		return fmt.Errorf("failed to write specification: %w", err)
+	}
+
+	fmt.Printf("✅ Bundled OpenAPI specification generated successfully: %s\n", absOutputFile)
+	return nil
+}
@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/picogrid/go-op/internal/changelog"
+	"github.com/picogrid/go-op/internal/scaffold"
+	"github.com/picogrid/go-op/operations"
+)
+
+var changelogCmd = &cobra.Command{
+	Use:   "changelog <from>..<to> | <old-spec> <new-spec>",
+	Short: "Render an API changelog between two versions of a specification",
+	Long: `Render a human-readable API changelog between two versions of an OpenAPI specification,
+grouped into added, changed, deprecated, and removed operations and ready to paste into
+release notes. Changes that may break existing clients are marked as breaking.
+
+A range of git revisions reads the spec file from git history; an empty end of the range
+reads the working tree. When --spec contains {version}, the range instead names tagged spec
+files, such as those 'go-op publish' writes, with a leading v dropped from each version.
+
+Examples:
+  # Changelog between two tags
+  go-op changelog v1.3.0..v1.4.0 --spec api/openapi.yaml
+
+  # Changes since the last tag, including uncommitted ones
+  go-op changelog v1.4.0..
+
+  # Changelog between tagged spec files specs/openapi-1.3.0.yaml and specs/openapi-1.4.0.yaml
+  go-op changelog v1.3.0..v1.4.0 --spec "specs/openapi-{version}.yaml"
+
+  # Changelog between two spec files, written to a file
+  go-op changelog old.yaml new.yaml -o CHANGES.md`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runChangelog,
+}
+
+var (
+	changelogSpec   string
+	changelogOutput string
+	changelogTitle  string
+)
+
+func init() {
+	rootCmd.AddCommand(changelogCmd)
+
+	changelogCmd.Flags().StringVar(&changelogSpec, "spec", "openapi.yaml", "spec file read at each revision, or a pattern of tagged spec files using {version}")
+	changelogCmd.Flags().StringVarP(&changelogOutput, "output", "o", "", "output file (defaults to standard output)")
+	changelogCmd.Flags().StringVarP(&changelogTitle, "title", "t", "", "changelog heading (defaults to the range or the new spec's version)")
+}
+
+func runChangelog(cmd *cobra.Command, args []string) error {
+	var oldSpec, newSpec *operations.OpenAPISpec
+	var err error
+	heading := changelogTitle
+
+	if len(args) == 2 {
+		if oldSpec, err = scaffold.LoadSpec(args[0]); err != nil {
+			return err
+		}
+		if newSpec, err = scaffold.LoadSpec(args[1]); err != nil {
+			return err
+		}
+		if heading == "" {
+			heading = newSpec.Info.Version
+		}
+	} else {
+		from, to, found := strings.Cut(args[0], "..")
+		if !found || from == "" {
+			return fmt.Errorf("invalid range %q: expected <from>..<to>", args[0])
+		}
+		if oldSpec, err = loadSpecAt(from); err != nil {
+			return err
+		}
+		if newSpec, err = loadSpecAt(to); err != nil {
+			return err
+		}
+		if heading == "" {
+			heading = args[0]
+			if to == "" {
+				heading = from + "..HEAD"
+			}
+		}
+	}
+
+	rendered := changelog.Diff(oldSpec, newSpec).Markdown(heading)
+	if changelogOutput == "" {
+		fmt.Print(rendered)
+		return nil
+	}
+
+	absOutputFile, err := filepath.Abs(changelogOutput)
+	if err != nil {
+		return fmt.Errorf("failed to resolve output file: %w", err)
+	}
+	if err := os.WriteFile(absOutputFile, []byte(rendered), 0o644); err != nil {
+		return fmt.Errorf("failed to write changelog: %w", err)
+	}
+	fmt.Printf("✅ Changelog generated successfully: %s\n", absOutputFile)
+	return nil
+}
+
+// loadSpecAt loads the spec at a git revision, from the working tree for an empty revision,
+// or from the tagged spec file of a version when --spec is a pattern
+func loadSpecAt(revision string) (*operations.OpenAPISpec, error) {
+	if strings.Contains(changelogSpec, "{version}") {
+		if revision == "" {
+			return nil, fmt.Errorf("a range of tagged spec files needs both versions")
+		}
+		file := strings.ReplaceAll(changelogSpec, "{version}", strings.TrimPrefix(revision, "v"))
+		verbosePrint("Reading tagged spec file: %s", file)
+		return scaffold.LoadSpec(file)
+	}
+	if revision == "" {
+		verbosePrint("Reading spec from the working tree: %s", changelogSpec)
+		return scaffold.LoadSpec(changelogSpec)
+	}
+
+	absSpecFile, err := filepath.Abs(changelogSpec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve spec file: %w", err)
+	}
+	verbosePrint("Reading %s at %s", changelogSpec, revision)
+	git := exec.Command("git", "show", revision+":./"+filepath.Base(absSpecFile))
+	git.Dir = filepath.Dir(absSpecFile)
+	var stderr bytes.Buffer
+	git.Stderr = &stderr
+	data, err := git.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s at %s: %s", changelogSpec, revision, strings.TrimSpace(stderr.String()))
+	}
+	spec, err := scaffold.ParseSpec(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s at %s: %w", changelogSpec, revision, err)
+	}
+	return spec, nil
+}
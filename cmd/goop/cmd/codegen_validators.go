@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/picogrid/go-op/internal/generator"
+)
+
+var codegenValidatorsCmd = &cobra.Command{
+	Use:   "codegen-validators",
+	Short: "Generate reflection-free Go validators from operation schemas",
+	Long: `Generate plain Go validation code for the schemas declared by go-op operations.
+
+This command scans your Go source code for go-op operation definitions and emits a
+struct type with a Validate method for every params, query, headers, and body object
+schema. Handlers created with CreateValidatedHandler call Validate directly on bound
+values of these types instead of converting them to maps for dynamic validation.
+
+Examples:
+  # Generate validators for the current directory
+  go-op codegen-validators
+
+  # Generate into a specific package and file
+  go-op codegen-validators -i ./api -o ./api/validators_gen.go -p api`,
+	RunE: runCodegenValidators,
+}
+
+var (
+	codegenInput   string
+	codegenOutput  string
+	codegenPackage string
+)
+
+func init() {
+	rootCmd.AddCommand(codegenValidatorsCmd)
+
+	codegenValidatorsCmd.Flags().StringVarP(&codegenInput, "input", "i", ".", "input directory to scan for Go files")
+	codegenValidatorsCmd.Flags().StringVarP(&codegenOutput, "output", "o", "validators_gen.go", "output Go file path")
+	codegenValidatorsCmd.Flags().StringVarP(&codegenPackage, "package", "p", "", "package name for generated code (defaults to the output directory name)")
+}
+
+func runCodegenValidators(cmd *cobra.Command, args []string) error {
+	absInputDir, err := filepath.Abs(codegenInput)
+	if err != nil {
+		return fmt.Errorf("failed to resolve input directory: %w", err)
+	}
+
+	absOutputFile, err := filepath.Abs(codegenOutput)
+	if err != nil {
+		return fmt.Errorf("failed to resolve output file: %w", err)
+	}
+
+	packageName := codegenPackage
+	if packageName == "" {
+		packageName = filepath.Base(filepath.Dir(absOutputFile))
+	}
+
+	verbosePrint("Resolved input directory: %s", absInputDir)
+	verbosePrint("Resolved output file: %s", absOutputFile)
+	verbosePrint("Package name: %s", packageName)
+
+	gen := generator.New(&generator.Config{
+		InputDir: absInputDir,
+		Verbose:  verbose,
+	})
+
+	verbosePrint("Scanning for go-op operations...")
+	if err := gen.ScanOperations(); err != nil {
+		return fmt.Errorf("failed to scan operations: %w", err)
+	}
+
+	source, err := gen.GenerateValidators(packageName)
+	if err != nil {
+		return fmt.Errorf("failed to generate validators: %w", err)
+	}
+
+	if err := os.WriteFile(absOutputFile, source, 0o644); err != nil {
+		return fmt.Errorf("failed to write validators: %w", err)
+	}
+
+	fmt.Printf("✅ Validators generated successfully: %s\n", absOutputFile)
+	return nil
+}
@@ -28,7 +28,10 @@ Examples:
   go-op combine -o combined.yaml -c services.yaml
 
   # Generate JSON output instead of YAML
-  go-op combine -o combined.json -f json user-*.yaml order-*.yaml`,
+  go-op combine -o combined.json -f json user-*.yaml order-*.yaml
+
+  # Split the combined spec into per-path and per-schema files
+  go-op combine -o ./spec/openapi.yaml --split user-service.yaml order-service.yaml`,
 	RunE: runCombine,
 }
 
@@ -38,6 +41,7 @@ var (
 	combineVersion string
 	combineBaseURL string
 	combineFormat  string
+	combineSplit   bool
 	combineConfig  string
 	combineVerbose bool
 	servicePrefix  []string
@@ -53,6 +57,7 @@ func init() {
 	// Output flags
 	combineCmd.Flags().StringVarP(&combineOutput, "output", "o", "combined-api.yaml", "output file path")
 	combineCmd.Flags().StringVarP(&combineFormat, "format", "f", "yaml", "output format (yaml or json)")
+	combineCmd.Flags().BoolVar(&combineSplit, "split", false, "write path items and schemas to separate files next to the output file")
 
 	// API metadata flags
 	combineCmd.Flags().StringVarP(&combineTitle, "title", "t", "Combined API", "API title for the combined specification")
@@ -97,6 +102,7 @@ func runCombine(cmd *cobra.Command, args []string) error {
 	config := &combiner.Config{
 		OutputFile:     absOutputFile,
 		Format:         combineFormat,
+		Split:          combineSplit,
 		Title:          combineTitle,
 		Version:        combineVersion,
 		BaseURL:        combineBaseURL,
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/picogrid/go-op/internal/generator"
+)
+
+var coverageCmd = &cobra.Command{
+	Use:   "coverage",
+	Short: "Report routes registered on Gin that bypass go-op",
+	Long: `Report routes registered directly on a Gin engine or router group that bypass go-op
+validation and spec generation.
+
+This command scans your Go source code for go-op operations and for routes registered with
+GET, POST, Handle, Any and the other Gin route methods, and lists the routes that no go-op
+operation documents. A hand-written route counts as documented when an operation declares
+the same method and path.
+
+Examples:
+  # Report undocumented routes of a service
+  go-op coverage -i ./service
+
+  # Ignore the spec and debug endpoints
+  go-op coverage -i ./service --ignore /openapi.json --ignore "/debug/*"
+
+  # Fail in CI when a route bypasses go-op
+  go-op coverage -i ./service --fail`,
+	RunE: runCoverage,
+}
+
+var (
+	coverageInput  string
+	coverageIgnore []string
+	coverageFail   bool
+)
+
+func init() {
+	rootCmd.AddCommand(coverageCmd)
+
+	coverageCmd.Flags().StringVarP(&coverageInput, "input", "i", ".", "input directory to scan for Go files")
+	coverageCmd.Flags().StringSliceVar(&coverageIgnore, "ignore", []string{}, "route path pattern to leave out of the report, such as /debug/* (can be specified multiple times)")
+	coverageCmd.Flags().BoolVar(&coverageFail, "fail", false, "exit with an error when a route is undocumented")
+}
+
+func runCoverage(cmd *cobra.Command, args []string) error {
+	absInputDir, err := filepath.Abs(coverageInput)
+	if err != nil {
+		return fmt.Errorf("failed to resolve input directory: %w", err)
+	}
+	verbosePrint("Resolved input directory: %s", absInputDir)
+
+	gen := generator.New(&generator.Config{InputDir: absInputDir, Verbose: verbose})
+	if err := gen.ScanOperations(); err != nil {
+		return fmt.Errorf("failed to scan operations: %w", err)
+	}
+	report := gen.Coverage(coverageIgnore)
+
+	fmt.Printf("go-op operations: %d\n", report.Operations)
+	fmt.Printf("Gin routes:       %d (%d undocumented)\n", len(report.Routes), len(report.Undocumented))
+
+	if len(report.Undocumented) == 0 {
+		fmt.Println("✅ Every route is documented by a go-op operation")
+		return nil
+	}
+
+	fmt.Println("\nRoutes bypassing go-op validation and spec generation:")
+	for _, route := range report.Undocumented {
+		location := route.SourceFile
+		if rel, err := filepath.Rel(absInputDir, route.SourceFile); err == nil {
+			location = rel
+		}
+		fmt.Printf("  %-7s %-40s %s:%d\n", route.Method, route.Path, location, route.LineNumber)
+	}
+
+	if coverageFail {
+		return fmt.Errorf("%d routes are not documented by go-op operations", len(report.Undocumented))
+	}
+	return nil
+}
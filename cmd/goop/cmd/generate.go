@@ -2,11 +2,14 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/picogrid/go-op/internal/generator"
+	"github.com/picogrid/go-op/plugin"
 )
 
 var generateCmd = &cobra.Command{
@@ -28,6 +31,21 @@ Examples:
   # Generate with custom title and version
   go-op generate -t "My API" -V "2.0.0"
 
+  # Generate the spec published to partners
+  go-op generate -i ./api -o ./partner-openapi.yaml --audience partner
+
+  # Split into paths/*.yaml and components/schemas/*.yaml next to the root file
+  go-op generate -i ./api -o ./spec/openapi.yaml --split
+
+  # Document examples from table-driven tests marked with // goop:example
+  go-op generate -i ./api --examples-from-tests
+
+  # Run the goop-gen-gateway plugin from PATH alongside the spec
+  go-op generate -i ./api -o ./openapi.yaml --plugin gateway --plugin-opt gateway:upstream=http://orders:8080
+
+  # Run a plugin by path and write its files to a separate directory
+  go-op generate -i ./api --plugin ./bin/terraform-routes --plugin-out ./deploy/routes
+
   # Generate with verbose output
   go-op generate -v -i ./api`,
 	RunE: runGenerate,
@@ -41,6 +59,13 @@ var (
 	description string
 	servers     []string
 	format      string
+	split       bool
+	audience    string
+	plugins     []string
+	pluginOpts  []string
+	pluginOut   string
+
+	examplesFromTests bool
 )
 
 func init() {
@@ -50,12 +75,20 @@ func init() {
 	generateCmd.Flags().StringVarP(&inputDir, "input", "i", ".", "input directory to scan for Go files")
 	generateCmd.Flags().StringVarP(&outputFile, "output", "o", "openapi.yaml", "output file path")
 	generateCmd.Flags().StringVarP(&format, "format", "f", "yaml", "output format (yaml or json)")
+	generateCmd.Flags().BoolVar(&split, "split", false, "write path items and schemas to separate files next to the output file")
 
 	// OpenAPI metadata flags
 	generateCmd.Flags().StringVarP(&title, "title", "t", "", "API title (auto-detected if not specified)")
 	generateCmd.Flags().StringVarP(&version, "version", "V", "1.0.0", "API version")
 	generateCmd.Flags().StringVarP(&description, "description", "d", "", "API description")
 	generateCmd.Flags().StringSliceVarP(&servers, "server", "s", []string{}, "server URLs (can be specified multiple times)")
+	generateCmd.Flags().StringVar(&audience, "audience", "", "include only operations documented for this audience")
+	generateCmd.Flags().BoolVar(&examplesFromTests, "examples-from-tests", false, "document examples from table-driven tests marked with // goop:example")
+
+	// Plugin flags
+	generateCmd.Flags().StringSliceVar(&plugins, "plugin", []string{}, "generator plugin to run: a name looked up as goop-gen-<name> on PATH, or a path (can be specified multiple times)")
+	generateCmd.Flags().StringArrayVar(&pluginOpts, "plugin-opt", []string{}, "plugin option as name:key=value (can be specified multiple times)")
+	generateCmd.Flags().StringVar(&pluginOut, "plugin-out", "", "directory for plugin output files (defaults to the output file's directory)")
 }
 
 func runGenerate(cmd *cobra.Command, args []string) error {
@@ -82,11 +115,15 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 		InputDir:    absInputDir,
 		OutputFile:  absOutputFile,
 		Format:      format,
+		Split:       split,
 		Title:       title,
 		Version:     version,
 		Description: description,
 		Servers:     servers,
+		Audience:    audience,
 		Verbose:     verbose,
+
+		ExamplesFromTests: examplesFromTests,
 	}
 
 	// Create and run the generator
@@ -109,6 +146,10 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 
 	fmt.Printf("✅ OpenAPI specification generated successfully: %s\n", absOutputFile)
 
+	if err := runPlugins(cmd, gen, filepath.Dir(absOutputFile)); err != nil {
+		return err
+	}
+
 	if verbose {
 		stats := gen.GetStats()
 		fmt.Printf("📊 Generation statistics:\n")
@@ -119,3 +160,63 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// runPlugins runs the requested generator plugins and writes their files
+func runPlugins(cmd *cobra.Command, gen *generator.Generator, defaultOut string) error {
+	if len(plugins) == 0 {
+		return nil
+	}
+
+	options, err := parsePluginOptions(pluginOpts)
+	if err != nil {
+		return err
+	}
+
+	outDir := defaultOut
+	if pluginOut != "" {
+		if outDir, err = filepath.Abs(pluginOut); err != nil {
+			return fmt.Errorf("failed to resolve plugin output directory: %w", err)
+		}
+	}
+
+	for _, name := range plugins {
+		path, err := plugin.Lookup(name)
+		if err != nil {
+			return err
+		}
+
+		verbosePrint("Running plugin %s (%s)...", name, path)
+		resp, err := plugin.Invoke(cmd.Context(), path, gen.PluginRequest(options[name]), os.Stderr)
+		if err != nil {
+			return err
+		}
+
+		files, err := resp.WriteFiles(outDir)
+		if err != nil {
+			return fmt.Errorf("failed to write files from plugin %s: %w", name, err)
+		}
+		for _, file := range files {
+			verbosePrint("Wrote %s", file)
+		}
+		fmt.Printf("✅ Plugin %s generated %d file(s) in %s\n", name, len(files), outDir)
+	}
+
+	return nil
+}
+
+// parsePluginOptions groups name:key=value options by plugin name
+func parsePluginOptions(opts []string) (map[string]map[string]string, error) {
+	options := make(map[string]map[string]string)
+	for _, opt := range opts {
+		name, option, ok := strings.Cut(opt, ":")
+		key, value, hasValue := strings.Cut(option, "=")
+		if !ok || name == "" || key == "" || !hasValue {
+			return nil, fmt.Errorf("invalid plugin option %q: expected name:key=value", opt)
+		}
+		if options[name] == nil {
+			options[name] = make(map[string]string)
+		}
+		options[name][key] = value
+	}
+	return options, nil
+}
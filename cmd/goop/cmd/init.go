@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/picogrid/go-op/internal/scaffold"
+)
+
+var initCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Create new go-op projects",
+}
+
+var initServiceCmd = &cobra.Command{
+	Use:   "service [dir]",
+	Short: "Scaffold a new go-op service",
+	Long: `Scaffold a new service in the recommended layout, instead of copying an example.
+
+The service gets a main package that registers its operations and serves the spec,
+a schemas package with the validator schemas, an operations package with operation
+definitions and handlers, and a Makefile with build, run, test, and spec targets.
+Existing files are never overwritten.
+
+Examples:
+  # Scaffold a Gin service in ./order-service
+  go-op init service ./order-service --framework gin
+
+  # Scaffold into the current directory with an explicit module path
+  go-op init service --module github.com/acme/orders`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runInitService,
+}
+
+var (
+	initFramework string
+	initModule    string
+	initName      string
+)
+
+func init() {
+	rootCmd.AddCommand(initCmd)
+	initCmd.AddCommand(initServiceCmd)
+
+	initServiceCmd.Flags().StringVar(&initFramework, "framework", "gin", fmt.Sprintf("web framework (%s)", strings.Join(scaffold.Frameworks(), ", ")))
+	initServiceCmd.Flags().StringVarP(&initModule, "module", "m", "", "Go module path (defaults to the service name)")
+	initServiceCmd.Flags().StringVarP(&initName, "name", "n", "", "service name (defaults to the directory name)")
+}
+
+func runInitService(cmd *cobra.Command, args []string) error {
+	dir := "."
+	if len(args) > 0 {
+		dir = args[0]
+	}
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return fmt.Errorf("failed to resolve directory: %w", err)
+	}
+
+	verbosePrint("Scaffolding %s service in %s", initFramework, absDir)
+	files, err := scaffold.Service(scaffold.Options{
+		Dir:       absDir,
+		Name:      initName,
+		Module:    initModule,
+		Framework: initFramework,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to scaffold service: %w", err)
+	}
+
+	for _, file := range files {
+		fmt.Printf("  created %s\n", filepath.Join(dir, file))
+	}
+	fmt.Printf("✅ Service scaffolded in %s\n", absDir)
+	fmt.Println("Next: run 'go mod tidy', then 'make run'")
+	return nil
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/picogrid/go-op/internal/publish"
+)
+
+var publishCmd = &cobra.Command{
+	Use:   "publish <spec-file>",
+	Short: "Publish an OpenAPI specification to S3, GitHub releases, SwaggerHub, or Backstage",
+	Long: `Publish an OpenAPI specification to the places its readers fetch it from, so spec
+distribution is a single CI step.
+
+Targets are given as URLs and published in order:
+  s3://bucket/prefix            upload to S3 (AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY, AWS_REGION;
+                                AWS_ENDPOINT_URL for S3-compatible stores)
+  github://owner/repo[@tag]     attach to a release, tag defaults to v<version> (GITHUB_TOKEN)
+  swaggerhub://owner/api        save the version on SwaggerHub (SWAGGERHUB_API_KEY)
+  backstage://host?entity=ref   refresh a Backstage API entity (BACKSTAGE_TOKEN)
+
+Published files are named with --name, where {name} is the spec file name without its
+extension and {version} is the spec's info.version.
+
+Examples:
+  # Upload openapi-1.2.0.yaml to S3
+  go-op publish openapi.yaml --to s3://acme-specs/orders
+
+  # Attach the spec to the release and tell the team on Slack
+  go-op publish openapi.yaml --to github://acme/orders --slack-webhook "$SLACK_WEBHOOK_URL"
+
+  # Upload a stable name and refresh the Backstage entity reading it
+  go-op publish openapi.yaml --name "{name}" --to s3://acme-specs/orders \
+    --to "backstage://backstage.acme.dev?entity=api:default/orders"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPublish,
+}
+
+var (
+	publishTargets []string
+	publishName    string
+	publishSlack   string
+)
+
+func init() {
+	rootCmd.AddCommand(publishCmd)
+
+	publishCmd.Flags().StringArrayVar(&publishTargets, "to", []string{}, "target to publish to (can be specified multiple times)")
+	publishCmd.Flags().StringVar(&publishName, "name", publish.DefaultNamePattern, "published file name without extension, using {name} and {version}")
+	publishCmd.Flags().StringVar(&publishSlack, "slack-webhook", "", "Slack incoming webhook URL to notify once published")
+	_ = publishCmd.MarkFlagRequired("to")
+}
+
+func runPublish(cmd *cobra.Command, args []string) error {
+	absSpecFile, err := filepath.Abs(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to resolve spec file: %w", err)
+	}
+	spec, err := publish.LoadSpec(absSpecFile)
+	if err != nil {
+		return err
+	}
+
+	// Parse every target before publishing anything, so a typo does not leave a partial release
+	opts := publish.Options{Getenv: os.Getenv}
+	targets := make([]publish.Target, 0, len(publishTargets))
+	for _, raw := range publishTargets {
+		target, err := publish.ParseTarget(raw, opts)
+		if err != nil {
+			return err
+		}
+		targets = append(targets, target)
+	}
+
+	ctx := context.Background()
+	fileName := spec.FileName(publishName)
+	var published []string
+	for i, target := range targets {
+		verbosePrint("Publishing %s to %s", fileName, publishTargets[i])
+		location, err := target.Publish(ctx, spec, fileName)
+		if err != nil {
+			return err
+		}
+		if location == "" {
+			location = publishTargets[i]
+		}
+		published = append(published, location)
+		fmt.Printf("✅ Published to %s: %s\n", publishTargets[i], location)
+	}
+
+	if publishSlack != "" {
+		verbosePrint("Notifying Slack...")
+		if err := publish.Notify(ctx, opts, publishSlack, spec, published); err != nil {
+			return fmt.Errorf("failed to notify Slack: %w", err)
+		}
+	}
+	return nil
+}
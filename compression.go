@@ -0,0 +1,131 @@
+package goop
+
+import (
+	"compress/gzip"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Headers used to negotiate response compression
+const (
+	AcceptEncodingHeader  = "Accept-Encoding"
+	ContentEncodingHeader = "Content-Encoding"
+)
+
+// DefaultCompressionMinSize is the smallest response body compressed when Compression.MinSize is zero
+// Smaller bodies rarely shrink enough to be worth the CPU and the encoding overhead.
+const DefaultCompressionMinSize = 1024
+
+// DefaultCompressibleTypes are the content types compressed when Compression.ContentTypes is empty
+var DefaultCompressibleTypes = []string{
+	"application/json",
+	"application/problem+json",
+	"application/vnd.api+json",
+	"application/xml",
+	"application/yaml",
+	"text/",
+}
+
+// Encoder compresses response bodies with one content coding, such as gzip or br
+type Encoder struct {
+	// Name of the content coding in Accept-Encoding and Content-Encoding
+	Name string
+	// NewWriter returns a writer compressing into w; closing it flushes the remaining output
+	NewWriter func(w io.Writer) io.WriteCloser
+}
+
+// GzipEncoder compresses responses with gzip at the default compression level
+func GzipEncoder() Encoder {
+	return Encoder{
+		Name:      "gzip",
+		NewWriter: func(w io.Writer) io.WriteCloser { return gzip.NewWriter(w) },
+	}
+}
+
+// Compression configures which responses are compressed and with which encoders
+// gzip is the only built-in encoder; Brotli and other codings need an Encoder wrapping a writer
+// from another library.
+type Compression struct {
+	// Encoders in order of preference when the client accepts several equally (defaults to gzip)
+	Encoders []Encoder
+	// ContentTypes that are compressed, matched by prefix (defaults to DefaultCompressibleTypes)
+	ContentTypes []string
+	// MinSize is the smallest body compressed, in bytes (defaults to DefaultCompressionMinSize)
+	MinSize int
+}
+
+// withDefaults returns c with unset fields replaced by their defaults
+func (c Compression) withDefaults() Compression {
+	if len(c.Encoders) == 0 {
+		c.Encoders = []Encoder{GzipEncoder()}
+	}
+	if len(c.ContentTypes) == 0 {
+		c.ContentTypes = DefaultCompressibleTypes
+	}
+	if c.MinSize <= 0 {
+		c.MinSize = DefaultCompressionMinSize
+	}
+	return c
+}
+
+// Compressible reports whether a body of size bytes with contentType should be compressed
+func (c Compression) Compressible(contentType string, size int) bool {
+	c = c.withDefaults()
+	if size < c.MinSize {
+		return false
+	}
+	mediaType := strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0]))
+	if mediaType == "" {
+		return false
+	}
+	for _, allowed := range c.ContentTypes {
+		if strings.HasPrefix(mediaType, strings.ToLower(allowed)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Negotiate returns the encoder to use for a request with the given Accept-Encoding header
+// The coding with the highest quality wins, ties going to the earlier encoder, and codings with
+// quality zero are refused. The boolean is false when the client accepts none of the encoders.
+func (c Compression) Negotiate(acceptEncoding string) (Encoder, bool) {
+	c = c.withDefaults()
+
+	qualities := make(map[string]float64)
+	wildcard := -1.0
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		quality := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		if name == "*" {
+			wildcard = quality
+		} else {
+			qualities[name] = quality
+		}
+	}
+
+	var best Encoder
+	bestQuality := 0.0
+	for _, encoder := range c.Encoders {
+		quality, ok := qualities[encoder.Name]
+		if !ok {
+			quality = wildcard
+		}
+		if quality > bestQuality {
+			best, bestQuality = encoder, quality
+		}
+	}
+	return best, bestQuality > 0
+}
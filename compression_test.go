@@ -0,0 +1,79 @@
+package goop
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+// TestCompressionNegotiate tests choosing an encoder from Accept-Encoding
+func TestCompressionNegotiate(t *testing.T) {
+	brotli := Encoder{Name: "br", NewWriter: func(w io.Writer) io.WriteCloser { return nopWriteCloser{w} }}
+	compression := Compression{Encoders: []Encoder{brotli, GzipEncoder()}}
+
+	tests := []struct {
+		accept string
+		want   string
+	}{
+		{"gzip, br", "br"},
+		{"gzip", "gzip"},
+		{"br;q=0.5, gzip;q=0.8", "gzip"},
+		{"GZIP", "gzip"},
+		{"*", "br"},
+		{"br;q=0, *", "gzip"},
+		{"identity", ""},
+		{"gzip;q=0", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		encoder, ok := compression.Negotiate(tt.accept)
+		if ok != (tt.want != "") || encoder.Name != tt.want {
+			t.Errorf("Negotiate(%q) = %q, %v, want %q", tt.accept, encoder.Name, ok, tt.want)
+		}
+	}
+
+	if encoder, ok := (Compression{}).Negotiate("gzip, deflate"); !ok || encoder.Name != "gzip" {
+		t.Errorf("Expected gzip by default, got %q", encoder.Name)
+	}
+}
+
+// TestCompressible tests the content type allowlist and minimum size
+func TestCompressible(t *testing.T) {
+	var defaults Compression
+	if !defaults.Compressible("application/json; charset=utf-8", 2048) {
+		t.Error("Expected large JSON to be compressible")
+	}
+	if !defaults.Compressible("text/html", DefaultCompressionMinSize) {
+		t.Error("Expected text at the minimum size to be compressible")
+	}
+	if defaults.Compressible("application/json", DefaultCompressionMinSize-1) {
+		t.Error("Expected small bodies not to be compressible")
+	}
+	if defaults.Compressible("image/png", 2048) || defaults.Compressible("", 2048) {
+		t.Error("Expected other content types not to be compressible")
+	}
+
+	custom := Compression{ContentTypes: []string{"application/x-ndjson"}, MinSize: 10}
+	if !custom.Compressible("application/x-ndjson", 10) || custom.Compressible("application/json", 100) {
+		t.Error("Expected only the configured content types to be compressible")
+	}
+}
+
+// TestGzipEncoder tests that the gzip encoder writes a gzip stream
+func TestGzipEncoder(t *testing.T) {
+	var buf bytes.Buffer
+	w := GzipEncoder().NewWriter(&buf)
+	if _, err := w.Write([]byte("hello")); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !bytes.HasPrefix(buf.Bytes(), []byte{0x1f, 0x8b}) {
+		t.Errorf("Expected gzip magic bytes, got %x", buf.Bytes())
+	}
+}
+
+type nopWriteCloser struct{ io.Writer }
+
+func (nopWriteCloser) Close() error { return nil }
@@ -0,0 +1,82 @@
+package goop
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// DecodeJSON decodes a JSON document into the generic values schemas validate: maps, slices,
+// strings, booleans, nil, and float64 numbers. Numbers whose digits float64 cannot hold exactly,
+// such as integers above 2^53 or long decimals, are kept as json.Number so precise validators
+// like Integer64, BigInt, and Decimal see the original value.
+func DecodeJSON(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, errors.New("invalid character after top-level value")
+	}
+	return exactNumbers(value), nil
+}
+
+// exactNumbers replaces json.Number values with float64 wherever the conversion is exact
+func exactNumbers(value interface{}) interface{} {
+	switch v := value.(type) {
+	case json.Number:
+		if f, ok := exactFloat(v); ok {
+			return f
+		}
+		return v
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = exactNumbers(item)
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = exactNumbers(item)
+		}
+		return v
+	default:
+		return value
+	}
+}
+
+// exactFloat converts n to float64, reporting whether the float has the same decimal value
+// Numbers in exponent notation are converted whenever they are in range.
+func exactFloat(n json.Number) (float64, bool) {
+	s := string(n)
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, false
+	}
+	if strings.ContainsAny(s, "eE") {
+		return f, true
+	}
+
+	// Up to 15 significant digits always survive the round trip through float64
+	digits := strings.Trim(strings.NewReplacer("-", "", ".", "").Replace(s), "0")
+	if digits == "" {
+		return f, true
+	}
+	if f != 0 && len(digits) <= 15 {
+		return f, true
+	}
+	return f, trimFraction(strconv.FormatFloat(f, 'f', -1, 64)) == trimFraction(s)
+}
+
+// trimFraction removes trailing zeros after the decimal point, and the point itself when nothing follows it
+func trimFraction(s string) string {
+	if !strings.Contains(s, ".") {
+		return s
+	}
+	return strings.TrimSuffix(strings.TrimRight(s, "0"), ".")
+}
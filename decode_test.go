@@ -0,0 +1,51 @@
+package goop
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// TestDecodeJSON tests that numbers keep their exact value when float64 would round them
+func TestDecodeJSON(t *testing.T) {
+	value, err := DecodeJSON([]byte(`{
+		"count": 42,
+		"price": 19.99,
+		"id": 9007199254740993,
+		"large": 12345678901234567890,
+		"exact": 10000000000000000000,
+		"precise": 0.1000000000000000055511151231257827,
+		"scientific": 1.5e3,
+		"items": [1, 18446744073709551615]
+	}`))
+	if err != nil {
+		t.Fatalf("DecodeJSON failed: %v", err)
+	}
+	m := value.(map[string]interface{})
+
+	floats := map[string]float64{"count": 42, "price": 19.99, "exact": 1e19, "scientific": 1500}
+	for key, want := range floats {
+		if got, ok := m[key].(float64); !ok || got != want {
+			t.Errorf("Expected %s to decode as float64 %v, got %v (%T)", key, want, m[key], m[key])
+		}
+	}
+
+	numbers := map[string]json.Number{
+		"id":      "9007199254740993",
+		"large":   "12345678901234567890",
+		"precise": "0.1000000000000000055511151231257827",
+	}
+	for key, want := range numbers {
+		if got, ok := m[key].(json.Number); !ok || got != want {
+			t.Errorf("Expected %s to stay json.Number %s, got %v (%T)", key, want, m[key], m[key])
+		}
+	}
+
+	items := m["items"].([]interface{})
+	if items[0] != 1.0 || items[1] != json.Number("18446744073709551615") {
+		t.Errorf("Expected array numbers to be converted the same way, got %v", items)
+	}
+
+	if _, err := DecodeJSON([]byte(`{"a": 1} {"b": 2}`)); err == nil {
+		t.Error("Expected trailing data to be rejected")
+	}
+}
@@ -12,6 +12,7 @@ type ValidationError struct {
 	Field     string            `json:"field"`
 	Value     interface{}       `json:"value"`
 	Details   []ValidationError `json:"details,omitempty"`
+	Severity  string            `json:"severity,omitempty"`
 }
 
 func NewValidationError(field string, value interface{}, message string) *ValidationError {
@@ -37,6 +38,16 @@ func NewNestedValidationError(field string, value interface{}, message string, d
 	}
 }
 
+// WithField returns err as a ValidationError reported under field, keeping any nested details
+func WithField(field string, err error) ValidationError {
+	if validationErr, ok := err.(*ValidationError); ok {
+		result := *validationErr
+		result.Field = field
+		return result
+	}
+	return *NewValidationError(field, nil, err.Error())
+}
+
 // sanitizeValueForError creates a clean representation of values for error messages
 func sanitizeValueForError(value interface{}) interface{} {
 	return sanitizeValueForErrorWithCycleDetection(value, make(map[uintptr]bool))
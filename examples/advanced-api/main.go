@@ -480,12 +480,34 @@ func main() {
 			productListResponseSchema,
 		))
 
+	// Product reviews operation - not implemented yet, so it serves the declared examples
+	reviewsResponseSchema := validators.Object(map[string]interface{}{
+		"reviews": validators.Array(validators.Object(map[string]interface{}{
+			"author": validators.String().Min(1).Example("Jane Doe").Required(),
+			"rating": validators.Number().Min(1).Max(5).Example(5).Required(),
+			"text":   validators.String().Max(2000).Example("Works exactly as described.").Optional(),
+		}).Required()).Required(),
+	}).Required()
+	listReviewsOp := operations.NewSimple().
+		GET("/products/{id}/reviews").
+		Summary("List product reviews").
+		Description("Lists the customer reviews of a product").
+		Tags("products").
+		WithParams(productParamsSchema).
+		WithResponse(reviewsResponseSchema).
+		Handler(nil)
+
 	// Register operations
 	router.Register(createProductOp)
 	router.Register(getProductOp)
 	router.Register(updateProductOp)
 	router.Register(searchProductsOp)
 
+	// Operations without handlers respond with their examples during development
+	if err := operations.SeedFromExamples(router).Register(listReviewsOp); err != nil {
+		panic(fmt.Sprintf("Failed to register example operations: %v", err))
+	}
+
 	// Health check endpoint
 	engine.GET("/health", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{
@@ -500,8 +522,8 @@ func main() {
 	fmt.Println("📖 OpenAPI spec: http://localhost:8080/openapi.json")
 	fmt.Println("🏥 Health check: http://localhost:8080/health")
 
-	if err := engine.Run(":8080"); err != nil {
-		panic(fmt.Sprintf("Failed to start server: %v", err))
+	if err := operations.Serve(context.Background(), engine, operations.ServeOptions{Addr: ":8080"}); err != nil {
+		panic(fmt.Sprintf("Server failed: %v", err))
 	}
 }
 
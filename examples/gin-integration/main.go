@@ -624,5 +624,7 @@ func main() {
 	fmt.Println("- Refactoring safety - renaming struct fields will show errors")
 	fmt.Println("- Zero runtime reflection for maximum performance")
 
-	engine.Run(":8003")
+	if err := operations.Serve(context.Background(), engine, operations.ServeOptions{Addr: ":8003"}); err != nil {
+		panic(fmt.Sprintf("Server failed: %v", err))
+	}
 }
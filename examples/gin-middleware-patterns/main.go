@@ -10,6 +10,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 
+	goop "github.com/picogrid/go-op"
 	"github.com/picogrid/go-op/operations"
 	ginadapter "github.com/picogrid/go-op/operations/adapters/gin"
 	"github.com/picogrid/go-op/validators"
@@ -134,44 +135,18 @@ func RequireJWTAuth() gin.HandlerFunc {
 	}
 }
 
-// Company context middleware - extracts company ID from header
-func RequireCompanyID() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		companyID := c.GetHeader("X-COMPANY-ID")
-		if companyID == "" {
-			// Also check query parameter as fallback
-			companyID = c.Query("company_id")
-		}
-
-		if companyID == "" {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": "Company ID is required",
-				"code":  "COMPANY_ID_MISSING",
-			})
-			c.Abort()
-			return
-		}
-
-		// Validate company ID format (UUID)
-		if _, err := uuid.Parse(companyID); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": "Invalid company ID format",
-				"code":  "COMPANY_ID_INVALID",
-			})
-			c.Abort()
-			return
-		}
-
-		// Simulate company validation and storage
-		company := Company{
-			ID:   companyID,
-			Name: fmt.Sprintf("Company %s", companyID[:8]),
-		}
-
-		c.Set("company", company)
-		c.Set("company_id", companyID)
-		c.Next()
+// resolveCompany loads the company a request acts for from its X-COMPANY-ID header
+// Malformed IDs are returned as validation errors, which the router rejects with 400 Bad Request.
+func resolveCompany(ctx context.Context, id string) (interface{}, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, goop.NewValidationError("X-COMPANY-ID", id, "company ID must be a UUID")
 	}
+
+	// Simulate company lookup
+	return Company{
+		ID:   id,
+		Name: fmt.Sprintf("Company %s", id[:8]),
+	}, nil
 }
 
 // Role-based access control middleware
@@ -294,10 +269,12 @@ func RateLimitMiddleware() gin.HandlerFunc {
 
 // Business logic handlers (pure functions - no HTTP concerns)
 func getSettingsHandler(ctx context.Context, params struct{}, query struct{}, body struct{}) (SettingsResponse, error) {
+	company, _ := goop.TenantFromContext[Company](ctx)
+
 	// Simulate database lookup
 	return SettingsResponse{
 		ID:        "set_" + uuid.New().String()[:8],
-		CompanyID: "comp_123",
+		CompanyID: company.ID,
 		UserID:    "usr_123",
 		Settings: Settings{
 			NotificationsEnabled: true,
@@ -313,10 +290,12 @@ func getSettingsHandler(ctx context.Context, params struct{}, query struct{}, bo
 }
 
 func createSettingsHandler(ctx context.Context, params struct{}, query struct{}, body CreateSettingsRequest) (SettingsResponse, error) {
+	company, _ := goop.TenantFromContext[Company](ctx)
+
 	// Simulate settings creation
 	return SettingsResponse{
 		ID:        "set_" + uuid.New().String()[:8],
-		CompanyID: "comp_123",
+		CompanyID: company.ID,
 		UserID:    "usr_123",
 		Settings:  Settings(body),
 		CreatedAt: time.Now(),
@@ -381,9 +360,6 @@ func main() {
 	// Global auth middleware - all routes require authentication
 	engine.Use(RequireJWTAuth())
 
-	// Global org context - all routes require company ID
-	engine.Use(RequireCompanyID())
-
 	// Create OpenAPI generator
 	openAPIGen := operations.NewOpenAPIGenerator("Middleware Patterns API", "1.0.0")
 	openAPIGen.SetDescription("Comprehensive example of middleware patterns with go-op and Gin")
@@ -391,6 +367,9 @@ func main() {
 	// Create go-op router
 	router := ginadapter.NewGinRouter(engine, openAPIGen)
 
+	// Org context - operations act for the company in X-COMPANY-ID, documented on each of them
+	router.UseTenantResolver(goop.TenantHeader("X-COMPANY-ID", resolveCompany))
+
 	// Define schemas for validation and OpenAPI generation
 	createSettingsSchema := validators.Object(map[string]interface{}{
 		"notifications_enabled": validators.Bool().Required(),
@@ -454,6 +433,7 @@ func main() {
 		Summary("Get current user information").
 		Description("Returns information about the currently authenticated user").
 		Tags("User").
+		WithoutTenant().
 		WithResponse(userResponseSchema).
 		Handler(ginGetUserInfo) // No additional middleware - global auth is sufficient
 
@@ -505,10 +485,10 @@ func main() {
 			"patterns": gin.H{
 				"global_middleware": []string{
 					"Recovery", "Logging", "CORS", "Security Headers",
-					"Rate Limiting", "JWT Auth", "Org Context",
+					"Rate Limiting", "JWT Auth",
 				},
 				"per_operation_middleware": []string{
-					"Role-based Access Control", "Scope-based Access Control",
+					"Org Context", "Role-based Access Control", "Scope-based Access Control",
 				},
 			},
 			"endpoints": gin.H{
@@ -549,5 +529,7 @@ func main() {
 	fmt.Println("  curl -H 'Authorization: Bearer admin_token' -H 'X-COMPANY-ID: 550e8400-e29b-41d4-a716-446655440000' http://localhost:8080/api/v1/settings")
 	fmt.Println("  curl -H 'Authorization: Bearer super_admin_token' -H 'X-COMPANY-ID: 550e8400-e29b-41d4-a716-446655440000' http://localhost:8080/v1/admin/status")
 
-	engine.Run(":8080")
+	if err := operations.Serve(context.Background(), engine, operations.ServeOptions{Addr: ":8080"}); err != nil {
+		panic(fmt.Sprintf("Server failed: %v", err))
+	}
 }
@@ -1046,6 +1046,19 @@ func main() {
 		}).Required()).
 		Handler(ginadapter.CreateValidatedHandler(sendBulkNotificationHandler, nil, nil, sendBulkNotificationBodySchema, nil))
 
+	// Each notification in a batch is validated and sent on its own, with a result per item
+	sendBatchOp := operations.NewSimple().
+		POST("/notifications/batch").
+		Summary("Send notification batch").
+		Description("Sends distinct notifications in one request, reporting the outcome of each").
+		Tags("notifications", "bulk", "messaging").
+		Handler(operations.Batch(sendNotificationBodySchema, func(ctx context.Context, item SendNotificationRequest) (Notification, error) {
+			return sendNotificationHandler(ctx, struct{}{}, struct{}{}, item)
+		}).
+			Concurrency(8).
+			ItemStatus(http.StatusCreated).
+			Resource(notificationResponseSchema))
+
 	sendTemplatedOp := operations.NewSimple().
 		POST("/notifications/templated").
 		Summary("Send templated notification").
@@ -1227,6 +1240,7 @@ func main() {
 	// Register all operations
 	router.Register(sendNotificationOp)
 	router.Register(sendBulkOp)
+	router.Register(sendBatchOp)
 	router.Register(sendTemplatedOp)
 	router.Register(getNotificationOp)
 	router.Register(markReadOp)
@@ -1250,5 +1264,7 @@ func main() {
 
 	fmt.Println("🚀 Notification Service starting on :8003")
 	fmt.Println("📚 Generate OpenAPI spec: go-op generate -i ./examples/notification-service -o ./notification-service.yaml")
-	engine.Run(":8003")
+	if err := operations.Serve(context.Background(), engine, operations.ServeOptions{Addr: ":8003"}); err != nil {
+		panic(fmt.Sprintf("Server failed: %v", err))
+	}
 }
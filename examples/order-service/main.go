@@ -19,8 +19,7 @@ type Order struct {
 	UserID          string      `json:"user_id"`
 	Status          string      `json:"status"`
 	Items           []OrderItem `json:"items"`
-	TotalAmount     float64     `json:"total_amount"`
-	Currency        string      `json:"currency"`
+	Total           Money       `json:"total"`
 	ShippingAddress Address     `json:"shipping_address"`
 	BillingAddress  Address     `json:"billing_address"`
 	CreatedAt       time.Time   `json:"created_at"`
@@ -29,11 +28,17 @@ type Order struct {
 
 // OrderItem represents an item in an order
 type OrderItem struct {
-	ProductID string  `json:"product_id"`
-	Name      string  `json:"name"`
-	Quantity  int     `json:"quantity"`
-	Price     float64 `json:"price"`
-	Subtotal  float64 `json:"subtotal"`
+	ProductID string `json:"product_id"`
+	Name      string `json:"name"`
+	Quantity  int    `json:"quantity"`
+	Price     Money  `json:"price"`
+	Subtotal  Money  `json:"subtotal"`
+}
+
+// Money is an amount in the currency's minor units, such as cents, avoiding floating point rounding
+type Money struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
 }
 
 // Address represents a shipping or billing address
@@ -150,12 +155,12 @@ type TimeSeriesDataPoint struct {
 func createOrderHandler(ctx context.Context, params struct{}, query struct{}, body CreateOrderRequest) (Order, error) {
 	// Simulate order creation with item processing
 	items := make([]OrderItem, len(body.Items))
-	total := 0.0
+	total := Money{Currency: body.Currency}
 
 	for i, item := range body.Items {
-		price := 29.99 // Simulate product lookup
-		subtotal := float64(item.Quantity) * price
-		total += subtotal
+		price := Money{Amount: 2999, Currency: body.Currency} // Simulate product lookup
+		subtotal := Money{Amount: int64(item.Quantity) * price.Amount, Currency: body.Currency}
+		total.Amount += subtotal.Amount
 
 		items[i] = OrderItem{
 			ProductID: item.ProductID,
@@ -171,8 +176,7 @@ func createOrderHandler(ctx context.Context, params struct{}, query struct{}, bo
 		UserID:          body.UserID,
 		Status:          "pending",
 		Items:           items,
-		TotalAmount:     total,
-		Currency:        body.Currency,
+		Total:           total,
 		ShippingAddress: body.ShippingAddress,
 		BillingAddress:  body.BillingAddress,
 		CreatedAt:       time.Now(),
@@ -191,12 +195,11 @@ func getOrderHandler(ctx context.Context, params GetOrderParams, query struct{},
 				ProductID: "prod_123",
 				Name:      "Wireless Headphones",
 				Quantity:  1,
-				Price:     99.99,
-				Subtotal:  99.99,
+				Price:     Money{Amount: 9999, Currency: "USD"},
+				Subtotal:  Money{Amount: 9999, Currency: "USD"},
 			},
 		},
-		TotalAmount: 99.99,
-		Currency:    "USD",
+		Total: Money{Amount: 9999, Currency: "USD"},
 		ShippingAddress: Address{
 			Street:     "123 Main St",
 			City:       "New York",
@@ -237,12 +240,11 @@ func listOrdersHandler(ctx context.Context, params struct{}, query ListOrdersQue
 			UserID: query.UserID,
 			Status: "completed",
 			Items: []OrderItem{
-				{ProductID: "prod_123", Name: "Laptop", Quantity: 1, Price: 999.99, Subtotal: 999.99},
+				{ProductID: "prod_123", Name: "Laptop", Quantity: 1, Price: Money{Amount: 99999, Currency: "USD"}, Subtotal: Money{Amount: 99999, Currency: "USD"}},
 			},
-			TotalAmount: 999.99,
-			Currency:    "USD",
-			CreatedAt:   time.Now().Add(-72 * time.Hour),
-			UpdatedAt:   time.Now().Add(-48 * time.Hour),
+			Total:     Money{Amount: 99999, Currency: "USD"},
+			CreatedAt: time.Now().Add(-72 * time.Hour),
+			UpdatedAt: time.Now().Add(-48 * time.Hour),
 		},
 	}
 
@@ -775,7 +777,7 @@ func main() {
 	createOrderBodySchema := validators.Object(map[string]interface{}{
 		"user_id":          validators.String().Min(1).Pattern("^usr_[a-zA-Z0-9]+$").Required(),
 		"items":            validators.Array(createOrderItemSchema).Required(),
-		"currency":         validators.String().Min(3).Max(3).Pattern("^[A-Z]{3}$").Optional().Default("USD"),
+		"currency":         validators.Currency().Optional().Default("USD"),
 		"shipping_address": addressSchema,
 		"billing_address":  addressSchema,
 		"payment_method":   paymentMethodSchema,
@@ -855,15 +857,15 @@ func main() {
 		"date_from": validators.String().Required(),
 		"date_to":   validators.String().Required(),
 		"group_by":  validators.String().Optional().Default("day"),
-		"currency":  validators.String().Pattern("^[A-Z]{3}$").Optional().Default("USD"),
+		"currency":  validators.Currency().Optional().Default("USD"),
 	}).Required()
 
 	orderItemSchema := validators.Object(map[string]interface{}{
 		"product_id": validators.String().Min(1).Required(),
 		"name":       validators.String().Min(1).Required(),
-		"quantity":   validators.Number().Integer().MultipleOf(1.0).Min(1).Required(),   // OpenAPI 3.1: Whole units
-		"price":      validators.Number().MultipleOf(0.01).ExclusiveMin(0.0).Required(), // OpenAPI 3.1: Currency precision, positive
-		"subtotal":   validators.Number().MultipleOf(0.01).Min(0.0).Required(),          // OpenAPI 3.1: Currency precision
+		"quantity":   validators.Number().Integer().MultipleOf(1.0).Min(1).Required(), // OpenAPI 3.1: Whole units
+		"price":      validators.MoneyMinorUnits().Required(),
+		"subtotal":   validators.MoneyMinorUnits().Required(),
 	}).Required()
 
 	orderResponseSchema := validators.Object(map[string]interface{}{
@@ -871,8 +873,7 @@ func main() {
 		"user_id":          validators.String().Min(1).Required(),
 		"status":           validators.String().Required(),
 		"items":            validators.Array(orderItemSchema).Required(),
-		"total_amount":     validators.Number().MultipleOf(0.01).Min(0.0).Required(), // OpenAPI 3.1: Currency precision
-		"currency":         validators.String().Min(3).Max(3).Required(),
+		"total":            validators.MoneyMinorUnits().Required(),
 		"shipping_address": addressSchema,
 		"billing_address":  addressSchema,
 		"created_at":       validators.String().Required(),
@@ -1185,5 +1186,7 @@ func main() {
 
 	fmt.Println("🚀 Order Service starting on :8002")
 	fmt.Println("📚 Generate OpenAPI spec: go-op generate -i ./examples/order-service -o ./order-service.yaml")
-	engine.Run(":8002")
+	if err := operations.Serve(context.Background(), engine, operations.ServeOptions{Addr: ":8002"}); err != nil {
+		panic(fmt.Sprintf("Server failed: %v", err))
+	}
 }
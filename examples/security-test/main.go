@@ -241,5 +241,7 @@ func main() {
 	fmt.Println("🔄 Multi-auth endpoint: GET /multi/users/{id} (accepts API Key OR Bearer)")
 	fmt.Println("🌍 Public endpoint: GET /public/users/{id} (no authentication)")
 
-	engine.Run(":8002")
+	if err := operations.Serve(context.Background(), engine, operations.ServeOptions{Addr: ":8002"}); err != nil {
+		panic(fmt.Sprintf("Server failed: %v", err))
+	}
 }
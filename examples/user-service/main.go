@@ -973,5 +973,7 @@ func main() {
 
 	fmt.Println("🚀 User Service starting on :8001")
 	fmt.Println("📚 Generate OpenAPI spec: go-op generate -i ./examples/user-service -o ./user-service.yaml")
-	engine.Run(":8001")
+	if err := operations.Serve(context.Background(), engine, operations.ServeOptions{Addr: ":8001"}); err != nil {
+		panic(fmt.Sprintf("Server failed: %v", err))
+	}
 }
@@ -0,0 +1,41 @@
+package goop
+
+import (
+	"io"
+	"mime"
+	"time"
+)
+
+// Headers of file downloads and range requests
+const (
+	ContentDispositionHeader = "Content-Disposition"
+	AcceptRangesHeader       = "Accept-Ranges"
+	ContentRangeHeader       = "Content-Range"
+)
+
+// DefaultFileContentType is the media type of files that do not declare one
+const DefaultFileContentType = "application/octet-stream"
+
+// FileResult is returned by handlers of download operations to stream a file instead of a JSON body
+// Readers implementing io.ReadSeeker, or io.ReaderAt with a Size, are served with HTTP range
+// support; other readers are streamed whole. Readers implementing io.Closer are closed once sent.
+type FileResult struct {
+	Reader io.Reader
+	// ContentType of the file (defaults to DefaultFileContentType)
+	ContentType string
+	// Filename offered to clients saving the file; empty serves the file inline
+	Filename string
+	// Size of the file in bytes, sent as Content-Length when the reader cannot seek (zero means unknown)
+	Size int64
+	// ModTime of the file, used for Last-Modified and conditional requests (zero means unknown)
+	ModTime time.Time
+}
+
+// ContentDisposition returns the Content-Disposition header value of the file
+// Files with a name are served as attachments, with non-ASCII names encoded as RFC 2231 requires.
+func (f FileResult) ContentDisposition() string {
+	if f.Filename == "" {
+		return "inline"
+	}
+	return mime.FormatMediaType("attachment", map[string]string{"filename": f.Filename})
+}
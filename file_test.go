@@ -0,0 +1,21 @@
+package goop
+
+import "testing"
+
+// TestFileResultContentDisposition tests Content-Disposition values of downloaded files
+func TestFileResultContentDisposition(t *testing.T) {
+	tests := []struct {
+		filename string
+		want     string
+	}{
+		{"", "inline"},
+		{"report.csv", "attachment; filename=report.csv"},
+		{"Q1 report.pdf", `attachment; filename="Q1 report.pdf"`},
+		{"résumé.pdf", "attachment; filename*=utf-8''r%C3%A9sum%C3%A9.pdf"},
+	}
+	for _, tt := range tests {
+		if got := (FileResult{Filename: tt.filename}).ContentDisposition(); got != tt.want {
+			t.Errorf("ContentDisposition() for %q = %q, want %q", tt.filename, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,603 @@
+package goop
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"regexp/syntax"
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxGenerateAttempts bounds how many candidates Generate tries before giving up
+const maxGenerateAttempts = 100
+
+// maxPatternRepeat bounds how many extra repetitions unbounded regex quantifiers produce
+const maxPatternRepeat = 5
+
+// GenerateOption configures Generate
+type GenerateOption func(*generateConfig)
+
+type generateConfig struct {
+	rand *rand.Rand
+}
+
+// WithSeed makes Generate deterministic: the same seed and schema produce the same value
+func WithSeed(seed int64) GenerateOption {
+	return func(c *generateConfig) {
+		c.rand = rand.New(rand.NewSource(seed))
+	}
+}
+
+// WithRand draws random values from r, so successive calls continue one sequence
+func WithRand(r *rand.Rand) GenerateOption {
+	return func(c *generateConfig) {
+		c.rand = r
+	}
+}
+
+// Generate produces a random value that satisfies schema, for mock servers, property tests,
+// and seeding demo data. Values use the types encoding/json decodes into: string, float64,
+// bool, []interface{}, and map[string]interface{}.
+//
+// Generation follows the schema's OpenAPI definition, honoring types, enums, const values,
+// patterns, formats, lengths, numeric bounds, and item and property counts. Optional properties
+// are included at random. Each candidate is checked with schema.Validate, so constraints that
+// are not visible in the definition, such as custom validators, are retried until they pass;
+// an error is returned when no valid value is found.
+func Generate(schema Schema, opts ...GenerateOption) (interface{}, error) {
+	generator, ok := schema.(OpenAPIGenerator)
+	if !ok {
+		return nil, fmt.Errorf("schema %T does not expose its constraints", schema)
+	}
+
+	config := &generateConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+	if config.rand == nil {
+		config.rand = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+
+	definition := generator.ToOpenAPISchema()
+	g := &valueGenerator{rand: config.rand}
+	var lastErr error
+	for attempt := 0; attempt < maxGenerateAttempts; attempt++ {
+		value, err := g.value(definition)
+		if err != nil {
+			return nil, err
+		}
+		if lastErr = schema.Validate(value); lastErr == nil {
+			return value, nil
+		}
+	}
+	return nil, fmt.Errorf("no valid value generated after %d attempts: %w", maxGenerateAttempts, lastErr)
+}
+
+// valueGenerator builds random values from OpenAPI schema definitions
+type valueGenerator struct {
+	rand *rand.Rand
+}
+
+// value generates a value for schema
+func (g *valueGenerator) value(schema *OpenAPISchema) (interface{}, error) {
+	if schema.Ref != "" {
+		return nil, fmt.Errorf("cannot generate values for reference %q", schema.Ref)
+	}
+	if schema.Const != nil {
+		return normalizeGenerated(schema.Const), nil
+	}
+	if len(schema.Enum) > 0 {
+		return normalizeGenerated(schema.Enum[g.rand.Intn(len(schema.Enum))]), nil
+	}
+	if len(schema.OneOf) > 0 {
+		return g.value(schema.OneOf[g.rand.Intn(len(schema.OneOf))])
+	}
+	if len(schema.AnyOf) > 0 {
+		return g.value(schema.AnyOf[g.rand.Intn(len(schema.AnyOf))])
+	}
+	if len(schema.AllOf) > 0 && !conditionalOnly(schema.AllOf) {
+		return g.value(mergeAllOf(schema))
+	}
+
+	switch schema.Type {
+	case "string":
+		return g.string(schema)
+	case "integer":
+		return g.number(schema, true), nil
+	case "number":
+		return g.number(schema, false), nil
+	case "boolean":
+		return g.rand.Intn(2) == 1, nil
+	case "array":
+		return g.array(schema)
+	case "object":
+		return g.object(schema)
+	case "null":
+		return nil, nil
+	}
+
+	if len(schema.Properties) > 0 {
+		return g.object(schema)
+	}
+	if schema.Items != nil {
+		return g.array(schema)
+	}
+	// An empty schema accepts anything; a short string is the simplest value
+	return g.letters(1, 8), nil
+}
+
+// string generates a string honoring format, pattern, and length limits
+func (g *valueGenerator) string(schema *OpenAPISchema) (interface{}, error) {
+	minLength, maxLength := 1, 0
+	if schema.MinLength != nil && *schema.MinLength > minLength {
+		minLength = *schema.MinLength
+	}
+	if schema.MaxLength != nil {
+		maxLength = *schema.MaxLength
+	}
+	// Generated text is ASCII, so byte limits bound the length directly
+	if schema.MinBytes != nil && *schema.MinBytes > minLength {
+		minLength = *schema.MinBytes
+	}
+	if schema.MaxBytes != nil && (maxLength == 0 || *schema.MaxBytes < maxLength) {
+		maxLength = *schema.MaxBytes
+	}
+	if maxLength == 0 || maxLength < minLength {
+		maxLength = minLength + 10
+	}
+
+	if value, ok := g.format(schema.Format); ok {
+		return value, nil
+	}
+	if value, ok := g.content(schema, minLength, maxLength); ok {
+		return value, nil
+	}
+
+	if schema.Pattern != "" {
+		re, err := syntax.Parse(schema.Pattern, syntax.Perl)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", schema.Pattern, err)
+		}
+		re = re.Simplify()
+		// Length limits are not expressed in the regex, so retry until one fits
+		var value string
+		for attempt := 0; attempt < maxGenerateAttempts; attempt++ {
+			var sb strings.Builder
+			g.pattern(&sb, re)
+			value = sb.String()
+			if len(value) >= minLength && len(value) <= maxLength {
+				break
+			}
+		}
+		return value, nil
+	}
+
+	return g.letters(minLength, maxLength), nil
+}
+
+// format generates a value for well-known string formats
+func (g *valueGenerator) format(format string) (string, bool) {
+	switch format {
+	case "email":
+		return g.letters(3, 10) + "@example.com", true
+	case "uri", "url":
+		return "https://example.com/" + g.letters(3, 10), true
+	case "uuid":
+		b := make([]byte, 16)
+		g.rand.Read(b)
+		b[6] = (b[6] & 0x0f) | 0x40
+		b[8] = (b[8] & 0x3f) | 0x80
+		return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), true
+	case "date-time":
+		return g.time().Format(time.RFC3339), true
+	case "date":
+		return g.time().Format(time.DateOnly), true
+	case "ipv4":
+		return fmt.Sprintf("%d.%d.%d.%d", g.rand.Intn(223)+1, g.rand.Intn(256), g.rand.Intn(256), g.rand.Intn(254)+1), true
+	case "ipv6":
+		return fmt.Sprintf("2001:db8::%x:%x", g.rand.Intn(0x10000), g.rand.Intn(0x10000)), true
+	case "cidr":
+		return fmt.Sprintf("10.%d.0.0/16", g.rand.Intn(256)), true
+	case "hostname":
+		return g.letters(3, 10) + ".example.com", true
+	case "port":
+		return fmt.Sprintf("%d", g.rand.Intn(65535-1024)+1024), true
+	case "password":
+		// One character of each class passes typical strength policies
+		return "Aa1!" + g.letters(8, 12), true
+	case "mac":
+		b := make([]byte, 6)
+		g.rand.Read(b)
+		b[0] = (b[0] | 0x02) & 0xfe // locally administered unicast
+		return fmt.Sprintf("%02x:%02x:%02x:%02x:%02x:%02x", b[0], b[1], b[2], b[3], b[4], b[5]), true
+	}
+	return "", false
+}
+
+// content generates encoded content for contentEncoding and contentMediaType keywords
+func (g *valueGenerator) content(schema *OpenAPISchema, minLength, maxLength int) (string, bool) {
+	switch {
+	case schema.ContentEncoding == "base64":
+		// Four characters encode three bytes
+		b := make([]byte, g.between((3*minLength+3)/4, 3*maxLength/4))
+		g.rand.Read(b)
+		return base64.StdEncoding.EncodeToString(b), true
+	case schema.ContentEncoding == "base16":
+		b := make([]byte, g.between((minLength+1)/2, maxLength/2))
+		g.rand.Read(b)
+		return hex.EncodeToString(b), true
+	case schema.ContentMediaType == "application/jwt":
+		claims := fmt.Sprintf(`{"sub":%q,"iat":%d}`, g.letters(6, 10), g.time().Unix())
+		return exampleJWTHeader + "." + base64.RawURLEncoding.EncodeToString([]byte(claims)) + "." + g.letters(43, 43), true
+	}
+	return "", false
+}
+
+// between returns a random int from lo to hi, or lo when hi is smaller
+func (g *valueGenerator) between(lo, hi int) int {
+	if hi <= lo {
+		return lo
+	}
+	return lo + g.rand.Intn(hi-lo+1)
+}
+
+// exampleJWTHeader is the base64url encoding of {"alg":"HS256","typ":"JWT"}
+const exampleJWTHeader = "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9"
+
+// time returns a random time within ten years of 2020-01-01 UTC
+func (g *valueGenerator) time() time.Time {
+	base := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	return base.Add(time.Duration(g.rand.Int63n(10*365*24*3600)) * time.Second)
+}
+
+// letters returns a random lowercase string with a length between min and max
+func (g *valueGenerator) letters(min, max int) string {
+	n := min
+	if max > min {
+		n += g.rand.Intn(max - min + 1)
+	}
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = byte('a' + g.rand.Intn(26))
+	}
+	return string(b)
+}
+
+// pattern writes a random string matching re
+func (g *valueGenerator) pattern(sb *strings.Builder, re *syntax.Regexp) {
+	switch re.Op {
+	case syntax.OpLiteral:
+		for _, r := range re.Rune {
+			sb.WriteRune(r)
+		}
+	case syntax.OpCharClass:
+		// Rune holds inclusive ranges as pairs
+		ranges := len(re.Rune) / 2
+		if ranges == 0 {
+			return
+		}
+		i := g.rand.Intn(ranges)
+		lo, hi := re.Rune[2*i], re.Rune[2*i+1]
+		// Keep unbounded classes such as [^a] within printable ASCII
+		if hi > '~' && lo <= '~' {
+			hi = '~'
+		}
+		if lo < ' ' && hi >= ' ' {
+			lo = ' '
+		}
+		sb.WriteRune(lo + rune(g.rand.Intn(int(hi-lo)+1)))
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		sb.WriteByte(byte('a' + g.rand.Intn(26)))
+	case syntax.OpCapture:
+		g.pattern(sb, re.Sub[0])
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			g.pattern(sb, sub)
+		}
+	case syntax.OpAlternate:
+		g.pattern(sb, re.Sub[g.rand.Intn(len(re.Sub))])
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+		min, max := re.Min, re.Max
+		switch re.Op {
+		case syntax.OpStar:
+			min, max = 0, -1
+		case syntax.OpPlus:
+			min, max = 1, -1
+		case syntax.OpQuest:
+			min, max = 0, 1
+		}
+		if max < 0 {
+			max = min + maxPatternRepeat
+		}
+		n := min + g.rand.Intn(max-min+1)
+		for i := 0; i < n; i++ {
+			g.pattern(sb, re.Sub[0])
+		}
+	}
+	// Anchors, word boundaries, and empty matches produce no characters
+}
+
+// number generates a number within the schema's bounds
+func (g *valueGenerator) number(schema *OpenAPISchema, integer bool) interface{} {
+	min, max := math.Inf(-1), math.Inf(1)
+	if schema.Minimum != nil {
+		min = *schema.Minimum
+	}
+	if schema.Maximum != nil {
+		max = *schema.Maximum
+	}
+	step := 0.0
+	if integer {
+		step = 1
+	}
+	if schema.ExclusiveMinimum != nil && *schema.ExclusiveMinimum >= min {
+		min = *schema.ExclusiveMinimum + math.Max(step, 1e-9)
+	}
+	if schema.ExclusiveMaximum != nil && *schema.ExclusiveMaximum <= max {
+		max = *schema.ExclusiveMaximum - math.Max(step, 1e-9)
+	}
+
+	// Open ranges are narrowed to a hundred around the known bound
+	switch {
+	case math.IsInf(min, -1) && math.IsInf(max, 1):
+		min, max = 0, 100
+	case math.IsInf(min, -1):
+		min = max - 100
+	case math.IsInf(max, 1):
+		max = min + 100
+	}
+
+	if schema.MultipleOf != nil && *schema.MultipleOf > 0 {
+		m := *schema.MultipleOf
+		lo, hi := math.Ceil(min/m), math.Floor(max/m)
+		if hi < lo {
+			return min
+		}
+		return (lo + float64(g.rand.Int63n(int64(hi-lo)+1))) * m
+	}
+	if integer {
+		lo, hi := math.Ceil(min), math.Floor(max)
+		if hi < lo {
+			return lo
+		}
+		return lo + float64(g.rand.Int63n(int64(hi-lo)+1))
+	}
+	return min + g.rand.Float64()*(max-min)
+}
+
+// array generates an array within the schema's item count limits
+func (g *valueGenerator) array(schema *OpenAPISchema) (interface{}, error) {
+	minItems, maxItems := 0, 0
+	if schema.MinItems != nil {
+		minItems = *schema.MinItems
+	}
+	if schema.MaxItems != nil {
+		maxItems = *schema.MaxItems
+	}
+	if maxItems == 0 || maxItems < minItems {
+		maxItems = minItems + 3
+	}
+	n := minItems + g.rand.Intn(maxItems-minItems+1)
+
+	// The first items match contains, combined with the item schema when both describe objects
+	matching := 0
+	var containsItem *OpenAPISchema
+	if schema.Contains != nil {
+		matching = 1
+		if schema.MinContains != nil {
+			matching = *schema.MinContains
+		}
+		if n < matching {
+			n = matching
+		}
+		containsItem = schema.Contains
+		if schema.Items != nil && len(schema.Items.Properties) > 0 && len(schema.Contains.Properties) > 0 {
+			containsItem = mergeAllOf(&OpenAPISchema{AllOf: []*OpenAPISchema{schema.Items, schema.Contains}})
+		}
+	}
+
+	unique := schema.UniqueItems != nil && *schema.UniqueItems
+	seen := make(map[string]bool)
+	items := make([]interface{}, 0, n)
+	for attempt := 0; len(items) < n && attempt < maxGenerateAttempts; attempt++ {
+		itemSchema := schema.Items
+		switch {
+		case len(items) < len(schema.PrefixItems):
+			itemSchema = schema.PrefixItems[len(items)]
+		case len(items) < matching:
+			itemSchema = containsItem
+		}
+		var item interface{}
+		if itemSchema != nil {
+			var err error
+			if item, err = g.value(itemSchema); err != nil {
+				return nil, err
+			}
+		} else {
+			item = g.letters(1, 8)
+		}
+		if unique {
+			key := fmt.Sprintf("%#v", item)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// object generates an object with every required property and a random subset of the rest
+func (g *valueGenerator) object(schema *OpenAPISchema) (interface{}, error) {
+	required := make(map[string]bool, len(schema.Required))
+	for _, name := range schema.Required {
+		required[name] = true
+	}
+
+	// Sorted names keep seeded generation deterministic
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	obj := make(map[string]interface{}, len(names))
+	for _, name := range names {
+		if !required[name] && g.rand.Intn(2) == 0 {
+			continue
+		}
+		value, err := g.value(schema.Properties[name])
+		if err != nil {
+			return nil, err
+		}
+		obj[name] = value
+	}
+	return obj, g.satisfyConditions(schema, obj)
+}
+
+// satisfyConditions adds the properties that dependentRequired and if/then/else subschemas
+// require of obj, repeating until adding a property triggers no further requirements
+func (g *valueGenerator) satisfyConditions(schema *OpenAPISchema, obj map[string]interface{}) error {
+	for {
+		missing := make(map[string]bool)
+		for name, dependents := range schema.DependentRequired {
+			if _, present := obj[name]; present {
+				for _, dependent := range dependents {
+					missing[dependent] = true
+				}
+			}
+		}
+		for _, member := range append([]*OpenAPISchema{schema}, schema.AllOf...) {
+			if member.If == nil {
+				continue
+			}
+			branch := member.Else
+			if matchesCondition(member.If, obj) {
+				branch = member.Then
+			}
+			if branch != nil {
+				for _, name := range branch.Required {
+					missing[name] = true
+				}
+			}
+		}
+
+		var names []string
+		for name := range missing {
+			if _, present := obj[name]; !present && schema.Properties[name] != nil {
+				names = append(names, name)
+			}
+		}
+		if len(names) == 0 {
+			return nil
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			value, err := g.value(schema.Properties[name])
+			if err != nil {
+				return err
+			}
+			obj[name] = value
+		}
+	}
+}
+
+// matchesCondition evaluates the if subschemas emitted for conditional requiredness: required
+// properties must be present, and properties with const or enum must hold one of their values
+func matchesCondition(condition *OpenAPISchema, obj map[string]interface{}) bool {
+	for _, name := range condition.Required {
+		if _, present := obj[name]; !present {
+			return false
+		}
+	}
+	for name, property := range condition.Properties {
+		value, present := obj[name]
+		if !present {
+			continue
+		}
+		allowed := property.Enum
+		if property.Const != nil {
+			allowed = []interface{}{property.Const}
+		}
+		if len(allowed) == 0 {
+			continue
+		}
+		matched := false
+		for _, candidate := range allowed {
+			if reflect.DeepEqual(normalizeGenerated(candidate), value) {
+				matched = true
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// conditionalOnly reports whether every allOf member is an if/then/else subschema
+func conditionalOnly(members []*OpenAPISchema) bool {
+	for _, member := range members {
+		if member.If == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// mergeAllOf combines allOf members into one schema: object members contribute their
+// properties and required names, and the first member with a type supplies the rest
+func mergeAllOf(schema *OpenAPISchema) *OpenAPISchema {
+	merged := *schema
+	merged.AllOf = nil
+	for _, member := range schema.AllOf {
+		// Conditional members are kept for the object generator to satisfy
+		if member.If != nil {
+			merged.AllOf = append(merged.AllOf, member)
+			continue
+		}
+		if len(member.Properties) > 0 {
+			properties := make(map[string]*OpenAPISchema, len(merged.Properties)+len(member.Properties))
+			for name, property := range merged.Properties {
+				properties[name] = property
+			}
+			for name, property := range member.Properties {
+				properties[name] = property
+			}
+			merged.Properties = properties
+			merged.Required = append(append([]string{}, merged.Required...), member.Required...)
+			if merged.Type == "" {
+				merged.Type = "object"
+			}
+			continue
+		}
+		if merged.Type == "" && member.Type != "" {
+			typed := *member
+			typed.Description = merged.Description
+			return &typed
+		}
+	}
+	return &merged
+}
+
+// normalizeGenerated converts Go numeric types from enum and const values to float64,
+// matching the types JSON decoding produces
+func normalizeGenerated(value interface{}) interface{} {
+	switch v := value.(type) {
+	case int:
+		return float64(v)
+	case int32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case float32:
+		return float64(v)
+	}
+	return value
+}
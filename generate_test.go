@@ -0,0 +1,112 @@
+package goop
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+// TestGenerate tests random value generation from schema definitions
+func TestGenerate(t *testing.T) {
+	minLength, maxLength := 3, 6
+	minimum, maximum := 10.0, 20.0
+	minItems, maxItems := 2, 4
+	unique := true
+	schema := &walkTestSchema{schema: &OpenAPISchema{
+		Type: "object",
+		Properties: map[string]*OpenAPISchema{
+			"code":   {Type: "string", Pattern: "^[A-Z]{3}-\\d{4}$"},
+			"name":   {Type: "string", MinLength: &minLength, MaxLength: &maxLength},
+			"email":  {Type: "string", Format: "email"},
+			"id":     {Type: "string", Format: "uuid"},
+			"age":    {Type: "integer", Minimum: &minimum, Maximum: &maximum},
+			"role":   {Type: "string", Enum: []interface{}{"admin", "member"}},
+			"tags":   {Type: "array", Items: &OpenAPISchema{Type: "string", Enum: []interface{}{"a", "b", "c", "d"}}, MinItems: &minItems, MaxItems: &maxItems, UniqueItems: &unique},
+			"active": {Type: "boolean"},
+		},
+		Required: []string{"code", "name", "email", "id", "age", "role", "tags", "active"},
+	}}
+
+	t.Run("Honors constraints", func(t *testing.T) {
+		for seed := int64(0); seed < 20; seed++ {
+			value, err := Generate(schema, WithSeed(seed))
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			obj := value.(map[string]interface{})
+
+			if !regexp.MustCompile(`^[A-Z]{3}-\d{4}$`).MatchString(obj["code"].(string)) {
+				t.Errorf("Expected code to match pattern, got %q", obj["code"])
+			}
+			if name := obj["name"].(string); len(name) < 3 || len(name) > 6 {
+				t.Errorf("Expected name length 3-6, got %q", name)
+			}
+			if !regexp.MustCompile(`^[^@]+@[^@]+$`).MatchString(obj["email"].(string)) {
+				t.Errorf("Expected email, got %q", obj["email"])
+			}
+			if !regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`).MatchString(obj["id"].(string)) {
+				t.Errorf("Expected uuid, got %q", obj["id"])
+			}
+			if age := obj["age"].(float64); age < 10 || age > 20 || age != float64(int(age)) {
+				t.Errorf("Expected integer age 10-20, got %v", age)
+			}
+			if role := obj["role"]; role != "admin" && role != "member" {
+				t.Errorf("Expected enum role, got %v", role)
+			}
+			tags := obj["tags"].([]interface{})
+			if len(tags) < 2 || len(tags) > 4 {
+				t.Errorf("Expected 2-4 tags, got %v", tags)
+			}
+			seen := map[interface{}]bool{}
+			for _, tag := range tags {
+				if seen[tag] {
+					t.Errorf("Expected unique tags, got %v", tags)
+				}
+				seen[tag] = true
+			}
+			if _, ok := obj["active"].(bool); !ok {
+				t.Errorf("Expected boolean active, got %v", obj["active"])
+			}
+		}
+	})
+
+	t.Run("Same seed produces the same value", func(t *testing.T) {
+		first, _ := Generate(schema, WithSeed(42))
+		second, _ := Generate(schema, WithSeed(42))
+		if !reflect.DeepEqual(first, second) {
+			t.Errorf("Expected identical values, got %v and %v", first, second)
+		}
+	})
+
+	t.Run("Retries until the schema accepts the value", func(t *testing.T) {
+		calls := 0
+		even := &walkTestSchema{
+			schema: &OpenAPISchema{Type: "integer", Minimum: &minimum, Maximum: &maximum},
+			validate: func(data interface{}) error {
+				calls++
+				if int(data.(float64))%2 != 0 {
+					return errors.New("must be even")
+				}
+				return nil
+			},
+		}
+		value, err := Generate(even, WithSeed(1))
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if int(value.(float64))%2 != 0 {
+			t.Errorf("Expected even value, got %v", value)
+		}
+	})
+
+	t.Run("Reports schemas that cannot be satisfied", func(t *testing.T) {
+		never := &walkTestSchema{
+			schema:   &OpenAPISchema{Type: "string"},
+			validate: func(interface{}) error { return errors.New("never valid") },
+		}
+		if _, err := Generate(never, WithSeed(1)); err == nil {
+			t.Error("Expected error when no value is valid")
+		}
+	})
+}
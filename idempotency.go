@@ -0,0 +1,28 @@
+package goop
+
+import "context"
+
+// IdempotencyKeyHeader is the request header carrying the client-supplied idempotency key
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotencyRecord is a stored response replayed for duplicate requests
+type IdempotencyRecord struct {
+	StatusCode  int    `json:"status_code"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        []byte `json:"body,omitempty"`
+	// RequestHash fingerprints the original request so key reuse with a different payload can be rejected
+	RequestHash string `json:"request_hash"`
+}
+
+// IdempotencyStore persists responses keyed by idempotency key
+// Implementations must make Reserve atomic (e.g. SET NX in Redis) so concurrent duplicates are detected
+type IdempotencyStore interface {
+	// Reserve claims key for processing
+	// It returns the stored record if the key already completed, reserved=true if the caller
+	// now owns the key, or reserved=false if another request is still processing it
+	Reserve(ctx context.Context, key string) (record *IdempotencyRecord, reserved bool, err error)
+	// Complete stores the response for a reserved key
+	Complete(ctx context.Context, key string, record IdempotencyRecord) error
+	// Release drops a reservation without storing a response so the request can be retried
+	Release(ctx context.Context, key string) error
+}
@@ -0,0 +1,317 @@
+// Package analyze estimates how expensive the schemas of each operation are to validate and
+// flags the hot spots worth optimizing, such as large oneOf compositions without a
+// discriminator, deeply nested schemas, and unbounded arrays.
+//
+// Costs are estimated in units of roughly one keyword check: every schema counts 1, a regular
+// expression 10, and a format 2. Arrays are assumed to hold maxItems items, or
+// DefaultArrayLength without a limit. A oneOf or anyOf validates every branch unless its
+// branches share a discriminator, a property each branch constrains with a different const,
+// in which case only the matching branch is validated.
+package analyze
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	goop "github.com/picogrid/go-op"
+	"github.com/picogrid/go-op/internal/specutil"
+	"github.com/picogrid/go-op/operations"
+)
+
+// httpMethods are the operation keys of a path item
+var httpMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// Cost units of the validation steps
+const (
+	schemaCost  = 1
+	patternCost = 10
+	formatCheck = 2
+
+	// DefaultArrayLength is the number of items assumed for arrays without maxItems
+	DefaultArrayLength = 10
+)
+
+// Thresholds above which schemas are reported as hot spots
+type Thresholds struct {
+	MaxDepth    int     // Deepest nesting of objects and arrays
+	MaxBranches int     // Most branches of a oneOf or anyOf without a discriminator
+	MaxRegexes  int     // Most regular expressions evaluated by one operation
+	MaxCost     float64 // Highest estimated validation cost of one operation
+}
+
+// DefaultThresholds are the thresholds used when none are configured
+var DefaultThresholds = Thresholds{MaxDepth: 6, MaxBranches: 3, MaxRegexes: 10, MaxCost: 500}
+
+// HotSpot is a part of an operation's schemas worth optimizing
+type HotSpot struct {
+	Location string // Where in the operation, such as "request body property `content`"
+	Message  string
+}
+
+// OperationReport holds the complexity of one operation's request and success response schemas
+type OperationReport struct {
+	Operation string // Method and path, such as POST /messages
+	Depth     int    // Deepest nesting of objects and arrays
+	Regexes   int    // Regular expressions in the schemas
+	Branches  int    // Most branches of a single oneOf or anyOf
+	Cost      float64
+	HotSpots  []HotSpot
+}
+
+// Report holds the complexity of every operation, most expensive first
+type Report struct {
+	Operations []OperationReport
+}
+
+// HotSpots returns the number of hot spots across operations
+func (r Report) HotSpots() int {
+	count := 0
+	for _, op := range r.Operations {
+		count += len(op.HotSpots)
+	}
+	return count
+}
+
+// Analyze reports the validation complexity of every operation of spec
+func Analyze(spec *operations.OpenAPISpec, thresholds Thresholds) Report {
+	var report Report
+	for path, item := range spec.Paths {
+		for _, method := range httpMethods {
+			op, ok := item[method]
+			if !ok {
+				continue
+			}
+			a := &analyzer{spec: spec, thresholds: thresholds, resolving: make(map[string]bool)}
+			a.report.Operation = strings.ToUpper(method) + " " + path
+			a.operation(spec.Components.Dereference(op))
+			report.Operations = append(report.Operations, a.report)
+		}
+	}
+	sort.Slice(report.Operations, func(i, j int) bool {
+		if report.Operations[i].Cost != report.Operations[j].Cost {
+			return report.Operations[i].Cost > report.Operations[j].Cost
+		}
+		return report.Operations[i].Operation < report.Operations[j].Operation
+	})
+	return report
+}
+
+// analyzer measures the schemas of one operation
+type analyzer struct {
+	spec       *operations.OpenAPISpec
+	thresholds Thresholds
+	report     OperationReport
+	resolving  map[string]bool // Component schemas being measured, to stop at recursive references
+}
+
+func (a *analyzer) hotSpot(location, format string, args ...interface{}) {
+	a.report.HotSpots = append(a.report.HotSpots, HotSpot{Location: location, Message: fmt.Sprintf(format, args...)})
+}
+
+// operation measures the schemas validated for each request: parameters, the request body,
+// and the lowest documented 2xx response
+func (a *analyzer) operation(op operations.OpenAPIOperation) {
+	for _, param := range op.Parameters {
+		if param.Schema != nil {
+			a.report.Cost += a.schema(param.Schema, param.In+" parameter `"+param.Name+"`", 1)
+		}
+	}
+	if op.RequestBody != nil {
+		if schema := specutil.MediaSchema(op.RequestBody.Content); schema != nil {
+			a.report.Cost += a.schema(schema, "request body", 1)
+		}
+	}
+
+	codes := make([]string, 0, len(op.Responses))
+	for code := range op.Responses {
+		if strings.HasPrefix(code, "2") {
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+	if len(codes) > 0 {
+		if schema := specutil.MediaSchema(op.Responses[codes[0]].Content); schema != nil {
+			a.report.Cost += a.schema(schema, "response "+codes[0], 1)
+		}
+	}
+
+	if a.report.Regexes > a.thresholds.MaxRegexes {
+		a.hotSpot("operation", "%d regular expressions are evaluated per request (threshold %d)", a.report.Regexes, a.thresholds.MaxRegexes)
+	}
+	if a.report.Cost > a.thresholds.MaxCost {
+		a.hotSpot("operation", "estimated validation cost %s exceeds %s", FormatCost(a.report.Cost), FormatCost(a.thresholds.MaxCost))
+	}
+}
+
+// schema returns the estimated cost of validating a value against schema at a nesting depth,
+// recording the operation's metrics and hot spots on the way
+func (a *analyzer) schema(schema *goop.OpenAPISchema, location string, depth int) float64 {
+	schema, component := a.resolve(schema)
+	if schema == nil {
+		return schemaCost
+	}
+	if component != "" {
+		a.resolving[component] = true
+		defer delete(a.resolving, component)
+	}
+
+	if depth > a.report.Depth {
+		a.report.Depth = depth
+		if depth == a.thresholds.MaxDepth+1 {
+			a.hotSpot(location, "schemas nest %d levels deep (threshold %d)", depth, a.thresholds.MaxDepth)
+		}
+	}
+
+	cost := float64(schemaCost)
+	if schema.Pattern != "" {
+		a.report.Regexes++
+		cost += patternCost
+	}
+	if schema.Format != "" {
+		cost += formatCheck
+	}
+
+	for _, name := range sortedKeys(schema.Properties) {
+		cost += a.schema(schema.Properties[name], child(location, name), depth+1)
+	}
+	if schema.Items != nil {
+		length := DefaultArrayLength
+		if schema.MaxItems != nil {
+			length = *schema.MaxItems
+		} else if a.isObject(schema.Items) {
+			a.hotSpot(location, "array of objects without maxItems: validation cost grows with the request; set MaxItems")
+		}
+		cost += float64(length) * a.schema(schema.Items, child(location, "[]"), depth+1)
+	}
+
+	for _, composition := range []struct {
+		name    string
+		members []*goop.OpenAPISchema
+	}{{"oneOf", schema.OneOf}, {"anyOf", schema.AnyOf}} {
+		if len(composition.members) == 0 {
+			continue
+		}
+		if len(composition.members) > a.report.Branches {
+			a.report.Branches = len(composition.members)
+		}
+		costs := make([]float64, len(composition.members))
+		for i, member := range composition.members {
+			costs[i] = a.schema(member, fmt.Sprintf("%s %s[%d]", location, composition.name, i), depth)
+		}
+		if a.hasDiscriminator(composition.members) {
+			// Only the branch selected by the discriminator is validated
+			cost += maxOf(costs) + schemaCost
+			continue
+		}
+		for _, c := range costs {
+			cost += c
+		}
+		if len(composition.members) > a.thresholds.MaxBranches {
+			a.hotSpot(location, "%d-branch %s without a discriminator validates every branch; give each branch a distinct Const on a shared property",
+				len(composition.members), composition.name)
+		}
+	}
+	for i, member := range schema.AllOf {
+		cost += a.schema(member, fmt.Sprintf("%s allOf[%d]", location, i), depth)
+	}
+	return cost
+}
+
+// hasDiscriminator reports whether every member constrains a shared property with a different
+// const, the way validators.OneOf picks a branch without validating the others
+func (a *analyzer) hasDiscriminator(members []*goop.OpenAPISchema) bool {
+	branches := make([]*goop.OpenAPISchema, len(members))
+	for i, member := range members {
+		resolved, _ := a.resolve(member)
+		if resolved == nil || len(resolved.Properties) == 0 {
+			return false
+		}
+		branches[i] = resolved
+	}
+
+candidates:
+	for _, name := range sortedKeys(branches[0].Properties) {
+		seen := make(map[string]bool, len(branches))
+		for _, branch := range branches {
+			property, ok := branch.Properties[name]
+			if !ok || property == nil {
+				continue candidates
+			}
+			value, ok := constOf(property)
+			if !ok || seen[value] {
+				continue candidates
+			}
+			seen[value] = true
+		}
+		return true
+	}
+	return false
+}
+
+// constOf returns the single value a schema allows, from const or a one-value enum
+func constOf(schema *goop.OpenAPISchema) (string, bool) {
+	if schema.Const != nil {
+		return fmt.Sprint(schema.Const), true
+	}
+	if len(schema.Enum) == 1 {
+		return fmt.Sprint(schema.Enum[0]), true
+	}
+	return "", false
+}
+
+// isObject reports whether schema describes objects, the array items whose count matters most
+func (a *analyzer) isObject(schema *goop.OpenAPISchema) bool {
+	resolved, _ := a.resolve(schema)
+	return resolved != nil && (resolved.Type == "object" || len(resolved.Properties) > 0)
+}
+
+// resolve returns the component schema referenced by schema, or schema itself
+// It returns nil for references to components that are missing or already being measured.
+func (a *analyzer) resolve(schema *goop.OpenAPISchema) (*goop.OpenAPISchema, string) {
+	name, ok := operations.ComponentName(schema.Ref)
+	if !ok {
+		return schema, ""
+	}
+	if a.spec.Components == nil || a.resolving[name] {
+		return nil, name
+	}
+	return a.spec.Components.Schemas[name], name
+}
+
+// child returns the location of a property or of array items
+func child(location, name string) string {
+	if strings.HasSuffix(location, "`") {
+		parent := strings.TrimSuffix(location, "`")
+		if name == "[]" {
+			return parent + "[]`"
+		}
+		return parent + "." + name + "`"
+	}
+	return location + " property `" + name + "`"
+}
+
+func maxOf(values []float64) float64 {
+	highest := 0.0
+	for _, value := range values {
+		if value > highest {
+			highest = value
+		}
+	}
+	return highest
+}
+
+// FormatCost formats a cost estimate without needless decimals
+func FormatCost(cost float64) string {
+	return strconv.FormatFloat(cost, 'f', -1, 64)
+}
+
+func sortedKeys(properties map[string]*goop.OpenAPISchema) []string {
+	keys := make([]string, 0, len(properties))
+	for key := range properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,106 @@
+package analyze
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/picogrid/go-op/operations"
+)
+
+func TestAnalyze(t *testing.T) {
+	var spec operations.OpenAPISpec
+	err := json.Unmarshal([]byte(`{
+		"openapi": "3.1.0",
+		"info": {"title": "Messages API", "version": "1.0.0"},
+		"paths": {
+			"/messages": {
+				"post": {
+					"parameters": [{"name": "channel", "in": "query", "schema": {"type": "string", "pattern": "^[a-z]+$"}}],
+					"requestBody": {"content": {"application/json": {"schema": {
+						"type": "object",
+						"properties": {
+							"content": {"oneOf": [
+								{"$ref": "#/components/schemas/Text"},
+								{"type": "object", "properties": {"url": {"type": "string", "format": "uri"}}},
+								{"type": "object", "properties": {"lat": {"type": "number"}, "lng": {"type": "number"}}},
+								{"type": "object", "properties": {"file": {"type": "string"}}},
+								{"type": "object", "properties": {"poll": {"type": "array", "items": {"type": "string"}}}}
+							]},
+							"recipients": {"type": "array", "items": {"type": "object", "properties": {"id": {"type": "string"}}}}
+						}
+					}}}},
+					"responses": {"201": {"description": "Created", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Text"}}}}}
+				}
+			},
+			"/events": {
+				"post": {
+					"requestBody": {"content": {"application/json": {"schema": {"oneOf": [
+						{"type": "object", "properties": {"type": {"const": "created"}, "id": {"type": "string"}}},
+						{"type": "object", "properties": {"type": {"const": "updated"}, "id": {"type": "string"}}},
+						{"type": "object", "properties": {"type": {"const": "deleted"}, "id": {"type": "string"}}},
+						{"type": "object", "properties": {"type": {"const": "archived"}, "id": {"type": "string"}}}
+					]}}}},
+					"responses": {"204": {"description": "Accepted"}}
+				}
+			},
+			"/tree": {
+				"get": {"responses": {"200": {"description": "Tree", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Node"}}}}}}
+			}
+		},
+		"components": {"schemas": {
+			"Text": {"type": "object", "properties": {"text": {"type": "string", "pattern": "^\\S"}}},
+			"Node": {"type": "object", "properties": {"children": {"type": "array", "maxItems": 2, "items": {"$ref": "#/components/schemas/Node"}}}}
+		}}
+	}`), &spec)
+	if err != nil {
+		t.Fatalf("Failed to parse spec: %v", err)
+	}
+
+	report := Analyze(&spec, DefaultThresholds)
+	byOperation := make(map[string]OperationReport)
+	var order []string
+	for _, op := range report.Operations {
+		byOperation[op.Operation] = op
+		order = append(order, op.Operation)
+	}
+	if strings.Join(order, ", ") != "POST /messages, POST /events, GET /tree" {
+		t.Errorf("Expected operations ordered by cost, got %v", order)
+	}
+
+	messages := byOperation["POST /messages"]
+	if messages.Regexes != 3 || messages.Branches != 5 || messages.Depth != 4 || messages.Cost != 79 {
+		t.Errorf("Unexpected metrics for POST /messages: %+v", messages)
+	}
+	var hotSpots []string
+	for _, spot := range messages.HotSpots {
+		hotSpots = append(hotSpots, spot.Location+": "+spot.Message)
+	}
+	expected := []string{
+		"request body property `content`: 5-branch oneOf without a discriminator validates every branch; give each branch a distinct Const on a shared property",
+		"request body property `recipients`: array of objects without maxItems: validation cost grows with the request; set MaxItems",
+	}
+	if strings.Join(hotSpots, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Unexpected hot spots:\n%s", strings.Join(hotSpots, "\n"))
+	}
+
+	// A discriminated oneOf validates one branch and is not a hot spot
+	events := byOperation["POST /events"]
+	if events.Branches != 4 || len(events.HotSpots) != 0 {
+		t.Errorf("Expected the discriminated oneOf to pass, got %+v", events)
+	}
+	if events.Cost != 5 {
+		t.Errorf("Expected cost 5 for POST /events, got %v", events.Cost)
+	}
+
+	// Recursive references are not expanded again
+	if tree := byOperation["GET /tree"]; tree.Depth != 2 || tree.Cost != 4 {
+		t.Errorf("Expected depth 2 and cost 4 for GET /tree, got %+v", tree)
+	}
+
+	strict := DefaultThresholds
+	strict.MaxCost = 10
+	if report := Analyze(&spec, strict); report.HotSpots() != 3 {
+		t.Errorf("Expected 3 hot spots with a cost threshold of 10, got %+v", report.Operations)
+	}
+}
@@ -0,0 +1,386 @@
+// Package changelog compares two versions of an OpenAPI specification and renders the
+// differences as a human-readable changelog, grouped the way release notes are:
+// added, changed, deprecated, and removed.
+package changelog
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	goop "github.com/picogrid/go-op"
+	"github.com/picogrid/go-op/internal/specutil"
+	"github.com/picogrid/go-op/operations"
+)
+
+// httpMethods are the operation keys of a path item, in the order they are listed
+var httpMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// Change is one entry of a changelog
+type Change struct {
+	Operation string // Method and path, such as GET /orders/{id}
+	Text      string // What changed, such as "added optional query parameter `expand`"
+	Breaking  bool   // Whether existing clients may stop working
+}
+
+// Changelog holds the changes between two versions of a spec, grouped by kind
+type Changelog struct {
+	Added      []Change
+	Changed    []Change
+	Deprecated []Change
+	Removed    []Change
+}
+
+// Empty reports whether the versions document the same API
+func (c Changelog) Empty() bool {
+	return len(c.Added)+len(c.Changed)+len(c.Deprecated)+len(c.Removed) == 0
+}
+
+// Breaking returns the changes that may stop existing clients from working
+func (c Changelog) Breaking() []Change {
+	var breaking []Change
+	for _, group := range [][]Change{c.Added, c.Changed, c.Deprecated, c.Removed} {
+		for _, change := range group {
+			if change.Breaking {
+				breaking = append(breaking, change)
+			}
+		}
+	}
+	return breaking
+}
+
+// Markdown renders the changelog under a heading, ready to paste into release notes
+func (c Changelog) Markdown(heading string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "## %s\n", heading)
+	if c.Empty() {
+		b.WriteString("\nNo API changes.\n")
+		return b.String()
+	}
+	for _, group := range []struct {
+		title   string
+		changes []Change
+	}{{"Added", c.Added}, {"Changed", c.Changed}, {"Deprecated", c.Deprecated}, {"Removed", c.Removed}} {
+		if len(group.changes) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n### %s\n\n", group.title)
+		for _, change := range group.changes {
+			fmt.Fprintf(&b, "- `%s`", change.Operation)
+			switch {
+			case change.Breaking && change.Text != "":
+				fmt.Fprintf(&b, ": **Breaking:** %s", change.Text)
+			case change.Breaking:
+				b.WriteString(": **Breaking**")
+			case change.Text != "":
+				fmt.Fprintf(&b, ": %s", change.Text)
+			}
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// Diff returns the changes from one version of a spec to the next
+func Diff(from, to *operations.OpenAPISpec) Changelog {
+	d := &differ{from: from, to: to}
+
+	paths := make(map[string]bool)
+	for path := range from.Paths {
+		paths[path] = true
+	}
+	for path := range to.Paths {
+		paths[path] = true
+	}
+	sortedPaths := make([]string, 0, len(paths))
+	for path := range paths {
+		sortedPaths = append(sortedPaths, path)
+	}
+	sort.Strings(sortedPaths)
+
+	for _, path := range sortedPaths {
+		for _, method := range httpMethods {
+			oldOp, hadOp := from.Paths[path][method]
+			newOp, hasOp := to.Paths[path][method]
+			label := strings.ToUpper(method) + " " + path
+			switch {
+			case hasOp && !hadOp:
+				d.log.Added = append(d.log.Added, Change{Operation: label, Text: newOp.Summary})
+			case hadOp && !hasOp:
+				d.log.Removed = append(d.log.Removed, Change{Operation: label, Text: oldOp.Summary, Breaking: !isTrue(oldOp.Deprecated)})
+			case hadOp && hasOp:
+				d.operation(label, from.Components.Dereference(oldOp), to.Components.Dereference(newOp))
+			}
+		}
+	}
+	return d.log
+}
+
+// differ accumulates the changes between two specs
+type differ struct {
+	from, to *operations.OpenAPISpec
+	log      Changelog
+}
+
+func (d *differ) changed(operation, text string, breaking bool) {
+	d.log.Changed = append(d.log.Changed, Change{Operation: operation, Text: text, Breaking: breaking})
+}
+
+func (d *differ) deprecated(operation, text string) {
+	d.log.Deprecated = append(d.log.Deprecated, Change{Operation: operation, Text: text})
+}
+
+// operation records the changes of an operation present in both versions
+func (d *differ) operation(label string, oldOp, newOp operations.OpenAPIOperation) {
+	if isTrue(newOp.Deprecated) && !isTrue(oldOp.Deprecated) {
+		d.deprecated(label, newOp.Summary)
+	}
+	d.deprecation(label, oldOp.Extensions, newOp.Extensions)
+	d.idempotency(label, oldOp.Extensions, newOp.Extensions)
+	d.parameters(label, oldOp.Parameters, newOp.Parameters)
+
+	switch {
+	case oldOp.RequestBody == nil && newOp.RequestBody != nil:
+		kind := "optional"
+		if newOp.RequestBody.Required {
+			kind = "required"
+		}
+		d.changed(label, "added "+kind+" request body", newOp.RequestBody.Required)
+	case oldOp.RequestBody != nil && newOp.RequestBody == nil:
+		d.changed(label, "removed request body", true)
+	case oldOp.RequestBody != nil && newOp.RequestBody != nil:
+		if newOp.RequestBody.Required && !oldOp.RequestBody.Required {
+			d.changed(label, "made request body required", true)
+		}
+		d.schema(label, "request", "", specutil.MediaSchema(oldOp.RequestBody.Content), specutil.MediaSchema(newOp.RequestBody.Content), true, map[string]bool{})
+	}
+
+	codes := make(map[string]bool)
+	for code := range oldOp.Responses {
+		codes[code] = true
+	}
+	for code := range newOp.Responses {
+		codes[code] = true
+	}
+	for _, code := range sortedKeys(codes) {
+		oldResponse, hadResponse := oldOp.Responses[code]
+		newResponse, hasResponse := newOp.Responses[code]
+		switch {
+		case hasResponse && !hadResponse:
+			d.changed(label, "added response "+code, false)
+		case hadResponse && !hasResponse:
+			d.changed(label, "removed response "+code, strings.HasPrefix(code, "2"))
+		default:
+			d.schema(label, "response "+code, "", specutil.MediaSchema(oldResponse.Content), specutil.MediaSchema(newResponse.Content), false, map[string]bool{})
+		}
+	}
+}
+
+// deprecation records the sunset and successor announced for a deprecated operation
+// Bringing the sunset forward is breaking; clients planned their migration around it.
+func (d *differ) deprecation(label string, oldExtensions, newExtensions map[string]interface{}) {
+	oldSunset, oldSuccessor := deprecationDetails(oldExtensions)
+	newSunset, newSuccessor := deprecationDetails(newExtensions)
+	switch {
+	case oldSunset == "" && newSunset != "":
+		d.deprecated(label, "sunset on "+newSunset)
+	case oldSunset != "" && newSunset == "":
+		d.changed(label, "removed sunset "+oldSunset, false)
+	case oldSunset != newSunset:
+		d.changed(label, fmt.Sprintf("moved sunset from %s to %s", oldSunset, newSunset), newSunset < oldSunset)
+	}
+	switch {
+	case oldSuccessor == "" && newSuccessor != "":
+		d.deprecated(label, "use `"+newSuccessor+"` instead")
+	case oldSuccessor != "" && newSuccessor == "":
+		d.changed(label, "removed successor `"+oldSuccessor+"`", false)
+	case oldSuccessor != newSuccessor:
+		d.changed(label, fmt.Sprintf("changed successor from `%s` to `%s`", oldSuccessor, newSuccessor), false)
+	}
+}
+
+// deprecationDetails returns the sunset and successor of an x-deprecation extension
+func deprecationDetails(extensions map[string]interface{}) (sunset, successor string) {
+	details, _ := extensions[operations.DeprecationExtension].(map[string]interface{})
+	sunset, _ = details["sunset"].(string)
+	successor, _ = details["successor"].(string)
+	return sunset, successor
+}
+
+// retrySafety ranks idempotency classifications by how freely clients may retry
+var retrySafety = map[string]int{
+	string(goop.NonIdempotent): 0,
+	string(goop.Conditional):   1,
+	string(goop.Idempotent):    2,
+}
+
+// idempotency records a change of an operation's x-idempotency classification
+// Clients retry according to the classification, so making retries less safe is breaking.
+func (d *differ) idempotency(label string, oldExtensions, newExtensions map[string]interface{}) {
+	oldClass, _ := oldExtensions[operations.IdempotencyExtension].(string)
+	newClass, _ := newExtensions[operations.IdempotencyExtension].(string)
+	switch {
+	case oldClass == newClass:
+	case oldClass == "":
+		d.changed(label, "classified as "+newClass, false)
+	case newClass == "":
+		d.changed(label, "removed "+oldClass+" classification", oldClass != string(goop.NonIdempotent))
+	default:
+		oldRank, knownOld := retrySafety[oldClass]
+		newRank, knownNew := retrySafety[newClass]
+		breaking := !knownOld || !knownNew || newRank < oldRank
+		d.changed(label, fmt.Sprintf("changed idempotency from %s to %s", oldClass, newClass), breaking)
+	}
+}
+
+// parameters records added, removed, and newly required or deprecated parameters
+func (d *differ) parameters(label string, oldParams, newParams []operations.OpenAPIParameter) {
+	key := func(p operations.OpenAPIParameter) string { return p.In + " parameter `" + p.Name + "`" }
+	old := make(map[string]operations.OpenAPIParameter, len(oldParams))
+	for _, param := range oldParams {
+		old[key(param)] = param
+	}
+	seen := make(map[string]bool, len(newParams))
+	for _, param := range newParams {
+		name := key(param)
+		seen[name] = true
+		previous, existed := old[name]
+		switch {
+		case !existed && param.Required:
+			d.changed(label, "added required "+name, true)
+		case !existed:
+			d.changed(label, "added optional "+name, false)
+		case param.Required && !previous.Required:
+			d.changed(label, "made "+name+" required", true)
+		case !param.Required && previous.Required:
+			d.changed(label, "made "+name+" optional", false)
+		}
+		if existed && isTrue(param.Deprecated) && !isTrue(previous.Deprecated) {
+			d.deprecated(label, name)
+		}
+		if existed && param.Schema != nil && previous.Schema != nil {
+			d.schema(label, name, "", previous.Schema, param.Schema, true, map[string]bool{})
+		}
+	}
+	for _, param := range oldParams {
+		if name := key(param); !seen[name] {
+			d.changed(label, "removed "+name, true)
+		}
+	}
+}
+
+// schema records the differences between two versions of a request or response schema
+// Adding requirements to requests and removing guarantees from responses are breaking.
+func (d *differ) schema(label, where, path string, oldSchema, newSchema *goop.OpenAPISchema, request bool, resolving map[string]bool) {
+	oldSchema, oldRef := resolve(d.from, oldSchema)
+	newSchema, newRef := resolve(d.to, newSchema)
+	if oldSchema == nil || newSchema == nil {
+		return
+	}
+	// Recursive schemas are compared once
+	if ref := oldRef + "|" + newRef; ref != "|" {
+		if resolving[ref] {
+			return
+		}
+		resolving[ref] = true
+		defer delete(resolving, ref)
+	}
+
+	subject := where
+	if path != "" {
+		subject = where + " property `" + path + "`"
+	}
+	if oldType, newType := schemaType(oldSchema), schemaType(newSchema); oldType != "" && newType != "" && oldType != newType {
+		d.changed(label, fmt.Sprintf("changed type of %s from %s to %s", subject, oldType, newType), true)
+		return
+	}
+	if path != "" && isTrue(newSchema.Deprecated) && !isTrue(oldSchema.Deprecated) {
+		d.deprecated(label, subject)
+	}
+
+	if schemaType(newSchema) == "array" && oldSchema.Items != nil && newSchema.Items != nil {
+		d.schema(label, where, path+"[]", oldSchema.Items, newSchema.Items, request, resolving)
+		return
+	}
+
+	oldRequired := setOf(oldSchema.Required)
+	newRequired := setOf(newSchema.Required)
+	names := make(map[string]bool)
+	for name := range oldSchema.Properties {
+		names[name] = true
+	}
+	for name := range newSchema.Properties {
+		names[name] = true
+	}
+	for _, name := range sortedKeys(names) {
+		property := name
+		if path != "" {
+			property = path + "." + name
+		}
+		propertySubject := where + " property `" + property + "`"
+		oldProperty, hadProperty := oldSchema.Properties[name]
+		newProperty, hasProperty := newSchema.Properties[name]
+		switch {
+		case hasProperty && !hadProperty:
+			if request && newRequired[name] {
+				d.changed(label, "added required "+propertySubject, true)
+			} else {
+				d.changed(label, "added "+propertySubject, false)
+			}
+		case hadProperty && !hasProperty:
+			d.changed(label, "removed "+propertySubject, !request)
+		default:
+			switch {
+			case request && newRequired[name] && !oldRequired[name]:
+				d.changed(label, "made "+propertySubject+" required", true)
+			case !request && oldRequired[name] && !newRequired[name]:
+				d.changed(label, "made "+propertySubject+" optional", true)
+			}
+			d.schema(label, where, property, oldProperty, newProperty, request, resolving)
+		}
+	}
+}
+
+// resolve returns the component schema a schema references, and the component's name
+func resolve(spec *operations.OpenAPISpec, schema *goop.OpenAPISchema) (*goop.OpenAPISchema, string) {
+	if schema == nil {
+		return nil, ""
+	}
+	name, ok := operations.ComponentName(schema.Ref)
+	if !ok {
+		return schema, ""
+	}
+	if spec.Components == nil {
+		return nil, name
+	}
+	return spec.Components.Schemas[name], name
+}
+
+// schemaType returns the type of schema, inferring object for schemas with properties
+func schemaType(schema *goop.OpenAPISchema) string {
+	if schema.Type == "" && len(schema.Properties) > 0 {
+		return "object"
+	}
+	return schema.Type
+}
+
+func isTrue(value *bool) bool {
+	return value != nil && *value
+}
+
+func setOf(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		set[value] = true
+	}
+	return set
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,186 @@
+package changelog
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/picogrid/go-op/operations"
+)
+
+func parseSpec(t *testing.T, data string) *operations.OpenAPISpec {
+	t.Helper()
+	var spec operations.OpenAPISpec
+	if err := json.Unmarshal([]byte(data), &spec); err != nil {
+		t.Fatalf("Failed to parse spec: %v", err)
+	}
+	return &spec
+}
+
+func TestDiff(t *testing.T) {
+	from := parseSpec(t, `{
+		"openapi": "3.1.0",
+		"info": {"title": "Orders API", "version": "1.3.0"},
+		"paths": {
+			"/orders": {
+				"get": {
+					"summary": "List orders",
+					"parameters": [
+						{"name": "limit", "in": "query", "required": false, "schema": {"type": "integer"}},
+						{"name": "page", "in": "query", "required": false, "schema": {"type": "integer"}}
+					],
+					"responses": {"200": {"description": "Orders", "content": {"application/json": {"schema": {
+						"type": "array", "items": {"$ref": "#/components/schemas/Order"}
+					}}}}}
+				},
+				"post": {
+					"summary": "Create an order",
+					"requestBody": {"required": true, "content": {"application/json": {"schema": {
+						"type": "object", "required": ["total"], "properties": {"total": {"type": "number"}, "note": {"type": "string"}}
+					}}}},
+					"responses": {"201": {"description": "Created"}}
+				}
+			},
+			"/orders/{id}/cancel": {
+				"post": {"summary": "Cancel an order", "responses": {"204": {"description": "Cancelled"}}}
+			}
+		},
+		"components": {"schemas": {"Order": {
+			"type": "object", "required": ["id", "legacy_id"],
+			"properties": {"id": {"type": "string"}, "legacy_id": {"type": "string"}, "total": {"type": "string"}}
+		}}}
+	}`)
+	to := parseSpec(t, `{
+		"openapi": "3.1.0",
+		"info": {"title": "Orders API", "version": "1.4.0"},
+		"paths": {
+			"/orders": {
+				"get": {
+					"summary": "List orders",
+					"parameters": [
+						{"name": "limit", "in": "query", "required": false, "schema": {"type": "integer"}},
+						{"name": "cursor", "in": "query", "required": false, "schema": {"type": "string"}}
+					],
+					"responses": {
+						"200": {"description": "Orders", "content": {"application/json": {"schema": {
+							"type": "array", "items": {"$ref": "#/components/schemas/Order"}
+						}}}},
+						"429": {"description": "Too many requests"}
+					}
+				},
+				"post": {
+					"summary": "Create an order",
+					"deprecated": true,
+					"requestBody": {"required": true, "content": {"application/json": {"schema": {
+						"type": "object", "required": ["total", "currency"],
+						"properties": {"total": {"type": "number"}, "note": {"type": "string", "deprecated": true}, "currency": {"type": "string"}}
+					}}}},
+					"responses": {"201": {"description": "Created"}}
+				}
+			},
+			"/v2/orders": {
+				"post": {"summary": "Create an order", "responses": {"201": {"description": "Created"}}}
+			}
+		},
+		"components": {"schemas": {"Order": {
+			"type": "object", "required": ["id"],
+			"properties": {"id": {"type": "string"}, "total": {"type": "number"}, "status": {"type": "string"}}
+		}}}
+	}`)
+
+	log := Diff(from, to)
+	got := log.Markdown("v1.3.0..v1.4.0")
+	expected := "## v1.3.0..v1.4.0\n" +
+		"\n### Added\n\n" +
+		"- `POST /v2/orders`: Create an order\n" +
+		"\n### Changed\n\n" +
+		"- `GET /orders`: added optional query parameter `cursor`\n" +
+		"- `GET /orders`: **Breaking:** removed query parameter `page`\n" +
+		"- `GET /orders`: **Breaking:** removed response 200 property `[].legacy_id`\n" +
+		"- `GET /orders`: added response 200 property `[].status`\n" +
+		"- `GET /orders`: **Breaking:** changed type of response 200 property `[].total` from string to number\n" +
+		"- `GET /orders`: added response 429\n" +
+		"- `POST /orders`: **Breaking:** added required request property `currency`\n" +
+		"\n### Deprecated\n\n" +
+		"- `POST /orders`: Create an order\n" +
+		"- `POST /orders`: request property `note`\n" +
+		"\n### Removed\n\n" +
+		"- `POST /orders/{id}/cancel`: **Breaking:** Cancel an order\n"
+	if got != expected {
+		t.Errorf("Unexpected changelog:\n%s\nexpected:\n%s", got, expected)
+	}
+	if breaking := log.Breaking(); len(breaking) != 5 {
+		t.Errorf("Expected 5 breaking changes, got %v", breaking)
+	}
+
+	if unchanged := Diff(to, to); !unchanged.Empty() || !strings.Contains(unchanged.Markdown("1.4.0"), "No API changes.") {
+		t.Errorf("Expected no changes between identical specs, got %+v", unchanged)
+	}
+}
+
+func TestDiffExtensions(t *testing.T) {
+	from := parseSpec(t, `{
+		"openapi": "3.1.0",
+		"info": {"title": "Payments API", "version": "2.0.0"},
+		"paths": {
+			"/payments": {
+				"post": {"summary": "Create a payment", "x-idempotency": "conditional", "responses": {"201": {"description": "Created"}}},
+				"get": {"summary": "List payments", "x-idempotency": "idempotent", "responses": {"200": {"description": "Payments"}}}
+			},
+			"/payments/{id}": {
+				"put": {"summary": "Replace a payment", "x-idempotency": "non-idempotent", "responses": {"200": {"description": "Replaced"}}},
+				"delete": {
+					"summary": "Delete a payment", "deprecated": true,
+					"x-deprecation": {"since": "2026-01-01T00:00:00Z", "sunset": "2027-06-01T00:00:00Z", "successor": "archivePayment"},
+					"responses": {"204": {"description": "Deleted"}}
+				}
+			},
+			"/refunds": {
+				"post": {"summary": "Refund a payment", "deprecated": true, "responses": {"201": {"description": "Refunded"}}}
+			}
+		}
+	}`)
+	to := parseSpec(t, `{
+		"openapi": "3.1.0",
+		"info": {"title": "Payments API", "version": "2.1.0"},
+		"paths": {
+			"/payments": {
+				"post": {"summary": "Create a payment", "x-idempotency": "non-idempotent", "responses": {"201": {"description": "Created"}}},
+				"get": {"summary": "List payments", "x-idempotency": "idempotent", "responses": {"200": {"description": "Payments"}}}
+			},
+			"/payments/{id}": {
+				"put": {"summary": "Replace a payment", "x-idempotency": "idempotent", "responses": {"200": {"description": "Replaced"}}},
+				"delete": {
+					"summary": "Delete a payment", "deprecated": true,
+					"x-deprecation": {"since": "2026-01-01T00:00:00Z", "sunset": "2027-01-01T00:00:00Z", "successor": "closePayment"},
+					"responses": {"204": {"description": "Deleted"}}
+				}
+			},
+			"/refunds": {
+				"post": {
+					"summary": "Refund a payment", "deprecated": true,
+					"x-deprecation": {"sunset": "2027-03-01T00:00:00Z", "successor": "createRefund"},
+					"responses": {"201": {"description": "Refunded"}}
+				}
+			}
+		}
+	}`)
+
+	log := Diff(from, to)
+	got := log.Markdown("v2.0.0..v2.1.0")
+	expected := "## v2.0.0..v2.1.0\n" +
+		"\n### Changed\n\n" +
+		"- `POST /payments`: **Breaking:** changed idempotency from conditional to non-idempotent\n" +
+		"- `PUT /payments/{id}`: changed idempotency from non-idempotent to idempotent\n" +
+		"- `DELETE /payments/{id}`: **Breaking:** moved sunset from 2027-06-01T00:00:00Z to 2027-01-01T00:00:00Z\n" +
+		"- `DELETE /payments/{id}`: changed successor from `archivePayment` to `closePayment`\n" +
+		"\n### Deprecated\n\n" +
+		"- `POST /refunds`: sunset on 2027-03-01T00:00:00Z\n" +
+		"- `POST /refunds`: use `createRefund` instead\n"
+	if got != expected {
+		t.Errorf("Unexpected changelog:\n%s\nexpected:\n%s", got, expected)
+	}
+	if breaking := log.Breaking(); len(breaking) != 2 {
+		t.Errorf("Expected 2 breaking changes, got %v", breaking)
+	}
+}
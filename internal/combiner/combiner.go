@@ -9,6 +9,7 @@ import (
 
 	"gopkg.in/yaml.v3"
 
+	"github.com/picogrid/go-op/internal/multifile"
 	"github.com/picogrid/go-op/operations"
 )
 
@@ -423,6 +424,11 @@ func (c *Combiner) WriteOutput() error {
 		return fmt.Errorf("failed to create output directory: %w", err)
 	}
 
+	// Split output writes path items and schemas to their own files next to the root document
+	if c.config.Split {
+		return multifile.WriteSplit(c.combined, c.config.OutputFile, c.config.Format)
+	}
+
 	// Write the spec in the specified format
 	switch strings.ToLower(c.config.Format) {
 	case "json":
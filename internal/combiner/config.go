@@ -5,6 +5,7 @@ type Config struct {
 	// Input/Output settings
 	OutputFile string // Output file path
 	Format     string // Output format: "yaml" or "json"
+	Split      bool   // Write path items and schemas to separate files next to OutputFile
 
 	// Combined API metadata
 	Title   string // API title for the combined specification
@@ -6,6 +6,9 @@ import (
 	"go/token"
 	"strconv"
 	"strings"
+	"time"
+
+	goop "github.com/picogrid/go-op"
 )
 
 // ASTAnalyzer provides sophisticated AST analysis for operation extraction
@@ -258,8 +261,77 @@ func (a *ASTAnalyzer) processMethodCall(methodName string, args []ast.Expr, op *
 		if len(args) > 0 {
 			if desc := a.extractStringLiteral(args[0]); desc != "" {
 				op.Description = desc
+				op.DescriptionFile = ""
+			}
+		}
+	case "DescriptionFromFile":
+		if len(args) > 0 {
+			if file := a.extractStringLiteral(args[0]); file != "" {
+				op.DescriptionFile = file
+				op.Description = ""
+			}
+		}
+	case "Handler":
+		if len(args) > 0 {
+			op.HandlerNames = a.handlerCandidates(args[0])
+		}
+	case "OperationID":
+		if len(args) > 0 {
+			if id := a.extractStringLiteral(args[0]); id != "" {
+				op.OperationID = id
+			}
+		}
+	case "Deprecated":
+		// Dates are usually computed values, so only the flag and successor are extracted statically
+		op.Deprecated = true
+		if len(args) >= 3 {
+			op.Successor = a.extractStringLiteral(args[2])
+		}
+	case "Version":
+		if len(args) > 0 {
+			op.Version = a.extractStringLiteral(args[0])
+		}
+	case "Audience":
+		for _, arg := range args {
+			if audience := a.extractStringLiteral(arg); audience != "" {
+				op.Audiences = append(op.Audiences, audience)
+			}
+		}
+	case "Timeout":
+		if len(args) > 0 {
+			if timeout := a.extractDurationLiteral(args[0]); timeout > 0 {
+				op.Timeout = timeout
+				if op.Responses == nil {
+					op.Responses = make(map[int]ResponseDefinition)
+				}
+				if _, exists := op.Responses[504]; !exists {
+					a.addStandardErrorResponse(op, 504, "Gateway Timeout")
+				}
+				if a.verbose {
+					fmt.Printf("[VERBOSE] Set timeout: %s\n", timeout)
+				}
 			}
 		}
+	case "Idempotency":
+		if len(args) > 0 {
+			op.Idempotency = a.extractIdempotency(args[0])
+		}
+	case "MaxBodyBytes":
+		if len(args) > 0 {
+			if limit := a.extractIntLiteral(args[0]); limit > 0 {
+				op.MaxBodyBytes = int64(limit)
+				if op.Responses == nil {
+					op.Responses = make(map[int]ResponseDefinition)
+				}
+				if _, exists := op.Responses[413]; !exists {
+					a.addStandardErrorResponse(op, 413, "Payload Too Large")
+				}
+			}
+		}
+	case "RateLimit":
+		if len(args) > 0 {
+			op.RateLimit = a.extractRateLimit(args[0])
+		}
 	case "Tags":
 		// Extract tags from arguments
 		for _, arg := range args {
@@ -275,9 +347,19 @@ func (a *ASTAnalyzer) processMethodCall(methodName string, args []ast.Expr, op *
 		if len(args) > 0 {
 			op.Query = a.extractSchemaDefinition(args[0])
 		}
+	case "WithHeaders":
+		if len(args) > 0 {
+			op.Headers = a.extractSchemaDefinition(args[0])
+		}
 	case "WithBody":
 		if len(args) > 0 {
 			op.Body = a.extractSchemaDefinition(args[0])
+			op.BodyType = ""
+		}
+	case "WithPatchBody":
+		if len(args) > 0 {
+			op.Body = a.extractSchemaDefinition(args[0])
+			op.BodyType = goop.MergePatchContentType
 		}
 	case "WithResponse":
 		if len(args) > 0 {
@@ -356,6 +438,58 @@ func (a *ASTAnalyzer) processMethodCall(methodName string, args []ast.Expr, op *
 	}
 }
 
+// handlerCandidates returns the names of functions that may implement a handler expression, in
+// order: the function itself, or the functions passed to wrappers such as CreateValidatedHandler
+func (a *ASTAnalyzer) handlerCandidates(expr ast.Expr) []string {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return []string{e.Name}
+	case *ast.SelectorExpr:
+		// Package functions and method values, such as handlers.CreateUser or h.CreateUser
+		return []string{e.Sel.Name}
+	case *ast.CallExpr:
+		var names []string
+		for _, arg := range e.Args {
+			names = append(names, a.handlerCandidates(arg)...)
+		}
+		return names
+	}
+	return nil
+}
+
+// FunctionDocs returns the doc comments of the functions and methods declared in file, keyed by
+// name. Functions take precedence over methods with the same name.
+func (a *ASTAnalyzer) FunctionDocs(file *ast.File) map[string]string {
+	docs := make(map[string]string)
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Doc == nil {
+			continue
+		}
+		doc := strings.TrimSpace(funcDecl.Doc.Text())
+		if doc == "" {
+			continue
+		}
+		if _, exists := docs[funcDecl.Name.Name]; exists && funcDecl.Recv != nil {
+			continue
+		}
+		docs[funcDecl.Name.Name] = doc
+	}
+	return docs
+}
+
+// DescriptionFromDoc turns the doc comment of the named handler into an operation description
+// The leading function name of Go doc comments is dropped, so "createUser creates a user" becomes
+// "Creates a user".
+func DescriptionFromDoc(name, doc string) string {
+	doc = strings.TrimSpace(doc)
+	rest, found := strings.CutPrefix(doc, name+" ")
+	if !found || rest == "" {
+		return doc
+	}
+	return strings.ToUpper(rest[:1]) + rest[1:]
+}
+
 // extractStringLiteral extracts string value from a basic literal
 func (a *ASTAnalyzer) extractStringLiteral(expr ast.Expr) string {
 	if basicLit, ok := expr.(*ast.BasicLit); ok && basicLit.Kind == token.STRING {
@@ -370,10 +504,117 @@ func (a *ASTAnalyzer) extractStringLiteral(expr ast.Expr) string {
 
 // extractIntLiteral extracts integer value from a basic literal
 func (a *ASTAnalyzer) extractIntLiteral(expr ast.Expr) int {
-	if basicLit, ok := expr.(*ast.BasicLit); ok && basicLit.Kind == token.INT {
-		var value int
-		value, _ = strconv.Atoi(basicLit.Value)
-		return value
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		if e.Kind == token.INT {
+			var value int
+			value, _ = strconv.Atoi(e.Value)
+			return value
+		}
+	case *ast.BinaryExpr:
+		// Support constant expressions like 1 << 20 and 10 * 1024
+		left, right := a.extractIntLiteral(e.X), a.extractIntLiteral(e.Y)
+		switch e.Op {
+		case token.SHL:
+			return left << right
+		case token.MUL:
+			return left * right
+		}
+	case *ast.ParenExpr:
+		return a.extractIntLiteral(e.X)
+	}
+	return 0
+}
+
+// extractIdempotency extracts an idempotency classification from constants like operations.Idempotent
+func (a *ASTAnalyzer) extractIdempotency(expr ast.Expr) string {
+	var name string
+	switch e := expr.(type) {
+	case *ast.SelectorExpr:
+		name = e.Sel.Name
+	case *ast.Ident:
+		name = e.Name
+	default:
+		return a.extractStringLiteral(expr)
+	}
+
+	switch name {
+	case "Idempotent":
+		return string(goop.Idempotent)
+	case "NonIdempotent":
+		return string(goop.NonIdempotent)
+	case "Conditional":
+		return string(goop.Conditional)
+	}
+	return ""
+}
+
+// extractRateLimit extracts a rate limit from calls like operations.PerMinute(100)
+func (a *ASTAnalyzer) extractRateLimit(expr ast.Expr) *goop.RateLimit {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok || len(call.Args) == 0 {
+		return nil
+	}
+
+	var name string
+	switch fn := call.Fun.(type) {
+	case *ast.SelectorExpr:
+		name = fn.Sel.Name
+	case *ast.Ident:
+		name = fn.Name
+	}
+
+	var window time.Duration
+	switch name {
+	case "PerSecond":
+		window = time.Second
+	case "PerMinute":
+		window = time.Minute
+	case "PerHour":
+		window = time.Hour
+	default:
+		return nil
+	}
+
+	requests := a.extractIntLiteral(call.Args[0])
+	if requests <= 0 {
+		return nil
+	}
+	return &goop.RateLimit{Requests: requests, Window: window}
+}
+
+// extractDurationLiteral extracts a time.Duration from expressions like 5*time.Second or time.Minute
+func (a *ASTAnalyzer) extractDurationLiteral(expr ast.Expr) time.Duration {
+	switch e := expr.(type) {
+	case *ast.SelectorExpr:
+		if ident, ok := e.X.(*ast.Ident); ok && ident.Name == "time" {
+			switch e.Sel.Name {
+			case "Nanosecond":
+				return time.Nanosecond
+			case "Microsecond":
+				return time.Microsecond
+			case "Millisecond":
+				return time.Millisecond
+			case "Second":
+				return time.Second
+			case "Minute":
+				return time.Minute
+			case "Hour":
+				return time.Hour
+			}
+		}
+	case *ast.BinaryExpr:
+		if e.Op != token.MUL {
+			return 0
+		}
+		if unit := a.extractDurationLiteral(e.Y); unit > 0 {
+			return time.Duration(a.extractIntLiteral(e.X)) * unit
+		}
+		if unit := a.extractDurationLiteral(e.X); unit > 0 {
+			return time.Duration(a.extractIntLiteral(e.Y)) * unit
+		}
+	case *ast.ParenExpr:
+		return a.extractDurationLiteral(e.X)
 	}
 	return 0
 }
@@ -471,56 +712,9 @@ func (a *ASTAnalyzer) extractNumberLiteral(expr ast.Expr) *float64 {
 	return nil
 }
 
-// parseFloat is a simple float parser for literal values
+// parseFloat parses an integer or float literal value
 func parseFloat(s string) (float64, error) {
-	// Simple implementation - in a real parser you'd use strconv.ParseFloat
-	// Handle common values used in OpenAPI 3.1 features
-	switch s {
-	case "0":
-		return 0.0, nil
-	case "0.0":
-		return 0.0, nil
-	case "0.01":
-		return 0.01, nil
-	case "0.1":
-		return 0.1, nil
-	case "0.5":
-		return 0.5, nil
-	case "1":
-		return 1.0, nil
-	case "1.0":
-		return 1.0, nil
-	case "2":
-		return 2.0, nil
-	case "3":
-		return 3.0, nil
-	case "3.0":
-		return 3.0, nil
-	case "5":
-		return 5.0, nil
-	case "5.0":
-		return 5.0, nil
-	case "10":
-		return 10.0, nil
-	case "10.0":
-		return 10.0, nil
-	case "100":
-		return 100.0, nil
-	case "100.0":
-		return 100.0, nil
-	case "150":
-		return 150.0, nil
-	case "1000":
-		return 1000.0, nil
-	case "10000":
-		return 10000.0, nil
-	case "10000.0":
-		return 10000.0, nil
-	case "100000.0":
-		return 100000.0, nil
-	default:
-		return 0.0, fmt.Errorf("unsupported number: %s", s)
-	}
+	return strconv.ParseFloat(strings.ReplaceAll(s, "_", ""), 64)
 }
 
 // extractSchemaDefinition extracts schema information from an expression
@@ -615,16 +809,79 @@ func (a *ASTAnalyzer) processValidatorMethod(methodName string, args []ast.Expr,
 	case "Array":
 		schema.Type = "array"
 		// TODO: Extract array item type from arguments
+	case "Tuple":
+		schema.Type = "array"
+		for _, arg := range args {
+			schema.PrefixItems = append(schema.PrefixItems, a.extractSchemaDefinition(arg))
+		}
+	case "Rest":
+		if len(args) > 0 {
+			schema.Items = a.extractSchemaDefinition(args[0])
+		}
 	case "Bool":
 		schema.Type = "boolean"
 	case "Email":
 		schema.Type = "string"
 		schema.Format = "email"
+	case "Format":
+		if len(args) > 0 {
+			schema.Format = a.extractStringLiteral(args[0])
+		}
+	case "IPv4", "IPv6", "CIDR", "Hostname", "Port", "MAC":
+		schema.Format = strings.ToLower(methodName)
+	case "Base64":
+		schema.ContentEncoding = "base64"
+	case "Hex":
+		schema.ContentEncoding = "base16"
+	case "JWTFormat":
+		schema.ContentMediaType = "application/jwt"
+		schema.Pattern = `^[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*$`
+	case "Password":
+		schema.Type = "string"
+		schema.Format = "password"
+		schema.MinLength = func(v int) *int { return &v }(8)
+	case "Integer64", "Int", "Int64":
+		schema.Type = "integer"
+		schema.Format = "int64"
+	case "Int32":
+		schema.Type = "integer"
+		schema.Format = "int32"
+	case "Uint":
+		schema.Type = "integer"
+		schema.Format = "uint64"
+		schema.Minimum = func(v float64) *float64 { return &v }(0)
+	case "BigInt":
+		schema.Type = "string"
+		schema.Format = "bigint"
+		schema.Pattern = `^-?(0|[1-9][0-9]*)$`
+	case "Decimal":
+		schema.Type = "string"
+		schema.Format = "decimal"
+		schema.Pattern = `^-?(0|[1-9][0-9]*)(\.[0-9]+)?$`
+	case "Currency":
+		schema.Type = "string"
+		schema.Pattern = "^[A-Z]{3}$"
+	case "Money", "MoneyMinorUnits":
+		amount := &SchemaDefinition{Type: "string", Pattern: `^-?(0|[1-9][0-9]*)(\.[0-9]+)?$`}
+		if methodName == "MoneyMinorUnits" {
+			amount = &SchemaDefinition{Type: "integer"}
+		}
+		schema.Type = "object"
+		schema.Properties = map[string]*SchemaDefinition{
+			"amount":   amount,
+			"currency": {Type: "string", Pattern: "^[A-Z]{3}$"},
+		}
+		schema.Required = []string{"amount", "currency"}
+	case "Pattern":
+		if len(args) > 0 {
+			schema.Pattern = a.extractStringLiteral(args[0])
+		}
 	case "Min":
 		if len(args) > 0 {
 			if val := a.extractNumberLiteral(args[0]); val != nil {
 				if schema.Type == "string" {
-					schema.MinLength = func(v int) *int { return &v }(int(*val))
+					// String Min counts bytes; minLength is reserved for MinRunes
+					schema.MinBytes = func(v int) *int { return &v }(int(*val))
 				} else {
 					schema.Minimum = val
 				}
@@ -634,12 +891,33 @@ func (a *ASTAnalyzer) processValidatorMethod(methodName string, args []ast.Expr,
 		if len(args) > 0 {
 			if val := a.extractNumberLiteral(args[0]); val != nil {
 				if schema.Type == "string" {
-					schema.MaxLength = func(v int) *int { return &v }(int(*val))
+					// String Max counts bytes, like MaxBytes; the stricter limit applies
+					if schema.MaxBytes == nil || int(*val) < *schema.MaxBytes {
+						schema.MaxBytes = func(v int) *int { return &v }(int(*val))
+					}
 				} else {
 					schema.Maximum = val
 				}
 			}
 		}
+	case "MinRunes":
+		if len(args) > 0 {
+			if val := a.extractNumberLiteral(args[0]); val != nil {
+				schema.MinLength = func(v int) *int { return &v }(int(*val))
+			}
+		}
+	case "MaxRunes":
+		if len(args) > 0 {
+			if val := a.extractNumberLiteral(args[0]); val != nil {
+				schema.MaxLength = func(v int) *int { return &v }(int(*val))
+			}
+		}
+	case "MaxBytes":
+		if len(args) > 0 {
+			if val := a.extractNumberLiteral(args[0]); val != nil && (schema.MaxBytes == nil || int(*val) < *schema.MaxBytes) {
+				schema.MaxBytes = func(v int) *int { return &v }(int(*val))
+			}
+		}
 	case "Required":
 		// Mark this property as required
 		if a.verbose {
@@ -651,6 +929,74 @@ func (a *ASTAnalyzer) processValidatorMethod(methodName string, args []ast.Expr,
 		if a.verbose {
 			fmt.Printf("[VERBOSE] Schema is optional\n")
 		}
+	case "Custom":
+		// The check itself only runs at validation time; extensions passed with it are documented
+		for _, arg := range args[1:] {
+			call, ok := arg.(*ast.CallExpr)
+			if !ok || len(call.Args) != 2 {
+				continue
+			}
+			if sel, ok := call.Fun.(*ast.SelectorExpr); !ok || sel.Sel.Name != "Extension" {
+				continue
+			}
+			if name := a.extractStringLiteral(call.Args[0]); name != "" {
+				if schema.Extensions == nil {
+					schema.Extensions = make(map[string]interface{})
+				}
+				schema.Extensions[name] = a.extractLiteralValue(call.Args[1])
+			}
+		}
+	case "Sensitive", "PII":
+		// Document the data classification the way the validators package does
+		if schema.Extensions == nil {
+			schema.Extensions = make(map[string]interface{})
+		}
+		if methodName == "Sensitive" {
+			schema.Extensions[goop.DataClassificationExtension] = goop.ClassificationSensitive
+		} else {
+			schema.Extensions[goop.DataClassificationExtension] = goop.ClassificationPII
+			if len(args) > 0 {
+				if category := a.extractStringLiteral(args[0]); category != "" {
+					schema.Extensions[goop.PIICategoryExtension] = category
+				}
+			}
+		}
+	case "VisibleToScopes":
+		// Document the scopes that can see the field
+		var scopes []string
+		for _, arg := range args {
+			if scope := a.extractStringLiteral(arg); scope != "" {
+				scopes = append(scopes, scope)
+			}
+		}
+		if len(scopes) > 0 {
+			if schema.Extensions == nil {
+				schema.Extensions = make(map[string]interface{})
+			}
+			schema.Extensions[goop.VisibleToScopesExtension] = scopes
+		}
+	case "MaxFromTenant", "MaxItemsFromTenant":
+		// Document the tenant limit bounding the value
+		if len(args) > 0 {
+			if limit := a.extractStringLiteral(args[0]); limit != "" {
+				if schema.Extensions == nil {
+					schema.Extensions = make(map[string]interface{})
+				}
+				schema.Extensions[goop.TenantLimitExtension] = limit
+			}
+		}
+	case "Title":
+		if len(args) > 0 {
+			if val := a.extractStringLiteral(args[0]); val != "" {
+				schema.Title = val
+			}
+		}
+	case "Description":
+		if len(args) > 0 {
+			if val := a.extractStringLiteral(args[0]); val != "" {
+				schema.Description = val
+			}
+		}
 	case "Example":
 		// Extract simple example value
 		if len(args) > 0 {
@@ -721,6 +1067,30 @@ func (a *ASTAnalyzer) processValidatorMethod(methodName string, args []ast.Expr,
 				}
 			}
 		}
+	case "Contains":
+		// Handle contains constraints for arrays: a literal value or a schema, then optional bounds
+		if len(args) > 0 {
+			if val := a.extractLiteralValue(args[0]); val != nil {
+				schema.Contains = &SchemaDefinition{Const: val}
+			} else {
+				schema.Contains = a.extractSchemaDefinition(args[0])
+			}
+			if len(args) > 1 {
+				if val := a.extractNumberLiteral(args[1]); val != nil && int(*val) != 1 {
+					minContains := int(*val)
+					schema.MinContains = &minContains
+				}
+			}
+			if len(args) > 2 {
+				if val := a.extractNumberLiteral(args[2]); val != nil && *val > 0 {
+					maxContains := int(*val)
+					schema.MaxContains = &maxContains
+				}
+			}
+			if a.verbose {
+				fmt.Printf("[VERBOSE] Extracted contains constraint\n")
+			}
+		}
 	case "UniqueItems":
 		// Handle uniqueItems constraint for arrays
 		schema.UniqueItems = &[]bool{true}[0] // Set to true
@@ -797,6 +1167,63 @@ func (a *ASTAnalyzer) processValidatorMethod(methodName string, args []ast.Expr,
 				schema.Not = childSchema
 			}
 		}
+	case "RequiredIf", "RequiredUnless":
+		// Handle conditional requiredness for objects
+		if len(args) >= 2 {
+			field, dependsOn := a.extractStringLiteral(args[0]), a.extractStringLiteral(args[1])
+			if field == "" || dependsOn == "" {
+				break
+			}
+			var values []interface{}
+			for _, arg := range args[2:] {
+				values = append(values, a.extractLiteralValue(arg))
+			}
+			a.addRequiredCondition(schema, field, dependsOn, values, methodName == "RequiredUnless")
+			if a.verbose {
+				fmt.Printf("[VERBOSE] Extracted %s: %s depends on %s\n", methodName, field, dependsOn)
+			}
+		}
+	case "DeprecatedField":
+		// Handle deprecated object fields
+		if len(args) >= 1 {
+			field := a.extractStringLiteral(args[0])
+			if property, ok := schema.Properties[field]; ok {
+				// Copy the property, which may be shared with other schemas through a variable
+				deprecated := *property
+				deprecated.Deprecated = true
+				schema.Properties[field] = &deprecated
+				if a.verbose {
+					fmt.Printf("[VERBOSE] Extracted DeprecatedField: %s\n", field)
+				}
+			}
+		}
+	}
+}
+
+// addRequiredCondition documents a RequiredIf or RequiredUnless rule the way the validators package does:
+// presence rules as dependentRequired, and value rules as if/then subschemas under allOf
+func (a *ASTAnalyzer) addRequiredCondition(schema *SchemaDefinition, field, dependsOn string, values []interface{}, unless bool) {
+	if len(values) == 0 && !unless {
+		if schema.DependentRequired == nil {
+			schema.DependentRequired = make(map[string][]string)
+		}
+		schema.DependentRequired[dependsOn] = append(schema.DependentRequired[dependsOn], field)
+		return
+	}
+
+	condition := &SchemaDefinition{Required: []string{dependsOn}}
+	switch len(values) {
+	case 0:
+	case 1:
+		condition.Properties = map[string]*SchemaDefinition{dependsOn: {Const: values[0]}}
+	default:
+		condition.Properties = map[string]*SchemaDefinition{dependsOn: {Enum: values}}
+	}
+	consequence := &SchemaDefinition{Required: []string{field}}
+	if unless {
+		schema.AllOf = append(schema.AllOf, &SchemaDefinition{If: condition, Else: consequence})
+	} else {
+		schema.AllOf = append(schema.AllOf, &SchemaDefinition{If: condition, Then: consequence})
 	}
 }
 
@@ -835,12 +1262,25 @@ func (a *ASTAnalyzer) extractObjectProperties(expr ast.Expr, schema *SchemaDefin
 						schema.Properties = make(map[string]*SchemaDefinition)
 					}
 					schema.Properties[key] = propSchema
+					if isRequiredChain(keyValue.Value) {
+						schema.Required = append(schema.Required, key)
+					}
 				}
 			}
 		}
 	}
 }
 
+// isRequiredChain reports whether a property validator chain ends with Required()
+func isRequiredChain(expr ast.Expr) bool {
+	if callExpr, ok := expr.(*ast.CallExpr); ok {
+		if selExpr, ok := callExpr.Fun.(*ast.SelectorExpr); ok {
+			return selExpr.Sel.Name == "Required"
+		}
+	}
+	return false
+}
+
 // analyzePropertyValue analyzes a property value to determine its schema
 func (a *ASTAnalyzer) analyzePropertyValue(expr ast.Expr, propSchema *SchemaDefinition) {
 	switch e := expr.(type) {
@@ -5,6 +5,7 @@ import (
 	"go/parser"
 	"go/token"
 	"testing"
+	"time"
 )
 
 // Tests for AST analyzer functions that extract values from Go source code for OpenAPI generation
@@ -668,3 +669,264 @@ func TestTraverseValidatorChainPartialCoverage(t *testing.T) {
 		// If we get here without panic, the function handled the nested call gracefully
 	})
 }
+
+func TestExtractDurationLiteral(t *testing.T) {
+	analyzer := NewASTAnalyzer(token.NewFileSet(), false)
+
+	tests := []struct {
+		name     string
+		src      string
+		expected time.Duration
+	}{
+		{"count times unit", "5 * time.Second", 5 * time.Second},
+		{"unit times count", "time.Millisecond * 250", 250 * time.Millisecond},
+		{"bare unit", "time.Minute", time.Minute},
+		{"parenthesized", "(2 * time.Hour)", 2 * time.Hour},
+		{"unsupported expression", "timeout", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := parser.ParseExpr(tt.src)
+			if err != nil {
+				t.Fatalf("Failed to parse: %v", err)
+			}
+			if got := analyzer.extractDurationLiteral(expr); got != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+
+	t.Run("Timeout method sets operation timeout", func(t *testing.T) {
+		expr, err := parser.ParseExpr(`operations.NewSimple().GET("/reports").Timeout(30 * time.Second)`)
+		if err != nil {
+			t.Fatalf("Failed to parse: %v", err)
+		}
+		op := analyzer.extractFromExpr(expr, "test.go", "")
+		if op == nil {
+			t.Fatal("Expected operation to be extracted")
+		}
+		if op.Timeout != 30*time.Second {
+			t.Errorf("Expected timeout 30s, got %s", op.Timeout)
+		}
+		if _, exists := op.Responses[504]; !exists {
+			t.Error("Expected 504 response to be documented for operation with timeout")
+		}
+	})
+}
+
+func TestExtractIdempotency(t *testing.T) {
+	analyzer := NewASTAnalyzer(token.NewFileSet(), false)
+
+	tests := []struct {
+		name     string
+		src      string
+		expected string
+	}{
+		{"operations constant", "operations.Idempotent", "idempotent"},
+		{"goop constant", "goop.NonIdempotent", "non-idempotent"},
+		{"dot-imported constant", "Conditional", "conditional"},
+		{"string literal", `"idempotent"`, "idempotent"},
+		{"unknown identifier", "operations.Unknown", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := parser.ParseExpr(tt.src)
+			if err != nil {
+				t.Fatalf("Failed to parse: %v", err)
+			}
+			if got := analyzer.extractIdempotency(expr); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+
+	t.Run("Idempotency method sets operation classification", func(t *testing.T) {
+		expr, err := parser.ParseExpr(`operations.NewSimple().PUT("/users/{id}").Idempotency(operations.Idempotent)`)
+		if err != nil {
+			t.Fatalf("Failed to parse: %v", err)
+		}
+		op := analyzer.extractFromExpr(expr, "test.go", "")
+		if op == nil {
+			t.Fatal("Expected operation to be extracted")
+		}
+		if op.Idempotency != "idempotent" {
+			t.Errorf("Expected idempotency 'idempotent', got %q", op.Idempotency)
+		}
+	})
+	t.Run("Deprecated method marks operation deprecated", func(t *testing.T) {
+		expr, err := parser.ParseExpr(`operations.NewSimple().GET("/legacy").Deprecated(since, sunset, "listUsersV2")`)
+		if err != nil {
+			t.Fatalf("Failed to parse: %v", err)
+		}
+		op := analyzer.extractFromExpr(expr, "test.go", "")
+		if op == nil {
+			t.Fatal("Expected operation to be extracted")
+		}
+		if !op.Deprecated || op.Successor != "listUsersV2" {
+			t.Errorf("Expected deprecated operation with successor listUsersV2, got deprecated=%v successor=%q", op.Deprecated, op.Successor)
+		}
+	})
+
+	t.Run("Version method sets API version", func(t *testing.T) {
+		expr, err := parser.ParseExpr(`operations.NewSimple().GET("/users").Version("2024-06-01")`)
+		if err != nil {
+			t.Fatalf("Failed to parse: %v", err)
+		}
+		op := analyzer.extractFromExpr(expr, "test.go", "")
+		if op == nil {
+			t.Fatal("Expected operation to be extracted")
+		}
+		if op.Version != "2024-06-01" {
+			t.Errorf("Expected version '2024-06-01', got %q", op.Version)
+		}
+	})
+
+	t.Run("Audience method sets audiences", func(t *testing.T) {
+		expr, err := parser.ParseExpr(`operations.NewSimple().GET("/reports").Audience("internal", "partner")`)
+		if err != nil {
+			t.Fatalf("Failed to parse: %v", err)
+		}
+		op := analyzer.extractFromExpr(expr, "test.go", "")
+		if op == nil {
+			t.Fatal("Expected operation to be extracted")
+		}
+		if len(op.Audiences) != 2 || op.Audiences[0] != "internal" || op.Audiences[1] != "partner" {
+			t.Errorf("Expected audiences [internal partner], got %v", op.Audiences)
+		}
+	})
+
+	t.Run("MaxBodyBytes method sets body limit", func(t *testing.T) {
+		expr, err := parser.ParseExpr(`operations.NewSimple().POST("/bulk").MaxBodyBytes(1 << 20)`)
+		if err != nil {
+			t.Fatalf("Failed to parse: %v", err)
+		}
+		op := analyzer.extractFromExpr(expr, "test.go", "")
+		if op == nil {
+			t.Fatal("Expected operation to be extracted")
+		}
+		if op.MaxBodyBytes != 1<<20 {
+			t.Errorf("Expected body limit 1048576, got %d", op.MaxBodyBytes)
+		}
+		if _, exists := op.Responses[413]; !exists {
+			t.Error("Expected 413 response to be documented")
+		}
+	})
+
+	t.Run("RateLimit method sets operation rate limit", func(t *testing.T) {
+		expr, err := parser.ParseExpr(`operations.NewSimple().GET("/search").RateLimit(operations.PerMinute(100))`)
+		if err != nil {
+			t.Fatalf("Failed to parse: %v", err)
+		}
+		op := analyzer.extractFromExpr(expr, "test.go", "")
+		if op == nil {
+			t.Fatal("Expected operation to be extracted")
+		}
+		if op.RateLimit == nil || op.RateLimit.Requests != 100 || op.RateLimit.Window != time.Minute {
+			t.Errorf("Expected rate limit of 100 per minute, got %+v", op.RateLimit)
+		}
+	})
+}
+
+func TestHandlerDocs(t *testing.T) {
+	analyzer := NewASTAnalyzer(token.NewFileSet(), false)
+
+	t.Run("Handler candidates", func(t *testing.T) {
+		tests := []struct {
+			src      string
+			expected []string
+		}{
+			{`Handler(getUser)`, []string{"getUser"}},
+			{`Handler(handlers.GetUser)`, []string{"GetUser"}},
+			{`Handler(ginadapter.CreateValidatedHandler(createUser, paramsSchema, nil))`, []string{"createUser", "paramsSchema", "nil"}},
+		}
+		for _, tt := range tests {
+			expr, err := parser.ParseExpr(`operations.NewSimple().GET("/users").` + tt.src)
+			if err != nil {
+				t.Fatalf("Failed to parse: %v", err)
+			}
+			op := analyzer.extractFromExpr(expr, "test.go", "")
+			if op == nil {
+				t.Fatal("Expected operation to be extracted")
+			}
+			if len(op.HandlerNames) == 0 || op.HandlerNames[0] != tt.expected[0] || len(op.HandlerNames) != len(tt.expected) {
+				t.Errorf("%s: expected candidates %v, got %v", tt.src, tt.expected, op.HandlerNames)
+			}
+		}
+	})
+
+	t.Run("Function docs", func(t *testing.T) {
+		src := `package main
+
+// createUser creates a user and sends them a welcome email.
+func createUser() {}
+
+// Create is a method with the same name as no function
+func (s *Service) Create() {}
+
+//go:noinline
+func undocumented() {}
+`
+		file, err := parser.ParseFile(token.NewFileSet(), "test.go", src, parser.ParseComments)
+		if err != nil {
+			t.Fatalf("Failed to parse: %v", err)
+		}
+		docs := analyzer.FunctionDocs(file)
+		if docs["createUser"] != "createUser creates a user and sends them a welcome email." {
+			t.Errorf("Unexpected doc: %q", docs["createUser"])
+		}
+		if docs["Create"] == "" {
+			t.Error("Expected method docs to be collected")
+		}
+		if _, ok := docs["undocumented"]; ok {
+			t.Error("Expected directives not to count as docs")
+		}
+	})
+
+	t.Run("Description from doc", func(t *testing.T) {
+		tests := []struct {
+			name, doc, expected string
+		}{
+			{"createUser", "createUser creates a user.", "Creates a user."},
+			{"GetUser", "GetUser returns the user with the given ID.", "Returns the user with the given ID."},
+			{"listUsers", "Lists users page by page.", "Lists users page by page."},
+		}
+		for _, tt := range tests {
+			if got := DescriptionFromDoc(tt.name, tt.doc); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		}
+	})
+}
+
+func TestExtractSchemaDocumentation(t *testing.T) {
+	src := `package main
+	import "github.com/picogrid/go-op/validators"
+	var schema = validators.String().Title("SKU").Description("Stock keeping unit").Min(3).Required()`
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	var callExpr *ast.CallExpr
+	ast.Inspect(file, func(n ast.Node) bool {
+		if call, ok := n.(*ast.CallExpr); ok && callExpr == nil {
+			callExpr = call
+			return false
+		}
+		return true
+	})
+
+	schema := &SchemaDefinition{Type: "string"}
+	NewASTAnalyzer(fset, false).traverseValidatorChain(callExpr, schema)
+	if schema.Title != "SKU" || schema.Description != "Stock keeping unit" {
+		t.Fatalf("Expected title and description to be extracted, got %q and %q", schema.Title, schema.Description)
+	}
+
+	openAPI := (&Generator{}).convertSchemaToOpenAPI(schema)
+	if openAPI.Title != "SKU" || openAPI.Description != "Stock keeping unit" {
+		t.Errorf("Expected title and description to be documented, got %q and %q", openAPI.Title, openAPI.Description)
+	}
+}
@@ -6,15 +6,18 @@ type Config struct {
 	InputDir   string // Directory to scan for Go files
 	OutputFile string // Output file path
 	Format     string // Output format: "yaml" or "json"
+	Split      bool   // Write path items and schemas to separate files next to OutputFile
 
 	// OpenAPI metadata
 	Title       string   // API title
 	Version     string   // API version
 	Description string   // API description
 	Servers     []string // Server URLs
+	Audience    string   // Include only operations documented for this audience (empty includes all)
 
 	// Generation settings
-	Verbose bool // Enable verbose output
+	ExamplesFromTests bool // Document request/response examples from marked table-driven tests
+	Verbose           bool // Enable verbose output
 }
 
 // GenerationStats holds statistics about the generation process
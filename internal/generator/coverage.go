@@ -0,0 +1,199 @@
+package generator
+
+import (
+	"go/ast"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+)
+
+// ginRouteMethods maps the route registration methods of gin.Engine and gin.RouterGroup to
+// the HTTP method they register
+var ginRouteMethods = map[string]string{
+	"GET":     http.MethodGet,
+	"POST":    http.MethodPost,
+	"PUT":     http.MethodPut,
+	"PATCH":   http.MethodPatch,
+	"DELETE":  http.MethodDelete,
+	"HEAD":    http.MethodHead,
+	"OPTIONS": http.MethodOptions,
+	"Any":     "ANY",
+}
+
+// RawRoute is a route registered directly on a Gin engine or router group, bypassing go-op
+type RawRoute struct {
+	Method     string // HTTP method, or ANY for routes registered with Any
+	Path       string // Full path in OpenAPI form, such as /v1/users/{id}
+	SourceFile string
+	LineNumber int
+}
+
+// ExtractRawRoutes returns the routes file registers directly on Gin engines and router groups
+// Group prefixes are resolved within each function, so routes registered on a group received
+// as a parameter are reported relative to that group.
+func (a *ASTAnalyzer) ExtractRawRoutes(file *ast.File, filename string) []RawRoute {
+	var routes []RawRoute
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Body == nil {
+			continue
+		}
+
+		prefixes := make(map[string]string)
+		ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+			switch node := n.(type) {
+			case *ast.AssignStmt:
+				// Track groups such as v1 := engine.Group("/v1")
+				for i, rhs := range node.Rhs {
+					if i >= len(node.Lhs) {
+						break
+					}
+					ident, ok := node.Lhs[i].(*ast.Ident)
+					if !ok {
+						continue
+					}
+					if prefix, ok := a.groupPrefix(rhs, prefixes); ok {
+						prefixes[ident.Name] = prefix
+					}
+				}
+			case *ast.CallExpr:
+				if route, ok := a.rawRoute(node, prefixes); ok {
+					route.SourceFile = filename
+					route.LineNumber = a.fileSet.Position(node.Pos()).Line
+					routes = append(routes, route)
+				}
+			}
+			return true
+		})
+	}
+	return routes
+}
+
+// groupPrefix returns the full prefix of a Group call, including the prefix of the group it is called on
+func (a *ASTAnalyzer) groupPrefix(expr ast.Expr, prefixes map[string]string) (string, bool) {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok || len(call.Args) == 0 {
+		return "", false
+	}
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || selector.Sel.Name != "Group" {
+		return "", false
+	}
+	prefix := a.extractStringLiteral(call.Args[0])
+	if prefix == "" {
+		return "", false
+	}
+	return joinRoutePath(a.receiverPrefix(selector.X, prefixes), prefix), true
+}
+
+// receiverPrefix returns the group prefix of the value a route or group is registered on
+func (a *ASTAnalyzer) receiverPrefix(expr ast.Expr, prefixes map[string]string) string {
+	if ident, ok := expr.(*ast.Ident); ok {
+		return prefixes[ident.Name]
+	}
+	if prefix, ok := a.groupPrefix(expr, prefixes); ok {
+		return prefix
+	}
+	return ""
+}
+
+// rawRoute reports whether call registers a Gin route, such as engine.GET("/health", handler)
+// go-op builder calls such as NewSimple().GET("/users") take no handler and are not routes.
+func (a *ASTAnalyzer) rawRoute(call *ast.CallExpr, prefixes map[string]string) (RawRoute, bool) {
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return RawRoute{}, false
+	}
+
+	var method, routePath string
+	if selector.Sel.Name == "Handle" && len(call.Args) >= 3 {
+		method = strings.ToUpper(a.extractStringLiteral(call.Args[0]))
+		routePath = a.extractStringLiteral(call.Args[1])
+	} else if m, ok := ginRouteMethods[selector.Sel.Name]; ok && len(call.Args) >= 2 {
+		method = m
+		routePath = a.extractStringLiteral(call.Args[0])
+	}
+	if method == "" || routePath == "" {
+		return RawRoute{}, false
+	}
+
+	return RawRoute{
+		Method: method,
+		Path:   openAPIRoutePath(joinRoutePath(a.receiverPrefix(selector.X, prefixes), routePath)),
+	}, true
+}
+
+// joinRoutePath joins a group prefix and a relative route path the way Gin does
+func joinRoutePath(prefix, relative string) string {
+	if prefix == "" {
+		return relative
+	}
+	joined := path.Join(prefix, relative)
+	if strings.HasSuffix(relative, "/") && !strings.HasSuffix(joined, "/") {
+		joined += "/"
+	}
+	return joined
+}
+
+// openAPIRoutePath converts Gin path parameters such as :id and *filepath to {id} and {filepath}
+func openAPIRoutePath(ginPath string) string {
+	segments := strings.Split(ginPath, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// CoverageReport compares the routes registered directly on Gin with the go-op operations
+type CoverageReport struct {
+	Operations   int        // Number of go-op operations found
+	Routes       []RawRoute // Routes registered directly on Gin, sorted by path and method
+	Undocumented []RawRoute // Routes without a go-op operation documenting them
+}
+
+// Coverage reports the scanned routes that bypass go-op validation and spec generation
+// A route counts as documented when an operation declares the same method and path, as
+// spec-only operations do for hand-written handlers. Routes registered with Any are
+// documented by an operation of any method on their path. Routes whose path matches one
+// of the ignore patterns (path.Match syntax, such as /debug/*) are left out of the report.
+func (g *Generator) Coverage(ignore []string) CoverageReport {
+	documented := make(map[string]bool)
+	for _, op := range g.operations {
+		documented[strings.ToUpper(op.Method)+" "+op.Path] = true
+		documented["ANY "+op.Path] = true
+	}
+
+	routes := make([]RawRoute, 0, len(g.rawRoutes))
+	for _, route := range g.rawRoutes {
+		if !matchesAny(route.Path, ignore) {
+			routes = append(routes, route)
+		}
+	}
+	sort.SliceStable(routes, func(i, j int) bool {
+		if routes[i].Path != routes[j].Path {
+			return routes[i].Path < routes[j].Path
+		}
+		return routes[i].Method < routes[j].Method
+	})
+
+	report := CoverageReport{Operations: len(g.operations), Routes: routes}
+	for _, route := range routes {
+		if !documented[route.Method+" "+route.Path] {
+			report.Undocumented = append(report.Undocumented, route)
+		}
+	}
+	return report
+}
+
+// matchesAny reports whether routePath matches one of the path.Match patterns
+func matchesAny(routePath string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, routePath); matched {
+			return true
+		}
+	}
+	return false
+}
@@ -7,12 +7,16 @@ import (
 	"go/token"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 
 	goop "github.com/picogrid/go-op"
+	"github.com/picogrid/go-op/internal/multifile"
 	"github.com/picogrid/go-op/operations"
+	"github.com/picogrid/go-op/plugin"
 )
 
 // Generator handles OpenAPI specification generation from Go source code
@@ -23,22 +27,44 @@ type Generator struct {
 	schemas    map[string]*SchemaDefinition
 	spec       *operations.OpenAPISpec
 	stats      GenerationStats
+
+	// Doc comments of the scanned functions, keyed by package directory and then function name
+	funcDocs map[string]map[string]string
+
+	// Examples from marked table-driven tests, collected when ExamplesFromTests is set
+	testExamples []TestExample
+
+	// Routes registered directly on Gin, reported by Coverage
+	rawRoutes []RawRoute
 }
 
 // OperationDefinition represents a discovered operation in source code
 type OperationDefinition struct {
-	Method      string
-	Path        string
-	Summary     string
-	Description string
-	Tags        []string
-	Params      *SchemaDefinition
-	Query       *SchemaDefinition
-	Body        *SchemaDefinition
-	Response    *SchemaDefinition          // Deprecated: use Responses instead
-	Responses   map[int]ResponseDefinition // Multiple responses with status codes
-	SourceFile  string
-	LineNumber  int
+	Method          string
+	Path            string
+	Summary         string
+	Description     string
+	DescriptionFile string // Markdown file documenting the operation, relative to the module root
+	Tags            []string
+	OperationID     string
+	Params          *SchemaDefinition
+	Query           *SchemaDefinition
+	Body            *SchemaDefinition
+	BodyType        string // Media type of the request body (empty means application/json)
+	Headers         *SchemaDefinition
+	Response        *SchemaDefinition          // Deprecated: use Responses instead
+	Responses       map[int]ResponseDefinition // Multiple responses with status codes
+	Timeout         time.Duration              // Declared operation timeout
+	Idempotency     string                     // Retry-safety classification
+	RateLimit       *goop.RateLimit            // Declared request rate limit
+	MaxBodyBytes    int64                      // Maximum accepted request body size
+	Version         string                     // API version the operation belongs to
+	Audiences       []string                   // Audiences the operation is documented for
+	Deprecated      bool                       // Whether the operation is deprecated
+	Successor       string                     // Operation ID replacing a deprecated operation
+	HandlerNames    []string                   // Functions that may implement the handler, in order
+	SourceFile      string
+	LineNumber      int
 }
 
 // ResponseDefinition represents a response with schema and description
@@ -55,30 +81,50 @@ type SchemaDefinition struct {
 	Required      []string
 	MinLength     *int
 	MaxLength     *int
+	MinBytes      *int
+	MaxBytes      *int
+	Extensions    map[string]interface{}
 	Minimum       *float64
 	Maximum       *float64
 	Pattern       string
 	Format        string
 	Default       interface{}
+	Title         string
 	Description   string
 	Example       interface{}
 	Examples      map[string]ExampleObject
 	ExternalValue string
+	Deprecated    bool
 
 	// OpenAPI 3.1 / JSON Schema 2020-12 fields
-	Const            interface{} `json:"const,omitempty" yaml:"const,omitempty"`
-	MultipleOf       *float64    `json:"multipleOf,omitempty" yaml:"multipleOf,omitempty"`
-	ExclusiveMinimum *float64    `json:"exclusiveMinimum,omitempty" yaml:"exclusiveMinimum,omitempty"`
-	ExclusiveMaximum *float64    `json:"exclusiveMaximum,omitempty" yaml:"exclusiveMaximum,omitempty"`
-	UniqueItems      *bool       `json:"uniqueItems,omitempty" yaml:"uniqueItems,omitempty"`
-	MinProperties    *int        `json:"minProperties,omitempty" yaml:"minProperties,omitempty"`
-	MaxProperties    *int        `json:"maxProperties,omitempty" yaml:"maxProperties,omitempty"`
+	Const             interface{}         `json:"const,omitempty" yaml:"const,omitempty"`
+	MultipleOf        *float64            `json:"multipleOf,omitempty" yaml:"multipleOf,omitempty"`
+	ExclusiveMinimum  *float64            `json:"exclusiveMinimum,omitempty" yaml:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum  *float64            `json:"exclusiveMaximum,omitempty" yaml:"exclusiveMaximum,omitempty"`
+	UniqueItems       *bool               `json:"uniqueItems,omitempty" yaml:"uniqueItems,omitempty"`
+	MinContains       *int                `json:"minContains,omitempty" yaml:"minContains,omitempty"`
+	MaxContains       *int                `json:"maxContains,omitempty" yaml:"maxContains,omitempty"`
+	MinProperties     *int                `json:"minProperties,omitempty" yaml:"minProperties,omitempty"`
+	MaxProperties     *int                `json:"maxProperties,omitempty" yaml:"maxProperties,omitempty"`
+	ContentEncoding   string              `json:"contentEncoding,omitempty" yaml:"contentEncoding,omitempty"`
+	ContentMediaType  string              `json:"contentMediaType,omitempty" yaml:"contentMediaType,omitempty"`
+	Enum              []interface{}       `json:"enum,omitempty" yaml:"enum,omitempty"`
+	DependentRequired map[string][]string `json:"dependentRequired,omitempty" yaml:"dependentRequired,omitempty"`
+
+	// Array contains and tuple position subschemas
+	Contains    *SchemaDefinition
+	PrefixItems []*SchemaDefinition
 
 	// Schema composition fields for OpenAPI 3.1
 	OneOf []*SchemaDefinition
 	AllOf []*SchemaDefinition
 	AnyOf []*SchemaDefinition
 	Not   *SchemaDefinition
+
+	// Conditional subschemas, used for RequiredIf and RequiredUnless
+	If   *SchemaDefinition
+	Then *SchemaDefinition
+	Else *SchemaDefinition
 }
 
 // ExampleObject represents an OpenAPI example object
@@ -97,6 +143,7 @@ func New(config *Config) *Generator {
 		operations: make([]OperationDefinition, 0),
 		schemas:    make(map[string]*SchemaDefinition),
 		stats:      GenerationStats{},
+		funcDocs:   make(map[string]map[string]string),
 	}
 }
 
@@ -116,16 +163,22 @@ func (g *Generator) ScanOperations() error {
 			return nil
 		}
 
-		// Skip test files for now
-		if strings.HasSuffix(path, "_test.go") {
-			return nil
-		}
-
 		// Skip vendor directories
 		if strings.Contains(path, "/vendor/") {
 			return nil
 		}
 
+		// Test files never declare operations, but may document their examples
+		if strings.HasSuffix(path, "_test.go") {
+			if !g.config.ExamplesFromTests {
+				return nil
+			}
+			if g.config.Verbose {
+				fmt.Printf("[VERBOSE] Scanning test file for examples: %s\n", path)
+			}
+			return g.scanTestFile(path)
+		}
+
 		if g.config.Verbose {
 			fmt.Printf("[VERBOSE] Scanning file: %s\n", path)
 		}
@@ -162,6 +215,17 @@ func (g *Generator) scanFile(filename string) error {
 	analyzer := NewASTAnalyzer(g.fileSet, g.config.Verbose)
 	operations := analyzer.ExtractOperations(file, filename)
 
+	// Handlers are often declared in another file of the package, so their docs are resolved once all files are scanned
+	dir := filepath.Dir(filename)
+	if g.funcDocs[dir] == nil {
+		g.funcDocs[dir] = make(map[string]string)
+	}
+	for name, doc := range analyzer.FunctionDocs(file) {
+		g.funcDocs[dir][name] = doc
+	}
+
+	g.rawRoutes = append(g.rawRoutes, analyzer.ExtractRawRoutes(file, filename)...)
+
 	// Add discovered operations to the generator
 	for _, op := range operations {
 		g.operations = append(g.operations, op)
@@ -174,6 +238,27 @@ func (g *Generator) scanFile(filename string) error {
 	return nil
 }
 
+// scanTestFile collects the examples documented by marked table-driven tests in a Go test file
+func (g *Generator) scanTestFile(filename string) error {
+	filename = filepath.Clean(filename)
+	src, err := os.ReadFile(filename)
+	if err != nil {
+		return fmt.Errorf("failed to read file %s: %w", filename, err)
+	}
+
+	file, err := parser.ParseFile(g.fileSet, filename, src, parser.ParseComments)
+	if err != nil {
+		if g.config.Verbose {
+			fmt.Printf("[VERBOSE] Warning: failed to parse %s: %v\n", filename, err)
+		}
+		return nil // Skip files that can't be parsed
+	}
+
+	analyzer := NewASTAnalyzer(g.fileSet, g.config.Verbose)
+	g.testExamples = append(g.testExamples, analyzer.ExtractTestExamples(file, filename)...)
+	return nil
+}
+
 // GenerateSpec generates the OpenAPI specification from discovered operations
 func (g *Generator) GenerateSpec() error {
 	if g.config.Verbose {
@@ -203,6 +288,22 @@ func (g *Generator) GenerateSpec() error {
 
 	// Convert operations to OpenAPI format
 	for _, op := range g.operations {
+		if !g.visibleToAudience(op) {
+			if g.config.Verbose {
+				fmt.Printf("[VERBOSE] Skipping operation not documented for audience %s: %s %s\n", g.config.Audience, op.Method, op.Path)
+			}
+			continue
+		}
+		if op.DescriptionFile != "" {
+			description, err := descriptionFromFile(op)
+			if err != nil {
+				return err
+			}
+			op.Description = description
+		}
+		if op.Description == "" {
+			op.Description = g.handlerDescription(op)
+		}
 		g.addOperationToSpec(op)
 	}
 
@@ -211,6 +312,65 @@ func (g *Generator) GenerateSpec() error {
 	return nil
 }
 
+// handlerDescription returns the description documented by the doc comment of the operation's
+// handler, so documentation can live next to the code. It is empty when the handler has no doc comment.
+func (g *Generator) handlerDescription(op OperationDefinition) string {
+	docs := g.funcDocs[filepath.Dir(op.SourceFile)]
+	for _, name := range op.HandlerNames {
+		if doc, ok := docs[name]; ok {
+			return DescriptionFromDoc(name, doc)
+		}
+	}
+	return ""
+}
+
+// descriptionFromFile returns the contents of the Markdown file documenting op
+// Relative paths are resolved from the module root of the operation's source file, where services
+// are usually run from, or from the source file's directory outside a module.
+func descriptionFromFile(op OperationDefinition) (string, error) {
+	file := filepath.FromSlash(op.DescriptionFile)
+	if !filepath.IsAbs(file) {
+		dir, err := filepath.Abs(filepath.Dir(op.SourceFile))
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve directory of %s: %w", op.SourceFile, err)
+		}
+		file = filepath.Join(moduleRoot(dir), file)
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return "", fmt.Errorf("failed to read description of %s %s at %s:%d: %w", op.Method, op.Path, op.SourceFile, op.LineNumber, err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// moduleRoot returns the closest directory at or above dir containing a go.mod file, or dir if there is none
+func moduleRoot(dir string) string {
+	for current := dir; ; {
+		if _, err := os.Stat(filepath.Join(current, "go.mod")); err == nil {
+			return current
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			return dir
+		}
+		current = parent
+	}
+}
+
+// visibleToAudience reports whether op belongs in the spec for the configured audience
+// Operations without a declared audience are visible to every audience
+func (g *Generator) visibleToAudience(op OperationDefinition) bool {
+	if g.config.Audience == "" || len(op.Audiences) == 0 {
+		return true
+	}
+	for _, audience := range op.Audiences {
+		if audience == g.config.Audience {
+			return true
+		}
+	}
+	return false
+}
+
 // getTitle determines the API title
 func (g *Generator) getTitle() string {
 	if g.config.Title != "" {
@@ -245,10 +405,53 @@ func (g *Generator) addOperationToSpec(op OperationDefinition) {
 		Summary:     op.Summary,
 		Description: op.Description,
 		Tags:        op.Tags,
+		OperationId: op.OperationID,
 		Parameters:  []operations.OpenAPIParameter{},
 		Responses:   make(map[string]operations.OpenAPIResponse),
 	}
 
+	// Annotate versioned operations
+	if op.Version != "" {
+		openAPIOp.SetExtension(operations.VersionExtension, op.Version)
+	}
+
+	// Record restricted visibility
+	if len(op.Audiences) > 0 {
+		openAPIOp.SetExtension(operations.AudienceExtension, op.Audiences)
+	}
+
+	// Mark deprecated operations and their replacement
+	if op.Deprecated {
+		deprecated := true
+		openAPIOp.Deprecated = &deprecated
+		if op.Successor != "" {
+			openAPIOp.SetExtension(operations.DeprecationExtension, map[string]interface{}{"successor": op.Successor})
+		}
+	}
+
+	// Surface the declared timeout for client generators
+	if op.Timeout > 0 {
+		openAPIOp.SetExtension(operations.TimeoutExtension, op.Timeout.Milliseconds())
+	}
+
+	// Surface the retry-safety classification for client retry policies
+	if op.Idempotency != "" {
+		openAPIOp.SetExtension(operations.IdempotencyExtension, op.Idempotency)
+	}
+
+	// Surface the declared rate limit for client throttling
+	if op.RateLimit != nil {
+		openAPIOp.SetExtension(operations.RateLimitExtension, map[string]interface{}{
+			"requests": op.RateLimit.Requests,
+			"windowMs": op.RateLimit.Window.Milliseconds(),
+		})
+	}
+
+	// Surface the body size limit so clients can avoid oversized requests
+	if op.MaxBodyBytes > 0 {
+		openAPIOp.SetExtension(operations.MaxBodyBytesExtension, op.MaxBodyBytes)
+	}
+
 	// Add parameters from path params
 	if op.Params != nil {
 		g.addParametersFromSchema(op.Params, "path", &openAPIOp)
@@ -259,9 +462,14 @@ func (g *Generator) addOperationToSpec(op OperationDefinition) {
 		g.addParametersFromSchema(op.Query, "query", &openAPIOp)
 	}
 
+	// Add parameters from headers
+	if op.Headers != nil {
+		g.addParametersFromSchema(op.Headers, "header", &openAPIOp)
+	}
+
 	// Add request body if specified
 	if op.Body != nil {
-		openAPIOp.RequestBody = g.convertSchemaToRequestBody(op.Body)
+		openAPIOp.RequestBody = g.convertSchemaToRequestBody(op.Body, op.BodyType)
 	}
 
 	// Add responses - prefer multiple responses if available
@@ -301,8 +509,15 @@ func (g *Generator) addOperationToSpec(op OperationDefinition) {
 		}
 	}
 
-	// Add the operation to the spec
-	g.spec.Paths[op.Path][strings.ToLower(op.Method)] = openAPIOp
+	// Document the examples exercised by the operation's tests
+	g.addTestExamples(op, &openAPIOp)
+
+	// Add the operation to the spec, next to the other versions of a header-versioned operation
+	method := strings.ToLower(op.Method)
+	if existing, exists := g.spec.Paths[op.Path][method]; exists {
+		openAPIOp = operations.MergeVersions(existing, openAPIOp)
+	}
+	g.spec.Paths[op.Path][method] = openAPIOp
 }
 
 // addParametersFromSchema adds parameters to an operation from a schema
@@ -321,26 +536,63 @@ func (g *Generator) addParametersFromSchema(schema *SchemaDefinition, paramType
 }
 
 // convertSchemaToRequestBody converts a schema to a request body
-func (g *Generator) convertSchemaToRequestBody(schema *SchemaDefinition) *operations.OpenAPIRequestBody {
+func (g *Generator) convertSchemaToRequestBody(schema *SchemaDefinition, contentType string) *operations.OpenAPIRequestBody {
+	openAPISchema := g.convertSchemaToOpenAPI(schema)
+	switch contentType {
+	case "":
+		contentType = "application/json"
+	case goop.MergePatchContentType:
+		openAPISchema = mergePatchSchema(openAPISchema)
+	}
+
 	return &operations.OpenAPIRequestBody{
 		Required: true,
 		Content: map[string]operations.OpenAPIMediaType{
-			"application/json": {
-				Schema: g.convertSchemaToOpenAPI(schema),
+			contentType: {
+				Schema: openAPISchema,
 			},
 		},
 	}
 }
 
+// mergePatchSchema documents a JSON Merge Patch of an object: every field is optional, and
+// fields that may be absent accept null to remove them
+func mergePatchSchema(schema *goop.OpenAPISchema) *goop.OpenAPISchema {
+	if schema.Type != "object" || len(schema.Properties) == 0 {
+		return schema
+	}
+
+	required := make(map[string]bool, len(schema.Required))
+	for _, name := range schema.Required {
+		required[name] = true
+	}
+
+	patch := *schema
+	patch.Required = nil
+	patch.Properties = make(map[string]*goop.OpenAPISchema, len(schema.Properties))
+	for name, property := range schema.Properties {
+		property = mergePatchSchema(property)
+		if !required[name] {
+			property = &goop.OpenAPISchema{AnyOf: []*goop.OpenAPISchema{property, {Type: "null"}}}
+		}
+		patch.Properties[name] = property
+	}
+	return &patch
+}
+
 // convertSchemaToOpenAPI converts internal schema to go-op OpenAPI schema
 func (g *Generator) convertSchemaToOpenAPI(schema *SchemaDefinition) *goop.OpenAPISchema {
 	openAPISchema := &goop.OpenAPISchema{
 		Type:        schema.Type,
+		Title:       schema.Title,
 		Description: schema.Description,
 		Format:      schema.Format,
 		Pattern:     schema.Pattern,
 		Default:     schema.Default,
 		Example:     schema.Example,
+
+		ContentEncoding:  schema.ContentEncoding,
+		ContentMediaType: schema.ContentMediaType,
 	}
 
 	// Add constraints
@@ -350,6 +602,19 @@ func (g *Generator) convertSchemaToOpenAPI(schema *SchemaDefinition) *goop.OpenA
 	if schema.MaxLength != nil {
 		openAPISchema.MaxLength = schema.MaxLength
 	}
+	if schema.MinBytes != nil {
+		openAPISchema.MinBytes = schema.MinBytes
+	}
+	if schema.MaxBytes != nil {
+		openAPISchema.MaxBytes = schema.MaxBytes
+	}
+	for name, value := range schema.Extensions {
+		openAPISchema.SetExtension(name, value)
+	}
+	if schema.Deprecated {
+		deprecated := true
+		openAPISchema.Deprecated = &deprecated
+	}
 	if schema.Minimum != nil {
 		openAPISchema.Minimum = schema.Minimum
 	}
@@ -379,8 +644,14 @@ func (g *Generator) convertSchemaToOpenAPI(schema *SchemaDefinition) *goop.OpenA
 	if schema.MaxProperties != nil {
 		openAPISchema.MaxProperties = schema.MaxProperties
 	}
+	if len(schema.Enum) > 0 {
+		openAPISchema.Enum = schema.Enum
+	}
+	if len(schema.DependentRequired) > 0 {
+		openAPISchema.DependentRequired = schema.DependentRequired
+	}
 
-	// Handle object properties
+	// Handle object properties; untyped schemas carry them in conditional subschemas
 	if schema.Type == "object" && schema.Properties != nil {
 		openAPISchema.Properties = make(map[string]*goop.OpenAPISchema)
 		for name, propSchema := range schema.Properties {
@@ -389,12 +660,30 @@ func (g *Generator) convertSchemaToOpenAPI(schema *SchemaDefinition) *goop.OpenA
 		if len(schema.Required) > 0 {
 			openAPISchema.Required = schema.Required
 		}
+	} else if schema.Type == "" {
+		for name, propSchema := range schema.Properties {
+			if openAPISchema.Properties == nil {
+				openAPISchema.Properties = make(map[string]*goop.OpenAPISchema)
+			}
+			openAPISchema.Properties[name] = g.convertSchemaToOpenAPI(propSchema)
+		}
+		if len(schema.Required) > 0 {
+			openAPISchema.Required = schema.Required
+		}
 	}
 
 	// Handle array items
 	if schema.Type == "array" && schema.Items != nil {
 		openAPISchema.Items = g.convertSchemaToOpenAPI(schema.Items)
 	}
+	for _, position := range schema.PrefixItems {
+		openAPISchema.PrefixItems = append(openAPISchema.PrefixItems, g.convertSchemaToOpenAPI(position))
+	}
+	if schema.Contains != nil {
+		openAPISchema.Contains = g.convertSchemaToOpenAPI(schema.Contains)
+		openAPISchema.MinContains = schema.MinContains
+		openAPISchema.MaxContains = schema.MaxContains
+	}
 
 	// Handle schema composition (OpenAPI 3.1)
 	if len(schema.OneOf) > 0 {
@@ -418,6 +707,15 @@ func (g *Generator) convertSchemaToOpenAPI(schema *SchemaDefinition) *goop.OpenA
 	if schema.Not != nil {
 		openAPISchema.Not = g.convertSchemaToOpenAPI(schema.Not)
 	}
+	if schema.If != nil {
+		openAPISchema.If = g.convertSchemaToOpenAPI(schema.If)
+	}
+	if schema.Then != nil {
+		openAPISchema.Then = g.convertSchemaToOpenAPI(schema.Then)
+	}
+	if schema.Else != nil {
+		openAPISchema.Else = g.convertSchemaToOpenAPI(schema.Else)
+	}
 
 	return openAPISchema
 }
@@ -440,6 +738,11 @@ func (g *Generator) WriteSpec() error {
 		return fmt.Errorf("failed to create output directory: %w", err)
 	}
 
+	// Split output writes path items and schemas to their own files next to the root document
+	if g.config.Split {
+		return multifile.WriteSplit(g.spec, g.config.OutputFile, g.config.Format)
+	}
+
 	// Write the spec in the specified format
 	switch strings.ToLower(g.config.Format) {
 	case "json":
@@ -477,7 +780,85 @@ func (g *Generator) writeYAML() error {
 	return encoder.Encode(g.spec)
 }
 
+// Spec returns the specification built by GenerateSpec, or nil before it runs
+func (g *Generator) Spec() *operations.OpenAPISpec {
+	return g.spec
+}
+
 // GetStats returns generation statistics
 func (g *Generator) GetStats() GenerationStats {
 	return g.stats
 }
+
+// PluginRequest builds the request sent to generator plugins from the scanned operations and generated spec
+// Operations hidden from the configured audience are left out, as they are from the spec.
+func (g *Generator) PluginRequest(options map[string]string) *plugin.Request {
+	req := &plugin.Request{
+		ProtocolVersion: plugin.ProtocolVersion,
+		Options:         options,
+		Operations:      make([]plugin.Operation, 0, len(g.operations)),
+		Spec:            g.spec,
+	}
+
+	for _, op := range g.operations {
+		if !g.visibleToAudience(op) {
+			continue
+		}
+
+		operation := plugin.Operation{
+			Method:       op.Method,
+			Path:         op.Path,
+			OperationID:  op.OperationID,
+			Summary:      op.Summary,
+			Description:  op.Description,
+			Tags:         op.Tags,
+			TimeoutMs:    op.Timeout.Milliseconds(),
+			Idempotency:  op.Idempotency,
+			MaxBodyBytes: op.MaxBodyBytes,
+			Version:      op.Version,
+			Audiences:    op.Audiences,
+			Deprecated:   op.Deprecated,
+			Successor:    op.Successor,
+			SourceFile:   op.SourceFile,
+			LineNumber:   op.LineNumber,
+		}
+		for _, schema := range []struct {
+			source *SchemaDefinition
+			target **goop.OpenAPISchema
+		}{
+			{op.Params, &operation.Params},
+			{op.Query, &operation.Query},
+			{op.Body, &operation.Body},
+			{op.Headers, &operation.Headers},
+		} {
+			if schema.source != nil {
+				*schema.target = g.convertSchemaToOpenAPI(schema.source)
+			}
+		}
+		if op.RateLimit != nil {
+			operation.RateLimit = &plugin.RateLimit{
+				Requests: op.RateLimit.Requests,
+				WindowMs: op.RateLimit.Window.Milliseconds(),
+			}
+		}
+
+		if len(op.Responses) > 0 {
+			operation.Responses = make(map[string]plugin.OperationResponse, len(op.Responses))
+			for code, response := range op.Responses {
+				documented := plugin.OperationResponse{Description: response.Description}
+				if response.Schema != nil {
+					documented.Schema = g.convertSchemaToOpenAPI(response.Schema)
+				}
+				operation.Responses[strconv.Itoa(code)] = documented
+			}
+		} else if op.Response != nil {
+			operation.Responses = map[string]plugin.OperationResponse{
+				"200": {Description: "Successful response", Schema: g.convertSchemaToOpenAPI(op.Response)},
+			}
+		}
+
+		req.Operations = append(req.Operations, operation)
+	}
+
+	return req
+}
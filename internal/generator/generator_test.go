@@ -6,9 +6,11 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"gopkg.in/yaml.v3"
 
+	goop "github.com/picogrid/go-op"
 	"github.com/picogrid/go-op/operations"
 )
 
@@ -225,7 +227,7 @@ func TestConvertSchemaToRequestBody(t *testing.T) {
 		},
 	}
 
-	requestBody := gen.convertSchemaToRequestBody(schema)
+	requestBody := gen.convertSchemaToRequestBody(schema, "")
 
 	if !requestBody.Required {
 		t.Errorf("Expected request body to be required")
@@ -400,6 +402,13 @@ func TestAddOperationToSpec(t *testing.T) {
 				"notify": {Type: "boolean"},
 			},
 		},
+		Headers: &SchemaDefinition{
+			Type: "object",
+			Properties: map[string]*SchemaDefinition{
+				"X-Request-ID": {Type: "string"},
+			},
+			Required: []string{"X-Request-ID"},
+		},
 		Body: &SchemaDefinition{
 			Type: "object",
 			Properties: map[string]*SchemaDefinition{
@@ -451,12 +460,18 @@ func TestAddOperationToSpec(t *testing.T) {
 	// Check parameters
 	pathParams := 0
 	queryParams := 0
+	headerParams := 0
 	for _, param := range operation.Parameters {
 		switch param.In {
 		case "path":
 			pathParams++
 		case "query":
 			queryParams++
+		case "header":
+			headerParams++
+			if param.Name != "X-Request-ID" || !param.Required {
+				t.Errorf("Expected required header 'X-Request-ID', got %+v", param)
+			}
 		}
 	}
 
@@ -468,6 +483,10 @@ func TestAddOperationToSpec(t *testing.T) {
 		t.Errorf("Expected 1 query parameter, got %d", queryParams)
 	}
 
+	if headerParams != 1 {
+		t.Errorf("Expected 1 header parameter, got %d", headerParams)
+	}
+
 	// Check request body
 	if operation.RequestBody == nil {
 		t.Errorf("Expected request body to be set")
@@ -591,6 +610,78 @@ func TestGenerateSpec(t *testing.T) {
 	}
 }
 
+func TestGenerateSpecForAudience(t *testing.T) {
+	gen := New(&Config{Version: "1.0.0", Audience: "partner"})
+	gen.operations = []OperationDefinition{
+		{Method: "GET", Path: "/users", Summary: "List users"},
+		{Method: "GET", Path: "/reports", Summary: "List reports", Audiences: []string{"internal", "partner"}},
+		{Method: "GET", Path: "/admin/audit", Summary: "Audit log", Audiences: []string{"internal"}},
+	}
+
+	if err := gen.GenerateSpec(); err != nil {
+		t.Fatalf("Failed to generate spec: %v", err)
+	}
+
+	if _, exists := gen.spec.Paths["/users"]; !exists {
+		t.Error("Expected operation without an audience to be included")
+	}
+	reports, exists := gen.spec.Paths["/reports"]["get"]
+	if !exists {
+		t.Fatal("Expected partner operation to be included")
+	}
+	if audiences, ok := reports.Extensions["x-audience"].([]string); !ok || len(audiences) != 2 {
+		t.Errorf("Expected x-audience extension, got %v", reports.Extensions["x-audience"])
+	}
+	if _, exists := gen.spec.Paths["/admin/audit"]; exists {
+		t.Error("Expected internal-only operation to be excluded")
+	}
+}
+
+func TestPluginRequest(t *testing.T) {
+	gen := New(&Config{Version: "1.0.0", Audience: "partner"})
+	gen.operations = []OperationDefinition{
+		{
+			Method:      "POST",
+			Path:        "/orders",
+			OperationID: "createOrder",
+			Body:        &SchemaDefinition{Type: "object", Properties: map[string]*SchemaDefinition{"sku": {Type: "string"}}},
+			Responses: map[int]ResponseDefinition{
+				201: {Description: "Order created", Schema: &SchemaDefinition{Type: "object"}},
+			},
+			Timeout:   2 * time.Second,
+			RateLimit: &goop.RateLimit{Requests: 10, Window: time.Minute},
+		},
+		{Method: "GET", Path: "/orders/{id}", Response: &SchemaDefinition{Type: "object"}},
+		{Method: "GET", Path: "/admin/audit", Audiences: []string{"internal"}},
+	}
+	if err := gen.GenerateSpec(); err != nil {
+		t.Fatalf("Failed to generate spec: %v", err)
+	}
+
+	req := gen.PluginRequest(map[string]string{"upstream": "orders"})
+	if req.ProtocolVersion != "1" || req.Options["upstream"] != "orders" || req.Spec != gen.spec {
+		t.Errorf("Unexpected request header %+v", req)
+	}
+	if len(req.Operations) != 2 {
+		t.Fatalf("Expected 2 operations visible to partner, got %d", len(req.Operations))
+	}
+
+	create := req.Operations[0]
+	if create.OperationID != "createOrder" || create.Body == nil || create.Body.Properties["sku"].Type != "string" {
+		t.Errorf("Unexpected operation %+v", create)
+	}
+	if create.Responses["201"].Description != "Order created" || create.Responses["201"].Schema == nil {
+		t.Errorf("Expected 201 response, got %+v", create.Responses)
+	}
+	if create.TimeoutMs != 2000 || create.RateLimit == nil || create.RateLimit.WindowMs != 60000 {
+		t.Errorf("Expected timeout and rate limit in milliseconds, got %d and %+v", create.TimeoutMs, create.RateLimit)
+	}
+
+	if get := req.Operations[1]; get.Responses["200"].Schema == nil {
+		t.Errorf("Expected legacy response as 200, got %+v", get.Responses)
+	}
+}
+
 func TestWriteSpec(t *testing.T) {
 	tempDir := t.TempDir()
 
@@ -826,3 +917,267 @@ func intPtr(i int) *int {
 func floatPtr(f float64) *float64 {
 	return &f
 }
+
+func TestHandlerDescriptions(t *testing.T) {
+	tempDir := t.TempDir()
+	files := map[string]string{
+		"handlers.go": `package main
+
+// createUser creates a user and sends them a welcome email.
+func createUser(ctx context.Context, params struct{}, query struct{}, body User) (User, error) {
+	return body, nil
+}
+
+// getUser returns a user.
+func getUser(c *gin.Context) {}
+
+func deleteUser(c *gin.Context) {}
+`,
+		"routes.go": `package main
+
+func routes() {
+	createOp := operations.NewSimple().
+		POST("/users").
+		Handler(ginadapter.CreateValidatedHandler(createUser, nil, nil, userSchema, userSchema))
+	getOp := operations.NewSimple().
+		GET("/users/{id}").
+		Description("Fetches one user").
+		Handler(getUser)
+	deleteOp := operations.NewSimple().
+		DELETE("/users/{id}").
+		Handler(deleteUser)
+}
+`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("Failed to create %s: %v", name, err)
+		}
+	}
+
+	gen := New(&Config{InputDir: tempDir})
+	if err := gen.ScanOperations(); err != nil {
+		t.Fatalf("Failed to scan operations: %v", err)
+	}
+	if err := gen.GenerateSpec(); err != nil {
+		t.Fatalf("Failed to generate spec: %v", err)
+	}
+
+	expected := map[string]string{
+		"post /users":        "Creates a user and sends them a welcome email.",
+		"get /users/{id}":    "Fetches one user",
+		"delete /users/{id}": "",
+	}
+	for key, description := range expected {
+		method, path, _ := strings.Cut(key, " ")
+		op, ok := gen.spec.Paths[path][method]
+		if !ok {
+			t.Fatalf("Expected operation %s", key)
+		}
+		if op.Description != description {
+			t.Errorf("%s: expected description %q, got %q", key, description, op.Description)
+		}
+	}
+}
+
+func TestExamplesFromTests(t *testing.T) {
+	tempDir := t.TempDir()
+	files := map[string]string{
+		"routes.go": `package main
+
+var createUserOp = operations.NewSimple().
+	POST("/users").
+	WithBody(validators.Object(map[string]interface{}{
+		"name": validators.String().Required(),
+	})).
+	WithSuccessResponse(201, validators.Object(map[string]interface{}{
+		"id":   validators.String(),
+		"name": validators.String(),
+	}), "User created").
+	WithBadRequestError(errorSchema)
+`,
+		"routes_test.go": `package main
+
+func TestCreateUser(t *testing.T) {
+	// goop:example POST /users
+	tests := []struct {
+		name   string
+		body   string
+		status int
+		want   string
+	}{
+		{"valid user", ` + "`" + `{"name": "Ada"}` + "`" + `, http.StatusCreated, ` + "`" + `{"id": "u1", "name": "Ada"}` + "`" + `},
+		{name: "missing name", body: "{}", status: 400, want: ` + "`" + `{"error": "bad_request"}` + "`" + `},
+		{name: "teapot", body: "{}", status: 418, want: "{}"},
+	}
+
+	// Unmarked tables are ignored
+	others := []struct{ name, body string }{{"other", "{}"}}
+}
+`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("Failed to create %s: %v", name, err)
+		}
+	}
+
+	generate := func(fromTests bool) operations.OpenAPIOperation {
+		gen := New(&Config{InputDir: tempDir, ExamplesFromTests: fromTests})
+		if err := gen.ScanOperations(); err != nil {
+			t.Fatalf("Failed to scan operations: %v", err)
+		}
+		if err := gen.GenerateSpec(); err != nil {
+			t.Fatalf("Failed to generate spec: %v", err)
+		}
+		return gen.spec.Paths["/users"]["post"]
+	}
+
+	op := generate(false)
+	if examples := op.RequestBody.Content["application/json"].Examples; examples != nil {
+		t.Errorf("Expected no examples without ExamplesFromTests, got %v", examples)
+	}
+
+	op = generate(true)
+	requestExamples := op.RequestBody.Content["application/json"].Examples
+	if len(requestExamples) != 1 {
+		t.Fatalf("Expected only the successful case as a request example, got %v", requestExamples)
+	}
+	if name := requestExamples["valid user"].Value.(map[string]interface{})["name"]; name != "Ada" {
+		t.Errorf("Expected request example name Ada, got %v", name)
+	}
+
+	created := op.Responses["201"].Content["application/json"].Examples["valid user"]
+	if id := created.Value.(map[string]interface{})["id"]; id != "u1" {
+		t.Errorf("Expected 201 example id u1, got %v", id)
+	}
+	badRequest := op.Responses["400"].Content["application/json"].Examples["missing name"]
+	if errorCode := badRequest.Value.(map[string]interface{})["error"]; errorCode != "bad_request" {
+		t.Errorf("Expected 400 example error bad_request, got %v", errorCode)
+	}
+	if _, ok := op.Responses["418"]; ok {
+		t.Error("Expected cases with undocumented statuses to be skipped")
+	}
+}
+
+func TestCoverage(t *testing.T) {
+	tempDir := t.TempDir()
+	content := `package main
+
+func setupRoutes(engine *gin.Engine, router *ginadapter.GinRouter) {
+	engine.GET("/health", func(c *gin.Context) {})
+	engine.GET("/openapi.json", router.ServeSpec(openAPIGen))
+
+	v1 := engine.Group("/v1")
+	{
+		v1.POST("/users", AuthMiddleware(), createUser)
+		v1.GET("/users/:id", getUser)
+		admin := v1.Group("/admin")
+		admin.Handle("delete", "/cache", clearCache)
+	}
+	engine.Any("/legacy/*rest", proxy)
+
+	createOp := operations.NewSimple().
+		POST("/v2/users").
+		Handler(createUserV2)
+	getOp := operations.NewSimple().
+		GET("/v1/users/{id}").
+		Handler(nil)
+	router.Register(createOp, getOp)
+}
+`
+	if err := os.WriteFile(filepath.Join(tempDir, "routes.go"), []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to create routes.go: %v", err)
+	}
+
+	gen := New(&Config{InputDir: tempDir})
+	if err := gen.ScanOperations(); err != nil {
+		t.Fatalf("Failed to scan operations: %v", err)
+	}
+
+	report := gen.Coverage([]string{"/openapi.json"})
+	if report.Operations != 2 {
+		t.Errorf("Expected 2 operations, got %d", report.Operations)
+	}
+	var routes []string
+	for _, route := range report.Routes {
+		routes = append(routes, route.Method+" "+route.Path)
+	}
+	expectedRoutes := []string{"GET /health", "ANY /legacy/{rest}", "DELETE /v1/admin/cache", "POST /v1/users", "GET /v1/users/{id}"}
+	if strings.Join(routes, ", ") != strings.Join(expectedRoutes, ", ") {
+		t.Errorf("Expected routes %v, got %v", expectedRoutes, routes)
+	}
+
+	var undocumented []string
+	for _, route := range report.Undocumented {
+		undocumented = append(undocumented, route.Method+" "+route.Path)
+	}
+	expectedUndocumented := []string{"GET /health", "ANY /legacy/{rest}", "DELETE /v1/admin/cache", "POST /v1/users"}
+	if strings.Join(undocumented, ", ") != strings.Join(expectedUndocumented, ", ") {
+		t.Errorf("Expected undocumented routes %v, got %v", expectedUndocumented, undocumented)
+	}
+	if line := report.Routes[0].LineNumber; line != 4 {
+		t.Errorf("Expected /health on line 4, got %d", line)
+	}
+}
+
+func TestDescriptionFromFile(t *testing.T) {
+	tempDir := t.TempDir()
+	files := map[string]string{
+		"go.mod":                "module example.com/orders\n",
+		"docs/orders/create.md": "\nCreates an order.\n\n## Payment\n\nCards are charged when the order ships.\n",
+		"api/orders.go": `package api
+
+var createOp = operations.NewSimple().
+	POST("/orders").
+	Summary("Create an order").
+	DescriptionFromFile("docs/orders/create.md").
+	Handler(createOrder)
+
+var listOp = operations.NewSimple().
+	GET("/orders").
+	DescriptionFromFile("docs/orders/list.md").
+	Description("Lists orders").
+	Handler(listOrders)
+`,
+	}
+	for name, content := range files {
+		target := filepath.Join(tempDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			t.Fatalf("Failed to create directory for %s: %v", name, err)
+		}
+		if err := os.WriteFile(target, []byte(content), 0o644); err != nil {
+			t.Fatalf("Failed to create %s: %v", name, err)
+		}
+	}
+
+	gen := New(&Config{InputDir: filepath.Join(tempDir, "api")})
+	if err := gen.ScanOperations(); err != nil {
+		t.Fatalf("Failed to scan operations: %v", err)
+	}
+	if err := gen.GenerateSpec(); err != nil {
+		t.Fatalf("Failed to generate spec: %v", err)
+	}
+
+	expected := "Creates an order.\n\n## Payment\n\nCards are charged when the order ships."
+	if got := gen.Spec().Paths["/orders"]["post"].Description; got != expected {
+		t.Errorf("Expected description from the Markdown file, got %q", got)
+	}
+	// A later Description replaces the file
+	if got := gen.Spec().Paths["/orders"]["get"].Description; got != "Lists orders" {
+		t.Errorf("Expected the literal description, got %q", got)
+	}
+
+	t.Run("Missing file", func(t *testing.T) {
+		if err := os.Remove(filepath.Join(tempDir, "docs", "orders", "create.md")); err != nil {
+			t.Fatalf("Failed to remove description: %v", err)
+		}
+		gen := New(&Config{InputDir: filepath.Join(tempDir, "api")})
+		if err := gen.ScanOperations(); err != nil {
+			t.Fatalf("Failed to scan operations: %v", err)
+		}
+		if err := gen.GenerateSpec(); err == nil || !strings.Contains(err.Error(), "POST /orders") {
+			t.Errorf("Expected an error naming the operation, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,243 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/picogrid/go-op/operations"
+)
+
+// ExampleMarker is the comment that marks a table-driven test whose cases document an operation
+// It is followed by the operation ID or by the method and path, as in
+//
+//	// goop:example POST /users
+//	tests := []struct{ name, body, want string; status int }{...}
+const ExampleMarker = "goop:example"
+
+// TestExample is a request/response pair taken from one case of a marked table-driven test
+type TestExample struct {
+	OperationID string
+	Method      string
+	Path        string
+	Name        string      // Case name, used as the example name
+	Status      int         // Expected status code (0 means the operation's success code)
+	Request     interface{} // Request body, nil if the case has none
+	Response    interface{} // Expected response body, nil if the case has none
+	SourceFile  string
+	LineNumber  int
+}
+
+// Table fields recognised in test cases, by lower-cased field name
+var (
+	exampleNameFields     = map[string]bool{"name": true, "desc": true, "description": true}
+	exampleRequestFields  = map[string]bool{"request": true, "req": true, "body": true, "input": true}
+	exampleResponseFields = map[string]bool{"response": true, "resp": true, "want": true, "wantbody": true, "expected": true}
+	exampleStatusFields   = map[string]bool{"status": true, "code": true, "statuscode": true, "wantstatus": true, "wantcode": true}
+)
+
+// httpStatusNames maps net/http status constants to their codes, for constants named after
+// their status text such as http.StatusCreated and http.StatusNotFound
+var httpStatusNames = func() map[string]int {
+	names := make(map[string]int)
+	replacer := strings.NewReplacer(" ", "", "-", "", "'", "")
+	for code := 100; code < 600; code++ {
+		if text := http.StatusText(code); text != "" {
+			names["Status"+replacer.Replace(text)] = code
+		}
+	}
+	return names
+}()
+
+// ExtractTestExamples returns the examples documented by the marked table-driven tests in file
+func (a *ASTAnalyzer) ExtractTestExamples(file *ast.File, filename string) []TestExample {
+	// Markers apply to the table declared on the line after them
+	targets := make(map[int]string)
+	for _, group := range file.Comments {
+		for _, comment := range group.List {
+			text := strings.TrimSpace(strings.TrimPrefix(comment.Text, "//"))
+			if target, ok := strings.CutPrefix(text, ExampleMarker); ok && (target == "" || target[0] == ' ') {
+				targets[a.fileSet.Position(comment.End()).Line+1] = strings.TrimSpace(target)
+			}
+		}
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+
+	var examples []TestExample
+	ast.Inspect(file, func(n ast.Node) bool {
+		table, ok := n.(*ast.CompositeLit)
+		if !ok {
+			return true
+		}
+		arrayType, ok := table.Type.(*ast.ArrayType)
+		if !ok {
+			return true
+		}
+		line := a.fileSet.Position(table.Pos()).Line
+		target, ok := targets[line]
+		if !ok {
+			return true
+		}
+		delete(targets, line)
+
+		base := TestExample{SourceFile: filename}
+		if method, path, found := strings.Cut(target, " "); found {
+			base.Method, base.Path = strings.ToUpper(method), strings.TrimSpace(path)
+		} else {
+			base.OperationID = target
+		}
+
+		// Unkeyed cases are matched to the fields of the table's struct type by position
+		var fields []string
+		if structType, ok := arrayType.Elt.(*ast.StructType); ok {
+			for _, field := range structType.Fields.List {
+				for _, name := range field.Names {
+					fields = append(fields, name.Name)
+				}
+			}
+		}
+
+		for i, elt := range table.Elts {
+			row, ok := elt.(*ast.CompositeLit)
+			if !ok {
+				continue
+			}
+			example := base
+			example.Name = "case" + strconv.Itoa(i+1)
+			example.LineNumber = a.fileSet.Position(row.Pos()).Line
+			for j, value := range row.Elts {
+				field := ""
+				if kv, ok := value.(*ast.KeyValueExpr); ok {
+					if ident, ok := kv.Key.(*ast.Ident); ok {
+						field = ident.Name
+					}
+					value = kv.Value
+				} else if j < len(fields) {
+					field = fields[j]
+				}
+				a.setTestExampleField(&example, strings.ToLower(field), value)
+			}
+			if example.Request != nil || example.Response != nil {
+				examples = append(examples, example)
+			}
+		}
+		return false
+	})
+
+	if a.verbose {
+		for line, target := range targets {
+			fmt.Printf("[VERBOSE] Warning: no table-driven test follows the %s %s marker at %s:%d\n", ExampleMarker, target, filename, line-1)
+		}
+	}
+	return examples
+}
+
+// setTestExampleField records value as the recognised table field of a test case
+func (a *ASTAnalyzer) setTestExampleField(example *TestExample, field string, value ast.Expr) {
+	switch {
+	case exampleNameFields[field]:
+		if name := a.extractTestString(value); name != "" {
+			example.Name = name
+		}
+	case exampleRequestFields[field]:
+		example.Request = a.extractTestBody(value)
+	case exampleResponseFields[field]:
+		example.Response = a.extractTestBody(value)
+	case exampleStatusFields[field]:
+		if selector, ok := value.(*ast.SelectorExpr); ok {
+			example.Status = httpStatusNames[selector.Sel.Name]
+		} else {
+			example.Status = a.extractIntLiteral(value)
+		}
+	}
+}
+
+// extractTestString returns the value of an interpreted or raw string literal
+func (a *ASTAnalyzer) extractTestString(expr ast.Expr) string {
+	if lit, ok := expr.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+		if value, err := strconv.Unquote(lit.Value); err == nil {
+			return value
+		}
+	}
+	return ""
+}
+
+// extractTestBody returns a body given as a JSON string or as a map or slice literal
+// Strings that are not JSON are documented as-is; other expressions cannot be evaluated and give nil.
+func (a *ASTAnalyzer) extractTestBody(expr ast.Expr) interface{} {
+	if lit, ok := expr.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+		text := a.extractTestString(lit)
+		if text == "" {
+			return nil
+		}
+		var value interface{}
+		if err := json.Unmarshal([]byte(text), &value); err == nil {
+			return value
+		}
+		return text
+	}
+	return a.extractLiteralValue(expr)
+}
+
+// matches reports whether the example documents op
+func (e TestExample) matches(op OperationDefinition) bool {
+	if e.OperationID != "" {
+		return e.OperationID == op.OperationID
+	}
+	return e.Method == strings.ToUpper(op.Method) && e.Path == op.Path
+}
+
+// addTestExamples documents the examples taken from tests of op on its request body and responses
+// Request bodies are only documented from cases expecting success, so rejected requests are not
+// presented as examples to follow. Cases expecting an undocumented status are skipped.
+func (g *Generator) addTestExamples(op OperationDefinition, openAPIOp *operations.OpenAPIOperation) {
+	successCode := ""
+	for code := range openAPIOp.Responses {
+		if strings.HasPrefix(code, "2") && (successCode == "" || code < successCode) {
+			successCode = code
+		}
+	}
+
+	for _, example := range g.testExamples {
+		if !example.matches(op) {
+			continue
+		}
+		code := successCode
+		if example.Status != 0 {
+			code = strconv.Itoa(example.Status)
+		}
+		response, documented := openAPIOp.Responses[code]
+		if !documented {
+			if g.config.Verbose {
+				fmt.Printf("[VERBOSE] Skipping example %q from %s:%d: status %s is not documented for %s %s\n",
+					example.Name, example.SourceFile, example.LineNumber, code, op.Method, op.Path)
+			}
+			continue
+		}
+
+		if example.Request != nil && strings.HasPrefix(code, "2") && openAPIOp.RequestBody != nil {
+			addMediaExample(openAPIOp.RequestBody.Content, example.Name, example.Request)
+		}
+		if example.Response != nil {
+			addMediaExample(response.Content, example.Name, example.Response)
+			openAPIOp.Responses[code] = response
+		}
+	}
+}
+
+// addMediaExample adds a named example to every media type of content
+func addMediaExample(content map[string]operations.OpenAPIMediaType, name string, value interface{}) {
+	for mediaType, media := range content {
+		if media.Examples == nil {
+			media.Examples = make(map[string]operations.OpenAPIExample)
+		}
+		media.Examples[name] = operations.OpenAPIExample{Summary: name, Value: value}
+		content[mediaType] = media
+	}
+}
@@ -0,0 +1,377 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// validatorPart describes one request component an operation can declare a schema for
+type validatorPart struct {
+	suffix string
+	label  string
+	tag    string
+	schema func(op OperationDefinition) *SchemaDefinition
+}
+
+var validatorParts = []validatorPart{
+	{"Params", "path parameters", "uri", func(op OperationDefinition) *SchemaDefinition { return op.Params }},
+	{"Query", "query parameters", "form", func(op OperationDefinition) *SchemaDefinition { return op.Query }},
+	{"Headers", "request headers", "header", func(op OperationDefinition) *SchemaDefinition { return op.Headers }},
+	{"Body", "request body", "", func(op OperationDefinition) *SchemaDefinition { return op.Body }},
+}
+
+// GenerateValidators emits Go source with a struct type and a reflection-free Validate method
+// for every object schema declared by the discovered operations.
+// The structs embed goop.Generated, so handlers created with CreateValidatedHandler recognise them
+// as goop.GeneratedValidator and call Validate instead of running the dynamic schema validator.
+func (g *Generator) GenerateValidators(packageName string) ([]byte, error) {
+	w := &validatorWriter{imports: map[string]bool{}, names: map[string]bool{}}
+
+	ops := make([]OperationDefinition, len(g.operations))
+	copy(ops, g.operations)
+	sort.SliceStable(ops, func(i, j int) bool {
+		if ops[i].Path != ops[j].Path {
+			return ops[i].Path < ops[j].Path
+		}
+		return ops[i].Method < ops[j].Method
+	})
+
+	for _, op := range ops {
+		base := operationTypeName(op)
+		for _, part := range validatorParts {
+			schema := part.schema(op)
+			if schema == nil || schema.Type != "object" || len(schema.Properties) == 0 {
+				continue
+			}
+			doc := fmt.Sprintf("the %s of %s %s", part.label, op.Method, op.Path)
+			w.writeStruct(base+part.suffix, doc, part.tag, schema)
+		}
+	}
+
+	var out bytes.Buffer
+	out.WriteString("// Code generated by goop codegen-validators. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&out, "package %s\n\n", packageName)
+
+	imports := make([]string, 0, len(w.imports))
+	for path := range w.imports {
+		imports = append(imports, path)
+	}
+	sort.Strings(imports)
+	out.WriteString("import (\n")
+	for _, path := range imports {
+		fmt.Fprintf(&out, "\t%s\n", strconv.Quote(path))
+	}
+	if len(imports) > 0 {
+		out.WriteString("\n")
+	}
+	out.WriteString("\tgoop \"github.com/picogrid/go-op\"\n)\n\n")
+
+	if len(w.patterns) > 0 {
+		out.WriteString("// Patterns are compiled once at package initialization\nvar (\n")
+		for i, pattern := range w.patterns {
+			fmt.Fprintf(&out, "\tpattern%d = regexp.MustCompile(%s)\n", i, strconv.Quote(pattern))
+		}
+		out.WriteString(")\n\n")
+	}
+
+	out.Write(w.body.Bytes())
+
+	formatted, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated validators: %w", err)
+	}
+	return formatted, nil
+}
+
+// validatorWriter accumulates generated types, patterns, and imports
+type validatorWriter struct {
+	body     bytes.Buffer
+	imports  map[string]bool
+	patterns []string
+	names    map[string]bool
+}
+
+// writeStruct emits a struct type for an object schema and its Validate method
+// Nested object properties are emitted as their own types
+func (w *validatorWriter) writeStruct(name, doc, tag string, schema *SchemaDefinition) {
+	name = w.uniqueName(name)
+
+	required := make(map[string]bool, len(schema.Required))
+	for _, field := range schema.Required {
+		required[field] = true
+	}
+
+	properties := make([]string, 0, len(schema.Properties))
+	for property := range schema.Properties {
+		properties = append(properties, property)
+	}
+	sort.Strings(properties)
+
+	type field struct {
+		property string
+		goName   string
+		goType   string
+		schema   *SchemaDefinition
+		required bool
+	}
+	fields := make([]field, 0, len(properties))
+	// The embedded goop.Generated marker takes the field name Generated
+	usedNames := map[string]bool{"Generated": true}
+	for _, property := range properties {
+		propertySchema := schema.Properties[property]
+		goName := exportedIdentifier(property)
+		for usedNames[goName] {
+			goName += "_"
+		}
+		usedNames[goName] = true
+
+		goType := w.goType(name+goName, property, propertySchema)
+		if !required[property] && isScalarOrStruct(propertySchema) {
+			goType = "*" + goType
+		}
+		fields = append(fields, field{property, goName, goType, propertySchema, required[property]})
+	}
+
+	fmt.Fprintf(&w.body, "// %s holds %s\ntype %s struct {\n\tgoop.Generated\n\n", name, doc, name)
+	for _, f := range fields {
+		tags := fmt.Sprintf(`json:"%s`, f.property)
+		if !f.required {
+			tags += ",omitempty"
+		}
+		tags += `"`
+		if tag != "" {
+			tags = fmt.Sprintf(`%s:"%s" %s`, tag, f.property, tags)
+		}
+		fmt.Fprintf(&w.body, "\t%s %s `%s`\n", f.goName, f.goType, tags)
+	}
+	w.body.WriteString("}\n\n")
+
+	fmt.Fprintf(&w.body, "// Validate checks %s against its schema without reflection\n", name)
+	fmt.Fprintf(&w.body, "func (v *%s) Validate() error {\n", name)
+	w.body.WriteString("\tvar details []goop.ValidationError\n")
+	for _, f := range fields {
+		if !hasChecks(f.schema) {
+			continue
+		}
+		access := "v." + f.goName
+		if strings.HasPrefix(f.goType, "*") {
+			fmt.Fprintf(&w.body, "\tif %s != nil {\n", access)
+			w.writeChecks("*"+access, strconv.Quote(f.property), f.schema)
+			w.body.WriteString("\t}\n")
+			continue
+		}
+		w.writeChecks(access, strconv.Quote(f.property), f.schema)
+	}
+	w.body.WriteString("\tif len(details) > 0 {\n")
+	w.body.WriteString("\t\treturn goop.NewNestedValidationError(\"\", nil, \"object validation failed\", details)\n")
+	w.body.WriteString("\t}\n\treturn nil\n}\n\n")
+}
+
+// goType returns the Go type for a schema, emitting nested struct types as needed
+func (w *validatorWriter) goType(typeName, property string, schema *SchemaDefinition) string {
+	switch schema.Type {
+	case "string":
+		return "string"
+	case "integer":
+		return "int64"
+	case "number":
+		return "float64"
+	case "boolean":
+		return "bool"
+	case "array":
+		if schema.Items == nil {
+			return "[]interface{}"
+		}
+		return "[]" + w.goType(typeName+"Item", property, schema.Items)
+	case "object":
+		if len(schema.Properties) == 0 {
+			return "map[string]interface{}"
+		}
+		nested := w.uniqueName(typeName)
+		// Reserve the name now so the nested type keeps it when emitted
+		delete(w.names, nested)
+		w.writeStruct(nested, fmt.Sprintf("the %s property", property), "", schema)
+		return nested
+	default:
+		return "interface{}"
+	}
+}
+
+// writeChecks emits constraint checks for a value expression, reporting failures under field
+func (w *validatorWriter) writeChecks(expr, field string, schema *SchemaDefinition) {
+	fail := func(condition, message string) {
+		fmt.Fprintf(&w.body, "\tif %s {\n\t\tdetails = append(details, *goop.NewValidationError(%s, %s, %s))\n\t}\n",
+			condition, field, expr, strconv.Quote(message))
+	}
+
+	switch schema.Type {
+	case "string":
+		// minLength and maxLength count characters; the byte limits count UTF-8 bytes
+		if schema.MinLength != nil && *schema.MinLength > 0 {
+			w.imports["unicode/utf8"] = true
+			fail(fmt.Sprintf("utf8.RuneCountInString(%s) < %d", expr, *schema.MinLength),
+				fmt.Sprintf("string is too short, minimum length is %d characters", *schema.MinLength))
+		}
+		if schema.MaxLength != nil {
+			w.imports["unicode/utf8"] = true
+			fail(fmt.Sprintf("utf8.RuneCountInString(%s) > %d", expr, *schema.MaxLength),
+				fmt.Sprintf("string is too long, maximum length is %d characters", *schema.MaxLength))
+		}
+		if schema.MinBytes != nil && *schema.MinBytes > 0 {
+			fail(fmt.Sprintf("len(%s) < %d", expr, *schema.MinBytes),
+				fmt.Sprintf("string is too short, minimum length is %d", *schema.MinBytes))
+		}
+		if schema.MaxBytes != nil {
+			fail(fmt.Sprintf("len(%s) > %d", expr, *schema.MaxBytes),
+				fmt.Sprintf("string is too large, maximum size is %d bytes", *schema.MaxBytes))
+		}
+		if schema.Pattern != "" {
+			fail(fmt.Sprintf("!%s.MatchString(%s)", w.pattern(schema.Pattern), expr), "string does not match required pattern")
+		}
+		if schema.Format == "email" {
+			emailPattern := `^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`
+			fail(fmt.Sprintf("len(%s) > 254 || !%s.MatchString(%s)", expr, w.pattern(emailPattern), expr), "invalid email format")
+		}
+		if value, ok := schema.Const.(string); ok {
+			fail(fmt.Sprintf("%s != %s", expr, strconv.Quote(value)), fmt.Sprintf("value must be exactly '%s'", value))
+		}
+	case "number", "integer":
+		number := expr
+		if schema.Type == "integer" {
+			number = "float64(" + expr + ")"
+		}
+		if schema.Minimum != nil {
+			fail(fmt.Sprintf("%s < %v", number, *schema.Minimum), fmt.Sprintf("number must be at least %v", *schema.Minimum))
+		}
+		if schema.Maximum != nil {
+			fail(fmt.Sprintf("%s > %v", number, *schema.Maximum), fmt.Sprintf("number must be at most %v", *schema.Maximum))
+		}
+		if schema.ExclusiveMinimum != nil {
+			fail(fmt.Sprintf("%s <= %v", number, *schema.ExclusiveMinimum), fmt.Sprintf("number must be greater than %v", *schema.ExclusiveMinimum))
+		}
+		if schema.ExclusiveMaximum != nil {
+			fail(fmt.Sprintf("%s >= %v", number, *schema.ExclusiveMaximum), fmt.Sprintf("number must be less than %v", *schema.ExclusiveMaximum))
+		}
+		if schema.MultipleOf != nil && *schema.MultipleOf != 0 {
+			w.imports["math"] = true
+			fail(fmt.Sprintf("math.Mod(%s, %v) != 0", number, *schema.MultipleOf), fmt.Sprintf("number must be a multiple of %v", *schema.MultipleOf))
+		}
+	case "array":
+		if schema.UniqueItems != nil && *schema.UniqueItems && schema.Items != nil && isScalarType(schema.Items.Type) {
+			fmt.Fprintf(&w.body, "\tif len(%s) > 1 {\n", expr)
+			fmt.Fprintf(&w.body, "\t\tseen := make(map[%s]bool, len(%s))\n", scalarGoType(schema.Items.Type), expr)
+			fmt.Fprintf(&w.body, "\t\tfor _, item := range %s {\n\t\t\tif seen[item] {\n", expr)
+			fmt.Fprintf(&w.body, "\t\t\t\tdetails = append(details, *goop.NewValidationError(%s, %s, \"array items must be unique\"))\n", field, expr)
+			w.body.WriteString("\t\t\t\tbreak\n\t\t\t}\n\t\t\tseen[item] = true\n\t\t}\n\t}\n")
+		}
+		if schema.Items != nil && hasChecks(schema.Items) {
+			w.imports["strconv"] = true
+			fmt.Fprintf(&w.body, "\tfor i := range %s {\n", expr)
+			w.writeChecks(fmt.Sprintf("%s[i]", expr), field+` + "[" + strconv.Itoa(i) + "]"`, schema.Items)
+			w.body.WriteString("\t}\n")
+		}
+	case "object":
+		if len(schema.Properties) > 0 {
+			fmt.Fprintf(&w.body, "\tif err := %s.Validate(); err != nil {\n", strings.TrimPrefix(expr, "*"))
+			fmt.Fprintf(&w.body, "\t\tdetails = append(details, goop.WithField(%s, err))\n\t}\n", field)
+		}
+	}
+}
+
+// pattern registers a regular expression and returns the variable holding it
+func (w *validatorWriter) pattern(expr string) string {
+	w.imports["regexp"] = true
+	for i, existing := range w.patterns {
+		if existing == expr {
+			return fmt.Sprintf("pattern%d", i)
+		}
+	}
+	w.patterns = append(w.patterns, expr)
+	return fmt.Sprintf("pattern%d", len(w.patterns)-1)
+}
+
+// uniqueName returns name, or name with a numeric suffix when it is already taken
+func (w *validatorWriter) uniqueName(name string) string {
+	candidate := name
+	for i := 2; w.names[candidate]; i++ {
+		candidate = fmt.Sprintf("%s%d", name, i)
+	}
+	w.names[candidate] = true
+	return candidate
+}
+
+// operationTypeName derives a type name prefix from the operation ID, or the method and path
+func operationTypeName(op OperationDefinition) string {
+	if op.OperationID != "" {
+		return exportedIdentifier(op.OperationID)
+	}
+	return exportedIdentifier(strings.ToLower(op.Method) + " " + op.Path)
+}
+
+// exportedIdentifier converts a name like "user_id" or "/users/{id}" into an exported Go identifier
+func exportedIdentifier(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			b.WriteRune(unicode.ToUpper(r))
+			upper = false
+			continue
+		}
+		b.WriteRune(r)
+	}
+	result := b.String()
+	if result == "" || unicode.IsDigit(rune(result[0])) {
+		result = "Field" + result
+	}
+	return result
+}
+
+func isScalarType(schemaType string) bool {
+	return schemaType == "string" || schemaType == "number" || schemaType == "integer" || schemaType == "boolean"
+}
+
+func scalarGoType(schemaType string) string {
+	switch schemaType {
+	case "string":
+		return "string"
+	case "integer":
+		return "int64"
+	case "boolean":
+		return "bool"
+	default:
+		return "float64"
+	}
+}
+
+// isScalarOrStruct reports whether optional fields of this schema need a pointer to represent absence
+func isScalarOrStruct(schema *SchemaDefinition) bool {
+	return isScalarType(schema.Type) || (schema.Type == "object" && len(schema.Properties) > 0)
+}
+
+// hasChecks reports whether validating a value of this schema emits any code
+func hasChecks(schema *SchemaDefinition) bool {
+	switch schema.Type {
+	case "string":
+		return (schema.MinLength != nil && *schema.MinLength > 0) || schema.MaxLength != nil ||
+			(schema.MinBytes != nil && *schema.MinBytes > 0) || schema.MaxBytes != nil ||
+			schema.Pattern != "" || schema.Format == "email" || schema.Const != nil
+	case "number", "integer":
+		return schema.Minimum != nil || schema.Maximum != nil || schema.ExclusiveMinimum != nil ||
+			schema.ExclusiveMaximum != nil || schema.MultipleOf != nil
+	case "object":
+		return len(schema.Properties) > 0
+	case "array":
+		return schema.Items != nil && (hasChecks(schema.Items) || (schema.UniqueItems != nil && *schema.UniqueItems))
+	}
+	return false
+}
@@ -0,0 +1,106 @@
+package generator
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerateValidators(t *testing.T) {
+	tempDir := t.TempDir()
+
+	goFile := filepath.Join(tempDir, "api.go")
+	goContent := `
+package main
+
+import "github.com/picogrid/go-op/operations"
+import "github.com/picogrid/go-op/validators"
+
+var createUserOperation = operations.NewSimple().
+	POST("/users/{org_id}").
+	WithParams(validators.Object(map[string]interface{}{
+		"org_id": validators.String().Min(3).Required(),
+	})).
+	WithBody(validators.Object(map[string]interface{}{
+		"email": validators.String().Email().Required(),
+		"username": validators.String().Min(3).Max(20).Pattern("^[a-z0-9_]+$").Required(),
+		"age": validators.Number().Min(18).Optional(),
+		"display_name": validators.String().MinRunes(1).MaxRunes(50).Max(200).Optional(),
+		"address": validators.Object(map[string]interface{}{
+			"city": validators.String().Min(1).Required(),
+		}).Optional(),
+	}))
+`
+	if err := os.WriteFile(goFile, []byte(goContent), 0o644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	gen := New(&Config{InputDir: tempDir})
+	if err := gen.ScanOperations(); err != nil {
+		t.Fatalf("Failed to scan operations: %v", err)
+	}
+
+	source, err := gen.GenerateValidators("api")
+	if err != nil {
+		t.Fatalf("Failed to generate validators: %v", err)
+	}
+	code := string(source)
+
+	t.Run("Output is valid Go", func(t *testing.T) {
+		if _, err := parser.ParseFile(token.NewFileSet(), "validators_gen.go", source, parser.AllErrors); err != nil {
+			t.Fatalf("Generated code does not parse: %v\n%s", err, code)
+		}
+		if !strings.HasPrefix(code, "// Code generated by goop codegen-validators. DO NOT EDIT.") {
+			t.Error("Expected generated code header")
+		}
+	})
+
+	t.Run("Types and tags", func(t *testing.T) {
+		for _, expected := range []string{
+			"type PostUsersOrgIdParams struct",
+			"type PostUsersOrgIdBody struct {\n\tgoop.Generated\n",
+			"type PostUsersOrgIdBodyAddress struct",
+			"`uri:\"org_id\" json:\"org_id\"`",
+			"*float64                   `json:\"age,omitempty\"`",
+			"*PostUsersOrgIdBodyAddress `json:\"address,omitempty\"`",
+			"func (v *PostUsersOrgIdBody) Validate() error",
+		} {
+			if !strings.Contains(code, expected) {
+				t.Errorf("Expected generated code to contain %q\n%s", expected, code)
+			}
+		}
+	})
+
+	t.Run("Constraint checks", func(t *testing.T) {
+		for _, expected := range []string{
+			"len(v.OrgId) < 3",
+			"len(v.Username) > 20",
+			"regexp.MustCompile(\"^[a-z0-9_]+$\")",
+			"*v.Age < 18",
+			"utf8.RuneCountInString(*v.DisplayName) > 50",
+			"len(*v.DisplayName) > 200",
+			"v.Address.Validate()",
+			"goop.WithField(\"address\", err)",
+		} {
+			if !strings.Contains(code, expected) {
+				t.Errorf("Expected generated code to contain %q\n%s", expected, code)
+			}
+		}
+		if strings.Contains(code, "interface{}") {
+			t.Errorf("Expected no interface{} in generated code\n%s", code)
+		}
+	})
+
+	t.Run("Operation type names fall back to method and path", func(t *testing.T) {
+		name := operationTypeName(OperationDefinition{Method: "GET", Path: "/users/{id}"})
+		if name != "GetUsersId" {
+			t.Errorf("Expected GetUsersId, got %s", name)
+		}
+		if exportedIdentifier("2fa_code") != "Field2faCode" {
+			t.Errorf("Expected identifiers starting with digits to be prefixed, got %s", exportedIdentifier("2fa_code"))
+		}
+	})
+}
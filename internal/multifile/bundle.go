@@ -0,0 +1,300 @@
+package multifile
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Bundle loads the root document at rootFile and resolves its references to other files
+// into a single document. Files under components/<kind>/ become entries of the bundled
+// components section referenced locally; other referenced files are inlined.
+func Bundle(rootFile string) (map[string]interface{}, error) {
+	rootFile, err := filepath.Abs(rootFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve root file: %w", err)
+	}
+	b := &bundler{
+		rootFile:       rootFile,
+		rootDir:        filepath.Dir(rootFile),
+		docs:           make(map[string]interface{}),
+		componentNames: make(map[string]componentName),
+		components:     make(map[string]map[string]interface{}),
+		inlining:       make(map[string]bool),
+	}
+
+	doc, err := b.load(rootFile)
+	if err != nil {
+		return nil, err
+	}
+	root, ok := doc.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s: root document must be an object", rootFile)
+	}
+	b.indexComponents(root)
+
+	resolved, err := b.resolve(root, rootFile)
+	if err != nil {
+		return nil, err
+	}
+	bundled := resolved.(map[string]interface{})
+
+	// Component files replace the root entries that referenced them
+	if len(b.components) > 0 {
+		components, _ := bundled["components"].(map[string]interface{})
+		if components == nil {
+			components = make(map[string]interface{})
+			bundled["components"] = components
+		}
+		for kind, entries := range b.components {
+			section, _ := components[kind].(map[string]interface{})
+			if section == nil {
+				section = make(map[string]interface{})
+				components[kind] = section
+			}
+			for name, value := range entries {
+				section[name] = value
+			}
+		}
+	}
+	return bundled, nil
+}
+
+// componentName identifies an entry of the components section
+type componentName struct {
+	kind string
+	name string
+}
+
+// bundler resolves external references relative to the file containing them
+type bundler struct {
+	rootFile string
+	rootDir  string
+
+	// Parsed documents by absolute file name
+	docs map[string]interface{}
+
+	// Component names of files referenced from the root components section
+	componentNames map[string]componentName
+
+	// Resolved component files by kind and name
+	components map[string]map[string]interface{}
+
+	// References currently being inlined, for cycle detection
+	inlining map[string]bool
+}
+
+// indexComponents names component files after the root entries that reference them,
+// so entries keep their names even when file names differ
+func (b *bundler) indexComponents(root map[string]interface{}) {
+	components, _ := root["components"].(map[string]interface{})
+	for kind, section := range components {
+		entries, _ := section.(map[string]interface{})
+		for name, entry := range entries {
+			ref, ok := refOf(entry)
+			if !ok || strings.Contains(ref, "#") {
+				continue
+			}
+			b.componentNames[filepath.Join(b.rootDir, filepath.FromSlash(ref))] = componentName{kind: kind, name: name}
+		}
+	}
+}
+
+// componentOf returns the component a whole file defines, if any
+func (b *bundler) componentOf(file string) (componentName, bool) {
+	if name, ok := b.componentNames[file]; ok {
+		return name, true
+	}
+	rel, err := filepath.Rel(b.rootDir, file)
+	if err != nil {
+		return componentName{}, false
+	}
+	parts := strings.Split(filepath.ToSlash(rel), "/")
+	if len(parts) != 3 || parts[0] != "components" {
+		return componentName{}, false
+	}
+	return componentName{kind: parts[1], name: strings.TrimSuffix(parts[2], filepath.Ext(parts[2]))}, true
+}
+
+// resolve copies value, declared in file, with its references bundled
+func (b *bundler) resolve(value interface{}, file string) (interface{}, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if ref, ok := refOf(v); ok {
+			return b.resolveRef(v, ref, file)
+		}
+		result := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			resolved, err := b.resolve(item, file)
+			if err != nil {
+				return nil, err
+			}
+			result[key] = resolved
+		}
+		return result, nil
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			resolved, err := b.resolve(item, file)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = resolved
+		}
+		return result, nil
+	default:
+		return value, nil
+	}
+}
+
+// resolveRef bundles the reference object v whose $ref is ref, declared in file
+func (b *bundler) resolveRef(v map[string]interface{}, ref, file string) (interface{}, error) {
+	if strings.Contains(ref, "://") {
+		// Remote references are left for tooling that can fetch them
+		return v, nil
+	}
+
+	target, pointer, _ := strings.Cut(ref, "#")
+	targetFile := file
+	if target != "" {
+		targetFile = filepath.Join(filepath.Dir(file), filepath.FromSlash(target))
+	}
+
+	if targetFile == b.rootFile {
+		return withRef(v, "#"+pointer), nil
+	}
+
+	if component, ok := b.componentOf(targetFile); ok {
+		if err := b.loadComponent(component, targetFile); err != nil {
+			return nil, err
+		}
+		return withRef(v, "#/components/"+component.kind+"/"+escapePointer(component.name)+pointer), nil
+	}
+
+	// Anything else is inlined in place of the reference
+	key := targetFile + "#" + pointer
+	if b.inlining[key] {
+		return nil, fmt.Errorf("%s: circular reference to %s", file, ref)
+	}
+	b.inlining[key] = true
+	defer delete(b.inlining, key)
+
+	doc, err := b.load(targetFile)
+	if err != nil {
+		return nil, err
+	}
+	value, err := lookupPointer(doc, pointer)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid reference %s: %w", file, ref, err)
+	}
+	return b.resolve(value, targetFile)
+}
+
+// loadComponent resolves a component file into the bundled components section once
+func (b *bundler) loadComponent(component componentName, file string) error {
+	entries := b.components[component.kind]
+	if entries == nil {
+		entries = make(map[string]interface{})
+		b.components[component.kind] = entries
+	}
+	if _, ok := entries[component.name]; ok {
+		return nil
+	}
+
+	// Reserve the entry first so components referencing each other terminate
+	entries[component.name] = nil
+	doc, err := b.load(file)
+	if err != nil {
+		return err
+	}
+	resolved, err := b.resolve(doc, file)
+	if err != nil {
+		return err
+	}
+	entries[component.name] = resolved
+	return nil
+}
+
+// load parses file once; JSON files are parsed as YAML, which is a superset
+func (b *bundler) load(file string) (interface{}, error) {
+	if doc, ok := b.docs[file]; ok {
+		return doc, nil
+	}
+	data, err := os.ReadFile(filepath.Clean(file))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	var doc interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+	}
+	doc = normalize(doc)
+	b.docs[file] = doc
+	return doc, nil
+}
+
+// refOf returns the $ref of a reference object
+func refOf(value interface{}) (string, bool) {
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+	ref, ok := m["$ref"].(string)
+	return ref, ok
+}
+
+// withRef copies the reference object v with its $ref replaced, keeping sibling keywords
+func withRef(v map[string]interface{}, ref string) map[string]interface{} {
+	result := make(map[string]interface{}, len(v))
+	for key, value := range v {
+		result[key] = value
+	}
+	result["$ref"] = ref
+	return result
+}
+
+// lookupPointer returns the value at a JSON pointer within doc
+func lookupPointer(doc interface{}, pointer string) (interface{}, error) {
+	if pointer == "" || pointer == "/" {
+		return doc, nil
+	}
+	current := doc
+	for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%q does not name an object member", pointer)
+		}
+		current, ok = m[unescapePointer(token)]
+		if !ok {
+			return nil, fmt.Errorf("%q not found", pointer)
+		}
+	}
+	return current, nil
+}
+
+// normalize converts YAML mappings with non-string keys, such as unquoted status codes, to string keys
+func normalize(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = normalize(item)
+		}
+		return v
+	case map[interface{}]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			result[fmt.Sprint(key)] = normalize(item)
+		}
+		return result
+	case []interface{}:
+		for i, item := range v {
+			v[i] = normalize(item)
+		}
+		return v
+	default:
+		return value
+	}
+}
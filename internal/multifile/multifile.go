@@ -0,0 +1,349 @@
+// Package multifile splits OpenAPI specifications into one file per path item and
+// schema connected by relative $refs, and bundles such layouts back into one document.
+//
+// A specification split with the root file openapi.yaml is laid out as:
+//
+//	openapi.yaml                  top-level fields; path items and schemas are $refs
+//	paths/users_{id}.yaml         one file per path item
+//	components/schemas/User.yaml  one file per component schema
+package multifile
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/picogrid/go-op/operations"
+)
+
+// Directories of split files, relative to the root document
+const (
+	PathsDir   = "paths"
+	SchemasDir = "components/schemas"
+)
+
+// rootKeyOrder is the order top-level fields are written in, matching operations.OpenAPISpec
+var rootKeyOrder = []string{
+	"openapi", "info", "servers", "security", "paths", "components",
+	"tags", "externalDocs", "webhooks", "jsonSchemaDialect",
+}
+
+// Split divides spec into documents keyed by slash-separated paths relative to the root
+// document, which is stored under rootName. Path items and component schemas get their own
+// files with the extension of format, and local references between them become relative $refs.
+func Split(spec interface{}, rootName, format string) (map[string]interface{}, error) {
+	ext, err := extension(format)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := toGeneric(spec)
+	if err != nil {
+		return nil, err
+	}
+	root, ok := doc.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("specification must be an object, got %T", doc)
+	}
+
+	files := make(map[string]interface{})
+
+	// Assign every schema a file first so references between schemas can be rewritten
+	schemaFiles := make(map[string]string)
+	var schemas map[string]interface{}
+	if components, ok := root["components"].(map[string]interface{}); ok {
+		schemas, _ = components["schemas"].(map[string]interface{})
+	}
+	used := make(map[string]bool)
+	for _, name := range sortedKeys(schemas) {
+		schemaFiles[name] = SchemasDir + "/" + uniqueName(safeName(name), used) + ext
+	}
+	for name, schema := range schemas {
+		files[schemaFiles[name]] = rewriteRefs(schema, SchemasDir, rootName, schemaFiles)
+		schemas[name] = map[string]interface{}{"$ref": schemaFiles[name]}
+	}
+
+	if paths, ok := root["paths"].(map[string]interface{}); ok {
+		used := make(map[string]bool)
+		for _, p := range sortedKeys(paths) {
+			file := PathsDir + "/" + uniqueName(pathFileName(p), used) + ext
+			files[file] = rewriteRefs(paths[p], PathsDir, rootName, schemaFiles)
+			paths[p] = map[string]interface{}{"$ref": file}
+		}
+	}
+
+	files[rootName] = root
+	return files, nil
+}
+
+// WriteSplit splits spec and writes the root document to rootFile with the split files
+// alongside it. Files left in the split directories by earlier runs are removed.
+func WriteSplit(spec interface{}, rootFile, format string) error {
+	ext, err := extension(format)
+	if err != nil {
+		return err
+	}
+	files, err := Split(spec, filepath.Base(rootFile), format)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(rootFile)
+	for _, sub := range []string{PathsDir, SchemasDir} {
+		if err := removeStale(filepath.Join(dir, filepath.FromSlash(sub)), ext, files, sub); err != nil {
+			return err
+		}
+	}
+	for _, name := range sortedKeys(files) {
+		if err := WriteDocument(filepath.Join(dir, filepath.FromSlash(name)), files[name], format); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeStale deletes files with ext in dir that are not part of the new layout
+func removeStale(dir, ext string, files map[string]interface{}, sub string) error {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read directory %s: %w", dir, err)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ext {
+			continue
+		}
+		if _, ok := files[sub+"/"+entry.Name()]; ok {
+			continue
+		}
+		if err := os.Remove(filepath.Join(dir, entry.Name())); err != nil {
+			return fmt.Errorf("failed to remove stale file: %w", err)
+		}
+	}
+	return nil
+}
+
+// WriteDocument writes doc to filename in format, creating parent directories as needed
+func WriteDocument(filename string, doc interface{}, format string) error {
+	if err := os.MkdirAll(filepath.Dir(filename), 0o750); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	file, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	defer file.Close()
+	return Encode(file, doc, format)
+}
+
+// Encode writes doc in format; top-level OpenAPI fields are written in specification order
+func Encode(w io.Writer, doc interface{}, format string) error {
+	if root, ok := doc.(map[string]interface{}); ok && isRoot(root) {
+		doc = newOrderedDocument(root)
+	}
+	switch strings.ToLower(format) {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(doc)
+	case "yaml", "yml":
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(doc); err != nil {
+			return err
+		}
+		return encoder.Close()
+	default:
+		return fmt.Errorf("unsupported format: %s (supported: yaml, json)", format)
+	}
+}
+
+// extension returns the file extension for format
+func extension(format string) (string, error) {
+	switch strings.ToLower(format) {
+	case "json":
+		return ".json", nil
+	case "yaml", "yml":
+		return ".yaml", nil
+	default:
+		return "", fmt.Errorf("unsupported format: %s (supported: yaml, json)", format)
+	}
+}
+
+// rewriteRefs copies value with local references made relative to a file in dir
+// Schema references point to the schema's file; other references point into the root document
+func rewriteRefs(value interface{}, dir, rootName string, schemaFiles map[string]string) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			if ref, ok := item.(string); ok && key == "$ref" && strings.HasPrefix(ref, "#") {
+				result[key] = relativeRef(ref, dir, rootName, schemaFiles)
+				continue
+			}
+			result[key] = rewriteRefs(item, dir, rootName, schemaFiles)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			result[i] = rewriteRefs(item, dir, rootName, schemaFiles)
+		}
+		return result
+	default:
+		return value
+	}
+}
+
+// relativeRef converts a local reference to one relative to dir
+func relativeRef(ref, dir, rootName string, schemaFiles map[string]string) string {
+	if pointer, ok := operations.ComponentName(ref); ok {
+		name, rest, _ := strings.Cut(pointer, "/")
+		if file, ok := schemaFiles[unescapePointer(name)]; ok {
+			if rest != "" {
+				return relativePath(dir, file) + "#/" + rest
+			}
+			return relativePath(dir, file)
+		}
+	}
+	return relativePath(dir, rootName) + ref
+}
+
+// relativePath returns the slash-separated path of target relative to dir
+func relativePath(dir, target string) string {
+	rel, err := filepath.Rel(filepath.FromSlash(dir), filepath.FromSlash(target))
+	if err != nil {
+		return target
+	}
+	return filepath.ToSlash(rel)
+}
+
+// pathFileName derives a file name from an API path, e.g. /users/{id} -> users_{id}
+func pathFileName(p string) string {
+	name := strings.Trim(p, "/")
+	if name == "" {
+		return "root"
+	}
+	return safeName(name)
+}
+
+// safeName replaces path separators so name can be used as a single file name
+func safeName(name string) string {
+	return strings.NewReplacer("/", "_", "\\", "_").Replace(name)
+}
+
+// uniqueName returns name, suffixed with a counter if it was already used
+func uniqueName(name string, used map[string]bool) string {
+	candidate := name
+	for i := 2; used[strings.ToLower(candidate)]; i++ {
+		candidate = fmt.Sprintf("%s_%d", name, i)
+	}
+	used[strings.ToLower(candidate)] = true
+	return candidate
+}
+
+// unescapePointer decodes a JSON pointer token
+func unescapePointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+}
+
+// escapePointer encodes a JSON pointer token
+func escapePointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+// toGeneric converts a typed specification to maps and slices via its JSON form
+func toGeneric(spec interface{}) (interface{}, error) {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode specification: %w", err)
+	}
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to decode specification: %w", err)
+	}
+	return doc, nil
+}
+
+// sortedKeys returns the keys of m in order
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// isRoot reports whether doc is an OpenAPI root document
+func isRoot(doc map[string]interface{}) bool {
+	_, ok := doc["openapi"]
+	return ok
+}
+
+// orderedDocument is a mapping whose keys are encoded in a fixed order
+type orderedDocument struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+// newOrderedDocument orders the known top-level fields of root, followed by any others
+func newOrderedDocument(root map[string]interface{}) orderedDocument {
+	doc := orderedDocument{values: root}
+	known := make(map[string]bool, len(rootKeyOrder))
+	for _, key := range rootKeyOrder {
+		known[key] = true
+		if _, ok := root[key]; ok {
+			doc.keys = append(doc.keys, key)
+		}
+	}
+	for _, key := range sortedKeys(root) {
+		if !known[key] {
+			doc.keys = append(doc.keys, key)
+		}
+	}
+	return doc
+}
+
+func (d orderedDocument) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range d.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(d.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func (d orderedDocument) MarshalYAML() (interface{}, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	for _, key := range d.keys {
+		value := &yaml.Node{}
+		if err := value.Encode(d.values[key]); err != nil {
+			return nil, err
+		}
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+	}
+	return node, nil
+}
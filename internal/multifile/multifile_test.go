@@ -0,0 +1,225 @@
+package multifile
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	goop "github.com/picogrid/go-op"
+	"github.com/picogrid/go-op/operations"
+)
+
+func testSpec() *operations.OpenAPISpec {
+	userRef := &goop.OpenAPISchema{Ref: "#/components/schemas/User"}
+	return &operations.OpenAPISpec{
+		OpenAPI: "3.1.0",
+		Info:    operations.OpenAPIInfo{Title: "Users", Version: "1.0.0"},
+		Paths: map[string]map[string]operations.OpenAPIOperation{
+			"/users": {
+				"post": {
+					OperationId: "createUser",
+					RequestBody: &operations.OpenAPIRequestBody{
+						Content: map[string]operations.OpenAPIMediaType{"application/json": {Schema: userRef}},
+					},
+					Responses: map[string]operations.OpenAPIResponse{
+						"201": {Description: "Created", Content: map[string]operations.OpenAPIMediaType{"application/json": {Schema: userRef}}},
+						"409": {Description: "Conflict", Content: map[string]operations.OpenAPIMediaType{"application/json": {Schema: &goop.OpenAPISchema{Ref: "#/components/schemas/User/properties/id"}}}},
+					},
+				},
+			},
+			"/users/{id}": {
+				"get": {
+					OperationId: "getUser",
+					Responses: map[string]operations.OpenAPIResponse{
+						"200": {Description: "OK", Content: map[string]operations.OpenAPIMediaType{"application/json": {Schema: userRef}}},
+					},
+				},
+			},
+		},
+		Components: &operations.OpenAPIComponents{
+			Schemas: map[string]*goop.OpenAPISchema{
+				"User": {
+					Type: "object",
+					Properties: map[string]*goop.OpenAPISchema{
+						"id":      {Type: "string"},
+						"address": {Ref: "#/components/schemas/Address"},
+						"friends": {Type: "array", Items: userRef},
+					},
+				},
+				"Address": {Type: "object", Properties: map[string]*goop.OpenAPISchema{"city": {Type: "string"}}},
+			},
+		},
+	}
+}
+
+func TestSplit(t *testing.T) {
+	files, err := Split(testSpec(), "openapi.yaml", "yaml")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	for _, name := range []string{"openapi.yaml", "paths/users.yaml", "paths/users_{id}.yaml", "components/schemas/User.yaml", "components/schemas/Address.yaml"} {
+		if _, ok := files[name]; !ok {
+			t.Errorf("Expected file %s, got %v", name, sortedKeys(files))
+		}
+	}
+
+	root := files["openapi.yaml"].(map[string]interface{})
+	if ref, _ := refOf(root["paths"].(map[string]interface{})["/users/{id}"]); ref != "paths/users_{id}.yaml" {
+		t.Errorf("Expected path item reference, got %q", ref)
+	}
+
+	data, _ := json.Marshal(files["paths/users.yaml"])
+	for _, ref := range []string{`"../components/schemas/User.yaml"`, `"../components/schemas/User.yaml#/properties/id"`} {
+		if !strings.Contains(string(data), ref) {
+			t.Errorf("Expected path file to contain %s, got %s", ref, data)
+		}
+	}
+
+	data, _ = json.Marshal(files["components/schemas/User.yaml"])
+	for _, ref := range []string{`"Address.yaml"`, `"User.yaml"`} {
+		if !strings.Contains(string(data), ref) {
+			t.Errorf("Expected schema file to contain %s, got %s", ref, data)
+		}
+	}
+
+	// References to other components point into the root document
+	files, err = Split(map[string]interface{}{
+		"openapi": "3.1.0",
+		"paths": map[string]interface{}{
+			"/": map[string]interface{}{"get": map[string]interface{}{
+				"responses": map[string]interface{}{"400": map[string]interface{}{"$ref": "#/components/responses/BadRequest"}},
+			}},
+		},
+	}, "openapi.json", "json")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	data, _ = json.Marshal(files["paths/root.json"])
+	if !strings.Contains(string(data), `"../openapi.json#/components/responses/BadRequest"`) {
+		t.Errorf("Expected reference into the root document, got %s", data)
+	}
+
+	if _, err := Split(testSpec(), "openapi.xml", "xml"); err == nil {
+		t.Error("Expected error for unsupported format")
+	}
+}
+
+func TestWriteSplitAndBundle(t *testing.T) {
+	for _, format := range []string{"yaml", "json"} {
+		t.Run(format, func(t *testing.T) {
+			dir := t.TempDir()
+			rootFile := filepath.Join(dir, "api", "openapi."+format)
+
+			// A file left over from an earlier run is removed
+			stale := filepath.Join(dir, "api", "paths", "orders."+format)
+			if err := os.MkdirAll(filepath.Dir(stale), 0o750); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(stale, []byte("{}"), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := WriteSplit(testSpec(), rootFile, format); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if _, err := os.Stat(stale); !os.IsNotExist(err) {
+				t.Error("Expected stale path file to be removed")
+			}
+
+			bundled, err := Bundle(rootFile)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			expected, _ := toGeneric(testSpec())
+			if !reflect.DeepEqual(normalizeNumbers(t, bundled), expected) {
+				got, _ := json.MarshalIndent(bundled, "", "  ")
+				want, _ := json.MarshalIndent(expected, "", "  ")
+				t.Errorf("Bundled spec differs from the original\ngot:  %s\nwant: %s", got, want)
+			}
+		})
+	}
+}
+
+func TestBundleInlinesFragments(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("openapi.yaml", `openapi: 3.1.0
+info: {title: Pets, version: 1.0.0}
+paths:
+  /pets:
+    $ref: paths/pets.yaml
+`)
+	write("paths/pets.yaml", `get:
+  parameters:
+    - $ref: ../shared.yaml#/parameters/limit
+  responses:
+    200:
+      description: OK
+      content:
+        application/json:
+          schema:
+            $ref: ../components/schemas/Pet.yaml
+`)
+	write("shared.yaml", `parameters:
+  limit: {name: limit, in: query}
+`)
+	write("components/schemas/Pet.yaml", `type: object`)
+
+	bundled, err := Bundle(filepath.Join(dir, "openapi.yaml"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var out strings.Builder
+	if err := Encode(&out, bundled, "yaml"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !strings.HasPrefix(out.String(), "openapi: 3.1.0\ninfo:") {
+		t.Errorf("Expected top-level fields in specification order, got:\n%s", out.String())
+	}
+
+	var decoded map[string]interface{}
+	if err := yaml.Unmarshal([]byte(out.String()), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	get := decoded["paths"].(map[string]interface{})["/pets"].(map[string]interface{})["get"].(map[string]interface{})
+	if get["parameters"].([]interface{})[0].(map[string]interface{})["name"] != "limit" {
+		t.Errorf("Expected parameter to be inlined, got %v", get["parameters"])
+	}
+	schema := get["responses"].(map[string]interface{})["200"].(map[string]interface{})["content"].(map[string]interface{})["application/json"].(map[string]interface{})["schema"]
+	if ref, _ := refOf(schema); ref != "#/components/schemas/Pet" {
+		t.Errorf("Expected local schema reference, got %v", schema)
+	}
+	if decoded["components"].(map[string]interface{})["schemas"].(map[string]interface{})["Pet"] == nil {
+		t.Error("Expected Pet to be added to components")
+	}
+
+	write("paths/pets.yaml", `$ref: pets.yaml`)
+	if _, err := Bundle(filepath.Join(dir, "openapi.yaml")); err == nil || !strings.Contains(err.Error(), "circular") {
+		t.Errorf("Expected circular reference error, got %v", err)
+	}
+}
+
+// normalizeNumbers converts YAML-decoded numbers to their JSON-decoded form for comparison
+func normalizeNumbers(t *testing.T, doc interface{}) interface{} {
+	t.Helper()
+	generic, err := toGeneric(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return generic
+}
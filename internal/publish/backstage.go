@@ -0,0 +1,60 @@
+package publish
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// backstageTarget refreshes a Backstage API entity so the catalog re-reads its definition
+//
+// Backstage reads API definitions from the URLs its entities reference, such as a
+// definition: $text: https://... pointing at the file another target publishes, so this
+// target is listed after that one. The token is read from BACKSTAGE_TOKEN, if set.
+type backstageTarget struct {
+	opts    Options
+	baseURL string
+	entity  string
+	token   string
+}
+
+func newBackstageTarget(u *url.URL, opts Options) (*backstageTarget, error) {
+	entity := u.Query().Get("entity")
+	if entity == "" {
+		return nil, fmt.Errorf("invalid target %q: expected backstage://host?entity=api:default/name", u.String())
+	}
+	return &backstageTarget{
+		opts:    opts,
+		baseURL: "https://" + u.Host + strings.TrimSuffix(u.Path, "/"),
+		entity:  entity,
+		token:   opts.getenv("BACKSTAGE_TOKEN"),
+	}, nil
+}
+
+func (t *backstageTarget) Publish(ctx context.Context, spec Spec, fileName string) (string, error) {
+	payload, err := json.Marshal(map[string]string{"entityRef": t.entity})
+	if err != nil {
+		return "", err
+	}
+	header := http.Header{}
+	if t.token != "" {
+		header.Set("Authorization", "Bearer "+t.token)
+	}
+	if _, err := send(ctx, t.opts, http.MethodPost, t.baseURL+"/api/catalog/refresh", "application/json", payload, header); err != nil {
+		return "", fmt.Errorf("failed to refresh Backstage entity %s: %w", t.entity, err)
+	}
+
+	// Entity references have the form [kind:][namespace/]name
+	kind, rest, found := strings.Cut(t.entity, ":")
+	if !found {
+		kind, rest = "api", t.entity
+	}
+	namespace, name, found := strings.Cut(rest, "/")
+	if !found {
+		namespace, name = "default", rest
+	}
+	return fmt.Sprintf("%s/catalog/%s/%s/%s", t.baseURL, namespace, strings.ToLower(kind), name), nil
+}
@@ -0,0 +1,105 @@
+package publish
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// githubTarget attaches specs to a GitHub release as assets, replacing an asset of the same name
+//
+// The token is read from GITHUB_TOKEN and the API from GITHUB_API_URL (default
+// https://api.github.com), both of which GitHub Actions sets. The release tag defaults to
+// v<version>, and the release must already exist.
+type githubTarget struct {
+	opts        Options
+	owner, repo string
+	tag         string
+	apiURL      string
+	token       string
+}
+
+func newGitHubTarget(u *url.URL, opts Options) (*githubTarget, error) {
+	repo, tag, _ := strings.Cut(strings.Trim(u.Path, "/"), "@")
+	if repo == "" || strings.Contains(repo, "/") {
+		return nil, fmt.Errorf("invalid target %q: expected github://owner/repo[@tag]", u.String())
+	}
+	t := &githubTarget{
+		opts:   opts,
+		owner:  u.Host,
+		repo:   repo,
+		tag:    tag,
+		apiURL: strings.TrimSuffix(opts.getenv("GITHUB_API_URL"), "/"),
+		token:  opts.getenv("GITHUB_TOKEN"),
+	}
+	if t.apiURL == "" {
+		t.apiURL = "https://api.github.com"
+	}
+	if t.token == "" {
+		return nil, fmt.Errorf("github://%s/%s: GITHUB_TOKEN must be set", t.owner, t.repo)
+	}
+	return t, nil
+}
+
+// githubRelease is the part of a GitHub release the target uses
+type githubRelease struct {
+	UploadURL string `json:"upload_url"`
+	Assets    []struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"assets"`
+}
+
+func (t *githubTarget) Publish(ctx context.Context, spec Spec, fileName string) (string, error) {
+	tag := t.tag
+	if tag == "" {
+		if spec.Version == "" {
+			return "", fmt.Errorf("github://%s/%s: the spec has no info.version to name the release tag after", t.owner, t.repo)
+		}
+		tag = "v" + spec.Version
+	}
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+t.token)
+	header.Set("Accept", "application/vnd.github+json")
+
+	releaseURL := fmt.Sprintf("%s/repos/%s/%s/releases/tags/%s", t.apiURL, t.owner, t.repo, url.PathEscape(tag))
+	body, err := send(ctx, t.opts, http.MethodGet, releaseURL, "", nil, header)
+	if err != nil {
+		var statusErr *StatusError
+		if errors.As(err, &statusErr) && statusErr.Status == http.StatusNotFound {
+			return "", fmt.Errorf("github://%s/%s: release %s does not exist", t.owner, t.repo, tag)
+		}
+		return "", fmt.Errorf("failed to find release %s: %w", tag, err)
+	}
+	var release githubRelease
+	if err := json.Unmarshal(body, &release); err != nil {
+		return "", fmt.Errorf("failed to decode release %s: %w", tag, err)
+	}
+
+	// Assets cannot be overwritten, so republishing a version replaces the asset
+	for _, asset := range release.Assets {
+		if asset.Name == fileName {
+			if _, err := send(ctx, t.opts, http.MethodDelete, asset.URL, "", nil, header); err != nil {
+				return "", fmt.Errorf("failed to replace release asset %s: %w", fileName, err)
+			}
+		}
+	}
+
+	// The upload URL is a URI template such as .../assets{?name,label}
+	uploadURL, _, _ := strings.Cut(release.UploadURL, "{")
+	body, err = send(ctx, t.opts, http.MethodPost, uploadURL+"?name="+url.QueryEscape(fileName), spec.ContentType, spec.Data, header)
+	if err != nil {
+		return "", fmt.Errorf("failed to upload release asset %s: %w", fileName, err)
+	}
+	var asset struct {
+		BrowserDownloadURL string `json:"browser_download_url"`
+	}
+	if err := json.Unmarshal(body, &asset); err != nil {
+		return "", fmt.Errorf("failed to decode release asset %s: %w", fileName, err)
+	}
+	return asset.BrowserDownloadURL, nil
+}
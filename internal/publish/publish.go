@@ -0,0 +1,220 @@
+// Package publish distributes a generated specification to the places its readers fetch it
+// from, so spec distribution is a single CI step. Targets are given as URLs:
+//
+//	s3://bucket/prefix               uploads to an S3 bucket (or an S3-compatible store)
+//	github://owner/repo[@tag]        attaches to a GitHub release (tag defaults to v<version>)
+//	swaggerhub://owner/api           saves a version of an API on SwaggerHub
+//	backstage://host?entity=ref      refreshes a Backstage API entity so it re-reads its definition
+//
+// Credentials come from the environment variables each target documents.
+package publish
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultNamePattern names published files after the spec file and its version, such as openapi-1.2.0.yaml
+const DefaultNamePattern = "{name}-{version}"
+
+// Spec is a specification file to publish
+type Spec struct {
+	Data        []byte
+	Name        string // File name without extension, such as openapi
+	Ext         string // File extension, such as .yaml
+	Title       string // info.title of the spec
+	Version     string // info.version of the spec
+	ContentType string
+}
+
+// LoadSpec reads a YAML or JSON specification and its title and version
+func LoadSpec(filename string) (Spec, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return Spec{}, fmt.Errorf("failed to read %s: %w", filename, err)
+	}
+	var doc struct {
+		Info struct {
+			Title   string `yaml:"title"`
+			Version string `yaml:"version"`
+		} `yaml:"info"`
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return Spec{}, fmt.Errorf("failed to parse %s: %w", filename, err)
+	}
+
+	ext := filepath.Ext(filename)
+	spec := Spec{
+		Data:        data,
+		Name:        strings.TrimSuffix(filepath.Base(filename), ext),
+		Ext:         ext,
+		Title:       doc.Info.Title,
+		Version:     doc.Info.Version,
+		ContentType: "application/yaml",
+	}
+	if ext == ".json" {
+		spec.ContentType = "application/json"
+	}
+	return spec, nil
+}
+
+// FileName returns the published file name for a pattern using the {name} and {version} placeholders
+func (s Spec) FileName(pattern string) string {
+	if pattern == "" {
+		pattern = DefaultNamePattern
+	}
+	name := strings.NewReplacer("{name}", s.Name, "{version}", s.Version).Replace(pattern)
+	return name + s.Ext
+}
+
+// Target is a place specifications are published to
+type Target interface {
+	// Publish publishes the spec as fileName and returns the URL it can be read from, if any
+	Publish(ctx context.Context, spec Spec, fileName string) (string, error)
+}
+
+// Options configure how targets reach their services
+type Options struct {
+	Client *http.Client            // HTTP client (defaults to http.DefaultClient)
+	Getenv func(key string) string // Environment lookup for credentials and endpoints (defaults to os.Getenv)
+}
+
+func (o Options) client() *http.Client {
+	if o.Client != nil {
+		return o.Client
+	}
+	return http.DefaultClient
+}
+
+func (o Options) getenv(key string) string {
+	if o.Getenv != nil {
+		return o.Getenv(key)
+	}
+	return os.Getenv(key)
+}
+
+// ParseTarget returns the target a URL such as s3://bucket/specs describes
+func ParseTarget(raw string, opts Options) (Target, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid target %q: %w", raw, err)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid target %q: missing %s", raw, targetHostName(u.Scheme))
+	}
+
+	switch u.Scheme {
+	case "s3":
+		return newS3Target(u, opts)
+	case "github":
+		return newGitHubTarget(u, opts)
+	case "swaggerhub":
+		return newSwaggerHubTarget(u, opts)
+	case "backstage":
+		return newBackstageTarget(u, opts)
+	default:
+		return nil, fmt.Errorf("unsupported target %q (supported: s3://, github://, swaggerhub://, backstage://)", raw)
+	}
+}
+
+// targetHostName describes what the host of a target URL names
+func targetHostName(scheme string) string {
+	switch scheme {
+	case "s3":
+		return "bucket"
+	case "github", "swaggerhub":
+		return "owner"
+	default:
+		return "host"
+	}
+}
+
+// Notify posts a message listing the published URLs to a Slack incoming webhook
+func Notify(ctx context.Context, opts Options, webhook string, spec Spec, published []string) error {
+	title := spec.Title
+	if title == "" {
+		title = spec.Name
+	}
+	lines := []string{fmt.Sprintf("Published %s %s", title, spec.Version)}
+	for _, location := range published {
+		lines = append(lines, "• "+location)
+	}
+	payload, err := json.Marshal(map[string]string{"text": strings.Join(lines, "\n")})
+	if err != nil {
+		return err
+	}
+	_, err = send(ctx, opts, http.MethodPost, webhook, "application/json", payload, nil)
+	return err
+}
+
+// send performs a request and returns the response body, failing on non-2xx statuses
+func send(ctx context.Context, opts Options, method, target, contentType string, body []byte, header http.Header) ([]byte, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := opts.client().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return respBody, &StatusError{Method: method, URL: redact(target), Status: resp.StatusCode, Body: strings.TrimSpace(string(respBody))}
+	}
+	return respBody, nil
+}
+
+// StatusError is returned when a service answers with a non-2xx status
+type StatusError struct {
+	Method string
+	URL    string
+	Status int
+	Body   string
+}
+
+func (e *StatusError) Error() string {
+	msg := fmt.Sprintf("%s %s: %d %s", e.Method, e.URL, e.Status, http.StatusText(e.Status))
+	if e.Body != "" {
+		msg += ": " + e.Body
+	}
+	return msg
+}
+
+// secretPathPattern matches the secret path of Slack webhook URLs
+var secretPathPattern = regexp.MustCompile(`^(https://hooks\.slack\.com/services)/.*$`)
+
+// redact hides secrets carried in URLs from error messages
+func redact(target string) string {
+	target = secretPathPattern.ReplaceAllString(target, "$1/***")
+	if u, err := url.Parse(target); err == nil && u.RawQuery != "" {
+		u.RawQuery = ""
+		return u.String()
+	}
+	return target
+}
@@ -0,0 +1,160 @@
+package publish
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestLoadSpec(t *testing.T) {
+	specFile := filepath.Join(t.TempDir(), "openapi.json")
+	if err := os.WriteFile(specFile, []byte(`{"openapi": "3.1.0", "info": {"title": "Orders API", "version": "1.2.0"}}`), 0o644); err != nil {
+		t.Fatalf("Failed to write spec: %v", err)
+	}
+
+	spec, err := LoadSpec(specFile)
+	if err != nil {
+		t.Fatalf("LoadSpec failed: %v", err)
+	}
+	if spec.Title != "Orders API" || spec.Version != "1.2.0" || spec.ContentType != "application/json" {
+		t.Errorf("Unexpected spec metadata: %+v", spec)
+	}
+	if name := spec.FileName(""); name != "openapi-1.2.0.json" {
+		t.Errorf("Expected openapi-1.2.0.json, got %s", name)
+	}
+	if name := spec.FileName("orders/{version}/{name}"); name != "orders/1.2.0/openapi.json" {
+		t.Errorf("Expected orders/1.2.0/openapi.json, got %s", name)
+	}
+}
+
+func TestParseTarget(t *testing.T) {
+	opts := Options{Getenv: func(string) string { return "" }}
+	tests := []struct {
+		target  string
+		wantErr string
+	}{
+		{"ftp://host/specs", "unsupported target"},
+		{"s3:///specs", "missing bucket"},
+		{"s3://bucket/specs", "AWS_ACCESS_KEY_ID"},
+		{"github://acme", "expected github://owner/repo"},
+		{"github://acme/orders", "GITHUB_TOKEN"},
+		{"swaggerhub://acme/orders", "SWAGGERHUB_API_KEY"},
+		{"backstage://backstage.example.com", "expected backstage://host?entity="},
+	}
+	for _, tt := range tests {
+		_, err := ParseTarget(tt.target, opts)
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s: expected error containing %q, got %v", tt.target, tt.wantErr, err)
+		}
+	}
+}
+
+// recordedRequest is a request received by the fake services
+type recordedRequest struct {
+	Method, URI, Auth, Body string
+}
+
+func TestPublish(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		requests []recordedRequest
+	)
+	var server *httptest.Server
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		requests = append(requests, recordedRequest{r.Method, r.URL.RequestURI(), r.Header.Get("Authorization"), string(body)})
+		mu.Unlock()
+
+		switch r.URL.Path {
+		case "/repos/acme/orders/releases/tags/v1.2.0":
+			_, _ = io.WriteString(w, `{"upload_url": "`+server.URL+`/uploads/7/assets{?name,label}",
+				"assets": [{"name": "openapi-1.2.0.yaml", "url": "`+server.URL+`/assets/9"}]}`)
+		case "/uploads/7/assets":
+			w.WriteHeader(http.StatusCreated)
+			_, _ = io.WriteString(w, `{"browser_download_url": "https://github.com/acme/orders/releases/download/v1.2.0/openapi-1.2.0.yaml"}`)
+		case "/repos/acme/orders/releases/tags/v9.9.9":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	env := map[string]string{
+		"AWS_ACCESS_KEY_ID":     "AKID",
+		"AWS_SECRET_ACCESS_KEY": "secret",
+		"AWS_REGION":            "eu-west-1",
+		"AWS_ENDPOINT_URL":      server.URL,
+		"GITHUB_TOKEN":          "ghp_token",
+		"GITHUB_API_URL":        server.URL,
+		"SWAGGERHUB_API_KEY":    "sh-key",
+		"SWAGGERHUB_URL":        server.URL,
+	}
+	opts := Options{Client: server.Client(), Getenv: func(key string) string { return env[key] }}
+	spec := Spec{Data: []byte("openapi: 3.1.0\n"), Name: "openapi", Ext: ".yaml", Title: "Orders API", Version: "1.2.0", ContentType: "application/yaml"}
+	host := strings.TrimPrefix(server.URL, "https://")
+
+	expected := map[string]string{
+		"s3://specs/orders":                          server.URL + "/specs/orders/openapi-1.2.0.yaml",
+		"github://acme/orders":                       "https://github.com/acme/orders/releases/download/v1.2.0/openapi-1.2.0.yaml",
+		"swaggerhub://acme/orders?isPrivate=false":   "https://app.swaggerhub.com/apis/acme/orders/1.2.0",
+		"backstage://" + host + "?entity=api:orders": server.URL + "/catalog/default/api/orders",
+	}
+	for raw, wantURL := range expected {
+		target, err := ParseTarget(raw, opts)
+		if err != nil {
+			t.Fatalf("ParseTarget(%s) failed: %v", raw, err)
+		}
+		location, err := target.Publish(context.Background(), spec, spec.FileName(""))
+		if err != nil {
+			t.Fatalf("Publish to %s failed: %v", raw, err)
+		}
+		if location != wantURL {
+			t.Errorf("%s: expected URL %s, got %s", raw, wantURL, location)
+		}
+	}
+
+	missing, _ := ParseTarget("github://acme/orders@v9.9.9", opts)
+	if _, err := missing.Publish(context.Background(), spec, spec.FileName("")); err == nil || !strings.Contains(err.Error(), "release v9.9.9 does not exist") {
+		t.Errorf("Expected a missing release error, got %v", err)
+	}
+
+	if err := Notify(context.Background(), opts, server.URL+"/slack", spec, []string{"https://example.com/openapi-1.2.0.yaml"}); err != nil {
+		t.Fatalf("Notify failed: %v", err)
+	}
+
+	received := make(map[string]recordedRequest)
+	for _, req := range requests {
+		received[req.Method+" "+req.URI] = req
+	}
+
+	upload, ok := received["PUT /specs/orders/openapi-1.2.0.yaml"]
+	if !ok {
+		t.Fatalf("Expected an S3 upload, got %v", requests)
+	}
+	if !strings.HasPrefix(upload.Auth, "AWS4-HMAC-SHA256 Credential=AKID/") || !strings.Contains(upload.Auth, "/eu-west-1/s3/aws4_request") || upload.Body != "openapi: 3.1.0\n" {
+		t.Errorf("Unexpected S3 upload: %+v", upload)
+	}
+	if _, ok := received["DELETE /assets/9"]; !ok {
+		t.Error("Expected the existing release asset to be replaced")
+	}
+	if asset := received["POST /uploads/7/assets?name=openapi-1.2.0.yaml"]; asset.Auth != "Bearer ghp_token" {
+		t.Errorf("Expected an authenticated release asset upload, got %+v", asset)
+	}
+	if save := received["POST /apis/acme/orders?force=true&isPrivate=false&version=1.2.0"]; save.Auth != "sh-key" {
+		t.Errorf("Expected a SwaggerHub save with the API key, got %+v", save)
+	}
+	if refresh := received["POST /api/catalog/refresh"]; refresh.Body != `{"entityRef":"api:orders"}` {
+		t.Errorf("Expected a Backstage refresh of api:orders, got %+v", refresh)
+	}
+	if slack := received["POST /slack"]; !strings.Contains(slack.Body, "Published Orders API 1.2.0") {
+		t.Errorf("Expected a Slack notification, got %+v", slack)
+	}
+}
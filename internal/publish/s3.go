@@ -0,0 +1,144 @@
+package publish
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// s3Target uploads specs to an S3 bucket, authenticating with AWS Signature Version 4
+//
+// Credentials are read from AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN,
+// the region from AWS_REGION or AWS_DEFAULT_REGION (default us-east-1). AWS_ENDPOINT_URL
+// points the target at an S3-compatible store, which is addressed path-style.
+type s3Target struct {
+	opts                             Options
+	bucket, prefix                   string
+	region, endpoint                 string
+	accessKey, secretKey, sessionKey string
+	now                              func() time.Time
+}
+
+func newS3Target(u *url.URL, opts Options) (*s3Target, error) {
+	t := &s3Target{
+		opts:       opts,
+		bucket:     u.Host,
+		prefix:     strings.Trim(u.Path, "/"),
+		region:     opts.getenv("AWS_REGION"),
+		endpoint:   strings.TrimSuffix(opts.getenv("AWS_ENDPOINT_URL"), "/"),
+		accessKey:  opts.getenv("AWS_ACCESS_KEY_ID"),
+		secretKey:  opts.getenv("AWS_SECRET_ACCESS_KEY"),
+		sessionKey: opts.getenv("AWS_SESSION_TOKEN"),
+		now:        time.Now,
+	}
+	if t.region == "" {
+		t.region = opts.getenv("AWS_DEFAULT_REGION")
+	}
+	if t.region == "" {
+		t.region = "us-east-1"
+	}
+	if t.accessKey == "" || t.secretKey == "" {
+		return nil, fmt.Errorf("s3://%s: AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY must be set", t.bucket)
+	}
+	return t, nil
+}
+
+func (t *s3Target) Publish(ctx context.Context, spec Spec, fileName string) (string, error) {
+	key := path.Join(t.prefix, fileName)
+	objectURL := fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", t.bucket, t.region, escapePath(key))
+	if t.endpoint != "" {
+		objectURL = fmt.Sprintf("%s/%s/%s", t.endpoint, t.bucket, escapePath(key))
+	}
+
+	header, err := t.sign(http.MethodPut, objectURL, spec.ContentType, spec.Data)
+	if err != nil {
+		return "", err
+	}
+	if _, err := send(ctx, t.opts, http.MethodPut, objectURL, "", spec.Data, header); err != nil {
+		return "", fmt.Errorf("failed to upload s3://%s/%s: %w", t.bucket, key, err)
+	}
+	return objectURL, nil
+}
+
+// sign returns the headers of a request signed with AWS Signature Version 4
+func (t *s3Target) sign(method, target, contentType string, body []byte) (http.Header, error) {
+	u, err := url.Parse(target)
+	if err != nil {
+		return nil, err
+	}
+	now := t.now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256Hex(body)
+
+	header := http.Header{}
+	header.Set("Content-Type", contentType)
+	header.Set("Host", u.Host)
+	header.Set("X-Amz-Content-Sha256", payloadHash)
+	header.Set("X-Amz-Date", amzDate)
+	if t.sessionKey != "" {
+		header.Set("X-Amz-Security-Token", t.sessionKey)
+	}
+
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, strings.ToLower(name))
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&canonicalHeaders, "%s:%s\n", name, strings.TrimSpace(header.Get(name)))
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		method,
+		u.EscapedPath(),
+		u.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	scope := date + "/" + t.region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, sha256Hex([]byte(canonicalRequest))}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+t.secretKey), date)
+	for _, part := range []string{t.region, "s3", "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		t.accessKey, scope, signedHeaders, signature))
+	// Host is set from the URL by net/http
+	header.Del("Host")
+	return header, nil
+}
+
+// escapePath escapes each segment of an object key
+func escapePath(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,67 @@
+package publish
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// swaggerHubTarget saves specs as versions of an API on SwaggerHub
+//
+// The API key is read from SWAGGERHUB_API_KEY and the registry API from SWAGGERHUB_URL (default
+// https://api.swaggerhub.com) for on-premise installations. Query parameters of the target, such
+// as ?isPrivate=false or ?setDefault=true, are passed to SwaggerHub's save API.
+type swaggerHubTarget struct {
+	opts       Options
+	owner, api string
+	query      url.Values
+	apiURL     string
+	apiKey     string
+}
+
+func newSwaggerHubTarget(u *url.URL, opts Options) (*swaggerHubTarget, error) {
+	api := strings.Trim(u.Path, "/")
+	if api == "" || strings.Contains(api, "/") {
+		return nil, fmt.Errorf("invalid target %q: expected swaggerhub://owner/api", u.String())
+	}
+	t := &swaggerHubTarget{
+		opts:   opts,
+		owner:  u.Host,
+		api:    api,
+		query:  u.Query(),
+		apiURL: strings.TrimSuffix(opts.getenv("SWAGGERHUB_URL"), "/"),
+		apiKey: opts.getenv("SWAGGERHUB_API_KEY"),
+	}
+	if t.apiURL == "" {
+		t.apiURL = "https://api.swaggerhub.com"
+	}
+	if t.apiKey == "" {
+		return nil, fmt.Errorf("swaggerhub://%s/%s: SWAGGERHUB_API_KEY must be set", t.owner, t.api)
+	}
+	return t, nil
+}
+
+// Publish saves the spec under its own version; the file name does not apply to SwaggerHub
+func (t *swaggerHubTarget) Publish(ctx context.Context, spec Spec, fileName string) (string, error) {
+	query := url.Values{}
+	for key, values := range t.query {
+		query[key] = values
+	}
+	if spec.Version != "" && query.Get("version") == "" {
+		query.Set("version", spec.Version)
+	}
+	if query.Get("isPrivate") == "" {
+		query.Set("isPrivate", "true")
+	}
+	query.Set("force", "true")
+
+	header := http.Header{}
+	header.Set("Authorization", t.apiKey)
+	saveURL := fmt.Sprintf("%s/apis/%s/%s?%s", t.apiURL, url.PathEscape(t.owner), url.PathEscape(t.api), query.Encode())
+	if _, err := send(ctx, t.opts, http.MethodPost, saveURL, spec.ContentType, spec.Data, header); err != nil {
+		return "", fmt.Errorf("failed to save %s/%s on SwaggerHub: %w", t.owner, t.api, err)
+	}
+	return fmt.Sprintf("https://app.swaggerhub.com/apis/%s/%s/%s", t.owner, t.api, query.Get("version")), nil
+}
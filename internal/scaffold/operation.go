@@ -0,0 +1,727 @@
+package scaffold
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+
+	goop "github.com/picogrid/go-op"
+	"github.com/picogrid/go-op/internal/specutil"
+	"github.com/picogrid/go-op/operations"
+	"github.com/picogrid/go-op/validators"
+)
+
+// httpMethods are the operation keys of a path item, in the order operations are scaffolded
+var httpMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// OperationOptions configure operations scaffolded from a path of an existing spec
+type OperationOptions struct {
+	Path    string // Path of the operations in the spec, such as /orders/{id}
+	Method  string // Method to scaffold; empty scaffolds every method of the path
+	Package string // Package name of the generated file
+	Source  string // Name of the spec, mentioned in the generated file's header
+}
+
+// LoadSpec reads an OpenAPI document in JSON or YAML
+// Path-level parameters are copied to the operations of their path, and type lists such as
+// [string, "null"] are narrowed to their first non-null type, so hand-written specs decode.
+func LoadSpec(filename string) (*operations.OpenAPISpec, error) {
+	data, err := os.ReadFile(filepath.Clean(filename))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spec: %w", err)
+	}
+	return ParseSpec(data)
+}
+
+// ParseSpec parses an OpenAPI document in JSON or YAML, normalized the way LoadSpec describes
+func ParseSpec(data []byte) (*operations.OpenAPISpec, error) {
+	var doc interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse spec: %w", err)
+	}
+	root, ok := doc.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("spec must be an object")
+	}
+	if paths, ok := root["paths"].(map[string]interface{}); ok {
+		for path, item := range paths {
+			if pathItem, ok := item.(map[string]interface{}); ok {
+				paths[path] = operationsOf(pathItem)
+			}
+		}
+	}
+	narrowTypeLists(root)
+
+	normalized, err := json.Marshal(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse spec: %w", err)
+	}
+	var spec operations.OpenAPISpec
+	if err := json.Unmarshal(normalized, &spec); err != nil {
+		return nil, fmt.Errorf("failed to parse spec: %w", err)
+	}
+	return &spec, nil
+}
+
+// operationsOf returns the operations of a path item, with the path-level parameters they do not override
+func operationsOf(pathItem map[string]interface{}) map[string]interface{} {
+	shared, _ := pathItem["parameters"].([]interface{})
+	ops := make(map[string]interface{})
+	for _, method := range httpMethods {
+		op, ok := pathItem[method].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		own, _ := op["parameters"].([]interface{})
+		declared := make(map[string]bool, len(own))
+		for _, param := range own {
+			if p, ok := param.(map[string]interface{}); ok {
+				declared[fmt.Sprint(p["in"], ":", p["name"])] = true
+			}
+		}
+		for _, param := range shared {
+			if p, ok := param.(map[string]interface{}); ok && !declared[fmt.Sprint(p["in"], ":", p["name"])] {
+				own = append(own, p)
+			}
+		}
+		if len(own) > 0 {
+			op["parameters"] = own
+		}
+		ops[method] = op
+	}
+	return ops
+}
+
+// narrowTypeLists replaces OpenAPI 3.1 type lists with their first non-null type
+func narrowTypeLists(value interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if types, ok := v["type"].([]interface{}); ok {
+			delete(v, "type")
+			for _, t := range types {
+				if name, ok := t.(string); ok && name != "null" {
+					v["type"] = name
+					break
+				}
+			}
+		}
+		for _, child := range v {
+			narrowTypeLists(child)
+		}
+	case []interface{}:
+		for _, child := range v {
+			narrowTypeLists(child)
+		}
+	}
+}
+
+// Operation returns Go source for the operations at opts.Path of spec: validator schemas, typed
+// request and response structs, a handler stub, and a function building each operation. The
+// handlers use the Gin adapter and return an error until they are implemented.
+func Operation(spec *operations.OpenAPISpec, opts OperationOptions) ([]byte, error) {
+	pathItem, ok := spec.Paths[opts.Path]
+	if !ok {
+		return nil, fmt.Errorf("path %s not found in spec", opts.Path)
+	}
+
+	w := &operationWriter{spec: spec, names: make(map[string]bool), resolving: make(map[string]bool)}
+	found := false
+	for _, method := range httpMethods {
+		if opts.Method != "" && !strings.EqualFold(opts.Method, method) {
+			continue
+		}
+		op, ok := pathItem[method]
+		if !ok {
+			continue
+		}
+		found = true
+		w.writeOperation(strings.ToUpper(method), opts.Path, spec.Components.Dereference(op))
+	}
+	if !found {
+		return nil, fmt.Errorf("no %s operation at path %s", strings.ToUpper(opts.Method), opts.Path)
+	}
+
+	var out bytes.Buffer
+	source := opts.Source
+	if source == "" {
+		source = "an OpenAPI spec"
+	}
+	fmt.Fprintf(&out, "// Scaffolded by goop scaffold-op from %s %s.\n", source, opts.Path)
+	out.WriteString("// Review the schemas and implement the handlers; this file is not regenerated.\n\n")
+	fmt.Fprintf(&out, "package %s\n\n", opts.Package)
+	w.writeImports(&out)
+	out.Write(w.body.Bytes())
+
+	formatted, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format scaffolded operation: %w", err)
+	}
+	return formatted, nil
+}
+
+// writeImports writes the import block of the generated file
+// The validators package is only imported when an operation has a schema.
+func (w *operationWriter) writeImports(out *bytes.Buffer) {
+	out.WriteString("import (\n\t\"context\"\n\t\"errors\"\n\n")
+	out.WriteString("\t\"github.com/picogrid/go-op/operations\"\n")
+	out.WriteString("\tginadapter \"github.com/picogrid/go-op/operations/adapters/gin\"\n")
+	if bytes.Contains(w.body.Bytes(), []byte("validators.")) {
+		out.WriteString("\t\"github.com/picogrid/go-op/validators\"\n")
+	}
+	out.WriteString(")\n\n")
+}
+
+// FileName returns the Go file name for the operations of a path, such as orders_id.go for /orders/{id}
+func FileName(path string) string {
+	var segments []string
+	for _, segment := range strings.Split(path, "/") {
+		if segment = strings.Trim(segment, "{}"); segment != "" {
+			segments = append(segments, strings.ToLower(segment))
+		}
+	}
+	if len(segments) == 0 {
+		return "root.go"
+	}
+	return strings.Join(segments, "_") + ".go"
+}
+
+// operationWriter accumulates the generated declarations of one file
+type operationWriter struct {
+	spec      *operations.OpenAPISpec
+	body      bytes.Buffer
+	names     map[string]bool
+	resolving map[string]bool // Component schemas being expanded, to stop at recursive references
+
+	// server makes operations call the methods of a Handler interface instead of handler stubs
+	server   bool
+	handlers []handlerMethod
+}
+
+// handlerMethod is a method of the generated Handler interface
+type handlerMethod struct {
+	name      string
+	label     string
+	summary   string
+	signature string
+	result    string
+}
+
+// requestPart is the schema and Go type of one request component of an operation
+type requestPart struct {
+	schema string // Schema variable, "nil" when the operation has none
+	goType string // Go type bound by the handler, struct{} when the operation has none
+}
+
+func (w *operationWriter) writeOperation(method, path string, op operations.OpenAPIOperation) {
+	base := identifier(op.OperationId)
+	if op.OperationId == "" {
+		base = identifier(strings.ToLower(method) + " " + path)
+	}
+	base = w.uniqueName(base)
+	label := method + " " + path
+
+	var schemas, types bytes.Buffer
+	part := func(suffix, doc, tag string, schema *goop.OpenAPISchema, required bool) requestPart {
+		if schema == nil {
+			return requestPart{schema: "nil", goType: "struct{}"}
+		}
+		name := w.uniqueName(base + suffix)
+		fmt.Fprintf(&schemas, "\t// %sSchema validates the %s of %s\n", name, doc, label)
+		fmt.Fprintf(&schemas, "\t%sSchema = %s\n", name, w.schemaExpr(schema, required))
+		goType := w.goType(&types, name, "the "+doc+" of "+label, tag, schema)
+		return requestPart{schema: name + "Schema", goType: goType}
+	}
+
+	params := part("Params", "path parameters", "uri", parameterSchema(op.Parameters, "path"), true)
+	query := part("Query", "query parameters", "form", parameterSchema(op.Parameters, "query"), true)
+	headers := part("Headers", "request headers", "header", parameterSchema(op.Parameters, "header"), true)
+
+	var bodySchema *goop.OpenAPISchema
+	bodyRequired := false
+	if op.RequestBody != nil {
+		bodySchema = specutil.MediaSchema(op.RequestBody.Content)
+		bodyRequired = op.RequestBody.Required
+	}
+	body := part("Body", "request body", "", bodySchema, bodyRequired)
+
+	// The lowest documented 2xx response is the handler's result
+	codes := make([]string, 0, len(op.Responses))
+	for code := range op.Responses {
+		if _, err := strconv.Atoi(code); err == nil {
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+	result := requestPart{schema: "nil", goType: "struct{}"}
+	var responses []string
+	for _, code := range codes {
+		response := op.Responses[code]
+		description := response.Description
+		if description == "" {
+			statusCode, _ := strconv.Atoi(code)
+			description = http.StatusText(statusCode)
+		}
+		schema := specutil.MediaSchema(response.Content)
+		responseSchema := "nil"
+		switch {
+		case schema != nil && strings.HasPrefix(code, "2") && result.schema == "nil":
+			result = part("Response", "response body", "", schema, true)
+			responseSchema = result.schema
+		case schema != nil:
+			statusCode, _ := strconv.Atoi(code)
+			name := w.uniqueName(base + identifier(http.StatusText(statusCode)) + code)
+			fmt.Fprintf(&schemas, "\t// %sSchema validates the %s response of %s\n", name, code, label)
+			fmt.Fprintf(&schemas, "\t%sSchema = %s\n", name, w.schemaExpr(schema, true))
+			responseSchema = name + "Schema"
+		}
+		responses = append(responses, fmt.Sprintf("WithResponseCode(%s, %s, %s)", code, responseSchema, strconv.Quote(description)))
+	}
+
+	if schemas.Len() > 0 {
+		w.body.WriteString("var (\n")
+		w.body.Write(schemas.Bytes())
+		w.body.WriteString(")\n\n")
+	}
+	w.body.Write(types.Bytes())
+
+	// Operation builder
+	handler, builderParams := lowerFirst(base), ""
+	if w.server {
+		handler, builderParams = "h."+base, "h Handler"
+		fmt.Fprintf(&w.body, "// %sOperation returns the %s operation, served by h\n", base, label)
+	} else {
+		fmt.Fprintf(&w.body, "// %sOperation returns the %s operation\n", base, label)
+	}
+	fmt.Fprintf(&w.body, "func %sOperation(%s) operations.CompiledOperation {\n\treturn operations.NewSimple().\n", base, builderParams)
+	switch method {
+	case "GET", "POST", "PUT", "PATCH", "DELETE":
+		fmt.Fprintf(&w.body, "\t\t%s(%s).\n", method, strconv.Quote(path))
+	default:
+		fmt.Fprintf(&w.body, "\t\tMethod(%s, %s).\n", strconv.Quote(method), strconv.Quote(path))
+	}
+	if op.OperationId != "" {
+		fmt.Fprintf(&w.body, "\t\tOperationID(%s).\n", strconv.Quote(op.OperationId))
+	}
+	if op.Summary != "" {
+		fmt.Fprintf(&w.body, "\t\tSummary(%s).\n", strconv.Quote(op.Summary))
+	}
+	if op.Description != "" {
+		fmt.Fprintf(&w.body, "\t\tDescription(%s).\n", strconv.Quote(op.Description))
+	}
+	if len(op.Tags) > 0 {
+		quoted := make([]string, len(op.Tags))
+		for i, tag := range op.Tags {
+			quoted[i] = strconv.Quote(tag)
+		}
+		fmt.Fprintf(&w.body, "\t\tTags(%s).\n", strings.Join(quoted, ", "))
+	}
+	for _, with := range []struct{ method, schema string }{{"WithParams", params.schema}, {"WithQuery", query.schema}, {"WithHeaders", headers.schema}, {"WithBody", body.schema}} {
+		if with.schema != "nil" {
+			fmt.Fprintf(&w.body, "\t\t%s(%s).\n", with.method, with.schema)
+		}
+	}
+	for _, response := range responses {
+		fmt.Fprintf(&w.body, "\t\t%s.\n", response)
+	}
+
+	if headers.schema != "nil" {
+		fmt.Fprintf(&w.body, "\t\tHandler(ginadapter.CreateValidatedHandlerWithHeaders(%s, %s, %s, %s, %s, %s))\n}\n\n",
+			handler, params.schema, query.schema, body.schema, headers.schema, result.schema)
+	} else {
+		fmt.Fprintf(&w.body, "\t\tHandler(ginadapter.CreateValidatedHandler(%s, %s, %s, %s, %s))\n}\n\n",
+			handler, params.schema, query.schema, body.schema, result.schema)
+	}
+
+	signature := fmt.Sprintf("(ctx context.Context, params %s, query %s, body %s", params.goType, query.goType, body.goType)
+	if headers.schema != "nil" {
+		signature += ", headers " + headers.goType
+	}
+	signature += fmt.Sprintf(") (%s, error)", result.goType)
+
+	if w.server {
+		w.handlers = append(w.handlers, handlerMethod{name: base, label: label, summary: op.Summary, signature: signature, result: result.goType})
+		return
+	}
+
+	// Handler stub
+	fmt.Fprintf(&w.body, "// %s implements %s.\n", handler, label)
+	fmt.Fprintf(&w.body, "func %s%s {\n\tvar result %s\n", handler, signature, result.goType)
+	fmt.Fprintf(&w.body, "\treturn result, errors.New(%s)\n}\n\n", strconv.Quote(label+" is not implemented"))
+}
+
+// parameterSchema returns an object schema with the parameters in a location, or nil if there are none
+func parameterSchema(params []operations.OpenAPIParameter, in string) *goop.OpenAPISchema {
+	var schema *goop.OpenAPISchema
+	for _, param := range params {
+		if param.In != in || param.Name == "" {
+			continue
+		}
+		if schema == nil {
+			schema = &goop.OpenAPISchema{Type: "object", Properties: make(map[string]*goop.OpenAPISchema)}
+		}
+		property := param.Schema
+		if property == nil {
+			property = &goop.OpenAPISchema{Type: "string"}
+		}
+		schema.Properties[param.Name] = property
+		if param.Required || in == "path" {
+			schema.Required = append(schema.Required, param.Name)
+		}
+	}
+	return schema
+}
+
+// resolve returns the component schema referenced by schema, or schema itself
+// The boolean is false for references to components that are missing or already being expanded.
+func (w *operationWriter) resolve(schema *goop.OpenAPISchema) (*goop.OpenAPISchema, string, bool) {
+	name, ok := operations.ComponentName(schema.Ref)
+	if !ok {
+		return schema, "", schema.Ref == ""
+	}
+	if w.spec.Components == nil || w.resolving[name] {
+		return nil, name, false
+	}
+	target, exists := w.spec.Components.Schemas[name]
+	return target, name, exists && target != nil
+}
+
+// schemaExpr returns a validators expression equivalent to schema
+// Referenced components are inlined; keywords without a builder method are left for review.
+func (w *operationWriter) schemaExpr(schema *goop.OpenAPISchema, required bool) string {
+	finish := ".Optional()"
+	if required {
+		finish = ".Required()"
+	}
+
+	schema, component, ok := w.resolve(schema)
+	if !ok {
+		// Recursive and unresolvable references accept any object
+		return "validators.Object(map[string]interface{}{})" + finish
+	}
+	if component != "" {
+		w.resolving[component] = true
+		defer delete(w.resolving, component)
+	}
+	schema = w.mergeAllOf(schema)
+
+	var members []*goop.OpenAPISchema
+	composition := ""
+	switch {
+	case len(schema.OneOf) > 0:
+		composition, members = "OneOf", schema.OneOf
+	case len(schema.AnyOf) > 0:
+		composition, members = "AnyOf", schema.AnyOf
+	case len(schema.AllOf) > 0:
+		composition, members = "AllOf", schema.AllOf
+	}
+	if composition != "" {
+		exprs := make([]string, len(members))
+		for i, member := range members {
+			exprs[i] = w.schemaExpr(member, true)
+		}
+		return fmt.Sprintf("validators.%s(\n%s,\n)%s", composition, strings.Join(exprs, ",\n"), finish)
+	}
+
+	var expr strings.Builder
+	switch schemaType(schema) {
+	case "string":
+		expr.WriteString("validators.String()")
+		if schema.MinLength != nil {
+			fmt.Fprintf(&expr, ".MinRunes(%d)", *schema.MinLength)
+		}
+		if schema.MaxLength != nil {
+			fmt.Fprintf(&expr, ".MaxRunes(%d)", *schema.MaxLength)
+		}
+		if schema.MinBytes != nil {
+			fmt.Fprintf(&expr, ".Min(%d)", *schema.MinBytes)
+		}
+		if schema.MaxBytes != nil {
+			fmt.Fprintf(&expr, ".MaxBytes(%d)", *schema.MaxBytes)
+		}
+		if schema.Pattern != "" {
+			fmt.Fprintf(&expr, ".Pattern(%s)", goString(schema.Pattern))
+		}
+		switch schema.Format {
+		case "":
+		case "email":
+			expr.WriteString(".Email()")
+		case "uri", "url":
+			expr.WriteString(".URL()")
+		default:
+			// Built-in formats such as date-time and uuid; others are documentation only
+			if validators.IsRegisteredFormat(schema.Format) {
+				fmt.Fprintf(&expr, ".Format(%s)", strconv.Quote(schema.Format))
+			}
+		}
+	case "integer", "number":
+		expr.WriteString("validators.Number()")
+		if schemaType(schema) == "integer" {
+			expr.WriteString(".Integer()")
+		}
+		for _, bound := range []struct {
+			method string
+			value  *float64
+		}{{"Min", schema.Minimum}, {"Max", schema.Maximum}, {"ExclusiveMin", schema.ExclusiveMinimum}, {"ExclusiveMax", schema.ExclusiveMaximum}, {"MultipleOf", schema.MultipleOf}} {
+			if bound.value != nil {
+				fmt.Fprintf(&expr, ".%s(%s)", bound.method, strconv.FormatFloat(*bound.value, 'g', -1, 64))
+			}
+		}
+	case "boolean":
+		expr.WriteString("validators.Bool()")
+	case "array":
+		items := "nil"
+		if schema.Items != nil {
+			items = w.schemaExpr(schema.Items, true)
+		}
+		fmt.Fprintf(&expr, "validators.Array(%s)", items)
+		if schema.MinItems != nil {
+			fmt.Fprintf(&expr, ".MinItems(%d)", *schema.MinItems)
+		}
+		if schema.MaxItems != nil {
+			fmt.Fprintf(&expr, ".MaxItems(%d)", *schema.MaxItems)
+		}
+		if schema.UniqueItems != nil && *schema.UniqueItems {
+			expr.WriteString(".UniqueItems()")
+		}
+	default:
+		expr.WriteString("validators.Object(map[string]interface{}{\n")
+		requiredProperties := make(map[string]bool, len(schema.Required))
+		for _, name := range schema.Required {
+			requiredProperties[name] = true
+		}
+		for _, name := range sortedKeys(schema.Properties) {
+			fmt.Fprintf(&expr, "%s: %s,", strconv.Quote(name), w.schemaExpr(schema.Properties[name], requiredProperties[name]))
+			if enum := schema.Properties[name].Enum; len(enum) > 0 {
+				values := make([]string, len(enum))
+				for i, value := range enum {
+					values[i] = goLiteral(value)
+				}
+				fmt.Fprintf(&expr, " // enum: %s", strings.Join(values, ", "))
+			}
+			expr.WriteString("\n")
+		}
+		expr.WriteString("})")
+	}
+
+	if schema.Example != nil {
+		fmt.Fprintf(&expr, ".Example(%s)", goLiteral(schema.Example))
+	}
+	expr.WriteString(finish)
+	if !required && schema.Default != nil {
+		switch schemaType(schema) {
+		case "string", "boolean":
+			fmt.Fprintf(&expr, ".Default(%s)", goLiteral(schema.Default))
+		case "integer", "number":
+			if value, ok := schema.Default.(float64); ok {
+				fmt.Fprintf(&expr, ".Default(%s)", strconv.FormatFloat(value, 'g', -1, 64))
+			}
+		}
+	}
+	return expr.String()
+}
+
+// mergeAllOf flattens an allOf of object schemas into one object, the usual way specs express
+// inheritance; other compositions are returned unchanged
+func (w *operationWriter) mergeAllOf(schema *goop.OpenAPISchema) *goop.OpenAPISchema {
+	if len(schema.AllOf) == 0 {
+		return schema
+	}
+	merged := *schema
+	merged.AllOf = nil
+	merged.Properties = make(map[string]*goop.OpenAPISchema)
+	for name, property := range schema.Properties {
+		merged.Properties[name] = property
+	}
+	for _, member := range schema.AllOf {
+		resolved, _, ok := w.resolve(member)
+		if !ok {
+			return schema
+		}
+		resolved = w.mergeAllOf(resolved)
+		if schemaType(resolved) != "object" || len(resolved.OneOf)+len(resolved.AnyOf) > 0 {
+			return schema
+		}
+		for name, property := range resolved.Properties {
+			merged.Properties[name] = property
+		}
+		merged.Required = append(merged.Required, resolved.Required...)
+	}
+	merged.Type = "object"
+	return &merged
+}
+
+// goType returns the Go type of values described by schema, writing named struct types for objects
+func (w *operationWriter) goType(out *bytes.Buffer, name, doc, tag string, schema *goop.OpenAPISchema) string {
+	schema, component, ok := w.resolve(schema)
+	if !ok {
+		return "map[string]interface{}"
+	}
+	if component != "" {
+		w.resolving[component] = true
+		defer delete(w.resolving, component)
+	}
+	schema = w.mergeAllOf(schema)
+	if len(schema.OneOf)+len(schema.AnyOf)+len(schema.AllOf) > 0 {
+		return "interface{}"
+	}
+
+	switch schemaType(schema) {
+	case "string":
+		return "string"
+	case "integer":
+		return "int64"
+	case "number":
+		return "float64"
+	case "boolean":
+		return "bool"
+	case "array":
+		if schema.Items == nil {
+			return "[]interface{}"
+		}
+		return "[]" + w.goType(out, name+"Item", "an item of "+doc, "", schema.Items)
+	}
+	if len(schema.Properties) == 0 {
+		return "map[string]interface{}"
+	}
+
+	required := make(map[string]bool, len(schema.Required))
+	for _, property := range schema.Required {
+		required[property] = true
+	}
+	var fields bytes.Buffer
+	used := make(map[string]bool)
+	for _, property := range sortedKeys(schema.Properties) {
+		field := identifier(property)
+		for used[field] {
+			field += "_"
+		}
+		used[field] = true
+
+		fieldType := w.goType(out, name+field, "the "+property+" property", "", schema.Properties[property])
+		if !required[property] && isScalarOrStruct(fieldType) {
+			fieldType = "*" + fieldType
+		}
+		tags := fmt.Sprintf(`json:"%s`, property)
+		if !required[property] {
+			tags += ",omitempty"
+		}
+		tags += `"`
+		if tag != "" {
+			tags = fmt.Sprintf(`%s:"%s" %s`, tag, property, tags)
+		}
+		fmt.Fprintf(&fields, "\t%s %s `%s`\n", field, fieldType, tags)
+	}
+	fmt.Fprintf(out, "// %s holds %s\ntype %s struct {\n%s}\n\n", name, doc, name, fields.String())
+	return name
+}
+
+// schemaType returns the type of schema, inferring object for schemas with properties
+func schemaType(schema *goop.OpenAPISchema) string {
+	if schema.Type == "" && len(schema.Properties) > 0 {
+		return "object"
+	}
+	return schema.Type
+}
+
+// isScalarOrStruct reports whether optional fields of a Go type need a pointer to represent absence
+func isScalarOrStruct(goType string) bool {
+	return !strings.HasPrefix(goType, "[]") && !strings.HasPrefix(goType, "map[") && goType != "interface{}"
+}
+
+// uniqueName returns name, or name with a numeric suffix when it is already taken
+func (w *operationWriter) uniqueName(name string) string {
+	candidate := name
+	for i := 2; w.names[candidate]; i++ {
+		candidate = fmt.Sprintf("%s%d", name, i)
+	}
+	w.names[candidate] = true
+	return candidate
+}
+
+// identifier converts a name like "get /orders/{id}" or "order_id" into an exported Go identifier
+func identifier(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	result := b.String()
+	if result == "" || unicode.IsDigit(rune(result[0])) {
+		result = "Op" + result
+	}
+	return result
+}
+
+func lowerFirst(name string) string {
+	return strings.ToLower(name[:1]) + name[1:]
+}
+
+// goString quotes s as a raw string literal when it has backslashes, as patterns usually do
+func goString(s string) string {
+	if strings.Contains(s, `\`) && !strings.Contains(s, "`") {
+		return "`" + s + "`"
+	}
+	return strconv.Quote(s)
+}
+
+// goLiteral returns a Go expression for a decoded JSON value
+func goLiteral(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "nil"
+	case string:
+		return strconv.Quote(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case int:
+		return strconv.Itoa(v)
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = goLiteral(item)
+		}
+		return "[]interface{}{" + strings.Join(items, ", ") + "}"
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		entries := make([]string, len(keys))
+		for i, key := range keys {
+			entries[i] = strconv.Quote(key) + ": " + goLiteral(v[key])
+		}
+		return "map[string]interface{}{" + strings.Join(entries, ", ") + "}"
+	default:
+		return strconv.Quote(fmt.Sprint(v))
+	}
+}
+
+func sortedKeys(properties map[string]*goop.OpenAPISchema) []string {
+	keys := make([]string, 0, len(properties))
+	for key := range properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
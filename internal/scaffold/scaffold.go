@@ -0,0 +1,150 @@
+// Package scaffold creates new go-op services laid out the way the library recommends:
+//
+//	go.mod
+//	main.go                 router, spec generator, and server startup
+//	schemas/schemas.go      validator schemas shared by operations
+//	operations/items.go     operation definitions and their handlers
+//	Makefile                build, test, and spec generation targets
+package scaffold
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"go/format"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+//go:embed templates
+var templates embed.FS
+
+// templateSuffix keeps template Go files out of the build
+const templateSuffix = ".tmpl"
+
+// Options configure a scaffolded service
+type Options struct {
+	Dir       string // Directory to create the service in
+	Name      string // Service name, used for the API title and binary (defaults to the directory name)
+	Module    string // Go module path (defaults to Name)
+	Framework string // Web framework the service uses, one of Frameworks()
+}
+
+// Frameworks returns the web frameworks services can be scaffolded for, sorted
+func Frameworks() []string {
+	entries, err := fs.ReadDir(templates, "templates")
+	if err != nil {
+		return nil
+	}
+	frameworks := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			frameworks = append(frameworks, entry.Name())
+		}
+	}
+	sort.Strings(frameworks)
+	return frameworks
+}
+
+// templateData is the data available to templates
+type templateData struct {
+	Name   string
+	Title  string
+	Module string
+}
+
+// Service writes a new service skeleton to opts.Dir and returns the paths of the files it
+// created, relative to opts.Dir. Existing files are never overwritten: if any file of the
+// skeleton already exists, nothing is written.
+func Service(opts Options) ([]string, error) {
+	frameworks := Frameworks()
+	if i := sort.SearchStrings(frameworks, opts.Framework); i == len(frameworks) || frameworks[i] != opts.Framework {
+		return nil, fmt.Errorf("unsupported framework %q (supported: %s)", opts.Framework, strings.Join(frameworks, ", "))
+	}
+	root := path.Join("templates", opts.Framework)
+
+	absDir, err := filepath.Abs(opts.Dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve directory: %w", err)
+	}
+	data := templateData{Name: opts.Name, Module: opts.Module}
+	if data.Name == "" {
+		data.Name = filepath.Base(absDir)
+	}
+	if data.Module == "" {
+		data.Module = data.Name
+	}
+	data.Title = title(data.Name)
+
+	files, err := render(root, data)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		if _, err := os.Stat(filepath.Join(absDir, filepath.FromSlash(name))); err == nil {
+			return nil, fmt.Errorf("%s already exists", filepath.Join(opts.Dir, filepath.FromSlash(name)))
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		target := filepath.Join(absDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create directory for %s: %w", name, err)
+		}
+		if err := os.WriteFile(target, files[name], 0o644); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", name, err)
+		}
+	}
+	return names, nil
+}
+
+// render executes every template under root, keyed by output path relative to the service directory
+func render(root string, data templateData) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	err := fs.WalkDir(templates, root, func(name string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		source, err := fs.ReadFile(templates, name)
+		if err != nil {
+			return err
+		}
+		tmpl, err := template.New(name).Parse(string(source))
+		if err != nil {
+			return fmt.Errorf("failed to parse template %s: %w", name, err)
+		}
+		var out bytes.Buffer
+		if err := tmpl.Execute(&out, data); err != nil {
+			return fmt.Errorf("failed to render template %s: %w", name, err)
+		}
+
+		output := strings.TrimSuffix(strings.TrimPrefix(name, root+"/"), templateSuffix)
+		content := out.Bytes()
+		if strings.HasSuffix(output, ".go") {
+			if content, err = format.Source(content); err != nil {
+				return fmt.Errorf("failed to format %s: %w", output, err)
+			}
+		}
+		files[output] = content
+		return nil
+	})
+	return files, err
+}
+
+// title turns a service name such as order-service into an API title such as Order Service API
+func title(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool { return r == '-' || r == '_' || r == ' ' })
+	for i, word := range words {
+		words[i] = strings.ToUpper(word[:1]) + word[1:]
+	}
+	return strings.Join(append(words, "API"), " ")
+}
@@ -0,0 +1,198 @@
+package scaffold
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/picogrid/go-op/operations"
+)
+
+func TestService(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "order-service")
+
+	files, err := Service(Options{Dir: dir, Module: "example.com/orders", Framework: "gin"})
+	if err != nil {
+		t.Fatalf("Service failed: %v", err)
+	}
+	expected := []string{"Makefile", "go.mod", "main.go", "operations/items.go", "schemas/schemas.go"}
+	if !reflect.DeepEqual(files, expected) {
+		t.Errorf("Expected files %v, got %v", expected, files)
+	}
+
+	read := func(name string) string {
+		content, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", name, err)
+		}
+		return string(content)
+	}
+	if goMod := read("go.mod"); !strings.HasPrefix(goMod, "module example.com/orders\n") {
+		t.Errorf("Expected go.mod to declare the module, got:\n%s", goMod)
+	}
+	if main := read("main.go"); !strings.Contains(main, `"Order Service API"`) || !strings.Contains(main, `"example.com/orders/operations"`) {
+		t.Errorf("Expected main.go to use the service title and operations package, got:\n%s", main)
+	}
+	if makefile := read("Makefile"); !strings.Contains(makefile, "BINARY := order-service") {
+		t.Errorf("Expected Makefile to build the service binary, got:\n%s", makefile)
+	}
+}
+
+func TestServiceDoesNotOverwrite(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n"), 0o644); err != nil {
+		t.Fatalf("Failed to create main.go: %v", err)
+	}
+
+	if _, err := Service(Options{Dir: dir, Framework: "gin"}); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Fatalf("Expected an error for the existing main.go, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "go.mod")); !os.IsNotExist(err) {
+		t.Error("Expected no files to be written when one already exists")
+	}
+}
+
+func TestServiceUnsupportedFramework(t *testing.T) {
+	for _, framework := range []string{"", "echo", "gin/schemas"} {
+		if _, err := Service(Options{Dir: t.TempDir(), Framework: framework}); err == nil {
+			t.Errorf("Expected framework %q to be rejected", framework)
+		}
+	}
+}
+
+func TestOperation(t *testing.T) {
+	specFile := filepath.Join(t.TempDir(), "legacy.yaml")
+	spec := `openapi: 3.0.3
+info: {title: Legacy, version: "1.0"}
+paths:
+  /orders/{id}:
+    parameters:
+      - {name: id, in: path, required: true, schema: {type: string}}
+    get:
+      operationId: getOrder
+      summary: Get an order
+      responses:
+        "200":
+          description: The order
+          content:
+            application/json:
+              schema: {$ref: '#/components/schemas/Order'}
+        "404":
+          description: Not found
+    delete:
+      responses:
+        "204": {description: Deleted}
+components:
+  schemas:
+    Order:
+      type: object
+      required: [total]
+      properties:
+        total: {type: number, minimum: 0}
+        note: {type: [string, "null"], maxLength: 200}
+        parent: {$ref: '#/components/schemas/Order'}
+`
+	if err := os.WriteFile(specFile, []byte(spec), 0o644); err != nil {
+		t.Fatalf("Failed to write spec: %v", err)
+	}
+
+	loaded, err := LoadSpec(specFile)
+	if err != nil {
+		t.Fatalf("LoadSpec failed: %v", err)
+	}
+	source, err := Operation(loaded, OperationOptions{Path: "/orders/{id}", Method: "get", Package: "orders"})
+	if err != nil {
+		t.Fatalf("Operation failed: %v", err)
+	}
+
+	for _, expected := range []string{
+		"package orders",
+		`"id": validators.String().Required()`,
+		`"note":   validators.String().MaxRunes(200).Optional()`,
+		`"parent": validators.Object(map[string]interface{}{}).Optional()`,
+		`"total":  validators.Number().Min(0).Required()`,
+		"Id string `uri:\"id\" json:\"id\"`",
+		"Total  float64",
+		`OperationID("getOrder")`,
+		`WithResponseCode(404, nil, "Not found")`,
+		"ginadapter.CreateValidatedHandler(getOrder, GetOrderParamsSchema, nil, nil, GetOrderResponseSchema)",
+		"func getOrder(ctx context.Context, params GetOrderParams, query struct{}, body struct{}) (GetOrderResponse, error)",
+	} {
+		if !strings.Contains(string(source), expected) {
+			t.Errorf("Expected scaffolded source to contain %q, got:\n%s", expected, source)
+		}
+	}
+	if strings.Contains(string(source), "DELETE") {
+		t.Error("Expected only the requested method to be scaffolded")
+	}
+
+	if _, err := Operation(loaded, OperationOptions{Path: "/missing", Package: "orders"}); err == nil {
+		t.Error("Expected an error for a path missing from the spec")
+	}
+	if name := FileName("/orders/{id}"); name != "orders_id.go" {
+		t.Errorf("Expected file name orders_id.go, got %s", name)
+	}
+}
+
+func TestServer(t *testing.T) {
+	specFile := filepath.Join(t.TempDir(), "api.yaml")
+	spec := `openapi: 3.0.3
+info: {title: Orders API, version: "1.0"}
+paths:
+  /health:
+    get:
+      responses:
+        "204": {description: Healthy}
+  /orders:
+    post:
+      operationId: createOrder
+      summary: Create an order
+      parameters:
+        - {name: Idempotency-Key, in: header, required: true, schema: {type: string}}
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema: {type: object, required: [total], properties: {total: {type: number}}}
+      responses:
+        "201":
+          description: Created
+          content:
+            application/json:
+              schema: {type: object, properties: {id: {type: string}}}
+`
+	if err := os.WriteFile(specFile, []byte(spec), 0o644); err != nil {
+		t.Fatalf("Failed to write spec: %v", err)
+	}
+	loaded, err := LoadSpec(specFile)
+	if err != nil {
+		t.Fatalf("LoadSpec failed: %v", err)
+	}
+
+	source, err := Server(loaded, ServerOptions{Package: "api", Source: "api.yaml"})
+	if err != nil {
+		t.Fatalf("Server failed: %v", err)
+	}
+	for _, expected := range []string{
+		"// Code generated by goop server-stub from api.yaml. DO NOT EDIT.",
+		"func CreateOrderOperation(h Handler) operations.CompiledOperation",
+		"ginadapter.CreateValidatedHandlerWithHeaders(h.CreateOrder, nil, nil, CreateOrderBodySchema, CreateOrderHeadersSchema, CreateOrderResponseSchema)",
+		"// CreateOrder serves POST /orders: Create an order",
+		"CreateOrder(ctx context.Context, params struct{}, query struct{}, body CreateOrderBody, headers CreateOrderHeaders) (CreateOrderResponse, error)",
+		"GetHealth(ctx context.Context, params struct{}, query struct{}, body struct{}) (struct{}, error)",
+		"func (UnimplementedHandler) GetHealth(",
+		"\t\tGetHealthOperation(h),\n\t\tCreateOrderOperation(h),\n",
+		"func Register(router operations.Registrar, h Handler) error",
+	} {
+		if !strings.Contains(string(source), expected) {
+			t.Errorf("Expected server stubs to contain %q, got:\n%s", expected, source)
+		}
+	}
+
+	empty := &operations.OpenAPISpec{}
+	if _, err := Server(empty, ServerOptions{Package: "api"}); err == nil {
+		t.Error("Expected an error for a spec without operations")
+	}
+}
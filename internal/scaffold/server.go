@@ -0,0 +1,104 @@
+package scaffold
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/picogrid/go-op/operations"
+)
+
+// ServerFileName is the name of the file server stubs are written to
+const ServerFileName = "server.gen.go"
+
+// ServerOptions configure server stubs generated from a spec
+type ServerOptions struct {
+	Package string // Package name of the generated file
+	Source  string // Name of the spec, mentioned in the generated file's header
+}
+
+// Server returns Go source serving every operation of spec with go-op: validator schemas, typed
+// request and response structs, operation builders, a Handler interface with a method per
+// operation, and the Operations and Register functions wiring a Handler to a router.
+//
+// Unlike Operation, the source is meant to be regenerated whenever the spec changes, so teams
+// that author specs first implement Handler in other files of the package.
+func Server(spec *operations.OpenAPISpec, opts ServerOptions) ([]byte, error) {
+	w := &operationWriter{spec: spec, names: make(map[string]bool), resolving: make(map[string]bool), server: true}
+	for _, name := range []string{"Handler", "UnimplementedHandler", "Operations", "Register"} {
+		w.names[name] = true
+	}
+
+	paths := make([]string, 0, len(spec.Paths))
+	for path := range spec.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		for _, method := range httpMethods {
+			if op, ok := spec.Paths[path][method]; ok {
+				w.writeOperation(strings.ToUpper(method), path, spec.Components.Dereference(op))
+			}
+		}
+	}
+	if len(w.handlers) == 0 {
+		return nil, fmt.Errorf("spec has no operations")
+	}
+	w.writeWiring(spec.Info.Title)
+
+	var out bytes.Buffer
+	source := opts.Source
+	if source == "" {
+		source = "an OpenAPI spec"
+	}
+	fmt.Fprintf(&out, "// Code generated by goop server-stub from %s. DO NOT EDIT.\n\n", source)
+	fmt.Fprintf(&out, "package %s\n\n", opts.Package)
+	w.writeImports(&out)
+	out.Write(w.body.Bytes())
+
+	formatted, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format server stubs: %w", err)
+	}
+	return formatted, nil
+}
+
+// writeWiring writes the Handler interface, its unimplemented default, and the functions
+// registering the operations
+func (w *operationWriter) writeWiring(title string) {
+	if title == "" {
+		title = "the API"
+	}
+	fmt.Fprintf(&w.body, "// Handler serves the operations of %s\n", title)
+	w.body.WriteString("type Handler interface {\n")
+	for _, method := range w.handlers {
+		fmt.Fprintf(&w.body, "\t// %s serves %s", method.name, method.label)
+		if method.summary != "" {
+			fmt.Fprintf(&w.body, ": %s", method.summary)
+		}
+		fmt.Fprintf(&w.body, "\n\t%s%s\n", method.name, method.signature)
+	}
+	w.body.WriteString("}\n\n")
+
+	w.body.WriteString("// UnimplementedHandler answers every operation with an error. Embed it in a Handler so\n")
+	w.body.WriteString("// the Handler keeps compiling when operations are added to the spec.\n")
+	w.body.WriteString("type UnimplementedHandler struct{}\n\n")
+	for _, method := range w.handlers {
+		fmt.Fprintf(&w.body, "// %s reports that %s is not implemented\n", method.name, method.label)
+		fmt.Fprintf(&w.body, "func (UnimplementedHandler) %s%s {\n\tvar result %s\n", method.name, method.signature, method.result)
+		fmt.Fprintf(&w.body, "\treturn result, errors.New(%s)\n}\n\n", strconv.Quote(method.label+" is not implemented"))
+	}
+
+	w.body.WriteString("// Operations returns every operation of the spec, served by h\n")
+	w.body.WriteString("func Operations(h Handler) []operations.CompiledOperation {\n\treturn []operations.CompiledOperation{\n")
+	for _, method := range w.handlers {
+		fmt.Fprintf(&w.body, "\t\t%sOperation(h),\n", method.name)
+	}
+	w.body.WriteString("\t}\n}\n\n")
+
+	w.body.WriteString("// Register registers every operation of the spec, served by h, with router\n")
+	w.body.WriteString("func Register(router operations.Registrar, h Handler) error {\n\treturn router.Register(Operations(h)...)\n}\n")
+}
@@ -0,0 +1,25 @@
+// Package specutil holds helpers shared by the tools that read generated OpenAPI specifications.
+package specutil
+
+import (
+	"sort"
+
+	goop "github.com/picogrid/go-op"
+	"github.com/picogrid/go-op/operations"
+)
+
+// MediaSchema returns the JSON schema of content, or of its first media type if it has no JSON
+func MediaSchema(content map[string]operations.OpenAPIMediaType) *goop.OpenAPISchema {
+	if media, ok := content["application/json"]; ok {
+		return media.Schema
+	}
+	mediaTypes := make([]string, 0, len(content))
+	for mediaType := range content {
+		mediaTypes = append(mediaTypes, mediaType)
+	}
+	sort.Strings(mediaTypes)
+	if len(mediaTypes) > 0 {
+		return content[mediaTypes[0]].Schema
+	}
+	return nil
+}
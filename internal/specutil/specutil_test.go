@@ -0,0 +1,30 @@
+package specutil
+
+import (
+	"testing"
+
+	goop "github.com/picogrid/go-op"
+	"github.com/picogrid/go-op/operations"
+)
+
+func TestMediaSchema(t *testing.T) {
+	jsonSchema := &goop.OpenAPISchema{Type: "object"}
+	csvSchema := &goop.OpenAPISchema{Type: "string"}
+	xmlSchema := &goop.OpenAPISchema{Type: "object"}
+
+	if got := MediaSchema(map[string]operations.OpenAPIMediaType{
+		"text/csv":         {Schema: csvSchema},
+		"application/json": {Schema: jsonSchema},
+	}); got != jsonSchema {
+		t.Errorf("Expected the JSON schema, got %+v", got)
+	}
+	if got := MediaSchema(map[string]operations.OpenAPIMediaType{
+		"text/csv":        {Schema: csvSchema},
+		"application/xml": {Schema: xmlSchema},
+	}); got != xmlSchema {
+		t.Errorf("Expected the first media type's schema, got %+v", got)
+	}
+	if got := MediaSchema(nil); got != nil {
+		t.Errorf("Expected no schema without content, got %+v", got)
+	}
+}
@@ -0,0 +1,47 @@
+package goop
+
+import (
+	"context"
+	"time"
+)
+
+// Response headers of asynchronous operations, pointing clients to the job and its polling interval
+const (
+	LocationHeader   = "Location"
+	RetryAfterHeader = "Retry-After"
+)
+
+// Job statuses, from acceptance to completion
+const (
+	JobPending   = "pending"
+	JobRunning   = "running"
+	JobSucceeded = "succeeded"
+	JobFailed    = "failed"
+)
+
+// Job is the status resource of an asynchronous operation
+// Clients poll it until it is done, then read the result or the error.
+type Job struct {
+	ID string `json:"id"`
+	// OperationID of the operation that accepted the job
+	OperationID string      `json:"operation_id,omitempty"`
+	Status      string      `json:"status"`
+	Result      interface{} `json:"result,omitempty"`
+	Error       string      `json:"error,omitempty"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+}
+
+// Done reports whether the job has finished, successfully or not
+func (j Job) Done() bool {
+	return j.Status == JobSucceeded || j.Status == JobFailed
+}
+
+// JobStore persists the jobs of asynchronous operations
+// Use a shared store, such as a database table, when jobs are polled across replicas.
+type JobStore interface {
+	// Save creates or replaces the job with the same ID
+	Save(ctx context.Context, job Job) error
+	// Get returns the job with id, or nil if there is none
+	Get(ctx context.Context, id string) (*Job, error)
+}
@@ -0,0 +1,17 @@
+package goop
+
+import "testing"
+
+// TestJobDone tests which job statuses are final
+func TestJobDone(t *testing.T) {
+	for status, done := range map[string]bool{
+		JobPending:   false,
+		JobRunning:   false,
+		JobSucceeded: true,
+		JobFailed:    true,
+	} {
+		if got := (Job{Status: status}).Done(); got != done {
+			t.Errorf("Job{Status: %q}.Done() = %v, want %v", status, got, done)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package goop
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// JSONSchemaDialect is the $schema URI of documents produced by ToJSONSchema
+const JSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// componentRefPrefix is the JSON pointer prefix OpenAPI uses for shared component schemas
+const componentRefPrefix = "#/components/schemas/"
+
+// JSONSchemaExporter is implemented by schemas that can export themselves as standalone
+// JSON Schema documents
+type JSONSchemaExporter interface {
+	ToJSONSchema() ([]byte, error)
+}
+
+// ComponentResolver returns the schema registered as a shared component under name
+type ComponentResolver func(name string) (Schema, bool)
+
+// JSONSchemaOption configures ToJSONSchema
+type JSONSchemaOption func(*jsonSchemaConfig)
+
+type jsonSchemaConfig struct {
+	resolve ComponentResolver
+	id      string
+}
+
+// WithComponentResolver resolves component references, which are embedded under $defs
+// so the document stays self-contained
+func WithComponentResolver(resolve ComponentResolver) JSONSchemaOption {
+	return func(c *jsonSchemaConfig) {
+		c.resolve = resolve
+	}
+}
+
+// WithSchemaID sets the $id of the exported document
+func WithSchemaID(id string) JSONSchemaOption {
+	return func(c *jsonSchemaConfig) {
+		c.id = id
+	}
+}
+
+// ToJSONSchema exports schema as a self-contained JSON Schema draft 2020-12 document.
+// The document carries the same constraints as the schema's OpenAPI definition, with
+// OpenAPI-only keywords translated: example becomes examples, and component references
+// point into $defs. References that cannot be resolved are reported as errors, since the
+// document would not be usable on its own.
+func ToJSONSchema(schema Schema, opts ...JSONSchemaOption) ([]byte, error) {
+	generator, ok := schema.(OpenAPIGenerator)
+	if !ok {
+		return nil, fmt.Errorf("schema %T does not expose its constraints", schema)
+	}
+
+	config := &jsonSchemaConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	exporter := &jsonSchemaExporter{config: config, defs: make(map[string]interface{})}
+	root, err := exporter.convert(generator.ToOpenAPISchema())
+	if err != nil {
+		return nil, err
+	}
+
+	root["$schema"] = JSONSchemaDialect
+	if config.id != "" {
+		root["$id"] = config.id
+	}
+	if len(exporter.defs) > 0 {
+		root["$defs"] = exporter.defs
+	}
+	return json.MarshalIndent(root, "", "  ")
+}
+
+// jsonSchemaExporter converts OpenAPI schemas, collecting referenced components
+type jsonSchemaExporter struct {
+	config *jsonSchemaConfig
+	defs   map[string]interface{}
+}
+
+// convert returns the JSON Schema form of schema as a generic JSON object
+func (e *jsonSchemaExporter) convert(schema *OpenAPISchema) (map[string]interface{}, error) {
+	// Keywords shared with OpenAPI are marshaled as-is; subschemas are converted separately
+	shallow := *schema
+	shallow.Properties, shallow.Items, shallow.Contains, shallow.Not = nil, nil, nil, nil
+	shallow.AllOf, shallow.OneOf, shallow.AnyOf, shallow.PrefixItems = nil, nil, nil, nil
+	shallow.If, shallow.Then, shallow.Else = nil, nil, nil
+	shallow.Example = nil
+	if shallow.AdditionalProperties != nil && shallow.AdditionalProperties.Schema != nil {
+		shallow.AdditionalProperties = nil
+	}
+
+	data, err := json.Marshal(shallow)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal schema: %w", err)
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal schema: %w", err)
+	}
+
+	if schema.Example != nil {
+		doc["examples"] = []interface{}{schema.Example}
+	}
+	if strings.HasPrefix(schema.Ref, componentRefPrefix) {
+		name := strings.TrimPrefix(schema.Ref, componentRefPrefix)
+		if err := e.define(name); err != nil {
+			return nil, err
+		}
+		doc["$ref"] = "#/$defs/" + name
+	}
+
+	if len(schema.Properties) > 0 {
+		properties := make(map[string]interface{}, len(schema.Properties))
+		for name, property := range schema.Properties {
+			if properties[name], err = e.convert(property); err != nil {
+				return nil, err
+			}
+		}
+		doc["properties"] = properties
+	}
+	if schema.Items != nil {
+		if doc["items"], err = e.convert(schema.Items); err != nil {
+			return nil, err
+		}
+	}
+	if schema.Not != nil {
+		if doc["not"], err = e.convert(schema.Not); err != nil {
+			return nil, err
+		}
+	}
+	for keyword, subschema := range map[string]*OpenAPISchema{"contains": schema.Contains, "if": schema.If, "then": schema.Then, "else": schema.Else} {
+		if subschema == nil {
+			continue
+		}
+		if doc[keyword], err = e.convert(subschema); err != nil {
+			return nil, err
+		}
+	}
+	if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+		if doc["additionalProperties"], err = e.convert(schema.AdditionalProperties.Schema); err != nil {
+			return nil, err
+		}
+	}
+	for keyword, members := range map[string][]*OpenAPISchema{"prefixItems": schema.PrefixItems, "allOf": schema.AllOf, "oneOf": schema.OneOf, "anyOf": schema.AnyOf} {
+		if len(members) == 0 {
+			continue
+		}
+		converted := make([]interface{}, len(members))
+		for i, member := range members {
+			if converted[i], err = e.convert(member); err != nil {
+				return nil, err
+			}
+		}
+		doc[keyword] = converted
+	}
+	return doc, nil
+}
+
+// define adds the component name to $defs the first time it is referenced
+func (e *jsonSchemaExporter) define(name string) error {
+	if _, exists := e.defs[name]; exists {
+		return nil
+	}
+	if e.config.resolve == nil {
+		return fmt.Errorf("unresolved schema reference %q: use WithComponentResolver", name)
+	}
+	component, ok := e.config.resolve(name)
+	if !ok {
+		return fmt.Errorf("unresolved schema reference %q", name)
+	}
+	generator, ok := component.(OpenAPIGenerator)
+	if !ok {
+		return fmt.Errorf("component %q does not expose its constraints", name)
+	}
+
+	// Reserve the name first so recursive components terminate
+	e.defs[name] = nil
+	definition, err := e.convert(generator.ToOpenAPISchema())
+	if err != nil {
+		return err
+	}
+	e.defs[name] = definition
+	return nil
+}
@@ -0,0 +1,103 @@
+package goop
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// TestToJSONSchema tests standalone JSON Schema export
+func TestToJSONSchema(t *testing.T) {
+	decode := func(t *testing.T, data []byte) map[string]interface{} {
+		t.Helper()
+		var doc map[string]interface{}
+		if err := json.Unmarshal(data, &doc); err != nil {
+			t.Fatalf("Expected valid JSON, got %v", err)
+		}
+		return doc
+	}
+
+	t.Run("Produces a draft 2020-12 document", func(t *testing.T) {
+		minLength := 2
+		schema := &walkTestSchema{schema: &OpenAPISchema{
+			Type: "object",
+			Properties: map[string]*OpenAPISchema{
+				"name": {Type: "string", MinLength: &minLength, Example: "Ada"},
+				"tags": {Type: "array", Items: &OpenAPISchema{Type: "string", Example: "admin"}},
+			},
+			Required: []string{"name"},
+		}}
+
+		data, err := ToJSONSchema(schema, WithSchemaID("https://example.com/user.json"))
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		doc := decode(t, data)
+
+		if doc["$schema"] != JSONSchemaDialect {
+			t.Errorf("Expected $schema %s, got %v", JSONSchemaDialect, doc["$schema"])
+		}
+		if doc["$id"] != "https://example.com/user.json" {
+			t.Errorf("Expected $id to be set, got %v", doc["$id"])
+		}
+
+		name := doc["properties"].(map[string]interface{})["name"].(map[string]interface{})
+		if _, ok := name["example"]; ok {
+			t.Error("Expected OpenAPI example keyword to be removed")
+		}
+		if examples, ok := name["examples"].([]interface{}); !ok || examples[0] != "Ada" {
+			t.Errorf("Expected examples [Ada], got %v", name["examples"])
+		}
+		if name["minLength"] != float64(2) {
+			t.Errorf("Expected minLength 2, got %v", name["minLength"])
+		}
+
+		items := doc["properties"].(map[string]interface{})["tags"].(map[string]interface{})["items"].(map[string]interface{})
+		if examples, ok := items["examples"].([]interface{}); !ok || examples[0] != "admin" {
+			t.Errorf("Expected nested examples to be translated, got %v", items)
+		}
+	})
+
+	t.Run("Embeds referenced components under $defs", func(t *testing.T) {
+		address := &walkTestSchema{schema: &OpenAPISchema{Type: "object", Properties: map[string]*OpenAPISchema{
+			"city": {Type: "string"},
+		}}}
+		schema := &walkTestSchema{schema: &OpenAPISchema{
+			Type: "object",
+			Properties: map[string]*OpenAPISchema{
+				"home": {Ref: "#/components/schemas/Address"},
+				"work": {Ref: "#/components/schemas/Address"},
+			},
+		}}
+		resolve := func(name string) (Schema, bool) {
+			return address, name == "Address"
+		}
+
+		data, err := ToJSONSchema(schema, WithComponentResolver(resolve))
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		doc := decode(t, data)
+
+		home := doc["properties"].(map[string]interface{})["home"].(map[string]interface{})
+		if home["$ref"] != "#/$defs/Address" {
+			t.Errorf("Expected $ref to point into $defs, got %v", home["$ref"])
+		}
+		defs, ok := doc["$defs"].(map[string]interface{})
+		if !ok || defs["Address"] == nil {
+			t.Fatalf("Expected Address definition, got %v", doc["$defs"])
+		}
+	})
+
+	t.Run("Rejects unresolved references", func(t *testing.T) {
+		schema := &walkTestSchema{schema: &OpenAPISchema{Ref: "#/components/schemas/Missing"}}
+		if _, err := ToJSONSchema(schema); err == nil {
+			t.Error("Expected error for unresolved reference")
+		}
+	})
+
+	t.Run("Rejects schemas without OpenAPI support", func(t *testing.T) {
+		if _, err := ToJSONSchema(walkPlainSchema{}); err == nil {
+			t.Error("Expected error for schema without OpenAPI support")
+		}
+	})
+}
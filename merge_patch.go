@@ -0,0 +1,84 @@
+package goop
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// MergePatchContentType is the media type of JSON Merge Patch documents (RFC 7396)
+const MergePatchContentType = "application/merge-patch+json"
+
+// MergePatch is a JSON Merge Patch document (RFC 7396) received as a PATCH request body
+// Unlike a struct with pointer fields, it distinguishes fields the client left out from fields
+// explicitly set to null, which remove the value.
+type MergePatch map[string]interface{}
+
+// Has reports whether the patch sets or removes the named field
+func (p MergePatch) Has(field string) bool {
+	_, ok := p[field]
+	return ok
+}
+
+// IsNull reports whether the patch removes the named field with an explicit null
+func (p MergePatch) IsNull(field string) bool {
+	value, ok := p[field]
+	return ok && value == nil
+}
+
+// Apply returns target with the patch applied as defined by RFC 7396
+// Nested objects are patched recursively, null removes a member, and other values replace it.
+// target is not modified.
+func (p MergePatch) Apply(target map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(target)+len(p))
+	for name, value := range target {
+		result[name] = value
+	}
+	for name, value := range p {
+		switch patch := value.(type) {
+		case nil:
+			delete(result, name)
+		case map[string]interface{}:
+			existing, _ := result[name].(map[string]interface{})
+			result[name] = MergePatch(patch).Apply(existing)
+		default:
+			result[name] = value
+		}
+	}
+	return result
+}
+
+// ApplyTo applies the patch to v, a pointer to a value that round-trips through JSON such as a struct
+//
+// Example:
+//
+//	user, err := store.Get(ctx, params.ID)
+//	if err := patch.ApplyTo(&user); err != nil {
+//	    return User{}, err
+//	}
+func (p MergePatch) ApplyTo(v interface{}) error {
+	target := reflect.ValueOf(v)
+	if target.Kind() != reflect.Pointer || target.IsNil() {
+		return fmt.Errorf("patch target must be a non-nil pointer, got %T", v)
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode patch target: %w", err)
+	}
+	var document map[string]interface{}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return fmt.Errorf("patch target must be a JSON object: %w", err)
+	}
+
+	patched, err := json.Marshal(p.Apply(document))
+	if err != nil {
+		return fmt.Errorf("failed to encode patched value: %w", err)
+	}
+	// Reset the target so removed fields do not keep their previous values
+	target.Elem().Set(reflect.Zero(target.Elem().Type()))
+	if err := json.Unmarshal(patched, v); err != nil {
+		return fmt.Errorf("failed to decode patched value: %w", err)
+	}
+	return nil
+}
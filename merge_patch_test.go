@@ -0,0 +1,76 @@
+package goop
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// TestMergePatch tests applying JSON Merge Patch documents as defined by RFC 7396
+func TestMergePatch(t *testing.T) {
+	t.Run("Has and IsNull distinguish absent fields from nulls", func(t *testing.T) {
+		var patch MergePatch
+		if err := json.Unmarshal([]byte(`{"nickname": null, "email": "ada@example.com"}`), &patch); err != nil {
+			t.Fatalf("Failed to decode patch: %v", err)
+		}
+		if !patch.Has("nickname") || !patch.IsNull("nickname") {
+			t.Error("Expected nickname to be removed")
+		}
+		if !patch.Has("email") || patch.IsNull("email") {
+			t.Error("Expected email to be set")
+		}
+		if patch.Has("name") || patch.IsNull("name") {
+			t.Error("Expected name to be absent")
+		}
+	})
+
+	t.Run("Apply merges recursively", func(t *testing.T) {
+		target := map[string]interface{}{
+			"name":     "Ada",
+			"nickname": "ada",
+			"address":  map[string]interface{}{"city": "London", "zip": "N1"},
+		}
+		patch := MergePatch{
+			"nickname": nil,
+			"address":  map[string]interface{}{"zip": nil, "street": "Main St"},
+			"tags":     []interface{}{"admin"},
+		}
+
+		got := patch.Apply(target)
+		want := map[string]interface{}{
+			"name":    "Ada",
+			"address": map[string]interface{}{"city": "London", "street": "Main St"},
+			"tags":    []interface{}{"admin"},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Expected %v, got %v", want, got)
+		}
+		if target["nickname"] != "ada" {
+			t.Error("Expected target to be left unchanged")
+		}
+	})
+
+	t.Run("ApplyTo patches structs", func(t *testing.T) {
+		type user struct {
+			Name     string  `json:"name"`
+			Nickname *string `json:"nickname,omitempty"`
+			Age      int     `json:"age"`
+		}
+		nickname := "ada"
+		u := user{Name: "Ada", Nickname: &nickname, Age: 36}
+
+		if err := (MergePatch{"nickname": nil, "age": 37}).ApplyTo(&u); err != nil {
+			t.Fatalf("Expected patch to apply, got: %v", err)
+		}
+		if u.Name != "Ada" || u.Nickname != nil || u.Age != 37 {
+			t.Errorf("Unexpected patched user: %+v", u)
+		}
+	})
+
+	t.Run("ApplyTo rejects non-object targets", func(t *testing.T) {
+		values := []string{"a"}
+		if err := (MergePatch{"name": "x"}).ApplyTo(&values); err == nil {
+			t.Error("Expected error for non-object target")
+		}
+	})
+}
@@ -1,17 +1,22 @@
 package goop
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 )
 
 // OpenAPISchema represents the structure of an OpenAPI 3.1 schema
 // This is generated at build time, not runtime, for zero performance overhead
 type OpenAPISchema struct {
+	Ref         string                    `json:"$ref,omitempty" yaml:"$ref,omitempty"`
 	Type        string                    `json:"type,omitempty" yaml:"type,omitempty"`
 	Format      string                    `json:"format,omitempty" yaml:"format,omitempty"`
 	Properties  map[string]*OpenAPISchema `json:"properties,omitempty" yaml:"properties,omitempty"`
 	Items       *OpenAPISchema            `json:"items,omitempty" yaml:"items,omitempty"`
+	PrefixItems []*OpenAPISchema          `json:"prefixItems,omitempty" yaml:"prefixItems,omitempty"`
 	Required    []string                  `json:"required,omitempty" yaml:"required,omitempty"`
 	MinLength   *int                      `json:"minLength,omitempty" yaml:"minLength,omitempty"`
 	MaxLength   *int                      `json:"maxLength,omitempty" yaml:"maxLength,omitempty"`
@@ -23,20 +28,32 @@ type OpenAPISchema struct {
 	Description string                    `json:"description,omitempty" yaml:"description,omitempty"`
 	Example     interface{}               `json:"example,omitempty" yaml:"example,omitempty"`
 
+	// OpenAPI 3.1 Fixed Fields - String content
+	ContentEncoding  string `json:"contentEncoding,omitempty" yaml:"contentEncoding,omitempty"`
+	ContentMediaType string `json:"contentMediaType,omitempty" yaml:"contentMediaType,omitempty"`
+
+	// MinBytes and MaxBytes limit the UTF-8 encoded size of a string, where minLength and maxLength count characters
+	MinBytes *int `json:"x-min-bytes,omitempty" yaml:"x-min-bytes,omitempty"`
+	MaxBytes *int `json:"x-max-bytes,omitempty" yaml:"x-max-bytes,omitempty"`
+
 	// OpenAPI 3.1 Fixed Fields - Numeric validation
 	MultipleOf       *float64 `json:"multipleOf,omitempty" yaml:"multipleOf,omitempty"`
 	ExclusiveMinimum *float64 `json:"exclusiveMinimum,omitempty" yaml:"exclusiveMinimum,omitempty"`
 	ExclusiveMaximum *float64 `json:"exclusiveMaximum,omitempty" yaml:"exclusiveMaximum,omitempty"`
 
 	// OpenAPI 3.1 Fixed Fields - Array validation
-	MaxItems    *int  `json:"maxItems,omitempty" yaml:"maxItems,omitempty"`
-	MinItems    *int  `json:"minItems,omitempty" yaml:"minItems,omitempty"`
-	UniqueItems *bool `json:"uniqueItems,omitempty" yaml:"uniqueItems,omitempty"`
+	MaxItems    *int           `json:"maxItems,omitempty" yaml:"maxItems,omitempty"`
+	MinItems    *int           `json:"minItems,omitempty" yaml:"minItems,omitempty"`
+	UniqueItems *bool          `json:"uniqueItems,omitempty" yaml:"uniqueItems,omitempty"`
+	Contains    *OpenAPISchema `json:"contains,omitempty" yaml:"contains,omitempty"`
+	MinContains *int           `json:"minContains,omitempty" yaml:"minContains,omitempty"`
+	MaxContains *int           `json:"maxContains,omitempty" yaml:"maxContains,omitempty"`
 
 	// OpenAPI 3.1 Fixed Fields - Object validation
 	MaxProperties        *int                 `json:"maxProperties,omitempty" yaml:"maxProperties,omitempty"`
 	MinProperties        *int                 `json:"minProperties,omitempty" yaml:"minProperties,omitempty"`
 	AdditionalProperties *OpenAPISchemaOrBool `json:"additionalProperties,omitempty" yaml:"additionalProperties,omitempty"`
+	DependentRequired    map[string][]string  `json:"dependentRequired,omitempty" yaml:"dependentRequired,omitempty"`
 
 	// OpenAPI 3.1 Fixed Fields - Schema composition
 	AllOf []*OpenAPISchema `json:"allOf,omitempty" yaml:"allOf,omitempty"`
@@ -44,12 +61,116 @@ type OpenAPISchema struct {
 	AnyOf []*OpenAPISchema `json:"anyOf,omitempty" yaml:"anyOf,omitempty"`
 	Not   *OpenAPISchema   `json:"not,omitempty" yaml:"not,omitempty"`
 
+	// OpenAPI 3.1 Fixed Fields - Conditional subschemas
+	If   *OpenAPISchema `json:"if,omitempty" yaml:"if,omitempty"`
+	Then *OpenAPISchema `json:"then,omitempty" yaml:"then,omitempty"`
+	Else *OpenAPISchema `json:"else,omitempty" yaml:"else,omitempty"`
+
 	// OpenAPI 3.1 Fixed Fields - Metadata
 	Title      string      `json:"title,omitempty" yaml:"title,omitempty"`
 	Const      interface{} `json:"const,omitempty" yaml:"const,omitempty"`
 	ReadOnly   *bool       `json:"readOnly,omitempty" yaml:"readOnly,omitempty"`
 	WriteOnly  *bool       `json:"writeOnly,omitempty" yaml:"writeOnly,omitempty"`
 	Deprecated *bool       `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+
+	// Specification extensions (x-*) emitted inline with the schema keywords
+	Extensions map[string]interface{} `json:"-" yaml:",inline"`
+}
+
+// SchemaExtension is a specification extension to attach to a generated schema, such as
+// x-validation: luhn for a check that JSON Schema keywords cannot express
+type SchemaExtension struct {
+	Name  string
+	Value interface{}
+}
+
+// ExtensionName returns name as a specification extension name
+// OpenAPI 3.1 requires extension names to start with "x-"; the prefix is added when missing.
+func ExtensionName(name string) string {
+	if strings.HasPrefix(name, "x-") {
+		return name
+	}
+	return "x-" + name
+}
+
+// SetExtension sets a specification extension on the schema
+// Names without the "x-" prefix OpenAPI 3.1 requires are prefixed, so "validation" sets x-validation
+func (s *OpenAPISchema) SetExtension(name string, value interface{}) {
+	if s.Extensions == nil {
+		s.Extensions = make(map[string]interface{})
+	}
+	s.Extensions[ExtensionName(name)] = value
+}
+
+// MarshalJSON implements custom JSON marshaling so extensions are emitted inline
+func (s OpenAPISchema) MarshalJSON() ([]byte, error) {
+	type schemaAlias OpenAPISchema
+	data, err := json.Marshal(schemaAlias(s))
+	if err != nil || len(s.Extensions) == 0 {
+		return data, err
+	}
+
+	names := make([]string, 0, len(s.Extensions))
+	for name := range s.Extensions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// Splice the extensions into the object before its closing brace
+	buf := data[:len(data)-1]
+	for _, name := range names {
+		key, err := json.Marshal(name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(s.Extensions[name])
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode extension %s: %w", name, err)
+		}
+		if len(buf) > 1 {
+			buf = append(buf, ',')
+		}
+		buf = append(buf, key...)
+		buf = append(buf, ':')
+		buf = append(buf, value...)
+	}
+	return append(buf, '}'), nil
+}
+
+// typedSchemaExtensions are x-* keywords decoded into OpenAPISchema fields rather than Extensions
+var typedSchemaExtensions = map[string]bool{"x-min-bytes": true, "x-max-bytes": true}
+
+// UnmarshalJSON implements custom JSON unmarshaling that collects x-* extensions
+func (s *OpenAPISchema) UnmarshalJSON(data []byte) error {
+	type schemaAlias OpenAPISchema
+	var alias schemaAlias
+	if err := json.Unmarshal(data, &alias); err != nil {
+		return err
+	}
+
+	// Most schemas carry no extensions; skip the second decode for them
+	if bytes.Contains(data, []byte(`"x-`)) {
+		var raw map[string]json.RawMessage
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return err
+		}
+		for name, value := range raw {
+			if !strings.HasPrefix(name, "x-") || typedSchemaExtensions[name] {
+				continue
+			}
+			var decoded interface{}
+			if err := json.Unmarshal(value, &decoded); err != nil {
+				return fmt.Errorf("failed to decode extension %s: %w", name, err)
+			}
+			if alias.Extensions == nil {
+				alias.Extensions = make(map[string]interface{})
+			}
+			alias.Extensions[name] = decoded
+		}
+	}
+
+	*s = OpenAPISchema(alias)
+	return nil
 }
 
 // OpenAPISchemaOrBool represents either a schema or a boolean value
@@ -913,6 +913,38 @@ func TestOpenAPISchemaOrBool(t *testing.T) {
 	})
 }
 
+// TestOpenAPISchemaExtensions tests that x-* extensions are emitted inline and read back
+func TestOpenAPISchemaExtensions(t *testing.T) {
+	maxBytes := 64
+	schema := &OpenAPISchema{Type: "string", MaxBytes: &maxBytes}
+	schema.SetExtension("x-validation", "luhn")
+
+	data, err := json.Marshal(schema)
+	if err != nil {
+		t.Fatalf("Failed to marshal schema: %v", err)
+	}
+	expected := `{"type":"string","x-max-bytes":64,"x-validation":"luhn"}`
+	if string(data) != expected {
+		t.Errorf("Expected JSON '%s', got '%s'", expected, data)
+	}
+
+	var decoded OpenAPISchema
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Failed to unmarshal schema: %v", err)
+	}
+	if decoded.Extensions["x-validation"] != "luhn" {
+		t.Errorf("Expected x-validation extension, got %v", decoded.Extensions)
+	}
+	if _, exists := decoded.Extensions["x-max-bytes"]; exists || decoded.MaxBytes == nil || *decoded.MaxBytes != 64 {
+		t.Errorf("Expected x-max-bytes to decode into MaxBytes only, got %v and %v", decoded.Extensions, decoded.MaxBytes)
+	}
+
+	schema.SetExtension("format-hint", "card")
+	if schema.Extensions["x-format-hint"] != "card" {
+		t.Errorf("Expected names without x- to be prefixed, got %v", schema.Extensions)
+	}
+}
+
 // Helper function for creating bool pointers
 func boolPtr(b bool) *bool {
 	return &b
@@ -0,0 +1,67 @@
+package gin_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	goop "github.com/picogrid/go-op"
+	"github.com/picogrid/go-op/operations"
+	ginadapter "github.com/picogrid/go-op/operations/adapters/gin"
+	"github.com/picogrid/go-op/validators"
+)
+
+// TestAsync tests serving asynchronous operations and their job status endpoint
+func TestAsync(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type Export struct {
+		Format string `json:"format"`
+	}
+	bodySchema := validators.Object(map[string]interface{}{
+		"format": validators.String().Min(1).Required(),
+	}).Required()
+	export := func(ctx context.Context, body Export) (map[string]string, error) {
+		owner, _ := goop.AuthFromContext[string](ctx)
+		return map[string]string{"format": body.Format, "owner": owner}, nil
+	}
+
+	store := operations.NewMemoryJobStore(time.Hour)
+	engine := gin.New()
+	engine.Use(func(c *gin.Context) { ginadapter.SetAuth(c, "usr_1") })
+	router := ginadapter.NewGinRouter(engine)
+	api := router.Group("/api")
+	require.NoError(t, api.Register(
+		operations.NewSimple().POST("/exports").WithBody(bodySchema).Handler(operations.Async(export, store)),
+		operations.NewSimple().POST("/imports").WithBody(bodySchema).Handler(operations.Async(export, store)),
+	))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/exports", strings.NewReader(`{"format": "csv"}`))
+	req.Header.Set("Content-Type", "application/json")
+	engine.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusAccepted, w.Code)
+	assert.Equal(t, "5", w.Header().Get(goop.RetryAfterHeader))
+	location := w.Header().Get(goop.LocationHeader)
+	require.True(t, strings.HasPrefix(location, "/jobs/"), "unexpected Location %q", location)
+
+	var job goop.Job
+	require.Eventually(t, func() bool {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", location, nil)
+		engine.ServeHTTP(w, req)
+		return w.Code == http.StatusOK && json.Unmarshal(w.Body.Bytes(), &job) == nil && job.Done()
+	}, time.Second, 5*time.Millisecond)
+
+	assert.Equal(t, goop.JobSucceeded, job.Status)
+	assert.Equal(t, map[string]interface{}{"format": "csv", "owner": "usr_1"}, job.Result)
+}
@@ -0,0 +1,85 @@
+package gin
+
+import (
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// auditKey is the Gin context key of the auditCapture validated handlers fill in
+const auditKey = "goop.audit"
+
+// auditCapture holds the validated request of an audited operation
+type auditCapture struct {
+	validated bool
+	params    interface{}
+	body      interface{}
+}
+
+// SetAuditSink records audit events for the POST, PUT, PATCH, and DELETE operations registered afterwards
+func (r *GinRouter) SetAuditSink(sink goop.AuditSink) {
+	r.auditSink = sink
+}
+
+// Audit creates middleware that records an audit event to sink for every request to op
+// Events of requests that pass validation in a validated handler carry the path parameters as the
+// target and the body fields as changes, with fields classified with Sensitive or PII masked.
+// Requests rejected earlier are recorded with their status only. Sink failures are logged with
+// the operation logger rather than failing the request, whose response has already been written.
+func Audit(sink goop.AuditSink, op goop.CompiledOperation) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		capture := &auditCapture{}
+		c.Set(auditKey, capture)
+		c.Next()
+
+		event := goop.AuditEvent{
+			OperationID: op.OperationID,
+			Method:      op.Method,
+			Path:        op.Path,
+			Time:        start,
+			StatusCode:  c.Writer.Status(),
+		}
+		event.Actor, _ = c.Get(AuthKey)
+		event.Tenant, _ = c.Get(TenantKey)
+		if capture.validated {
+			event.Target, _ = structToMap(capture.params)
+			event.Changes = auditChanges(op.RequestRedactor, capture.body)
+		}
+
+		if err := sink.Record(c.Request.Context(), event); err != nil {
+			LoggerFromContext(c).ErrorContext(c.Request.Context(), "audit event not recorded",
+				slog.String("operationId", op.OperationID),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+}
+
+// auditChanges returns the fields set by a validated body with classified values masked
+func auditChanges(redactor *goop.Redactor, body interface{}) []goop.AuditChange {
+	if body == nil {
+		return nil
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil
+	}
+	value, err := goop.DecodeJSON(data)
+	if err != nil {
+		return nil
+	}
+	return goop.AuditChanges(redactor.Redact(value))
+}
+
+// auditCaptureFrom returns the capture of the request's Audit middleware, or nil if it is not audited
+func auditCaptureFrom(c *gin.Context) *auditCapture {
+	if value, exists := c.Get(auditKey); exists {
+		return value.(*auditCapture)
+	}
+	return nil
+}
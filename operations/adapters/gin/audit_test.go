@@ -0,0 +1,147 @@
+package gin_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	goop "github.com/picogrid/go-op"
+	"github.com/picogrid/go-op/operations"
+	ginadapter "github.com/picogrid/go-op/operations/adapters/gin"
+	"github.com/picogrid/go-op/validators"
+)
+
+type auditLog struct {
+	mu     sync.Mutex
+	events []goop.AuditEvent
+}
+
+func (l *auditLog) Record(ctx context.Context, event goop.AuditEvent) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = append(l.events, event)
+	return nil
+}
+
+// TestAudit tests recording audit events for mutating operations
+func TestAudit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type UserParams struct {
+		ID string `uri:"id" json:"id"`
+	}
+	type UpdateUser struct {
+		Name     string `json:"name"`
+		Password string `json:"password"`
+		Address  struct {
+			City string `json:"city"`
+		} `json:"address"`
+	}
+	paramsSchema := validators.Object(map[string]interface{}{
+		"id": validators.String().Required(),
+	}).Required()
+	bodySchema := validators.Object(map[string]interface{}{
+		"name":     validators.String().Min(1).Required(),
+		"password": validators.String().Sensitive().Required(),
+		"address": validators.Object(map[string]interface{}{
+			"city": validators.String().Required(),
+		}).Required(),
+	}).Required()
+
+	sink := &auditLog{}
+	engine := gin.New()
+	engine.Use(func(c *gin.Context) {
+		ginadapter.SetAuth(c, "usr_admin")
+	})
+	router := ginadapter.NewGinRouter(engine)
+	router.SetAuditSink(sink)
+
+	update := func(ctx context.Context, params UserParams, _ struct{}, body UpdateUser) (struct{}, error) {
+		return struct{}{}, nil
+	}
+	get := func(ctx context.Context, params UserParams, _ struct{}, _ struct{}) (struct{}, error) {
+		return struct{}{}, nil
+	}
+	require.NoError(t, router.Register(
+		operations.NewSimple().
+			PUT("/users/{id}").
+			OperationID("updateUser").
+			WithParams(paramsSchema).
+			WithBody(bodySchema).
+			Handler(ginadapter.CreateValidatedHandler(update, paramsSchema, nil, bodySchema, nil)),
+		operations.NewSimple().
+			GET("/users/{id}").
+			WithParams(paramsSchema).
+			Handler(ginadapter.CreateValidatedHandler(get, paramsSchema, nil, nil, nil)),
+	))
+
+	request := func(method, body string) int {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, "/users/usr_123", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		engine.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	t.Run("Records validated changes", func(t *testing.T) {
+		require.Equal(t, http.StatusOK, request("PUT", `{"name":"Ada","password":"secret","address":{"city":"London"}}`))
+		require.Len(t, sink.events, 1)
+		event := sink.events[0]
+		assert.Equal(t, "updateUser", event.OperationID)
+		assert.Equal(t, "/users/{id}", event.Path)
+		assert.Equal(t, "usr_admin", event.Actor)
+		assert.Equal(t, map[string]interface{}{"id": "usr_123"}, event.Target)
+		assert.Equal(t, []goop.AuditChange{
+			{Field: "address.city", Value: "London"},
+			{Field: "name", Value: "Ada"},
+			{Field: "password", Value: goop.RedactedValue},
+		}, event.Changes)
+		assert.True(t, event.Succeeded())
+	})
+
+	t.Run("Records rejected requests without changes", func(t *testing.T) {
+		require.Equal(t, http.StatusBadRequest, request("PUT", `{"name":"","password":"secret","address":{"city":"London"}}`))
+		require.Len(t, sink.events, 2)
+		event := sink.events[1]
+		assert.Equal(t, http.StatusBadRequest, event.StatusCode)
+		assert.False(t, event.Succeeded())
+		assert.Nil(t, event.Target)
+		assert.Nil(t, event.Changes)
+	})
+
+	t.Run("Skips reads", func(t *testing.T) {
+		require.Equal(t, http.StatusOK, request("GET", ""))
+		assert.Len(t, sink.events, 2)
+	})
+}
+
+type failingSink struct{}
+
+func (failingSink) Record(ctx context.Context, event goop.AuditEvent) error {
+	return errors.New("audit store unavailable")
+}
+
+// TestAuditSinkFailure tests that sink failures do not change the response
+func TestAuditSinkFailure(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	engine := gin.New()
+	router := ginadapter.NewGinRouter(engine)
+	router.SetAuditSink(failingSink{})
+	require.NoError(t, router.Register(operations.NewSimple().
+		DELETE("/sessions").
+		Handler(gin.HandlerFunc(func(c *gin.Context) { c.Status(http.StatusNoContent) }))))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("DELETE", "/sessions", nil)
+	engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+}
@@ -0,0 +1,39 @@
+package gin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuthKey is the Gin context key holding the authenticated principal
+// Handlers should read it through goop.AuthFromContext rather than this key
+const AuthKey = "goop.auth"
+
+// Authenticator resolves the principal for a request or returns an error if authentication fails
+type Authenticator func(c *gin.Context) (interface{}, error)
+
+// SetAuth stores the authenticated principal on the Gin context
+// Validated handlers expose it to business logic via goop.AuthFromContext
+func SetAuth(c *gin.Context, principal interface{}) {
+	c.Set(AuthKey, principal)
+}
+
+// RequireAuth creates middleware that enforces authentication before the handler runs
+// Requests that fail authentication are rejected with 401 Unauthorized
+func RequireAuth(authenticate Authenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, err := authenticate(c)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error":   "Authentication failed",
+				"details": err.Error(),
+			})
+			c.Abort()
+			return
+		}
+
+		SetAuth(c, principal)
+		c.Next()
+	}
+}
@@ -0,0 +1,80 @@
+package gin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	goop "github.com/picogrid/go-op"
+)
+
+type testUser struct {
+	ID   string
+	Role string
+}
+
+func TestRequireAuth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	authenticate := func(c *gin.Context) (interface{}, error) {
+		if c.GetHeader("Authorization") != "Bearer valid" {
+			return nil, errors.New("invalid token")
+		}
+		return &testUser{ID: "123", Role: "admin"}, nil
+	}
+
+	handler := func(ctx context.Context, _ struct{}, _ struct{}, _ struct{}) (map[string]string, error) {
+		user, ok := goop.AuthFromContext[*testUser](ctx)
+		if !ok {
+			return nil, errors.New("principal not found in context")
+		}
+		return map[string]string{"id": user.ID, "role": user.Role}, nil
+	}
+
+	newRouter := func() *gin.Engine {
+		router := gin.New()
+		router.GET("/me", RequireAuth(authenticate), CreateValidatedHandler(handler, nil, nil, nil, nil))
+		return router
+	}
+
+	t.Run("passes typed principal to handler", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/me", nil)
+		req.Header.Set("Authorization", "Bearer valid")
+		newRouter().ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"id":"123"`)
+		assert.Contains(t, w.Body.String(), `"role":"admin"`)
+	})
+
+	t.Run("rejects unauthenticated requests", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/me", nil)
+		newRouter().ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid token")
+	})
+
+	t.Run("principal set via SetAuth is available", func(t *testing.T) {
+		router := gin.New()
+		router.Use(func(c *gin.Context) {
+			SetAuth(c, &testUser{ID: "456", Role: "viewer"})
+			c.Next()
+		})
+		router.GET("/me", CreateValidatedHandler(handler, nil, nil, nil, nil))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/me", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"id":"456"`)
+	})
+}
@@ -0,0 +1,61 @@
+package gin_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	goop "github.com/picogrid/go-op"
+	"github.com/picogrid/go-op/operations"
+	ginadapter "github.com/picogrid/go-op/operations/adapters/gin"
+	"github.com/picogrid/go-op/validators"
+)
+
+// TestBatch tests serving batch operations with the caller in the item handler context
+func TestBatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type Invite struct {
+		Email string `json:"email"`
+	}
+	itemSchema := validators.Object(map[string]interface{}{
+		"email": validators.String().Email().Required(),
+	}).Required()
+	invite := func(ctx context.Context, item Invite) (map[string]string, error) {
+		inviter, _ := goop.AuthFromContext[string](ctx)
+		return map[string]string{"email": item.Email, "invitedBy": inviter}, nil
+	}
+
+	engine := gin.New()
+	engine.Use(func(c *gin.Context) { ginadapter.SetAuth(c, "usr_1") })
+	generator := operations.NewOpenAPIGenerator("Invites", "1.0.0")
+	router := ginadapter.NewGinRouter(engine, generator)
+	require.NoError(t, router.Register(operations.NewSimple().
+		POST("/invites/batch").
+		Handler(operations.Batch(itemSchema, invite).Concurrency(4))))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/invites/batch", strings.NewReader(`[{"email": "a@example.com"}, {"email": "nope"}]`))
+	req.Header.Set("Content-Type", "application/json")
+	engine.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusMultiStatus, w.Code)
+	var response operations.BatchResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Results, 2)
+	assert.Equal(t, http.StatusOK, response.Results[0].Status)
+	assert.Equal(t, "usr_1", response.Results[0].Resource.(map[string]interface{})["invitedBy"])
+	assert.Equal(t, http.StatusBadRequest, response.Results[1].Status)
+
+	op := generator.Spec.Paths["/invites/batch"]["post"]
+	assert.Contains(t, op.Responses, "207")
+	require.NotNil(t, op.RequestBody)
+	assert.Equal(t, "array", op.RequestBody.Content["application/json"].Schema.Type)
+}
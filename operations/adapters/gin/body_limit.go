@@ -0,0 +1,51 @@
+package gin
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MaxBodyBytes creates middleware that rejects request bodies larger than limit with 413
+// The body is read up to the limit before any handler decodes it, then restored for downstream binding
+func MaxBodyBytes(limit int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.ContentLength > limit {
+			abortPayloadTooLarge(c, limit)
+			return
+		}
+		if c.Request.Body == nil {
+			c.Next()
+			return
+		}
+
+		// Read one byte past the limit to detect oversized bodies without a Content-Length
+		body, err := io.ReadAll(io.LimitReader(c.Request.Body, limit+1))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Failed to read request body",
+				"details": err.Error(),
+			})
+			c.Abort()
+			return
+		}
+		if int64(len(body)) > limit {
+			abortPayloadTooLarge(c, limit)
+			return
+		}
+
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		c.Next()
+	}
+}
+
+func abortPayloadTooLarge(c *gin.Context, limit int64) {
+	c.JSON(http.StatusRequestEntityTooLarge, gin.H{
+		"error":   "Request body too large",
+		"details": fmt.Sprintf("request body must be at most %d bytes", limit),
+	})
+	c.Abort()
+}
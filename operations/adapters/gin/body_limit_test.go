@@ -0,0 +1,67 @@
+package gin_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/picogrid/go-op/operations"
+	ginadapter "github.com/picogrid/go-op/operations/adapters/gin"
+)
+
+// TestMaxBodyBytes tests per-operation request body size limits
+func TestMaxBodyBytes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type NotificationRequest struct {
+		Message string `json:"message"`
+	}
+
+	handler := func(ctx context.Context, _ struct{}, _ struct{}, body NotificationRequest) (map[string]string, error) {
+		return map[string]string{"message": body.Message}, nil
+	}
+
+	engine := gin.New()
+	router := ginadapter.NewGinRouter(engine)
+	op := operations.NewSimple().
+		POST("/notifications").
+		MaxBodyBytes(32).
+		Handler(ginadapter.CreateValidatedHandler(handler, nil, nil, nil, nil))
+	assert.NoError(t, router.Register(op))
+
+	t.Run("accepts bodies within the limit", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/notifications", strings.NewReader(`{"message":"hi"}`))
+		req.Header.Set("Content-Type", "application/json")
+		engine.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("rejects bodies over the declared content length", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/notifications", strings.NewReader(`{"message":"`+strings.Repeat("x", 64)+`"}`))
+		req.Header.Set("Content-Type", "application/json")
+		engine.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+		assert.Contains(t, w.Body.String(), "Request body too large")
+	})
+
+	t.Run("rejects streamed bodies over the limit", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		body := io.MultiReader(strings.NewReader(`{"message":"`), strings.NewReader(strings.Repeat("x", 64)+`"}`))
+		req, _ := http.NewRequest("POST", "/notifications", body)
+		req.ContentLength = -1
+		req.Header.Set("Content-Type", "application/json")
+		engine.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	})
+}
@@ -0,0 +1,40 @@
+package gin_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/picogrid/go-op/operations"
+	ginadapter "github.com/picogrid/go-op/operations/adapters/gin"
+)
+
+// TestBuildInfo tests serving the build info of the generator from the engine root
+func TestBuildInfo(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	generator := operations.NewOpenAPIGenerator("Orders", "1.0.0")
+	generator.SetBuildInfo("2.0.1", "ffda4cf", time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC))
+	generator.ExposeBuildInfo("/meta")
+
+	engine := gin.New()
+	router := ginadapter.NewGinRouter(engine, generator)
+	require.NoError(t, router.Group("/api").Register(
+		operations.NewSimple().GET("/orders").Handler(gin.HandlerFunc(func(c *gin.Context) { c.JSON(http.StatusOK, []string{}) })),
+		operations.NewSimple().GET("/customers").Handler(gin.HandlerFunc(func(c *gin.Context) { c.JSON(http.StatusOK, []string{}) })),
+	))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/meta", nil)
+	engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"version": "2.0.1", "commit": "ffda4cf", "buildTime": "2026-03-01T12:00:00Z"}`, w.Body.String())
+
+	_, documented := generator.Spec.Paths["/meta"]["get"]
+	assert.True(t, documented, "expected the meta operation to be documented")
+}
@@ -0,0 +1,60 @@
+package gin
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// CacheControl creates middleware that lets clients cache successful responses as policy allows
+// Cache-Control and Expires are only set on 2xx responses, so errors are never cached.
+func CacheControl(policy goop.CachePolicy) gin.HandlerFunc {
+	cacheControl := policy.CacheControl()
+
+	return func(c *gin.Context) {
+		writer := &cacheWriter{ResponseWriter: c.Writer, cacheControl: cacheControl, maxAge: policy.MaxAge}
+		c.Writer = writer
+		c.Next()
+		// Responses without a body are written by Gin after the middleware returns
+		if !writer.Written() {
+			writer.setHeaders()
+		}
+	}
+}
+
+// cacheWriter sets the caching headers once the status code is known, just before the headers are sent
+type cacheWriter struct {
+	gin.ResponseWriter
+	cacheControl string
+	maxAge       time.Duration
+	done         bool
+}
+
+func (w *cacheWriter) setHeaders() {
+	if w.done {
+		return
+	}
+	w.done = true
+	if status := w.Status(); status >= 200 && status < 300 {
+		w.Header().Set(goop.CacheControlHeader, w.cacheControl)
+		w.Header().Set(goop.ExpiresHeader, time.Now().Add(w.maxAge).UTC().Format(http.TimeFormat))
+	}
+}
+
+func (w *cacheWriter) WriteHeaderNow() {
+	w.setHeaders()
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *cacheWriter) Write(data []byte) (int, error) {
+	w.setHeaders()
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *cacheWriter) WriteString(s string) (int, error) {
+	w.setHeaders()
+	return w.ResponseWriter.WriteString(s)
+}
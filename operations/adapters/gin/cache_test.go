@@ -0,0 +1,79 @@
+package gin_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	goop "github.com/picogrid/go-op"
+	"github.com/picogrid/go-op/operations"
+	ginadapter "github.com/picogrid/go-op/operations/adapters/gin"
+)
+
+// TestCacheControl tests caching headers on cacheable operations
+func TestCacheControl(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	engine := gin.New()
+	router := ginadapter.NewGinRouter(engine)
+	require.NoError(t, router.Register(
+		operations.NewSimple().
+			GET("/products").
+			Cacheable(5*time.Minute, true).
+			Handler(gin.HandlerFunc(func(c *gin.Context) {
+				if c.Query("fail") != "" {
+					c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Unavailable"})
+					return
+				}
+				c.JSON(http.StatusOK, []string{"widget"})
+			})),
+		operations.NewSimple().
+			GET("/products/count").
+			Cacheable(time.Minute, false).
+			Handler(gin.HandlerFunc(func(c *gin.Context) { c.Status(http.StatusNoContent) })),
+		operations.NewSimple().
+			GET("/cart").
+			Handler(gin.HandlerFunc(func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{}) })),
+	))
+
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", path, nil)
+		engine.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Successful responses may be cached", func(t *testing.T) {
+		w := get("/products")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "public, max-age=300", w.Header().Get(goop.CacheControlHeader))
+
+		expires, err := http.ParseTime(w.Header().Get(goop.ExpiresHeader))
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now().Add(5*time.Minute), expires, 2*time.Second)
+	})
+
+	t.Run("Error responses are not cached", func(t *testing.T) {
+		w := get("/products?fail=1")
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.Empty(t, w.Header().Get(goop.CacheControlHeader))
+		assert.Empty(t, w.Header().Get(goop.ExpiresHeader))
+	})
+
+	t.Run("Responses without a body may be cached", func(t *testing.T) {
+		w := get("/products/count")
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.Equal(t, "private, max-age=60", w.Header().Get(goop.CacheControlHeader))
+		assert.NotEmpty(t, w.Header().Get(goop.ExpiresHeader))
+	})
+
+	t.Run("Other operations are unaffected", func(t *testing.T) {
+		w := get("/cart")
+		assert.Empty(t, w.Header().Get(goop.CacheControlHeader))
+	})
+}
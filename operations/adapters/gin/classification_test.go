@@ -0,0 +1,87 @@
+package gin_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/picogrid/go-op/operations"
+	ginadapter "github.com/picogrid/go-op/operations/adapters/gin"
+	"github.com/picogrid/go-op/validators"
+)
+
+// TestClassifiedFields tests masking fields classified with Sensitive or PII
+func TestClassifiedFields(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type Contact struct {
+		Name  string `json:"name"`
+		Email string `json:"email"`
+		Notes string `json:"notes,omitempty"`
+	}
+
+	contactSchema := operations.Component("ClassifiedContact", validators.Object(map[string]interface{}{
+		"name":  validators.String().Required(),
+		"email": validators.String().Email().PII("email").Required(),
+		"notes": validators.String().Sensitive().Optional(),
+	}).Required())
+
+	var logs bytes.Buffer
+	engine := gin.New()
+	generator := operations.NewOpenAPIGenerator("Contacts", "1.0.0")
+	router := ginadapter.NewGinRouter(engine, generator)
+	router.SetLogger(slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	recorder := operations.NewExampleRecorder(1)
+	router.SetRecorder(recorder)
+
+	handler := func(ctx context.Context, _ struct{}, _ struct{}, body Contact) (Contact, error) {
+		return body, nil
+	}
+	op := operations.NewSimple().
+		POST("/contacts").
+		OperationID("createContact").
+		WithBody(contactSchema).
+		WithResponse(contactSchema).
+		Handler(ginadapter.CreateValidatedHandler(handler, nil, nil, contactSchema, contactSchema))
+	require.NoError(t, router.Register(op))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/contacts", strings.NewReader(`{"name":"Ada","email":"ada@example.com","notes":"VIP"}`))
+	req.Header.Set("Content-Type", "application/json")
+	engine.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "ada@example.com", "responses are not masked")
+
+	t.Run("Recordings", func(t *testing.T) {
+		exchanges := recorder.Exchanges()
+		require.Len(t, exchanges, 1)
+		masked := `{"name":"Ada","email":"[REDACTED]","notes":"[REDACTED]"}`
+		assert.JSONEq(t, masked, string(exchanges[0].RequestBody))
+		assert.JSONEq(t, masked, string(exchanges[0].ResponseBody))
+	})
+
+	t.Run("Logs", func(t *testing.T) {
+		lines := strings.Split(strings.TrimSpace(logs.String()), "\n")
+		var entry map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(lines[len(lines)-1]), &entry))
+		assert.Equal(t, "request completed", entry["msg"])
+		assert.JSONEq(t, `{"name":"Ada","email":"[REDACTED]","notes":"[REDACTED]"}`, entry["requestBody"].(string))
+	})
+
+	t.Run("Spec", func(t *testing.T) {
+		spec, err := json.Marshal(generator.Spec.Components.Schemas["ClassifiedContact"])
+		require.NoError(t, err)
+		assert.Contains(t, string(spec), `"x-data-classification":"pii"`)
+		assert.Contains(t, string(spec), `"x-pii-category":"email"`)
+		assert.Contains(t, string(spec), `"x-data-classification":"sensitive"`)
+	})
+}
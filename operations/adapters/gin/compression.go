@@ -0,0 +1,123 @@
+package gin
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// SetCompression compresses the responses of operations registered afterwards when clients accept it
+// Only bodies of the allowed content types and at least the minimum size are compressed.
+func (r *GinRouter) SetCompression(compression goop.Compression) {
+	r.compression = &compression
+}
+
+// uncompressedKey marks responses that must be sent as written, such as files served in ranges
+const uncompressedKey = "goop.uncompressed"
+
+// skipCompression streams the response of c to the client without buffering or compressing it
+func skipCompression(c *gin.Context) {
+	c.Set(uncompressedKey, true)
+}
+
+// Compress creates middleware that compresses response bodies with the encoding negotiated from
+// Accept-Encoding. Responses are buffered so the size and content type are known before deciding,
+// and bodies already encoded by the handler are sent unchanged. Files, partial content, and other
+// responses that advertise byte ranges are streamed uncompressed, since ranges refer to the
+// uncompressed bytes.
+func Compress(compression goop.Compression) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		writer := &compressWriter{ResponseWriter: c.Writer, c: c}
+		c.Writer = writer
+		c.Next()
+		c.Writer = writer.ResponseWriter
+		if writer.streaming() {
+			c.Writer.WriteHeaderNow()
+			return
+		}
+
+		body := writer.body.Bytes()
+		header := c.Writer.Header()
+		status := c.Writer.Status()
+		if status != http.StatusNoContent && status != http.StatusNotModified && header.Get(goop.ContentEncodingHeader) == "" &&
+			compression.Compressible(header.Get("Content-Type"), len(body)) {
+			// The response depends on Accept-Encoding even when this client gets it uncompressed
+			addVary(header, goop.AcceptEncodingHeader)
+			if encoder, ok := compression.Negotiate(c.GetHeader(goop.AcceptEncodingHeader)); ok {
+				if compressed, ok := encode(encoder, body); ok {
+					body = compressed
+					header.Set(goop.ContentEncodingHeader, encoder.Name)
+				}
+			}
+		}
+
+		if header.Get("Content-Length") != "" {
+			header.Set("Content-Length", strconv.Itoa(len(body)))
+		}
+		_, _ = c.Writer.Write(body)
+	}
+}
+
+// compressWriter buffers the response body for compression, unless the response turns out to be
+// one that must be streamed as written
+type compressWriter struct {
+	gin.ResponseWriter
+	c      *gin.Context
+	body   bytes.Buffer
+	stream *bool
+}
+
+// streaming reports whether the response is written through uncompressed, deciding on first use
+func (w *compressWriter) streaming() bool {
+	if w.stream == nil {
+		header := w.Header()
+		stream := w.c.GetBool(uncompressedKey) || w.Status() == http.StatusPartialContent ||
+			header.Get(goop.ContentRangeHeader) != "" || header.Get(goop.AcceptRangesHeader) != ""
+		w.stream = &stream
+	}
+	return *w.stream
+}
+
+func (w *compressWriter) Write(data []byte) (int, error) {
+	if w.streaming() {
+		return w.ResponseWriter.Write(data)
+	}
+	return w.body.Write(data)
+}
+
+func (w *compressWriter) WriteString(s string) (int, error) {
+	if w.streaming() {
+		return w.ResponseWriter.WriteString(s)
+	}
+	return w.body.WriteString(s)
+}
+
+// encode compresses body with encoder, reporting false if the encoder fails
+func encode(encoder goop.Encoder, body []byte) ([]byte, bool) {
+	var compressed bytes.Buffer
+	w := encoder.NewWriter(&compressed)
+	if _, err := w.Write(body); err != nil {
+		return nil, false
+	}
+	if err := w.Close(); err != nil {
+		return nil, false
+	}
+	return compressed.Bytes(), true
+}
+
+// addVary adds name to the Vary header unless it is already listed
+func addVary(header http.Header, name string) {
+	for _, value := range header.Values("Vary") {
+		for _, listed := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(listed), name) {
+				return
+			}
+		}
+	}
+	header.Add("Vary", name)
+}
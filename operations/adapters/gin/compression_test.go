@@ -0,0 +1,95 @@
+package gin_test
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	goop "github.com/picogrid/go-op"
+	"github.com/picogrid/go-op/operations"
+	ginadapter "github.com/picogrid/go-op/operations/adapters/gin"
+)
+
+// TestCompression tests negotiated response compression
+func TestCompression(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	large := strings.Repeat("widget ", 500)
+	engine := gin.New()
+	router := ginadapter.NewGinRouter(engine)
+	router.SetCompression(goop.Compression{})
+	require.NoError(t, router.Register(
+		operations.NewSimple().
+			GET("/products").
+			Handler(gin.HandlerFunc(func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"description": large}) })),
+		operations.NewSimple().
+			GET("/products/count").
+			Handler(gin.HandlerFunc(func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"count": 3}) })),
+		operations.NewSimple().
+			GET("/products/feed").
+			Handler(gin.HandlerFunc(func(c *gin.Context) {
+				c.Header(goop.ContentRangeHeader, "bytes 0-3499/7000")
+				c.Data(http.StatusPartialContent, "application/json", []byte(large))
+			})),
+		operations.NewSimple().
+			GET("/products/image").
+			Handler(gin.HandlerFunc(func(c *gin.Context) { c.Data(http.StatusOK, "image/png", []byte(large)) })),
+	))
+
+	get := func(path, acceptEncoding string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", path, nil)
+		if acceptEncoding != "" {
+			req.Header.Set(goop.AcceptEncodingHeader, acceptEncoding)
+		}
+		engine.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Compresses large JSON for clients accepting gzip", func(t *testing.T) {
+		w := get("/products", "gzip, deflate")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "gzip", w.Header().Get(goop.ContentEncodingHeader))
+		assert.Equal(t, goop.AcceptEncodingHeader, w.Header().Get("Vary"))
+		assert.Less(t, w.Body.Len(), len(large))
+
+		reader, err := gzip.NewReader(w.Body)
+		require.NoError(t, err)
+		body, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"description":"`+large+`"}`, string(body))
+	})
+
+	t.Run("Sends uncompressed responses to other clients", func(t *testing.T) {
+		w := get("/products", "")
+		assert.Empty(t, w.Header().Get(goop.ContentEncodingHeader))
+		assert.Equal(t, goop.AcceptEncodingHeader, w.Header().Get("Vary"))
+		assert.JSONEq(t, `{"description":"`+large+`"}`, w.Body.String())
+	})
+
+	t.Run("Leaves small responses uncompressed", func(t *testing.T) {
+		w := get("/products/count", "gzip")
+		assert.Empty(t, w.Header().Get(goop.ContentEncodingHeader))
+		assert.JSONEq(t, `{"count":3}`, w.Body.String())
+	})
+
+	t.Run("Leaves other content types uncompressed", func(t *testing.T) {
+		w := get("/products/image", "gzip")
+		assert.Empty(t, w.Header().Get(goop.ContentEncodingHeader))
+		assert.Equal(t, large, w.Body.String())
+	})
+
+	t.Run("Leaves partial content uncompressed", func(t *testing.T) {
+		w := get("/products/feed", "gzip")
+		assert.Equal(t, http.StatusPartialContent, w.Code)
+		assert.Empty(t, w.Header().Get(goop.ContentEncodingHeader))
+		assert.Equal(t, large, w.Body.String())
+	})
+}
@@ -0,0 +1,67 @@
+package gin_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	goop "github.com/picogrid/go-op"
+	ginadapter "github.com/picogrid/go-op/operations/adapters/gin"
+	"github.com/picogrid/go-op/validators"
+)
+
+type listQuery struct {
+	PageSize int `form:"page_size" json:"page_size"`
+}
+
+func TestContextAwareValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// The maximum page size depends on the caller's plan, set by earlier middleware
+	maxPageSize := func(ctx context.Context, size int64) error {
+		limit := int64(50)
+		if plan, _ := ctx.Value("plan").(string); plan == "enterprise" {
+			limit = 500
+		}
+		if size > limit {
+			return goop.NewValidationError("page_size", size, fmt.Sprintf("page_size is limited to %d on this plan", limit))
+		}
+		return nil
+	}
+	querySchema := validators.Object(map[string]interface{}{
+		"page_size": validators.Int().Min(1).CustomCtx(maxPageSize).Required(),
+	}).Required()
+
+	handler := ginadapter.CreateValidatedHandler(
+		func(ctx context.Context, params struct{}, query listQuery, body struct{}) (listQuery, error) {
+			return query, nil
+		},
+		nil, querySchema, nil, nil,
+	)
+
+	engine := gin.New()
+	engine.GET("/items", func(c *gin.Context) {
+		c.Set("plan", c.GetHeader("X-Plan"))
+		handler(c)
+	})
+
+	request := func(plan string, pageSize int) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/items?page_size=%d", pageSize), nil)
+		req.Header.Set("X-Plan", plan)
+		engine.ServeHTTP(w, req)
+		return w
+	}
+
+	w := request("free", 200)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "page_size is limited to 50 on this plan")
+
+	assert.Equal(t, http.StatusOK, request("enterprise", 200).Code)
+	assert.Equal(t, http.StatusOK, request("free", 20).Code)
+}
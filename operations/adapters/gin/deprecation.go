@@ -0,0 +1,31 @@
+package gin
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// Deprecation creates middleware that announces a deprecated operation on every response
+// The Deprecation header uses the RFC 9745 structured date form and Sunset uses an RFC 8594 HTTP-date
+func Deprecation(deprecation goop.Deprecation) gin.HandlerFunc {
+	deprecated := "true"
+	if !deprecation.Since.IsZero() {
+		deprecated = fmt.Sprintf("@%d", deprecation.Since.Unix())
+	}
+	var sunset string
+	if !deprecation.Sunset.IsZero() {
+		sunset = deprecation.Sunset.UTC().Format(http.TimeFormat)
+	}
+
+	return func(c *gin.Context) {
+		c.Header(goop.DeprecationHeader, deprecated)
+		if sunset != "" {
+			c.Header(goop.SunsetHeader, sunset)
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,52 @@
+package gin_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/picogrid/go-op/operations"
+	ginadapter "github.com/picogrid/go-op/operations/adapters/gin"
+)
+
+// TestDeprecation tests Deprecation and Sunset response headers
+func TestDeprecation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	since := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	sunset := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	engine := gin.New()
+	router := ginadapter.NewGinRouter(engine)
+	assert.NoError(t, router.Register(
+		operations.NewSimple().
+			GET("/legacy").
+			Deprecated(since, sunset, "listUsers").
+			Handler(gin.HandlerFunc(func(c *gin.Context) { c.Status(http.StatusNoContent) })),
+		operations.NewSimple().
+			GET("/current").
+			Handler(gin.HandlerFunc(func(c *gin.Context) { c.Status(http.StatusNoContent) })),
+	))
+
+	t.Run("deprecated operations announce deprecation and sunset", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/legacy", nil)
+		engine.ServeHTTP(w, req)
+
+		assert.Equal(t, "@1717200000", w.Header().Get("Deprecation"))
+		assert.Equal(t, "Wed, 01 Jan 2025 00:00:00 GMT", w.Header().Get("Sunset"))
+	})
+
+	t.Run("other operations are unaffected", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/current", nil)
+		engine.ServeHTTP(w, req)
+
+		assert.Empty(t, w.Header().Get("Deprecation"))
+		assert.Empty(t, w.Header().Get("Sunset"))
+	})
+}
@@ -0,0 +1,51 @@
+package gin_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/picogrid/go-op/operations"
+	ginadapter "github.com/picogrid/go-op/operations/adapters/gin"
+	"github.com/picogrid/go-op/validators"
+)
+
+// TestSeedFromExamples tests serving declared examples for operations without handlers
+func TestSeedFromExamples(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	userSchema := validators.Object(map[string]interface{}{
+		"id":   validators.String().Example("usr_123").Required(),
+		"name": validators.String().Example("Jane").Required(),
+	}).Required()
+	notFoundSchema := validators.Object(map[string]interface{}{
+		"message": validators.String().Example("user not found").Required(),
+	}).Required()
+
+	engine := gin.New()
+	api := operations.SeedFromExamples(ginadapter.NewGinRouter(engine).Group("/api"))
+	require.NoError(t, api.Register(
+		operations.NewSimple().GET("/users/{id}").
+			WithParams(validators.Object(map[string]interface{}{"id": validators.String().Required()}).Required()).
+			WithResponse(userSchema).
+			WithNotFoundError(notFoundSchema).
+			Handler(nil),
+	))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/users/usr_9", nil)
+	engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"id": "usr_123", "name": "Jane"}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/api/users/usr_9", nil)
+	req.Header.Set(operations.PreferHeader, "code=404")
+	engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.JSONEq(t, `{"message": "user not found"}`, w.Body.String())
+}
@@ -0,0 +1,64 @@
+package gin
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// serveFile streams the file returned by a download handler
+// Seekable files are served with http.ServeContent, which answers range and conditional requests;
+// other files are streamed whole and advertise that ranges are not supported. Files are sent
+// uncompressed even when the router compresses responses.
+func serveFile(c *gin.Context, file goop.FileResult) {
+	// Ranges refer to the file's bytes, so files are never buffered for compression
+	skipCompression(c)
+	if closer, ok := file.Reader.(io.Closer); ok {
+		defer closer.Close()
+	}
+
+	contentType := file.ContentType
+	if contentType == "" {
+		contentType = goop.DefaultFileContentType
+	}
+	c.Header("Content-Type", contentType)
+	c.Header(goop.ContentDispositionHeader, file.ContentDisposition())
+
+	content, seekable := file.Reader.(io.ReadSeeker)
+	if readerAt, ok := file.Reader.(io.ReaderAt); ok && !seekable && file.Size > 0 {
+		content, seekable = io.NewSectionReader(readerAt, 0, file.Size), true
+	}
+	if seekable {
+		http.ServeContent(c.Writer, c.Request, file.Filename, file.ModTime, content)
+		return
+	}
+
+	c.Header(goop.AcceptRangesHeader, "none")
+	if !file.ModTime.IsZero() {
+		c.Header("Last-Modified", file.ModTime.UTC().Format(http.TimeFormat))
+	}
+	if file.Size > 0 {
+		c.Header("Content-Length", strconv.FormatInt(file.Size, 10))
+	}
+	c.Status(http.StatusOK)
+	if c.Request.Method != http.MethodHead {
+		_, _ = io.Copy(c.Writer, file.Reader)
+	}
+}
+
+// fileResult returns the file of a download handler's result, if it returned one
+func fileResult(result interface{}) (goop.FileResult, bool) {
+	switch file := result.(type) {
+	case goop.FileResult:
+		return file, file.Reader != nil
+	case *goop.FileResult:
+		if file != nil && file.Reader != nil {
+			return *file, true
+		}
+	}
+	return goop.FileResult{}, false
+}
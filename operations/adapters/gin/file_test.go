@@ -0,0 +1,135 @@
+package gin_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	goop "github.com/picogrid/go-op"
+	"github.com/picogrid/go-op/operations"
+	ginadapter "github.com/picogrid/go-op/operations/adapters/gin"
+)
+
+// TestFileDownload tests serving files returned by download handlers
+func TestFileDownload(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	content := "0123456789abcdefghij"
+	modified := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	seekable := func(ctx context.Context, _ struct{}, _ struct{}, _ struct{}) (goop.FileResult, error) {
+		return goop.FileResult{Reader: strings.NewReader(content), ContentType: "text/csv", Filename: "export.csv", ModTime: modified}, nil
+	}
+	streamed := func(ctx context.Context, _ struct{}, _ struct{}, _ struct{}) (*goop.FileResult, error) {
+		return &goop.FileResult{Reader: io.MultiReader(bytes.NewBufferString(content)), Size: int64(len(content))}, nil
+	}
+
+	engine := gin.New()
+	router := ginadapter.NewGinRouter(engine)
+	require.NoError(t, router.Register(
+		operations.NewSimple().GET("/export").WithFileResponse("text/csv").
+			Handler(ginadapter.CreateValidatedHandler(seekable, nil, nil, nil, nil)),
+		operations.NewSimple().GET("/stream").WithFileResponse().
+			Handler(ginadapter.CreateValidatedHandler(streamed, nil, nil, nil, nil)),
+	))
+
+	get := func(path string, headers map[string]string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", path, nil)
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+		engine.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Serves the whole file as an attachment", func(t *testing.T) {
+		w := get("/export", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, content, w.Body.String())
+		assert.Equal(t, "text/csv", w.Header().Get("Content-Type"))
+		assert.Equal(t, "attachment; filename=export.csv", w.Header().Get(goop.ContentDispositionHeader))
+		assert.Equal(t, "bytes", w.Header().Get(goop.AcceptRangesHeader))
+		assert.Equal(t, "Sat, 01 Jun 2024 00:00:00 GMT", w.Header().Get("Last-Modified"))
+	})
+
+	t.Run("Serves requested ranges", func(t *testing.T) {
+		w := get("/export", map[string]string{"Range": "bytes=5-9"})
+		assert.Equal(t, http.StatusPartialContent, w.Code)
+		assert.Equal(t, "56789", w.Body.String())
+		assert.Equal(t, "bytes 5-9/20", w.Header().Get(goop.ContentRangeHeader))
+	})
+
+	t.Run("Rejects ranges outside the file", func(t *testing.T) {
+		w := get("/export", map[string]string{"Range": "bytes=50-60"})
+		assert.Equal(t, http.StatusRequestedRangeNotSatisfiable, w.Code)
+	})
+
+	t.Run("Answers conditional requests", func(t *testing.T) {
+		w := get("/export", map[string]string{"If-Modified-Since": "Sat, 01 Jun 2024 00:00:00 GMT"})
+		assert.Equal(t, http.StatusNotModified, w.Code)
+	})
+
+	t.Run("Streams readers that cannot seek", func(t *testing.T) {
+		w := get("/stream", map[string]string{"Range": "bytes=5-9"})
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, content, w.Body.String())
+		assert.Equal(t, goop.DefaultFileContentType, w.Header().Get("Content-Type"))
+		assert.Equal(t, "inline", w.Header().Get(goop.ContentDispositionHeader))
+		assert.Equal(t, "none", w.Header().Get(goop.AcceptRangesHeader))
+		assert.Equal(t, "20", w.Header().Get("Content-Length"))
+	})
+}
+
+// TestFileDownloadCompression tests that files are streamed uncompressed when compression is enabled
+func TestFileDownloadCompression(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	content := strings.Repeat("id,name\n", 500)
+	download := func(ctx context.Context, _ struct{}, _ struct{}, _ struct{}) (goop.FileResult, error) {
+		return goop.FileResult{Reader: strings.NewReader(content), ContentType: "text/csv", Filename: "export.csv"}, nil
+	}
+
+	engine := gin.New()
+	router := ginadapter.NewGinRouter(engine)
+	router.SetCompression(goop.Compression{})
+	require.NoError(t, router.Register(
+		operations.NewSimple().GET("/export").WithFileResponse("text/csv").
+			Handler(ginadapter.CreateValidatedHandler(download, nil, nil, nil, nil)),
+	))
+
+	get := func(headers map[string]string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/export", nil)
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+		engine.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Ranges are served from the uncompressed file", func(t *testing.T) {
+		w := get(map[string]string{"Range": "bytes=0-1999", goop.AcceptEncodingHeader: "gzip"})
+		assert.Equal(t, http.StatusPartialContent, w.Code)
+		assert.Empty(t, w.Header().Get(goop.ContentEncodingHeader))
+		assert.Equal(t, "bytes 0-1999/4000", w.Header().Get(goop.ContentRangeHeader))
+		assert.Equal(t, "2000", w.Header().Get("Content-Length"))
+		assert.Equal(t, content[:2000], w.Body.String())
+	})
+
+	t.Run("Whole files are sent as stored", func(t *testing.T) {
+		w := get(map[string]string{goop.AcceptEncodingHeader: "gzip"})
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Header().Get(goop.ContentEncodingHeader))
+		assert.Equal(t, "4000", w.Header().Get("Content-Length"))
+		assert.Equal(t, content, w.Body.String())
+	})
+}
@@ -0,0 +1,60 @@
+package gin
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// GinGroup registers operations on a gin.RouterGroup while tracking full paths for generators
+// Group-scoped middleware attached to the underlying RouterGroup applies to every operation
+type GinGroup struct {
+	router *GinRouter
+	group  *gin.RouterGroup
+}
+
+// Group creates a route group with the given path prefix and group-scoped middleware
+func (r *GinRouter) Group(prefix string, middleware ...gin.HandlerFunc) *GinGroup {
+	return &GinGroup{
+		router: r,
+		group:  r.engine.Group(prefix, middleware...),
+	}
+}
+
+// Group creates a nested route group under this group
+func (g *GinGroup) Group(prefix string, middleware ...gin.HandlerFunc) *GinGroup {
+	return &GinGroup{
+		router: g.router,
+		group:  g.group.Group(prefix, middleware...),
+	}
+}
+
+// GinGroup returns the underlying gin.RouterGroup
+// Use it to attach Gin-specific middleware or routes that are not go-op operations
+func (g *GinGroup) GinGroup() *gin.RouterGroup {
+	return g.group
+}
+
+// Use attaches middleware to the group
+func (g *GinGroup) Use(middleware ...gin.HandlerFunc) *GinGroup {
+	g.group.Use(middleware...)
+	return g
+}
+
+// BasePath returns the full path prefix of the group
+func (g *GinGroup) BasePath() string {
+	return g.group.BasePath()
+}
+
+// Register registers operations whose paths are relative to the group prefix
+// Generators receive the full path, so the spec matches the served routes
+func (g *GinGroup) Register(ops ...goop.CompiledOperation) error {
+	for _, op := range ops {
+		if err := g.router.registerOn(g.group, op); err != nil {
+			return fmt.Errorf("failed to register operation %s %s: %w", op.Method, op.Path, err)
+		}
+	}
+	return nil
+}
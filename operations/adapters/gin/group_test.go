@@ -0,0 +1,91 @@
+package gin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// recordingGenerator records processed operations for assertions
+type recordingGenerator struct {
+	paths []string
+}
+
+func (g *recordingGenerator) Process(info goop.OperationInfo) error {
+	g.paths = append(g.paths, info.Path)
+	return nil
+}
+
+func TestGinGroup(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := gin.HandlerFunc(func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"route": c.FullPath(), "tenant": c.GetString("tenant")})
+	})
+
+	t.Run("registers operations on the router group with full spec paths", func(t *testing.T) {
+		engine := gin.New()
+		generator := &recordingGenerator{}
+		router := NewGinRouter(engine, generator)
+
+		v2 := router.Group("/v2")
+		err := v2.Register(goop.CompiledOperation{Method: "GET", Path: "/users/{id}", Handler: handler})
+		assert.NoError(t, err)
+
+		assert.Equal(t, []string{"/v2/users/{id}"}, generator.paths)
+		assert.Equal(t, "/v2/users/{id}", router.GetOperations()[0].Path)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/v2/users/42", nil)
+		engine.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"route":"/v2/users/:id"`)
+	})
+
+	t.Run("group middleware applies to operations", func(t *testing.T) {
+		engine := gin.New()
+		router := NewGinRouter(engine)
+
+		v2 := router.Group("/v2", func(c *gin.Context) {
+			c.Set("tenant", "acme")
+			c.Next()
+		})
+		assert.NoError(t, v2.Register(goop.CompiledOperation{Method: "GET", Path: "/users", Handler: handler}))
+
+		// Plain Gin routes on the underlying group share the same middleware
+		v2.GinGroup().GET("/legacy", handler)
+
+		for _, path := range []string{"/v2/users", "/v2/legacy"} {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", path, nil)
+			engine.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Contains(t, w.Body.String(), `"tenant":"acme"`)
+		}
+	})
+
+	t.Run("nested groups accumulate prefixes", func(t *testing.T) {
+		engine := gin.New()
+		generator := &recordingGenerator{}
+		router := NewGinRouter(engine, generator)
+
+		admin := router.Group("/api").Group("/admin")
+		assert.Equal(t, "/api/admin", admin.BasePath())
+		assert.NoError(t, admin.Register(goop.CompiledOperation{Method: "GET", Path: "/", Handler: handler}))
+
+		assert.Equal(t, []string{"/api/admin"}, generator.paths)
+	})
+
+	t.Run("rejects non-gin handlers", func(t *testing.T) {
+		router := NewGinRouter(gin.New())
+		err := router.Group("/v2").Register(goop.CompiledOperation{Method: "GET", Path: "/users", Handler: "invalid"})
+		assert.Error(t, err)
+	})
+}
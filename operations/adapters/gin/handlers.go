@@ -3,6 +3,8 @@ package gin
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -23,15 +25,47 @@ func structToMap(v interface{}) (map[string]interface{}, error) {
 		return nil, err
 	}
 
-	// Unmarshal JSON to map
-	var m map[string]interface{}
-	if err := json.Unmarshal(data, &m); err != nil {
+	// Decode JSON to map, keeping numbers that float64 would round as json.Number
+	value, err := goop.DecodeJSON(data)
+	if err != nil {
 		return nil, err
 	}
 
+	m, ok := value.(map[string]interface{})
+	if !ok && value != nil {
+		return nil, fmt.Errorf("expected a JSON object, got %T", value)
+	}
 	return m, nil
 }
 
+// requestContext returns the request context carrying the Gin context values, the authenticated
+// principal, the tenant, and the parsed filters
+func requestContext(c *gin.Context) context.Context {
+	// Transfer all Gin context values to standard context
+	// We intentionally use string keys here to preserve Gin's context keys
+	ctx := c.Request.Context()
+	for key, value := range c.Keys {
+		ctx = context.WithValue(ctx, key, value) //nolint:staticcheck // SA1029: Gin uses string keys, we must preserve them
+	}
+
+	// Expose the authenticated principal under a typed key for goop.AuthFromContext
+	if principal, exists := c.Get(AuthKey); exists {
+		ctx = goop.WithAuth(ctx, principal)
+	}
+
+	// Expose the resolved tenant under a typed key for goop.TenantFromContext and tenant limits
+	if tenant, exists := c.Get(TenantKey); exists {
+		ctx = goop.WithTenant(ctx, tenant)
+	}
+
+	// Expose parsed sort keys and filters under a typed key, e.g. for checking cursor tokens
+	if filters, exists := c.Get(FiltersKey); exists {
+		ctx = goop.WithFilters(ctx, filters.(goop.Filters))
+	}
+
+	return ctx
+}
+
 // CreateValidatedHandler creates a high-performance Gin handler with automatic validation
 // This function generates optimized validation code without reflection
 func CreateValidatedHandler[P, Q, B, R any](
@@ -41,36 +75,101 @@ func CreateValidatedHandler[P, Q, B, R any](
 	bodySchema goop.Schema,
 	responseSchema goop.Schema,
 ) GinHandler {
+	withHeaders := func(ctx context.Context, params P, query Q, body B, _ struct{}) (R, error) {
+		return handler(ctx, params, query, body)
+	}
+	return CreateValidatedHandlerWithHeaders(withHeaders, paramsSchema, querySchema, bodySchema, nil, responseSchema)
+}
+
+// CreateValidatedHandlerWithHeaders creates a validated Gin handler that also binds typed request headers
+// Header fields are bound using `header` struct tags and validated by their `json` names,
+// which should match the property names of headerSchema (e.g. `header:"X-Request-ID" json:"X-Request-ID"`)
+func CreateValidatedHandlerWithHeaders[P, Q, B, H, R any](
+	handler goop.HandlerWithHeaders[P, Q, B, H, R],
+	paramsSchema goop.Schema,
+	querySchema goop.Schema,
+	bodySchema goop.Schema,
+	headerSchema goop.Schema,
+	responseSchema goop.Schema,
+) GinHandler {
+	// Compile schemas once so each request uses the precomputed validation path
+	paramsSchema = goop.CompileSchema(paramsSchema)
+	querySchema = goop.CompileSchema(querySchema)
+	bodySchema = goop.CompileSchema(bodySchema)
+	headerSchema = goop.CompileSchema(headerSchema)
+	responseSchema = goop.CompileSchema(responseSchema)
+
 	return func(c *gin.Context) {
 		var params P
 		var query Q
 		var body B
+		var headers H
+
+		// The context is built before validation so CustomCtx checks can read tenant and auth data
+		ctx := requestContext(c)
+
+		// Request validation collects warnings, which are added to the response instead of failing it
+		var warnings []goop.ValidationError
+		validationCtx := goop.WithWarningHandler(ctx, func(warning goop.ValidationError) {
+			warnings = append(warnings, warning)
+		})
+
+		// Validate and bind request headers
+		if headerSchema != nil {
+			if err := c.ShouldBindHeader(&headers); err != nil {
+				_ = c.Error(err).SetType(gin.ErrorTypeBind)
+				c.JSON(http.StatusBadRequest, errorBody(c, "Invalid request headers", err.Error()))
+				return
+			}
+
+			// Types with generated validators are checked directly, skipping map conversion
+			var validationErr error
+			if validatable, ok := any(&headers).(goop.GeneratedValidator); ok {
+				validationErr = validatable.Validate()
+			} else {
+				// Convert struct to map for validation
+				headersMap, err := structToMap(headers)
+				if err != nil {
+					_ = c.Error(err).SetType(gin.ErrorTypeBind)
+					c.JSON(http.StatusBadRequest, errorBody(c, "Failed to process request headers", err.Error()))
+					return
+				}
+				validationErr = goop.ValidateCtx(validationCtx, headerSchema, headersMap)
+			}
+
+			if err := validationErr; err != nil {
+				_ = c.Error(err).SetType(gin.ErrorTypeBind)
+				c.JSON(http.StatusBadRequest, errorBody(c, "Header validation failed", err.Error()))
+				return
+			}
+		}
 
 		// Validate and bind parameters with zero allocation paths
 		if paramsSchema != nil {
 			if err := c.ShouldBindUri(&params); err != nil {
-				c.JSON(http.StatusBadRequest, gin.H{
-					"error":   "Invalid path parameters",
-					"details": err.Error(),
-				})
+				_ = c.Error(err).SetType(gin.ErrorTypeBind)
+				c.JSON(http.StatusBadRequest, errorBody(c, "Invalid path parameters", err.Error()))
 				return
 			}
 
-			// Convert struct to map for validation
-			paramsMap, err := structToMap(params)
-			if err != nil {
-				c.JSON(http.StatusBadRequest, gin.H{
-					"error":   "Failed to process path parameters",
-					"details": err.Error(),
-				})
-				return
+			// Types with generated validators are checked directly, skipping map conversion
+			var validationErr error
+			if validatable, ok := any(&params).(goop.GeneratedValidator); ok {
+				validationErr = validatable.Validate()
+			} else {
+				// Convert struct to map for validation
+				paramsMap, err := structToMap(params)
+				if err != nil {
+					_ = c.Error(err).SetType(gin.ErrorTypeBind)
+					c.JSON(http.StatusBadRequest, errorBody(c, "Failed to process path parameters", err.Error()))
+					return
+				}
+				validationErr = goop.ValidateCtx(validationCtx, paramsSchema, paramsMap)
 			}
 
-			if err := paramsSchema.Validate(paramsMap); err != nil {
-				c.JSON(http.StatusBadRequest, gin.H{
-					"error":   "Path parameter validation failed",
-					"details": err.Error(),
-				})
+			if err := validationErr; err != nil {
+				_ = c.Error(err).SetType(gin.ErrorTypeBind)
+				c.JSON(http.StatusBadRequest, errorBody(c, "Path parameter validation failed", err.Error()))
 				return
 			}
 		}
@@ -78,102 +177,144 @@ func CreateValidatedHandler[P, Q, B, R any](
 		// Validate and bind query parameters
 		if querySchema != nil {
 			if err := c.ShouldBindQuery(&query); err != nil {
-				c.JSON(http.StatusBadRequest, gin.H{
-					"error":   "Invalid query parameters",
-					"details": err.Error(),
-				})
+				_ = c.Error(err).SetType(gin.ErrorTypeBind)
+				c.JSON(http.StatusBadRequest, errorBody(c, "Invalid query parameters", err.Error()))
 				return
 			}
 
-			// Convert struct to map for validation
-			queryMap, err := structToMap(query)
-			if err != nil {
-				c.JSON(http.StatusBadRequest, gin.H{
-					"error":   "Failed to process query parameters",
-					"details": err.Error(),
-				})
-				return
+			// Types with generated validators are checked directly, skipping map conversion
+			var validationErr error
+			if validatable, ok := any(&query).(goop.GeneratedValidator); ok {
+				validationErr = validatable.Validate()
+			} else {
+				// Convert struct to map for validation
+				queryMap, err := structToMap(query)
+				if err != nil {
+					_ = c.Error(err).SetType(gin.ErrorTypeBind)
+					c.JSON(http.StatusBadRequest, errorBody(c, "Failed to process query parameters", err.Error()))
+					return
+				}
+				validationErr = goop.ValidateCtx(validationCtx, querySchema, queryMap)
 			}
 
-			if err := querySchema.Validate(queryMap); err != nil {
-				c.JSON(http.StatusBadRequest, gin.H{
-					"error":   "Query parameter validation failed",
-					"details": err.Error(),
-				})
+			if err := validationErr; err != nil {
+				_ = c.Error(err).SetType(gin.ErrorTypeBind)
+				c.JSON(http.StatusBadRequest, errorBody(c, "Query parameter validation failed", err.Error()))
 				return
 			}
 		}
 
+		// Pass parsed sort and filter expressions to query structs that receive them
+		if filters, exists := c.Get(FiltersKey); exists {
+			if receiver, ok := any(&query).(goop.FilterReceiver); ok {
+				receiver.SetFilters(filters.(goop.Filters))
+			}
+		}
+
 		// Validate and bind request body
 		if bodySchema != nil {
 			if err := c.ShouldBindJSON(&body); err != nil {
-				c.JSON(http.StatusBadRequest, gin.H{
-					"error":   "Invalid request body",
-					"details": err.Error(),
-				})
+				_ = c.Error(err).SetType(gin.ErrorTypeBind)
+				c.JSON(http.StatusBadRequest, errorBody(c, "Invalid request body", err.Error()))
 				return
 			}
 
-			// Convert struct to map for validation
-			// ForStruct validators expect map[string]interface{}, not struct types
-			bodyMap, err := structToMap(body)
-			if err != nil {
-				c.JSON(http.StatusBadRequest, gin.H{
-					"error":   "Failed to process request body",
-					"details": err.Error(),
-				})
-				return
+			// Types with generated validators are checked directly, skipping map conversion
+			var validationErr error
+			if validatable, ok := any(&body).(goop.GeneratedValidator); ok {
+				validationErr = validatable.Validate()
+			} else {
+				// Convert struct to map for validation
+				// ForStruct validators expect map[string]interface{}, not struct types
+				bodyMap, err := structToMap(body)
+				if err != nil {
+					_ = c.Error(err).SetType(gin.ErrorTypeBind)
+					c.JSON(http.StatusBadRequest, errorBody(c, "Failed to process request body", err.Error()))
+					return
+				}
+				validationErr = goop.ValidateCtx(validationCtx, bodySchema, bodyMap)
 			}
 
-			if err := bodySchema.Validate(bodyMap); err != nil {
-				c.JSON(http.StatusBadRequest, gin.H{
-					"error":   "Request body validation failed",
-					"details": err.Error(),
-				})
+			if err := validationErr; err != nil {
+				_ = c.Error(err).SetType(gin.ErrorTypeBind)
+				c.JSON(http.StatusBadRequest, errorBody(c, "Request body validation failed", err.Error()))
 				return
 			}
 		}
 
-		// Transfer all Gin context values to standard context
-		// We intentionally use string keys here to preserve Gin's context keys
-		ctx := c.Request.Context()
-		for key, value := range c.Keys {
-			ctx = context.WithValue(ctx, key, value) //nolint:staticcheck // SA1029: Gin uses string keys, we must preserve them
+		if len(warnings) > 0 {
+			c.Set(WarningsKey, warnings)
+		}
+
+		// Audited operations record the validated target and changes
+		if capture := auditCaptureFrom(c); capture != nil {
+			capture.validated = true
+			if paramsSchema != nil {
+				capture.params = params
+			}
+			if bodySchema != nil {
+				capture.body = body
+			}
 		}
 
 		// Call the business logic handler
-		result, err := handler(ctx, params, query, body)
+		result, err := handler(ctx, params, query, body, headers)
+		if err != nil && (errors.Is(err, context.DeadlineExceeded) || errors.Is(ctx.Err(), context.DeadlineExceeded)) {
+			// The operation deadline set by Timeout middleware was exceeded
+			_ = c.Error(err).SetType(gin.ErrorTypePrivate)
+			c.JSON(http.StatusGatewayTimeout, errorBody(c, "Request timed out", err.Error()))
+			return
+		}
 		if err != nil {
 			// Handle business logic errors
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error":   "Internal server error",
-				"details": err.Error(),
-			})
+			_ = c.Error(err).SetType(gin.ErrorTypePrivate)
+			c.JSON(http.StatusInternalServerError, errorBody(c, "Internal server error", err.Error()))
 			return
 		}
 
-		// Validate response if schema is provided
-		if responseSchema != nil {
-			// Convert struct to map for validation
-			resultMap, err := structToMap(result)
-			if err != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{
-					"error":   "Failed to process response",
-					"details": err.Error(),
-				})
-				return
+		// Downloads stream the returned file rather than a validated JSON body
+		if file, ok := fileResult(result); ok {
+			serveFile(c, file)
+			return
+		}
+
+		if len(warnings) > 0 {
+			// Pointer results receive the warnings directly, value results through their address
+			var body interface{} = &result
+			if _, ok := any(result).(goop.WarningReceiver); ok {
+				body = result
+			}
+			reportWarnings(c, body, warnings)
+		}
+
+		// Validate response if schema is provided and the response validation policy allows it
+		if responseSchema != nil && shouldValidateResponse(c) {
+			// Types with generated validators are checked directly, skipping map conversion
+			var validationErr error
+			if validatable, ok := any(&result).(goop.GeneratedValidator); ok {
+				validationErr = validatable.Validate()
+			} else {
+				// Convert struct to map for validation
+				resultMap, err := structToMap(result)
+				if err != nil {
+					_ = c.Error(err).SetType(gin.ErrorTypePrivate)
+					c.JSON(http.StatusInternalServerError, errorBody(c, "Failed to process response", err.Error()))
+					return
+				}
+				validationErr = goop.ValidateCtx(ctx, responseSchema, resultMap)
 			}
 
-			if err := responseSchema.Validate(resultMap); err != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{
-					"error":   "Response validation failed",
-					"details": err.Error(),
-				})
+			if err := validationErr; err != nil {
+				_ = c.Error(err).SetType(gin.ErrorTypePrivate)
+				c.JSON(http.StatusInternalServerError, errorBody(c, "Response validation failed", err.Error()))
 				return
 			}
 		}
 
-		// Return successful response
+		// Return successful response, keeping the media type of bodies that declare one
+		if typed, ok := any(result).(goop.ContentTyper); ok {
+			c.Header("Content-Type", typed.ContentType())
+		}
 		c.JSON(http.StatusOK, result)
 	}
 }
@@ -185,23 +326,23 @@ func ValidationMiddleware(
 	querySchema goop.Schema,
 	bodySchema goop.Schema,
 ) gin.HandlerFunc {
+	paramsSchema = goop.CompileSchema(paramsSchema)
+	querySchema = goop.CompileSchema(querySchema)
+	bodySchema = goop.CompileSchema(bodySchema)
+
 	return func(c *gin.Context) {
 		// Validate path parameters
 		if paramsSchema != nil {
 			var params interface{}
 			if err := c.ShouldBindUri(&params); err != nil {
-				c.JSON(http.StatusBadRequest, gin.H{
-					"error":   "Invalid path parameters",
-					"details": err.Error(),
-				})
+				_ = c.Error(err).SetType(gin.ErrorTypeBind)
+				c.JSON(http.StatusBadRequest, errorBody(c, "Invalid path parameters", err.Error()))
 				c.Abort()
 				return
 			}
-			if err := paramsSchema.Validate(params); err != nil {
-				c.JSON(http.StatusBadRequest, gin.H{
-					"error":   "Path parameter validation failed",
-					"details": err.Error(),
-				})
+			if err := goop.ValidateCtx(c.Request.Context(), paramsSchema, params); err != nil {
+				_ = c.Error(err).SetType(gin.ErrorTypeBind)
+				c.JSON(http.StatusBadRequest, errorBody(c, "Path parameter validation failed", err.Error()))
 				c.Abort()
 				return
 			}
@@ -212,18 +353,14 @@ func ValidationMiddleware(
 		if querySchema != nil {
 			var query interface{}
 			if err := c.ShouldBindQuery(&query); err != nil {
-				c.JSON(http.StatusBadRequest, gin.H{
-					"error":   "Invalid query parameters",
-					"details": err.Error(),
-				})
+				_ = c.Error(err).SetType(gin.ErrorTypeBind)
+				c.JSON(http.StatusBadRequest, errorBody(c, "Invalid query parameters", err.Error()))
 				c.Abort()
 				return
 			}
-			if err := querySchema.Validate(query); err != nil {
-				c.JSON(http.StatusBadRequest, gin.H{
-					"error":   "Query parameter validation failed",
-					"details": err.Error(),
-				})
+			if err := goop.ValidateCtx(c.Request.Context(), querySchema, query); err != nil {
+				_ = c.Error(err).SetType(gin.ErrorTypeBind)
+				c.JSON(http.StatusBadRequest, errorBody(c, "Query parameter validation failed", err.Error()))
 				c.Abort()
 				return
 			}
@@ -234,18 +371,14 @@ func ValidationMiddleware(
 		if bodySchema != nil {
 			var body interface{}
 			if err := c.ShouldBindJSON(&body); err != nil {
-				c.JSON(http.StatusBadRequest, gin.H{
-					"error":   "Invalid request body",
-					"details": err.Error(),
-				})
+				_ = c.Error(err).SetType(gin.ErrorTypeBind)
+				c.JSON(http.StatusBadRequest, errorBody(c, "Invalid request body", err.Error()))
 				c.Abort()
 				return
 			}
-			if err := bodySchema.Validate(body); err != nil {
-				c.JSON(http.StatusBadRequest, gin.H{
-					"error":   "Request body validation failed",
-					"details": err.Error(),
-				})
+			if err := goop.ValidateCtx(c.Request.Context(), bodySchema, body); err != nil {
+				_ = c.Error(err).SetType(gin.ErrorTypeBind)
+				c.JSON(http.StatusBadRequest, errorBody(c, "Request body validation failed", err.Error()))
 				c.Abort()
 				return
 			}
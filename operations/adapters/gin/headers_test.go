@@ -0,0 +1,87 @@
+package gin_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	ginadapter "github.com/picogrid/go-op/operations/adapters/gin"
+	"github.com/picogrid/go-op/validators"
+)
+
+// TestCreateValidatedHandlerWithHeaders tests typed header binding and validation
+func TestCreateValidatedHandlerWithHeaders(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type RequestHeaders struct {
+		RequestID  string `header:"X-Request-ID" json:"X-Request-ID"`
+		APIVersion string `header:"X-API-Version" json:"X-API-Version,omitempty"`
+	}
+
+	headerSchema := validators.Object(map[string]interface{}{
+		"X-Request-ID":  validators.String().Min(8).Required(),
+		"X-API-Version": validators.String().Pattern(`^v\d+$`).Optional(),
+	}).Required()
+
+	handler := func(ctx context.Context, _ struct{}, _ struct{}, _ struct{}, headers RequestHeaders) (map[string]string, error) {
+		return map[string]string{
+			"requestId":  headers.RequestID,
+			"apiVersion": headers.APIVersion,
+		}, nil
+	}
+
+	newRouter := func() *gin.Engine {
+		router := gin.New()
+		router.GET("/test", ginadapter.CreateValidatedHandlerWithHeaders(handler, nil, nil, nil, headerSchema, nil))
+		return router
+	}
+
+	t.Run("binds valid headers into typed struct", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/test", nil)
+		req.Header.Set("X-Request-ID", "req-12345")
+		req.Header.Set("X-API-Version", "v2")
+		newRouter().ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"requestId":"req-12345"`)
+		assert.Contains(t, w.Body.String(), `"apiVersion":"v2"`)
+	})
+
+	t.Run("rejects missing required header", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/test", nil)
+		newRouter().ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Header validation failed")
+	})
+
+	t.Run("rejects header failing schema constraints", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/test", nil)
+		req.Header.Set("X-Request-ID", "req-12345")
+		req.Header.Set("X-API-Version", "latest")
+		newRouter().ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Header validation failed")
+	})
+
+	t.Run("skips header binding without schema", func(t *testing.T) {
+		router := gin.New()
+		router.GET("/test", ginadapter.CreateValidatedHandlerWithHeaders(handler, nil, nil, nil, nil, nil))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/test", nil)
+		req.Header.Set("X-Request-ID", "req-12345")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"requestId":""`)
+	})
+}
@@ -0,0 +1,64 @@
+package gin_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/picogrid/go-op/operations"
+	ginadapter "github.com/picogrid/go-op/operations/adapters/gin"
+)
+
+// TestHealthCheckOperations tests serving health check operations through the Gin router
+func TestHealthCheckOperations(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ready := true
+	engine := gin.New()
+	router := ginadapter.NewGinRouter(engine)
+	assert.NoError(t, router.Register(operations.HealthCheck(operations.HealthCheckOptions{
+		Checks: map[string]operations.HealthCheckFunc{
+			"database": func(ctx context.Context) error {
+				if !ready {
+					return errors.New("not connected")
+				}
+				return nil
+			},
+		},
+	})...))
+
+	t.Run("liveness is served", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/health", nil)
+		engine.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"status":"up"}`, w.Body.String())
+	})
+
+	t.Run("readiness follows dependency checks", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/ready", nil)
+		engine.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		ready = false
+		w = httptest.NewRecorder()
+		engine.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.Contains(t, w.Body.String(), "not connected")
+	})
+
+	t.Run("health operations are kept for generators", func(t *testing.T) {
+		paths := make([]string, 0, 2)
+		for _, op := range router.GetOperations() {
+			paths = append(paths, op.Path)
+		}
+		assert.ElementsMatch(t, []string{"/health", "/ready"}, paths)
+	})
+}
@@ -0,0 +1,155 @@
+package gin
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// maxIdempotencyKeyLength bounds the accepted Idempotency-Key header length
+const maxIdempotencyKeyLength = 255
+
+// IdempotencyScopeFunc derives the caller scope Idempotency-Key values are stored under
+// Keys sent by callers in different scopes never replay each other's responses.
+type IdempotencyScopeFunc func(c *gin.Context) string
+
+// CallerScope scopes idempotency keys to the tenant ID and the authenticated principal of the request
+// Principals are compared by their formatted value; use a custom IdempotencyScopeFunc returning a
+// stable subject ID when principals carry per-request data.
+func CallerScope(c *gin.Context) string {
+	var scope string
+	if id, exists := c.Get(TenantIDKey); exists {
+		scope += fmt.Sprintf("tenant=%v ", id)
+	}
+	if principal, exists := c.Get(AuthKey); exists {
+		scope += fmt.Sprintf("principal=%v ", principal)
+	}
+	return scope
+}
+
+// SetIdempotencyScope sets how operations registered afterwards scope their idempotency keys
+// A nil scopeFunc uses CallerScope.
+func (r *GinRouter) SetIdempotencyScope(scopeFunc IdempotencyScopeFunc) {
+	r.idempotencyScope = scopeFunc
+}
+
+// Idempotency creates middleware that deduplicates requests by their Idempotency-Key header
+// The first request with a key is processed and its response stored; duplicates replay the stored
+// response, concurrent duplicates receive 409, and reusing a key with a different payload receives 422.
+// Server errors release the key so the client can retry. Keys are scoped to the route and to the
+// caller returned by scopeFunc, which defaults to CallerScope when nil.
+func Idempotency(store goop.IdempotencyStore, scopeFunc IdempotencyScopeFunc) gin.HandlerFunc {
+	if scopeFunc == nil {
+		scopeFunc = CallerScope
+	}
+
+	return func(c *gin.Context) {
+		key := c.GetHeader(goop.IdempotencyKeyHeader)
+		if key == "" || len(key) > maxIdempotencyKeyLength {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid idempotency key",
+				"details": "the Idempotency-Key header is required and must be at most 255 characters",
+			})
+			c.Abort()
+			return
+		}
+
+		// Fingerprint the request body and restore it for downstream binding
+		var bodyBytes []byte
+		if c.Request.Body != nil {
+			var err error
+			bodyBytes, err = io.ReadAll(c.Request.Body)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{
+					"error":   "Failed to read request body",
+					"details": err.Error(),
+				})
+				c.Abort()
+				return
+			}
+			c.Request.Body = io.NopCloser(bytes.NewReader(bodyBytes))
+		}
+		hash := sha256.Sum256(bodyBytes)
+		requestHash := hex.EncodeToString(hash[:])
+
+		// Scope keys to the caller and route so the same key can be used by other tenants and endpoints
+		scopedKey := scopeFunc(c) + c.Request.Method + " " + c.FullPath() + " " + key
+
+		ctx := c.Request.Context()
+		record, reserved, err := store.Reserve(ctx, scopedKey)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "Idempotency store unavailable",
+				"details": err.Error(),
+			})
+			c.Abort()
+			return
+		}
+
+		if record != nil {
+			if record.RequestHash != requestHash {
+				c.JSON(http.StatusUnprocessableEntity, gin.H{
+					"error":   "Idempotency key reused",
+					"details": "the Idempotency-Key was already used with a different request payload",
+				})
+				c.Abort()
+				return
+			}
+			c.Header("Idempotent-Replayed", "true")
+			c.Data(record.StatusCode, record.ContentType, record.Body)
+			c.Abort()
+			return
+		}
+
+		if !reserved {
+			c.JSON(http.StatusConflict, gin.H{
+				"error":   "Request in progress",
+				"details": "a request with this Idempotency-Key is still being processed",
+			})
+			c.Abort()
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		// The request context may have been cancelled by a timeout, which must not leave the key reserved
+		ctx = context.WithoutCancel(ctx)
+		if recorder.Status() >= http.StatusInternalServerError {
+			_ = store.Release(ctx, scopedKey)
+			return
+		}
+
+		_ = store.Complete(ctx, scopedKey, goop.IdempotencyRecord{
+			StatusCode:  recorder.Status(),
+			ContentType: recorder.Header().Get("Content-Type"),
+			Body:        recorder.body.Bytes(),
+			RequestHash: requestHash,
+		})
+	}
+}
+
+// responseRecorder captures the response body while writing it through to the client
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	r.body.Write(data)
+	return r.ResponseWriter.Write(data)
+}
+
+func (r *responseRecorder) WriteString(s string) (int, error) {
+	r.body.WriteString(s)
+	return r.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,231 @@
+package gin_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	goop "github.com/picogrid/go-op"
+	"github.com/picogrid/go-op/operations"
+	ginadapter "github.com/picogrid/go-op/operations/adapters/gin"
+)
+
+// TestIdempotency tests Idempotency-Key deduplication for registered operations
+func TestIdempotency(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type PaymentRequest struct {
+		Amount float64 `json:"amount"`
+	}
+
+	newRouter := func(fail bool) (*gin.Engine, *int32) {
+		var calls int32
+		handler := func(ctx context.Context, _ struct{}, _ struct{}, body PaymentRequest) (map[string]interface{}, error) {
+			n := atomic.AddInt32(&calls, 1)
+			if fail {
+				return nil, errors.New("payment processor unavailable")
+			}
+			return map[string]interface{}{"charge": n, "amount": body.Amount}, nil
+		}
+
+		engine := gin.New()
+		router := ginadapter.NewGinRouter(engine)
+		op := operations.NewSimple().
+			POST("/payments").
+			WithIdempotency(operations.NewMemoryIdempotencyStore(time.Hour)).
+			Handler(ginadapter.CreateValidatedHandler(handler, nil, nil, nil, nil))
+		assert.NoError(t, router.Register(op))
+		return engine, &calls
+	}
+
+	send := func(engine *gin.Engine, key, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/payments", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if key != "" {
+			req.Header.Set("Idempotency-Key", key)
+		}
+		engine.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("replays stored response for duplicate key", func(t *testing.T) {
+		engine, calls := newRouter(false)
+
+		first := send(engine, "key-1", `{"amount":10}`)
+		second := send(engine, "key-1", `{"amount":10}`)
+
+		assert.Equal(t, http.StatusOK, first.Code)
+		assert.Equal(t, http.StatusOK, second.Code)
+		assert.Equal(t, first.Body.String(), second.Body.String())
+		assert.Equal(t, "true", second.Header().Get("Idempotent-Replayed"))
+		assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+	})
+
+	t.Run("processes distinct keys independently", func(t *testing.T) {
+		engine, calls := newRouter(false)
+
+		send(engine, "key-1", `{"amount":10}`)
+		send(engine, "key-2", `{"amount":10}`)
+
+		assert.Equal(t, int32(2), atomic.LoadInt32(calls))
+	})
+
+	t.Run("rejects missing key", func(t *testing.T) {
+		engine, calls := newRouter(false)
+
+		w := send(engine, "", `{"amount":10}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, int32(0), atomic.LoadInt32(calls))
+	})
+
+	t.Run("rejects key reuse with different payload", func(t *testing.T) {
+		engine, _ := newRouter(false)
+
+		send(engine, "key-1", `{"amount":10}`)
+		w := send(engine, "key-1", `{"amount":20}`)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	})
+
+	t.Run("releases key after server error", func(t *testing.T) {
+		engine, calls := newRouter(true)
+
+		first := send(engine, "key-1", `{"amount":10}`)
+		second := send(engine, "key-1", `{"amount":10}`)
+
+		assert.Equal(t, http.StatusInternalServerError, first.Code)
+		assert.Equal(t, http.StatusInternalServerError, second.Code)
+		assert.Equal(t, int32(2), atomic.LoadInt32(calls))
+	})
+
+	t.Run("rejects concurrent duplicate in progress", func(t *testing.T) {
+		store := operations.NewMemoryIdempotencyStore(time.Hour)
+		_, reserved, err := store.Reserve(context.Background(), "POST /payments key-1")
+		assert.NoError(t, err)
+		assert.True(t, reserved)
+
+		engine := gin.New()
+		engine.POST("/payments", ginadapter.Idempotency(store, nil), func(c *gin.Context) {
+			c.JSON(http.StatusOK, gin.H{"ok": true})
+		})
+
+		w := send(engine, "key-1", `{"amount":10}`)
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+}
+
+// contextAwareStore fails writes on a done context, as stores backed by a network client do
+type contextAwareStore struct {
+	*operations.MemoryIdempotencyStore
+}
+
+func (s contextAwareStore) Complete(ctx context.Context, key string, record operations.IdempotencyRecord) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return s.MemoryIdempotencyStore.Complete(ctx, key, record)
+}
+
+func (s contextAwareStore) Release(ctx context.Context, key string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return s.MemoryIdempotencyStore.Release(ctx, key)
+}
+
+// TestIdempotencyAfterTimeout tests that a request outliving its deadline still completes its key
+func TestIdempotencyAfterTimeout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := func(ctx context.Context, _ struct{}, _ struct{}, _ struct{}) (map[string]interface{}, error) {
+		<-ctx.Done()
+		return map[string]interface{}{"charged": true}, nil
+	}
+
+	engine := gin.New()
+	engine.Use(ginadapter.Timeout(10 * time.Millisecond))
+	router := ginadapter.NewGinRouter(engine)
+	assert.NoError(t, router.Register(operations.NewSimple().
+		POST("/payments").
+		WithIdempotency(contextAwareStore{operations.NewMemoryIdempotencyStore(time.Hour)}).
+		Handler(ginadapter.CreateValidatedHandler(handler, nil, nil, nil, nil))))
+
+	send := func() *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/payments", strings.NewReader(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Idempotency-Key", "late")
+		engine.ServeHTTP(w, req)
+		return w
+	}
+
+	first := send()
+	assert.Equal(t, http.StatusOK, first.Code)
+
+	// The key was completed despite the cancelled request context, so the retry is replayed
+	retry := send()
+	assert.Equal(t, http.StatusOK, retry.Code)
+	assert.Equal(t, "true", retry.Header().Get("Idempotent-Replayed"))
+}
+
+// TestIdempotencyCallerScope tests that tenants and principals cannot replay each other's responses
+func TestIdempotencyCallerScope(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := func(ctx context.Context, _ struct{}, _ struct{}, _ struct{}) (map[string]interface{}, error) {
+		tenant, _ := goop.TenantFromContext[string](ctx)
+		principal, _ := goop.AuthFromContext[string](ctx)
+		return map[string]interface{}{"tenant": tenant, "principal": principal}, nil
+	}
+
+	engine := gin.New()
+	engine.Use(func(c *gin.Context) {
+		ginadapter.SetAuth(c, c.GetHeader("X-User"))
+	})
+	router := ginadapter.NewGinRouter(engine)
+	router.UseTenantResolver(goop.TenantHeader("X-Company-ID", func(ctx context.Context, id string) (interface{}, error) {
+		return id, nil
+	}))
+	assert.NoError(t, router.Register(operations.NewSimple().
+		POST("/payments").
+		WithIdempotency(operations.NewMemoryIdempotencyStore(time.Hour)).
+		Handler(ginadapter.CreateValidatedHandler(handler, nil, nil, nil, nil))))
+
+	send := func(tenant, user string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/payments", strings.NewReader(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Idempotency-Key", "shared-key")
+		req.Header.Set("X-Company-ID", tenant)
+		req.Header.Set("X-User", user)
+		engine.ServeHTTP(w, req)
+		return w
+	}
+
+	acme := send("acme", "alice")
+	assert.Equal(t, http.StatusOK, acme.Code)
+	assert.JSONEq(t, `{"tenant":"acme","principal":"alice"}`, acme.Body.String())
+
+	globex := send("globex", "alice")
+	assert.Equal(t, http.StatusOK, globex.Code)
+	assert.Empty(t, globex.Header().Get("Idempotent-Replayed"))
+	assert.JSONEq(t, `{"tenant":"globex","principal":"alice"}`, globex.Body.String())
+
+	otherUser := send("acme", "bob")
+	assert.Empty(t, otherUser.Header().Get("Idempotent-Replayed"))
+	assert.JSONEq(t, `{"tenant":"acme","principal":"bob"}`, otherUser.Body.String())
+
+	retry := send("acme", "alice")
+	assert.Equal(t, "true", retry.Header().Get("Idempotent-Replayed"))
+	assert.Equal(t, acme.Body.String(), retry.Body.String())
+}
@@ -0,0 +1,81 @@
+package gin
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// LoggerKey is the Gin context key holding the operation-scoped logger
+const LoggerKey = "goop.logger"
+
+// SetLogger enables structured logging for operations registered afterwards
+// Registration, validation failures, handler errors, and request latency are logged with operation context
+func (r *GinRouter) SetLogger(logger *slog.Logger) {
+	r.logger = logger
+}
+
+// LoggerFromContext returns the operation-scoped logger set by RequestLogger
+// Falls back to slog.Default when the router has no logger configured
+func LoggerFromContext(c *gin.Context) *slog.Logger {
+	if value, exists := c.Get(LoggerKey); exists {
+		if logger, ok := value.(*slog.Logger); ok {
+			return logger
+		}
+	}
+	return slog.Default()
+}
+
+// RequestLogger creates middleware that logs each request to op with its latency and outcome
+// Validation failures are logged at warn level and handler errors at error level; errors are
+// collected from c.Errors, which validated handlers populate. Request IDs assigned by RequestID
+// are logged with each request. When the logger is enabled for debug level, request bodies are
+// logged too, with fields classified with Sensitive or PII masked.
+func RequestLogger(logger *slog.Logger, op goop.CompiledOperation) gin.HandlerFunc {
+	opLogger := logger.With(
+		slog.String("operationId", op.OperationID),
+		slog.String("method", op.Method),
+		slog.String("path", op.Path),
+	)
+
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Set(LoggerKey, opLogger)
+
+		// Bodies are buffered and restored for downstream binding
+		var requestBody []byte
+		logBody := c.Request.Body != nil && opLogger.Enabled(c.Request.Context(), slog.LevelDebug)
+		if logBody {
+			requestBody, _ = io.ReadAll(c.Request.Body)
+			c.Request.Body = io.NopCloser(bytes.NewReader(requestBody))
+		}
+
+		c.Next()
+
+		attrs := []any{
+			slog.Int("status", c.Writer.Status()),
+			slog.Duration("latency", time.Since(start)),
+		}
+		if id := c.GetString(RequestIDKey); id != "" {
+			attrs = append(attrs, slog.String("requestId", id))
+		}
+		if len(requestBody) > 0 {
+			attrs = append(attrs, slog.String("requestBody", string(op.RequestRedactor.RedactJSON(requestBody))))
+		}
+
+		if private := c.Errors.ByType(gin.ErrorTypePrivate); len(private) > 0 {
+			opLogger.ErrorContext(c.Request.Context(), "handler failed", append(attrs, slog.String("error", private.String()))...)
+			return
+		}
+		if bind := c.Errors.ByType(gin.ErrorTypeBind); len(bind) > 0 {
+			opLogger.WarnContext(c.Request.Context(), "request validation failed", append(attrs, slog.String("error", bind.String()))...)
+			return
+		}
+		opLogger.InfoContext(c.Request.Context(), "request completed", attrs...)
+	}
+}
@@ -0,0 +1,113 @@
+package gin_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/picogrid/go-op/operations"
+	ginadapter "github.com/picogrid/go-op/operations/adapters/gin"
+	"github.com/picogrid/go-op/validators"
+)
+
+// TestLogging tests structured request logging with operation context
+func TestLogging(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type CreateUserRequest struct {
+		Email string `json:"email"`
+	}
+
+	bodySchema := validators.Object(map[string]interface{}{
+		"email": validators.String().Email().Required(),
+	}).Required()
+
+	newRouter := func(buf *bytes.Buffer) *gin.Engine {
+		handler := func(ctx context.Context, _ struct{}, _ struct{}, body CreateUserRequest) (map[string]string, error) {
+			if body.Email == "fail@example.com" {
+				return nil, errors.New("database unavailable")
+			}
+			return map[string]string{"email": body.Email}, nil
+		}
+
+		engine := gin.New()
+		router := ginadapter.NewGinRouter(engine)
+		router.SetLogger(slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+		op := operations.NewSimple().
+			POST("/users").
+			OperationID("createUser").
+			WithBody(bodySchema).
+			Handler(ginadapter.CreateValidatedHandler(handler, nil, nil, bodySchema, nil))
+		assert.NoError(t, router.Register(op))
+		return engine
+	}
+
+	send := func(engine *gin.Engine, body string) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/users", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		engine.ServeHTTP(w, req)
+	}
+
+	lastEntry := func(t *testing.T, buf *bytes.Buffer) map[string]interface{} {
+		t.Helper()
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		var entry map[string]interface{}
+		assert.NoError(t, json.Unmarshal([]byte(lines[len(lines)-1]), &entry))
+		return entry
+	}
+
+	t.Run("logs registration", func(t *testing.T) {
+		var buf bytes.Buffer
+		newRouter(&buf)
+
+		entry := lastEntry(t, &buf)
+		assert.Equal(t, "registered operation", entry["msg"])
+		assert.Equal(t, "createUser", entry["operationId"])
+	})
+
+	t.Run("logs completed requests with latency", func(t *testing.T) {
+		var buf bytes.Buffer
+		engine := newRouter(&buf)
+		send(engine, `{"email":"user@example.com"}`)
+
+		entry := lastEntry(t, &buf)
+		assert.Equal(t, "request completed", entry["msg"])
+		assert.Equal(t, "INFO", entry["level"])
+		assert.Equal(t, "/users", entry["path"])
+		assert.Equal(t, float64(http.StatusOK), entry["status"])
+		assert.Contains(t, entry, "latency")
+	})
+
+	t.Run("logs validation failures", func(t *testing.T) {
+		var buf bytes.Buffer
+		engine := newRouter(&buf)
+		send(engine, `{"email":"not-an-email"}`)
+
+		entry := lastEntry(t, &buf)
+		assert.Equal(t, "request validation failed", entry["msg"])
+		assert.Equal(t, "WARN", entry["level"])
+		assert.NotEmpty(t, entry["error"])
+	})
+
+	t.Run("logs handler errors", func(t *testing.T) {
+		var buf bytes.Buffer
+		engine := newRouter(&buf)
+		send(engine, `{"email":"fail@example.com"}`)
+
+		entry := lastEntry(t, &buf)
+		assert.Equal(t, "handler failed", entry["msg"])
+		assert.Equal(t, "ERROR", entry["level"])
+		assert.Contains(t, entry["error"], "database unavailable")
+		assert.Equal(t, "createUser", entry["operationId"])
+	})
+}
@@ -0,0 +1,32 @@
+package gin
+
+import (
+	goop "github.com/picogrid/go-op"
+)
+
+// OperationMiddleware wraps an operation's handler with access to the operation's metadata
+// It is called once per operation at registration, so decisions based only on metadata, such as
+// which tags require authorization, can be made before any request is served.
+type OperationMiddleware func(op goop.OperationInfo, next GinHandler) GinHandler
+
+// UseOperationMiddleware adds middleware wrapping the handler of every operation registered afterwards
+// Middleware runs in the order added, after the router's built-in middleware such as timeouts and
+// rate limiting, and before the operation handler validates the request.
+//
+// Example:
+//
+//	router.UseOperationMiddleware(func(op goop.OperationInfo, next gin.HandlerFunc) gin.HandlerFunc {
+//	    if !slices.Contains(op.Tags, "admin") {
+//	        return next
+//	    }
+//	    return func(c *gin.Context) {
+//	        if !isAdmin(c) {
+//	            c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Forbidden"})
+//	            return
+//	        }
+//	        next(c)
+//	    }
+//	})
+func (r *GinRouter) UseOperationMiddleware(middleware ...OperationMiddleware) {
+	r.operationMiddleware = append(r.operationMiddleware, middleware...)
+}
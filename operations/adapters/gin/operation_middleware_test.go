@@ -0,0 +1,72 @@
+package gin_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	goop "github.com/picogrid/go-op"
+	"github.com/picogrid/go-op/operations"
+	ginadapter "github.com/picogrid/go-op/operations/adapters/gin"
+)
+
+// TestOperationMiddleware tests middleware with access to operation metadata
+func TestOperationMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	engine := gin.New()
+	router := ginadapter.NewGinRouter(engine)
+
+	var audit []string
+	router.UseOperationMiddleware(
+		func(op goop.OperationInfo, next gin.HandlerFunc) gin.HandlerFunc {
+			return func(c *gin.Context) {
+				audit = append(audit, op.Operation.OperationID)
+				next(c)
+			}
+		},
+		func(op goop.OperationInfo, next gin.HandlerFunc) gin.HandlerFunc {
+			if !slices.Contains(op.Tags, "admin") {
+				return next
+			}
+			return func(c *gin.Context) {
+				if c.GetHeader("X-Role") != "admin" {
+					c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Forbidden"})
+					return
+				}
+				next(c)
+			}
+		},
+	)
+
+	noContent := gin.HandlerFunc(func(c *gin.Context) { c.Status(http.StatusNoContent) })
+	group := router.Group("/v1")
+	require.NoError(t, group.Register(
+		operations.NewSimple().GET("/reports").OperationID("listReports").Tags("admin").Handler(noContent),
+		operations.NewSimple().GET("/health").OperationID("health").Handler(noContent),
+	))
+
+	serve := func(path, role string) int {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", path, nil)
+		req.Header.Set("X-Role", role)
+		engine.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	t.Run("middleware sees operation metadata", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, serve("/v1/reports", "viewer"))
+		assert.Equal(t, http.StatusNoContent, serve("/v1/reports", "admin"))
+		assert.Equal(t, http.StatusNoContent, serve("/v1/health", "viewer"))
+	})
+
+	t.Run("middleware runs in the order added", func(t *testing.T) {
+		// The audit middleware wraps the authorization check, so rejected requests are audited too
+		assert.Equal(t, []string{"listReports", "listReports", "health"}, audit)
+	})
+}
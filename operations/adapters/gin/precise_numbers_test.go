@@ -0,0 +1,61 @@
+package gin_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	ginadapter "github.com/picogrid/go-op/operations/adapters/gin"
+	"github.com/picogrid/go-op/validators"
+)
+
+// TestPreciseNumberValidation tests that request bodies are validated without float64 rounding
+func TestPreciseNumberValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type Transfer struct {
+		ID     int64  `json:"id"`
+		Amount string `json:"amount"`
+	}
+
+	bodySchema := validators.Object(map[string]interface{}{
+		"id":     validators.Integer64().Max(9007199254740992).Required(),
+		"amount": validators.Decimal().Precision(12, 2).Required(),
+	}).Required()
+
+	handler := ginadapter.CreateValidatedHandler(
+		func(ctx context.Context, params struct{}, query struct{}, body Transfer) (Transfer, error) {
+			return body, nil
+		},
+		nil, nil, bodySchema, nil,
+	)
+
+	engine := gin.New()
+	engine.POST("/transfers", handler)
+
+	post := func(body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/transfers", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		engine.ServeHTTP(w, req)
+		return w
+	}
+
+	w := post(`{"id": 9007199254740992, "amount": "100.25"}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"id":9007199254740992`)
+
+	// Rounded to float64, this ID would equal the maximum
+	w = post(`{"id": 9007199254740993, "amount": "100.25"}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "maximum is 9007199254740992")
+
+	w = post(`{"id": 1, "amount": "100.255"}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "at most 2 decimal places")
+}
@@ -0,0 +1,76 @@
+package gin
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// QueryStyles creates middleware that normalizes styled query parameters into the form Gin binds
+// Delimited arrays become repeated keys, and deepObject parameters (filter[status]=active)
+// become a single JSON value that binds into map or struct fields. deepObject properties are bound as strings.
+func QueryStyles(styles map[string]goop.ParameterSerialization) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if len(styles) > 0 {
+			normalizeQuery(c.Request, styles)
+		}
+		c.Next()
+	}
+}
+
+// normalizeQuery rewrites the request query string according to the parameter styles
+func normalizeQuery(req *http.Request, styles map[string]goop.ParameterSerialization) {
+	values := req.URL.Query()
+
+	for name, serialization := range styles {
+		switch serialization.Style {
+		case goop.StyleDeepObject:
+			object := make(map[string]string)
+			for key, vals := range values {
+				if strings.HasPrefix(key, name+"[") && strings.HasSuffix(key, "]") {
+					object[key[len(name)+1:len(key)-1]] = vals[len(vals)-1]
+					delete(values, key)
+				}
+			}
+			if len(object) > 0 {
+				data, err := json.Marshal(object)
+				if err == nil {
+					values.Set(name, string(data))
+				}
+			}
+		case goop.StyleForm, goop.StyleSpaceDelimited, goop.StylePipeDelimited:
+			// Exploded form arrays are already repeated keys, which Gin binds natively
+			if serialization.Style == goop.StyleForm && serialization.Explode {
+				continue
+			}
+			vals, ok := values[name]
+			if !ok {
+				continue
+			}
+			separator := queryStyleSeparator(serialization.Style)
+			split := make([]string, 0, len(vals))
+			for _, val := range vals {
+				split = append(split, strings.Split(val, separator)...)
+			}
+			values[name] = split
+		}
+	}
+
+	req.URL.RawQuery = values.Encode()
+}
+
+// queryStyleSeparator returns the array delimiter for a non-exploded query style
+func queryStyleSeparator(style goop.ParameterStyle) string {
+	switch style {
+	case goop.StyleSpaceDelimited:
+		return " "
+	case goop.StylePipeDelimited:
+		return "|"
+	default:
+		return ","
+	}
+}
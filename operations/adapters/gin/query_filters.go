@@ -0,0 +1,32 @@
+package gin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// FiltersKey is the Gin context key holding the sort keys and filter conditions parsed for the request
+const FiltersKey = "goop.filters"
+
+// QueryFilters creates middleware that parses sort keys and filter expressions declared by set
+// Requests using undeclared sort keys or operators are rejected with 400. Validated handlers pass the
+// parsed filters to query structs implementing goop.FilterReceiver, typically by embedding goop.Filters.
+func QueryFilters(set goop.QueryFilterSet) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		filters, err := set.Parse(c.Request.URL.Query())
+		if err != nil {
+			_ = c.Error(err).SetType(gin.ErrorTypeBind)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid query filters",
+				"details": err.Error(),
+			})
+			return
+		}
+
+		c.Set(FiltersKey, filters)
+		c.Next()
+	}
+}
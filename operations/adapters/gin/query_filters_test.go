@@ -0,0 +1,84 @@
+package gin_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/picogrid/go-op/operations"
+	ginadapter "github.com/picogrid/go-op/operations/adapters/gin"
+	"github.com/picogrid/go-op/validators"
+)
+
+// TestQueryFilters tests parsing sort and filter expressions into handler query structs
+func TestQueryFilters(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type ListQuery struct {
+		operations.Filters
+		Limit int `form:"limit" json:"limit,omitempty"`
+	}
+
+	querySchema := validators.Object(map[string]interface{}{
+		"limit": validators.Number().Integer().Optional(),
+	}).Strict().Optional()
+
+	handler := func(ctx context.Context, _ struct{}, query ListQuery, _ struct{}) (map[string]interface{}, error) {
+		return map[string]interface{}{
+			"sort":       query.Sort,
+			"conditions": query.Conditions,
+			"limit":      query.Limit,
+		}, nil
+	}
+
+	engine := gin.New()
+	router := ginadapter.NewGinRouter(engine)
+	op := operations.NewSimple().
+		GET("/messages").
+		WithQuery(querySchema).
+		WithQueryFilters(operations.QueryFilters(
+			operations.Filter("status", operations.FilterEq, operations.FilterIn),
+			operations.Sortable("created_at"),
+		)).
+		Handler(ginadapter.CreateValidatedHandler(handler, nil, querySchema, nil, nil))
+	require.NoError(t, router.Register(op))
+
+	get := func(target string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", target, nil)
+		engine.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("passes parsed filters to the handler", func(t *testing.T) {
+		w := get("/messages?sort=-created_at&status=in:sent,queued&limit=5")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var body struct {
+			Sort       []operations.SortField       `json:"sort"`
+			Conditions []operations.FilterCondition `json:"conditions"`
+			Limit      int                          `json:"limit"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, []operations.SortField{{Field: "created_at", Descending: true}}, body.Sort)
+		assert.Equal(t, []operations.FilterCondition{{Field: "status", Operator: operations.FilterIn, Values: []string{"sent", "queued"}}}, body.Conditions)
+		assert.Equal(t, 5, body.Limit)
+	})
+
+	t.Run("rejects undeclared sort keys", func(t *testing.T) {
+		w := get("/messages?sort=status")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Invalid query filters")
+	})
+
+	t.Run("rejects unsupported operators", func(t *testing.T) {
+		w := get("/messages?status=gt:sent")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
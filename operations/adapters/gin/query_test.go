@@ -0,0 +1,109 @@
+package gin_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	goop "github.com/picogrid/go-op"
+	ginadapter "github.com/picogrid/go-op/operations/adapters/gin"
+	"github.com/picogrid/go-op/validators"
+)
+
+// TestQueryStyles tests binding of styled query parameters
+func TestQueryStyles(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type ListQuery struct {
+		Tags   []string          `form:"tags" json:"tags,omitempty"`
+		IDs    []string          `form:"ids" json:"ids,omitempty"`
+		Filter map[string]string `form:"filter" json:"filter,omitempty"`
+	}
+
+	querySchema := validators.Object(map[string]interface{}{
+		"tags": validators.Array(validators.String().Required()).MaxItems(3).Optional(),
+		"ids":  validators.Array(validators.String().Required()).Optional(),
+		"filter": validators.Object(map[string]interface{}{
+			"status": validators.String().Required(),
+		}).Optional(),
+	}).Required()
+
+	handler := func(ctx context.Context, _ struct{}, query ListQuery, _ struct{}) (ListQuery, error) {
+		return query, nil
+	}
+
+	newRouter := func() *gin.Engine {
+		engine := gin.New()
+		router := ginadapter.NewGinRouter(engine)
+		err := router.Register(goop.CompiledOperation{
+			Method: "GET",
+			Path:   "/items",
+			QueryStyles: map[string]goop.ParameterSerialization{
+				"tags":   {Style: goop.StyleForm, Explode: true},
+				"ids":    {Style: goop.StylePipeDelimited},
+				"filter": {Style: goop.StyleDeepObject, Explode: true},
+			},
+			Handler: ginadapter.CreateValidatedHandler(handler, nil, querySchema, nil, nil),
+		})
+		assert.NoError(t, err)
+		return engine
+	}
+
+	t.Run("binds repeated form parameters", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/items?tags=a&tags=b", nil)
+		newRouter().ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"tags":["a","b"]`)
+	})
+
+	t.Run("binds pipe-delimited arrays", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/items?ids=1|2|3", nil)
+		newRouter().ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"ids":["1","2","3"]`)
+	})
+
+	t.Run("binds deepObject parameters", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/items?filter[status]=active&filter[owner]=me", nil)
+		newRouter().ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"status":"active"`)
+		assert.Contains(t, w.Body.String(), `"owner":"me"`)
+	})
+
+	t.Run("validates normalized parameters", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/items?filter[owner]=me&tags=a&tags=b&tags=c&tags=d", nil)
+		newRouter().ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Query parameter validation failed")
+	})
+
+	t.Run("middleware splits non-exploded form arrays", func(t *testing.T) {
+		engine := gin.New()
+		engine.GET("/items",
+			ginadapter.QueryStyles(map[string]goop.ParameterSerialization{
+				"tags": {Style: goop.StyleForm},
+			}),
+			ginadapter.CreateValidatedHandler(handler, nil, querySchema, nil, nil),
+		)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/items?tags=a,b", nil)
+		engine.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"tags":["a","b"]`)
+	})
+}
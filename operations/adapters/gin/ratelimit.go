@@ -0,0 +1,63 @@
+package gin
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// RateLimitKeyFunc derives the caller identity a rate limit is counted against
+type RateLimitKeyFunc func(c *gin.Context) string
+
+// ClientIPKey counts rate limits per client IP and route
+func ClientIPKey(c *gin.Context) string {
+	return c.ClientIP() + " " + c.Request.Method + " " + c.FullPath()
+}
+
+// SetRateLimiter enables enforcement of operation rate limits for operations registered afterwards
+// A nil keyFunc counts requests per client IP and route
+func (r *GinRouter) SetRateLimiter(limiter goop.RateLimiter, keyFunc RateLimitKeyFunc) {
+	r.rateLimiter = limiter
+	r.rateLimitKey = keyFunc
+}
+
+// RateLimit creates middleware that enforces limit using limiter
+// Responses carry X-RateLimit-* headers; requests over the limit receive 429 with Retry-After
+func RateLimit(limiter goop.RateLimiter, limit goop.RateLimit, keyFunc RateLimitKeyFunc) gin.HandlerFunc {
+	if keyFunc == nil {
+		keyFunc = ClientIPKey
+	}
+
+	return func(c *gin.Context) {
+		result, err := limiter.Allow(c.Request.Context(), keyFunc(c), limit)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "Rate limiter unavailable",
+				"details": err.Error(),
+			})
+			c.Abort()
+			return
+		}
+
+		reset := strconv.Itoa(int(math.Ceil(result.ResetAfter.Seconds())))
+		c.Header(goop.RateLimitLimitHeader, strconv.Itoa(limit.Requests))
+		c.Header(goop.RateLimitRemainingHeader, strconv.Itoa(result.Remaining))
+		c.Header(goop.RateLimitResetHeader, reset)
+
+		if !result.Allowed {
+			c.Header("Retry-After", reset)
+			c.JSON(http.StatusTooManyRequests, gin.H{
+				"error":   "Rate limit exceeded",
+				"details": "retry after " + reset + " seconds",
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,96 @@
+package gin_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	goop "github.com/picogrid/go-op"
+	"github.com/picogrid/go-op/operations"
+	ginadapter "github.com/picogrid/go-op/operations/adapters/gin"
+)
+
+type failingLimiter struct{}
+
+func (failingLimiter) Allow(ctx context.Context, key string, limit goop.RateLimit) (goop.RateLimitResult, error) {
+	return goop.RateLimitResult{}, errors.New("redis unavailable")
+}
+
+// TestRateLimit tests rate limit enforcement for registered operations
+func TestRateLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := func(ctx context.Context, _ struct{}, _ struct{}, _ struct{}) (map[string]string, error) {
+		return map[string]string{"status": "ok"}, nil
+	}
+
+	newRouter := func(limiter goop.RateLimiter, keyFunc ginadapter.RateLimitKeyFunc) *gin.Engine {
+		engine := gin.New()
+		router := ginadapter.NewGinRouter(engine)
+		router.SetRateLimiter(limiter, keyFunc)
+		op := operations.NewSimple().
+			GET("/search").
+			RateLimit(operations.PerMinute(2)).
+			Handler(ginadapter.CreateValidatedHandler(handler, nil, nil, nil, nil))
+		assert.NoError(t, router.Register(op))
+		return engine
+	}
+
+	send := func(engine *gin.Engine, tenant string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/search", nil)
+		req.Header.Set("X-Tenant", tenant)
+		engine.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("rejects requests over the limit", func(t *testing.T) {
+		engine := newRouter(operations.NewMemoryRateLimiter(), nil)
+
+		first := send(engine, "")
+		assert.Equal(t, http.StatusOK, first.Code)
+		assert.Equal(t, "2", first.Header().Get("X-RateLimit-Limit"))
+		assert.Equal(t, "1", first.Header().Get("X-RateLimit-Remaining"))
+		assert.Equal(t, "60", first.Header().Get("X-RateLimit-Reset"))
+
+		assert.Equal(t, http.StatusOK, send(engine, "").Code)
+
+		limited := send(engine, "")
+		assert.Equal(t, http.StatusTooManyRequests, limited.Code)
+		assert.Equal(t, "0", limited.Header().Get("X-RateLimit-Remaining"))
+		assert.NotEmpty(t, limited.Header().Get("Retry-After"))
+		assert.Contains(t, limited.Body.String(), "Rate limit exceeded")
+	})
+
+	t.Run("counts limits per key", func(t *testing.T) {
+		engine := newRouter(operations.NewMemoryRateLimiter(), func(c *gin.Context) string {
+			return c.GetHeader("X-Tenant")
+		})
+
+		assert.Equal(t, http.StatusOK, send(engine, "a").Code)
+		assert.Equal(t, http.StatusOK, send(engine, "a").Code)
+		assert.Equal(t, http.StatusTooManyRequests, send(engine, "a").Code)
+		assert.Equal(t, http.StatusOK, send(engine, "b").Code)
+	})
+
+	t.Run("limiter errors return 500", func(t *testing.T) {
+		engine := newRouter(failingLimiter{}, nil)
+
+		w := send(engine, "")
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Contains(t, w.Body.String(), "Rate limiter unavailable")
+	})
+
+	t.Run("limits are not enforced without a limiter", func(t *testing.T) {
+		engine := newRouter(nil, nil)
+
+		for i := 0; i < 3; i++ {
+			assert.Equal(t, http.StatusOK, send(engine, "").Code)
+		}
+	})
+}
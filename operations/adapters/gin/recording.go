@@ -0,0 +1,60 @@
+package gin
+
+import (
+	"bytes"
+	"io"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// SetRecorder captures request/response pairs of operations registered afterwards
+// Intended for development, e.g. with operations.ExampleRecorder to keep documented examples realistic
+func (r *GinRouter) SetRecorder(recorder goop.ExchangeRecorder) {
+	r.recorder = recorder
+}
+
+// RecordExchanges creates middleware that passes every request to op and its response to recorder
+// Request bodies are buffered and restored for downstream binding. Fields classified with
+// Sensitive or PII are masked in the recorded bodies.
+func RecordExchanges(recorder goop.ExchangeRecorder, op goop.CompiledOperation) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		var requestBody []byte
+		if c.Request.Body != nil {
+			var err error
+			requestBody, err = io.ReadAll(c.Request.Body)
+			if err != nil {
+				// Leave unreadable bodies for the handler to reject
+				requestBody = nil
+			}
+			c.Request.Body = io.NopCloser(bytes.NewReader(requestBody))
+		}
+		requestHeaders := c.Request.Header.Clone()
+
+		writer := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = writer
+		c.Next()
+
+		scheme := "http"
+		if c.Request.TLS != nil {
+			scheme = "https"
+		}
+		recorder.Record(goop.RecordedExchange{
+			OperationID:     op.OperationID,
+			Method:          op.Method,
+			Path:            op.Path,
+			URL:             scheme + "://" + c.Request.Host + c.Request.URL.RequestURI(),
+			StartedAt:       start,
+			Duration:        time.Since(start),
+			RequestHeaders:  requestHeaders,
+			RequestBody:     op.RequestRedactor.RedactJSON(requestBody),
+			StatusCode:      writer.Status(),
+			ResponseHeaders: writer.Header().Clone(),
+			ResponseBody:    op.ResponseRedactor.RedactJSON(writer.body.Bytes()),
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package gin_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/picogrid/go-op/operations"
+	ginadapter "github.com/picogrid/go-op/operations/adapters/gin"
+	"github.com/picogrid/go-op/validators"
+)
+
+// TestRecording tests capturing request/response pairs and writing them back as examples
+func TestRecording(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type CreateUserRequest struct {
+		Email string `json:"email"`
+	}
+
+	bodySchema := validators.Object(map[string]interface{}{
+		"email": validators.String().Email().Required(),
+	}).Required()
+	responseSchema := validators.Object(map[string]interface{}{
+		"email": validators.String().Required(),
+	}).Required()
+
+	engine := gin.New()
+	generator := operations.NewOpenAPIGenerator("Users", "1.0.0")
+	router := ginadapter.NewGinRouter(engine, generator)
+	recorder := operations.NewExampleRecorder(1)
+	router.SetRecorder(recorder)
+
+	handler := func(ctx context.Context, _ struct{}, _ struct{}, body CreateUserRequest) (map[string]string, error) {
+		return map[string]string{"email": body.Email}, nil
+	}
+	op := operations.NewSimple().
+		POST("/users").
+		OperationID("createUser").
+		WithBody(bodySchema).
+		WithResponse(responseSchema).
+		WithBadRequestError(operations.BadRequestErrorSchema).
+		Handler(ginadapter.CreateValidatedHandler(handler, nil, nil, bodySchema, responseSchema))
+	require.NoError(t, router.Register(op))
+
+	send := func(body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/users", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer secret")
+		engine.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Captures exchanges without disturbing the handler", func(t *testing.T) {
+		w := send(`{"email":"ada@example.com"}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "ada@example.com")
+
+		send(`{"email":"invalid"}`)
+		send(`{"email":"grace@example.com"}`)
+
+		exchanges := recorder.Exchanges()
+		require.Len(t, exchanges, 2, "one exchange per status should be kept")
+		assert.Equal(t, "createUser", exchanges[0].OperationID)
+		assert.Equal(t, "/users", exchanges[0].Path)
+		assert.Equal(t, http.StatusOK, exchanges[0].StatusCode)
+		assert.JSONEq(t, `{"email":"ada@example.com"}`, string(exchanges[0].RequestBody))
+		assert.JSONEq(t, `{"email":"ada@example.com"}`, string(exchanges[0].ResponseBody))
+		assert.Equal(t, "[REDACTED]", exchanges[0].RequestHeaders.Get("Authorization"))
+		assert.Equal(t, http.StatusBadRequest, exchanges[1].StatusCode)
+	})
+
+	t.Run("Writes recorded examples into the spec", func(t *testing.T) {
+		recorder.ApplyExamples(generator.Spec)
+
+		documented := generator.Spec.Paths["/users"]["post"]
+		requestExample := documented.RequestBody.Content["application/json"].Examples["recorded1"]
+		assert.Equal(t, map[string]interface{}{"email": "ada@example.com"}, requestExample.Value)
+		assert.Len(t, documented.RequestBody.Content["application/json"].Examples, 1, "failed requests should not become examples")
+
+		assert.Contains(t, documented.Responses["200"].Content["application/json"].Examples, "recorded1")
+		assert.Contains(t, documented.Responses["400"].Content["application/json"].Examples, "recorded1")
+	})
+}
@@ -0,0 +1,46 @@
+package gin
+
+import (
+	"github.com/gin-gonic/gin"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// RequestIDKey is the Gin context key holding the request ID
+// Handlers should read it through goop.RequestIDFromContext rather than this key
+const RequestIDKey = "goop.requestId"
+
+// UseRequestIDs correlates requests to operations registered afterwards with an X-Request-ID
+// The header is documented on each of them, logged by RequestLogger, and included in the error
+// responses of validated handlers so clients can report failures with it.
+func (r *GinRouter) UseRequestIDs() {
+	r.requestIDs = true
+}
+
+// RequestID creates middleware that accepts the X-Request-ID of each request or generates one
+// Client IDs that are too long or contain spaces or control characters are replaced. The ID is
+// echoed in the response header and stored on both the Gin and request contexts.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(goop.RequestIDHeader)
+		if !goop.ValidRequestID(id) {
+			id = goop.NewRequestID()
+		}
+		c.Set(RequestIDKey, id)
+		c.Request = c.Request.WithContext(goop.WithRequestID(c.Request.Context(), id))
+		c.Header(goop.RequestIDHeader, id)
+		c.Next()
+	}
+}
+
+// errorBody builds the JSON body of an error response, with the request ID when there is one
+func errorBody(c *gin.Context, message, details string) gin.H {
+	body := gin.H{
+		"error":   message,
+		"details": details,
+	}
+	if id := c.GetString(RequestIDKey); id != "" {
+		body["request_id"] = id
+	}
+	return body
+}
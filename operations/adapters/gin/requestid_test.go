@@ -0,0 +1,144 @@
+package gin_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	goop "github.com/picogrid/go-op"
+	"github.com/picogrid/go-op/operations"
+	ginadapter "github.com/picogrid/go-op/operations/adapters/gin"
+	"github.com/picogrid/go-op/validators"
+)
+
+// TestRequestIDs tests correlating requests with X-Request-ID
+func TestRequestIDs(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type Note struct {
+		Text string `json:"text"`
+	}
+	noteSchema := validators.Object(map[string]interface{}{
+		"text": validators.String().Min(1).Required(),
+	}).Required()
+
+	var logs bytes.Buffer
+	engine := gin.New()
+	generator := operations.NewOpenAPIGenerator("Notes", "1.0.0")
+	router := ginadapter.NewGinRouter(engine, generator)
+	router.SetLogger(slog.New(slog.NewJSONHandler(&logs, nil)))
+	router.UseRequestIDs()
+
+	handler := func(ctx context.Context, _ struct{}, _ struct{}, body Note) (map[string]string, error) {
+		return map[string]string{"requestId": goop.RequestIDFromContext(ctx)}, nil
+	}
+	require.NoError(t, router.Register(operations.NewSimple().
+		POST("/notes").
+		WithBody(noteSchema).
+		Handler(ginadapter.CreateValidatedHandler(handler, nil, nil, noteSchema, nil))))
+
+	request := func(id, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/notes", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if id != "" {
+			req.Header.Set(goop.RequestIDHeader, id)
+		}
+		engine.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Accepts the client ID", func(t *testing.T) {
+		w := request("req-123", `{"text":"hi"}`)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "req-123", w.Header().Get(goop.RequestIDHeader))
+		assert.JSONEq(t, `{"requestId":"req-123"}`, w.Body.String())
+	})
+
+	t.Run("Generates missing and invalid IDs", func(t *testing.T) {
+		for _, id := range []string{"", "not valid"} {
+			w := request(id, `{"text":"hi"}`)
+			require.Equal(t, http.StatusOK, w.Code)
+			generated := w.Header().Get(goop.RequestIDHeader)
+			assert.Len(t, generated, 32)
+			assert.NotEqual(t, id, generated)
+		}
+	})
+
+	t.Run("Includes the ID in validation errors", func(t *testing.T) {
+		w := request("req-456", `{"text":""}`)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, "req-456", body["request_id"])
+		assert.Equal(t, "Request body validation failed", body["error"])
+	})
+
+	t.Run("Logs the ID", func(t *testing.T) {
+		assert.Contains(t, logs.String(), `"requestId":"req-456"`)
+	})
+
+	t.Run("Documents the header", func(t *testing.T) {
+		op := generator.Spec.Paths["/notes"]["post"]
+		require.Len(t, op.Parameters, 1)
+		assert.Equal(t, goop.RequestIDHeader, op.Parameters[0].Name)
+		assert.Equal(t, "header", op.Parameters[0].In)
+		assert.False(t, op.Parameters[0].Required)
+		for code, response := range op.Responses {
+			assert.Contains(t, response.Headers, goop.RequestIDHeader, "response %s", code)
+		}
+	})
+}
+
+// TestValidationErrorMatchesSpec tests that rejected requests get the 400 body the spec documents
+func TestValidationErrorMatchesSpec(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	noteSchema := validators.Object(map[string]interface{}{
+		"text": validators.String().Min(1).Required(),
+	}).Required()
+	handler := func(ctx context.Context, _ struct{}, _ struct{}, body map[string]interface{}) (map[string]interface{}, error) {
+		return body, nil
+	}
+
+	for _, requestIDs := range []bool{false, true} {
+		engine := gin.New()
+		generator := operations.NewOpenAPIGenerator("Notes", "1.0.0")
+		generator.SetAutoValidationErrors(true)
+		router := ginadapter.NewGinRouter(engine, generator)
+		if requestIDs {
+			router.UseRequestIDs()
+		}
+		require.NoError(t, router.Register(operations.NewSimple().
+			POST("/notes").
+			WithBody(noteSchema).
+			WithSuccessResponse(http.StatusOK, noteSchema, "The note").
+			Handler(ginadapter.CreateValidatedHandler(handler, nil, nil, noteSchema, nil))))
+
+		documented := generator.Spec.Paths["/notes"]["post"].Responses["400"].Content["application/json"].Schema
+		require.NotNil(t, documented)
+		validator, err := validators.FromOpenAPISchema(documented, generator.Spec.Components.Schemas)
+		require.NoError(t, err)
+
+		for _, body := range []string{`{"text":""}`, `{"text":`} {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/notes", strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			engine.ServeHTTP(w, req)
+			require.Equal(t, http.StatusBadRequest, w.Code)
+
+			var response interface{}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.NoError(t, validator.Validate(response), "request IDs %v, body %s: %s", requestIDs, body, w.Body.String())
+		}
+	}
+}
@@ -2,6 +2,7 @@ package gin
 
 import (
 	"fmt"
+	"log/slog"
 	"net/http"
 	"strings"
 
@@ -46,24 +47,214 @@ func (r *GinRouter) Register(ops ...goop.CompiledOperation) error {
 
 // registerSingle registers a single compiled operation with the Gin router
 func (r *GinRouter) registerSingle(op goop.CompiledOperation) error {
-	// Store the operation for generator processing
-	r.operations = append(r.operations, op)
+	return r.registerOn(&r.engine.RouterGroup, op)
+}
+
+// registerOn registers an operation on a Gin router group
+// The operation path is relative to the group; the full path is recorded for generators
+func (r *GinRouter) registerOn(group *gin.RouterGroup, op goop.CompiledOperation) error {
+	// Path-versioned operations are served under a leading version segment
+	if op.Version != "" && r.versioning == VersionByPath {
+		op.Path = mountPath("/"+op.Version, op.Path)
+	}
 
 	// Convert OpenAPI path format to Gin format for routing
 	// This keeps the framework-agnostic operation definition while adapting to Gin's requirements
 	ginPath := ConvertOpenAPIPathToGin(op.Path)
 
+	// Track the full path so the spec matches the routes Gin actually serves
+	op.Path = mountPath(strings.TrimSuffix(group.BasePath(), "/"), op.Path)
+
+	// Operations behind disabled feature flags are not served, and only documented when requested
+	if !op.Enabled() {
+		if r.documentDisabled {
+			return r.process(operationInfo(&op))
+		}
+		return nil
+	}
+
+	// Store the operation for generator processing
+	r.operations = append(r.operations, op)
+
 	// Register the handler with Gin - zero reflection, maximum performance
 	var ginHandler GinHandler
-	if handler, ok := op.Handler.(GinHandler); ok {
+	switch handler := op.Handler.(type) {
+	case GinHandler:
 		ginHandler = handler
+	case http.Handler:
+		// Framework-agnostic handlers, such as health checks and batches, are adapted to Gin
+		ginHandler = wrapHTTPHandler(handler)
+	default:
+		return fmt.Errorf("handler must be a gin.HandlerFunc or http.Handler for Gin router, got %T", op.Handler)
+	}
+
+	// Correlated operations carry the request ID header so generators document it
+	if r.requestIDs && op.RequestIDHeader == "" {
+		op.RequestIDHeader = goop.RequestIDHeader
+	}
+
+	// Operations acting for a tenant carry the resolver so generators document its parameter
+	if r.tenantResolver != nil && op.Tenant == nil && r.tenantResolver.AppliesTo(&op) {
+		op.Tenant = r.tenantResolver
+	}
+
+	// Build-time metadata shared by generators and operation middleware
+	info := operationInfo(&op)
+
+	// Wrap the handler with operation middleware, the first registered outermost
+	for i := len(r.operationMiddleware) - 1; i >= 0; i-- {
+		ginHandler = r.operationMiddleware[i](info, ginHandler)
+	}
+	handlers := make([]gin.HandlerFunc, 0, 12)
+	if op.RequestIDHeader != "" {
+		// Assign the request ID before anything can log or reject the request
+		handlers = append(handlers, RequestID())
+	}
+	if r.logger != nil {
+		// Log first so latency and outcome cover every other middleware
+		handlers = append(handlers, RequestLogger(r.logger, op))
+	}
+	if r.compression != nil {
+		// Compress outside everything that reads the response, so recordings and replays stay uncompressed
+		handlers = append(handlers, Compress(*r.compression))
+	}
+	if r.recorder != nil {
+		// Record the final response, including early rejections by other middleware
+		handlers = append(handlers, RecordExchanges(r.recorder, op))
+	}
+	if op.Deprecation != nil {
+		// Announce deprecation on every response, including early rejections
+		handlers = append(handlers, Deprecation(*op.Deprecation))
+	}
+	if op.Cache != nil {
+		// Caching headers depend on the final status, so the writer is wrapped before any rejection
+		handlers = append(handlers, CacheControl(*op.Cache))
+	}
+	if op.Timeout > 0 {
+		// Bound the whole request, including deduplication and rate limiting, by the operation deadline
+		handlers = append(handlers, Timeout(op.Timeout))
+	}
+	if op.MaxBodyBytes > 0 {
+		// Reject oversized payloads before anything reads or decodes the body
+		handlers = append(handlers, MaxBodyBytes(op.MaxBodyBytes))
+	}
+	if op.Tenant != nil {
+		// Resolve the tenant first so deduplication and validation are scoped to it, and rate limit keys can read it
+		handlers = append(handlers, ResolveTenant(*op.Tenant))
+	}
+	if r.auditSink != nil && goop.IsAuditedMethod(op.Method) {
+		// Audit once the actor and tenant are known, including requests rejected afterwards
+		handlers = append(handlers, Audit(r.auditSink, op))
+	}
+	if op.IdempotencyStore != nil {
+		// Deduplicate retried requests before any other processing
+		handlers = append(handlers, Idempotency(op.IdempotencyStore, r.idempotencyScope))
+	}
+	if op.RateLimit != nil && r.rateLimiter != nil {
+		// Reject callers over their limit before doing any work
+		handlers = append(handlers, RateLimit(r.rateLimiter, *op.RateLimit, r.rateLimitKey))
+	}
+	if policy := op.ResponseValidation; policy != nil || r.responseValidation != nil {
+		if policy == nil {
+			policy = r.responseValidation
+		}
+		handlers = append(handlers, ResponseValidation(policy))
+	}
+	if len(op.QueryStyles) > 0 {
+		// Normalize styled query parameters before the handler binds them
+		handlers = append(handlers, QueryStyles(op.QueryStyles))
+	}
+	if op.QueryFilters != nil {
+		// Parse sort and filter expressions after query styles are normalized
+		handlers = append(handlers, QueryFilters(*op.QueryFilters))
+	}
+	if op.SparseFields != nil {
+		// Prune the response after the handler has validated it in full
+		handlers = append(handlers, SparseFields(*op.SparseFields))
+	}
+	if op.ResponseVisibility != nil {
+		// Strip scoped fields after the handler has validated the full response
+		handlers = append(handlers, ScopedFields(op.ResponseVisibility))
+	}
+	if op.Version != "" && r.versioning == VersionByHeader {
+		// Several versions share one route; the version is selected per request
+		if err := r.registerVersion(group, op.Method, ginPath, op.Version, append(handlers, ginHandler)); err != nil {
+			return err
+		}
 	} else {
-		// If it's not a GinHandler, we can't register it
-		return fmt.Errorf("handler must be a gin.HandlerFunc for Gin router, got %T", op.Handler)
+		group.Handle(op.Method, ginPath, append(handlers, ginHandler)...)
+	}
+
+	if err := r.process(info); err != nil {
+		return err
+	}
+
+	// Async operations bring their job status operation, served once per path from the engine root
+	if op.JobStatus != nil && !r.hasOperation(op.JobStatus.Method, op.JobStatus.Path) {
+		if err := r.registerOn(&r.engine.RouterGroup, *op.JobStatus); err != nil {
+			return err
+		}
 	}
-	r.engine.Handle(op.Method, ginPath, ginHandler)
 
-	// Process with all generators (build-time analysis)
+	// Generators exposing build info bring their meta operation, served once from the engine root
+	for _, generator := range r.generators {
+		if provider, ok := generator.(goop.MetaOperationProvider); ok {
+			if meta := provider.MetaOperation(); meta != nil && !r.hasOperation(meta.Method, meta.Path) {
+				if err := r.registerOn(&r.engine.RouterGroup, *meta); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if r.logger != nil {
+		r.logger.Debug("registered operation",
+			slog.String("operationId", op.OperationID),
+			slog.String("method", op.Method),
+			slog.String("path", op.Path),
+		)
+	}
+
+	return nil
+}
+
+// hasOperation reports whether an operation with method and path is registered
+func (r *GinRouter) hasOperation(method, path string) bool {
+	for _, op := range r.operations {
+		if op.Method == method && op.Path == path {
+			return true
+		}
+	}
+	return false
+}
+
+// wrapHTTPHandler adapts handler to Gin, passing the principal and tenant in the request context
+func wrapHTTPHandler(handler http.Handler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(requestContext(c))
+		handler.ServeHTTP(c.Writer, c.Request)
+	}
+}
+
+// process passes an operation to all generators
+func (r *GinRouter) process(info goop.OperationInfo) error {
+	for _, generator := range r.generators {
+		if err := generator.Process(info); err != nil {
+			return fmt.Errorf("generator processing failed: %w", err)
+		}
+	}
+	return nil
+}
+
+// DocumentDisabledOperations passes operations registered afterwards to the generators even when their
+// feature flag is off. They are not served or returned by GetOperations, and generators mark them as
+// disabled, so a documentation build can publish the spec of a fully flagged deployment.
+func (r *GinRouter) DocumentDisabledOperations() {
+	r.documentDisabled = true
+}
+
+// operationInfo extracts the metadata passed to generators and operation middleware
+func operationInfo(op *goop.CompiledOperation) goop.OperationInfo {
 	info := goop.OperationInfo{
 		Method:      op.Method,
 		Path:        op.Path,
@@ -71,7 +262,7 @@ func (r *GinRouter) registerSingle(op goop.CompiledOperation) error {
 		Description: op.Description,
 		Tags:        op.Tags,
 		Security:    op.Security,
-		Operation:   &op,
+		Operation:   op,
 	}
 
 	// Extract validation info if schemas are present and enhanced
@@ -101,16 +292,39 @@ func (r *GinRouter) registerSingle(op goop.CompiledOperation) error {
 		}
 	}
 
-	// Process with all generators
-	for _, generator := range r.generators {
-		if err := generator.Process(info); err != nil {
-			return fmt.Errorf("generator processing failed: %w", err)
+	return info
+}
+
+// OperationSource is implemented by routers whose operations can be mounted into a GinRouter
+// Both operations.Router and GinRouter satisfy it
+type OperationSource interface {
+	GetOperations() []goop.CompiledOperation
+}
+
+// Mount registers all operations from child under the given path prefix
+// This lets feature packages expose their own routers that are composed into one engine and spec
+func (r *GinRouter) Mount(prefix string, child OperationSource) error {
+	prefix = strings.TrimSuffix(prefix, "/")
+	for _, op := range child.GetOperations() {
+		op.Path = mountPath(prefix, op.Path)
+		if err := r.Register(op); err != nil {
+			return err
 		}
 	}
-
 	return nil
 }
 
+// mountPath joins a mount prefix and an operation path with a single slash
+func mountPath(prefix, path string) string {
+	if prefix == "" {
+		return path
+	}
+	if path == "" || path == "/" {
+		return prefix
+	}
+	return prefix + "/" + strings.TrimPrefix(path, "/")
+}
+
 // GetOperations returns all registered operations
 // Useful for build-time analysis and spec generation
 func (r *GinRouter) GetOperations() []goop.CompiledOperation {
@@ -137,11 +351,14 @@ func (r *GinRouter) WithMiddleware(handler GinHandler, middleware ...GinHandler)
 }
 
 // ServeSpec serves the OpenAPI specification as JSON
-// This is useful for development and documentation purposes
+// Generators that serve their own document, such as operations.OpenAPIGenerator, are served through
+// their cached handler. Other generators get a basic summary of the registered operations.
 func (r *GinRouter) ServeSpec(generator goop.Generator) gin.HandlerFunc {
+	if handler, ok := generator.(http.Handler); ok {
+		return gin.WrapH(handler)
+	}
+
 	return func(c *gin.Context) {
-		// This would be implemented by specific generators
-		// For now, return basic operation info
 		specs := make([]map[string]interface{}, 0, len(r.operations))
 		for _, op := range r.operations {
 			spec := map[string]interface{}{
@@ -0,0 +1,54 @@
+package gin
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// ScopedFields creates middleware that strips the response fields the caller's scopes cannot see
+// from successful JSON responses. Scopes come from the principal stored with SetAuth when it
+// implements goop.ScopedPrincipal; callers without one only see unscoped fields.
+// Responses are stripped after handlers validate them, so the full response is checked against its schema.
+func ScopedFields(visibility *goop.FieldVisibility) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		writer := &bufferedWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		c.Next()
+		c.Writer = writer.ResponseWriter
+
+		body := writer.body.Bytes()
+		status := c.Writer.Status()
+		if status >= 200 && status < 300 && strings.Contains(c.Writer.Header().Get("Content-Type"), "json") {
+			// Numbers are kept as written so large IDs survive the round trip
+			decoder := json.NewDecoder(bytes.NewReader(body))
+			decoder.UseNumber()
+			var response interface{}
+			if err := decoder.Decode(&response); err == nil {
+				if stripped, err := json.Marshal(visibility.Filter(response, callerScopes(c))); err == nil {
+					body = stripped
+				}
+			}
+		}
+
+		if c.Writer.Header().Get("Content-Length") != "" {
+			c.Writer.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		}
+		_, _ = c.Writer.Write(body)
+	}
+}
+
+// callerScopes returns the scopes of the authenticated principal, if it carries any
+func callerScopes(c *gin.Context) []string {
+	if principal, exists := c.Get(AuthKey); exists {
+		if scoped, ok := principal.(goop.ScopedPrincipal); ok {
+			return scoped.Scopes()
+		}
+	}
+	return nil
+}
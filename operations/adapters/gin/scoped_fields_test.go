@@ -0,0 +1,84 @@
+package gin_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/picogrid/go-op/operations"
+	ginadapter "github.com/picogrid/go-op/operations/adapters/gin"
+	"github.com/picogrid/go-op/validators"
+)
+
+type scopedUser struct {
+	scopes []string
+}
+
+func (u scopedUser) Scopes() []string {
+	return u.scopes
+}
+
+// TestScopedFields tests stripping response fields marked with VisibleToScopes
+func TestScopedFields(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type Employee struct {
+		Name   string  `json:"name"`
+		Salary float64 `json:"salary"`
+	}
+
+	employeeSchema := validators.Object(map[string]interface{}{
+		"name":   validators.String().Required(),
+		"salary": validators.Number().VisibleToScopes("admin", "payroll").Required(),
+	}).Required()
+
+	engine := gin.New()
+	engine.Use(func(c *gin.Context) {
+		if scope := c.GetHeader("X-Scope"); scope != "" {
+			ginadapter.SetAuth(c, scopedUser{scopes: []string{scope}})
+		}
+	})
+	router := ginadapter.NewGinRouter(engine, operations.NewOpenAPIGenerator("Employees", "1.0.0"))
+
+	handler := func(ctx context.Context, _ struct{}, _ struct{}, _ struct{}) (Employee, error) {
+		return Employee{Name: "Ada", Salary: 100}, nil
+	}
+	op := operations.NewSimple().
+		GET("/employee").
+		WithResponse(employeeSchema).
+		Handler(ginadapter.CreateValidatedHandler(handler, nil, nil, nil, employeeSchema))
+	require.NoError(t, router.Register(op))
+
+	request := func(scope string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/employee", nil)
+		if scope != "" {
+			req.Header.Set("X-Scope", scope)
+		}
+		engine.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Caller with a scope", func(t *testing.T) {
+		w := request("payroll")
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"name":"Ada","salary":100}`, w.Body.String())
+	})
+
+	t.Run("Caller without a scope", func(t *testing.T) {
+		w := request("user")
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"name":"Ada"}`, w.Body.String())
+	})
+
+	t.Run("Anonymous caller", func(t *testing.T) {
+		w := request("")
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"name":"Ada"}`, w.Body.String())
+	})
+}
@@ -0,0 +1,129 @@
+package gin
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// SparseFields creates middleware that prunes successful JSON responses to the fields requested
+// with the fields query parameter. Requests naming fields outside the fieldset are rejected with 400.
+// Responses are pruned after handlers validate them, so the full response is checked against its schema.
+func SparseFields(fieldset goop.SparseFieldset) gin.HandlerFunc {
+	allowed := make(map[string]bool, len(fieldset.Fields))
+	for _, field := range fieldset.Fields {
+		allowed[field] = true
+	}
+
+	return func(c *gin.Context) {
+		requested := requestedFields(c)
+		if len(requested) == 0 {
+			c.Next()
+			return
+		}
+
+		var unknown []string
+		for field := range requested {
+			if !allowed[field] {
+				unknown = append(unknown, field)
+			}
+		}
+		if len(unknown) > 0 {
+			sort.Strings(unknown)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid fields parameter",
+				"details": "unknown fields: " + strings.Join(unknown, ", "),
+			})
+			return
+		}
+
+		writer := &bufferedWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		c.Next()
+		c.Writer = writer.ResponseWriter
+
+		body := writer.body.Bytes()
+		status := c.Writer.Status()
+		if status >= 200 && status < 300 && strings.Contains(c.Writer.Header().Get("Content-Type"), "json") {
+			// Numbers are kept as written so large IDs survive the round trip
+			decoder := json.NewDecoder(bytes.NewReader(body))
+			decoder.UseNumber()
+			var response interface{}
+			if err := decoder.Decode(&response); err == nil {
+				if pruned, err := json.Marshal(pruneResponse(response, requested, fieldset.Envelope)); err == nil {
+					body = pruned
+				}
+			}
+		}
+
+		if c.Writer.Header().Get("Content-Length") != "" {
+			c.Writer.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		}
+		_, _ = c.Writer.Write(body)
+	}
+}
+
+// requestedFields collects field names from comma-separated and repeated fields parameters
+func requestedFields(c *gin.Context) map[string]bool {
+	fields := make(map[string]bool)
+	for _, value := range c.QueryArray(goop.FieldsQueryParameter) {
+		for _, field := range strings.Split(value, ",") {
+			if field = strings.TrimSpace(field); field != "" {
+				fields[field] = true
+			}
+		}
+	}
+	return fields
+}
+
+// pruneResponse keeps the requested fields of each resource in a decoded response
+// Envelope names the member holding the resources; the envelope's other members are kept as they are.
+func pruneResponse(response interface{}, fields map[string]bool, envelope string) interface{} {
+	if envelope != "" {
+		if object, ok := response.(map[string]interface{}); ok {
+			if resources, exists := object[envelope]; exists {
+				object[envelope] = pruneResponse(resources, fields, "")
+			}
+			return object
+		}
+	}
+
+	switch value := response.(type) {
+	case []interface{}:
+		for i, item := range value {
+			value[i] = pruneResponse(item, fields, "")
+		}
+		return value
+	case map[string]interface{}:
+		for name := range value {
+			if !fields[name] {
+				delete(value, name)
+			}
+		}
+		return value
+	default:
+		return response
+	}
+}
+
+// bufferedWriter holds the response body so it can be rewritten before it is sent
+// The status code and headers are recorded by the wrapped writer and sent with the rewritten body
+type bufferedWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *bufferedWriter) Write(data []byte) (int, error) {
+	return w.body.Write(data)
+}
+
+func (w *bufferedWriter) WriteString(s string) (int, error) {
+	return w.body.WriteString(s)
+}
@@ -0,0 +1,157 @@
+package gin_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/picogrid/go-op/operations"
+	ginadapter "github.com/picogrid/go-op/operations/adapters/gin"
+	"github.com/picogrid/go-op/operations/pagination"
+	"github.com/picogrid/go-op/validators"
+)
+
+// TestSparseFields tests pruning responses to the fields query parameter
+func TestSparseFields(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type User struct {
+		ID    string `json:"id"`
+		Name  string `json:"name"`
+		Email string `json:"email"`
+	}
+
+	userSchema := validators.Object(map[string]interface{}{
+		"id":    validators.String().Required(),
+		"name":  validators.String().Required(),
+		"email": validators.String().Email().Required(),
+	}).Required()
+	users := []User{
+		{ID: "1", Name: "Ada", Email: "ada@example.com"},
+		{ID: "2", Name: "Grace", Email: "grace@example.com"},
+	}
+
+	engine := gin.New()
+	router := ginadapter.NewGinRouter(engine)
+
+	getUser := operations.NewSimple().
+		GET("/users/1").
+		WithResponse(userSchema).
+		SparseFields().
+		Handler(ginadapter.CreateValidatedHandler(
+			func(ctx context.Context, _ struct{}, _ struct{}, _ struct{}) (User, error) {
+				return users[0], nil
+			}, nil, nil, nil, userSchema))
+	listUsers := operations.NewSimple().
+		GET("/users").
+		WithResponse(validators.Array(userSchema).Required()).
+		SparseFields().
+		Handler(ginadapter.CreateValidatedHandler(
+			func(ctx context.Context, _ struct{}, _ struct{}, _ struct{}) ([]User, error) {
+				return users, nil
+			}, nil, nil, nil, nil))
+	listEnvelope := operations.NewSimple().
+		GET("/envelope/users").
+		WithResponse(operations.ListResponse(userSchema)).
+		SparseFields().
+		Handler(ginadapter.CreateValidatedHandler(
+			func(ctx context.Context, _ struct{}, _ struct{}, _ struct{}) (operations.Envelope[[]User], error) {
+				return operations.NewListEnvelope(users, 2), nil
+			}, nil, nil, nil, nil))
+	pageUsers := operations.NewSimple().
+		GET("/pages/users").
+		WithResponse(pagination.PageSchema(userSchema)).
+		SparseFields("id", "name").
+		Handler(ginadapter.CreateValidatedHandler(
+			func(ctx context.Context, _ struct{}, _ struct{}, _ struct{}) (pagination.Page[User], error) {
+				return pagination.NewPage(users, pagination.PageQuery{Page: 1, PageSize: 1}, 3), nil
+			}, nil, nil, nil, nil))
+
+	type Account struct {
+		ID    int64  `json:"id"`
+		Owner string `json:"owner"`
+	}
+	accountSchema := validators.Object(map[string]interface{}{
+		"id":    validators.Number().Integer().Required(),
+		"owner": validators.String().Required(),
+	}).Required()
+	getAccount := operations.NewSimple().
+		GET("/accounts/1").
+		WithResponse(accountSchema).
+		SparseFields().
+		Handler(ginadapter.CreateValidatedHandler(
+			func(ctx context.Context, _ struct{}, _ struct{}, _ struct{}) (Account, error) {
+				return Account{ID: 9007199254740993, Owner: "Ada"}, nil
+			}, nil, nil, nil, nil))
+	require.NoError(t, router.Register(getUser, listUsers, listEnvelope, pageUsers, getAccount))
+
+	get := func(t *testing.T, target string) *httptest.ResponseRecorder {
+		t.Helper()
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", target, nil)
+		engine.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("returns the full response without fields", func(t *testing.T) {
+		w := get(t, "/users/1")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"id":"1","name":"Ada","email":"ada@example.com"}`, w.Body.String())
+	})
+
+	t.Run("prunes an object response", func(t *testing.T) {
+		w := get(t, "/users/1?fields=id,name")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"id":"1","name":"Ada"}`, w.Body.String())
+		assert.Contains(t, w.Header().Get("Content-Type"), "application/json")
+	})
+
+	t.Run("prunes each item of an array response", func(t *testing.T) {
+		w := get(t, "/users?fields=email&fields=id")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `[{"id":"1","email":"ada@example.com"},{"id":"2","email":"grace@example.com"}]`, w.Body.String())
+	})
+
+	t.Run("prunes envelope data and keeps metadata", func(t *testing.T) {
+		w := get(t, "/envelope/users?fields=name")
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"name": "Ada"},
+			map[string]interface{}{"name": "Grace"},
+		}, body["data"])
+		assert.Equal(t, map[string]interface{}{"total_count": float64(2)}, body["meta"])
+	})
+
+	t.Run("prunes pagination items and keeps page metadata", func(t *testing.T) {
+		w := get(t, "/pages/users?fields=id,name")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{
+			"items": [{"id":"1","name":"Ada"},{"id":"2","name":"Grace"}],
+			"page": 1,
+			"page_size": 1,
+			"total_count": 3,
+			"has_next": true
+		}`, w.Body.String())
+	})
+
+	t.Run("keeps large integers exact", func(t *testing.T) {
+		w := get(t, "/accounts/1?fields=id")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, `{"id":9007199254740993}`, w.Body.String())
+	})
+
+	t.Run("rejects unknown fields", func(t *testing.T) {
+		w := get(t, "/users/1?fields=id,password,token")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "unknown fields: password, token")
+	})
+}
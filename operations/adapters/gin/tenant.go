@@ -0,0 +1,75 @@
+package gin
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// TenantKey is the Gin context key holding the resolved tenant
+// Handlers should read it through goop.TenantFromContext rather than this key
+const TenantKey = "goop.tenant"
+
+// TenantIDKey is the Gin context key holding the tenant ID the request was resolved from
+const TenantIDKey = "goop.tenant_id"
+
+// UseTenantResolver resolves the tenant of operations registered afterwards before their handlers run
+// The tenant parameter is documented on each of them; operations built WithoutTenant are skipped.
+func (r *GinRouter) UseTenantResolver(resolver goop.TenantResolver) {
+	r.tenantResolver = &resolver
+}
+
+// SetTenant stores the resolved tenant on the Gin context
+// Validated handlers expose it to business logic via goop.TenantFromContext
+func SetTenant(c *gin.Context, tenant interface{}) {
+	c.Set(TenantKey, tenant)
+}
+
+// ResolveTenant creates middleware that resolves the tenant of each request with resolver
+// Requests without a tenant ID, or with one rejected as malformed, receive 400 Bad Request;
+// tenants the caller cannot act for are rejected with 403 Forbidden.
+func ResolveTenant(resolver goop.TenantResolver) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var id string
+		if resolver.In == "path" {
+			id = c.Param(resolver.Name)
+		} else {
+			id = c.GetHeader(resolver.Name)
+		}
+		if id == "" {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Tenant required",
+				"details": "missing " + resolver.Name + " " + resolver.In + " parameter",
+			})
+			c.Abort()
+			return
+		}
+
+		tenant, err := resolver.Resolve(c.Request.Context(), id)
+		if err != nil {
+			var validationErr *goop.ValidationError
+			if errors.As(err, &validationErr) {
+				c.JSON(http.StatusBadRequest, gin.H{
+					"error":   "Invalid tenant",
+					"details": err.Error(),
+				})
+			} else {
+				c.JSON(http.StatusForbidden, gin.H{
+					"error":   "Tenant not accessible",
+					"details": err.Error(),
+				})
+			}
+			c.Abort()
+			return
+		}
+
+		// Plain Gin handlers read the tenant from the request context
+		c.Set(TenantIDKey, id)
+		SetTenant(c, tenant)
+		c.Request = c.Request.WithContext(goop.WithTenant(c.Request.Context(), tenant))
+		c.Next()
+	}
+}
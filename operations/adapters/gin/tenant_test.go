@@ -0,0 +1,172 @@
+package gin_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	goop "github.com/picogrid/go-op"
+	"github.com/picogrid/go-op/operations"
+	ginadapter "github.com/picogrid/go-op/operations/adapters/gin"
+	"github.com/picogrid/go-op/validators"
+)
+
+type company struct {
+	ID    string
+	Seats int64
+}
+
+func (c company) TenantLimit(name string) (int64, bool) {
+	if name == "seats" {
+		return c.Seats, true
+	}
+	return 0, false
+}
+
+// TestTenantResolver tests resolving the tenant of each request with UseTenantResolver
+func TestTenantResolver(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	resolve := func(ctx context.Context, id string) (interface{}, error) {
+		switch id {
+		case "acme":
+			return company{ID: "acme", Seats: 3}, nil
+		case "bad id":
+			return nil, goop.NewValidationError("X-Company-ID", id, "company ID must not contain spaces")
+		}
+		return nil, errors.New("unknown company")
+	}
+
+	type Subscription struct {
+		Seats int `json:"seats"`
+	}
+	type Result struct {
+		Company string `json:"company"`
+		Seats   int    `json:"seats"`
+	}
+	subscriptionSchema := validators.Object(map[string]interface{}{
+		"seats": validators.Int().Min(1).MaxFromTenant("seats").Required(),
+	}).Required()
+
+	engine := gin.New()
+	generator := operations.NewOpenAPIGenerator("Companies", "1.0.0")
+	router := ginadapter.NewGinRouter(engine, generator)
+	router.UseTenantResolver(goop.TenantHeader("X-Company-ID", resolve))
+
+	handler := func(ctx context.Context, _ struct{}, _ struct{}, body Subscription) (Result, error) {
+		tenant, ok := goop.TenantFromContext[company](ctx)
+		if !ok {
+			return Result{}, errors.New("tenant not found in context")
+		}
+		return Result{Company: tenant.ID, Seats: body.Seats}, nil
+	}
+	subscribe := operations.NewSimple().
+		POST("/subscription").
+		WithBody(subscriptionSchema).
+		Handler(ginadapter.CreateValidatedHandler(handler, nil, nil, subscriptionSchema, nil))
+	status := operations.NewSimple().
+		GET("/status").
+		WithoutTenant().
+		Handler(gin.HandlerFunc(func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"status": "up"}) }))
+	require.NoError(t, router.Register(subscribe, status))
+
+	request := func(method, path, tenant, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if tenant != "" {
+			req.Header.Set("X-Company-ID", tenant)
+		}
+		engine.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Passes the typed tenant to handlers", func(t *testing.T) {
+		w := request("POST", "/subscription", "acme", `{"seats":2}`)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.JSONEq(t, `{"company":"acme","seats":2}`, w.Body.String())
+	})
+
+	t.Run("Rejects requests without a tenant", func(t *testing.T) {
+		w := request("POST", "/subscription", "", `{"seats":2}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Tenant required")
+	})
+
+	t.Run("Rejects malformed tenant IDs", func(t *testing.T) {
+		w := request("POST", "/subscription", "bad id", `{"seats":2}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Invalid tenant")
+	})
+
+	t.Run("Rejects inaccessible tenants", func(t *testing.T) {
+		w := request("POST", "/subscription", "globex", `{"seats":2}`)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), "unknown company")
+	})
+
+	t.Run("Enforces tenant limits", func(t *testing.T) {
+		w := request("POST", "/subscription", "acme", `{"seats":4}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "seats limit is 3")
+	})
+
+	t.Run("Skips exempt operations", func(t *testing.T) {
+		w := request("GET", "/status", "", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Documents the tenant parameter", func(t *testing.T) {
+		parameters := generator.Spec.Paths["/subscription"]["post"].Parameters
+		require.Len(t, parameters, 1)
+		assert.Equal(t, "X-Company-ID", parameters[0].Name)
+		assert.Equal(t, "header", parameters[0].In)
+		assert.True(t, parameters[0].Required)
+		assert.Empty(t, generator.Spec.Paths["/status"]["get"].Parameters)
+
+		seats := generator.Spec.Paths["/subscription"]["post"].RequestBody.Content["application/json"].Schema.Properties["seats"]
+		assert.Equal(t, "seats", seats.Extensions[goop.TenantLimitExtension])
+	})
+}
+
+// TestTenantPathParam tests resolving the tenant from a path parameter
+func TestTenantPathParam(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	engine := gin.New()
+	generator := operations.NewOpenAPIGenerator("Orgs", "1.0.0")
+	router := ginadapter.NewGinRouter(engine, generator)
+	router.UseTenantResolver(goop.TenantPathParam("orgId", func(ctx context.Context, id string) (interface{}, error) {
+		return company{ID: id}, nil
+	}))
+
+	users := operations.NewSimple().
+		GET("/orgs/{orgId}/users").
+		Handler(gin.HandlerFunc(func(c *gin.Context) {
+			// Plain Gin handlers read the tenant from the request context
+			tenant, _ := goop.TenantFromContext[company](c.Request.Context())
+			c.JSON(http.StatusOK, gin.H{"org": tenant.ID})
+		}))
+	me := operations.NewSimple().
+		GET("/me").
+		Handler(gin.HandlerFunc(func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{}) }))
+	require.NoError(t, router.Register(users, me))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/orgs/acme/users", nil)
+	engine.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"org":"acme"}`, w.Body.String())
+
+	parameters := generator.Spec.Paths["/orgs/{orgId}/users"]["get"].Parameters
+	require.Len(t, parameters, 1)
+	assert.Equal(t, "path", parameters[0].In)
+	assert.Empty(t, generator.Spec.Paths["/me"]["get"].Parameters)
+}
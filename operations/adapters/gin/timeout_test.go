@@ -0,0 +1,77 @@
+package gin_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/picogrid/go-op/operations"
+	ginadapter "github.com/picogrid/go-op/operations/adapters/gin"
+)
+
+// TestTimeout tests per-operation deadline enforcement
+func TestTimeout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newRouter := func(timeout time.Duration, work time.Duration) *gin.Engine {
+		handler := func(ctx context.Context, _ struct{}, _ struct{}, _ struct{}) (map[string]string, error) {
+			select {
+			case <-time.After(work):
+				return map[string]string{"status": "done"}, nil
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+
+		engine := gin.New()
+		router := ginadapter.NewGinRouter(engine)
+		builder := operations.NewSimple().GET("/reports")
+		if timeout > 0 {
+			builder = builder.Timeout(timeout)
+		}
+		assert.NoError(t, router.Register(builder.Handler(ginadapter.CreateValidatedHandler(handler, nil, nil, nil, nil))))
+		return engine
+	}
+
+	send := func(engine *gin.Engine) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/reports", nil)
+		engine.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("returns 504 when the deadline is exceeded", func(t *testing.T) {
+		w := send(newRouter(10*time.Millisecond, time.Second))
+		assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+		assert.Contains(t, w.Body.String(), "Request timed out")
+	})
+
+	t.Run("completes within the deadline", func(t *testing.T) {
+		w := send(newRouter(time.Second, 0))
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("handler context has the deadline", func(t *testing.T) {
+		var hasDeadline bool
+		handler := func(ctx context.Context, _ struct{}, _ struct{}, _ struct{}) (map[string]string, error) {
+			_, hasDeadline = ctx.Deadline()
+			return map[string]string{}, nil
+		}
+
+		engine := gin.New()
+		router := ginadapter.NewGinRouter(engine)
+		op := operations.NewSimple().
+			GET("/reports").
+			Timeout(time.Second).
+			Handler(ginadapter.CreateValidatedHandler(handler, nil, nil, nil, nil))
+		assert.NoError(t, router.Register(op))
+
+		send(engine)
+		assert.True(t, hasDeadline)
+	})
+}
@@ -1,6 +1,9 @@
 package gin
 
 import (
+	"log/slog"
+	"net/http"
+
 	"github.com/gin-gonic/gin"
 
 	goop "github.com/picogrid/go-op"
@@ -15,6 +18,46 @@ type GinRouter struct {
 	engine     *gin.Engine
 	generators []goop.Generator
 	operations []goop.CompiledOperation
+
+	// Rate limit enforcement for operations that declare a RateLimit
+	rateLimiter  goop.RateLimiter
+	rateLimitKey RateLimitKeyFunc
+
+	// Caller scope of idempotency keys (nil scopes them by tenant and principal)
+	idempotencyScope IdempotencyScopeFunc
+
+	// API version routing for operations that declare a Version
+	versioning     VersionStrategy
+	defaultVersion string
+	versionEngine  *gin.Engine
+	versionRoutes  map[string][]string
+
+	// Structured logger for registration and request outcomes (nil disables logging)
+	logger *slog.Logger
+
+	// Default response validation policy (nil always validates)
+	responseValidation goop.ValidationPolicy
+
+	// Development recorder for request/response pairs (nil disables recording)
+	recorder goop.ExchangeRecorder
+
+	// Correlate requests to operations registered afterwards with an X-Request-ID
+	requestIDs bool
+
+	// Tenant resolution for operations registered afterwards (nil disables it)
+	tenantResolver *goop.TenantResolver
+
+	// Sink for audit events of mutating operations (nil disables auditing)
+	auditSink goop.AuditSink
+
+	// Response compression for operations registered afterwards (nil disables it)
+	compression *goop.Compression
+
+	// Middleware wrapping the handler of each operation registered afterwards
+	operationMiddleware []OperationMiddleware
+
+	// Pass operations behind disabled feature flags to generators without serving them
+	documentDisabled bool
 }
 
 // NewGinRouter creates a new Gin-based router with the specified engine and generators
@@ -30,3 +73,8 @@ func NewGinRouter(engine *gin.Engine, generators ...goop.Generator) *GinRouter {
 func (r *GinRouter) GetEngine() *gin.Engine {
 	return r.engine
 }
+
+// ServeHTTP serves requests with the underlying Gin engine, so the router can be used as an http.Handler
+func (r *GinRouter) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.engine.ServeHTTP(w, req)
+}
@@ -0,0 +1,105 @@
+package gin_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	goop "github.com/picogrid/go-op"
+	ginadapter "github.com/picogrid/go-op/operations/adapters/gin"
+	"github.com/picogrid/go-op/validators"
+)
+
+// generatedBody mirrors a type emitted by goop codegen-validators
+type generatedBody struct {
+	goop.Generated
+
+	Name string `json:"name"`
+}
+
+func (v *generatedBody) Validate() error {
+	if len(v.Name) < 3 {
+		return goop.NewNestedValidationError("", nil, "object validation failed", []goop.ValidationError{
+			*goop.NewValidationError("name", v.Name, "generated check failed"),
+		})
+	}
+	return nil
+}
+
+func TestValidatableBody(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// The runtime schema accepts any name, so rejections come from the generated Validate method
+	bodySchema := validators.Object(map[string]interface{}{
+		"name": validators.String().Required(),
+	}).Required()
+
+	handler := ginadapter.CreateValidatedHandler(
+		func(ctx context.Context, params struct{}, query struct{}, body generatedBody) (generatedBody, error) {
+			return body, nil
+		},
+		nil, nil, bodySchema, nil,
+	)
+
+	engine := gin.New()
+	engine.POST("/items", handler)
+
+	t.Run("Generated validator rejects", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/items", bytes.NewBufferString(`{"name":"ab"}`))
+		req.Header.Set("Content-Type", "application/json")
+		engine.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "generated check failed")
+	})
+
+	t.Run("Generated validator accepts", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/items", bytes.NewBufferString(`{"name":"abc"}`))
+		req.Header.Set("Content-Type", "application/json")
+		engine.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
+
+// handwrittenBody has its own Validate method, as request types often do
+type handwrittenBody struct {
+	Name string `json:"name"`
+}
+
+func (v *handwrittenBody) Validate() error {
+	return nil
+}
+
+func TestHandwrittenValidateKeepsSchema(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	bodySchema := validators.Object(map[string]interface{}{
+		"name": validators.String().Min(3).Required(),
+	}).Required()
+
+	handler := ginadapter.CreateValidatedHandler(
+		func(ctx context.Context, params struct{}, query struct{}, body handwrittenBody) (handwrittenBody, error) {
+			return body, nil
+		},
+		nil, nil, bodySchema, nil,
+	)
+
+	engine := gin.New()
+	engine.POST("/items", handler)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/items", bytes.NewBufferString(`{"name":"ab"}`))
+	req.Header.Set("Content-Type", "application/json")
+	engine.ServeHTTP(w, req)
+
+	// Only generated validators replace the schema, so the min length is still enforced
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,34 @@
+package gin
+
+import (
+	"github.com/gin-gonic/gin"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// ResponseValidationKey is the Gin context key holding the response validation policy for a request
+const ResponseValidationKey = "goop.responseValidation"
+
+// SetResponseValidation sets the default response validation policy for operations registered afterwards
+// Operations that declare their own policy keep it; without any policy responses are always validated
+func (r *GinRouter) SetResponseValidation(policy goop.ValidationPolicy) {
+	r.responseValidation = policy
+}
+
+// ResponseValidation creates middleware that applies policy to validated handlers later in the chain
+func ResponseValidation(policy goop.ValidationPolicy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(ResponseValidationKey, policy)
+		c.Next()
+	}
+}
+
+// shouldValidateResponse reports whether the response schema should be checked for this request
+func shouldValidateResponse(c *gin.Context) bool {
+	if value, exists := c.Get(ResponseValidationKey); exists {
+		if policy, ok := value.(goop.ValidationPolicy); ok && policy != nil {
+			return policy()
+		}
+	}
+	return true
+}
@@ -0,0 +1,83 @@
+package gin_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/picogrid/go-op/operations"
+	ginadapter "github.com/picogrid/go-op/operations/adapters/gin"
+	"github.com/picogrid/go-op/validators"
+)
+
+// TestResponseValidationPolicy tests enabling and disabling response validation
+func TestResponseValidationPolicy(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type User struct {
+		Email string `json:"email"`
+	}
+
+	responseSchema := validators.Object(map[string]interface{}{
+		"email": validators.String().Email().Required(),
+	}).Required()
+
+	// The handler returns a response that violates its schema
+	handler := func(ctx context.Context, _ struct{}, _ struct{}, _ struct{}) (User, error) {
+		return User{Email: "not-an-email"}, nil
+	}
+
+	send := func(engine *gin.Engine) int {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/users/me", nil)
+		engine.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	newOperation := func(builder *operations.SimpleOperationBuilder) operations.CompiledOperation {
+		return builder.Handler(ginadapter.CreateValidatedHandler(handler, nil, nil, nil, responseSchema))
+	}
+
+	t.Run("validates responses by default", func(t *testing.T) {
+		engine := gin.New()
+		router := ginadapter.NewGinRouter(engine)
+		assert.NoError(t, router.Register(newOperation(operations.NewSimple().GET("/users/me"))))
+
+		assert.Equal(t, http.StatusInternalServerError, send(engine))
+	})
+
+	t.Run("router policy disables response validation", func(t *testing.T) {
+		engine := gin.New()
+		router := ginadapter.NewGinRouter(engine)
+		router.SetResponseValidation(operations.Never)
+		assert.NoError(t, router.Register(newOperation(operations.NewSimple().GET("/users/me"))))
+
+		assert.Equal(t, http.StatusOK, send(engine))
+	})
+
+	t.Run("operation policy overrides router default", func(t *testing.T) {
+		engine := gin.New()
+		router := ginadapter.NewGinRouter(engine)
+		router.SetResponseValidation(operations.Never)
+		assert.NoError(t, router.Register(newOperation(
+			operations.NewSimple().GET("/users/me").WithResponseValidation(operations.Always),
+		)))
+
+		assert.Equal(t, http.StatusInternalServerError, send(engine))
+	})
+
+	t.Run("OnlyInDev skips validation in production", func(t *testing.T) {
+		t.Setenv(operations.EnvironmentVariable, "production")
+
+		engine := gin.New()
+		router := ginadapter.NewGinRouter(engine)
+		router.SetResponseValidation(operations.OnlyInDev)
+		assert.NoError(t, router.Register(newOperation(operations.NewSimple().GET("/users/me"))))
+
+		assert.Equal(t, http.StatusOK, send(engine))
+	})
+}
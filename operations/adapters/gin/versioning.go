@@ -0,0 +1,113 @@
+package gin
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// VersionStrategy selects how clients choose the API version of an operation
+type VersionStrategy int
+
+const (
+	// VersionByHeader serves all versions on the same path and selects one with the API-Version header
+	VersionByHeader VersionStrategy = iota
+	// VersionByPath serves each version under a leading path segment, such as /v2/users
+	VersionByPath
+)
+
+// versionParentKey carries the outer Gin context into the version engine
+type versionParentKey struct{}
+
+// SetVersioning configures how versioned operations registered afterwards are routed
+// With VersionByHeader, requests without an API-Version header use defaultVersion when the
+// route provides it, and otherwise the latest version by string order (e.g. "2024-06-01" > "2023-01-15")
+func (r *GinRouter) SetVersioning(strategy VersionStrategy, defaultVersion string) {
+	r.versioning = strategy
+	r.defaultVersion = defaultVersion
+}
+
+// registerVersion registers one version of a header-versioned route
+// Each version's handler chain lives on an internal engine under a /<version> prefix, and the public
+// route dispatches to it so per-operation middleware keeps normal c.Next semantics
+func (r *GinRouter) registerVersion(group *gin.RouterGroup, method, ginPath, version string, handlers []gin.HandlerFunc) error {
+	fullPath := mountPath(strings.TrimSuffix(group.BasePath(), "/"), ginPath)
+	key := method + " " + fullPath
+
+	if r.versionEngine == nil {
+		r.versionEngine = gin.New()
+		r.versionRoutes = make(map[string][]string)
+	}
+
+	versions, exists := r.versionRoutes[key]
+	for _, v := range versions {
+		if v == version {
+			return fmt.Errorf("version %s of %s is already registered", version, key)
+		}
+	}
+
+	r.versionEngine.Handle(method, "/"+version+fullPath, append([]gin.HandlerFunc{inheritParentKeys}, handlers...)...)
+	versions = append(versions, version)
+	sort.Strings(versions)
+	r.versionRoutes[key] = versions
+
+	if !exists {
+		group.Handle(method, ginPath, r.dispatchVersion(key))
+	}
+	return nil
+}
+
+// dispatchVersion selects the requested version of a route and serves it from the version engine
+func (r *GinRouter) dispatchVersion(key string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		versions := r.versionRoutes[key]
+
+		version := c.GetHeader(goop.APIVersionHeader)
+		if version == "" {
+			version = versions[len(versions)-1]
+			if containsVersion(versions, r.defaultVersion) {
+				version = r.defaultVersion
+			}
+		}
+		if !containsVersion(versions, version) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Unsupported API version",
+				"details": fmt.Sprintf("supported versions: %s", strings.Join(versions, ", ")),
+			})
+			c.Abort()
+			return
+		}
+
+		c.Header(goop.APIVersionHeader, version)
+
+		req := c.Request.Clone(context.WithValue(c.Request.Context(), versionParentKey{}, c))
+		req.URL.Path = "/" + version + c.Request.URL.Path
+		req.URL.RawPath = ""
+		r.versionEngine.ServeHTTP(c.Writer, req)
+	}
+}
+
+// inheritParentKeys copies values set by outer middleware (such as the authenticated principal)
+// into the version engine's context
+func inheritParentKeys(c *gin.Context) {
+	if parent, ok := c.Request.Context().Value(versionParentKey{}).(*gin.Context); ok {
+		for key, value := range parent.Keys {
+			c.Set(key, value)
+		}
+	}
+}
+
+func containsVersion(versions []string, version string) bool {
+	for _, v := range versions {
+		if v == version {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+package gin_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/picogrid/go-op/operations"
+	ginadapter "github.com/picogrid/go-op/operations/adapters/gin"
+)
+
+// TestVersioning tests routing of several API versions of the same operation
+func TestVersioning(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	versioned := func(version string) operations.CompiledOperation {
+		handler := func(ctx context.Context, _ struct{}, _ struct{}, _ struct{}) (map[string]string, error) {
+			user, _ := ctx.Value("user").(string)
+			return map[string]string{"version": version, "user": user}, nil
+		}
+		return operations.NewSimple().
+			GET("/users/{id}").
+			Version(version).
+			Handler(ginadapter.CreateValidatedHandler(handler, nil, nil, nil, nil))
+	}
+
+	send := func(engine *gin.Engine, path, version string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", path, nil)
+		if version != "" {
+			req.Header.Set("API-Version", version)
+		}
+		engine.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("selects version by header", func(t *testing.T) {
+		engine := gin.New()
+		router := ginadapter.NewGinRouter(engine)
+		api := router.Group("/api", func(c *gin.Context) { c.Set("user", "alice") })
+		assert.NoError(t, api.Register(versioned("2023-01-15"), versioned("2024-06-01")))
+
+		w := send(engine, "/api/users/42", "2023-01-15")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"version":"2023-01-15","user":"alice"}`, w.Body.String())
+		assert.Equal(t, "2023-01-15", w.Header().Get("API-Version"))
+
+		w = send(engine, "/api/users/42", "")
+		assert.Contains(t, w.Body.String(), `"version":"2024-06-01"`, "expected latest version by default")
+
+		w = send(engine, "/api/users/42", "1999-01-01")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Unsupported API version")
+	})
+
+	t.Run("default version applies without a header", func(t *testing.T) {
+		engine := gin.New()
+		router := ginadapter.NewGinRouter(engine)
+		router.SetVersioning(ginadapter.VersionByHeader, "2023-01-15")
+		assert.NoError(t, router.Register(versioned("2023-01-15"), versioned("2024-06-01")))
+
+		w := send(engine, "/users/1", "")
+		assert.Contains(t, w.Body.String(), `"version":"2023-01-15"`)
+	})
+
+	t.Run("duplicate versions are rejected", func(t *testing.T) {
+		router := ginadapter.NewGinRouter(gin.New())
+		assert.Error(t, router.Register(versioned("v1"), versioned("v1")))
+	})
+
+	t.Run("selects version by path segment", func(t *testing.T) {
+		engine := gin.New()
+		router := ginadapter.NewGinRouter(engine)
+		router.SetVersioning(ginadapter.VersionByPath, "")
+		assert.NoError(t, router.Register(versioned("v1"), versioned("v2")))
+
+		assert.Contains(t, send(engine, "/v1/users/7", "").Body.String(), `"version":"v1"`)
+		assert.Contains(t, send(engine, "/v2/users/7", "").Body.String(), `"version":"v2"`)
+
+		paths := []string{}
+		for _, op := range router.GetOperations() {
+			paths = append(paths, op.Path)
+		}
+		assert.ElementsMatch(t, []string{"/v1/users/{id}", "/v2/users/{id}"}, paths)
+	})
+}
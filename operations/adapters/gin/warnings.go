@@ -0,0 +1,53 @@
+package gin
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// WarningsKey is the Gin context key holding the validation warnings of a request, set by
+// validated handlers when request validation reports any
+const WarningsKey = "goop.warnings"
+
+// WarningHeader is the response header listing validation warnings when enabled with ValidationWarningHeader
+const WarningHeader = "X-Validation-Warnings"
+
+// warningHeaderKey is the Gin context key enabling WarningHeader for a request
+const warningHeaderKey = "goop.warningHeader"
+
+// ValidationWarningHeader creates middleware that makes validated handlers later in the chain list
+// request validation warnings in the X-Validation-Warnings header, as "field: message" pairs
+// separated by semicolons
+func ValidationWarningHeader() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(warningHeaderKey, true)
+		c.Next()
+	}
+}
+
+// reportWarnings adds request validation warnings to a successful response
+// Bodies implementing goop.WarningReceiver, such as operations.Envelope, receive the warnings.
+func reportWarnings(c *gin.Context, result interface{}, warnings []goop.ValidationError) {
+	if c.GetBool(warningHeaderKey) {
+		c.Header(WarningHeader, formatWarnings(warnings))
+	}
+	if receiver, ok := result.(goop.WarningReceiver); ok {
+		receiver.SetWarnings(warnings)
+	}
+}
+
+// formatWarnings joins warnings for the WarningHeader value
+func formatWarnings(warnings []goop.ValidationError) string {
+	parts := make([]string, len(warnings))
+	for i, warning := range warnings {
+		if warning.Field == "" {
+			parts[i] = warning.Message
+		} else {
+			parts[i] = warning.Field + ": " + warning.Message
+		}
+	}
+	return strings.Join(parts, "; ")
+}
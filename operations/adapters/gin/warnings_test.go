@@ -0,0 +1,69 @@
+package gin_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/picogrid/go-op/operations"
+	ginadapter "github.com/picogrid/go-op/operations/adapters/gin"
+	"github.com/picogrid/go-op/validators"
+)
+
+type profileBody struct {
+	Name     string `json:"name"`
+	Nickname string `json:"nickname,omitempty"`
+}
+
+func TestValidationWarnings(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	bodySchema := validators.Object(map[string]interface{}{
+		"name":     validators.String().Required(),
+		"nickname": validators.String().Optional(),
+	}).DeprecatedField("nickname", "nickname is replaced by display_name").Required()
+
+	handler := ginadapter.CreateValidatedHandler(
+		func(ctx context.Context, params struct{}, query struct{}, body profileBody) (operations.Envelope[profileBody], error) {
+			return operations.NewEnvelope(body), nil
+		},
+		nil, nil, bodySchema, operations.EnvelopeSchema(validators.Object(map[string]interface{}{
+			"name":     validators.String().Required(),
+			"nickname": validators.String().Optional(),
+		}).Required()),
+	)
+
+	engine := gin.New()
+	engine.POST("/profile", ginadapter.ValidationWarningHeader(), handler)
+
+	request := func(body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/profile", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		engine.ServeHTTP(w, req)
+		return w
+	}
+
+	w := request(`{"name": "Ada", "nickname": "ada"}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "nickname: nickname is replaced by display_name", w.Header().Get(ginadapter.WarningHeader))
+
+	var response operations.Envelope[profileBody]
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, []operations.ErrorObject{{
+		Code:    operations.WarningCode,
+		Message: "nickname is replaced by display_name",
+		Field:   "nickname",
+	}}, response.Warnings)
+
+	w = request(`{"name": "Ada"}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get(ginadapter.WarningHeader))
+	assert.NotContains(t, w.Body.String(), "warnings")
+}
@@ -0,0 +1,209 @@
+// Package msgops validates inbound messages from queues and streams such as Kafka, NATS, and SQS
+// with the same schemas and error shapes used for HTTP operations.
+//
+// Consumers are broker-agnostic: convert each delivery into a Message and pass it to Handle.
+//
+//	consumer := msgops.NewConsumer(orderCreatedSchema, handleOrderCreated)
+//	nc.Subscribe("orders.created", func(m *nats.Msg) {
+//	    if err := consumer.Handle(ctx, msgops.Message{Topic: m.Subject, Body: m.Data}); err == nil {
+//	        m.Ack()
+//	    }
+//	})
+package msgops
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// ErrInvalidMessage is matched by errors for messages that cannot be decoded or fail validation
+// Retrying such messages cannot succeed, so they are usually acknowledged or dead-lettered.
+var ErrInvalidMessage = errors.New("invalid message")
+
+// Message is an inbound message from a queue or stream
+type Message struct {
+	// Topic is the topic, subject, or queue the message was received from
+	Topic   string
+	Key     []byte
+	Headers map[string]string
+	Body    []byte
+}
+
+// Handler processes a validated message payload
+type Handler[T any] func(ctx context.Context, payload T, msg Message) error
+
+// Error describes why a message was not handled, in the same shape as HTTP error responses
+type Error struct {
+	Message string `json:"error"`
+	Details string `json:"details"`
+	Err     error  `json:"-"`
+	invalid bool
+}
+
+func (e *Error) Error() string {
+	return e.Message + ": " + e.Details
+}
+
+// Unwrap returns the underlying error, and ErrInvalidMessage for invalid messages
+func (e *Error) Unwrap() []error {
+	if e.invalid {
+		return []error{e.Err, ErrInvalidMessage}
+	}
+	return []error{e.Err}
+}
+
+// Outcome statuses reported to observers
+const (
+	StatusHandled = "handled"
+	StatusInvalid = "invalid"
+	StatusFailed  = "failed"
+)
+
+// Outcome reports how a message was processed, for metrics such as counters and latency histograms
+type Outcome struct {
+	Topic   string
+	Status  string
+	Latency time.Duration
+	Err     error
+}
+
+// ConsumerOption configures a Consumer
+type ConsumerOption func(*consumerConfig)
+
+type consumerConfig struct {
+	logger     *slog.Logger
+	observer   func(ctx context.Context, outcome Outcome)
+	deadLetter func(ctx context.Context, msg Message, err error) error
+}
+
+// WithLogger logs each message with its latency and outcome
+// Validation failures are logged at warn level and handler errors at error level, as RequestLogger does for HTTP operations
+func WithLogger(logger *slog.Logger) ConsumerOption {
+	return func(c *consumerConfig) {
+		c.logger = logger
+	}
+}
+
+// WithObserver calls observer after each message, typically to record metrics
+func WithObserver(observer func(ctx context.Context, outcome Outcome)) ConsumerOption {
+	return func(c *consumerConfig) {
+		c.observer = observer
+	}
+}
+
+// WithDeadLetter passes invalid messages to deadLetter instead of returning their errors from Handle
+// Handle returns the error from deadLetter, so a nil error acknowledges the invalid message.
+func WithDeadLetter(deadLetter func(ctx context.Context, msg Message, err error) error) ConsumerOption {
+	return func(c *consumerConfig) {
+		c.deadLetter = deadLetter
+	}
+}
+
+// Consumer decodes and validates message payloads before passing them to a handler
+type Consumer[T any] struct {
+	schema  goop.Schema
+	handler Handler[T]
+	config  consumerConfig
+}
+
+// NewConsumer creates a consumer that decodes JSON payloads into T and validates them with schema
+// A nil schema skips validation. Types with generated validators are checked directly.
+//
+// Example:
+//
+//	consumer := msgops.NewConsumer(orderCreatedSchema,
+//	    func(ctx context.Context, event OrderCreated, msg msgops.Message) error {
+//	        return orders.Fulfill(ctx, event.OrderID)
+//	    },
+//	    msgops.WithLogger(logger),
+//	)
+func NewConsumer[T any](schema goop.Schema, handler Handler[T], opts ...ConsumerOption) *Consumer[T] {
+	consumer := &Consumer[T]{
+		// Compile the schema once so each message uses the precomputed validation path
+		schema:  goop.CompileSchema(schema),
+		handler: handler,
+	}
+	for _, opt := range opts {
+		opt(&consumer.config)
+	}
+	return consumer
+}
+
+// Handle decodes, validates, and handles msg
+// Errors are *Error values; invalid messages match ErrInvalidMessage with errors.Is.
+func (c *Consumer[T]) Handle(ctx context.Context, msg Message) error {
+	start := time.Now()
+	err := c.handle(ctx, msg)
+
+	outcome := Outcome{Topic: msg.Topic, Status: StatusHandled, Latency: time.Since(start), Err: err}
+	switch {
+	case errors.Is(err, ErrInvalidMessage):
+		outcome.Status = StatusInvalid
+	case err != nil:
+		outcome.Status = StatusFailed
+	}
+	c.report(ctx, outcome)
+
+	if outcome.Status == StatusInvalid && c.config.deadLetter != nil {
+		return c.config.deadLetter(ctx, msg, err)
+	}
+	return err
+}
+
+func (c *Consumer[T]) handle(ctx context.Context, msg Message) error {
+	var payload T
+	if err := json.Unmarshal(msg.Body, &payload); err != nil {
+		return &Error{Message: "Invalid message payload", Details: err.Error(), Err: err, invalid: true}
+	}
+
+	if c.schema != nil {
+		// Types with generated validators are checked directly, skipping map conversion
+		var validationErr error
+		if validatable, ok := any(&payload).(goop.GeneratedValidator); ok {
+			validationErr = validatable.Validate()
+		} else {
+			// Validate the decoded payload, as schemas expect generic JSON values rather than structs
+			data, err := goop.DecodeJSON(msg.Body)
+			if err != nil {
+				return &Error{Message: "Failed to process message payload", Details: err.Error(), Err: err, invalid: true}
+			}
+			validationErr = goop.ValidateCtx(ctx, c.schema, data)
+		}
+		if validationErr != nil {
+			return &Error{Message: "Message payload validation failed", Details: validationErr.Error(), Err: validationErr, invalid: true}
+		}
+	}
+
+	if err := c.handler(ctx, payload, msg); err != nil {
+		return &Error{Message: "Message handler failed", Details: err.Error(), Err: err}
+	}
+	return nil
+}
+
+// report logs and observes the outcome of a message
+func (c *Consumer[T]) report(ctx context.Context, outcome Outcome) {
+	if c.config.observer != nil {
+		c.config.observer(ctx, outcome)
+	}
+	if c.config.logger == nil {
+		return
+	}
+
+	attrs := []any{
+		slog.String("topic", outcome.Topic),
+		slog.Duration("latency", outcome.Latency),
+	}
+	switch outcome.Status {
+	case StatusInvalid:
+		c.config.logger.WarnContext(ctx, "message validation failed", append(attrs, slog.String("error", outcome.Err.Error()))...)
+	case StatusFailed:
+		c.config.logger.ErrorContext(ctx, "message handler failed", append(attrs, slog.String("error", outcome.Err.Error()))...)
+	default:
+		c.config.logger.InfoContext(ctx, "message handled", attrs...)
+	}
+}
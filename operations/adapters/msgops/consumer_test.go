@@ -0,0 +1,124 @@
+package msgops_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/picogrid/go-op/operations/adapters/msgops"
+	"github.com/picogrid/go-op/validators"
+)
+
+type orderCreated struct {
+	OrderID string  `json:"order_id"`
+	Total   float64 `json:"total"`
+}
+
+// TestConsumer tests decoding, validating, and handling messages
+func TestConsumer(t *testing.T) {
+	schema := validators.Object(map[string]interface{}{
+		"order_id": validators.String().Min(1).Required(),
+		"total":    validators.Number().Min(0).Required(),
+	}).Strict().Required()
+
+	var handled []orderCreated
+	handler := func(ctx context.Context, event orderCreated, msg msgops.Message) error {
+		if event.OrderID == "fail" {
+			return errors.New("database unavailable")
+		}
+		handled = append(handled, event)
+		return nil
+	}
+
+	var outcomes []msgops.Outcome
+	var logs bytes.Buffer
+	consumer := msgops.NewConsumer(schema, handler,
+		msgops.WithObserver(func(ctx context.Context, outcome msgops.Outcome) {
+			outcomes = append(outcomes, outcome)
+		}),
+		msgops.WithLogger(slog.New(slog.NewJSONHandler(&logs, nil))),
+	)
+	handle := func(body string) error {
+		return consumer.Handle(context.Background(), msgops.Message{Topic: "orders.created", Body: []byte(body)})
+	}
+
+	t.Run("handles valid messages", func(t *testing.T) {
+		require.NoError(t, handle(`{"order_id":"ord_1","total":42}`))
+		assert.Equal(t, []orderCreated{{OrderID: "ord_1", Total: 42}}, handled)
+		assert.Equal(t, msgops.StatusHandled, outcomes[len(outcomes)-1].Status)
+		assert.Equal(t, "orders.created", outcomes[len(outcomes)-1].Topic)
+	})
+
+	t.Run("rejects invalid payloads", func(t *testing.T) {
+		err := handle(`{"order_id":"ord_2","total":-1}`)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, msgops.ErrInvalidMessage)
+
+		var msgErr *msgops.Error
+		require.ErrorAs(t, err, &msgErr)
+		assert.Equal(t, "Message payload validation failed", msgErr.Message)
+		assert.Equal(t, msgops.StatusInvalid, outcomes[len(outcomes)-1].Status)
+	})
+
+	t.Run("validates the payload as sent", func(t *testing.T) {
+		err := handle(`{"order_id":"ord_3","total":1,"coupon":"FREE"}`)
+		assert.ErrorIs(t, err, msgops.ErrInvalidMessage)
+	})
+
+	t.Run("rejects malformed payloads", func(t *testing.T) {
+		err := handle(`{"order_id":`)
+		assert.ErrorIs(t, err, msgops.ErrInvalidMessage)
+
+		var msgErr *msgops.Error
+		require.ErrorAs(t, err, &msgErr)
+		assert.Equal(t, "Invalid message payload", msgErr.Message)
+	})
+
+	t.Run("reports handler errors", func(t *testing.T) {
+		err := handle(`{"order_id":"fail","total":1}`)
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, msgops.ErrInvalidMessage)
+		assert.Equal(t, msgops.StatusFailed, outcomes[len(outcomes)-1].Status)
+
+		body, marshalErr := json.Marshal(err)
+		require.NoError(t, marshalErr)
+		assert.JSONEq(t, `{"error":"Message handler failed","details":"database unavailable"}`, string(body))
+	})
+
+	t.Run("logs outcomes", func(t *testing.T) {
+		assert.Contains(t, logs.String(), `"msg":"message handled"`)
+		assert.Contains(t, logs.String(), `"msg":"message validation failed"`)
+		assert.Contains(t, logs.String(), `"msg":"message handler failed"`)
+	})
+}
+
+// TestConsumerDeadLetter tests routing invalid messages to a dead letter handler
+func TestConsumerDeadLetter(t *testing.T) {
+	schema := validators.Object(map[string]interface{}{
+		"order_id": validators.String().Min(1).Required(),
+	}).Required()
+
+	var deadLettered []string
+	consumer := msgops.NewConsumer(schema,
+		func(ctx context.Context, event orderCreated, msg msgops.Message) error {
+			return errors.New("handler failed")
+		},
+		msgops.WithDeadLetter(func(ctx context.Context, msg msgops.Message, err error) error {
+			deadLettered = append(deadLettered, string(msg.Body))
+			return nil
+		}),
+	)
+
+	assert.NoError(t, consumer.Handle(context.Background(), msgops.Message{Body: []byte(`{}`)}))
+	assert.Equal(t, []string{`{}`}, deadLettered)
+
+	// Handler failures are returned for redelivery rather than dead-lettered
+	assert.Error(t, consumer.Handle(context.Background(), msgops.Message{Body: []byte(`{"order_id":"ord_1"}`)}))
+	assert.Len(t, deadLettered, 1)
+}
@@ -0,0 +1,251 @@
+package operations
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	goop "github.com/picogrid/go-op"
+	"github.com/picogrid/go-op/validators"
+)
+
+// DefaultJobStatusPath serves the status of asynchronous operations unless StatusPath is set
+const DefaultJobStatusPath = "/jobs/{id}"
+
+// DefaultJobRetryAfter is the polling interval suggested to clients unless RetryAfter is set
+const DefaultJobRetryAfter = 5 * time.Second
+
+// Job is the status resource of an asynchronous operation
+type Job = goop.Job
+
+// JobStore persists the jobs of asynchronous operations
+type JobStore = goop.JobStore
+
+// Job statuses, from acceptance to completion
+const (
+	JobPending   = goop.JobPending
+	JobRunning   = goop.JobRunning
+	JobSucceeded = goop.JobSucceeded
+	JobFailed    = goop.JobFailed
+)
+
+// AsyncHandler serves asynchronous operations, accepting requests with 202 and running their work
+// in the background. Registering one as the handler of an operation validates the body against the
+// schema set with WithBody, documents the 202 Job response with its Location and Retry-After
+// headers, and registers the job status operation with the router.
+type AsyncHandler[B, R any] struct {
+	handler      func(ctx context.Context, body B) (R, error)
+	store        goop.JobStore
+	bodySchema   goop.Schema
+	resultSchema goop.Schema
+	operationID  string
+	statusPath   string
+	retryAfter   time.Duration
+}
+
+// Async creates a handler that records a job in store for each request and runs handler for it
+// The result of handler becomes the result of the job; an error fails the job with its message.
+// The work runs with the request context values but is not cancelled when the response is sent.
+//
+// Example:
+//
+//	operations.NewSimple().
+//	    POST("/reports").
+//	    WithBody(reportSchema).
+//	    Handler(operations.Async(generateReport, jobStore))
+func Async[B, R any](handler func(ctx context.Context, body B) (R, error), store goop.JobStore) *AsyncHandler[B, R] {
+	return &AsyncHandler[B, R]{
+		handler:    handler,
+		store:      store,
+		statusPath: DefaultJobStatusPath,
+		retryAfter: DefaultJobRetryAfter,
+	}
+}
+
+// StatusPath sets the path of the job status operation, which must end with the {id} segment
+func (a *AsyncHandler[B, R]) StatusPath(statusPath string) *AsyncHandler[B, R] {
+	a.statusPath = statusPath
+	return a
+}
+
+// RetryAfter sets how long clients are asked to wait before polling the job again
+func (a *AsyncHandler[B, R]) RetryAfter(interval time.Duration) *AsyncHandler[B, R] {
+	a.retryAfter = interval
+	return a
+}
+
+// Result documents the schema of the result of succeeded jobs
+func (a *AsyncHandler[B, R]) Result(schema goop.Schema) *AsyncHandler[B, R] {
+	a.resultSchema = schema
+	return a
+}
+
+// ServeHTTP validates the request, records a pending job, and starts the work
+func (a *AsyncHandler[B, R]) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var body B
+	if a.bodySchema != nil {
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeBadRequest(w, r, "Invalid request body", err.Error())
+			return
+		}
+		value, err := goop.DecodeJSON(data)
+		if err != nil {
+			writeBadRequest(w, r, "Invalid request body", err.Error())
+			return
+		}
+		if err := goop.ValidateCtx(r.Context(), a.bodySchema, value); err != nil {
+			writeBadRequest(w, r, "Request body validation failed", err.Error())
+			return
+		}
+		if err := json.Unmarshal(data, &body); err != nil {
+			writeBadRequest(w, r, "Invalid request body", err.Error())
+			return
+		}
+	}
+
+	now := time.Now().UTC()
+	job := goop.Job{ID: uuid.NewString(), OperationID: a.operationID, Status: goop.JobPending, CreatedAt: now, UpdatedAt: now}
+	if err := a.store.Save(r.Context(), job); err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "Failed to create job", "details": err.Error()})
+		return
+	}
+
+	go a.run(context.WithoutCancel(r.Context()), job, body)
+
+	w.Header().Set(goop.LocationHeader, jobLocation(a.statusPath, job.ID))
+	w.Header().Set(goop.RetryAfterHeader, retryAfterSeconds(a.retryAfter))
+	writeJSON(w, http.StatusAccepted, job)
+}
+
+// run performs the work of job and records its outcome
+// Store errors cannot be reported to the client, so a job that cannot be saved stays in its last saved state.
+func (a *AsyncHandler[B, R]) run(ctx context.Context, job goop.Job, body B) {
+	job.Status, job.UpdatedAt = goop.JobRunning, time.Now().UTC()
+	_ = a.store.Save(ctx, job)
+
+	result, err := a.handler(ctx, body)
+	job.UpdatedAt = time.Now().UTC()
+	if err != nil {
+		job.Status, job.Error = goop.JobFailed, err.Error()
+	} else {
+		job.Status, job.Result = goop.JobSucceeded, result
+	}
+	_ = a.store.Save(ctx, job)
+}
+
+// prepare binds the handler to the operation it serves
+func (a *AsyncHandler[B, R]) prepare(operationID string, bodySchema goop.Schema) asyncOperation {
+	prepared := *a
+	prepared.operationID = operationID
+	prepared.bodySchema = goop.CompileSchema(bodySchema)
+	return &prepared
+}
+
+// statusOperation returns the operation serving the jobs of the handler
+func (a *AsyncHandler[B, R]) statusOperation() CompiledOperation {
+	return JobStatus(a.statusPath, a.store, a.retryAfter, a.resultSchema)
+}
+
+// asyncOperation is implemented by async handlers so the builder can bind and document them
+type asyncOperation interface {
+	prepare(operationID string, bodySchema goop.Schema) asyncOperation
+	statusOperation() CompiledOperation
+}
+
+// JobStatus creates the operation reporting jobs in store, served at statusPath ending with {id}
+// Async operations register it automatically; use it directly to serve jobs created elsewhere.
+// Unfinished jobs are returned with a Retry-After header and unknown ones with 404. The status is
+// one of pending, running, succeeded, or failed.
+func JobStatus(statusPath string, store goop.JobStore, retryAfter time.Duration, resultSchema goop.Schema) CompiledOperation {
+	suffix := statusPath[strings.Index(statusPath, "{id}")+len("{id}"):]
+
+	return NewSimple().
+		GET(statusPath).
+		OperationID("getJob").
+		Summary("Get job status").
+		Description("Reports the status of an asynchronous operation, and its result or error once done").
+		Tags("jobs").
+		Idempotency(goop.Idempotent).
+		WithoutDefaultResponses(http.StatusBadRequest).
+		WithSuccessResponse(http.StatusOK, JobSchema(resultSchema), "Current state of the job").
+		WithNotFoundError(NotFoundErrorSchema).
+		Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// The ID is the last segment before any fixed suffix, wherever the operation is mounted
+			id := path.Base(strings.TrimSuffix(r.URL.Path, suffix))
+			job, err := store.Get(r.Context(), id)
+			if err != nil {
+				writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "Failed to load job", "details": err.Error()})
+				return
+			}
+			if job == nil {
+				writeJSON(w, http.StatusNotFound, map[string]string{"error": "not_found", "message": "The requested job was not found"})
+				return
+			}
+			if !job.Done() {
+				w.Header().Set(goop.RetryAfterHeader, retryAfterSeconds(retryAfter))
+			}
+			writeJSON(w, http.StatusOK, job)
+		}))
+}
+
+// JobSchema returns the schema of the Job resource, with the result of succeeded jobs described by
+// resultSchema. A nil resultSchema leaves the result undocumented.
+func JobSchema(resultSchema goop.Schema) goop.Schema {
+	fields := map[string]interface{}{
+		"id":           validators.String().Min(1).Example("0b0c2f9e-4f5d-4c8e-9d0a-1f7b6f0a3c21").Required(),
+		"operation_id": validators.String().Optional(),
+		"status":       validators.String().Example(goop.JobPending).Required(),
+		"error":        validators.String().Optional(),
+		"created_at":   validators.String().Required(),
+		"updated_at":   validators.String().Required(),
+	}
+	if resultSchema != nil {
+		fields["result"] = resultSchema
+	}
+	return validators.Object(fields).Required()
+}
+
+// jobLocation returns the URL of the job with id, served at statusPath
+func jobLocation(statusPath, id string) string {
+	return strings.Replace(statusPath, "{id}", id, 1)
+}
+
+// retryAfterSeconds formats interval as a Retry-After value, rounding up to whole seconds
+func retryAfterSeconds(interval time.Duration) string {
+	return strconv.FormatInt(int64((interval+time.Second-1)/time.Second), 10)
+}
+
+// writeJSON writes body as a JSON response with code
+func writeJSON(w http.ResponseWriter, code int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// documentAsync adds the Location and Retry-After headers to the 202 response of an asynchronous operation
+func documentAsync(operation *OpenAPIOperation) {
+	response, exists := operation.Responses["202"]
+	if !exists {
+		return
+	}
+	if response.Headers == nil {
+		response.Headers = make(map[string]OpenAPIHeader)
+	}
+	response.Headers[goop.LocationHeader] = OpenAPIHeader{
+		Description: "URL of the job to poll for the outcome of the operation",
+		Schema:      &goop.OpenAPISchema{Type: "string"},
+	}
+	response.Headers[goop.RetryAfterHeader] = OpenAPIHeader{
+		Description: "Seconds to wait before polling the job",
+		Schema:      &goop.OpenAPISchema{Type: "integer"},
+	}
+	operation.Responses["202"] = response
+}
@@ -0,0 +1,150 @@
+package operations
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	goop "github.com/picogrid/go-op"
+	"github.com/picogrid/go-op/validators"
+)
+
+// TestAsync tests accepting asynchronous operations and reporting their jobs
+func TestAsync(t *testing.T) {
+	type ReportRequest struct {
+		Month string `json:"month"`
+	}
+	bodySchema := validators.Object(map[string]interface{}{
+		"month": validators.String().Pattern(`^\d{4}-\d{2}$`).Required(),
+	}).Required()
+	generate := func(ctx context.Context, body ReportRequest) (map[string]string, error) {
+		if body.Month == "1999-12" {
+			return nil, errors.New("no data before 2000")
+		}
+		return map[string]string{"url": "/reports/" + body.Month + ".csv"}, nil
+	}
+
+	store := NewMemoryJobStore(time.Hour)
+	op := NewSimple().
+		POST("/reports").
+		OperationID("generateReport").
+		WithBody(bodySchema).
+		Handler(Async(generate, store).RetryAfter(1500 * time.Millisecond))
+
+	submit := func(t *testing.T, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		w := httptest.NewRecorder()
+		op.Handler.(http.Handler).ServeHTTP(w, httptest.NewRequest(op.Method, op.Path, strings.NewReader(body)))
+		return w
+	}
+	poll := func(t *testing.T, location string) Job {
+		t.Helper()
+		status := op.JobStatus.Handler.(http.Handler)
+		deadline := time.Now().Add(time.Second)
+		for {
+			w := httptest.NewRecorder()
+			status.ServeHTTP(w, httptest.NewRequest(http.MethodGet, location, nil))
+			if w.Code != http.StatusOK {
+				t.Fatalf("Expected 200 polling %s, got %d", location, w.Code)
+			}
+			var job Job
+			if err := json.Unmarshal(w.Body.Bytes(), &job); err != nil {
+				t.Fatalf("Failed to decode job: %v", err)
+			}
+			if job.Done() || time.Now().After(deadline) {
+				return job
+			}
+			if w.Header().Get(goop.RetryAfterHeader) == "" {
+				t.Error("Expected unfinished jobs to carry Retry-After")
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}
+
+	t.Run("Accepts the request and completes the job", func(t *testing.T) {
+		w := submit(t, `{"month": "2024-06"}`)
+		if w.Code != http.StatusAccepted {
+			t.Fatalf("Expected 202, got %d: %s", w.Code, w.Body.String())
+		}
+		if got := w.Header().Get(goop.RetryAfterHeader); got != "2" {
+			t.Errorf("Expected Retry-After rounded up to 2 seconds, got %q", got)
+		}
+		var accepted Job
+		if err := json.Unmarshal(w.Body.Bytes(), &accepted); err != nil {
+			t.Fatalf("Failed to decode job: %v", err)
+		}
+		if accepted.Status != JobPending || accepted.OperationID != "generateReport" {
+			t.Errorf("Expected a pending generateReport job, got %+v", accepted)
+		}
+		location := w.Header().Get(goop.LocationHeader)
+		if location != "/jobs/"+accepted.ID {
+			t.Fatalf("Expected Location /jobs/%s, got %q", accepted.ID, location)
+		}
+
+		job := poll(t, location)
+		if job.Status != JobSucceeded || job.Result.(map[string]interface{})["url"] != "/reports/2024-06.csv" {
+			t.Errorf("Expected the report URL, got %+v", job)
+		}
+	})
+
+	t.Run("Records failures on the job", func(t *testing.T) {
+		w := submit(t, `{"month": "1999-12"}`)
+		job := poll(t, w.Header().Get(goop.LocationHeader))
+		if job.Status != JobFailed || job.Error != "no data before 2000" {
+			t.Errorf("Expected a failed job, got %+v", job)
+		}
+	})
+
+	t.Run("Rejects invalid bodies without creating a job", func(t *testing.T) {
+		if w := submit(t, `{"month": "June"}`); w.Code != http.StatusBadRequest {
+			t.Errorf("Expected 400, got %d", w.Code)
+		}
+	})
+
+	t.Run("Reports unknown jobs as not found", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		op.JobStatus.Handler.(http.Handler).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/jobs/unknown", nil))
+		if w.Code != http.StatusNotFound {
+			t.Errorf("Expected 404, got %d", w.Code)
+		}
+	})
+}
+
+// TestAsyncSpec tests documenting asynchronous operations and registering their status operation once
+func TestAsyncSpec(t *testing.T) {
+	store := NewMemoryJobStore(time.Hour)
+	work := func(ctx context.Context, body struct{}) (struct{}, error) { return struct{}{}, nil }
+
+	generator := NewOpenAPIGenerator("Test API", "1.0.0")
+	router := NewRouter(generator)
+	for _, op := range []CompiledOperation{
+		NewSimple().POST("/reports").Handler(Async(work, store)),
+		NewSimple().POST("/exports").Handler(Async(work, store)),
+	} {
+		if err := router.Register(op); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+	}
+
+	if got := len(router.GetOperations()); got != 3 {
+		t.Errorf("Expected the status operation to be registered once, got %d operations", got)
+	}
+	if _, exists := generator.Spec.Paths["/jobs/{id}"]["get"]; !exists {
+		t.Fatal("Expected the job status operation to be documented")
+	}
+
+	accepted, exists := generator.Spec.Paths["/reports"]["post"].Responses["202"]
+	if !exists {
+		t.Fatal("Expected a documented 202 response")
+	}
+	for _, header := range []string{goop.LocationHeader, goop.RetryAfterHeader} {
+		if _, exists := accepted.Headers[header]; !exists {
+			t.Errorf("Expected the 202 response to document %s", header)
+		}
+	}
+}
@@ -0,0 +1,275 @@
+package operations
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// asyncMessagePrefix is the reference prefix for message components in AsyncAPI documents
+const asyncMessagePrefix = "#/components/messages/"
+
+// AsyncAPIGenerator generates AsyncAPI 2.6 documents for event-driven APIs such as Kafka topics
+// and outgoing webhooks, documenting message payloads with the same schemas used for validation
+type AsyncAPIGenerator struct {
+	Title       string
+	Version     string
+	Description string
+	Spec        *AsyncAPISpec
+}
+
+// AsyncChannel registers a channel and the messages an application exchanges on it
+type AsyncChannel struct {
+	// Name is the channel address, such as a topic name; {name} segments are channel parameters
+	Name        string
+	Description string
+
+	// Parameters is an object schema documenting the channel parameters
+	Parameters goop.Schema
+
+	// Sends lists messages the application publishes to the channel, documented as the subscribe operation
+	Sends []AsyncMessage
+
+	// Receives lists messages the application consumes from the channel, documented as the publish operation
+	Receives []AsyncMessage
+}
+
+// AsyncMessage describes a message and its payload schema
+// Messages are documented once under components.messages, keyed by Name, and referenced from channels.
+type AsyncMessage struct {
+	Name        string
+	Title       string
+	Summary     string
+	Description string
+
+	// ContentType overrides the document's default content type, application/json
+	ContentType string
+
+	Payload goop.Schema
+	Headers goop.Schema
+}
+
+// AsyncAPISpec represents an AsyncAPI 2.6 document
+type AsyncAPISpec struct {
+	AsyncAPI           string                     `json:"asyncapi" yaml:"asyncapi"`
+	Info               AsyncAPIInfo               `json:"info" yaml:"info"`
+	DefaultContentType string                     `json:"defaultContentType,omitempty" yaml:"defaultContentType,omitempty"`
+	Servers            map[string]AsyncAPIServer  `json:"servers,omitempty" yaml:"servers,omitempty"`
+	Channels           map[string]AsyncAPIChannel `json:"channels" yaml:"channels"`
+	Components         *AsyncAPIComponents        `json:"components,omitempty" yaml:"components,omitempty"`
+}
+
+// AsyncAPIInfo represents the info section of an AsyncAPI document
+type AsyncAPIInfo struct {
+	Title       string          `json:"title" yaml:"title"`
+	Version     string          `json:"version" yaml:"version"`
+	Description string          `json:"description,omitempty" yaml:"description,omitempty"`
+	Contact     *OpenAPIContact `json:"contact,omitempty" yaml:"contact,omitempty"`
+	License     *OpenAPILicense `json:"license,omitempty" yaml:"license,omitempty"`
+}
+
+// AsyncAPIServer represents a message broker in an AsyncAPI document
+type AsyncAPIServer struct {
+	URL             string `json:"url" yaml:"url"`
+	Protocol        string `json:"protocol" yaml:"protocol"`
+	ProtocolVersion string `json:"protocolVersion,omitempty" yaml:"protocolVersion,omitempty"`
+	Description     string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+// AsyncAPIChannel represents a channel in an AsyncAPI document
+type AsyncAPIChannel struct {
+	Description string                       `json:"description,omitempty" yaml:"description,omitempty"`
+	Parameters  map[string]AsyncAPIParameter `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	Subscribe   *AsyncAPIOperation           `json:"subscribe,omitempty" yaml:"subscribe,omitempty"`
+	Publish     *AsyncAPIOperation           `json:"publish,omitempty" yaml:"publish,omitempty"`
+}
+
+// AsyncAPIParameter represents a channel parameter in an AsyncAPI document
+type AsyncAPIParameter struct {
+	Description string              `json:"description,omitempty" yaml:"description,omitempty"`
+	Schema      *goop.OpenAPISchema `json:"schema,omitempty" yaml:"schema,omitempty"`
+}
+
+// AsyncAPIOperation represents a subscribe or publish operation in an AsyncAPI document
+type AsyncAPIOperation struct {
+	Message AsyncAPIMessage `json:"message" yaml:"message"`
+}
+
+// AsyncAPIMessage represents a message, a reference to one, or a choice between messages
+type AsyncAPIMessage struct {
+	Ref         string              `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+	OneOf       []AsyncAPIMessage   `json:"oneOf,omitempty" yaml:"oneOf,omitempty"`
+	Name        string              `json:"name,omitempty" yaml:"name,omitempty"`
+	Title       string              `json:"title,omitempty" yaml:"title,omitempty"`
+	Summary     string              `json:"summary,omitempty" yaml:"summary,omitempty"`
+	Description string              `json:"description,omitempty" yaml:"description,omitempty"`
+	ContentType string              `json:"contentType,omitempty" yaml:"contentType,omitempty"`
+	Headers     *goop.OpenAPISchema `json:"headers,omitempty" yaml:"headers,omitempty"`
+	Payload     *goop.OpenAPISchema `json:"payload,omitempty" yaml:"payload,omitempty"`
+}
+
+// AsyncAPIComponents represents the components section of an AsyncAPI document
+type AsyncAPIComponents struct {
+	Schemas  map[string]*goop.OpenAPISchema `json:"schemas,omitempty" yaml:"schemas,omitempty"`
+	Messages map[string]AsyncAPIMessage     `json:"messages,omitempty" yaml:"messages,omitempty"`
+}
+
+// NewAsyncAPIGenerator creates a new AsyncAPI generator
+func NewAsyncAPIGenerator(title, version string) *AsyncAPIGenerator {
+	return &AsyncAPIGenerator{
+		Title:   title,
+		Version: version,
+		Spec: &AsyncAPISpec{
+			AsyncAPI: "2.6.0",
+			Info: AsyncAPIInfo{
+				Title:   title,
+				Version: version,
+			},
+			DefaultContentType: "application/json",
+			Servers:            make(map[string]AsyncAPIServer),
+			Channels:           make(map[string]AsyncAPIChannel),
+			Components: &AsyncAPIComponents{
+				Schemas:  make(map[string]*goop.OpenAPISchema),
+				Messages: make(map[string]AsyncAPIMessage),
+			},
+		},
+	}
+}
+
+// SetDescription sets the API description
+func (g *AsyncAPIGenerator) SetDescription(description string) {
+	g.Description = description
+	g.Spec.Info.Description = description
+}
+
+// AddServer adds a message broker the channels are served on
+func (g *AsyncAPIGenerator) AddServer(name string, server AsyncAPIServer) {
+	g.Spec.Servers[name] = server
+}
+
+// AddChannel documents a channel and its messages.
+// It returns an error when a message is missing its name or payload, or when a message name is
+// already registered with a different definition.
+//
+// Example:
+//
+//	err := generator.AddChannel(operations.AsyncChannel{
+//	    Name:  "orders.created",
+//	    Sends: []operations.AsyncMessage{{Name: "OrderCreated", Payload: orderSchema}},
+//	})
+func (g *AsyncAPIGenerator) AddChannel(channel AsyncChannel) error {
+	if channel.Name == "" {
+		return fmt.Errorf("channel name is required")
+	}
+	if _, exists := g.Spec.Channels[channel.Name]; exists {
+		return fmt.Errorf("channel %q is already registered", channel.Name)
+	}
+
+	documented := AsyncAPIChannel{Description: channel.Description}
+	if enhanced, ok := channel.Parameters.(goop.EnhancedSchema); ok {
+		schema := enhanced.ToOpenAPISchema()
+		documented.Parameters = make(map[string]AsyncAPIParameter, len(schema.Properties))
+		for name, property := range schema.Properties {
+			documented.Parameters[name] = AsyncAPIParameter{Description: property.Description, Schema: property}
+			resolveComponentSchemas(g.Spec.Components.Schemas, property, lookupComponent)
+		}
+	}
+
+	var err error
+	if documented.Subscribe, err = g.operation(channel.Name, channel.Sends); err != nil {
+		return err
+	}
+	if documented.Publish, err = g.operation(channel.Name, channel.Receives); err != nil {
+		return err
+	}
+
+	g.Spec.Channels[channel.Name] = documented
+	return nil
+}
+
+// operation registers messages and returns an operation referencing them, or nil without messages
+func (g *AsyncAPIGenerator) operation(channel string, messages []AsyncMessage) (*AsyncAPIOperation, error) {
+	if len(messages) == 0 {
+		return nil, nil
+	}
+
+	refs := make([]AsyncAPIMessage, 0, len(messages))
+	for _, message := range messages {
+		if err := g.registerMessage(message); err != nil {
+			return nil, fmt.Errorf("channel %q: %w", channel, err)
+		}
+		refs = append(refs, AsyncAPIMessage{Ref: asyncMessagePrefix + message.Name})
+	}
+
+	if len(refs) == 1 {
+		return &AsyncAPIOperation{Message: refs[0]}, nil
+	}
+	return &AsyncAPIOperation{Message: AsyncAPIMessage{OneOf: refs}}, nil
+}
+
+// registerMessage adds a message to components.messages
+func (g *AsyncAPIGenerator) registerMessage(message AsyncMessage) error {
+	if err := ValidateComponentKey(message.Name); err != nil {
+		return fmt.Errorf("invalid message name: %w", err)
+	}
+	payload, ok := message.Payload.(goop.EnhancedSchema)
+	if !ok {
+		return fmt.Errorf("message %q requires a payload schema", message.Name)
+	}
+
+	documented := AsyncAPIMessage{
+		Name:        message.Name,
+		Title:       message.Title,
+		Summary:     message.Summary,
+		Description: message.Description,
+		ContentType: message.ContentType,
+		Payload:     payload.ToOpenAPISchema(),
+	}
+	if headers, ok := message.Headers.(goop.EnhancedSchema); ok {
+		documented.Headers = headers.ToOpenAPISchema()
+	}
+
+	if existing, exists := g.Spec.Components.Messages[message.Name]; exists {
+		if !reflect.DeepEqual(existing, documented) {
+			return fmt.Errorf("message %q is already registered with a different definition", message.Name)
+		}
+		return nil
+	}
+
+	resolveComponentSchemas(g.Spec.Components.Schemas, documented.Payload, lookupComponent)
+	resolveComponentSchemas(g.Spec.Components.Schemas, documented.Headers, lookupComponent)
+	g.Spec.Components.Messages[message.Name] = documented
+	return nil
+}
+
+// WriteToFile writes the AsyncAPI document to a file
+func (g *AsyncAPIGenerator) WriteToFile(filename string) error {
+	// Clean and validate the filename to prevent path traversal attacks
+	filename = filepath.Clean(filename)
+	if !filepath.IsAbs(filename) {
+		return fmt.Errorf("filename must be an absolute path")
+	}
+
+	file, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create file %s: %w", filename, err)
+	}
+	defer file.Close()
+
+	return g.WriteToWriter(file)
+}
+
+// WriteToWriter writes the AsyncAPI document to a writer
+func (g *AsyncAPIGenerator) WriteToWriter(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(g.Spec); err != nil {
+		return fmt.Errorf("failed to encode AsyncAPI document: %w", err)
+	}
+	return nil
+}
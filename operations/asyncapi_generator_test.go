@@ -0,0 +1,126 @@
+package operations
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/picogrid/go-op/validators"
+)
+
+func TestAsyncAPIGenerator(t *testing.T) {
+	orderSchema := Component("AsyncOrder", validators.Object(map[string]interface{}{
+		"id":    validators.String().Required(),
+		"total": validators.Number().Min(0).Required(),
+	}).Required())
+	orderCreated := AsyncMessage{
+		Name:    "OrderCreated",
+		Summary: "An order was placed",
+		Payload: validators.Object(map[string]interface{}{
+			"order": orderSchema,
+		}).Required(),
+		Headers: validators.Object(map[string]interface{}{
+			"trace_id": validators.String().Optional(),
+		}).Optional(),
+	}
+	orderCancelled := AsyncMessage{
+		Name:    "OrderCancelled",
+		Payload: validators.Object(map[string]interface{}{"id": validators.String().Required()}).Required(),
+	}
+
+	newGenerator := func(t *testing.T) *AsyncAPIGenerator {
+		t.Helper()
+		generator := NewAsyncAPIGenerator("Order Events", "1.0.0")
+		generator.AddServer("production", AsyncAPIServer{URL: "kafka.example.com:9092", Protocol: "kafka"})
+		err := generator.AddChannel(AsyncChannel{
+			Name:        "orders/{region}",
+			Description: "Order lifecycle events",
+			Parameters: validators.Object(map[string]interface{}{
+				"region": validators.String().Required(),
+			}).Required(),
+			Sends: []AsyncMessage{orderCreated, orderCancelled},
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if err := generator.AddChannel(AsyncChannel{Name: "orders.replay", Receives: []AsyncMessage{orderCreated}}); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		return generator
+	}
+
+	t.Run("Documents channels", func(t *testing.T) {
+		spec := newGenerator(t).Spec
+		if spec.AsyncAPI != "2.6.0" || spec.Info.Title != "Order Events" {
+			t.Errorf("Unexpected document header %+v", spec)
+		}
+
+		channel := spec.Channels["orders/{region}"]
+		if channel.Publish != nil || channel.Subscribe == nil {
+			t.Fatalf("Expected only a subscribe operation, got %+v", channel)
+		}
+		if refs := channel.Subscribe.Message.OneOf; len(refs) != 2 || refs[0].Ref != "#/components/messages/OrderCreated" {
+			t.Errorf("Unexpected messages %+v", refs)
+		}
+		if channel.Parameters["region"].Schema.Type != "string" {
+			t.Errorf("Expected region parameter, got %+v", channel.Parameters)
+		}
+
+		replay := spec.Channels["orders.replay"]
+		if replay.Publish == nil || replay.Publish.Message.Ref != "#/components/messages/OrderCreated" {
+			t.Errorf("Expected a publish operation referencing OrderCreated, got %+v", replay)
+		}
+	})
+
+	t.Run("Documents messages and shared schemas", func(t *testing.T) {
+		spec := newGenerator(t).Spec
+		message := spec.Components.Messages["OrderCreated"]
+		if message.Name != "OrderCreated" || message.Summary != "An order was placed" {
+			t.Errorf("Unexpected message %+v", message)
+		}
+		if message.Payload.Properties["order"].Ref != ComponentRef("AsyncOrder") {
+			t.Errorf("Expected order to reference the shared component, got %+v", message.Payload.Properties["order"])
+		}
+		if message.Headers == nil || message.Headers.Properties["trace_id"] == nil {
+			t.Errorf("Expected trace_id header, got %+v", message.Headers)
+		}
+		if _, ok := spec.Components.Schemas["AsyncOrder"]; !ok {
+			t.Error("Expected AsyncOrder component schema")
+		}
+	})
+
+	t.Run("Writes JSON", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := newGenerator(t).WriteToWriter(&buf); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		var doc map[string]interface{}
+		if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+			t.Fatalf("Expected valid JSON, got: %v", err)
+		}
+		if doc["asyncapi"] != "2.6.0" || doc["defaultContentType"] != "application/json" {
+			t.Errorf("Unexpected document %v", doc)
+		}
+	})
+
+	t.Run("Rejects invalid registrations", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			channel AsyncChannel
+		}{
+			{"Missing channel name", AsyncChannel{Sends: []AsyncMessage{orderCreated}}},
+			{"Duplicate channel", AsyncChannel{Name: "orders.replay"}},
+			{"Missing message name", AsyncChannel{Name: "a", Sends: []AsyncMessage{{Payload: orderCancelled.Payload}}}},
+			{"Missing payload", AsyncChannel{Name: "b", Sends: []AsyncMessage{{Name: "Empty"}}}},
+			{"Conflicting message", AsyncChannel{Name: "c", Sends: []AsyncMessage{{Name: "OrderCreated", Payload: orderCancelled.Payload}}}},
+		}
+		generator := newGenerator(t)
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				if err := generator.AddChannel(tt.channel); err == nil {
+					t.Error("Expected error")
+				}
+			})
+		}
+	})
+}
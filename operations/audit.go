@@ -0,0 +1,47 @@
+package operations
+
+import (
+	"context"
+	"log/slog"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// LogAuditSink writes audit events as structured log records
+// Route the logger to a dedicated handler to keep an audit trail apart from request logs.
+type LogAuditSink struct {
+	logger *slog.Logger
+}
+
+// NewLogAuditSink creates an AuditSink logging each event at info level with logger
+func NewLogAuditSink(logger *slog.Logger) *LogAuditSink {
+	return &LogAuditSink{logger: logger}
+}
+
+// Record logs event with its operation, outcome, actor, target, and changes
+func (s *LogAuditSink) Record(ctx context.Context, event goop.AuditEvent) error {
+	attrs := []slog.Attr{
+		slog.String("operationId", event.OperationID),
+		slog.String("method", event.Method),
+		slog.String("path", event.Path),
+		slog.Time("time", event.Time),
+		slog.Int("status", event.StatusCode),
+	}
+	if event.Actor != nil {
+		attrs = append(attrs, slog.Any("actor", event.Actor))
+	}
+	if event.Tenant != nil {
+		attrs = append(attrs, slog.Any("tenant", event.Tenant))
+	}
+	if len(event.Target) > 0 {
+		attrs = append(attrs, slog.Any("target", event.Target))
+	}
+	if len(event.Changes) > 0 {
+		attrs = append(attrs, slog.Any("changes", event.Changes))
+	}
+	s.logger.LogAttrs(ctx, slog.LevelInfo, "audit", attrs...)
+	return nil
+}
+
+// Compile-time check that LogAuditSink implements AuditSink
+var _ goop.AuditSink = (*LogAuditSink)(nil)
@@ -0,0 +1,44 @@
+package operations
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	goop "github.com/picogrid/go-op"
+)
+
+func TestLogAuditSink(t *testing.T) {
+	var logs bytes.Buffer
+	sink := NewLogAuditSink(slog.New(slog.NewJSONHandler(&logs, nil)))
+
+	err := sink.Record(context.Background(), goop.AuditEvent{
+		OperationID: "updateUser",
+		Method:      "PUT",
+		Path:        "/users/{id}",
+		Actor:       "usr_admin",
+		Target:      map[string]interface{}{"id": "usr_123"},
+		Changes:     []goop.AuditChange{{Field: "name", Value: "Ada"}},
+		StatusCode:  200,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(logs.Bytes(), &entry); err != nil {
+		t.Fatalf("Expected a JSON log record, got %v", err)
+	}
+	if entry["msg"] != "audit" || entry["operationId"] != "updateUser" || entry["actor"] != "usr_admin" {
+		t.Errorf("Unexpected log record: %v", entry)
+	}
+	changes, ok := entry["changes"].([]interface{})
+	if !ok || len(changes) != 1 || changes[0].(map[string]interface{})["field"] != "name" {
+		t.Errorf("Expected the changes to be logged, got %v", entry["changes"])
+	}
+	if _, ok := entry["tenant"]; ok {
+		t.Error("Expected no tenant attribute without a tenant")
+	}
+}
@@ -0,0 +1,201 @@
+package operations
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	goop "github.com/picogrid/go-op"
+	"github.com/picogrid/go-op/validators"
+)
+
+// DefaultMaxBatchItems bounds the number of items in a batch request unless MaxItems is set
+const DefaultMaxBatchItems = 100
+
+// BatchItemHandler processes one validated item of a batch request, returning the resulting resource
+// Returning a *goop.ValidationError reports the item as invalid (400) rather than failed (500).
+type BatchItemHandler[B, R any] func(ctx context.Context, item B) (R, error)
+
+// BatchResult reports the outcome of one item of a batch request
+type BatchResult struct {
+	// Index of the item in the request
+	Index int `json:"index"`
+	// Status is the HTTP status the item would have had as a single request
+	Status   int         `json:"status"`
+	Error    string      `json:"error,omitempty"`
+	Resource interface{} `json:"resource,omitempty"`
+}
+
+// BatchResponse is the 207 Multi-Status response body of batch operations
+type BatchResponse struct {
+	Results   []BatchResult `json:"results"`
+	Succeeded int           `json:"succeeded"`
+	Failed    int           `json:"failed"`
+}
+
+// BatchHandler serves batch operations, validating an array payload and handling each item separately
+// Items that fail do not fail the request: it responds 207 with a result for every item, in request
+// order. Handlers are framework agnostic http.Handlers, and registering one as the handler of an
+// operation documents the array body and the 207 response unless the builder sets them.
+type BatchHandler[B, R any] struct {
+	itemSchema     goop.Schema
+	handler        BatchItemHandler[B, R]
+	resourceSchema goop.Schema
+	concurrency    int
+	maxItems       int
+	itemStatus     int
+}
+
+// Batch creates a batch handler validating each item with itemBody and processing it with itemHandler
+//
+// Example:
+//
+//	operations.NewSimple().
+//	    POST("/notifications/batch").
+//	    Handler(operations.Batch(notificationSchema, sendNotification).Concurrency(8))
+func Batch[B, R any](itemBody goop.Schema, itemHandler BatchItemHandler[B, R]) *BatchHandler[B, R] {
+	return &BatchHandler[B, R]{
+		itemSchema:  itemBody,
+		handler:     itemHandler,
+		concurrency: 1,
+		maxItems:    DefaultMaxBatchItems,
+		itemStatus:  http.StatusOK,
+	}
+}
+
+// Concurrency sets how many items are processed at once (default 1, processing items in order)
+func (b *BatchHandler[B, R]) Concurrency(workers int) *BatchHandler[B, R] {
+	if workers < 1 {
+		workers = 1
+	}
+	b.concurrency = workers
+	return b
+}
+
+// MaxItems sets the largest number of items accepted in one request (default DefaultMaxBatchItems)
+func (b *BatchHandler[B, R]) MaxItems(count int) *BatchHandler[B, R] {
+	b.maxItems = count
+	return b
+}
+
+// ItemStatus sets the status reported for items that succeed, such as 201 for creations (default 200)
+func (b *BatchHandler[B, R]) ItemStatus(code int) *BatchHandler[B, R] {
+	b.itemStatus = code
+	return b
+}
+
+// Resource documents the schema of the resources returned for items that succeed
+func (b *BatchHandler[B, R]) Resource(schema goop.Schema) *BatchHandler[B, R] {
+	b.resourceSchema = schema
+	return b
+}
+
+// ServeHTTP validates the array payload and responds with the result of every item
+func (b *BatchHandler[B, R]) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var items []json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&items); err != nil {
+		writeBadRequest(w, r, "Invalid request body", "expected a JSON array of items: "+err.Error())
+		return
+	}
+	if len(items) == 0 {
+		writeBadRequest(w, r, "Request body validation failed", "batch must contain at least one item")
+		return
+	}
+	if len(items) > b.maxItems {
+		writeBadRequest(w, r, "Request body validation failed", fmt.Sprintf("batch must contain at most %d items", b.maxItems))
+		return
+	}
+
+	response := BatchResponse{Results: make([]BatchResult, len(items))}
+	var wg sync.WaitGroup
+	workers := make(chan struct{}, b.concurrency)
+	for i, item := range items {
+		wg.Add(1)
+		workers <- struct{}{}
+		go func(i int, item json.RawMessage) {
+			defer wg.Done()
+			defer func() { <-workers }()
+			response.Results[i] = b.process(r.Context(), i, item)
+		}(i, item)
+	}
+	wg.Wait()
+
+	for _, result := range response.Results {
+		if result.Status < 300 {
+			response.Succeeded++
+		} else {
+			response.Failed++
+		}
+	}
+
+	writeJSON(w, http.StatusMultiStatus, response)
+}
+
+// process validates and handles one item
+func (b *BatchHandler[B, R]) process(ctx context.Context, index int, raw json.RawMessage) BatchResult {
+	value, err := goop.DecodeJSON(raw)
+	if err == nil {
+		err = goop.ValidateCtx(ctx, b.itemSchema, value)
+	}
+	if err != nil {
+		return BatchResult{Index: index, Status: http.StatusBadRequest, Error: err.Error()}
+	}
+
+	var item B
+	if err := json.Unmarshal(raw, &item); err != nil {
+		return BatchResult{Index: index, Status: http.StatusBadRequest, Error: err.Error()}
+	}
+
+	resource, err := b.handler(ctx, item)
+	var validationErr *goop.ValidationError
+	switch {
+	case err == nil:
+		return BatchResult{Index: index, Status: b.itemStatus, Resource: resource}
+	case errors.As(err, &validationErr):
+		return BatchResult{Index: index, Status: http.StatusBadRequest, Error: err.Error()}
+	case errors.Is(err, context.DeadlineExceeded):
+		return BatchResult{Index: index, Status: http.StatusGatewayTimeout, Error: err.Error()}
+	default:
+		return BatchResult{Index: index, Status: http.StatusInternalServerError, Error: err.Error()}
+	}
+}
+
+// bodySchema documents the array payload of the batch
+func (b *BatchHandler[B, R]) bodySchema() goop.Schema {
+	return validators.Array(b.itemSchema).MinItems(1).MaxItems(b.maxItems).Required()
+}
+
+// resultSchema documents the 207 response body of the batch
+func (b *BatchHandler[B, R]) resultSchema() goop.Schema {
+	result := map[string]interface{}{
+		"index":  validators.Number().Integer().Min(0).Required(),
+		"status": validators.Number().Integer().Example(b.itemStatus).Required(),
+		"error":  validators.String().Optional(),
+	}
+	if b.resourceSchema != nil {
+		result["resource"] = b.resourceSchema
+	}
+	return validators.Object(map[string]interface{}{
+		"results":   validators.Array(validators.Object(result).Required()).Required(),
+		"succeeded": validators.Number().Integer().Min(0).Required(),
+		"failed":    validators.Number().Integer().Min(0).Required(),
+	}).Required()
+}
+
+// batchDocumenter is implemented by batch handlers so the builder can document them
+type batchDocumenter interface {
+	bodySchema() goop.Schema
+	resultSchema() goop.Schema
+}
+
+// writeBadRequest rejects a request with 400, including the request ID when one was assigned
+func writeBadRequest(w http.ResponseWriter, r *http.Request, message, details string) {
+	body := map[string]string{"error": message, "details": details}
+	if id := goop.RequestIDFromContext(r.Context()); id != "" {
+		body["request_id"] = id
+	}
+	writeJSON(w, http.StatusBadRequest, body)
+}
@@ -0,0 +1,139 @@
+package operations
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	goop "github.com/picogrid/go-op"
+	"github.com/picogrid/go-op/validators"
+)
+
+// TestBatch tests per-item validation, handling, and documentation of batch operations
+func TestBatch(t *testing.T) {
+	type Notification struct {
+		To      string `json:"to"`
+		Message string `json:"message"`
+	}
+	type Sent struct {
+		ID string `json:"id"`
+	}
+	itemSchema := validators.Object(map[string]interface{}{
+		"to":      validators.String().Email().Required(),
+		"message": validators.String().Min(1).Required(),
+	}).Required()
+	send := func(ctx context.Context, n Notification) (Sent, error) {
+		switch n.Message {
+		case "blocked":
+			return Sent{}, goop.NewValidationError("to", n.To, "recipient has unsubscribed")
+		case "fail":
+			return Sent{}, errors.New("provider unavailable")
+		}
+		return Sent{ID: "msg_" + n.To}, nil
+	}
+
+	serve := func(t *testing.T, op CompiledOperation, body string) (int, BatchResponse) {
+		t.Helper()
+		w := httptest.NewRecorder()
+		op.Handler.(http.Handler).ServeHTTP(w, httptest.NewRequest(op.Method, op.Path, strings.NewReader(body)))
+
+		var response BatchResponse
+		if w.Code == http.StatusMultiStatus {
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to decode batch response: %v", err)
+			}
+			if err := op.Responses[w.Code].Schema.Validate(toMap(t, response)); err != nil {
+				t.Errorf("Expected response to match the documented schema, got: %v", err)
+			}
+		}
+		return w.Code, response
+	}
+
+	op := NewSimple().
+		POST("/notifications/batch").
+		Handler(Batch(itemSchema, send).ItemStatus(http.StatusCreated).Resource(validators.Object(map[string]interface{}{
+			"id": validators.String().Required(),
+		}).Optional()))
+
+	t.Run("Reports the result of every item", func(t *testing.T) {
+		code, response := serve(t, op, `[
+			{"to": "a@example.com", "message": "hi"},
+			{"to": "not-an-email", "message": "hi"},
+			{"to": "b@example.com", "message": "blocked"},
+			{"to": "c@example.com", "message": "fail"}
+		]`)
+		if code != http.StatusMultiStatus {
+			t.Fatalf("Expected 207, got %d", code)
+		}
+		statuses := []int{http.StatusCreated, http.StatusBadRequest, http.StatusBadRequest, http.StatusInternalServerError}
+		for i, result := range response.Results {
+			if result.Index != i || result.Status != statuses[i] {
+				t.Errorf("Expected item %d to have status %d, got %+v", i, statuses[i], result)
+			}
+		}
+		if response.Results[0].Resource.(map[string]interface{})["id"] != "msg_a@example.com" {
+			t.Errorf("Expected the created resource, got %v", response.Results[0].Resource)
+		}
+		if response.Results[3].Error != "provider unavailable" {
+			t.Errorf("Expected the handler error, got %q", response.Results[3].Error)
+		}
+		if response.Succeeded != 1 || response.Failed != 3 {
+			t.Errorf("Expected 1 succeeded and 3 failed, got %d and %d", response.Succeeded, response.Failed)
+		}
+	})
+
+	t.Run("Rejects payloads that are not a batch", func(t *testing.T) {
+		small := NewSimple().POST("/batch").Handler(Batch(itemSchema, send).MaxItems(1))
+		for _, body := range []string{`{"to": "a@example.com"}`, `[]`, `[{}, {}]`} {
+			if code, _ := serve(t, small, body); code != http.StatusBadRequest {
+				t.Errorf("Expected 400 for %s, got %d", body, code)
+			}
+		}
+	})
+
+	t.Run("Processes items concurrently", func(t *testing.T) {
+		var running, peak int32
+		slow := func(ctx context.Context, n Notification) (Sent, error) {
+			current := atomic.AddInt32(&running, 1)
+			defer atomic.AddInt32(&running, -1)
+			for {
+				seen := atomic.LoadInt32(&peak)
+				if current <= seen || atomic.CompareAndSwapInt32(&peak, seen, current) {
+					break
+				}
+			}
+			time.Sleep(20 * time.Millisecond)
+			return Sent{ID: n.To}, nil
+		}
+		concurrent := NewSimple().POST("/batch").Handler(Batch(itemSchema, slow).Concurrency(2))
+		item := `{"to": "a@example.com", "message": "hi"}`
+		code, response := serve(t, concurrent, "["+strings.Repeat(item+",", 3)+item+"]")
+		if code != http.StatusMultiStatus || response.Succeeded != 4 {
+			t.Fatalf("Expected 4 succeeded items, got %d %+v", code, response)
+		}
+		if peak != 2 {
+			t.Errorf("Expected 2 items in flight at most, got %d", peak)
+		}
+	})
+
+	t.Run("Documents the array body and 207 response", func(t *testing.T) {
+		if op.SuccessCode != http.StatusMultiStatus {
+			t.Errorf("Expected success code 207, got %d", op.SuccessCode)
+		}
+		if op.BodySpec == nil || op.BodySpec.Type != "array" || op.BodySpec.Items == nil {
+			t.Fatalf("Expected an array body spec, got %+v", op.BodySpec)
+		}
+		if op.BodySpec.MaxItems == nil || *op.BodySpec.MaxItems != DefaultMaxBatchItems {
+			t.Errorf("Expected at most %d items, got %v", DefaultMaxBatchItems, op.BodySpec.MaxItems)
+		}
+		if _, exists := op.Responses[http.StatusMultiStatus]; !exists {
+			t.Error("Expected a documented 207 response")
+		}
+	})
+}
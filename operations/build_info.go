@@ -0,0 +1,90 @@
+package operations
+
+import (
+	"net/http"
+	"time"
+
+	goop "github.com/picogrid/go-op"
+	"github.com/picogrid/go-op/validators"
+)
+
+// DefaultMetaPath is the path of the operation serving build info
+const DefaultMetaPath = "/meta"
+
+// BuildInfoExtension is the info extension identifying the build that produced a spec
+const BuildInfoExtension = goop.BuildInfoExtension
+
+// BuildInfo identifies the build of a service
+type BuildInfo = goop.BuildInfo
+
+// SetBuildInfo records the build producing the spec as the info.x-build extension, so consumers can
+// confirm which build the spec they are reading came from. Values are typically injected at build
+// time with -ldflags "-X main.commit=...".
+func (g *OpenAPIGenerator) SetBuildInfo(version, commit string, buildTime time.Time) {
+	g.specMu.Lock()
+	defer g.specMu.Unlock()
+
+	g.buildInfo = &BuildInfo{Version: version, Commit: commit, BuildTime: buildTime}
+
+	details := map[string]interface{}{"version": version}
+	if commit != "" {
+		details["commit"] = commit
+	}
+	if !buildTime.IsZero() {
+		details["buildTime"] = buildTime.UTC().Format(time.RFC3339)
+	}
+	g.Spec.Info.SetExtension(BuildInfoExtension, details)
+	g.meta = nil
+	g.InvalidateSpecCache()
+}
+
+// ExposeBuildInfo serves the build info set with SetBuildInfo from a public GET operation at path
+// (default "/meta"). Routers using the generator register the operation on their own.
+func (g *OpenAPIGenerator) ExposeBuildInfo(path string) {
+	if path == "" {
+		path = DefaultMetaPath
+	}
+	g.metaPath = path
+	g.meta = nil
+}
+
+// MetaOperation returns the operation serving build info, or nil unless ExposeBuildInfo was called
+// It implements goop.MetaOperationProvider.
+func (g *OpenAPIGenerator) MetaOperation() *CompiledOperation {
+	if g.metaPath == "" {
+		return nil
+	}
+	if g.meta == nil {
+		info := BuildInfo{}
+		if g.buildInfo != nil {
+			info = *g.buildInfo
+		}
+		op := buildInfoOperation(g.metaPath, info)
+		g.meta = &op
+	}
+	return g.meta
+}
+
+// buildInfoOperation creates the public operation serving info at path
+func buildInfoOperation(path string, info BuildInfo) CompiledOperation {
+	schema := validators.Object(map[string]interface{}{
+		"version":   validators.String().Example("1.4.2").Required(),
+		"commit":    validators.String().Example("9f749a3").Optional(),
+		"buildTime": validators.String().Format("date-time").Optional(),
+	}).Required()
+
+	return NewSimple().
+		GET(path).
+		OperationID("getBuildInfo").
+		Summary("Build info").
+		Description("Reports the version, commit, and build time of the running service").
+		Tags("meta").
+		NoAuth().
+		WithoutTenant().
+		Idempotency(goop.Idempotent).
+		WithoutDefaultResponses(http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden).
+		WithSuccessResponse(http.StatusOK, schema, "Build of the running service").
+		Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, http.StatusOK, info)
+		}))
+}
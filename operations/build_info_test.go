@@ -0,0 +1,76 @@
+package operations
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestBuildInfo tests documenting build info in the spec and serving it from the meta operation
+func TestBuildInfo(t *testing.T) {
+	builtAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	generator := NewOpenAPIGenerator("Test API", "1.0.0")
+	generator.SetBuildInfo("1.4.2", "9f749a3", builtAt)
+
+	t.Run("Documents the build in info", func(t *testing.T) {
+		data, err := json.Marshal(generator.Spec)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		var spec OpenAPISpec
+		if err := json.Unmarshal(data, &spec); err != nil {
+			t.Fatalf("Expected valid JSON, got: %v", err)
+		}
+		build, ok := spec.Info.Extensions[BuildInfoExtension].(map[string]interface{})
+		if !ok {
+			t.Fatalf("Expected %s extension, got %v", BuildInfoExtension, spec.Info.Extensions)
+		}
+		if build["version"] != "1.4.2" || build["commit"] != "9f749a3" || build["buildTime"] != "2026-03-01T12:00:00Z" {
+			t.Errorf("Unexpected build info: %v", build)
+		}
+		if spec.Info.Title != "Test API" {
+			t.Errorf("Expected the info fields to be kept, got %+v", spec.Info)
+		}
+	})
+
+	t.Run("Not exposed by default", func(t *testing.T) {
+		if op := generator.MetaOperation(); op != nil {
+			t.Errorf("Expected no meta operation, got %s %s", op.Method, op.Path)
+		}
+	})
+
+	t.Run("Registers the meta operation once", func(t *testing.T) {
+		generator.ExposeBuildInfo("")
+		router := NewRouter(generator)
+		for _, path := range []string{"/orders", "/customers"} {
+			if err := router.Register(NewSimple().GET(path).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+		}
+
+		var meta []CompiledOperation
+		for _, op := range router.GetOperations() {
+			if op.Path == DefaultMetaPath {
+				meta = append(meta, op)
+			}
+		}
+		if len(meta) != 1 {
+			t.Fatalf("Expected one meta operation, got %d", len(meta))
+		}
+		if _, documented := generator.Spec.Paths[DefaultMetaPath]["get"]; !documented {
+			t.Error("Expected the meta operation to be documented")
+		}
+
+		w := httptest.NewRecorder()
+		meta[0].Handler.(http.Handler).ServeHTTP(w, httptest.NewRequest(http.MethodGet, DefaultMetaPath, nil))
+		var body BuildInfo
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("Failed to decode body: %v", err)
+		}
+		if w.Code != http.StatusOK || body.Version != "1.4.2" || body.Commit != "9f749a3" || !body.BuildTime.Equal(builtAt) {
+			t.Errorf("Unexpected build info: %d %+v", w.Code, body)
+		}
+	})
+}
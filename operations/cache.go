@@ -0,0 +1,35 @@
+package operations
+
+import (
+	"strings"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// documentCaching adds the cache extension and the Cache-Control and Expires headers to the
+// successful responses of an operation
+func documentCaching(operation *OpenAPIOperation, policy goop.CachePolicy) {
+	operation.SetExtension(CacheExtension, map[string]interface{}{
+		"maxAgeSeconds": int64(policy.MaxAge.Seconds()),
+		"public":        policy.Public,
+	})
+
+	for code, response := range operation.Responses {
+		if !strings.HasPrefix(code, "2") {
+			continue
+		}
+		if response.Headers == nil {
+			response.Headers = make(map[string]OpenAPIHeader)
+		}
+		response.Headers[goop.CacheControlHeader] = OpenAPIHeader{
+			Description: "How long the response may be cached, and by whom",
+			Schema:      &goop.OpenAPISchema{Type: "string"},
+			Example:     policy.CacheControl(),
+		}
+		response.Headers[goop.ExpiresHeader] = OpenAPIHeader{
+			Description: "Date after which the response is stale, as an HTTP-date",
+			Schema:      &goop.OpenAPISchema{Type: "string"},
+		}
+		operation.Responses[code] = response
+	}
+}
@@ -0,0 +1,99 @@
+package operations
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	goop "github.com/picogrid/go-op"
+	"github.com/picogrid/go-op/validators"
+)
+
+// LoadComponentsDir registers every YAML or JSON schema file in fsys as a component schema
+// Each file becomes components/schemas/<name>, where <name> is the file name without its extension
+// Schemas that can be expressed with the validators package are also available via ComponentValidator
+func (g *OpenAPIGenerator) LoadComponentsDir(fsys fs.FS) error {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return fmt.Errorf("failed to read schema directory: %w", err)
+	}
+
+	// fs.ReadDir returns entries sorted by filename, so loading order is deterministic
+	var loaded []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		ext := strings.ToLower(path.Ext(entry.Name()))
+		if ext != ".yaml" && ext != ".yml" && ext != ".json" {
+			continue
+		}
+
+		name := strings.TrimSuffix(entry.Name(), path.Ext(entry.Name()))
+		if err := ValidateComponentKey(name); err != nil {
+			return fmt.Errorf("invalid schema file %s: %w", entry.Name(), err)
+		}
+		if _, exists := g.Spec.Components.Schemas[name]; exists {
+			return fmt.Errorf("component schema '%s' is already registered", name)
+		}
+
+		data, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return fmt.Errorf("failed to read schema file %s: %w", entry.Name(), err)
+		}
+
+		schema, err := parseComponentSchema(data, ext)
+		if err != nil {
+			return fmt.Errorf("failed to parse schema file %s: %w", entry.Name(), err)
+		}
+
+		g.Spec.Components.Schemas[name] = schema
+		loaded = append(loaded, name)
+	}
+
+	// Convert once every file is registered, so schemas can reference each other
+	for _, name := range loaded {
+		if validator, err := validators.FromOpenAPISchema(g.Spec.Components.Schemas[name], g.Spec.Components.Schemas); err == nil {
+			if g.componentValidators == nil {
+				g.componentValidators = make(map[string]goop.Schema)
+			}
+			g.componentValidators[name] = validator
+		}
+	}
+
+	return nil
+}
+
+// ComponentValidator returns the runtime validator for a component schema loaded from disk
+// The boolean is false when the schema was not loaded or could not be converted
+func (g *OpenAPIGenerator) ComponentValidator(name string) (goop.Schema, bool) {
+	validator, ok := g.componentValidators[name]
+	return validator, ok
+}
+
+// parseComponentSchema decodes a schema file into an OpenAPISchema
+// YAML is normalized through JSON so both formats share the same decoding rules
+func parseComponentSchema(data []byte, ext string) (*goop.OpenAPISchema, error) {
+	if ext != ".json" {
+		var raw interface{}
+		if err := yaml.Unmarshal(data, &raw); err != nil {
+			return nil, err
+		}
+		converted, err := json.Marshal(raw)
+		if err != nil {
+			return nil, err
+		}
+		data = converted
+	}
+
+	var schema goop.OpenAPISchema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, err
+	}
+	return &schema, nil
+}
@@ -0,0 +1,133 @@
+package operations
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoadComponentsDir(t *testing.T) {
+	schemas := fstest.MapFS{
+		"Address.yaml": &fstest.MapFile{Data: []byte(`
+type: object
+required: [street, country]
+properties:
+  street:
+    type: string
+    minLength: 1
+  country:
+    type: string
+    enum: [US, CA]
+  unit:
+    type: integer
+    minimum: 1
+`)},
+		"Tags.json": &fstest.MapFile{Data: []byte(`{"type":"array","items":{"type":"string"},"maxItems":2}`)},
+		"Pet.yml": &fstest.MapFile{Data: []byte(`
+oneOf:
+  - type: string
+  - type: number
+`)},
+		"README.md": &fstest.MapFile{Data: []byte("not a schema")},
+	}
+
+	t.Run("Registers component schemas", func(t *testing.T) {
+		gen := NewOpenAPIGenerator("Test API", "1.0.0")
+		if err := gen.LoadComponentsDir(schemas); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		for _, name := range []string{"Address", "Tags", "Pet"} {
+			if _, ok := gen.Spec.Components.Schemas[name]; !ok {
+				t.Errorf("Expected component schema '%s' to be registered", name)
+			}
+		}
+		if _, ok := gen.Spec.Components.Schemas["README"]; ok {
+			t.Error("Expected non-schema files to be ignored")
+		}
+
+		address := gen.Spec.Components.Schemas["Address"]
+		if address.Type != "object" || len(address.Properties) != 3 {
+			t.Errorf("Expected object schema with 3 properties, got %+v", address)
+		}
+	})
+
+	t.Run("Converts schemas to validators", func(t *testing.T) {
+		gen := NewOpenAPIGenerator("Test API", "1.0.0")
+		if err := gen.LoadComponentsDir(schemas); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		address, ok := gen.ComponentValidator("Address")
+		if !ok {
+			t.Fatal("Expected Address validator")
+		}
+		if err := address.Validate(map[string]interface{}{"street": "Main St", "country": "US"}); err != nil {
+			t.Errorf("Expected valid address, got %v", err)
+		}
+		if err := address.Validate(map[string]interface{}{"street": "Main St", "country": "MX"}); err == nil {
+			t.Error("Expected error for country outside enum")
+		}
+		if err := address.Validate(map[string]interface{}{"country": "US"}); err == nil {
+			t.Error("Expected error for missing required street")
+		}
+
+		tags, ok := gen.ComponentValidator("Tags")
+		if !ok {
+			t.Fatal("Expected Tags validator")
+		}
+		if err := tags.Validate([]interface{}{"a", "b", "c"}); err == nil {
+			t.Error("Expected error for too many tags")
+		}
+
+		pet, ok := gen.ComponentValidator("Pet")
+		if !ok {
+			t.Fatal("Expected Pet validator for the composition schema")
+		}
+		if err := pet.Validate("rex"); err != nil {
+			t.Errorf("Expected string pet to be valid, got %v", err)
+		}
+		if err := pet.Validate(true); err == nil {
+			t.Error("Expected error for a value matching no branch")
+		}
+	})
+
+	t.Run("Resolves references and number enums", func(t *testing.T) {
+		gen := NewOpenAPIGenerator("Test API", "1.0.0")
+		err := gen.LoadComponentsDir(fstest.MapFS{
+			"Order.json":    &fstest.MapFile{Data: []byte(`{"type":"object","required":["priority"],"properties":{"priority":{"$ref":"#/components/schemas/Priority"}}}`)},
+			"Priority.json": &fstest.MapFile{Data: []byte(`{"type":"integer","enum":[1,2,3]}`)},
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		order, ok := gen.ComponentValidator("Order")
+		if !ok {
+			t.Fatal("Expected Order validator referencing a component loaded after it")
+		}
+		if err := order.Validate(map[string]interface{}{"priority": 2}); err != nil {
+			t.Errorf("Expected valid priority, got %v", err)
+		}
+		if err := order.Validate(map[string]interface{}{"priority": 4}); err == nil {
+			t.Error("Expected error for priority outside the number enum")
+		}
+	})
+
+	t.Run("Rejects duplicate component names", func(t *testing.T) {
+		gen := NewOpenAPIGenerator("Test API", "1.0.0")
+		if err := gen.LoadComponentsDir(schemas); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if err := gen.LoadComponentsDir(schemas); err == nil {
+			t.Error("Expected error when loading duplicate component schemas")
+		}
+	})
+
+	t.Run("Rejects invalid schema files", func(t *testing.T) {
+		gen := NewOpenAPIGenerator("Test API", "1.0.0")
+		invalid := fstest.MapFS{"Broken.json": &fstest.MapFile{Data: []byte(`{"type":`)}}
+		if err := gen.LoadComponentsDir(invalid); err == nil {
+			t.Error("Expected error for malformed schema file")
+		}
+	})
+}
@@ -0,0 +1,184 @@
+package operations
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// JSON pointer prefixes for reusable parameters and responses
+const (
+	componentParameterPrefix = "#/components/parameters/"
+	componentResponsePrefix  = "#/components/responses/"
+)
+
+// ParameterDefinition describes a reusable parameter registered with OpenAPIGenerator.AddParameter
+type ParameterDefinition struct {
+	Name        string
+	In          string // "path", "query", "header", or "cookie"
+	Description string
+	Required    bool
+	Schema      goop.Schema
+}
+
+// ParameterRef returns the $ref pointer for a reusable parameter name
+func ParameterRef(name string) string {
+	return componentParameterPrefix + name
+}
+
+// ResponseRef returns the $ref pointer for a reusable response name
+func ResponseRef(name string) string {
+	return componentResponsePrefix + name
+}
+
+// AddParameter registers a reusable parameter under components/parameters, such as a shared PageParam
+// Parameters of operations processed afterwards with the same name and location are documented as
+// a $ref to it. Operations still validate the value with their own schemas, so the component should
+// describe the same constraints.
+func (g *OpenAPIGenerator) AddParameter(name string, param ParameterDefinition) {
+	g.specMu.Lock()
+	defer g.specMu.Unlock()
+
+	documented := OpenAPIParameter{
+		Name:        param.Name,
+		In:          param.In,
+		Description: param.Description,
+		Required:    param.Required || param.In == "path",
+	}
+	if enhanced, ok := param.Schema.(goop.EnhancedSchema); ok {
+		documented.Schema = enhanced.ToOpenAPISchema()
+		documented.Example = documented.Schema.Example
+		g.resolveComponentRefs(documented.Schema)
+	}
+
+	if g.Spec.Components.Parameters == nil {
+		g.Spec.Components.Parameters = make(map[string]OpenAPIParameter)
+	}
+	g.Spec.Components.Parameters[name] = documented
+	g.InvalidateSpecCache()
+}
+
+// AddResponse registers a reusable response under components/responses, such as a shared StandardError
+// Operations reference it with WithResponseRef, and default responses with a Ref set refer to it.
+func (g *OpenAPIGenerator) AddResponse(name string, response ResponseDefinition) {
+	g.specMu.Lock()
+	defer g.specMu.Unlock()
+
+	documented := schemaResponse(response.Schema, response.Description, response.ContentType)
+	for _, mediaType := range documented.Content {
+		g.resolveComponentRefs(mediaType.Schema)
+	}
+
+	if g.Spec.Components.Responses == nil {
+		g.Spec.Components.Responses = make(map[string]OpenAPIResponse)
+	}
+	g.Spec.Components.Responses[name] = documented
+	g.InvalidateSpecCache()
+}
+
+// referenceComponents documents the parameters and responses of operation that have reusable
+// components as references to them. Headers added to referenced responses are dropped, since a
+// reference cannot extend the component it points to.
+func (g *OpenAPIGenerator) referenceComponents(operation *OpenAPIOperation) {
+	names := make([]string, 0, len(g.Spec.Components.Parameters))
+	for name, param := range g.Spec.Components.Parameters {
+		if param.Ref == "" {
+			names = append(names, name)
+		}
+	}
+	// Sorted so the same component is chosen every time if several match
+	sort.Strings(names)
+
+	for i, param := range operation.Parameters {
+		if param.Ref != "" {
+			continue
+		}
+		for _, name := range names {
+			component := g.Spec.Components.Parameters[name]
+			if component.Name == param.Name && component.In == param.In {
+				operation.Parameters[i] = OpenAPIParameter{Ref: ParameterRef(name)}
+				break
+			}
+		}
+	}
+
+	for code, response := range operation.Responses {
+		if response.Ref != "" {
+			operation.Responses[code] = OpenAPIResponse{Ref: response.Ref}
+		}
+	}
+}
+
+// Dereference returns op with the parameters and responses that reference components replaced by
+// their definitions, for tools that read operations on their own. References to components missing
+// from c are kept, and op itself is left unchanged.
+func (c *OpenAPIComponents) Dereference(op OpenAPIOperation) OpenAPIOperation {
+	if c == nil {
+		return op
+	}
+
+	parameters := make([]OpenAPIParameter, len(op.Parameters))
+	for i, param := range op.Parameters {
+		if name, ok := strings.CutPrefix(param.Ref, componentParameterPrefix); ok {
+			if component, exists := c.Parameters[name]; exists {
+				param = component
+			}
+		}
+		parameters[i] = param
+	}
+	if op.Parameters != nil {
+		op.Parameters = parameters
+	}
+
+	responses := make(map[string]OpenAPIResponse, len(op.Responses))
+	for code, response := range op.Responses {
+		if name, ok := strings.CutPrefix(response.Ref, componentResponsePrefix); ok {
+			if component, exists := c.Responses[name]; exists {
+				response = component
+			}
+		}
+		responses[code] = response
+	}
+	if op.Responses != nil {
+		op.Responses = responses
+	}
+	return op
+}
+
+// MarshalJSON emits referencing parameters as a bare $ref
+func (p OpenAPIParameter) MarshalJSON() ([]byte, error) {
+	if p.Ref != "" {
+		return json.Marshal(map[string]string{"$ref": p.Ref})
+	}
+	type parameterAlias OpenAPIParameter
+	return json.Marshal(parameterAlias(p))
+}
+
+// MarshalYAML emits referencing parameters as a bare $ref
+func (p OpenAPIParameter) MarshalYAML() (interface{}, error) {
+	if p.Ref != "" {
+		return map[string]string{"$ref": p.Ref}, nil
+	}
+	type parameterAlias OpenAPIParameter
+	return parameterAlias(p), nil
+}
+
+// MarshalJSON emits referencing responses as a bare $ref
+func (r OpenAPIResponse) MarshalJSON() ([]byte, error) {
+	if r.Ref != "" {
+		return json.Marshal(map[string]string{"$ref": r.Ref})
+	}
+	type responseAlias OpenAPIResponse
+	return json.Marshal(responseAlias(r))
+}
+
+// MarshalYAML emits referencing responses as a bare $ref
+func (r OpenAPIResponse) MarshalYAML() (interface{}, error) {
+	if r.Ref != "" {
+		return map[string]string{"$ref": r.Ref}, nil
+	}
+	type responseAlias OpenAPIResponse
+	return responseAlias(r), nil
+}
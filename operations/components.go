@@ -0,0 +1,197 @@
+package operations
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// componentSchemaPrefix is the JSON pointer prefix for shared component schemas
+const componentSchemaPrefix = "#/components/schemas/"
+
+// defaultComponents holds components registered with Component
+var defaultComponents = NewComponentRegistry()
+
+// ComponentRegistry is a namespace of shared component schemas
+// Generators resolve $refs in their own registry first, so APIs served from one process can
+// define components with the same name, such as a public and an admin User, without conflicts.
+type ComponentRegistry struct {
+	mu      sync.RWMutex
+	schemas map[string]goop.Schema
+}
+
+// NewComponentRegistry creates an empty component namespace
+// Pass it to OpenAPIGenerator.SetComponentRegistry for the API whose components it holds.
+func NewComponentRegistry() *ComponentRegistry {
+	return &ComponentRegistry{schemas: make(map[string]goop.Schema)}
+}
+
+// Component registers schema as a shared component in the registry and returns a schema that references it
+func (r *ComponentRegistry) Component(name string, schema goop.Schema) goop.Schema {
+	r.mu.Lock()
+	r.schemas[name] = schema
+	r.mu.Unlock()
+
+	return &componentRef{name: name, schema: schema, registry: r}
+}
+
+// Lookup returns the component registered under name
+func (r *ComponentRegistry) Lookup(name string) (goop.Schema, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	schema, ok := r.schemas[name]
+	return schema, ok
+}
+
+// resolve looks name up in the registry, then in the process-wide components
+func (r *ComponentRegistry) resolve(name string) (goop.Schema, bool) {
+	if schema, ok := r.Lookup(name); ok {
+		return schema, true
+	}
+	return lookupComponent(name)
+}
+
+// Component registers schema as a shared component and returns a schema that references it
+// The returned schema validates exactly like the original, but is documented as a $ref;
+// OpenAPI generators add the full component definition the first time it is referenced.
+// Components are registered in a process-wide namespace; use a ComponentRegistry to keep
+// the components of separate APIs apart.
+func Component(name string, schema goop.Schema) goop.Schema {
+	return defaultComponents.Component(name, schema)
+}
+
+// ComponentRef returns the $ref pointer for a component schema name
+func ComponentRef(name string) string {
+	return componentSchemaPrefix + name
+}
+
+// ComponentName returns the component schema name a $ref pointer refers to, the inverse of ComponentRef
+// It reports false for references outside the spec's component schemas.
+func ComponentName(ref string) (string, bool) {
+	return strings.CutPrefix(ref, componentSchemaPrefix)
+}
+
+// componentRef is a schema documented as a reference to a shared component
+type componentRef struct {
+	name     string
+	schema   goop.Schema
+	registry *ComponentRegistry
+}
+
+func (c *componentRef) Validate(data interface{}) error {
+	return c.schema.Validate(data)
+}
+
+func (c *componentRef) ValidateCtx(ctx context.Context, data interface{}) error {
+	return goop.ValidateCtx(ctx, c.schema, data)
+}
+
+func (c *componentRef) ToOpenAPISchema() *goop.OpenAPISchema {
+	return &goop.OpenAPISchema{Ref: ComponentRef(c.name)}
+}
+
+func (c *componentRef) GetValidationInfo() *goop.ValidationInfo {
+	if enhanced, ok := c.schema.(goop.EnhancedSchema); ok {
+		return enhanced.GetValidationInfo()
+	}
+	return &goop.ValidationInfo{Required: true}
+}
+
+// ToJSONSchema exports the component as a standalone JSON Schema document with its
+// definition and any components it references embedded under $defs
+func (c *componentRef) ToJSONSchema() ([]byte, error) {
+	return goop.ToJSONSchema(c, goop.WithComponentResolver(c.registry.resolve))
+}
+
+// ToJSONSchema exports schema as a self-contained JSON Schema draft 2020-12 document,
+// resolving references to schemas registered with Component
+func ToJSONSchema(schema goop.Schema, opts ...goop.JSONSchemaOption) ([]byte, error) {
+	return goop.ToJSONSchema(schema, append([]goop.JSONSchemaOption{goop.WithComponentResolver(lookupComponent)}, opts...)...)
+}
+
+// lookupComponent returns the shared component registered under name with Component
+func lookupComponent(name string) (goop.Schema, bool) {
+	return defaultComponents.Lookup(name)
+}
+
+// SetComponentRegistry resolves component references in registry before the process-wide components
+// Give each API served from one process its own registry so component definitions do not leak between specs.
+func (g *OpenAPIGenerator) SetComponentRegistry(registry *ComponentRegistry) {
+	g.components = registry
+}
+
+// lookupComponent returns the component the generator documents for name
+func (g *OpenAPIGenerator) lookupComponent(name string) (goop.Schema, bool) {
+	if g.components != nil {
+		return g.components.resolve(name)
+	}
+	return lookupComponent(name)
+}
+
+// resolveComponentRefs registers every shared component referenced from schema in the spec
+func (g *OpenAPIGenerator) resolveComponentRefs(schema *goop.OpenAPISchema) {
+	resolveComponentSchemas(g.Spec.Components.Schemas, schema, g.lookupComponent)
+}
+
+// resolveComponentSchemas adds the definition of every shared component referenced from schema to schemas
+// Component names are resolved with lookup.
+func resolveComponentSchemas(schemas map[string]*goop.OpenAPISchema, schema *goop.OpenAPISchema, lookup func(name string) (goop.Schema, bool)) {
+	if schema == nil {
+		return
+	}
+
+	if strings.HasPrefix(schema.Ref, componentSchemaPrefix) {
+		name := strings.TrimPrefix(schema.Ref, componentSchemaPrefix)
+		if _, exists := schemas[name]; !exists {
+			if component, ok := lookup(name); ok {
+				if enhanced, ok := component.(goop.EnhancedSchema); ok {
+					definition := enhanced.ToOpenAPISchema()
+					schemas[name] = definition
+					resolveComponentSchemas(schemas, definition, lookup)
+				}
+			}
+		}
+	}
+
+	for _, property := range schema.Properties {
+		resolveComponentSchemas(schemas, property, lookup)
+	}
+	resolveComponentSchemas(schemas, schema.Items, lookup)
+	resolveComponentSchemas(schemas, schema.Contains, lookup)
+	resolveComponentSchemas(schemas, schema.Not, lookup)
+	resolveComponentSchemas(schemas, schema.If, lookup)
+	resolveComponentSchemas(schemas, schema.Then, lookup)
+	resolveComponentSchemas(schemas, schema.Else, lookup)
+	for _, composed := range [][]*goop.OpenAPISchema{schema.PrefixItems, schema.AllOf, schema.OneOf, schema.AnyOf} {
+		for _, sub := range composed {
+			resolveComponentSchemas(schemas, sub, lookup)
+		}
+	}
+	if schema.AdditionalProperties != nil {
+		resolveComponentSchemas(schemas, schema.AdditionalProperties.Schema, lookup)
+	}
+}
+
+// resolveOperationRefs registers shared components referenced anywhere in the operation
+func (g *OpenAPIGenerator) resolveOperationRefs(operation *OpenAPIOperation) {
+	resolveOperationSchemas(g.Spec.Components.Schemas, operation, g.lookupComponent)
+}
+
+// resolveOperationSchemas adds the definition of every shared component referenced anywhere in the operation to schemas
+func resolveOperationSchemas(schemas map[string]*goop.OpenAPISchema, operation *OpenAPIOperation, lookup func(name string) (goop.Schema, bool)) {
+	for _, param := range operation.Parameters {
+		resolveComponentSchemas(schemas, param.Schema, lookup)
+	}
+	if operation.RequestBody != nil {
+		for _, mediaType := range operation.RequestBody.Content {
+			resolveComponentSchemas(schemas, mediaType.Schema, lookup)
+		}
+	}
+	for _, response := range operation.Responses {
+		for _, mediaType := range response.Content {
+			resolveComponentSchemas(schemas, mediaType.Schema, lookup)
+		}
+	}
+}
@@ -0,0 +1,276 @@
+package operations
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	goop "github.com/picogrid/go-op"
+	ginadapter "github.com/picogrid/go-op/operations/adapters/gin"
+	"github.com/picogrid/go-op/validators"
+)
+
+func TestComponentJSONSchema(t *testing.T) {
+	address := Component("JSONSchemaAddress", validators.Object(map[string]interface{}{
+		"city": validators.String().Required(),
+	}).Required())
+	order := validators.Object(map[string]interface{}{
+		"shipping": address,
+	}).Required()
+
+	t.Run("Embeds referenced components", func(t *testing.T) {
+		data, err := ToJSONSchema(order)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		var doc map[string]interface{}
+		if err := json.Unmarshal(data, &doc); err != nil {
+			t.Fatalf("Expected valid JSON, got %v", err)
+		}
+
+		shipping := doc["properties"].(map[string]interface{})["shipping"].(map[string]interface{})
+		if shipping["$ref"] != "#/$defs/JSONSchemaAddress" {
+			t.Errorf("Expected $ref into $defs, got %v", shipping["$ref"])
+		}
+		defs, _ := doc["$defs"].(map[string]interface{})
+		if defs["JSONSchemaAddress"] == nil {
+			t.Errorf("Expected component definition, got %v", doc["$defs"])
+		}
+	})
+
+	t.Run("Validator export reports unresolved components", func(t *testing.T) {
+		if _, err := order.ToJSONSchema(); err == nil {
+			t.Error("Expected error without a component resolver")
+		}
+	})
+}
+
+func TestComponentRegistries(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	publicComponents := NewComponentRegistry()
+	adminComponents := NewComponentRegistry()
+	publicUser := publicComponents.Component("RegistryUser", validators.Object(map[string]interface{}{
+		"name": validators.String().Required(),
+	}).Required())
+	adminUser := adminComponents.Component("RegistryUser", validators.Object(map[string]interface{}{
+		"name":  validators.String().Required(),
+		"email": validators.String().Email().Required(),
+	}).Required())
+
+	// Two APIs with their own generators and component namespaces on one engine
+	engine := gin.New()
+	publicGen := NewOpenAPIGenerator("Public API", "1.0.0")
+	publicGen.SetComponentRegistry(publicComponents)
+	adminGen := NewOpenAPIGenerator("Admin API", "1.0.0")
+	adminGen.SetComponentRegistry(adminComponents)
+
+	handler := func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"name": "Ada", "email": "ada@example.com"}) }
+	publicRouter := ginadapter.NewGinRouter(engine, publicGen)
+	if err := publicRouter.Register(NewSimple().GET("/users/me").WithResponse(publicUser).Handler(gin.HandlerFunc(handler))); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	adminRouter := ginadapter.NewGinRouter(engine, adminGen)
+	if err := adminRouter.Group("/admin").Register(NewSimple().GET("/users/{id}").WithResponse(adminUser).Handler(gin.HandlerFunc(handler))); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	engine.GET("/openapi.json", gin.WrapH(publicGen.SpecHandler()))
+	engine.GET("/admin/openapi.json", gin.WrapH(adminGen.SpecHandler()))
+
+	fetch := func(t *testing.T, path string) OpenAPISpec {
+		t.Helper()
+		recorder := httptest.NewRecorder()
+		engine.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		var spec OpenAPISpec
+		if err := json.Unmarshal(recorder.Body.Bytes(), &spec); err != nil {
+			t.Fatalf("Expected valid JSON from %s, got: %v", path, err)
+		}
+		return spec
+	}
+
+	t.Run("Serves separate specs", func(t *testing.T) {
+		public := fetch(t, "/openapi.json")
+		admin := fetch(t, "/admin/openapi.json")
+		if _, ok := public.Paths["/admin/users/{id}"]; ok {
+			t.Error("Expected admin operations to be left out of the public spec")
+		}
+		if _, ok := admin.Paths["/users/me"]; ok {
+			t.Error("Expected public operations to be left out of the admin spec")
+		}
+		if public.Components.Schemas["RegistryUser"].Properties["email"] != nil {
+			t.Error("Expected the public User component without email")
+		}
+		if admin.Components.Schemas["RegistryUser"].Properties["email"] == nil {
+			t.Error("Expected the admin User component with email")
+		}
+	})
+
+	t.Run("Falls back to process-wide components", func(t *testing.T) {
+		generator := NewOpenAPIGenerator("Errors API", "1.0.0")
+		generator.SetComponentRegistry(NewComponentRegistry())
+		generator.resolveComponentRefs(&goop.OpenAPISchema{Ref: ComponentRef("ErrorObject")})
+		if _, ok := generator.Spec.Components.Schemas["ErrorObject"]; !ok {
+			t.Error("Expected built-in components to resolve from the process-wide namespace")
+		}
+	})
+}
+
+func TestReusableParametersAndResponses(t *testing.T) {
+	generator := NewOpenAPIGenerator("Test API", "1.0.0")
+	generator.AddParameter("PageParam", ParameterDefinition{
+		Name:        "page",
+		In:          "query",
+		Description: "Page number, starting at 1",
+		Schema:      validators.Number().Min(1).Example(1).Optional(),
+	})
+	generator.AddResponse("StandardError", ResponseDefinition{
+		Schema:      Component("ReusableError", validators.Object(map[string]interface{}{"message": validators.String().Required()}).Required()),
+		Description: "Error",
+	})
+	generator.SetDefaultResponses(map[int]ResponseDefinition{500: {Ref: "StandardError"}})
+
+	querySchema := validators.Object(map[string]interface{}{
+		"page":   validators.Number().Min(1).Optional(),
+		"status": validators.String().Optional(),
+	}).Optional()
+	op := NewSimple().
+		GET("/orders").
+		WithQuery(querySchema).
+		WithResponse(validators.Array(validators.String()).Required()).
+		WithResponseRef(http.StatusBadRequest, "StandardError").
+		Deprecated(time.Now(), time.Time{}, "").
+		Handler(gin.HandlerFunc(func(c *gin.Context) {}))
+	if err := generator.Process(OperationInfo{Method: op.Method, Path: op.Path, Operation: &op}); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	operation := generator.Spec.Paths["/orders"]["get"]
+
+	t.Run("References matching parameters", func(t *testing.T) {
+		var refs, inline int
+		for _, param := range operation.Parameters {
+			switch {
+			case param.Ref == ParameterRef("PageParam"):
+				refs++
+			case param.Name == "status":
+				inline++
+			}
+		}
+		if refs != 1 || inline != 1 {
+			t.Errorf("Expected page as a reference and status inline, got %+v", operation.Parameters)
+		}
+	})
+
+	t.Run("References responses", func(t *testing.T) {
+		for _, code := range []string{"400", "500"} {
+			response := operation.Responses[code]
+			if response.Ref != ResponseRef("StandardError") || response.Headers != nil {
+				t.Errorf("Expected response %s to be a bare reference, got %+v", code, response)
+			}
+		}
+		if generator.Spec.Components.Schemas["ReusableError"] == nil {
+			t.Error("Expected the schema of the reusable response to be registered")
+		}
+	})
+
+	t.Run("Encodes references alone", func(t *testing.T) {
+		data, err := json.Marshal(operation)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		var doc map[string]interface{}
+		if err := json.Unmarshal(data, &doc); err != nil {
+			t.Fatalf("Expected valid JSON, got: %v", err)
+		}
+		response := doc["responses"].(map[string]interface{})["400"].(map[string]interface{})
+		if len(response) != 1 || response["$ref"] != "#/components/responses/StandardError" {
+			t.Errorf("Expected only $ref, got %v", response)
+		}
+	})
+
+	t.Run("Dereferences operations", func(t *testing.T) {
+		resolved := generator.Spec.Components.Dereference(operation)
+		if resolved.Responses["400"].Description != "Error" {
+			t.Errorf("Expected the reusable response, got %+v", resolved.Responses["400"])
+		}
+		for _, param := range resolved.Parameters {
+			if param.Ref != "" {
+				t.Errorf("Expected no references, got %+v", param)
+			}
+		}
+		if operation.Responses["400"].Ref == "" {
+			t.Error("Expected the original operation to be left unchanged")
+		}
+	})
+}
+
+func TestComponentsInNestedKeywords(t *testing.T) {
+	latitude := Component("NestedLatitude", validators.Number().Min(-90).Max(90).Required())
+	longitude := Component("NestedLongitude", validators.Number().Min(-180).Max(180).Required())
+	position := validators.Tuple(latitude, longitude).Required()
+
+	generator := NewOpenAPIGenerator("Test API", "1.0.0")
+	op := NewSimple().POST("/positions").WithBody(position).Handler(func(c *gin.Context) {})
+	if err := NewRouter(generator).Register(op); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	schema := generator.Spec.Paths["/positions"]["post"].RequestBody.Content["application/json"].Schema
+	if len(schema.PrefixItems) != 2 || schema.PrefixItems[0].Ref != ComponentRef("NestedLatitude") {
+		t.Fatalf("Expected tuple positions to reference components, got %+v", schema.PrefixItems)
+	}
+	for _, name := range []string{"NestedLatitude", "NestedLongitude"} {
+		if generator.Spec.Components.Schemas[name] == nil {
+			t.Errorf("Expected %s referenced from prefixItems to be registered", name)
+		}
+	}
+
+	t.Run("Contains", func(t *testing.T) {
+		admin := Component("NestedAdmin", validators.Object(map[string]interface{}{
+			"role": validators.String().Const("admin").Required(),
+		}).Required())
+		members := validators.Array(validators.Object(map[string]interface{}{
+			"role": validators.String().Required(),
+		}).Required()).Contains(admin).Required()
+
+		generator := NewOpenAPIGenerator("Test API", "1.0.0")
+		op := NewSimple().PUT("/members").WithBody(members).Handler(func(c *gin.Context) {})
+		if err := NewRouter(generator).Register(op); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if generator.Spec.Components.Schemas["NestedAdmin"] == nil {
+			t.Error("Expected the component referenced from contains to be registered")
+		}
+	})
+
+	t.Run("Conditional subschemas", func(t *testing.T) {
+		Component("NestedCard", validators.String().Min(12).Required())
+		Component("NestedIBAN", validators.String().Min(15).Required())
+		Component("NestedMethod", validators.String().Required())
+		schema := &goop.OpenAPISchema{
+			If:   &goop.OpenAPISchema{Properties: map[string]*goop.OpenAPISchema{"method": {Ref: ComponentRef("NestedMethod")}}},
+			Then: &goop.OpenAPISchema{Properties: map[string]*goop.OpenAPISchema{"account": {Ref: ComponentRef("NestedCard")}}},
+			Else: &goop.OpenAPISchema{Properties: map[string]*goop.OpenAPISchema{"account": {Ref: ComponentRef("NestedIBAN")}}},
+		}
+
+		generator := NewOpenAPIGenerator("Test API", "1.0.0")
+		generator.resolveComponentRefs(schema)
+		for _, name := range []string{"NestedMethod", "NestedCard", "NestedIBAN"} {
+			if generator.Spec.Components.Schemas[name] == nil {
+				t.Errorf("Expected %s referenced from if/then/else to be registered", name)
+			}
+		}
+	})
+}
+
+func TestComponentName(t *testing.T) {
+	if name, ok := ComponentName(ComponentRef("Order")); !ok || name != "Order" {
+		t.Errorf("Expected Order, got %q (%v)", name, ok)
+	}
+	if _, ok := ComponentName("#/components/responses/NotFound"); ok {
+		t.Error("Expected references outside component schemas to be rejected")
+	}
+}
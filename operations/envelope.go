@@ -0,0 +1,120 @@
+package operations
+
+import (
+	goop "github.com/picogrid/go-op"
+	"github.com/picogrid/go-op/validators"
+)
+
+// Envelope wraps response data with optional metadata, errors, and validation warnings
+type Envelope[T any] struct {
+	Data     T             `json:"data"`
+	Meta     *ResponseMeta `json:"meta,omitempty"`
+	Errors   []ErrorObject `json:"errors,omitempty"`
+	Warnings []ErrorObject `json:"warnings,omitempty"`
+}
+
+// ResponseMeta carries response metadata shared by all envelopes
+type ResponseMeta struct {
+	RequestID  string `json:"request_id,omitempty"`
+	TotalCount *int   `json:"total_count,omitempty"`
+}
+
+// ErrorObject describes a single error within an envelope
+type ErrorObject struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Field   string `json:"field,omitempty"`
+}
+
+// ErrorEnvelopeBody is the response body for failed requests using envelopes
+type ErrorEnvelopeBody struct {
+	Errors []ErrorObject `json:"errors"`
+	Meta   *ResponseMeta `json:"meta,omitempty"`
+}
+
+// NewEnvelope wraps data in an envelope
+func NewEnvelope[T any](data T) Envelope[T] {
+	return Envelope[T]{Data: data}
+}
+
+// WarningCode is the code of envelope warnings created from validation warnings
+const WarningCode = "validation_warning"
+
+// SetWarnings adds validation warnings to the envelope, implementing goop.WarningReceiver so
+// validated handlers fill in the warnings of the request
+func (e *Envelope[T]) SetWarnings(warnings []goop.ValidationError) {
+	for _, warning := range warnings {
+		e.Warnings = append(e.Warnings, ErrorObject{
+			Code:    WarningCode,
+			Message: warning.Message,
+			Field:   warning.Field,
+		})
+	}
+}
+
+// NewListEnvelope wraps a list of items in an envelope with the total count in its metadata
+func NewListEnvelope[T any](items []T, totalCount int) Envelope[[]T] {
+	if items == nil {
+		items = []T{}
+	}
+	return Envelope[[]T]{
+		Data: items,
+		Meta: &ResponseMeta{TotalCount: &totalCount},
+	}
+}
+
+// NewErrorEnvelope builds an error envelope body from one or more errors
+func NewErrorEnvelope(errors ...ErrorObject) ErrorEnvelopeBody {
+	return ErrorEnvelopeBody{Errors: errors}
+}
+
+// Shared envelope component schemas, documented once under components/schemas
+var (
+	// ErrorObjectSchema documents a single envelope error
+	ErrorObjectSchema = Component("ErrorObject", validators.Object(map[string]interface{}{
+		"code": validators.String().
+			Example("invalid_field").
+			Required(),
+		"message": validators.String().
+			Example("email must be a valid email address").
+			Required(),
+		"field": validators.String().
+			Example("email").
+			Optional(),
+	}).Required())
+
+	// ResponseMetaSchema documents envelope metadata
+	ResponseMetaSchema = Component("ResponseMeta", validators.Object(map[string]interface{}{
+		"request_id": validators.String().
+			Example("req_8f14e45f").
+			Optional(),
+		"total_count": validators.Number().Integer().Min(0).
+			Example(42).
+			Optional(),
+	}).Optional())
+
+	errorEnvelopeSchema = Component("ErrorEnvelope", validators.Object(map[string]interface{}{
+		"errors": validators.Array(ErrorObjectSchema).MinItems(1).Required(),
+		"meta":   ResponseMetaSchema,
+	}).Required())
+)
+
+// EnvelopeSchema returns the schema for an Envelope wrapping data described by dataSchema
+func EnvelopeSchema(dataSchema interface{}) goop.Schema {
+	return validators.Object(map[string]interface{}{
+		"data":     dataSchema,
+		"meta":     ResponseMetaSchema,
+		"errors":   validators.Array(ErrorObjectSchema).Optional(),
+		"warnings": validators.Array(ErrorObjectSchema).Optional(),
+	}).Required()
+}
+
+// ListResponse returns the schema for an Envelope wrapping a list of items described by itemSchema
+func ListResponse(itemSchema interface{}) goop.Schema {
+	return EnvelopeSchema(validators.Array(itemSchema).Required())
+}
+
+// ErrorEnvelope returns the shared schema for error envelope bodies
+func ErrorEnvelope() goop.Schema {
+	return errorEnvelopeSchema
+}
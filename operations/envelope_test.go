@@ -0,0 +1,98 @@
+package operations
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/picogrid/go-op/validators"
+)
+
+func TestEnvelopes(t *testing.T) {
+	userSchema := validators.Object(map[string]interface{}{
+		"id":    validators.String().Required(),
+		"email": validators.String().Email().Required(),
+	}).Required()
+
+	toMap := func(t *testing.T, v interface{}) map[string]interface{} {
+		t.Helper()
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		var m map[string]interface{}
+		if err := json.Unmarshal(data, &m); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return m
+	}
+
+	type user struct {
+		ID    string `json:"id"`
+		Email string `json:"email"`
+	}
+
+	t.Run("Envelope schema validates envelopes", func(t *testing.T) {
+		schema := EnvelopeSchema(userSchema)
+		if err := schema.Validate(toMap(t, NewEnvelope(user{ID: "1", Email: "a@example.com"}))); err != nil {
+			t.Errorf("Expected valid envelope, got %v", err)
+		}
+		if err := schema.Validate(map[string]interface{}{"meta": map[string]interface{}{}}); err == nil {
+			t.Error("Expected error for missing data")
+		}
+	})
+
+	t.Run("List response validates list envelopes", func(t *testing.T) {
+		schema := ListResponse(userSchema)
+		envelope := NewListEnvelope([]user{{ID: "1", Email: "a@example.com"}}, 10)
+		if err := schema.Validate(toMap(t, envelope)); err != nil {
+			t.Errorf("Expected valid list envelope, got %v", err)
+		}
+		if *envelope.Meta.TotalCount != 10 {
+			t.Errorf("Expected total count 10, got %d", *envelope.Meta.TotalCount)
+		}
+	})
+
+	t.Run("Error envelope requires errors", func(t *testing.T) {
+		body := NewErrorEnvelope(ErrorObject{Code: "not_found", Message: "user not found"})
+		if err := ErrorEnvelope().Validate(toMap(t, body)); err != nil {
+			t.Errorf("Expected valid error envelope, got %v", err)
+		}
+		if err := ErrorEnvelope().Validate(map[string]interface{}{"errors": []interface{}{}}); err == nil {
+			t.Error("Expected error for empty errors list")
+		}
+	})
+
+	t.Run("Envelope parts are documented as shared components", func(t *testing.T) {
+		generator := NewOpenAPIGenerator("Test API", "1.0.0")
+
+		op := NewSimple().
+			GET("/users").
+			WithSuccessResponse(200, ListResponse(userSchema), "Users").
+			WithErrorResponse(404, ErrorEnvelope(), "Not Found").
+			Handler(func(c *gin.Context) {})
+
+		if err := generator.Process(OperationInfo{Method: op.Method, Path: op.Path, Operation: &op}); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		operation := generator.Spec.Paths["/users"]["get"]
+		meta := operation.Responses["200"].Content["application/json"].Schema.Properties["meta"]
+		if meta == nil || meta.Ref != ComponentRef("ResponseMeta") {
+			t.Errorf("Expected meta to reference ResponseMeta, got %+v", meta)
+		}
+		if ref := operation.Responses["404"].Content["application/json"].Schema.Ref; ref != ComponentRef("ErrorEnvelope") {
+			t.Errorf("Expected 404 to reference ErrorEnvelope, got %q", ref)
+		}
+
+		for _, name := range []string{"ResponseMeta", "ErrorObject", "ErrorEnvelope"} {
+			if _, ok := generator.Spec.Components.Schemas[name]; !ok {
+				t.Errorf("Expected component schema '%s' to be registered", name)
+			}
+		}
+		if required := generator.Spec.Components.Schemas["ErrorObject"].Required; len(required) != 2 {
+			t.Errorf("Expected ErrorObject to have 2 required fields, got %v", required)
+		}
+	})
+}
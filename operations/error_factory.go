@@ -0,0 +1,338 @@
+package operations
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	goop "github.com/picogrid/go-op"
+	"github.com/picogrid/go-op/validators"
+)
+
+// ErrorFormat selects the body layout of error schemas built by an ErrorSchemaFactory
+type ErrorFormat string
+
+const (
+	// ErrorFormatStandard is the go-op error body: error, message, and code plus optional members
+	ErrorFormatStandard ErrorFormat = "standard"
+	// ErrorFormatProblem is an RFC 9457 problem details object served as application/problem+json
+	ErrorFormatProblem ErrorFormat = "problem"
+	// ErrorFormatJSONAPI is a JSON:API error document served as application/vnd.api+json
+	ErrorFormatJSONAPI ErrorFormat = "jsonapi"
+)
+
+// Media types of the error formats that are not plain JSON
+const (
+	ProblemJSONContentType = "application/problem+json"
+	JSONAPIContentType     = "application/vnd.api+json"
+)
+
+// ErrorSchemaFactory builds error response schemas for any status code in a single format
+// The built-in schemas such as NotFoundErrorSchema cover the minimal standard format;
+// use a factory to document details, trace IDs, and documentation links consistently.
+type ErrorSchemaFactory struct {
+	format           ErrorFormat
+	details          bool
+	traceID          bool
+	documentationURL string
+}
+
+// ErrorSchemaOption configures an ErrorSchemaFactory
+type ErrorSchemaOption func(*ErrorSchemaFactory)
+
+// NewErrorSchemaFactory creates a factory for standard format error schemas
+func NewErrorSchemaFactory(opts ...ErrorSchemaOption) *ErrorSchemaFactory {
+	f := &ErrorSchemaFactory{format: ErrorFormatStandard}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
+}
+
+// ProblemDetailsErrors creates a factory for RFC 9457 problem details error schemas
+func ProblemDetailsErrors(opts ...ErrorSchemaOption) *ErrorSchemaFactory {
+	return NewErrorSchemaFactory(append([]ErrorSchemaOption{WithErrorFormat(ErrorFormatProblem)}, opts...)...)
+}
+
+// JSONAPIErrors creates a factory for JSON:API error document schemas
+func JSONAPIErrors(opts ...ErrorSchemaOption) *ErrorSchemaFactory {
+	return NewErrorSchemaFactory(append([]ErrorSchemaOption{WithErrorFormat(ErrorFormatJSONAPI)}, opts...)...)
+}
+
+// WithErrorFormat sets the body layout of the built schemas
+func WithErrorFormat(format ErrorFormat) ErrorSchemaOption {
+	return func(f *ErrorSchemaFactory) {
+		f.format = format
+	}
+}
+
+// WithErrorDetails documents an array of per-field error details
+func WithErrorDetails() ErrorSchemaOption {
+	return func(f *ErrorSchemaFactory) {
+		f.details = true
+	}
+}
+
+// WithTraceID documents the trace ID clients can quote when reporting an error
+func WithTraceID() ErrorSchemaOption {
+	return func(f *ErrorSchemaFactory) {
+		f.traceID = true
+	}
+}
+
+// WithDocumentationURL documents a link to the error's documentation under baseURL
+// Examples link to baseURL followed by the error code, such as https://docs.example.com/errors/not_found
+func WithDocumentationURL(baseURL string) ErrorSchemaOption {
+	return func(f *ErrorSchemaFactory) {
+		f.documentationURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// ContentType returns the media type error responses are served with
+func (f *ErrorSchemaFactory) ContentType() string {
+	switch f.format {
+	case ErrorFormatProblem:
+		return ProblemJSONContentType
+	case ErrorFormatJSONAPI:
+		return JSONAPIContentType
+	default:
+		return "application/json"
+	}
+}
+
+// Schema builds the error schema for a status code
+func (f *ErrorSchemaFactory) Schema(code int) goop.Schema {
+	switch f.format {
+	case ErrorFormatProblem:
+		return f.problemSchema(code)
+	case ErrorFormatJSONAPI:
+		return f.jsonAPISchema(code)
+	default:
+		return f.standardSchema(code)
+	}
+}
+
+// Response builds the documented error response for a status code
+// The description cites the RFC section defining the status code.
+func (f *ErrorSchemaFactory) Response(code int) ResponseDefinition {
+	description := getStandardErrorDescription(code)
+	if reference := statusReference(code); reference != "" {
+		description = fmt.Sprintf("%s (%s)", description, reference)
+	}
+	return ResponseDefinition{
+		Schema:      f.Schema(code),
+		Description: description,
+		ContentType: f.ContentType(),
+	}
+}
+
+// Responses builds error responses for the given status codes
+// Pass the result to OpenAPIGenerator.SetDefaultResponses to document them on every operation
+func (f *ErrorSchemaFactory) Responses(codes ...int) map[int]ResponseDefinition {
+	responses := make(map[int]ResponseDefinition, len(codes))
+	for _, code := range codes {
+		responses[code] = f.Response(code)
+	}
+	return responses
+}
+
+// standardSchema builds the go-op error body
+func (f *ErrorSchemaFactory) standardSchema(code int) goop.Schema {
+	errorCode := errorCodeFor(code)
+	fields := map[string]interface{}{
+		"error": validators.String().
+			Example(errorCode).
+			Required(),
+		"message": validators.String().
+			Example(getStandardErrorDescription(code)).
+			Required(),
+		"code": validators.Number().Integer().
+			Example(code).
+			Optional(),
+	}
+	example := map[string]interface{}{
+		"error":   errorCode,
+		"message": getStandardErrorDescription(code),
+		"code":    code,
+	}
+
+	if f.details {
+		detail := map[string]interface{}{"field": "email", "message": "must be a valid email address"}
+		fields["details"] = validators.Array(validators.Object(map[string]interface{}{
+			"field":   validators.String().Optional(),
+			"message": validators.String().Required(),
+		}).Required()).Optional()
+		example["details"] = []interface{}{detail}
+	}
+	if f.traceID {
+		fields["trace_id"] = validators.String().
+			Example(exampleTraceID).
+			Optional()
+		example["trace_id"] = exampleTraceID
+	}
+	if f.documentationURL != "" {
+		url := f.documentationURL + "/" + errorCode
+		fields["documentation_url"] = validators.String().
+			Example(url).
+			Optional()
+		example["documentation_url"] = url
+	}
+
+	return validators.Object(fields).Example(example).Required()
+}
+
+// problemSchema builds an RFC 9457 problem details object
+// Details and the trace ID are documented as the "errors" and "trace_id" extension members.
+func (f *ErrorSchemaFactory) problemSchema(code int) goop.Schema {
+	problemType := "about:blank"
+	if f.documentationURL != "" {
+		problemType = f.documentationURL + "/" + errorCodeFor(code)
+	}
+	fields := map[string]interface{}{
+		"type": validators.String().
+			Example(problemType).
+			Optional(),
+		"title": validators.String().
+			Example(http.StatusText(code)).
+			Required(),
+		"status": validators.Number().Integer().
+			Example(code).
+			Required(),
+		"detail": validators.String().
+			Example(getStandardErrorDescription(code)).
+			Optional(),
+		"instance": validators.String().
+			Example("/requests/" + exampleTraceID).
+			Optional(),
+	}
+	example := map[string]interface{}{
+		"type":   problemType,
+		"title":  http.StatusText(code),
+		"status": code,
+		"detail": getStandardErrorDescription(code),
+	}
+
+	if f.details {
+		fields["errors"] = validators.Array(validators.Object(map[string]interface{}{
+			"pointer": validators.String().Optional(),
+			"detail":  validators.String().Required(),
+		}).Required()).Optional()
+		example["errors"] = []interface{}{
+			map[string]interface{}{"pointer": "#/email", "detail": "must be a valid email address"},
+		}
+	}
+	if f.traceID {
+		fields["trace_id"] = validators.String().
+			Example(exampleTraceID).
+			Optional()
+		example["trace_id"] = exampleTraceID
+	}
+
+	return validators.Object(fields).Example(example).Required()
+}
+
+// jsonAPISchema builds a JSON:API error document with a single error object per example
+func (f *ErrorSchemaFactory) jsonAPISchema(code int) goop.Schema {
+	errorCode := errorCodeFor(code)
+	fields := map[string]interface{}{
+		"status": validators.String().Optional(),
+		"code":   validators.String().Optional(),
+		"title":  validators.String().Optional(),
+		"detail": validators.String().Optional(),
+	}
+	errorExample := map[string]interface{}{
+		"status": fmt.Sprintf("%d", code),
+		"code":   errorCode,
+		"title":  http.StatusText(code),
+		"detail": getStandardErrorDescription(code),
+	}
+
+	if f.details {
+		fields["source"] = validators.Object(map[string]interface{}{
+			"pointer":   validators.String().Optional(),
+			"parameter": validators.String().Optional(),
+			"header":    validators.String().Optional(),
+		}).Optional()
+		errorExample["source"] = map[string]interface{}{"pointer": "/data/attributes/email"}
+	}
+	if f.traceID {
+		fields["meta"] = validators.Object(map[string]interface{}{
+			"trace_id": validators.String().Optional(),
+		}).Optional()
+		errorExample["meta"] = map[string]interface{}{"trace_id": exampleTraceID}
+	}
+	if f.documentationURL != "" {
+		fields["links"] = validators.Object(map[string]interface{}{
+			"about": validators.String().Optional(),
+		}).Optional()
+		errorExample["links"] = map[string]interface{}{"about": f.documentationURL + "/" + errorCode}
+	}
+
+	return validators.Object(map[string]interface{}{
+		"errors": validators.Array(validators.Object(fields).Required()).MinItems(1).Required(),
+	}).Example(map[string]interface{}{
+		"errors": []interface{}{errorExample},
+	}).Required()
+}
+
+// exampleTraceID is the trace ID shown in error examples
+const exampleTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+
+// errorCodeFor returns the machine-readable error code for a status, such as "not_found"
+func errorCodeFor(code int) string {
+	text := http.StatusText(code)
+	if text == "" {
+		return fmt.Sprintf("error_%d", code)
+	}
+	return strings.ReplaceAll(strings.ToLower(strings.ReplaceAll(text, "-", " ")), " ", "_")
+}
+
+// statusReference cites the RFC section defining an error status code
+func statusReference(code int) string {
+	if section, ok := rfc9110Sections[code]; ok {
+		return "RFC 9110, Section " + section
+	}
+	if section, ok := rfc6585Sections[code]; ok {
+		return "RFC 6585, Section " + section
+	}
+	return ""
+}
+
+// rfc9110Sections maps client and server error codes to their sections in RFC 9110
+var rfc9110Sections = map[int]string{
+	400: "15.5.1",
+	401: "15.5.2",
+	402: "15.5.3",
+	403: "15.5.4",
+	404: "15.5.5",
+	405: "15.5.6",
+	406: "15.5.7",
+	407: "15.5.8",
+	408: "15.5.9",
+	409: "15.5.10",
+	410: "15.5.11",
+	411: "15.5.12",
+	412: "15.5.13",
+	413: "15.5.14",
+	414: "15.5.15",
+	415: "15.5.16",
+	416: "15.5.17",
+	417: "15.5.18",
+	421: "15.5.20",
+	422: "15.5.21",
+	426: "15.5.22",
+	500: "15.6.1",
+	501: "15.6.2",
+	502: "15.6.3",
+	503: "15.6.4",
+	504: "15.6.5",
+	505: "15.6.6",
+}
+
+// rfc6585Sections maps the additional status codes defined in RFC 6585 to their sections
+var rfc6585Sections = map[int]string{
+	428: "3",
+	429: "4",
+	431: "5",
+	511: "6",
+}
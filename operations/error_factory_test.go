@@ -0,0 +1,131 @@
+package operations
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	goop "github.com/picogrid/go-op"
+)
+
+func TestErrorSchemaFactory(t *testing.T) {
+	openAPISchema := func(t *testing.T, schema goop.Schema) *goop.OpenAPISchema {
+		t.Helper()
+		enhanced, ok := schema.(goop.EnhancedSchema)
+		if !ok {
+			t.Fatal("Expected schema to support OpenAPI generation")
+		}
+		return enhanced.ToOpenAPISchema()
+	}
+
+	t.Run("Standard format with optional members", func(t *testing.T) {
+		factory := NewErrorSchemaFactory(
+			WithErrorDetails(),
+			WithTraceID(),
+			WithDocumentationURL("https://docs.example.com/errors/"),
+		)
+		schema := openAPISchema(t, factory.Schema(404))
+		for _, name := range []string{"error", "message", "code", "details", "trace_id", "documentation_url"} {
+			if schema.Properties[name] == nil {
+				t.Errorf("Expected property %s", name)
+			}
+		}
+		if schema.Properties["details"].Type != "array" {
+			t.Errorf("Expected details to be an array, got %s", schema.Properties["details"].Type)
+		}
+		example := schema.Example.(map[string]interface{})
+		if example["error"] != "not_found" {
+			t.Errorf("Expected error code not_found, got %v", example["error"])
+		}
+		if example["documentation_url"] != "https://docs.example.com/errors/not_found" {
+			t.Errorf("Unexpected documentation URL %v", example["documentation_url"])
+		}
+		if factory.ContentType() != "application/json" {
+			t.Errorf("Expected application/json, got %s", factory.ContentType())
+		}
+	})
+
+	t.Run("Standard format omits members that are not enabled", func(t *testing.T) {
+		schema := openAPISchema(t, NewErrorSchemaFactory().Schema(500))
+		for _, name := range []string{"details", "trace_id", "documentation_url"} {
+			if schema.Properties[name] != nil {
+				t.Errorf("Expected no %s property", name)
+			}
+		}
+	})
+
+	t.Run("Problem details", func(t *testing.T) {
+		factory := ProblemDetailsErrors(WithErrorDetails())
+		schema := openAPISchema(t, factory.Schema(409))
+		for _, name := range []string{"type", "title", "status", "detail", "instance", "errors"} {
+			if schema.Properties[name] == nil {
+				t.Errorf("Expected property %s", name)
+			}
+		}
+		example := schema.Example.(map[string]interface{})
+		if example["type"] != "about:blank" || example["title"] != "Conflict" || example["status"] != 409 {
+			t.Errorf("Unexpected problem example %v", example)
+		}
+		if factory.ContentType() != ProblemJSONContentType {
+			t.Errorf("Expected %s, got %s", ProblemJSONContentType, factory.ContentType())
+		}
+		if err := factory.Schema(409).Validate(example); err != nil {
+			t.Errorf("Expected example to validate, got %v", err)
+		}
+	})
+
+	t.Run("JSON:API errors", func(t *testing.T) {
+		factory := JSONAPIErrors(WithTraceID(), WithDocumentationURL("https://docs.example.com/errors"))
+		schema := openAPISchema(t, factory.Schema(422))
+		errors := schema.Properties["errors"]
+		if errors == nil || errors.Type != "array" || errors.Items == nil {
+			t.Fatalf("Expected errors array, got %+v", errors)
+		}
+		for _, name := range []string{"status", "code", "title", "detail", "meta", "links"} {
+			if errors.Items.Properties[name] == nil {
+				t.Errorf("Expected error object property %s", name)
+			}
+		}
+		example := schema.Example.(map[string]interface{})
+		if err := factory.Schema(422).Validate(example); err != nil {
+			t.Errorf("Expected example to validate, got %v", err)
+		}
+		if factory.ContentType() != JSONAPIContentType {
+			t.Errorf("Expected %s, got %s", JSONAPIContentType, factory.ContentType())
+		}
+	})
+
+	t.Run("Responses cite the defining RFC", func(t *testing.T) {
+		responses := NewErrorSchemaFactory().Responses(404, 429, 599)
+		if !strings.HasSuffix(responses[404].Description, "(RFC 9110, Section 15.5.5)") {
+			t.Errorf("Unexpected 404 description %q", responses[404].Description)
+		}
+		if !strings.HasSuffix(responses[429].Description, "(RFC 6585, Section 4)") {
+			t.Errorf("Unexpected 429 description %q", responses[429].Description)
+		}
+		if strings.Contains(responses[599].Description, "RFC") {
+			t.Errorf("Expected no reference for an unregistered code, got %q", responses[599].Description)
+		}
+	})
+
+	t.Run("Documents the factory content type", func(t *testing.T) {
+		generator := NewOpenAPIGenerator("Test API", "1.0.0")
+		generator.SetDefaultResponses(ProblemDetailsErrors().Responses(500))
+
+		op := NewSimple().
+			GET("/users/{id}").
+			WithErrorSchemas(ProblemDetailsErrors(), 404).
+			Handler(func(c *gin.Context) {})
+		if err := generator.Process(OperationInfo{Method: op.Method, Path: op.Path, Operation: &op}); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		operation := generator.Spec.Paths["/users/{id}"]["get"]
+		for _, code := range []string{"404", "500"} {
+			if _, ok := operation.Responses[code].Content[ProblemJSONContentType]; !ok {
+				t.Errorf("Expected %s response served as %s, got %v", code, ProblemJSONContentType, operation.Responses[code].Content)
+			}
+		}
+	})
+}
@@ -15,10 +15,11 @@ type StandardErrorResponse struct {
 
 // ValidationErrorResponse represents a validation error with field details
 type ValidationErrorResponse struct {
-	Error   string            `json:"error"`
-	Message string            `json:"message"`
-	Code    int               `json:"code,omitempty"`
-	Fields  map[string]string `json:"fields,omitempty"`
+	Error     string            `json:"error"`
+	Message   string            `json:"message"`
+	Code      int               `json:"code,omitempty"`
+	Fields    map[string]string `json:"fields,omitempty"`
+	RequestID string            `json:"request_id,omitempty"`
 }
 
 // Common error response schemas that can be reused across operations
@@ -64,6 +65,9 @@ var (
 				"age":   "Age must be between 13 and 120",
 			}).
 			Optional(),
+		"request_id": validators.String().
+			Example("4bf92f3577b34da6a3ce929d0e0e4736").
+			Optional(),
 	}).Example(map[string]interface{}{
 		"error":   "validation_failed",
 		"message": "Request validation failed",
@@ -74,6 +78,25 @@ var (
 		},
 	}).Required()
 
+	// RequestErrorSchema represents the 400 Bad Request body validated handlers send when they
+	// reject a request: what failed, the validation details, and the request ID when the router
+	// uses request IDs. It is documented on operations by AutoValidationErrors.
+	RequestErrorSchema = validators.Object(map[string]interface{}{
+		"error": validators.String().
+			Example("Request body validation failed").
+			Required(),
+		"details": validators.String().
+			Example("Field: email, Error: invalid email format").
+			Required(),
+		"request_id": validators.String().
+			Example("4bf92f3577b34da6a3ce929d0e0e4736").
+			Optional(),
+	}).Example(map[string]interface{}{
+		"error":      "Request body validation failed",
+		"details":    "Field: email, Error: invalid email format",
+		"request_id": "4bf92f3577b34da6a3ce929d0e0e4736",
+	}).Required()
+
 	// UnauthorizedErrorSchema represents a 401 Unauthorized response
 	UnauthorizedErrorSchema = validators.Object(map[string]interface{}{
 		"error": validators.String().
@@ -262,6 +285,48 @@ var (
 		"code":    503,
 		"details": "Service is under maintenance. Please try again later",
 	}).Required()
+
+	// PayloadTooLargeErrorSchema represents a 413 Payload Too Large response
+	PayloadTooLargeErrorSchema = validators.Object(map[string]interface{}{
+		"error": validators.String().
+			Example("payload_too_large").
+			Required(),
+		"message": validators.String().
+			Example("The request body exceeds the size limit").
+			Required(),
+		"code": validators.Number().
+			Example(413).
+			Optional(),
+		"details": validators.String().
+			Example("request body must be at most 1048576 bytes").
+			Optional(),
+	}).Example(map[string]interface{}{
+		"error":   "payload_too_large",
+		"message": "The request body exceeds the size limit",
+		"code":    413,
+		"details": "request body must be at most 1048576 bytes",
+	}).Required()
+
+	// GatewayTimeoutErrorSchema represents a 504 Gateway Timeout response
+	GatewayTimeoutErrorSchema = validators.Object(map[string]interface{}{
+		"error": validators.String().
+			Example("gateway_timeout").
+			Required(),
+		"message": validators.String().
+			Example("The request did not complete within its deadline").
+			Required(),
+		"code": validators.Number().
+			Example(504).
+			Optional(),
+		"details": validators.String().
+			Example("context deadline exceeded").
+			Optional(),
+	}).Example(map[string]interface{}{
+		"error":   "gateway_timeout",
+		"message": "The request did not complete within its deadline",
+		"code":    504,
+		"details": "context deadline exceeded",
+	}).Required()
 )
 
 // GetStandardErrorSchema returns the appropriate standard error schema for a given HTTP status code
@@ -277,6 +342,8 @@ func GetStandardErrorSchema(statusCode int) goop.Schema {
 		return NotFoundErrorSchema
 	case 409:
 		return ConflictErrorSchema
+	case 413:
+		return PayloadTooLargeErrorSchema
 	case 422:
 		return UnprocessableEntityErrorSchema
 	case 429:
@@ -287,8 +354,23 @@ func GetStandardErrorSchema(statusCode int) goop.Schema {
 		return BadGatewayErrorSchema
 	case 503:
 		return ServiceUnavailableErrorSchema
+	case 504:
+		return GatewayTimeoutErrorSchema
 	default:
 		// Return generic error schema for unknown status codes
 		return BadRequestErrorSchema
 	}
 }
+
+// StandardErrorResponses returns standard error responses for the given status codes
+// Pass the result to OpenAPIGenerator.SetDefaultResponses to document them on every operation
+func StandardErrorResponses(codes ...int) map[int]ResponseDefinition {
+	responses := make(map[int]ResponseDefinition, len(codes))
+	for _, code := range codes {
+		responses[code] = ResponseDefinition{
+			Schema:      GetStandardErrorSchema(code),
+			Description: getStandardErrorDescription(code),
+		}
+	}
+	return responses
+}
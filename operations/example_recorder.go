@@ -0,0 +1,346 @@
+package operations
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// RecordedExchange is a request/response pair captured from a served operation
+type RecordedExchange = goop.RecordedExchange
+
+// ExchangeRecorder receives exchanges captured by adapters in development
+type ExchangeRecorder = goop.ExchangeRecorder
+
+// redactedHeaders are replaced with a placeholder when recorded so credentials never reach examples or HAR files
+var redactedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// ExampleRecorder is an in-memory ExchangeRecorder for development
+// It keeps the first exchanges seen for each operation and response status, which can be
+// written back into a specification as examples or exported as a HAR file
+type ExampleRecorder struct {
+	mu        sync.Mutex
+	perStatus int
+	exchanges []RecordedExchange
+	counts    map[string]int
+}
+
+// NewExampleRecorder creates a recorder keeping up to perStatus exchanges for each
+// operation and response status (at least one)
+func NewExampleRecorder(perStatus int) *ExampleRecorder {
+	if perStatus < 1 {
+		perStatus = 1
+	}
+	return &ExampleRecorder{
+		perStatus: perStatus,
+		counts:    make(map[string]int),
+	}
+}
+
+// Record stores exchange unless enough exchanges with its operation and status were already kept
+// Credential headers are redacted before the exchange is stored
+func (r *ExampleRecorder) Record(exchange RecordedExchange) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := fmt.Sprintf("%s %s %d", exchange.Method, exchange.Path, exchange.StatusCode)
+	if r.counts[key] >= r.perStatus {
+		return
+	}
+	r.counts[key]++
+
+	exchange.RequestHeaders = redactHeaders(exchange.RequestHeaders)
+	exchange.ResponseHeaders = redactHeaders(exchange.ResponseHeaders)
+	r.exchanges = append(r.exchanges, exchange)
+}
+
+// Exchanges returns the recorded exchanges in the order they were recorded
+func (r *ExampleRecorder) Exchanges() []RecordedExchange {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	exchanges := make([]RecordedExchange, len(r.exchanges))
+	copy(exchanges, r.exchanges)
+	return exchanges
+}
+
+// Reset discards all recorded exchanges
+func (r *ExampleRecorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.exchanges = nil
+	r.counts = make(map[string]int)
+}
+
+// ApplyExamples adds the recorded JSON bodies to spec as named examples
+// Request bodies are taken from successful exchanges and added to the operation's request body;
+// response bodies are added to the documented response for their status. Undocumented
+// operations and statuses are skipped, and an existing single example is kept as "default".
+func (r *ExampleRecorder) ApplyExamples(spec *OpenAPISpec) {
+	names := make(map[string]int)
+	for _, exchange := range r.Exchanges() {
+		method := strings.ToLower(exchange.Method)
+		op, ok := spec.Paths[exchange.Path][method]
+		if !ok {
+			continue
+		}
+
+		if exchange.StatusCode < http.StatusBadRequest && op.RequestBody != nil {
+			if value, ok := jsonBody(exchange.RequestHeaders, exchange.RequestBody); ok {
+				key := exchange.Method + " " + exchange.Path + " request"
+				names[key]++
+				addRecordedExample(op.RequestBody.Content, fmt.Sprintf("recorded%d", names[key]), exchange, value)
+			}
+		}
+
+		status := fmt.Sprintf("%d", exchange.StatusCode)
+		// Examples cannot be added to references to reusable responses
+		if response, ok := op.Responses[status]; ok && response.Ref == "" {
+			if value, ok := jsonBody(exchange.ResponseHeaders, exchange.ResponseBody); ok {
+				key := exchange.Method + " " + exchange.Path + " " + status
+				names[key]++
+				if response.Content == nil {
+					response.Content = make(map[string]OpenAPIMediaType)
+				}
+				addRecordedExample(response.Content, fmt.Sprintf("recorded%d", names[key]), exchange, value)
+				op.Responses[status] = response
+			}
+		}
+
+		spec.Paths[exchange.Path][method] = op
+	}
+}
+
+// addRecordedExample adds value to the JSON media type in content
+func addRecordedExample(content map[string]OpenAPIMediaType, name string, exchange RecordedExchange, value interface{}) {
+	contentType := "application/json"
+	if _, ok := content[contentType]; !ok {
+		// Bodies documented with a structured JSON type, such as merge patches, keep their type
+		for documented := range content {
+			if strings.HasSuffix(documented, "+json") {
+				contentType = documented
+				break
+			}
+		}
+	}
+
+	mediaType := content[contentType]
+	if mediaType.Examples == nil {
+		mediaType.Examples = make(map[string]OpenAPIExample)
+	}
+	if mediaType.Example != nil {
+		mediaType.Examples["default"] = OpenAPIExample{Value: mediaType.Example}
+		mediaType.Example = nil
+	}
+	mediaType.Examples[name] = OpenAPIExample{
+		Summary: fmt.Sprintf("Recorded %s %s", exchange.Method, exchange.URL),
+		Value:   value,
+	}
+	content[contentType] = mediaType
+}
+
+// jsonBody decodes body when headers declare a JSON content type
+func jsonBody(headers http.Header, body []byte) (interface{}, bool) {
+	if len(body) == 0 {
+		return nil, false
+	}
+	mediaType, _, err := mime.ParseMediaType(headers.Get("Content-Type"))
+	if err != nil || (mediaType != "application/json" && !strings.HasSuffix(mediaType, "+json")) {
+		return nil, false
+	}
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return nil, false
+	}
+	return value, true
+}
+
+// redactHeaders copies headers with credential values replaced
+func redactHeaders(headers http.Header) http.Header {
+	if headers == nil {
+		return nil
+	}
+	result := headers.Clone()
+	for _, name := range redactedHeaders {
+		if _, ok := result[name]; ok {
+			result.Set(name, "[REDACTED]")
+		}
+	}
+	return result
+}
+
+// WriteHAR writes the recorded exchanges as an HTTP Archive (HAR 1.2) document
+func (r *ExampleRecorder) WriteHAR(w io.Writer) error {
+	exchanges := r.Exchanges()
+	entries := make([]harEntry, 0, len(exchanges))
+	for _, exchange := range exchanges {
+		entries = append(entries, newHAREntry(exchange))
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(harDocument{Log: harLog{
+		Version: "1.2",
+		Creator: harNameVersion{Name: "go-op", Version: "1.0"},
+		Entries: entries,
+	}})
+}
+
+// HAR 1.2 document structure (http://www.softwareishard.com/blog/har-12-spec/)
+type harDocument struct {
+	Log harLog `json:"log"`
+}
+
+type harLog struct {
+	Version string         `json:"version"`
+	Creator harNameVersion `json:"creator"`
+	Entries []harEntry     `json:"entries"`
+}
+
+type harNameVersion struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harEntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+	Comment         string      `json:"comment,omitempty"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+}
+
+type harTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+// newHAREntry converts a recorded exchange to a HAR entry
+func newHAREntry(exchange RecordedExchange) harEntry {
+	millis := float64(exchange.Duration) / float64(time.Millisecond)
+	entry := harEntry{
+		StartedDateTime: exchange.StartedAt.Format(time.RFC3339Nano),
+		Time:            millis,
+		Request: harRequest{
+			Method:      exchange.Method,
+			URL:         exchange.URL,
+			HTTPVersion: "HTTP/1.1",
+			Cookies:     []harNameValue{},
+			Headers:     harHeaders(exchange.RequestHeaders),
+			QueryString: harQuery(exchange.URL),
+			HeadersSize: -1,
+			BodySize:    len(exchange.RequestBody),
+		},
+		Response: harResponse{
+			Status:      exchange.StatusCode,
+			StatusText:  http.StatusText(exchange.StatusCode),
+			HTTPVersion: "HTTP/1.1",
+			Cookies:     []harNameValue{},
+			Headers:     harHeaders(exchange.ResponseHeaders),
+			Content: harContent{
+				Size:     len(exchange.ResponseBody),
+				MimeType: exchange.ResponseHeaders.Get("Content-Type"),
+				Text:     string(exchange.ResponseBody),
+			},
+			HeadersSize: -1,
+			BodySize:    len(exchange.ResponseBody),
+		},
+		Timings: harTimings{Wait: millis},
+		Comment: exchange.OperationID,
+	}
+	if len(exchange.RequestBody) > 0 {
+		entry.Request.PostData = &harPostData{
+			MimeType: exchange.RequestHeaders.Get("Content-Type"),
+			Text:     string(exchange.RequestBody),
+		}
+	}
+	return entry
+}
+
+// harHeaders lists headers sorted by name
+func harHeaders(headers http.Header) []harNameValue {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	result := make([]harNameValue, 0, len(names))
+	for _, name := range names {
+		for _, value := range headers[name] {
+			result = append(result, harNameValue{Name: name, Value: value})
+		}
+	}
+	return result
+}
+
+// harQuery lists the query parameters of rawURL
+func harQuery(rawURL string) []harNameValue {
+	result := []harNameValue{}
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return result
+	}
+	query := parsed.Query()
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, value := range query[name] {
+			result = append(result, harNameValue{Name: name, Value: value})
+		}
+	}
+	return result
+}
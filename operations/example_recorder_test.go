@@ -0,0 +1,122 @@
+package operations
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestExampleRecorder(t *testing.T) {
+	exchange := func(status int, body string) RecordedExchange {
+		return RecordedExchange{
+			OperationID:     "listUsers",
+			Method:          "GET",
+			Path:            "/users",
+			URL:             "http://localhost/users?limit=2&tag=a&tag=b",
+			StartedAt:       time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			Duration:        1500 * time.Microsecond,
+			RequestHeaders:  http.Header{"Cookie": {"session=abc"}, "Accept": {"application/json"}},
+			StatusCode:      status,
+			ResponseHeaders: http.Header{"Content-Type": {"application/json; charset=utf-8"}},
+			ResponseBody:    []byte(body),
+		}
+	}
+
+	t.Run("Keeps a bounded number of exchanges per status", func(t *testing.T) {
+		recorder := NewExampleRecorder(2)
+		recorder.Record(exchange(200, `[1]`))
+		recorder.Record(exchange(200, `[2]`))
+		recorder.Record(exchange(200, `[3]`))
+		recorder.Record(exchange(404, `{}`))
+
+		exchanges := recorder.Exchanges()
+		if len(exchanges) != 3 {
+			t.Fatalf("Expected 3 exchanges, got %d", len(exchanges))
+		}
+		if got := exchanges[0].RequestHeaders.Get("Cookie"); got != "[REDACTED]" {
+			t.Errorf("Expected cookie to be redacted, got %q", got)
+		}
+
+		recorder.Reset()
+		if len(recorder.Exchanges()) != 0 {
+			t.Error("Expected no exchanges after reset")
+		}
+	})
+
+	t.Run("Keeps an existing example alongside recorded ones", func(t *testing.T) {
+		spec := &OpenAPISpec{Paths: map[string]map[string]OpenAPIOperation{
+			"/users": {"get": {Responses: map[string]OpenAPIResponse{
+				"200": {Content: map[string]OpenAPIMediaType{"application/json": {Example: []interface{}{"documented"}}}},
+			}}},
+		}}
+		recorder := NewExampleRecorder(1)
+		recorder.Record(exchange(200, `["recorded"]`))
+		recorder.Record(exchange(500, `{}`))
+		recorder.ApplyExamples(spec)
+
+		mediaType := spec.Paths["/users"]["get"].Responses["200"].Content["application/json"]
+		if mediaType.Example != nil {
+			t.Error("Expected single example to move into examples")
+		}
+		if len(mediaType.Examples) != 2 || mediaType.Examples["default"].Value == nil || mediaType.Examples["recorded1"].Value == nil {
+			t.Errorf("Expected default and recorded1 examples, got %v", mediaType.Examples)
+		}
+		if err := mediaType.Validate(); err != nil {
+			t.Errorf("Expected valid media type, got %v", err)
+		}
+		if _, ok := spec.Paths["/users"]["get"].Responses["500"]; ok {
+			t.Error("Expected undocumented status to be skipped")
+		}
+	})
+
+	t.Run("Writes a HAR document", func(t *testing.T) {
+		recorder := NewExampleRecorder(1)
+		recorder.Record(exchange(200, `[1]`))
+
+		var buf bytes.Buffer
+		if err := recorder.WriteHAR(&buf); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		var har struct {
+			Log struct {
+				Version string `json:"version"`
+				Entries []struct {
+					StartedDateTime string  `json:"startedDateTime"`
+					Time            float64 `json:"time"`
+					Request         struct {
+						Method      string `json:"method"`
+						QueryString []struct {
+							Name  string `json:"name"`
+							Value string `json:"value"`
+						} `json:"queryString"`
+					} `json:"request"`
+					Response struct {
+						Status  int `json:"status"`
+						Content struct {
+							Text string `json:"text"`
+						} `json:"content"`
+					} `json:"response"`
+				} `json:"entries"`
+			} `json:"log"`
+		}
+		if err := json.Unmarshal(buf.Bytes(), &har); err != nil {
+			t.Fatalf("Expected valid JSON, got %v", err)
+		}
+		if har.Log.Version != "1.2" || len(har.Log.Entries) != 1 {
+			t.Fatalf("Unexpected HAR log %+v", har.Log)
+		}
+		entry := har.Log.Entries[0]
+		if entry.StartedDateTime != "2024-01-02T03:04:05Z" || entry.Time != 1.5 {
+			t.Errorf("Unexpected timing %s %v", entry.StartedDateTime, entry.Time)
+		}
+		if entry.Request.Method != "GET" || len(entry.Request.QueryString) != 3 {
+			t.Errorf("Unexpected request %+v", entry.Request)
+		}
+		if entry.Response.Status != 200 || entry.Response.Content.Text != `[1]` {
+			t.Errorf("Unexpected response %+v", entry.Response)
+		}
+	})
+}
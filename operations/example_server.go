@@ -0,0 +1,174 @@
+package operations
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// PreferHeader is the request header clients of example handlers use to pick a response, such as
+// "Prefer: code=404" to receive the documented not found example
+const PreferHeader = "Prefer"
+
+// Registrar registers operations with a router, such as the Gin router or one of its groups
+type Registrar interface {
+	Register(ops ...CompiledOperation) error
+}
+
+// SeedFromExamples returns a registrar that registers operations with router, giving operations
+// without a handler one that serves their declared response examples. It is meant for demo and
+// development servers, so endpoints can be exercised before they are implemented.
+//
+// Example:
+//
+//	router := operations.SeedFromExamples(ginadapter.NewGinRouter(engine, generator))
+//	router.Register(operations.NewSimple().GET("/users/{id}").WithResponse(userSchema).Handler(nil))
+func SeedFromExamples(router Registrar) Registrar {
+	return exampleSeeder{router: router}
+}
+
+// exampleSeeder fills in missing handlers before registering operations
+type exampleSeeder struct {
+	router Registrar
+}
+
+func (s exampleSeeder) Register(ops ...CompiledOperation) error {
+	seeded := make([]CompiledOperation, len(ops))
+	for i, op := range ops {
+		if op.Handler == nil {
+			op.Handler = ExampleHandler(op)
+		}
+		seeded[i] = op
+	}
+	return s.router.Register(seeded...)
+}
+
+// exampleResponse is a documented response an example handler can serve
+type exampleResponse struct {
+	schema      goop.Schema
+	contentType string
+}
+
+// ExampleHandler returns a handler that responds with the examples declared for the responses of op
+// It responds with the success code unless the request prefers another documented code through
+// the Prefer header. Bodies use the example of the response schema, or one assembled from the
+// examples of its fields; schemas without examples get a generated value that satisfies them.
+func ExampleHandler(op CompiledOperation) http.Handler {
+	responses := make(map[int]exampleResponse, len(op.Responses)+1)
+	for code, response := range op.Responses {
+		responses[code] = exampleResponse{schema: response.Schema, contentType: response.ContentType}
+	}
+	successCode := op.SuccessCode
+	if successCode == 0 {
+		successCode = http.StatusOK
+	}
+	if _, exists := responses[successCode]; !exists && op.ResponseSchema != nil {
+		responses[successCode] = exampleResponse{schema: op.ResponseSchema}
+	}
+	if _, exists := responses[successCode]; !exists {
+		// Fall back to the first documented success, as for operations declaring only a 201
+		codes := make([]int, 0, len(responses))
+		for code := range responses {
+			if code >= 200 && code < 300 {
+				codes = append(codes, code)
+			}
+		}
+		sort.Ints(codes)
+		if len(codes) > 0 {
+			successCode = codes[0]
+		}
+	}
+
+	// Bodies are computed once so every request gets the same example
+	bodies := make(map[int]interface{}, len(responses))
+	for code, response := range responses {
+		bodies[code] = exampleValue(response.schema)
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		code := successCode
+		if preferred, ok := preferredCode(r.Header.Get(PreferHeader)); ok {
+			if _, documented := responses[preferred]; documented {
+				code = preferred
+			}
+		}
+
+		body := bodies[code]
+		if body == nil {
+			w.WriteHeader(code)
+			return
+		}
+		if contentType := responses[code].contentType; contentType != "" {
+			w.Header().Set("Content-Type", contentType)
+			w.WriteHeader(code)
+			_ = json.NewEncoder(w).Encode(body)
+			return
+		}
+		writeJSON(w, code, body)
+	})
+}
+
+// preferredCode parses the code preference of a Prefer header, such as "code=404"
+func preferredCode(prefer string) (int, bool) {
+	for _, preference := range strings.Split(prefer, ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(preference), "=")
+		if strings.EqualFold(strings.TrimSpace(name), "code") {
+			code, err := strconv.Atoi(strings.Trim(strings.TrimSpace(value), `"`))
+			return code, err == nil
+		}
+	}
+	return 0, false
+}
+
+// exampleValue returns the declared or assembled example of schema, or a generated value when it has none
+func exampleValue(schema goop.Schema) interface{} {
+	generator, ok := schema.(goop.OpenAPIGenerator)
+	if !ok {
+		return nil
+	}
+	if example := schemaExample(generator.ToOpenAPISchema()); example != nil {
+		return example
+	}
+	// A fixed seed keeps generated examples stable across requests and restarts
+	value, err := goop.Generate(schema, goop.WithSeed(1))
+	if err != nil {
+		return nil
+	}
+	return value
+}
+
+// schemaExample returns the example of schema, assembling objects and arrays from the examples of
+// their properties and items. It returns nil when no example is declared.
+func schemaExample(schema *goop.OpenAPISchema) interface{} {
+	if schema == nil {
+		return nil
+	}
+	if schema.Example != nil {
+		return schema.Example
+	}
+	switch {
+	case len(schema.Properties) > 0:
+		object := make(map[string]interface{}, len(schema.Properties))
+		for name, property := range schema.Properties {
+			if example := schemaExample(property); example != nil {
+				object[name] = example
+			}
+		}
+		// Objects missing a required field are left to the generator so the example stays valid
+		for _, name := range schema.Required {
+			if _, ok := object[name]; !ok {
+				return nil
+			}
+		}
+		return object
+	case schema.Items != nil:
+		if example := schemaExample(schema.Items); example != nil {
+			return []interface{}{example}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package operations
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/picogrid/go-op/validators"
+)
+
+// recordingRegistrar records the operations registered with it
+type recordingRegistrar struct {
+	ops []CompiledOperation
+}
+
+func (r *recordingRegistrar) Register(ops ...CompiledOperation) error {
+	r.ops = append(r.ops, ops...)
+	return nil
+}
+
+// decodeExample decodes the JSON object served by an example handler
+func decodeExample(t *testing.T, w *httptest.ResponseRecorder) map[string]interface{} {
+	t.Helper()
+	var body map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Failed to decode body %q: %v", w.Body.String(), err)
+	}
+	return body
+}
+
+// TestExampleHandler tests serving the declared examples of an operation's responses
+func TestExampleHandler(t *testing.T) {
+	userSchema := validators.Object(map[string]interface{}{
+		"id":    validators.String().Example("usr_123").Required(),
+		"email": validators.String().Email().Example("jane@example.com").Required(),
+		"age":   validators.Number().Min(0).Optional(),
+	}).Required()
+	notFoundSchema := validators.Object(map[string]interface{}{
+		"message": validators.String().Required(),
+	}).Example(map[string]interface{}{"message": "user not found"}).Required()
+
+	op := NewSimple().
+		POST("/users").
+		WithBody(validators.Object(map[string]interface{}{}).Required()).
+		WithResponse(userSchema).
+		WithNotFoundError(notFoundSchema).
+		SuccessCode(http.StatusCreated).
+		Handler(nil)
+	handler := ExampleHandler(op)
+
+	serve := func(prefer string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/users", nil)
+		if prefer != "" {
+			r.Header.Set(PreferHeader, prefer)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+
+	t.Run("success example", func(t *testing.T) {
+		w := serve("")
+		if w.Code != http.StatusCreated {
+			t.Fatalf("Expected 201, got %d", w.Code)
+		}
+		if got := w.Header().Get("Content-Type"); got != "application/json" {
+			t.Errorf("Expected application/json, got %q", got)
+		}
+		body := decodeExample(t, w)
+		if body["id"] != "usr_123" || body["email"] != "jane@example.com" {
+			t.Errorf("Expected the field examples, got %v", body)
+		}
+		if _, ok := body["age"]; ok {
+			t.Errorf("Expected optional fields without examples to be omitted, got %v", body)
+		}
+		if err := userSchema.Validate(body); err != nil {
+			t.Errorf("Expected the example to satisfy the schema, got %v", err)
+		}
+	})
+
+	t.Run("preferred code", func(t *testing.T) {
+		w := serve("code=404")
+		if w.Code != http.StatusNotFound {
+			t.Fatalf("Expected 404, got %d", w.Code)
+		}
+		if body := decodeExample(t, w); body["message"] != "user not found" {
+			t.Errorf("Expected the declared example, got %v", body)
+		}
+	})
+
+	t.Run("undocumented preferred code", func(t *testing.T) {
+		if w := serve("code=418"); w.Code != http.StatusCreated {
+			t.Errorf("Expected undocumented codes to be ignored, got %d", w.Code)
+		}
+	})
+
+	t.Run("generated example", func(t *testing.T) {
+		schema := validators.Object(map[string]interface{}{
+			"count": validators.Number().Min(1).Max(10).Required(),
+		}).Required()
+		op := NewSimple().GET("/stats").WithResponse(schema).Handler(nil)
+
+		var bodies [2]string
+		for i := range bodies {
+			w := httptest.NewRecorder()
+			ExampleHandler(op).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/stats", nil))
+			if w.Code != http.StatusOK {
+				t.Fatalf("Expected 200, got %d", w.Code)
+			}
+			if err := schema.Validate(decodeExample(t, w)); err != nil {
+				t.Errorf("Expected a generated value satisfying the schema, got %v", err)
+			}
+			bodies[i] = w.Body.String()
+		}
+		if bodies[0] != bodies[1] {
+			t.Errorf("Expected generated examples to be stable, got %s and %s", bodies[0], bodies[1])
+		}
+	})
+
+	t.Run("no response body", func(t *testing.T) {
+		op := NewSimple().DELETE("/users/{id}").SuccessCode(http.StatusNoContent).Handler(nil)
+		w := httptest.NewRecorder()
+		ExampleHandler(op).ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/users/1", nil))
+		if w.Code != http.StatusNoContent || w.Body.Len() != 0 {
+			t.Errorf("Expected an empty 204, got %d %q", w.Code, w.Body.String())
+		}
+	})
+}
+
+// TestSeedFromExamples tests that only operations without handlers are given example handlers
+func TestSeedFromExamples(t *testing.T) {
+	implemented := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	router := &recordingRegistrar{}
+	seeded := SeedFromExamples(router)
+
+	err := seeded.Register(
+		NewSimple().GET("/users").Handler(implemented),
+		NewSimple().GET("/orders").WithResponse(validators.String().Example("ok").Required()).Handler(nil),
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(router.ops) != 2 {
+		t.Fatalf("Expected 2 registered operations, got %d", len(router.ops))
+	}
+	if _, ok := router.ops[0].Handler.(http.HandlerFunc); !ok {
+		t.Errorf("Expected implemented handlers to be kept, got %T", router.ops[0].Handler)
+	}
+	handler, ok := router.ops[1].Handler.(http.Handler)
+	if !ok {
+		t.Fatalf("Expected an example handler, got %T", router.ops[1].Handler)
+	}
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/orders", nil))
+	if w.Body.String() != "\"ok\"\n" {
+		t.Errorf("Expected the string example, got %q", w.Body.String())
+	}
+}
@@ -0,0 +1,72 @@
+package operations
+
+import (
+	"net/http"
+	"strconv"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// documentFileResponse documents the success response of a download operation as a binary file,
+// with the partial content and unsatisfiable range responses of range requests
+func documentFileResponse(operation *OpenAPIOperation, successCode int, contentTypes []string) {
+	content := make(map[string]OpenAPIMediaType, len(contentTypes))
+	for _, contentType := range contentTypes {
+		content[contentType] = OpenAPIMediaType{Schema: &goop.OpenAPISchema{Type: "string", Format: "binary"}}
+	}
+	headers := map[string]OpenAPIHeader{
+		goop.ContentDispositionHeader: {
+			Description: "Whether to display the file inline or save it as an attachment, and its filename",
+			Schema:      &goop.OpenAPISchema{Type: "string"},
+		},
+		goop.AcceptRangesHeader: {
+			Description: "Unit of range requests supported for the file, or none",
+			Schema:      &goop.OpenAPISchema{Type: "string"},
+		},
+	}
+
+	if successCode == 0 {
+		successCode = http.StatusOK
+	}
+	code := strconv.Itoa(successCode)
+	response := operation.Responses[code]
+	if response.Description == "" || response.Description == "Successful response" {
+		response.Description = "File content"
+	}
+	response.Content = content
+	response.Headers = mergeHeaders(response.Headers, headers)
+	operation.Responses[code] = response
+
+	operation.Responses["206"] = OpenAPIResponse{
+		Description: "Requested range of the file",
+		Content:     content,
+		Headers: mergeHeaders(map[string]OpenAPIHeader{
+			goop.ContentRangeHeader: {
+				Description: "Position of the returned range within the file, such as bytes 0-1023/4096",
+				Schema:      &goop.OpenAPISchema{Type: "string"},
+			},
+		}, headers),
+	}
+	operation.Responses["416"] = OpenAPIResponse{
+		Description: "Requested range is outside the file",
+		Headers: map[string]OpenAPIHeader{
+			goop.ContentRangeHeader: {
+				Description: "Size of the file, such as bytes */4096",
+				Schema:      &goop.OpenAPISchema{Type: "string"},
+			},
+		},
+	}
+}
+
+// mergeHeaders returns the headers of both maps, preferring those already in into
+func mergeHeaders(into, from map[string]OpenAPIHeader) map[string]OpenAPIHeader {
+	if into == nil {
+		into = make(map[string]OpenAPIHeader, len(from))
+	}
+	for name, header := range from {
+		if _, exists := into[name]; !exists {
+			into[name] = header
+		}
+	}
+	return into
+}
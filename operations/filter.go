@@ -0,0 +1,363 @@
+package operations
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// OperationFilter selects the operations included in a partial specification
+// path is the operation's path or webhook name, and method is lower case as in OpenAPISpec.Paths
+type OperationFilter func(path, method string, op OpenAPIOperation) bool
+
+// FilterByTags keeps operations tagged with at least one of tags
+func FilterByTags(tags ...string) OperationFilter {
+	return func(path, method string, op OpenAPIOperation) bool {
+		return hasAnyTag(op.Tags, tags)
+	}
+}
+
+// ExcludeTags drops operations tagged with any of tags
+func ExcludeTags(tags ...string) OperationFilter {
+	return func(path, method string, op OpenAPIOperation) bool {
+		return !hasAnyTag(op.Tags, tags)
+	}
+}
+
+// FilterByAudience keeps operations documented for audience
+// Operations without a declared audience are visible to every audience
+func FilterByAudience(audience string) OperationFilter {
+	return func(path, method string, op OpenAPIOperation) bool {
+		audiences := operationAudiences(op)
+		if len(audiences) == 0 {
+			return true
+		}
+		for _, candidate := range audiences {
+			if candidate == audience {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// operationAudiences reads the audience extension, which is a string slice when generated
+// and a generic slice when decoded from a spec document
+func operationAudiences(op OpenAPIOperation) []string {
+	switch audiences := op.Extensions[AudienceExtension].(type) {
+	case []string:
+		return audiences
+	case []interface{}:
+		names := make([]string, 0, len(audiences))
+		for _, audience := range audiences {
+			if name, ok := audience.(string); ok {
+				names = append(names, name)
+			}
+		}
+		return names
+	}
+	return nil
+}
+
+// hasAnyTag reports whether opTags contains any of tags
+func hasAnyTag(opTags, tags []string) bool {
+	for _, opTag := range opTags {
+		for _, tag := range tags {
+			if opTag == tag {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Filtered returns a copy of the specification containing only operations accepted by
+// every filter. Paths and webhooks left without operations are dropped, and component
+// schemas, security schemes, and tags are pruned to those the remaining operations use,
+// so internal models are not published alongside public operations.
+func (g *OpenAPIGenerator) Filtered(filters ...OperationFilter) *OpenAPISpec {
+	return filterSpec(g.Spec, filters)
+}
+
+// FilteredForScopes returns the specification Filtered returns, as seen by callers holding scopes
+// Response and component schemas omit the fields VisibleToScopes limits to other scopes, and
+// components only those fields used are dropped, so each audience sees its own response schemas.
+func (g *OpenAPIGenerator) FilteredForScopes(scopes []string, filters ...OperationFilter) *OpenAPISpec {
+	return filterSpec(visibleSpec(g.Spec, scopes), filters)
+}
+
+// filterSpec returns a copy of source with the operations accepted by every filter and the components they use
+func filterSpec(source *OpenAPISpec, filters []OperationFilter) *OpenAPISpec {
+	spec := *source
+	accept := func(path, method string, op OpenAPIOperation) bool {
+		for _, filter := range filters {
+			if !filter(path, method, op) {
+				return false
+			}
+		}
+		return true
+	}
+
+	usage := newSpecUsage(source.Components)
+
+	spec.Paths = make(map[string]map[string]OpenAPIOperation)
+	for path, methods := range source.Paths {
+		kept := make(map[string]OpenAPIOperation)
+		for method, op := range methods {
+			if accept(path, method, op) {
+				kept[method] = op
+				usage.addOperation(&op)
+			}
+		}
+		if len(kept) > 0 {
+			spec.Paths[path] = kept
+		}
+	}
+
+	if source.Webhooks != nil {
+		spec.Webhooks = make(map[string]OpenAPIWebhook)
+		for name, webhook := range source.Webhooks {
+			kept := make(map[string]OpenAPIOperation)
+			for method, op := range webhook.Operations {
+				if accept(name, method, op) {
+					kept[method] = op
+					usage.addOperation(&op)
+				}
+			}
+			if len(kept) > 0 {
+				spec.Webhooks[name] = OpenAPIWebhook{Operations: kept}
+			}
+		}
+	}
+
+	for _, requirement := range source.Security {
+		usage.addSecurity(requirement)
+	}
+
+	if source.Components != nil {
+		components := *source.Components
+		usage.addComponents(&components)
+
+		components.Schemas = make(map[string]*goop.OpenAPISchema)
+		for name := range usage.schemas {
+			if schema, ok := source.Components.Schemas[name]; ok {
+				components.Schemas[name] = schema
+			}
+		}
+		components.SecuritySchemes = make(map[string]goop.SecuritySchemeObject)
+		for name, scheme := range source.Components.SecuritySchemes {
+			if usage.securitySchemes[name] {
+				components.SecuritySchemes[name] = scheme
+			}
+		}
+		spec.Components = &components
+	}
+
+	spec.Tags = nil
+	for _, tag := range source.Tags {
+		if usage.tags[tag.Name] {
+			spec.Tags = append(spec.Tags, tag)
+		}
+	}
+
+	return &spec
+}
+
+// WriteFiltered writes the specification containing only operations accepted by every filter
+func (g *OpenAPIGenerator) WriteFiltered(w io.Writer, filters ...OperationFilter) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(g.Filtered(filters...)); err != nil {
+		return fmt.Errorf("failed to encode OpenAPI spec: %w", err)
+	}
+	return nil
+}
+
+// visibleSpec returns a copy of source whose response and component schemas omit the fields scopes cannot see
+func visibleSpec(source *OpenAPISpec, scopes []string) *OpenAPISpec {
+	spec := *source
+	visibleOperation := func(op OpenAPIOperation) OpenAPIOperation {
+		responses := make(map[string]OpenAPIResponse, len(op.Responses))
+		for status, response := range op.Responses {
+			response.Content = visibleContent(response.Content, scopes)
+			responses[status] = response
+		}
+		op.Responses = responses
+		return op
+	}
+
+	spec.Paths = make(map[string]map[string]OpenAPIOperation, len(source.Paths))
+	for path, methods := range source.Paths {
+		visible := make(map[string]OpenAPIOperation, len(methods))
+		for method, op := range methods {
+			visible[method] = visibleOperation(op)
+		}
+		spec.Paths[path] = visible
+	}
+	if source.Webhooks != nil {
+		spec.Webhooks = make(map[string]OpenAPIWebhook, len(source.Webhooks))
+		for name, webhook := range source.Webhooks {
+			visible := make(map[string]OpenAPIOperation, len(webhook.Operations))
+			for method, op := range webhook.Operations {
+				visible[method] = visibleOperation(op)
+			}
+			spec.Webhooks[name] = OpenAPIWebhook{Operations: visible}
+		}
+	}
+
+	if source.Components != nil {
+		components := *source.Components
+		components.Schemas = make(map[string]*goop.OpenAPISchema, len(source.Components.Schemas))
+		for name, schema := range source.Components.Schemas {
+			components.Schemas[name] = goop.VisibleSchema(schema, scopes)
+		}
+		if source.Components.Responses != nil {
+			components.Responses = make(map[string]OpenAPIResponse, len(source.Components.Responses))
+			for name, response := range source.Components.Responses {
+				response.Content = visibleContent(response.Content, scopes)
+				components.Responses[name] = response
+			}
+		}
+		spec.Components = &components
+	}
+	return &spec
+}
+
+// visibleContent copies content with schemas omitting the fields scopes cannot see
+func visibleContent(content map[string]OpenAPIMediaType, scopes []string) map[string]OpenAPIMediaType {
+	if content == nil {
+		return nil
+	}
+	visible := make(map[string]OpenAPIMediaType, len(content))
+	for mediaType, media := range content {
+		media.Schema = goop.VisibleSchema(media.Schema, scopes)
+		visible[mediaType] = media
+	}
+	return visible
+}
+
+// specUsage collects the components and tags used by the operations of a partial specification
+type specUsage struct {
+	components      *OpenAPIComponents
+	schemas         map[string]bool
+	securitySchemes map[string]bool
+	tags            map[string]bool
+}
+
+func newSpecUsage(components *OpenAPIComponents) *specUsage {
+	return &specUsage{
+		components:      components,
+		schemas:         make(map[string]bool),
+		securitySchemes: make(map[string]bool),
+		tags:            make(map[string]bool),
+	}
+}
+
+// addOperation records everything op references, including its callbacks
+func (u *specUsage) addOperation(op *OpenAPIOperation) {
+	for _, tag := range op.Tags {
+		u.tags[tag] = true
+	}
+	for _, requirement := range op.Security {
+		u.addSecurity(requirement)
+	}
+	for _, param := range op.Parameters {
+		u.addParameter(param)
+	}
+	if op.RequestBody != nil {
+		u.addContent(op.RequestBody.Content)
+	}
+	for _, response := range op.Responses {
+		u.addResponse(response)
+	}
+	for _, callback := range op.Callbacks {
+		for _, item := range callback {
+			for _, callbackOp := range []*OpenAPIOperation{item.Get, item.Put, item.Post, item.Delete, item.Options, item.Head, item.Patch, item.Trace} {
+				if callbackOp != nil {
+					u.addOperation(callbackOp)
+				}
+			}
+			for _, param := range item.Parameters {
+				u.addParameter(param)
+			}
+		}
+	}
+}
+
+// addComponents records schemas referenced from the retained non-schema components
+func (u *specUsage) addComponents(components *OpenAPIComponents) {
+	for _, response := range components.Responses {
+		u.addResponse(response)
+	}
+	for _, param := range components.Parameters {
+		u.addParameter(param)
+	}
+	for _, body := range components.RequestBodies {
+		u.addContent(body.Content)
+	}
+	for _, header := range components.Headers {
+		u.addSchema(header.Schema)
+	}
+}
+
+func (u *specUsage) addSecurity(requirement goop.SecurityRequirement) {
+	for name := range requirement {
+		u.securitySchemes[name] = true
+	}
+}
+
+func (u *specUsage) addParameter(param OpenAPIParameter) {
+	u.addSchema(param.Schema)
+	u.addContent(param.Content)
+}
+
+func (u *specUsage) addResponse(response OpenAPIResponse) {
+	u.addContent(response.Content)
+	for _, header := range response.Headers {
+		u.addSchema(header.Schema)
+	}
+}
+
+func (u *specUsage) addContent(content map[string]OpenAPIMediaType) {
+	for _, mediaType := range content {
+		u.addSchema(mediaType.Schema)
+	}
+}
+
+// addSchema records the component schemas referenced from schema, following references transitively
+func (u *specUsage) addSchema(schema *goop.OpenAPISchema) {
+	if schema == nil {
+		return
+	}
+
+	if strings.HasPrefix(schema.Ref, componentSchemaPrefix) {
+		name := strings.TrimPrefix(schema.Ref, componentSchemaPrefix)
+		if !u.schemas[name] {
+			u.schemas[name] = true
+			if u.components != nil {
+				u.addSchema(u.components.Schemas[name])
+			}
+		}
+	}
+
+	for _, property := range schema.Properties {
+		u.addSchema(property)
+	}
+	u.addSchema(schema.Items)
+	u.addSchema(schema.Contains)
+	u.addSchema(schema.Not)
+	u.addSchema(schema.If)
+	u.addSchema(schema.Then)
+	u.addSchema(schema.Else)
+	for _, composed := range [][]*goop.OpenAPISchema{schema.PrefixItems, schema.AllOf, schema.OneOf, schema.AnyOf} {
+		for _, sub := range composed {
+			u.addSchema(sub)
+		}
+	}
+	if schema.AdditionalProperties != nil {
+		u.addSchema(schema.AdditionalProperties.Schema)
+	}
+}
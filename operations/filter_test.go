@@ -0,0 +1,257 @@
+package operations
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	goop "github.com/picogrid/go-op"
+	"github.com/picogrid/go-op/validators"
+)
+
+func TestFilteredSpec(t *testing.T) {
+	generator := NewOpenAPIGenerator("Users", "1.0.0")
+	generator.AddTag(OpenAPITag{Name: "public"})
+	generator.AddTag(OpenAPITag{Name: "admin"})
+	if err := generator.AddSecurityScheme("bearerAuth", goop.NewBearerAuth("JWT", "")); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := generator.AddSecurityScheme("adminKey", goop.NewAPIKeyHeader("X-Admin-Key", "")); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	router := NewRouter(generator)
+
+	address := Component("FilterAddress", validators.Object(map[string]interface{}{
+		"city": validators.String().Required(),
+	}).Required())
+	publicUser := Component("FilterPublicUser", validators.Object(map[string]interface{}{
+		"id":      validators.String().Required(),
+		"address": address,
+	}).Required())
+	auditLog := Component("FilterAuditLog", validators.Object(map[string]interface{}{
+		"entries": validators.Array(validators.String()).Required(),
+	}).Required())
+
+	getUser := NewSimple().
+		GET("/users/{id}").
+		Tags("public").
+		RequireBearer("bearerAuth").
+		WithResponse(publicUser).
+		Handler(nil)
+	getAudit := NewSimple().
+		GET("/admin/audit").
+		Tags("admin").
+		RequireAPIKey("adminKey").
+		WithResponse(auditLog).
+		Handler(nil)
+	deleteUser := NewSimple().
+		DELETE("/users/{id}").
+		Tags("public", "admin").
+		Handler(nil)
+
+	for _, op := range []CompiledOperation{getUser, getAudit, deleteUser} {
+		if err := router.Register(op); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	t.Run("Keeps only matching operations", func(t *testing.T) {
+		spec := generator.Filtered(FilterByTags("public"))
+		if _, ok := spec.Paths["/admin/audit"]; ok {
+			t.Error("Expected admin path to be dropped")
+		}
+		if len(spec.Paths["/users/{id}"]) != 2 {
+			t.Errorf("Expected GET and DELETE /users/{id}, got %v", spec.Paths["/users/{id}"])
+		}
+		if len(generator.Spec.Paths) != 2 {
+			t.Error("Expected the full spec to be unchanged")
+		}
+	})
+
+	t.Run("Prunes unused components and tags", func(t *testing.T) {
+		spec := generator.Filtered(FilterByTags("public"), ExcludeTags("admin"))
+		if len(spec.Paths["/users/{id}"]) != 1 {
+			t.Errorf("Expected only GET /users/{id}, got %v", spec.Paths["/users/{id}"])
+		}
+		for _, name := range []string{"FilterPublicUser", "FilterAddress"} {
+			if _, ok := spec.Components.Schemas[name]; !ok {
+				t.Errorf("Expected schema %s to be kept", name)
+			}
+		}
+		if _, ok := spec.Components.Schemas["FilterAuditLog"]; ok {
+			t.Error("Expected admin-only schema to be pruned")
+		}
+		if _, ok := spec.Components.SecuritySchemes["adminKey"]; ok {
+			t.Error("Expected admin-only security scheme to be pruned")
+		}
+		if _, ok := spec.Components.SecuritySchemes["bearerAuth"]; !ok {
+			t.Error("Expected bearerAuth security scheme to be kept")
+		}
+		if len(spec.Tags) != 1 || spec.Tags[0].Name != "public" {
+			t.Errorf("Expected only the public tag, got %v", spec.Tags)
+		}
+		if _, ok := generator.Spec.Components.Schemas["FilterAuditLog"]; !ok {
+			t.Error("Expected the full spec to keep all schemas")
+		}
+	})
+
+	t.Run("Writes the filtered spec", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := generator.WriteFiltered(&buf, FilterByTags("admin")); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		var spec OpenAPISpec
+		if err := json.Unmarshal(buf.Bytes(), &spec); err != nil {
+			t.Fatalf("Expected valid JSON, got %v", err)
+		}
+		if _, ok := spec.Paths["/admin/audit"]; !ok {
+			t.Error("Expected admin path in the filtered spec")
+		}
+		if _, ok := spec.Components.Schemas["FilterPublicUser"]; ok {
+			t.Error("Expected public-only schema to be pruned")
+		}
+	})
+}
+
+func TestFilterByAudience(t *testing.T) {
+	generator := NewOpenAPIGenerator("Users", "1.0.0")
+	if err := generator.AddSecurityScheme("partnerKey", goop.NewAPIKeyHeader("X-Partner-Key", "")); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := generator.AddSecurityScheme("staffAuth", goop.NewBearerAuth("JWT", "")); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	router := NewRouter(generator)
+
+	listUsers := NewSimple().GET("/users").Handler(nil)
+	listReports := NewSimple().
+		GET("/reports").
+		Audience("internal", "partner").
+		RequireAPIKey("partnerKey").
+		Handler(nil)
+	auditLog := NewSimple().
+		GET("/admin/audit").
+		Audience("internal").
+		RequireBearer("staffAuth").
+		Handler(nil)
+
+	for _, op := range []CompiledOperation{listUsers, listReports, auditLog} {
+		if err := router.Register(op); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	t.Run("Documents declared audiences", func(t *testing.T) {
+		op := generator.Spec.Paths["/reports"]["get"]
+		audiences, ok := op.Extensions[AudienceExtension].([]string)
+		if !ok || len(audiences) != 2 {
+			t.Errorf("Expected x-audience extension, got %v", op.Extensions[AudienceExtension])
+		}
+		if _, ok := generator.Spec.Paths["/users"]["get"].Extensions[AudienceExtension]; ok {
+			t.Error("Expected no x-audience extension on an operation without audiences")
+		}
+	})
+
+	t.Run("Partner spec", func(t *testing.T) {
+		spec := generator.Filtered(FilterByAudience("partner"))
+		if _, ok := spec.Paths["/users"]; !ok {
+			t.Error("Expected operation without an audience to be included")
+		}
+		if _, ok := spec.Paths["/reports"]; !ok {
+			t.Error("Expected partner operation to be included")
+		}
+		if _, ok := spec.Paths["/admin/audit"]; ok {
+			t.Error("Expected internal-only operation to be excluded")
+		}
+		if _, ok := spec.Components.SecuritySchemes["staffAuth"]; ok {
+			t.Error("Expected internal security scheme to be pruned")
+		}
+		if _, ok := spec.Components.SecuritySchemes["partnerKey"]; !ok {
+			t.Error("Expected partner security scheme to be kept")
+		}
+	})
+
+	t.Run("Internal spec", func(t *testing.T) {
+		spec := generator.Filtered(FilterByAudience("internal"))
+		if len(spec.Paths) != 3 {
+			t.Errorf("Expected all 3 paths, got %d", len(spec.Paths))
+		}
+	})
+
+	t.Run("Decoded spec", func(t *testing.T) {
+		op := OpenAPIOperation{Extensions: map[string]interface{}{AudienceExtension: []interface{}{"partner"}}}
+		if !FilterByAudience("partner")("/reports", "get", op) {
+			t.Error("Expected decoded audience to match")
+		}
+		if FilterByAudience("public")("/reports", "get", op) {
+			t.Error("Expected decoded audience not to match another audience")
+		}
+	})
+
+	t.Run("Serves the audience spec", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		generator.SpecHandler(FilterByAudience("partner")).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("Expected 200, got %d", recorder.Code)
+		}
+		if contentType := recorder.Header().Get("Content-Type"); contentType != "application/json" {
+			t.Errorf("Expected application/json, got %s", contentType)
+		}
+		var spec OpenAPISpec
+		if err := json.Unmarshal(recorder.Body.Bytes(), &spec); err != nil {
+			t.Fatalf("Expected valid JSON, got %v", err)
+		}
+		if _, ok := spec.Paths["/admin/audit"]; ok {
+			t.Error("Expected internal-only operation to be excluded")
+		}
+	})
+}
+
+func TestFilteredForScopes(t *testing.T) {
+	generator := NewOpenAPIGenerator("Employees", "1.0.0")
+	router := NewRouter(generator)
+
+	employee := Component("ScopedEmployee", validators.Object(map[string]interface{}{
+		"name":   validators.String().Required(),
+		"salary": validators.Number().VisibleToScopes("admin").Required(),
+	}).Required())
+	listEmployees := NewSimple().
+		GET("/employees").
+		WithResponse(validators.Array(employee).Required()).
+		Handler(nil)
+	if err := router.Register(listEmployees); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	t.Run("Removes fields hidden from the scopes", func(t *testing.T) {
+		spec := generator.FilteredForScopes([]string{"user"})
+		schema := spec.Components.Schemas["ScopedEmployee"]
+		if _, ok := schema.Properties["salary"]; ok {
+			t.Error("Expected the admin-only field to be removed")
+		}
+		for _, name := range schema.Required {
+			if name == "salary" {
+				t.Error("Expected the removed field not to be required")
+			}
+		}
+		if _, ok := generator.Spec.Components.Schemas["ScopedEmployee"].Properties["salary"]; !ok {
+			t.Error("Expected the full spec to be left unchanged")
+		}
+	})
+
+	t.Run("Keeps fields visible to the scopes", func(t *testing.T) {
+		spec := generator.FilteredForScopes([]string{"admin"})
+		if _, ok := spec.Components.Schemas["ScopedEmployee"].Properties["salary"]; !ok {
+			t.Error("Expected the admin-only field to be kept")
+		}
+	})
+
+	t.Run("Applies operation filters", func(t *testing.T) {
+		spec := generator.FilteredForScopes(nil, FilterByTags("admin"))
+		if len(spec.Paths) != 0 {
+			t.Errorf("Expected no paths, got %d", len(spec.Paths))
+		}
+	})
+}
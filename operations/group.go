@@ -0,0 +1,150 @@
+package operations
+
+import (
+	"strings"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// Group holds defaults shared by a set of operations
+// Operations passed through a group inherit its path prefix, tags, security requirements, audiences, and error responses
+type Group struct {
+	prefix    string
+	tags      []string
+	security  goop.SecurityRequirements
+	audiences []string
+	errors    map[int]ResponseDefinition
+}
+
+// GroupOption configures a Group
+type GroupOption func(*Group)
+
+// NewGroup creates an operation group rooted at the given path prefix
+func NewGroup(prefix string, opts ...GroupOption) *Group {
+	g := &Group{
+		prefix: strings.TrimSuffix(prefix, "/"),
+		errors: make(map[int]ResponseDefinition),
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+// WithTags adds tags to every operation in the group
+func WithTags(tags ...string) GroupOption {
+	return func(g *Group) {
+		g.tags = append(g.tags, tags...)
+	}
+}
+
+// WithSecurity sets the default security requirements for operations in the group
+// Operations that declare their own security (including NoAuth) keep it
+func WithSecurity(requirements goop.SecurityRequirements) GroupOption {
+	return func(g *Group) {
+		g.security = requirements
+	}
+}
+
+// WithAudience restricts the documented visibility of operations in the group to the given audiences
+// Operations that declare their own audience keep it
+func WithAudience(audiences ...string) GroupOption {
+	return func(g *Group) {
+		g.audiences = append(g.audiences, audiences...)
+	}
+}
+
+// WithErrors adds standard error responses for the given status codes to every operation in the group
+func WithErrors(codes ...int) GroupOption {
+	return func(g *Group) {
+		for _, code := range codes {
+			g.errors[code] = ResponseDefinition{
+				Schema:      GetStandardErrorSchema(code),
+				Description: getStandardErrorDescription(code),
+			}
+		}
+	}
+}
+
+// WithErrorResponse adds a custom error response to every operation in the group
+func WithErrorResponse(code int, schema goop.Schema, description string) GroupOption {
+	return func(g *Group) {
+		g.errors[code] = ResponseDefinition{
+			Schema:      schema,
+			Description: description,
+		}
+	}
+}
+
+// Prefix returns the group's path prefix
+func (g *Group) Prefix() string {
+	return g.prefix
+}
+
+// Apply returns a copy of op with the group defaults applied
+// Operation-level settings take precedence over group defaults
+func (g *Group) Apply(op CompiledOperation) CompiledOperation {
+	op.Path = joinPath(g.prefix, op.Path)
+
+	if len(g.tags) > 0 {
+		tags := make([]string, 0, len(g.tags)+len(op.Tags))
+		seen := make(map[string]bool, len(g.tags)+len(op.Tags))
+		for _, tag := range append(append([]string{}, g.tags...), op.Tags...) {
+			if !seen[tag] {
+				seen[tag] = true
+				tags = append(tags, tag)
+			}
+		}
+		op.Tags = tags
+	}
+
+	if op.Security == nil && g.security != nil {
+		op.Security = g.security
+	}
+
+	if len(op.Audiences) == 0 && len(g.audiences) > 0 {
+		op.Audiences = g.audiences
+	}
+
+	if len(g.errors) > 0 {
+		responses := make(map[int]goop.ResponseDefinition, len(op.Responses)+len(g.errors))
+		for code, response := range op.Responses {
+			responses[code] = response
+		}
+		for code, response := range g.errors {
+			if _, exists := responses[code]; !exists {
+				responses[code] = goop.ResponseDefinition{
+					Schema:      response.Schema,
+					Description: response.Description,
+					Headers:     response.Headers,
+					ContentType: response.ContentType,
+					Ref:         response.Ref,
+				}
+			}
+		}
+		op.Responses = responses
+	}
+
+	return op
+}
+
+// Operations applies the group defaults to each operation
+// The result can be passed directly to a router's Register method
+func (g *Group) Operations(ops ...CompiledOperation) []CompiledOperation {
+	grouped := make([]CompiledOperation, len(ops))
+	for i, op := range ops {
+		grouped[i] = g.Apply(op)
+	}
+	return grouped
+}
+
+// joinPath joins a group prefix and an operation path with a single slash
+func joinPath(prefix, path string) string {
+	if prefix == "" {
+		return path
+	}
+	if path == "" || path == "/" {
+		return prefix
+	}
+	return prefix + "/" + strings.TrimPrefix(path, "/")
+}
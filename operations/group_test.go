@@ -0,0 +1,115 @@
+package operations
+
+import (
+	"reflect"
+	"testing"
+
+	goop "github.com/picogrid/go-op"
+)
+
+func TestGroup(t *testing.T) {
+	bearer := goop.SecurityRequirements{}.RequireScheme("bearerAuth")
+
+	group := NewGroup("/v2/",
+		WithTags("users"),
+		WithSecurity(bearer),
+		WithErrors(401, 500),
+	)
+
+	t.Run("Applies path prefix", func(t *testing.T) {
+		cases := map[string]string{
+			"/users":      "/v2/users",
+			"users/{id}":  "/v2/users/{id}",
+			"/":           "/v2",
+			"/users/{id}": "/v2/users/{id}",
+		}
+		for path, expected := range cases {
+			op := group.Apply(NewSimple().GET(path).Handler(nil))
+			if op.Path != expected {
+				t.Errorf("Expected path '%s' for '%s', got '%s'", expected, path, op.Path)
+			}
+		}
+	})
+
+	t.Run("Merges tags without duplicates", func(t *testing.T) {
+		op := group.Apply(NewSimple().GET("/users").Tags("users", "admin").Handler(nil))
+		expected := []string{"users", "admin"}
+		if !reflect.DeepEqual(op.Tags, expected) {
+			t.Errorf("Expected tags %v, got %v", expected, op.Tags)
+		}
+	})
+
+	t.Run("Inherits security unless overridden", func(t *testing.T) {
+		inherited := group.Apply(NewSimple().GET("/users").Handler(nil))
+		if !reflect.DeepEqual(inherited.Security, bearer) {
+			t.Errorf("Expected group security, got %v", inherited.Security)
+		}
+
+		public := group.Apply(NewSimple().GET("/health").NoAuth().Handler(nil))
+		if !reflect.DeepEqual(public.Security, goop.NoAuth()) {
+			t.Errorf("Expected NoAuth to be preserved, got %v", public.Security)
+		}
+	})
+
+	t.Run("Inherits audience unless overridden", func(t *testing.T) {
+		partnerGroup := NewGroup("/partner", WithAudience("partner"))
+
+		inherited := partnerGroup.Apply(NewSimple().GET("/reports").Handler(nil))
+		if !reflect.DeepEqual(inherited.Audiences, []string{"partner"}) {
+			t.Errorf("Expected group audience, got %v", inherited.Audiences)
+		}
+
+		internal := partnerGroup.Apply(NewSimple().GET("/audit").Audience("internal").Handler(nil))
+		if !reflect.DeepEqual(internal.Audiences, []string{"internal"}) {
+			t.Errorf("Expected operation audience to be preserved, got %v", internal.Audiences)
+		}
+	})
+
+	t.Run("Adds error responses without overriding operation responses", func(t *testing.T) {
+		custom := NewSimple().GET("/users").
+			WithUnauthorizedError(ValidationErrorSchema).
+			Handler(nil)
+		op := group.Apply(custom)
+
+		if _, ok := op.Responses[500]; !ok {
+			t.Error("Expected group 500 response to be added")
+		}
+		if op.Responses[401].Schema != ValidationErrorSchema {
+			t.Error("Expected operation 401 response to take precedence")
+		}
+		if _, ok := custom.Responses[500]; ok {
+			t.Error("Expected original operation to be left unchanged")
+		}
+	})
+
+	t.Run("Registers grouped operations with generators", func(t *testing.T) {
+		gen := NewOpenAPIGenerator("Test API", "1.0.0")
+		router := NewRouter(gen)
+
+		err := router.RegisterGroup(group,
+			NewSimple().GET("/users").Handler(nil),
+			NewSimple().POST("/users").Handler(nil),
+		)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		pathItem, ok := gen.Spec.Paths["/v2/users"]
+		if !ok {
+			t.Fatal("Expected /v2/users path in spec")
+		}
+		for _, method := range []string{"get", "post"} {
+			operation, ok := pathItem[method]
+			if !ok {
+				t.Errorf("Expected %s operation", method)
+				continue
+			}
+			if len(operation.Tags) != 1 || operation.Tags[0] != "users" {
+				t.Errorf("Expected tags [users], got %v", operation.Tags)
+			}
+			if _, ok := operation.Responses["401"]; !ok {
+				t.Error("Expected 401 response in spec")
+			}
+		}
+	})
+}
@@ -0,0 +1,204 @@
+package operations
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	goop "github.com/picogrid/go-op"
+	"github.com/picogrid/go-op/validators"
+)
+
+// Health statuses reported by health check operations
+const (
+	HealthStatusUp   = "up"
+	HealthStatusDown = "down"
+)
+
+// HealthCheckFunc checks a dependency, returning an error when it cannot serve requests
+type HealthCheckFunc func(ctx context.Context) error
+
+// HealthCheckOptions configures the operations created by HealthCheck
+type HealthCheckOptions struct {
+	// LivenessPath serves the liveness check (default "/health")
+	LivenessPath string
+
+	// ReadinessPath serves the readiness check (default "/ready")
+	ReadinessPath string
+
+	// Checks are the dependencies that must be up for the service to be ready, keyed by name
+	Checks map[string]HealthCheckFunc
+
+	// Timeout bounds each dependency check (default 5 seconds)
+	Timeout time.Duration
+
+	// Tags documented for the operations (default "health")
+	Tags []string
+}
+
+// HealthStatus is the response body of health check operations
+type HealthStatus struct {
+	Status string                       `json:"status"`
+	Checks map[string]HealthCheckResult `json:"checks,omitempty"`
+}
+
+// HealthCheckResult reports the outcome of a dependency check
+type HealthCheckResult struct {
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+	DurationMs int64  `json:"durationMs"`
+}
+
+// HealthCheck creates liveness and readiness operations to register with a router
+// The liveness operation reports up while the process can serve requests. The readiness operation
+// runs the dependency checks concurrently and responds 503 when any of them fails or times out.
+// Both are public, documented with their response schema, and served by plain http.Handlers.
+//
+// Example:
+//
+//	router.Register(operations.HealthCheck(operations.HealthCheckOptions{
+//	    Checks: map[string]operations.HealthCheckFunc{
+//	        "database": db.PingContext,
+//	    },
+//	})...)
+func HealthCheck(opts HealthCheckOptions) []CompiledOperation {
+	if opts.LivenessPath == "" {
+		opts.LivenessPath = "/health"
+	}
+	if opts.ReadinessPath == "" {
+		opts.ReadinessPath = "/ready"
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 5 * time.Second
+	}
+	if len(opts.Tags) == 0 {
+		opts.Tags = []string{"health"}
+	}
+
+	liveness := NewSimple().
+		GET(opts.LivenessPath).
+		OperationID("liveness").
+		Summary("Liveness check").
+		Description("Reports whether the service process is running").
+		Tags(opts.Tags...).
+		NoAuth().
+		WithoutTenant().
+		Idempotency(goop.Idempotent).
+		WithoutDefaultResponses(http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden).
+		WithSuccessResponse(http.StatusOK, healthStatusSchema(nil), "Service is running").
+		Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			writeHealthStatus(w, http.StatusOK, HealthStatus{Status: HealthStatusUp})
+		}))
+
+	names := make([]string, 0, len(opts.Checks))
+	for name := range opts.Checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	readiness := NewSimple().
+		GET(opts.ReadinessPath).
+		OperationID("readiness").
+		Summary("Readiness check").
+		Description("Reports whether the service and its dependencies can serve requests").
+		Tags(opts.Tags...).
+		NoAuth().
+		WithoutTenant().
+		Idempotency(goop.Idempotent).
+		WithoutDefaultResponses(http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden).
+		WithSuccessResponse(http.StatusOK, healthStatusSchema(names), "Service is ready").
+		WithErrorResponse(http.StatusServiceUnavailable, healthStatusSchema(names), "A dependency check failed").
+		Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			status := runHealthChecks(r.Context(), opts.Checks, opts.Timeout)
+			code := http.StatusOK
+			if status.Status != HealthStatusUp {
+				code = http.StatusServiceUnavailable
+			}
+			writeHealthStatus(w, code, status)
+		}))
+
+	return []CompiledOperation{liveness, readiness}
+}
+
+// runHealthChecks runs the checks concurrently, each bounded by timeout
+func runHealthChecks(ctx context.Context, checks map[string]HealthCheckFunc, timeout time.Duration) HealthStatus {
+	status := HealthStatus{Status: HealthStatusUp}
+	if len(checks) == 0 {
+		return status
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	status.Checks = make(map[string]HealthCheckResult, len(checks))
+	for name, check := range checks {
+		wg.Add(1)
+		go func(name string, check HealthCheckFunc) {
+			defer wg.Done()
+			result := runHealthCheck(ctx, check, timeout)
+
+			mu.Lock()
+			defer mu.Unlock()
+			status.Checks[name] = result
+			if result.Status != HealthStatusUp {
+				status.Status = HealthStatusDown
+			}
+		}(name, check)
+	}
+	wg.Wait()
+
+	return status
+}
+
+// runHealthCheck runs a single check, reporting it down when it does not return within timeout
+func runHealthCheck(ctx context.Context, check HealthCheckFunc, timeout time.Duration) HealthCheckResult {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() { done <- check(ctx) }()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	result := HealthCheckResult{Status: HealthStatusUp, DurationMs: time.Since(start).Milliseconds()}
+	if err != nil {
+		result.Status = HealthStatusDown
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// writeHealthStatus writes status as an uncached JSON response
+func writeHealthStatus(w http.ResponseWriter, code int, status HealthStatus) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(status)
+}
+
+// healthStatusSchema documents a health response reporting the named dependency checks
+func healthStatusSchema(checks []string) goop.Schema {
+	fields := map[string]interface{}{
+		"status": validators.String().Example(HealthStatusUp).Required(),
+	}
+	if len(checks) > 0 {
+		results := make(map[string]interface{}, len(checks))
+		for _, name := range checks {
+			results[name] = validators.Object(map[string]interface{}{
+				"status":     validators.String().Example(HealthStatusUp).Required(),
+				"error":      validators.String().Optional(),
+				"durationMs": validators.Number().Integer().Min(0).Required(),
+			}).Required()
+		}
+		fields["checks"] = validators.Object(results).Optional()
+	}
+	return validators.Object(fields).Required()
+}
@@ -0,0 +1,122 @@
+package operations
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestHealthCheck tests liveness and readiness operations
+func TestHealthCheck(t *testing.T) {
+	serve := func(t *testing.T, op CompiledOperation) (int, HealthStatus) {
+		t.Helper()
+		handler, ok := op.Handler.(http.Handler)
+		if !ok {
+			t.Fatalf("Expected an http.Handler, got %T", op.Handler)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(op.Method, op.Path, nil))
+
+		var status HealthStatus
+		if err := json.Unmarshal(w.Body.Bytes(), &status); err != nil {
+			t.Fatalf("Failed to decode health status: %v", err)
+		}
+		if err := op.Responses[w.Code].Schema.Validate(toMap(t, status)); err != nil {
+			t.Errorf("Expected response to match the documented schema, got: %v", err)
+		}
+		return w.Code, status
+	}
+
+	t.Run("Liveness reports up", func(t *testing.T) {
+		ops := HealthCheck(HealthCheckOptions{})
+		if ops[0].Path != "/health" || ops[1].Path != "/ready" {
+			t.Fatalf("Expected default paths, got %s and %s", ops[0].Path, ops[1].Path)
+		}
+		code, status := serve(t, ops[0])
+		if code != http.StatusOK || status.Status != HealthStatusUp {
+			t.Errorf("Expected 200 up, got %d %s", code, status.Status)
+		}
+	})
+
+	t.Run("Readiness reports each check", func(t *testing.T) {
+		ops := HealthCheck(HealthCheckOptions{
+			ReadinessPath: "/readyz",
+			Checks: map[string]HealthCheckFunc{
+				"database": func(ctx context.Context) error { return nil },
+				"cache":    func(ctx context.Context) error { return errors.New("connection refused") },
+			},
+		})
+		code, status := serve(t, ops[1])
+		if code != http.StatusServiceUnavailable || status.Status != HealthStatusDown {
+			t.Errorf("Expected 503 down, got %d %s", code, status.Status)
+		}
+		if status.Checks["database"].Status != HealthStatusUp {
+			t.Errorf("Expected database to be up, got %+v", status.Checks["database"])
+		}
+		if cache := status.Checks["cache"]; cache.Status != HealthStatusDown || cache.Error != "connection refused" {
+			t.Errorf("Expected cache to be down, got %+v", cache)
+		}
+	})
+
+	t.Run("Slow checks time out", func(t *testing.T) {
+		ops := HealthCheck(HealthCheckOptions{
+			Timeout: 10 * time.Millisecond,
+			Checks: map[string]HealthCheckFunc{
+				"queue": func(ctx context.Context) error {
+					<-ctx.Done()
+					return ctx.Err()
+				},
+			},
+		})
+		code, status := serve(t, ops[1])
+		if code != http.StatusServiceUnavailable || status.Checks["queue"].Error != context.DeadlineExceeded.Error() {
+			t.Errorf("Expected queue check to time out, got %d %+v", code, status.Checks["queue"])
+		}
+	})
+
+	t.Run("Operations are documented", func(t *testing.T) {
+		generator := NewOpenAPIGenerator("Test API", "1.0.0")
+		ops := HealthCheck(HealthCheckOptions{Checks: map[string]HealthCheckFunc{
+			"database": func(ctx context.Context) error { return nil },
+		}})
+		router := NewRouter(generator)
+		for _, op := range ops {
+			if err := router.Register(op); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+		}
+
+		readiness := generator.Spec.Paths["/ready"]["get"]
+		if readiness.OperationId != "readiness" {
+			t.Errorf("Expected readiness operation ID, got %q", readiness.OperationId)
+		}
+		if _, exists := readiness.Responses["503"]; !exists {
+			t.Error("Expected 503 response to be documented")
+		}
+		checks := readiness.Responses["200"].Content["application/json"].Schema.Properties["checks"]
+		if checks == nil || checks.Properties["database"] == nil {
+			t.Errorf("Expected database check to be documented, got %+v", checks)
+		}
+		if _, exists := generator.Spec.Paths["/health"]["get"]; !exists {
+			t.Error("Expected liveness operation to be documented")
+		}
+	})
+}
+
+// toMap converts a response body to the map form validated by object schemas
+func toMap(t *testing.T, v interface{}) map[string]interface{} {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("Failed to encode: %v", err)
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		t.Fatalf("Failed to decode: %v", err)
+	}
+	return m
+}
@@ -0,0 +1,90 @@
+package operations
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// MemoryIdempotencyStore is an in-process IdempotencyStore for single-instance deployments and tests
+// Expired entries are evicted as new keys arrive, at most once per TTL.
+// Use a shared store such as Redis when running multiple replicas
+type MemoryIdempotencyStore struct {
+	mu        sync.Mutex
+	ttl       time.Duration
+	entries   map[string]*memoryIdempotencyEntry
+	now       func() time.Time
+	nextSweep time.Time
+}
+
+type memoryIdempotencyEntry struct {
+	record    *goop.IdempotencyRecord // nil while the request is in progress
+	expiresAt time.Time
+}
+
+// NewMemoryIdempotencyStore creates an in-memory store that keeps responses for ttl
+func NewMemoryIdempotencyStore(ttl time.Duration) *MemoryIdempotencyStore {
+	return &MemoryIdempotencyStore{
+		ttl:     ttl,
+		entries: make(map[string]*memoryIdempotencyEntry),
+		now:     time.Now,
+	}
+}
+
+// Reserve claims key for processing or returns the stored record for a completed key
+func (s *MemoryIdempotencyStore) Reserve(ctx context.Context, key string) (*goop.IdempotencyRecord, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.prune(now)
+	if entry, ok := s.entries[key]; ok && now.Before(entry.expiresAt) {
+		if entry.record != nil {
+			record := *entry.record
+			return &record, false, nil
+		}
+		return nil, false, nil
+	}
+
+	s.entries[key] = &memoryIdempotencyEntry{expiresAt: now.Add(s.ttl)}
+	return nil, true, nil
+}
+
+// Complete stores the response for a reserved key
+func (s *MemoryIdempotencyStore) Complete(ctx context.Context, key string, record goop.IdempotencyRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries[key] = &memoryIdempotencyEntry{
+		record:    &record,
+		expiresAt: s.now().Add(s.ttl),
+	}
+	return nil
+}
+
+// Release drops a reservation so the request can be retried
+func (s *MemoryIdempotencyStore) Release(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entries, key)
+	return nil
+}
+
+// prune evicts expired entries, sweeping the map at most once per TTL so reservations stay cheap
+func (s *MemoryIdempotencyStore) prune(now time.Time) {
+	if now.Before(s.nextSweep) {
+		return
+	}
+	for key, entry := range s.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(s.entries, key)
+		}
+	}
+	s.nextSweep = now.Add(s.ttl)
+}
+
+// Compile-time check that MemoryIdempotencyStore implements IdempotencyStore
+var _ goop.IdempotencyStore = (*MemoryIdempotencyStore)(nil)
@@ -0,0 +1,108 @@
+package operations
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestMemoryIdempotencyStore(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Reserve, complete, and replay", func(t *testing.T) {
+		store := NewMemoryIdempotencyStore(time.Hour)
+
+		record, reserved, err := store.Reserve(ctx, "key")
+		if err != nil || record != nil || !reserved {
+			t.Fatalf("Expected fresh reservation, got record=%v reserved=%v err=%v", record, reserved, err)
+		}
+
+		record, reserved, _ = store.Reserve(ctx, "key")
+		if record != nil || reserved {
+			t.Error("Expected in-progress key to be neither reserved nor completed")
+		}
+
+		if err := store.Complete(ctx, "key", IdempotencyRecord{StatusCode: 201, Body: []byte("{}"), RequestHash: "abc"}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		record, reserved, _ = store.Reserve(ctx, "key")
+		if record == nil || reserved || record.StatusCode != 201 {
+			t.Errorf("Expected stored record, got record=%v reserved=%v", record, reserved)
+		}
+	})
+
+	t.Run("Release allows retry", func(t *testing.T) {
+		store := NewMemoryIdempotencyStore(time.Hour)
+		store.Reserve(ctx, "key")
+		store.Release(ctx, "key")
+
+		if _, reserved, _ := store.Reserve(ctx, "key"); !reserved {
+			t.Error("Expected released key to be reservable")
+		}
+	})
+
+	t.Run("Entries expire after TTL", func(t *testing.T) {
+		store := NewMemoryIdempotencyStore(time.Minute)
+		now := time.Now()
+		store.now = func() time.Time { return now }
+
+		store.Reserve(ctx, "key")
+		store.Complete(ctx, "key", IdempotencyRecord{StatusCode: 200})
+
+		now = now.Add(2 * time.Minute)
+		if record, reserved, _ := store.Reserve(ctx, "key"); record != nil || !reserved {
+			t.Error("Expected expired key to be reservable again")
+		}
+	})
+
+	t.Run("Expired keys are evicted", func(t *testing.T) {
+		store := NewMemoryIdempotencyStore(time.Minute)
+		now := time.Now()
+		store.now = func() time.Time { return now }
+
+		for _, key := range []string{"a", "b", "c"} {
+			store.Reserve(ctx, key)
+			store.Complete(ctx, key, IdempotencyRecord{StatusCode: 200})
+		}
+
+		now = now.Add(2 * time.Minute)
+		store.Reserve(ctx, "d")
+		if len(store.entries) != 1 {
+			t.Errorf("Expected only the new key to remain, got %d entries", len(store.entries))
+		}
+	})
+}
+
+func TestIdempotencySpec(t *testing.T) {
+	generator := NewOpenAPIGenerator("Test API", "1.0.0")
+
+	op := NewSimple().
+		POST("/payments").
+		WithIdempotency(NewMemoryIdempotencyStore(time.Hour)).
+		Handler(func(c *gin.Context) {})
+
+	if op.Idempotency != Conditional {
+		t.Errorf("Expected operation to be classified as conditional, got %q", op.Idempotency)
+	}
+
+	if err := generator.Process(OperationInfo{Method: op.Method, Path: op.Path, Operation: &op}); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	operation := generator.Spec.Paths["/payments"]["post"]
+	found := false
+	for _, param := range operation.Parameters {
+		if param.Name == IdempotencyKeyHeader && param.In == "header" && param.Required {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected required Idempotency-Key header parameter, got %+v", operation.Parameters)
+	}
+	if operation.Extensions[IdempotencyExtension] != "conditional" {
+		t.Errorf("Expected conditional idempotency extension, got %v", operation.Extensions[IdempotencyExtension])
+	}
+}
@@ -0,0 +1,64 @@
+package operations
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// MemoryJobStore is an in-process JobStore for single-instance deployments and tests
+// Use a shared store such as a database table when running multiple replicas
+type MemoryJobStore struct {
+	mu   sync.Mutex
+	ttl  time.Duration
+	jobs map[string]memoryJobEntry
+	now  func() time.Time
+}
+
+type memoryJobEntry struct {
+	job       goop.Job
+	expiresAt time.Time
+}
+
+// NewMemoryJobStore creates an in-memory store that keeps jobs for ttl after their last update
+func NewMemoryJobStore(ttl time.Duration) *MemoryJobStore {
+	return &MemoryJobStore{
+		ttl:  ttl,
+		jobs: make(map[string]memoryJobEntry),
+		now:  time.Now,
+	}
+}
+
+// Save creates or replaces the job with the same ID
+func (s *MemoryJobStore) Save(ctx context.Context, job goop.Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	// Expired jobs are dropped as new ones arrive so the store does not grow without bound
+	for id, entry := range s.jobs {
+		if !now.Before(entry.expiresAt) {
+			delete(s.jobs, id)
+		}
+	}
+	s.jobs[job.ID] = memoryJobEntry{job: job, expiresAt: now.Add(s.ttl)}
+	return nil
+}
+
+// Get returns the job with id, or nil if there is none or it expired
+func (s *MemoryJobStore) Get(ctx context.Context, id string) (*goop.Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.jobs[id]
+	if !ok || !s.now().Before(entry.expiresAt) {
+		return nil, nil
+	}
+	job := entry.job
+	return &job, nil
+}
+
+// Compile-time check that MemoryJobStore implements JobStore
+var _ goop.JobStore = (*MemoryJobStore)(nil)
@@ -0,0 +1,30 @@
+package operations
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestMemoryJobStore tests saving, replacing, and expiring jobs
+func TestMemoryJobStore(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	store := NewMemoryJobStore(time.Minute)
+	store.now = func() time.Time { return now }
+
+	if job, err := store.Get(ctx, "job_1"); err != nil || job != nil {
+		t.Fatalf("Expected no job, got %v, %v", job, err)
+	}
+
+	_ = store.Save(ctx, Job{ID: "job_1", Status: JobPending})
+	_ = store.Save(ctx, Job{ID: "job_1", Status: JobSucceeded})
+	if job, _ := store.Get(ctx, "job_1"); job == nil || job.Status != JobSucceeded {
+		t.Errorf("Expected the replaced job, got %+v", job)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if job, _ := store.Get(ctx, "job_1"); job != nil {
+		t.Errorf("Expected the job to expire, got %+v", job)
+	}
+}
@@ -0,0 +1,223 @@
+package operations
+
+import (
+	"context"
+
+	goop "github.com/picogrid/go-op"
+	"github.com/picogrid/go-op/validators"
+)
+
+// JSONAPIDocument is a JSON:API top-level document wrapping primary data
+// Data is a JSONAPIResource for single resources or a slice of them for collections.
+// Documents are served as application/vnd.api+json by router adapters.
+type JSONAPIDocument[T any] struct {
+	Data     T                      `json:"data"`
+	Included []interface{}          `json:"included,omitempty"`
+	Meta     map[string]interface{} `json:"meta,omitempty"`
+	Links    map[string]string      `json:"links,omitempty"`
+	JSONAPI  *JSONAPIVersion        `json:"jsonapi,omitempty"`
+}
+
+// JSONAPIErrorDocument is a JSON:API top-level document for failed requests
+type JSONAPIErrorDocument struct {
+	Errors []JSONAPIError         `json:"errors"`
+	Meta   map[string]interface{} `json:"meta,omitempty"`
+}
+
+// JSONAPIVersion describes the JSON:API version a document conforms to
+type JSONAPIVersion struct {
+	Version string `json:"version"`
+}
+
+// JSONAPIResource is a JSON:API resource object with attributes of type T
+type JSONAPIResource[T any] struct {
+	Type          string                         `json:"type"`
+	ID            string                         `json:"id"`
+	Attributes    T                              `json:"attributes"`
+	Relationships map[string]JSONAPIRelationship `json:"relationships,omitempty"`
+	Links         map[string]string              `json:"links,omitempty"`
+	Meta          map[string]interface{}         `json:"meta,omitempty"`
+}
+
+// JSONAPIResourceIdentifier identifies a related resource
+type JSONAPIResourceIdentifier struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// JSONAPIRelationship links a resource to related resources
+// Data is a *JSONAPIResourceIdentifier for to-one relationships and a slice of identifiers for to-many relationships.
+type JSONAPIRelationship struct {
+	Data  interface{}       `json:"data"`
+	Links map[string]string `json:"links,omitempty"`
+}
+
+// JSONAPIError is a JSON:API error object
+type JSONAPIError struct {
+	ID     string                 `json:"id,omitempty"`
+	Status string                 `json:"status,omitempty"`
+	Code   string                 `json:"code,omitempty"`
+	Title  string                 `json:"title,omitempty"`
+	Detail string                 `json:"detail,omitempty"`
+	Source *JSONAPIErrorSource    `json:"source,omitempty"`
+	Meta   map[string]interface{} `json:"meta,omitempty"`
+}
+
+// JSONAPIErrorSource points to the part of the request that caused an error
+type JSONAPIErrorSource struct {
+	Pointer   string `json:"pointer,omitempty"`
+	Parameter string `json:"parameter,omitempty"`
+	Header    string `json:"header,omitempty"`
+}
+
+// ContentType returns the JSON:API media type
+func (JSONAPIDocument[T]) ContentType() string {
+	return JSONAPIContentType
+}
+
+// ContentType returns the JSON:API media type
+func (JSONAPIErrorDocument) ContentType() string {
+	return JSONAPIContentType
+}
+
+// NewJSONAPIResource creates a resource object of the given type and ID
+func NewJSONAPIResource[T any](resourceType, id string, attributes T) JSONAPIResource[T] {
+	return JSONAPIResource[T]{
+		Type:       resourceType,
+		ID:         id,
+		Attributes: attributes,
+	}
+}
+
+// Relate returns a copy of the resource with the named relationship set
+func (r JSONAPIResource[T]) Relate(name string, relationship JSONAPIRelationship) JSONAPIResource[T] {
+	relationships := make(map[string]JSONAPIRelationship, len(r.Relationships)+1)
+	for key, value := range r.Relationships {
+		relationships[key] = value
+	}
+	relationships[name] = relationship
+	r.Relationships = relationships
+	return r
+}
+
+// JSONAPIToOne creates a to-one relationship
+func JSONAPIToOne(resourceType, id string) JSONAPIRelationship {
+	return JSONAPIRelationship{Data: &JSONAPIResourceIdentifier{Type: resourceType, ID: id}}
+}
+
+// JSONAPIToMany creates a to-many relationship to resources of one type
+func JSONAPIToMany(resourceType string, ids ...string) JSONAPIRelationship {
+	identifiers := make([]JSONAPIResourceIdentifier, len(ids))
+	for i, id := range ids {
+		identifiers[i] = JSONAPIResourceIdentifier{Type: resourceType, ID: id}
+	}
+	return JSONAPIRelationship{Data: identifiers}
+}
+
+// NewJSONAPIDocument wraps a single resource in a document
+func NewJSONAPIDocument[T any](resource JSONAPIResource[T]) JSONAPIDocument[JSONAPIResource[T]] {
+	return JSONAPIDocument[JSONAPIResource[T]]{Data: resource}
+}
+
+// NewJSONAPIErrorDocument builds an error document from one or more errors
+func NewJSONAPIErrorDocument(errors ...JSONAPIError) JSONAPIErrorDocument {
+	return JSONAPIErrorDocument{Errors: errors}
+}
+
+// NewJSONAPIListDocument wraps a collection of resources in a document
+func NewJSONAPIListDocument[T any](resources []JSONAPIResource[T]) JSONAPIDocument[[]JSONAPIResource[T]] {
+	if resources == nil {
+		resources = []JSONAPIResource[T]{}
+	}
+	return JSONAPIDocument[[]JSONAPIResource[T]]{Data: resources}
+}
+
+// JSONAPIHandler wraps a handler so its result is returned as the attributes of a single-resource document
+// id extracts the resource ID from the result. Pair it with WithJSONAPIResponse and JSONAPIDocumentSchema.
+func JSONAPIHandler[P, Q, B, R any](resourceType string, id func(R) string, handler goop.Handler[P, Q, B, R]) goop.Handler[P, Q, B, JSONAPIDocument[JSONAPIResource[R]]] {
+	return func(ctx context.Context, params P, query Q, body B) (JSONAPIDocument[JSONAPIResource[R]], error) {
+		result, err := handler(ctx, params, query, body)
+		if err != nil {
+			return JSONAPIDocument[JSONAPIResource[R]]{}, err
+		}
+		return NewJSONAPIDocument(NewJSONAPIResource(resourceType, id(result), result)), nil
+	}
+}
+
+// JSONAPIListHandler wraps a handler returning a slice so each item becomes a resource of a collection document
+func JSONAPIListHandler[P, Q, B, R any](resourceType string, id func(R) string, handler goop.Handler[P, Q, B, []R]) goop.Handler[P, Q, B, JSONAPIDocument[[]JSONAPIResource[R]]] {
+	return func(ctx context.Context, params P, query Q, body B) (JSONAPIDocument[[]JSONAPIResource[R]], error) {
+		results, err := handler(ctx, params, query, body)
+		if err != nil {
+			return JSONAPIDocument[[]JSONAPIResource[R]]{}, err
+		}
+		resources := make([]JSONAPIResource[R], len(results))
+		for i, result := range results {
+			resources[i] = NewJSONAPIResource(resourceType, id(result), result)
+		}
+		return NewJSONAPIListDocument(resources), nil
+	}
+}
+
+// Shared JSON:API component schemas, documented once under components/schemas
+var (
+	// JSONAPIResourceIdentifierSchema documents a resource identifier object
+	JSONAPIResourceIdentifierSchema = Component("JSONAPIResourceIdentifier", validators.Object(map[string]interface{}{
+		"type": validators.String().Example("users").Required(),
+		"id":   validators.String().Example("1").Required(),
+	}).Required())
+
+	jsonAPILinksSchema = validators.Object(map[string]interface{}{}).Optional()
+	jsonAPIMetaSchema  = validators.Object(map[string]interface{}{}).Optional()
+)
+
+// JSONAPIResourceSchema returns the schema for resources of resourceType with the given attributes
+// relationships maps relationship names to JSONAPIToOneSchema or JSONAPIToManySchema.
+func JSONAPIResourceSchema(resourceType string, attributesSchema interface{}, relationships map[string]goop.Schema) goop.Schema {
+	fields := map[string]interface{}{
+		"type":       validators.String().Const(resourceType).Required(),
+		"id":         validators.String().Required(),
+		"attributes": attributesSchema,
+		"links":      jsonAPILinksSchema,
+		"meta":       jsonAPIMetaSchema,
+	}
+	if len(relationships) > 0 {
+		relationshipFields := make(map[string]interface{}, len(relationships))
+		for name, schema := range relationships {
+			relationshipFields[name] = schema
+		}
+		fields["relationships"] = validators.Object(relationshipFields).Optional()
+	}
+	return validators.Object(fields).Required()
+}
+
+// JSONAPIToOneSchema returns the schema for a to-one relationship
+func JSONAPIToOneSchema() goop.Schema {
+	return validators.Object(map[string]interface{}{
+		"data":  JSONAPIResourceIdentifierSchema,
+		"links": jsonAPILinksSchema,
+	}).Optional()
+}
+
+// JSONAPIToManySchema returns the schema for a to-many relationship
+func JSONAPIToManySchema() goop.Schema {
+	return validators.Object(map[string]interface{}{
+		"data":  validators.Array(JSONAPIResourceIdentifierSchema).Required(),
+		"links": jsonAPILinksSchema,
+	}).Optional()
+}
+
+// JSONAPIDocumentSchema returns the schema for a document wrapping a single resource described by resourceSchema
+func JSONAPIDocumentSchema(resourceSchema interface{}) goop.Schema {
+	return validators.Object(map[string]interface{}{
+		"data":     resourceSchema,
+		"included": validators.Array(validators.Object(map[string]interface{}{}).Required()).Optional(),
+		"meta":     jsonAPIMetaSchema,
+		"links":    jsonAPILinksSchema,
+	}).Required()
+}
+
+// JSONAPIListSchema returns the schema for a document wrapping a collection of resources described by resourceSchema
+func JSONAPIListSchema(resourceSchema interface{}) goop.Schema {
+	return JSONAPIDocumentSchema(validators.Array(resourceSchema).Required())
+}
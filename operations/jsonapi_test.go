@@ -0,0 +1,147 @@
+package operations
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	goop "github.com/picogrid/go-op"
+	ginadapter "github.com/picogrid/go-op/operations/adapters/gin"
+	"github.com/picogrid/go-op/validators"
+)
+
+type jsonAPIArticle struct {
+	ID    string `json:"-"`
+	Title string `json:"title"`
+}
+
+func TestJSONAPI(t *testing.T) {
+	attributesSchema := validators.Object(map[string]interface{}{
+		"title": validators.String().Required(),
+	}).Required()
+	articleSchema := JSONAPIResourceSchema("articles", attributesSchema, map[string]goop.Schema{
+		"author":   JSONAPIToOneSchema(),
+		"comments": JSONAPIToManySchema(),
+	})
+
+	toMap := func(t *testing.T, v interface{}) map[string]interface{} {
+		t.Helper()
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		var m map[string]interface{}
+		if err := json.Unmarshal(data, &m); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return m
+	}
+
+	article := NewJSONAPIResource("articles", "1", jsonAPIArticle{Title: "JSON:API paints my bikeshed!"}).
+		Relate("author", JSONAPIToOne("people", "9")).
+		Relate("comments", JSONAPIToMany("comments", "5", "12"))
+
+	t.Run("Document schema validates documents", func(t *testing.T) {
+		schema := JSONAPIDocumentSchema(articleSchema)
+		document := toMap(t, NewJSONAPIDocument(article))
+		if err := schema.Validate(document); err != nil {
+			t.Errorf("Expected valid document, got %v", err)
+		}
+
+		data := document["data"].(map[string]interface{})
+		author := data["relationships"].(map[string]interface{})["author"].(map[string]interface{})
+		if author["data"].(map[string]interface{})["id"] != "9" {
+			t.Errorf("Expected author relationship, got %v", author)
+		}
+	})
+
+	t.Run("Resource schema checks the resource type", func(t *testing.T) {
+		wrongType := toMap(t, NewJSONAPIResource("people", "9", jsonAPIArticle{Title: "x"}))
+		if err := articleSchema.Validate(wrongType); err == nil {
+			t.Error("Expected error for a resource of another type")
+		}
+	})
+
+	t.Run("List schema validates collections", func(t *testing.T) {
+		schema := JSONAPIListSchema(articleSchema)
+		if err := schema.Validate(toMap(t, NewJSONAPIListDocument([]JSONAPIResource[jsonAPIArticle]{article}))); err != nil {
+			t.Errorf("Expected valid list document, got %v", err)
+		}
+		empty := toMap(t, NewJSONAPIListDocument[jsonAPIArticle](nil))
+		if items, ok := empty["data"].([]interface{}); !ok || len(items) != 0 {
+			t.Errorf("Expected empty data array, got %v", empty["data"])
+		}
+	})
+
+	t.Run("Documents the JSON:API media type", func(t *testing.T) {
+		generator := NewOpenAPIGenerator("Test API", "1.0.0")
+		op := NewSimple().
+			GET("/articles/{id}").
+			WithJSONAPIResponse(200, JSONAPIDocumentSchema(articleSchema), "Article").
+			Handler(nil)
+		if err := generator.Process(OperationInfo{Method: op.Method, Path: op.Path, Operation: &op}); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		content := generator.Spec.Paths["/articles/{id}"]["get"].Responses["200"].Content
+		if _, ok := content[JSONAPIContentType]; !ok {
+			t.Errorf("Expected %s content, got %v", JSONAPIContentType, content)
+		}
+	})
+
+	t.Run("Wraps handler results and serves the JSON:API media type", func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		router := ginadapter.NewGinRouter(gin.New())
+
+		documentSchema := JSONAPIDocumentSchema(articleSchema)
+		handler := JSONAPIHandler("articles", func(a jsonAPIArticle) string { return a.ID },
+			func(ctx context.Context, params struct{}, query struct{}, body struct{}) (jsonAPIArticle, error) {
+				return jsonAPIArticle{ID: "1", Title: "Hello"}, nil
+			})
+		op := NewSimple().
+			GET("/articles/1").
+			WithJSONAPIResponse(200, documentSchema, "Article").
+			Handler(ginadapter.CreateValidatedHandler(handler, nil, nil, nil, documentSchema))
+		if err := router.Register(op); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/articles/1", nil))
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("Expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		if contentType := recorder.Header().Get("Content-Type"); contentType != JSONAPIContentType {
+			t.Errorf("Expected %s, got %s", JSONAPIContentType, contentType)
+		}
+
+		var document map[string]interface{}
+		if err := json.Unmarshal(recorder.Body.Bytes(), &document); err != nil {
+			t.Fatalf("Expected valid JSON, got %v", err)
+		}
+		data := document["data"].(map[string]interface{})
+		if data["type"] != "articles" || data["id"] != "1" {
+			t.Errorf("Expected articles resource 1, got %v", data)
+		}
+		if data["attributes"].(map[string]interface{})["title"] != "Hello" {
+			t.Errorf("Expected attributes to hold the handler result, got %v", data["attributes"])
+		}
+	})
+
+	t.Run("List handler wraps each item", func(t *testing.T) {
+		handler := JSONAPIListHandler("articles", func(a jsonAPIArticle) string { return a.ID },
+			func(ctx context.Context, params struct{}, query struct{}, body struct{}) ([]jsonAPIArticle, error) {
+				return []jsonAPIArticle{{ID: "1", Title: "a"}, {ID: "2", Title: "b"}}, nil
+			})
+		document, err := handler(context.Background(), struct{}{}, struct{}{}, struct{}{})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(document.Data) != 2 || document.Data[1].ID != "2" || document.Data[1].Type != "articles" {
+			t.Errorf("Unexpected document %+v", document)
+		}
+	})
+}
@@ -4,10 +4,16 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"io/fs"
+	"net/http"
 	"os"
+	"path"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	goop "github.com/picogrid/go-op"
 )
@@ -22,6 +28,37 @@ type OpenAPIGenerator struct {
 	SecuritySchemes map[string]goop.SecurityScheme
 	GlobalSecurity  goop.SecurityRequirements
 	Spec            *OpenAPISpec
+
+	// APIVersion limits the spec to operations of one API version plus unversioned operations (empty includes all)
+	APIVersion string
+
+	// AutoValidationErrors documents a 400 validation error response on every operation with request validation
+	AutoValidationErrors bool
+
+	// DefaultResponses are documented on every operation that does not declare the same status code
+	DefaultResponses map[int]ResponseDefinition
+
+	// DescriptionFS holds the files named by DescriptionFromFile (nil reads them relative to the working directory)
+	DescriptionFS fs.FS
+
+	// Runtime validators for component schemas loaded via LoadComponentsDir
+	componentValidators map[string]goop.Schema
+
+	// Namespace resolved before the process-wide components (nil uses only process-wide components)
+	components *ComponentRegistry
+
+	// Revision of the spec, incremented to invalidate documents cached by SpecHandler
+	specRevision uint64
+
+	// Guards Spec while spec handlers render it, and the handler ServeHTTP serves the complete spec with
+	specMu      sync.RWMutex
+	specOnce    sync.Once
+	specHandler http.Handler
+
+	// Build info set with SetBuildInfo, and the path and operation serving it
+	buildInfo *BuildInfo
+	metaPath  string
+	meta      *CompiledOperation
 }
 
 // OpenAPIServer represents a server in the OpenAPI spec
@@ -73,6 +110,45 @@ type OpenAPIInfo struct {
 	TermsOfService string          `json:"termsOfService,omitempty" yaml:"termsOfService,omitempty"`
 	Contact        *OpenAPIContact `json:"contact,omitempty" yaml:"contact,omitempty"`
 	License        *OpenAPILicense `json:"license,omitempty" yaml:"license,omitempty"`
+
+	// Specification extensions (x-*) emitted inline with the info fields
+	Extensions map[string]interface{} `json:"-" yaml:",inline"`
+}
+
+// SetExtension sets a specification extension on the info object
+// Names without the "x-" prefix OpenAPI 3.1 requires are prefixed, so "internal" sets x-internal
+func (i *OpenAPIInfo) SetExtension(name string, value interface{}) {
+	if i.Extensions == nil {
+		i.Extensions = make(map[string]interface{})
+	}
+	i.Extensions[goop.ExtensionName(name)] = value
+}
+
+// MarshalJSON implements custom JSON marshaling so extensions are emitted inline
+func (i OpenAPIInfo) MarshalJSON() ([]byte, error) {
+	type infoAlias OpenAPIInfo
+	data, err := json.Marshal(infoAlias(i))
+	if err != nil {
+		return nil, err
+	}
+	return spliceExtensions(data, i.Extensions)
+}
+
+// UnmarshalJSON implements custom JSON unmarshaling that collects x-* extensions
+func (i *OpenAPIInfo) UnmarshalJSON(data []byte) error {
+	type infoAlias OpenAPIInfo
+	var alias infoAlias
+	if err := json.Unmarshal(data, &alias); err != nil {
+		return err
+	}
+	extensions, err := collectExtensions(data)
+	if err != nil {
+		return err
+	}
+	alias.Extensions = extensions
+
+	*i = OpenAPIInfo(alias)
+	return nil
 }
 
 // OpenAPIContact represents contact information for the API
@@ -117,6 +193,136 @@ type OpenAPIOperation struct {
 	OperationId  string                     `json:"operationId,omitempty" yaml:"operationId,omitempty"`
 	Deprecated   *bool                      `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
 	ExternalDocs *OpenAPIExternalDocs       `json:"externalDocs,omitempty" yaml:"externalDocs,omitempty"`
+
+	// Specification extensions (x-*) emitted inline with the operation fields
+	Extensions map[string]interface{} `json:"-" yaml:",inline"`
+}
+
+// Operation extensions emitted by the generators
+const (
+	// TimeoutExtension carries the declared timeout in milliseconds
+	// It only documents the timeout; no client generator applies it as a request deadline.
+	TimeoutExtension = "x-timeout-ms"
+	// IdempotencyExtension carries the retry-safety classification
+	IdempotencyExtension = "x-idempotency"
+	// RateLimitExtension carries the declared request rate limit
+	RateLimitExtension = "x-ratelimit"
+	// VersionExtension carries the API version an operation belongs to
+	VersionExtension = "x-api-version"
+	// VersionsExtension carries the other versions of a header-versioned operation, keyed by version
+	VersionsExtension = "x-versions"
+	// AudienceExtension carries the audiences an operation is documented for
+	AudienceExtension = "x-audience"
+	// DeprecationExtension carries deprecation and sunset dates and the successor operation
+	DeprecationExtension = "x-deprecation"
+	// MaxBodyBytesExtension carries the maximum accepted request body size in bytes
+	MaxBodyBytesExtension = "x-max-body-bytes"
+	// FeatureFlagExtension carries the feature flag gating an operation and whether it is on
+	FeatureFlagExtension = "x-feature-flag"
+	// CacheExtension carries how long successful responses may be cached and whether shared caches may store them
+	CacheExtension = "x-cache"
+)
+
+// SetExtension sets a specification extension on the operation
+// Names without the "x-" prefix OpenAPI 3.1 requires are prefixed, so "internal" sets x-internal
+func (o *OpenAPIOperation) SetExtension(name string, value interface{}) {
+	if o.Extensions == nil {
+		o.Extensions = make(map[string]interface{})
+	}
+	o.Extensions[goop.ExtensionName(name)] = value
+}
+
+// Extension creates a schema extension for the validators' Custom methods, so a check that
+// JSON Schema keywords cannot express still leaves a machine-readable marker in the spec
+//
+// Example:
+//
+//	"cardNumber": validators.String().Custom(luhn, operations.Extension("x-validation", "luhn")).Required()
+func Extension(name string, value interface{}) goop.SchemaExtension {
+	return goop.SchemaExtension{Name: name, Value: value}
+}
+
+// MarshalJSON implements custom JSON marshaling so extensions are emitted inline
+func (o OpenAPIOperation) MarshalJSON() ([]byte, error) {
+	type operationAlias OpenAPIOperation
+	data, err := json.Marshal(operationAlias(o))
+	if err != nil {
+		return nil, err
+	}
+	return spliceExtensions(data, o.Extensions)
+}
+
+// UnmarshalJSON implements custom JSON unmarshaling that collects x-* extensions
+func (o *OpenAPIOperation) UnmarshalJSON(data []byte) error {
+	type operationAlias OpenAPIOperation
+	var alias operationAlias
+	if err := json.Unmarshal(data, &alias); err != nil {
+		return err
+	}
+	extensions, err := collectExtensions(data)
+	if err != nil {
+		return err
+	}
+	alias.Extensions = extensions
+
+	*o = OpenAPIOperation(alias)
+	return nil
+}
+
+// spliceExtensions adds extensions to data, an encoded JSON object, sorted by name
+func spliceExtensions(data []byte, extensions map[string]interface{}) ([]byte, error) {
+	if len(extensions) == 0 {
+		return data, nil
+	}
+
+	names := make([]string, 0, len(extensions))
+	for name := range extensions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// Splice the extensions into the object before its closing brace
+	buf := data[:len(data)-1]
+	for _, name := range names {
+		key, err := json.Marshal(name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(extensions[name])
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode extension %s: %w", name, err)
+		}
+		if len(buf) > 1 {
+			buf = append(buf, ',')
+		}
+		buf = append(buf, key...)
+		buf = append(buf, ':')
+		buf = append(buf, value...)
+	}
+	return append(buf, '}'), nil
+}
+
+// collectExtensions returns the x-* extensions of data, an encoded JSON object, or nil if it has none
+func collectExtensions(data []byte) (map[string]interface{}, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	var extensions map[string]interface{}
+	for name, value := range raw {
+		if !strings.HasPrefix(name, "x-") {
+			continue
+		}
+		var decoded interface{}
+		if err := json.Unmarshal(value, &decoded); err != nil {
+			return nil, fmt.Errorf("failed to decode extension %s: %w", name, err)
+		}
+		if extensions == nil {
+			extensions = make(map[string]interface{})
+		}
+		extensions[name] = decoded
+	}
+	return extensions, nil
 }
 
 // OpenAPIExternalDocs represents external documentation for the API
@@ -127,6 +333,8 @@ type OpenAPIExternalDocs struct {
 
 // OpenAPIParameter represents a parameter in OpenAPI spec
 type OpenAPIParameter struct {
+	// Ref points to a reusable parameter under components/parameters; referencing parameters set nothing else
+	Ref             string                      `json:"$ref,omitempty" yaml:"$ref,omitempty"`
 	Name            string                      `json:"name" yaml:"name"`
 	In              string                      `json:"in" yaml:"in"` // "path", "query", "header", "cookie"
 	Description     string                      `json:"description,omitempty" yaml:"description,omitempty"`
@@ -151,6 +359,8 @@ type OpenAPIRequestBody struct {
 
 // OpenAPIResponse represents a response in OpenAPI spec
 type OpenAPIResponse struct {
+	// Ref points to a reusable response under components/responses; referencing responses set nothing else
+	Ref         string                      `json:"$ref,omitempty" yaml:"$ref,omitempty"`
 	Description string                      `json:"description" yaml:"description"`
 	Content     map[string]OpenAPIMediaType `json:"content,omitempty" yaml:"content,omitempty"`
 	Headers     map[string]OpenAPIHeader    `json:"headers,omitempty" yaml:"headers,omitempty"`
@@ -335,6 +545,26 @@ func (g *OpenAPIGenerator) SetJsonSchemaDialect(dialect string) {
 	g.Spec.JsonSchemaDialect = dialect
 }
 
+// SetAutoValidationErrors enables or disables automatic 400 validation error documentation
+// When enabled, operations with params, query, body, or header schemas get a standard
+// validation error response unless they already declare a 400 response
+func (g *OpenAPIGenerator) SetAutoValidationErrors(enabled bool) {
+	g.AutoValidationErrors = enabled
+}
+
+// SetDefaultResponses documents the given responses on every operation, such as 401, 403, 429, and 500
+// errors injected by authentication middleware or a gateway. Responses declared by an operation take
+// precedence, and operations can opt out of individual codes with WithoutDefaultResponses.
+func (g *OpenAPIGenerator) SetDefaultResponses(responses map[int]ResponseDefinition) {
+	g.DefaultResponses = responses
+}
+
+// SetAPIVersion restricts the spec to a single API version
+// Use one generator per version to produce a separate spec document for each version
+func (g *OpenAPIGenerator) SetAPIVersion(version string) {
+	g.APIVersion = version
+}
+
 // AddSecurityScheme adds a security scheme to the OpenAPI specification
 func (g *OpenAPIGenerator) AddSecurityScheme(name string, scheme goop.SecurityScheme) error {
 	// Validate the security scheme name
@@ -377,11 +607,106 @@ func (g *OpenAPIGenerator) ListSecuritySchemes() []string {
 
 // Process processes an operation and adds it to the OpenAPI specification
 func (g *OpenAPIGenerator) Process(info OperationInfo) error {
+	// Skip operations that belong to a different API version
+	if g.APIVersion != "" && info.Operation.Version != "" && info.Operation.Version != g.APIVersion {
+		return nil
+	}
+	if err := g.describe(&info); err != nil {
+		return err
+	}
+
+	// Spec handlers may be rendering the document while operations are registered
+	g.specMu.Lock()
+	defer g.specMu.Unlock()
+
+	operation := g.buildOperation(info)
+
+	// Register shared components referenced by the operation's schemas
+	g.resolveOperationRefs(&operation)
+
 	// Create path if it doesn't exist
 	if g.Spec.Paths[info.Path] == nil {
 		g.Spec.Paths[info.Path] = make(map[string]OpenAPIOperation)
 	}
 
+	// Header-versioned operations share a method and path, so a spec covering all versions documents them together
+	method := strings.ToLower(info.Method)
+	if existing, exists := g.Spec.Paths[info.Path][method]; exists {
+		operation = MergeVersions(existing, operation)
+	}
+
+	// Store the operation
+	g.Spec.Paths[info.Path][method] = operation
+	g.InvalidateSpecCache()
+
+	return nil
+}
+
+// MergeVersions combines two versions of an operation on the same method and path
+// The latest version (by string order, as routed without an API-Version header) is documented
+// as the operation, and the others under x-versions. Unversioned operations replace each other.
+func MergeVersions(existing, added OpenAPIOperation) OpenAPIOperation {
+	existingVersion, _ := existing.Extensions[VersionExtension].(string)
+	addedVersion, _ := added.Extensions[VersionExtension].(string)
+	if existingVersion == "" || addedVersion == "" || existingVersion == addedVersion {
+		return added
+	}
+
+	versions := make(map[string]OpenAPIOperation)
+	if others, ok := existing.Extensions[VersionsExtension].(map[string]OpenAPIOperation); ok {
+		for version, operation := range others {
+			versions[version] = operation
+		}
+	}
+	versions[existingVersion] = withoutExtension(existing, VersionsExtension)
+	versions[addedVersion] = added
+
+	latest := ""
+	for version := range versions {
+		if version > latest {
+			latest = version
+		}
+	}
+	merged := withoutExtension(versions[latest], VersionsExtension)
+	delete(versions, latest)
+	merged.SetExtension(VersionsExtension, versions)
+	return merged
+}
+
+// withoutExtension returns a copy of operation without the named extension
+func withoutExtension(operation OpenAPIOperation, name string) OpenAPIOperation {
+	extensions := make(map[string]interface{}, len(operation.Extensions))
+	for key, value := range operation.Extensions {
+		if key != name {
+			extensions[key] = value
+		}
+	}
+	operation.Extensions = extensions
+	return operation
+}
+
+// describe sets the description of an operation documented by a Markdown file to the file's contents
+func (g *OpenAPIGenerator) describe(info *OperationInfo) error {
+	if info.Operation == nil || info.Operation.DescriptionFile == "" {
+		return nil
+	}
+
+	var data []byte
+	var err error
+	if g.DescriptionFS != nil {
+		data, err = fs.ReadFile(g.DescriptionFS, path.Clean(filepath.ToSlash(info.Operation.DescriptionFile)))
+	} else {
+		data, err = os.ReadFile(info.Operation.DescriptionFile)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read description of %s %s: %w", info.Method, info.Path, err)
+	}
+	info.Description = strings.TrimSpace(string(data))
+	return nil
+}
+
+// buildOperation documents an operation without adding it to the specification
+func (g *OpenAPIGenerator) buildOperation(info OperationInfo) OpenAPIOperation {
 	// Create the operation
 	operation := OpenAPIOperation{
 		Summary:     info.Summary,
@@ -390,6 +715,40 @@ func (g *OpenAPIGenerator) Process(info OperationInfo) error {
 		Parameters:  []OpenAPIParameter{},
 		Responses:   make(map[string]OpenAPIResponse),
 		Security:    []goop.SecurityRequirement(info.Operation.Security),
+		OperationId: info.Operation.OperationID,
+	}
+
+	// Annotate versioned operations so a combined spec shows which version each belongs to
+	if info.Operation.Version != "" {
+		operation.SetExtension(VersionExtension, info.Operation.Version)
+	}
+
+	// Record restricted visibility so audience-specific specs can be filtered from the full spec
+	if len(info.Operation.Audiences) > 0 {
+		operation.SetExtension(AudienceExtension, info.Operation.Audiences)
+	}
+
+	// Mark flagged operations so specs from flagged and unflagged deployments can be compared
+	if flag := info.Operation.FeatureFlag; flag != nil {
+		operation.SetExtension(FeatureFlagExtension, map[string]interface{}{
+			"name":    flag.Name,
+			"enabled": info.Operation.Enabled(),
+		})
+	}
+
+	// Mark deprecated operations and document the headers announcing it
+	if info.Operation.Deprecation != nil {
+		documentDeprecation(&operation, *info.Operation.Deprecation)
+	}
+
+	// Surface the declared timeout so generated clients can default their request deadline
+	if info.Operation.Timeout > 0 {
+		operation.SetExtension(TimeoutExtension, info.Operation.Timeout.Milliseconds())
+	}
+
+	// Surface the retry-safety classification for client retry policies
+	if info.Operation.Idempotency != "" {
+		operation.SetExtension(IdempotencyExtension, string(info.Operation.Idempotency))
 	}
 
 	// Add path parameters
@@ -401,15 +760,54 @@ func (g *OpenAPIGenerator) Process(info OperationInfo) error {
 	// Add query parameters
 	if info.Operation.QuerySpec != nil {
 		queryParams := g.extractQueryParameters(info.Operation.QuerySpec)
+		for i := range queryParams {
+			if serialization, ok := info.Operation.QueryStyles[queryParams[i].Name]; ok {
+				explode := serialization.Explode
+				queryParams[i].Style = string(serialization.Style)
+				queryParams[i].Explode = &explode
+			}
+		}
 		operation.Parameters = append(operation.Parameters, queryParams...)
 	}
 
+	// Document sort keys and filter expressions
+	if info.Operation.QueryFilters != nil {
+		operation.Parameters = append(operation.Parameters, filterParameters(*info.Operation.QueryFilters, operation.Parameters)...)
+	}
+
+	// Document the sparse fieldset selector
+	if info.Operation.SparseFields != nil {
+		operation.Parameters = append(operation.Parameters, fieldsParameter(*info.Operation.SparseFields))
+	}
+
 	// Add header parameters
 	if info.Operation.HeaderSpec != nil {
 		headerParams := g.extractHeaderParameters(info.Operation.HeaderSpec)
 		operation.Parameters = append(operation.Parameters, headerParams...)
 	}
 
+	// Document the optional correlation header clients can send
+	if header := info.Operation.RequestIDHeader; header != "" && !hasParameter(operation.Parameters, header, "header") {
+		operation.Parameters = append(operation.Parameters, requestIDParameter(header))
+	}
+
+	// Document the tenant parameter, unless the operation already declares it in its own schemas
+	if info.Operation.Tenant != nil && !hasParameter(operation.Parameters, info.Operation.Tenant.Name, info.Operation.Tenant.In) {
+		operation.Parameters = append(operation.Parameters, tenantParameter(*info.Operation.Tenant))
+	}
+
+	// Document the Idempotency-Key header for operations that enforce it
+	if info.Operation.IdempotencyStore != nil {
+		minLength, maxLength := 1, 255
+		operation.Parameters = append(operation.Parameters, OpenAPIParameter{
+			Name:        goop.IdempotencyKeyHeader,
+			In:          "header",
+			Description: "Unique key identifying this request; retries with the same key replay the original response",
+			Required:    true,
+			Schema:      &goop.OpenAPISchema{Type: "string", MinLength: &minLength, MaxLength: &maxLength},
+		})
+	}
+
 	// Add request body
 	if info.Operation.BodySpec != nil {
 		mediaType := OpenAPIMediaType{
@@ -421,10 +819,15 @@ func (g *OpenAPIGenerator) Process(info OperationInfo) error {
 			mediaType.Example = info.Operation.BodySpec.Example
 		}
 
+		contentType := info.Operation.BodyContentType
+		if contentType == "" {
+			contentType = "application/json"
+		}
+
 		operation.RequestBody = &OpenAPIRequestBody{
 			Required: info.BodyInfo != nil && info.BodyInfo.Required,
 			Content: map[string]OpenAPIMediaType{
-				"application/json": mediaType,
+				contentType: mediaType,
 			},
 		}
 	}
@@ -433,31 +836,11 @@ func (g *OpenAPIGenerator) Process(info OperationInfo) error {
 	if len(info.Operation.Responses) > 0 {
 		// Use new multiple responses system
 		for code, responseDef := range info.Operation.Responses {
-			codeStr := fmt.Sprintf("%d", code)
-
-			response := OpenAPIResponse{
-				Description: responseDef.Description,
-			}
-
-			// Add schema if present
-			if responseDef.Schema != nil {
-				if enhanced, ok := responseDef.Schema.(goop.EnhancedSchema); ok {
-					mediaType := OpenAPIMediaType{
-						Schema: enhanced.ToOpenAPISchema(),
-					}
-
-					// Add example from schema if available
-					if enhanced.ToOpenAPISchema().Example != nil {
-						mediaType.Example = enhanced.ToOpenAPISchema().Example
-					}
-
-					response.Content = map[string]OpenAPIMediaType{
-						"application/json": mediaType,
-					}
-				}
+			if responseDef.Ref != "" {
+				operation.Responses[fmt.Sprintf("%d", code)] = OpenAPIResponse{Ref: ResponseRef(responseDef.Ref)}
+				continue
 			}
-
-			operation.Responses[codeStr] = response
+			operation.Responses[fmt.Sprintf("%d", code)] = schemaResponse(responseDef.Schema, responseDef.Description, responseDef.ContentType)
 		}
 	} else {
 		// Fallback to legacy single response for backward compatibility
@@ -491,8 +874,9 @@ func (g *OpenAPIGenerator) Process(info OperationInfo) error {
 					Schema: &goop.OpenAPISchema{
 						Type: "object",
 						Properties: map[string]*goop.OpenAPISchema{
-							"error":   {Type: "string"},
-							"details": {Type: "string"},
+							"error":      {Type: "string"},
+							"details":    {Type: "string"},
+							"request_id": {Type: "string"},
 						},
 						Required: []string{"error"},
 					},
@@ -507,8 +891,9 @@ func (g *OpenAPIGenerator) Process(info OperationInfo) error {
 					Schema: &goop.OpenAPISchema{
 						Type: "object",
 						Properties: map[string]*goop.OpenAPISchema{
-							"error":   {Type: "string"},
-							"details": {Type: "string"},
+							"error":      {Type: "string"},
+							"details":    {Type: "string"},
+							"request_id": {Type: "string"},
 						},
 						Required: []string{"error"},
 					},
@@ -517,10 +902,235 @@ func (g *OpenAPIGenerator) Process(info OperationInfo) error {
 		}
 	}
 
-	// Store the operation
-	g.Spec.Paths[info.Path][strings.ToLower(info.Method)] = operation
+	// Downloads respond with the file itself, in whole or in part
+	if len(info.Operation.FileContentTypes) > 0 {
+		documentFileResponse(&operation, info.Operation.SuccessCode, info.Operation.FileContentTypes)
+	}
 
-	return nil
+	// Keep docs honest by default: operations that validate input can fail with 400
+	if g.AutoValidationErrors && hasRequestValidation(info.Operation) {
+		if _, exists := operation.Responses["400"]; !exists {
+			operation.Responses["400"] = validationErrorResponse()
+		}
+	}
+
+	// Operations with a body size limit reject larger payloads with 413
+	if info.Operation.MaxBodyBytes > 0 {
+		operation.SetExtension(MaxBodyBytesExtension, info.Operation.MaxBodyBytes)
+		if _, exists := operation.Responses["413"]; !exists {
+			operation.Responses["413"] = standardErrorResponse(413)
+		}
+	}
+
+	// Operations with a deadline can fail with 504 when it is exceeded
+	if info.Operation.Timeout > 0 {
+		if _, exists := operation.Responses["504"]; !exists {
+			operation.Responses["504"] = standardErrorResponse(504)
+		}
+	}
+
+	// Document rate limits and the headers clients can use to track them
+	if info.Operation.RateLimit != nil {
+		documentRateLimit(&operation, *info.Operation.RateLimit)
+	}
+
+	// Document the generator's default responses the operation does not declare or opt out of
+	for code, responseDef := range g.DefaultResponses {
+		codeStr := fmt.Sprintf("%d", code)
+		if _, exists := operation.Responses[codeStr]; exists || skipsDefaultResponse(info.Operation, code) {
+			continue
+		}
+		if responseDef.Ref != "" {
+			operation.Responses[codeStr] = OpenAPIResponse{Ref: ResponseRef(responseDef.Ref)}
+			continue
+		}
+		operation.Responses[codeStr] = schemaResponse(responseDef.Schema, responseDef.Description, responseDef.ContentType)
+	}
+
+	// Deprecation headers are sent on every response, including errors
+	if info.Operation.Deprecation != nil {
+		documentDeprecationHeaders(&operation, *info.Operation.Deprecation)
+	}
+
+	// The request ID is echoed on every response, including errors
+	if info.Operation.RequestIDHeader != "" {
+		documentRequestIDHeader(&operation, info.Operation.RequestIDHeader)
+	}
+
+	// Caching headers are only sent on successful responses
+	if info.Operation.Cache != nil {
+		documentCaching(&operation, *info.Operation.Cache)
+	}
+
+	// Accepted jobs point clients to their status
+	if info.Operation.JobStatus != nil {
+		documentAsync(&operation)
+	}
+
+	// Parameters and responses with reusable components are documented as references to them
+	g.referenceComponents(&operation)
+
+	return operation
+}
+
+// documentDeprecation marks an operation deprecated and records its sunset details
+// Response headers are added after responses are built, so this only sets operation-level fields
+func documentDeprecation(operation *OpenAPIOperation, deprecation goop.Deprecation) {
+	deprecated := true
+	operation.Deprecated = &deprecated
+
+	details := map[string]interface{}{}
+	if !deprecation.Since.IsZero() {
+		details["since"] = deprecation.Since.UTC().Format(time.RFC3339)
+	}
+	if !deprecation.Sunset.IsZero() {
+		details["sunset"] = deprecation.Sunset.UTC().Format(time.RFC3339)
+	}
+	if deprecation.Successor != "" {
+		details["successor"] = deprecation.Successor
+	}
+	operation.SetExtension(DeprecationExtension, details)
+}
+
+// documentDeprecationHeaders adds the Deprecation and Sunset headers to every response of an operation
+func documentDeprecationHeaders(operation *OpenAPIOperation, deprecation goop.Deprecation) {
+	headers := map[string]OpenAPIHeader{
+		goop.DeprecationHeader: {
+			Description: "Date the operation was deprecated, as an RFC 9745 structured date",
+			Schema:      &goop.OpenAPISchema{Type: "string"},
+		},
+	}
+	if !deprecation.Sunset.IsZero() {
+		headers[goop.SunsetHeader] = OpenAPIHeader{
+			Description: "Date after which the operation will be removed, as an HTTP-date",
+			Schema:      &goop.OpenAPISchema{Type: "string"},
+		}
+	}
+
+	for code, response := range operation.Responses {
+		if response.Headers == nil {
+			response.Headers = make(map[string]OpenAPIHeader)
+		}
+		for name, header := range headers {
+			response.Headers[name] = header
+		}
+		operation.Responses[code] = response
+	}
+}
+
+// documentRateLimit adds the rate limit extension, response headers, and 429 response to an operation
+func documentRateLimit(operation *OpenAPIOperation, limit goop.RateLimit) {
+	operation.SetExtension(RateLimitExtension, map[string]interface{}{
+		"requests": limit.Requests,
+		"windowMs": limit.Window.Milliseconds(),
+	})
+
+	headers := map[string]OpenAPIHeader{
+		goop.RateLimitLimitHeader: {
+			Description: "Maximum number of requests allowed in the current window",
+			Schema:      &goop.OpenAPISchema{Type: "integer"},
+		},
+		goop.RateLimitRemainingHeader: {
+			Description: "Number of requests remaining in the current window",
+			Schema:      &goop.OpenAPISchema{Type: "integer"},
+		},
+		goop.RateLimitResetHeader: {
+			Description: "Seconds until the current window resets",
+			Schema:      &goop.OpenAPISchema{Type: "integer"},
+		},
+	}
+
+	for code, response := range operation.Responses {
+		if !strings.HasPrefix(code, "2") {
+			continue
+		}
+		if response.Headers == nil {
+			response.Headers = make(map[string]OpenAPIHeader)
+		}
+		for name, header := range headers {
+			response.Headers[name] = header
+		}
+		operation.Responses[code] = response
+	}
+
+	if _, exists := operation.Responses["429"]; !exists {
+		operation.Responses["429"] = OpenAPIResponse{
+			Description: getStandardErrorDescription(429),
+			Headers:     headers,
+		}
+	}
+}
+
+// schemaResponse builds a response documented by schema, served as application/json unless contentType is set
+func schemaResponse(schema goop.Schema, description, contentType string) OpenAPIResponse {
+	response := OpenAPIResponse{
+		Description: description,
+	}
+
+	// Add schema if present
+	if enhanced, ok := schema.(goop.EnhancedSchema); ok {
+		openAPISchema := enhanced.ToOpenAPISchema()
+		mediaType := OpenAPIMediaType{
+			Schema: openAPISchema,
+		}
+
+		// Add example from schema if available
+		if openAPISchema.Example != nil {
+			mediaType.Example = openAPISchema.Example
+		}
+
+		if contentType == "" {
+			contentType = "application/json"
+		}
+		response.Content = map[string]OpenAPIMediaType{
+			contentType: mediaType,
+		}
+	}
+
+	return response
+}
+
+// skipsDefaultResponse reports whether the operation opted out of the default response for code
+func skipsDefaultResponse(op *CompiledOperation, code int) bool {
+	for _, skipped := range op.SkippedDefaultResponses {
+		if skipped == code {
+			return true
+		}
+	}
+	return false
+}
+
+// hasRequestValidation reports whether the operation validates any part of the request
+func hasRequestValidation(op *CompiledOperation) bool {
+	return op.ParamsSchema != nil || op.QuerySchema != nil || op.BodySchema != nil || op.HeaderSchema != nil
+}
+
+// validationErrorResponse builds the standard 400 response for request validation failures
+func validationErrorResponse() OpenAPIResponse {
+	schema := RequestErrorSchema.(goop.EnhancedSchema).ToOpenAPISchema()
+	return OpenAPIResponse{
+		Description: "Validation Error - The request failed schema validation",
+		Content: map[string]OpenAPIMediaType{
+			"application/json": {
+				Schema:  schema,
+				Example: schema.Example,
+			},
+		},
+	}
+}
+
+// standardErrorResponse builds the documented response for a standard error status code
+func standardErrorResponse(code int) OpenAPIResponse {
+	schema := GetStandardErrorSchema(code).(goop.EnhancedSchema).ToOpenAPISchema()
+	return OpenAPIResponse{
+		Description: getStandardErrorDescription(code),
+		Content: map[string]OpenAPIMediaType{
+			"application/json": {
+				Schema:  schema,
+				Example: schema.Example,
+			},
+		},
+	}
 }
 
 // extractPathParameters extracts path parameters from the schema and path
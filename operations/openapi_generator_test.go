@@ -4,12 +4,16 @@ import (
 	"bytes"
 	"encoding/json"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"testing/fstest"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
 	goop "github.com/picogrid/go-op"
+	"github.com/picogrid/go-op/validators"
 )
 
 // TestNewOpenAPIGenerator tests OpenAPI generator creation
@@ -789,3 +793,665 @@ func TestParameterExtraction(t *testing.T) {
 		}
 	})
 }
+
+// TestOperationTimeoutExtension tests that declared timeouts are surfaced as spec extensions
+func TestOperationTimeoutExtension(t *testing.T) {
+	t.Run("Timeout is emitted as x-timeout-ms", func(t *testing.T) {
+		generator := NewOpenAPIGenerator("Test API", "1.0.0")
+
+		op := NewSimple().
+			GET("/reports").
+			Timeout(1500 * time.Millisecond).
+			Handler(func(c *gin.Context) {})
+
+		if op.Timeout != 1500*time.Millisecond {
+			t.Errorf("Expected compiled timeout 1.5s, got %s", op.Timeout)
+		}
+
+		if err := generator.Process(OperationInfo{Method: op.Method, Path: op.Path, Operation: &op}); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		operation := generator.Spec.Paths["/reports"]["get"]
+		if operation.Extensions[TimeoutExtension] != int64(1500) {
+			t.Errorf("Expected %s to be 1500, got %v", TimeoutExtension, operation.Extensions[TimeoutExtension])
+		}
+
+		data, err := json.Marshal(operation)
+		if err != nil {
+			t.Fatalf("Failed to marshal operation: %v", err)
+		}
+		if !strings.Contains(string(data), `"x-timeout-ms":1500`) {
+			t.Errorf("Expected inline extension in JSON, got %s", data)
+		}
+
+		var decoded OpenAPIOperation
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("Failed to unmarshal operation: %v", err)
+		}
+		if decoded.Extensions[TimeoutExtension] != float64(1500) {
+			t.Errorf("Expected extension to round-trip, got %v", decoded.Extensions)
+		}
+	})
+
+	t.Run("No timeout leaves extensions empty", func(t *testing.T) {
+		generator := NewOpenAPIGenerator("Test API", "1.0.0")
+		op := NewSimple().GET("/reports").Handler(func(c *gin.Context) {})
+
+		if err := generator.Process(OperationInfo{Method: op.Method, Path: op.Path, Operation: &op}); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		data, err := json.Marshal(generator.Spec.Paths["/reports"]["get"])
+		if err != nil {
+			t.Fatalf("Failed to marshal operation: %v", err)
+		}
+		if strings.Contains(string(data), "x-timeout-ms") {
+			t.Errorf("Expected no timeout extension, got %s", data)
+		}
+		if _, exists := generator.Spec.Paths["/reports"]["get"].Responses["504"]; exists {
+			t.Error("Expected no 504 response without a timeout")
+		}
+	})
+
+	t.Run("Timeout documents a 504 response", func(t *testing.T) {
+		generator := NewOpenAPIGenerator("Test API", "1.0.0")
+		op := NewSimple().GET("/reports").Timeout(5 * time.Second).Handler(func(c *gin.Context) {})
+
+		if err := generator.Process(OperationInfo{Method: op.Method, Path: op.Path, Operation: &op}); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		response, exists := generator.Spec.Paths["/reports"]["get"].Responses["504"]
+		if !exists {
+			t.Fatal("Expected 504 response to be documented")
+		}
+		if response.Content["application/json"].Schema == nil {
+			t.Error("Expected 504 response to have an error schema")
+		}
+	})
+}
+
+// TestDefaultResponses tests generator-wide default responses
+func TestDefaultResponses(t *testing.T) {
+	userSchema := validators.Object(map[string]interface{}{
+		"id": validators.String().Required(),
+	}).Required()
+
+	process := func(t *testing.T, generator *OpenAPIGenerator, op CompiledOperation) OpenAPIOperation {
+		t.Helper()
+		if err := generator.Process(OperationInfo{Method: op.Method, Path: op.Path, Operation: &op}); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		return generator.Spec.Paths[op.Path][strings.ToLower(op.Method)]
+	}
+
+	newGenerator := func() *OpenAPIGenerator {
+		generator := NewOpenAPIGenerator("Test API", "1.0.0")
+		generator.SetDefaultResponses(StandardErrorResponses(401, 403, 429, 500))
+		return generator
+	}
+
+	t.Run("Adds default responses to every operation", func(t *testing.T) {
+		op := NewSimple().
+			GET("/users/{id}").
+			WithSuccessResponse(200, userSchema, "OK").
+			Handler(func(c *gin.Context) {})
+
+		operation := process(t, newGenerator(), op)
+		for _, code := range []string{"200", "401", "403", "429", "500"} {
+			if _, ok := operation.Responses[code]; !ok {
+				t.Errorf("Expected %s response to be documented", code)
+			}
+		}
+		schema := operation.Responses["401"].Content["application/json"].Schema
+		if schema == nil || schema.Properties["error"] == nil {
+			t.Errorf("Expected standard error schema for 401, got %+v", schema)
+		}
+	})
+
+	t.Run("Keeps responses declared by the operation", func(t *testing.T) {
+		op := NewSimple().
+			GET("/users/{id}").
+			WithSuccessResponse(200, userSchema, "OK").
+			WithErrorResponse(403, ForbiddenErrorSchema, "Only the account owner can read this user").
+			Handler(func(c *gin.Context) {})
+
+		operation := process(t, newGenerator(), op)
+		if operation.Responses["403"].Description != "Only the account owner can read this user" {
+			t.Errorf("Expected declared 403 response to be preserved, got %q", operation.Responses["403"].Description)
+		}
+	})
+
+	t.Run("Skips opted out codes", func(t *testing.T) {
+		op := NewSimple().
+			GET("/health").
+			NoAuth().
+			WithSuccessResponse(200, userSchema, "OK").
+			WithoutDefaultResponses(401, 403).
+			Handler(func(c *gin.Context) {})
+
+		operation := process(t, newGenerator(), op)
+		for _, code := range []string{"401", "403"} {
+			if _, ok := operation.Responses[code]; ok {
+				t.Errorf("Expected %s response to be skipped", code)
+			}
+		}
+		if _, ok := operation.Responses["500"]; !ok {
+			t.Error("Expected 500 response to be documented")
+		}
+	})
+
+	t.Run("Keeps rate limit headers on 429", func(t *testing.T) {
+		op := NewSimple().
+			GET("/users").
+			WithSuccessResponse(200, userSchema, "OK").
+			RateLimit(goop.RateLimit{Requests: 10, Window: time.Minute}).
+			Handler(func(c *gin.Context) {})
+
+		operation := process(t, newGenerator(), op)
+		if _, ok := operation.Responses["429"].Headers[goop.RateLimitLimitHeader]; !ok {
+			t.Error("Expected the rate limit 429 response to take precedence over the default")
+		}
+	})
+}
+
+// TestAutoValidationErrors tests automatic 400 documentation for validated operations
+func TestAutoValidationErrors(t *testing.T) {
+	bodySchema := validators.Object(map[string]interface{}{
+		"name": validators.String().Required(),
+	}).Required()
+
+	process := func(t *testing.T, generator *OpenAPIGenerator, op CompiledOperation) OpenAPIOperation {
+		t.Helper()
+		if err := generator.Process(OperationInfo{Method: op.Method, Path: op.Path, Operation: &op}); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		return generator.Spec.Paths[op.Path][strings.ToLower(op.Method)]
+	}
+
+	t.Run("Adds validation error response when enabled", func(t *testing.T) {
+		generator := NewOpenAPIGenerator("Test API", "1.0.0")
+		generator.SetAutoValidationErrors(true)
+
+		op := NewSimple().
+			POST("/users").
+			WithBody(bodySchema).
+			WithCreatedResponse(bodySchema).
+			Handler(func(c *gin.Context) {})
+
+		operation := process(t, generator, op)
+		response, ok := operation.Responses["400"]
+		if !ok {
+			t.Fatal("Expected 400 response to be added")
+		}
+		schema := response.Content["application/json"].Schema
+		if schema == nil || schema.Properties["details"] == nil || schema.Properties["message"] != nil {
+			t.Errorf("Expected the error body validated handlers send, got %+v", schema)
+		}
+	})
+
+	t.Run("Keeps explicitly declared 400 response", func(t *testing.T) {
+		generator := NewOpenAPIGenerator("Test API", "1.0.0")
+		generator.SetAutoValidationErrors(true)
+
+		op := NewSimple().
+			POST("/users").
+			WithBody(bodySchema).
+			WithCreatedResponse(bodySchema).
+			WithBadRequestError(BadRequestErrorSchema).
+			Handler(func(c *gin.Context) {})
+
+		operation := process(t, generator, op)
+		if operation.Responses["400"].Description == validationErrorResponse().Description {
+			t.Errorf("Expected declared 400 response to be preserved, got %q", operation.Responses["400"].Description)
+		}
+	})
+
+	t.Run("Skips operations without request validation", func(t *testing.T) {
+		generator := NewOpenAPIGenerator("Test API", "1.0.0")
+		generator.SetAutoValidationErrors(true)
+
+		op := NewSimple().
+			GET("/health").
+			WithSuccessResponse(200, bodySchema, "OK").
+			Handler(func(c *gin.Context) {})
+
+		if _, ok := process(t, generator, op).Responses["400"]; ok {
+			t.Error("Expected no 400 response for operation without validation")
+		}
+	})
+
+	t.Run("Disabled by default", func(t *testing.T) {
+		generator := NewOpenAPIGenerator("Test API", "1.0.0")
+
+		op := NewSimple().
+			POST("/users").
+			WithBody(bodySchema).
+			WithCreatedResponse(bodySchema).
+			Handler(func(c *gin.Context) {})
+
+		if _, ok := process(t, generator, op).Responses["400"]; ok {
+			t.Error("Expected no 400 response when option is disabled")
+		}
+	})
+}
+
+// TestOperationIdempotencyExtension tests retry-safety classification in the spec
+// TestSetExtensionPrefix tests that extension names are given the x- prefix OpenAPI requires
+func TestSetExtensionPrefix(t *testing.T) {
+	var operation OpenAPIOperation
+	operation.SetExtension("internal", true)
+	operation.SetExtension("x-owner", "payments")
+	if operation.Extensions["x-internal"] != true || operation.Extensions["x-owner"] != "payments" || len(operation.Extensions) != 2 {
+		t.Errorf("Expected x-internal and x-owner, got %v", operation.Extensions)
+	}
+
+	var info OpenAPIInfo
+	info.SetExtension("logo", "logo.png")
+	if info.Extensions["x-logo"] != "logo.png" || len(info.Extensions) != 1 {
+		t.Errorf("Expected x-logo, got %v", info.Extensions)
+	}
+}
+
+func TestOperationIdempotencyExtension(t *testing.T) {
+	t.Run("Idempotency is emitted as x-idempotency", func(t *testing.T) {
+		generator := NewOpenAPIGenerator("Test API", "1.0.0")
+
+		op := NewSimple().
+			PUT("/users/{id}").
+			Idempotency(Idempotent).
+			Handler(func(c *gin.Context) {})
+
+		if err := generator.Process(OperationInfo{Method: op.Method, Path: op.Path, Operation: &op}); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		operation := generator.Spec.Paths["/users/{id}"]["put"]
+		if operation.Extensions[IdempotencyExtension] != "idempotent" {
+			t.Errorf("Expected %s to be 'idempotent', got %v", IdempotencyExtension, operation.Extensions[IdempotencyExtension])
+		}
+	})
+
+	t.Run("Unclassified operations omit the extension", func(t *testing.T) {
+		generator := NewOpenAPIGenerator("Test API", "1.0.0")
+
+		op := NewSimple().POST("/users").Handler(func(c *gin.Context) {})
+
+		if err := generator.Process(OperationInfo{Method: op.Method, Path: op.Path, Operation: &op}); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		if _, ok := generator.Spec.Paths["/users"]["post"].Extensions[IdempotencyExtension]; ok {
+			t.Error("Expected no idempotency extension")
+		}
+	})
+}
+
+// TestQueryParameterStyles tests style and explode emission for query parameters
+func TestQueryParameterStyles(t *testing.T) {
+	generator := NewOpenAPIGenerator("Test API", "1.0.0")
+
+	op := NewSimple().
+		GET("/items").
+		WithQuery(validators.Object(map[string]interface{}{
+			"filter": validators.Object(map[string]interface{}{
+				"status": validators.String().Optional(),
+			}).Optional(),
+			"ids":   validators.Array(validators.String().Required()).Optional(),
+			"limit": validators.Number().Optional(),
+		}).Required()).
+		QueryStyle("filter", StyleDeepObject, true).
+		QueryStyle("ids", StylePipeDelimited, false).
+		Handler(func(c *gin.Context) {})
+
+	if err := generator.Process(OperationInfo{Method: op.Method, Path: op.Path, Operation: &op}); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	params := make(map[string]OpenAPIParameter)
+	for _, param := range generator.Spec.Paths["/items"]["get"].Parameters {
+		params[param.Name] = param
+	}
+
+	if params["filter"].Style != "deepObject" || params["filter"].Explode == nil || !*params["filter"].Explode {
+		t.Errorf("Expected filter to be deepObject with explode, got %+v", params["filter"])
+	}
+	if params["ids"].Style != "pipeDelimited" || params["ids"].Explode == nil || *params["ids"].Explode {
+		t.Errorf("Expected ids to be pipeDelimited without explode, got %+v", params["ids"])
+	}
+	if params["limit"].Style != "" || params["limit"].Explode != nil {
+		t.Errorf("Expected limit to use default serialization, got %+v", params["limit"])
+	}
+}
+
+// TestMaxBodyBytesSpec tests that body size limits are documented
+func TestMaxBodyBytesSpec(t *testing.T) {
+	generator := NewOpenAPIGenerator("Test API", "1.0.0")
+	op := NewSimple().POST("/notifications/bulk").MaxBodyBytes(1 << 20).Handler(func(c *gin.Context) {})
+
+	if err := generator.Process(OperationInfo{Method: op.Method, Path: op.Path, Operation: &op}); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	operation := generator.Spec.Paths["/notifications/bulk"]["post"]
+	if operation.Extensions[MaxBodyBytesExtension] != int64(1<<20) {
+		t.Errorf("Expected %s to be 1048576, got %v", MaxBodyBytesExtension, operation.Extensions[MaxBodyBytesExtension])
+	}
+	response, exists := operation.Responses["413"]
+	if !exists {
+		t.Fatal("Expected 413 response to be documented")
+	}
+	if response.Description != getStandardErrorDescription(413) {
+		t.Errorf("Expected standard 413 description, got %q", response.Description)
+	}
+}
+
+// TestAPIVersionSpec tests version annotation and per-version spec filtering
+func TestAPIVersionSpec(t *testing.T) {
+	v1 := NewSimple().GET("/users").Summary("List users v1").Version("2023-01-15").Handler(func(c *gin.Context) {})
+	v2 := NewSimple().GET("/users").Summary("List users v2").Version("2024-06-01").Handler(func(c *gin.Context) {})
+	health := NewSimple().GET("/health").Handler(func(c *gin.Context) {})
+
+	process := func(t *testing.T, generator *OpenAPIGenerator, ops ...CompiledOperation) {
+		t.Helper()
+		for _, op := range ops {
+			op := op
+			info := OperationInfo{Method: op.Method, Path: op.Path, Summary: op.Summary, Operation: &op}
+			if err := generator.Process(info); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+		}
+	}
+
+	t.Run("Versioned operations are annotated", func(t *testing.T) {
+		generator := NewOpenAPIGenerator("Test API", "1.0.0")
+		process(t, generator, v1)
+
+		if got := generator.Spec.Paths["/users"]["get"].Extensions[VersionExtension]; got != "2023-01-15" {
+			t.Errorf("Expected %s to be 2023-01-15, got %v", VersionExtension, got)
+		}
+	})
+
+	t.Run("APIVersion selects one version per spec", func(t *testing.T) {
+		generator := NewOpenAPIGenerator("Test API", "2023-01-15")
+		generator.SetAPIVersion("2023-01-15")
+		process(t, generator, v1, v2, health)
+
+		if got := generator.Spec.Paths["/users"]["get"].Summary; got != "List users v1" {
+			t.Errorf("Expected v1 operation, got %q", got)
+		}
+		if _, exists := generator.Spec.Paths["/health"]["get"]; !exists {
+			t.Error("Expected unversioned operation to be included in every version")
+		}
+	})
+
+	t.Run("A combined spec documents every version", func(t *testing.T) {
+		generator := NewOpenAPIGenerator("Test API", "1.0.0")
+		process(t, generator, v2, v1)
+
+		operation := generator.Spec.Paths["/users"]["get"]
+		if operation.Summary != "List users v2" {
+			t.Errorf("Expected the latest version to be documented, got %q", operation.Summary)
+		}
+		data, err := json.Marshal(operation)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		var documented map[string]interface{}
+		if err := json.Unmarshal(data, &documented); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		versions, ok := documented[VersionsExtension].(map[string]interface{})
+		if !ok || len(versions) != 1 {
+			t.Fatalf("Expected %s to hold the other version, got %v", VersionsExtension, documented[VersionsExtension])
+		}
+		older, _ := versions["2023-01-15"].(map[string]interface{})
+		if older["summary"] != "List users v1" || older[VersionExtension] != "2023-01-15" {
+			t.Errorf("Expected the v1 operation under %s, got %v", VersionsExtension, versions)
+		}
+
+		// Re-registering a version replaces it rather than adding another
+		process(t, generator, v1)
+		if versions := generator.Spec.Paths["/users"]["get"].Extensions[VersionsExtension].(map[string]OpenAPIOperation); len(versions) != 1 {
+			t.Errorf("Expected one other version, got %d", len(versions))
+		}
+	})
+}
+
+// TestDeprecationSpec tests that deprecated operations are marked and document their headers
+func TestDeprecationSpec(t *testing.T) {
+	since := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	sunset := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	generator := NewOpenAPIGenerator("Test API", "1.0.0")
+	op := NewSimple().
+		GET("/legacy/users").
+		Deprecated(since, sunset, "listUsers").
+		WithNotFoundError(NotFoundErrorSchema).
+		Handler(func(c *gin.Context) {})
+
+	if err := generator.Process(OperationInfo{Method: op.Method, Path: op.Path, Operation: &op}); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	operation := generator.Spec.Paths["/legacy/users"]["get"]
+	if operation.Deprecated == nil || !*operation.Deprecated {
+		t.Error("Expected operation to be marked deprecated")
+	}
+
+	details, ok := operation.Extensions[DeprecationExtension].(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected %s extension, got %v", DeprecationExtension, operation.Extensions)
+	}
+	if details["sunset"] != "2025-01-01T00:00:00Z" || details["successor"] != "listUsers" {
+		t.Errorf("Unexpected deprecation details: %v", details)
+	}
+
+	for code, response := range operation.Responses {
+		if _, exists := response.Headers[DeprecationHeader]; !exists {
+			t.Errorf("Expected response %s to document the Deprecation header", code)
+		}
+		if _, exists := response.Headers[SunsetHeader]; !exists {
+			t.Errorf("Expected response %s to document the Sunset header", code)
+		}
+	}
+}
+
+// TestCachingSpec tests that cacheable operations document caching headers on successful responses
+func TestCachingSpec(t *testing.T) {
+	generator := NewOpenAPIGenerator("Test API", "1.0.0")
+	op := NewSimple().
+		GET("/products").
+		Cacheable(5*time.Minute, true).
+		WithNotFoundError(NotFoundErrorSchema).
+		Handler(func(c *gin.Context) {})
+
+	if err := generator.Process(OperationInfo{Method: op.Method, Path: op.Path, Operation: &op}); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	operation := generator.Spec.Paths["/products"]["get"]
+	details, ok := operation.Extensions[CacheExtension].(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected %s extension, got %v", CacheExtension, operation.Extensions)
+	}
+	if details["maxAgeSeconds"] != int64(300) || details["public"] != true {
+		t.Errorf("Unexpected cache details: %v", details)
+	}
+
+	for code, response := range operation.Responses {
+		header, exists := response.Headers[CacheControlHeader]
+		if strings.HasPrefix(code, "2") {
+			if !exists || header.Example != "public, max-age=300" {
+				t.Errorf("Expected response %s to document the Cache-Control header, got %+v", code, header)
+			}
+			if _, exists := response.Headers[ExpiresHeader]; !exists {
+				t.Errorf("Expected response %s to document the Expires header", code)
+			}
+		} else if exists {
+			t.Errorf("Expected error response %s not to document caching headers", code)
+		}
+	}
+}
+
+// TestFileResponseSpec tests that download operations document binary responses and range requests
+func TestFileResponseSpec(t *testing.T) {
+	generator := NewOpenAPIGenerator("Test API", "1.0.0")
+	op := NewSimple().
+		GET("/reports/{id}/pdf").
+		WithFileResponse("application/pdf").
+		Handler(func(c *gin.Context) {})
+
+	if err := generator.Process(OperationInfo{Method: op.Method, Path: op.Path, Operation: &op}); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	operation := generator.Spec.Paths["/reports/{id}/pdf"]["get"]
+	for _, code := range []string{"200", "206"} {
+		response, exists := operation.Responses[code]
+		if !exists {
+			t.Fatalf("Expected a %s response", code)
+		}
+		schema := response.Content["application/pdf"].Schema
+		if schema == nil || schema.Type != "string" || schema.Format != "binary" {
+			t.Errorf("Expected response %s to be a binary PDF, got %+v", code, response.Content)
+		}
+		if _, exists := response.Headers[goop.ContentDispositionHeader]; !exists {
+			t.Errorf("Expected response %s to document Content-Disposition", code)
+		}
+	}
+	if _, exists := operation.Responses["206"].Headers[goop.ContentRangeHeader]; !exists {
+		t.Error("Expected the partial response to document Content-Range")
+	}
+	if _, exists := operation.Responses["416"]; !exists {
+		t.Error("Expected a 416 response")
+	}
+}
+
+// TestMergePatchBodySpec tests documenting merge patch request bodies
+func TestMergePatchBodySpec(t *testing.T) {
+	user := validators.Object(map[string]interface{}{
+		"name":     validators.String().Required(),
+		"nickname": validators.String().Optional(),
+	}).Required()
+	op := NewSimple().PATCH("/users/{id}").WithPatchBody(user).Handler(func(c *gin.Context) {})
+
+	generator := NewOpenAPIGenerator("Test API", "1.0.0")
+	if err := NewRouter(generator).Register(op); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	body := generator.Spec.Paths["/users/{id}"]["patch"].RequestBody
+	if body == nil || !body.Required {
+		t.Fatal("Expected a required request body")
+	}
+	if _, exists := body.Content["application/json"]; exists {
+		t.Error("Expected merge patch body not to be documented as application/json")
+	}
+	mediaType, exists := body.Content[goop.MergePatchContentType]
+	if !exists {
+		t.Fatalf("Expected %s content, got %v", goop.MergePatchContentType, body.Content)
+	}
+	if len(mediaType.Schema.Required) != 0 {
+		t.Errorf("Expected no required fields in the patch schema, got %v", mediaType.Schema.Required)
+	}
+	if err := op.BodySchema.Validate(map[string]interface{}{"nickname": nil}); err != nil {
+		t.Errorf("Expected partial patch to be valid, got: %v", err)
+	}
+}
+
+// TestCustomValidationExtensionSpec tests that extensions given to Custom are documented
+func TestCustomValidationExtensionSpec(t *testing.T) {
+	luhn := func(number string) error {
+		sum := 0
+		for i := range number {
+			digit := int(number[len(number)-1-i] - '0')
+			if i%2 == 1 {
+				if digit *= 2; digit > 9 {
+					digit -= 9
+				}
+			}
+			sum += digit
+		}
+		if sum%10 != 0 {
+			return goop.NewValidationError("", number, "card number failed the Luhn check")
+		}
+		return nil
+	}
+	payment := validators.Object(map[string]interface{}{
+		"cardNumber": validators.String().Pattern(`^[0-9]{12,19}$`).Custom(luhn, Extension("x-validation", "luhn")).Required(),
+	}).Required()
+	op := NewSimple().POST("/payments").WithBody(payment).Handler(func(c *gin.Context) {})
+
+	generator := NewOpenAPIGenerator("Test API", "1.0.0")
+	if err := NewRouter(generator).Register(op); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	schema := generator.Spec.Paths["/payments"]["post"].RequestBody.Content["application/json"].Schema
+	data, err := json.Marshal(schema)
+	if err != nil {
+		t.Fatalf("Failed to marshal schema: %v", err)
+	}
+	if !strings.Contains(string(data), `"x-validation":"luhn"`) {
+		t.Errorf("Expected x-validation in the card number schema, got %s", data)
+	}
+
+	if err := payment.Validate(map[string]interface{}{"cardNumber": "4111111111111111"}); err != nil {
+		t.Errorf("Expected a valid card number, got: %v", err)
+	}
+	if err := payment.Validate(map[string]interface{}{"cardNumber": "4111111111111112"}); err == nil || !strings.Contains(err.Error(), "Luhn") {
+		t.Errorf("Expected the Luhn check to fail, got: %v", err)
+	}
+}
+
+// TestDescriptionFromFileSpec tests documenting an operation with a Markdown file
+func TestDescriptionFromFileSpec(t *testing.T) {
+	op := NewSimple().
+		POST("/orders").
+		DescriptionFromFile("docs/orders/create.md").
+		Handler(func(c *gin.Context) {})
+	if op.DescriptionFile != "docs/orders/create.md" || op.Description != "" {
+		t.Fatalf("Expected the file to be recorded, got %q and %q", op.DescriptionFile, op.Description)
+	}
+
+	generator := NewOpenAPIGenerator("Test API", "1.0.0")
+	generator.DescriptionFS = fstest.MapFS{
+		"docs/orders/create.md": {Data: []byte("Creates an order.\n\n## Payment\n\nCards are charged on shipping.\n")},
+	}
+	if err := NewRouter(generator).Register(op); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	expected := "Creates an order.\n\n## Payment\n\nCards are charged on shipping."
+	if got := generator.Spec.Paths["/orders"]["post"].Description; got != expected {
+		t.Errorf("Expected description from the file, got %q", got)
+	}
+
+	t.Run("Working directory", func(t *testing.T) {
+		dir := t.TempDir()
+		if err := os.MkdirAll(filepath.Join(dir, "docs", "orders"), 0o755); err != nil {
+			t.Fatalf("Failed to create docs: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "docs", "orders", "create.md"), []byte("Creates an order.\n"), 0o644); err != nil {
+			t.Fatalf("Failed to write description: %v", err)
+		}
+		t.Chdir(dir)
+
+		generator := NewOpenAPIGenerator("Test API", "1.0.0")
+		if err := NewRouter(generator).Register(op); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if got := generator.Spec.Paths["/orders"]["post"].Description; got != "Creates an order." {
+			t.Errorf("Expected description from the working directory, got %q", got)
+		}
+	})
+
+	t.Run("Missing file", func(t *testing.T) {
+		generator := NewOpenAPIGenerator("Test API", "1.0.0")
+		generator.DescriptionFS = fstest.MapFS{}
+		if err := NewRouter(generator).Register(op); err == nil || !strings.Contains(err.Error(), "POST /orders") {
+			t.Errorf("Expected an error naming the operation, got %v", err)
+		}
+	})
+}
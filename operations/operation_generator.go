@@ -0,0 +1,86 @@
+package operations
+
+import (
+	goop "github.com/picogrid/go-op"
+)
+
+// OperationGenerator is implemented by generators that need each operation as it is documented in
+// OpenAPI, such as SDK and gateway config generators. Unlike Generator, which receives validation
+// summaries, it receives the full parameter, request body, and response schemas, the effective
+// security requirements, and the operation's x- extensions.
+//
+// Register an OperationGenerator with a router through AsGenerator.
+type OperationGenerator interface {
+	ProcessOperation(op DocumentedOperation) error
+}
+
+// DocumentedOperation is an operation together with its complete OpenAPI documentation
+type DocumentedOperation struct {
+	OperationInfo
+
+	// Document is the operation as it appears in the generated specification, including
+	// parameters, request body, responses, and Extensions
+	Document OpenAPIOperation
+
+	// Schemas holds the definitions of the shared components referenced from Document, keyed by name
+	Schemas map[string]*goop.OpenAPISchema
+
+	// Security is the effective security requirements: the operation's own, or the global ones when it declares none
+	Security goop.SecurityRequirements
+
+	// SecuritySchemes holds the registered schemes referenced by Security, keyed by name
+	SecuritySchemes map[string]goop.SecuritySchemeObject
+}
+
+// DocumentOperation documents an operation with the generator's settings, such as default
+// responses and security schemes, without adding it to the specification
+func (g *OpenAPIGenerator) DocumentOperation(info OperationInfo) DocumentedOperation {
+	documented := DocumentedOperation{
+		OperationInfo:   info,
+		Document:        g.buildOperation(info),
+		Schemas:         make(map[string]*goop.OpenAPISchema),
+		Security:        info.Operation.Security,
+		SecuritySchemes: make(map[string]goop.SecuritySchemeObject),
+	}
+	resolveOperationSchemas(documented.Schemas, &documented.Document, g.lookupComponent)
+
+	if documented.Security == nil {
+		documented.Security = g.GlobalSecurity
+	}
+	for _, requirement := range documented.Security {
+		for name := range requirement {
+			if scheme, ok := g.SecuritySchemes[name]; ok {
+				documented.SecuritySchemes[name] = scheme.ToOpenAPI()
+			}
+		}
+	}
+
+	return documented
+}
+
+// AsGenerator adapts an OperationGenerator to the Generator interface accepted by routers
+// Operations are documented with the given OpenAPI generator's settings, or the defaults when it is nil.
+//
+// Example:
+//
+//	router := operations.NewRouter(openAPIGen, operations.AsGenerator(sdkGen, openAPIGen))
+func AsGenerator(generator OperationGenerator, documenter *OpenAPIGenerator) Generator {
+	if documenter == nil {
+		documenter = NewOpenAPIGenerator("", "")
+	}
+	return &operationGeneratorAdapter{generator: generator, documenter: documenter}
+}
+
+// operationGeneratorAdapter documents each processed operation before passing it to an OperationGenerator
+type operationGeneratorAdapter struct {
+	generator  OperationGenerator
+	documenter *OpenAPIGenerator
+}
+
+// Process documents the operation and forwards it to the wrapped generator
+func (a *operationGeneratorAdapter) Process(info OperationInfo) error {
+	if err := a.documenter.describe(&info); err != nil {
+		return err
+	}
+	return a.generator.ProcessOperation(a.documenter.DocumentOperation(info))
+}
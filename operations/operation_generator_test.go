@@ -0,0 +1,109 @@
+package operations
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	goop "github.com/picogrid/go-op"
+	"github.com/picogrid/go-op/validators"
+)
+
+// recordingGenerator records the documented operations it receives
+type recordingGenerator struct {
+	operations []DocumentedOperation
+	err        error
+}
+
+func (r *recordingGenerator) ProcessOperation(op DocumentedOperation) error {
+	r.operations = append(r.operations, op)
+	return r.err
+}
+
+func TestOperationGenerator(t *testing.T) {
+	customer := Component("DocumentedCustomer", validators.Object(map[string]interface{}{
+		"id": validators.String().Required(),
+	}).Required())
+	createOrder := NewSimple().
+		POST("/orders").
+		OperationID("createOrder").
+		WithBody(validators.Object(map[string]interface{}{
+			"customer": customer,
+			"sku":      validators.String().Required(),
+		}).Required()).
+		WithSuccessResponse(201, validators.Object(map[string]interface{}{
+			"id": validators.String().Required(),
+		}).Required(), "Order created").
+		Timeout(2 * time.Second).
+		Handler(func(c *gin.Context) {})
+	health := NewSimple().GET("/health").NoAuth().Handler(func(c *gin.Context) {})
+
+	documenter := NewOpenAPIGenerator("Orders API", "1.0.0")
+	if err := documenter.AddSecurityScheme("bearerAuth", goop.NewBearerAuth("JWT", "")); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	documenter.SetGlobalSecurity(goop.SecurityRequirements{}.RequireScheme("bearerAuth"))
+
+	recorder := &recordingGenerator{}
+	router := NewRouter(AsGenerator(recorder, documenter))
+	for _, op := range []CompiledOperation{createOrder, health} {
+		if err := router.Register(op); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+	}
+	if len(recorder.operations) != 2 {
+		t.Fatalf("Expected 2 documented operations, got %d", len(recorder.operations))
+	}
+
+	t.Run("Passes full schemas and extensions", func(t *testing.T) {
+		op := recorder.operations[0]
+		if op.Method != "POST" || op.Path != "/orders" || op.Document.OperationId != "createOrder" {
+			t.Errorf("Unexpected operation %s %s %q", op.Method, op.Path, op.Document.OperationId)
+		}
+		body := op.Document.RequestBody.Content["application/json"].Schema
+		if body.Properties["customer"].Ref != ComponentRef("DocumentedCustomer") {
+			t.Errorf("Expected customer to reference the shared component, got %+v", body.Properties["customer"])
+		}
+		if _, ok := op.Schemas["DocumentedCustomer"]; !ok {
+			t.Error("Expected referenced component definition")
+		}
+		if op.Document.Responses["201"].Description != "Order created" {
+			t.Errorf("Expected 201 response, got %+v", op.Document.Responses)
+		}
+		if op.Document.Extensions[TimeoutExtension] != int64(2000) {
+			t.Errorf("Expected timeout extension, got %v", op.Document.Extensions)
+		}
+	})
+
+	t.Run("Resolves effective security", func(t *testing.T) {
+		op := recorder.operations[0]
+		if len(op.Security) != 1 {
+			t.Fatalf("Expected global security, got %v", op.Security)
+		}
+		if _, ok := op.Security[0]["bearerAuth"]; !ok {
+			t.Errorf("Expected global security, got %v", op.Security)
+		}
+		if op.SecuritySchemes["bearerAuth"].Scheme != "bearer" {
+			t.Errorf("Expected bearerAuth scheme, got %+v", op.SecuritySchemes)
+		}
+
+		if public := recorder.operations[1]; len(public.Security) != 1 || len(public.Security[0]) != 0 {
+			t.Errorf("Expected public operation to keep its empty requirement, got %v", public.Security)
+		}
+	})
+
+	t.Run("Does not modify the documenter's spec", func(t *testing.T) {
+		if len(documenter.Spec.Paths) != 0 || len(documenter.Spec.Components.Schemas) != 0 {
+			t.Error("Expected documenting operations to leave the spec unchanged")
+		}
+	})
+
+	t.Run("Returns generator errors", func(t *testing.T) {
+		router := NewRouter(AsGenerator(&recordingGenerator{err: errors.New("unsupported")}, nil))
+		if err := router.Register(health); err == nil {
+			t.Error("Expected error")
+		}
+	})
+}
@@ -0,0 +1,227 @@
+// Package operationstest provides test doubles for asserting on generated API documentation.
+//
+// FakeGenerator records every processed operation and builds the same OpenAPI
+// specification as operations.OpenAPIGenerator, exposing query helpers so tests
+// can assert on documented contracts without string-matching JSON:
+//
+//	fake := operationstest.NewFakeGenerator()
+//	router := operations.NewRouter(fake)
+//	router.Register(createOrder)
+//
+//	if !fake.OperationByID("createOrder").HasResponse(201) { ... }
+package operationstest
+
+import (
+	"strconv"
+	"strings"
+
+	goop "github.com/picogrid/go-op"
+	"github.com/picogrid/go-op/operations"
+)
+
+// FakeGenerator is a Generator that records processed operations for inspection in tests
+type FakeGenerator struct {
+	// Processed holds every OperationInfo passed to Process, in registration order
+	Processed []operations.OperationInfo
+
+	// Spec is the underlying generator producing the documented specification
+	Spec *operations.OpenAPIGenerator
+}
+
+// NewFakeGenerator creates a fake generator backed by a real OpenAPI generator
+func NewFakeGenerator() *FakeGenerator {
+	return &FakeGenerator{
+		Spec: operations.NewOpenAPIGenerator("Test API", "0.0.0"),
+	}
+}
+
+// Process records the operation and forwards it to the underlying OpenAPI generator
+func (f *FakeGenerator) Process(info operations.OperationInfo) error {
+	f.Processed = append(f.Processed, info)
+	return f.Spec.Process(info)
+}
+
+// OperationByID returns the documented operation with the given operationId
+// The result is never nil; use Exists to check whether the operation was found
+func (f *FakeGenerator) OperationByID(id string) *Operation {
+	for path, methods := range f.Spec.Spec.Paths {
+		for method, op := range methods {
+			if op.OperationId == id {
+				op := f.Spec.Spec.Components.Dereference(op)
+				return &Operation{Method: strings.ToUpper(method), Path: path, op: &op}
+			}
+		}
+	}
+	return &Operation{}
+}
+
+// Operation returns the documented operation for the given method and path
+// The result is never nil; use Exists to check whether the operation was found
+func (f *FakeGenerator) Operation(method, path string) *Operation {
+	methods, ok := f.Spec.Spec.Paths[path]
+	if !ok {
+		return &Operation{}
+	}
+	op, ok := methods[strings.ToLower(method)]
+	if !ok {
+		return &Operation{}
+	}
+	op = f.Spec.Spec.Components.Dereference(op)
+	return &Operation{Method: strings.ToUpper(method), Path: path, op: &op}
+}
+
+// SchemaFor returns the component schema registered under name
+// The result is never nil; use Exists to check whether the schema was found
+func (f *FakeGenerator) SchemaFor(name string) *Schema {
+	return &Schema{schema: f.Spec.Spec.Components.Schemas[name]}
+}
+
+// Operation wraps a documented OpenAPI operation with query helpers
+type Operation struct {
+	Method string
+	Path   string
+	op     *operations.OpenAPIOperation
+}
+
+// Exists reports whether the operation was documented
+func (o *Operation) Exists() bool {
+	return o.op != nil
+}
+
+// Raw returns the underlying OpenAPI operation, or nil if it was not documented
+func (o *Operation) Raw() *operations.OpenAPIOperation {
+	return o.op
+}
+
+// HasResponse reports whether the operation documents a response for the status code
+func (o *Operation) HasResponse(code int) bool {
+	if o.op == nil {
+		return false
+	}
+	_, ok := o.op.Responses[strconv.Itoa(code)]
+	return ok
+}
+
+// HasTag reports whether the operation is tagged with tag
+func (o *Operation) HasTag(tag string) bool {
+	if o.op == nil {
+		return false
+	}
+	for _, t := range o.op.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// HasParameter reports whether the operation documents a parameter with the given name and location
+// Location is one of "path", "query", or "header"
+func (o *Operation) HasParameter(name, in string) bool {
+	if o.op == nil {
+		return false
+	}
+	for _, param := range o.op.Parameters {
+		if param.Name == name && param.In == in {
+			return true
+		}
+	}
+	return false
+}
+
+// RequiresScheme reports whether any security requirement of the operation references scheme
+func (o *Operation) RequiresScheme(scheme string) bool {
+	if o.op == nil {
+		return false
+	}
+	for _, requirement := range o.op.Security {
+		if _, ok := requirement[scheme]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// RequestSchema returns the JSON request body schema, or the JSON Merge Patch schema of a patch body
+func (o *Operation) RequestSchema() *Schema {
+	if o.op == nil || o.op.RequestBody == nil {
+		return &Schema{}
+	}
+	if media, ok := o.op.RequestBody.Content["application/json"]; ok {
+		return &Schema{schema: media.Schema}
+	}
+	return &Schema{schema: o.op.RequestBody.Content[goop.MergePatchContentType].Schema}
+}
+
+// ResponseSchema returns the JSON response body schema for the status code
+func (o *Operation) ResponseSchema(code int) *Schema {
+	if o.op == nil {
+		return &Schema{}
+	}
+	response, ok := o.op.Responses[strconv.Itoa(code)]
+	if !ok {
+		return &Schema{}
+	}
+	return &Schema{schema: response.Content["application/json"].Schema}
+}
+
+// Schema wraps a documented OpenAPI schema with query helpers
+type Schema struct {
+	schema *goop.OpenAPISchema
+}
+
+// Exists reports whether the schema was documented
+func (s *Schema) Exists() bool {
+	return s.schema != nil
+}
+
+// Raw returns the underlying OpenAPI schema, or nil if it was not documented
+func (s *Schema) Raw() *goop.OpenAPISchema {
+	return s.schema
+}
+
+// Type returns the schema type, or an empty string if the schema was not documented
+func (s *Schema) Type() string {
+	if s.schema == nil {
+		return ""
+	}
+	return s.schema.Type
+}
+
+// HasProperty reports whether the schema documents the named property
+func (s *Schema) HasProperty(name string) bool {
+	if s.schema == nil {
+		return false
+	}
+	_, ok := s.schema.Properties[name]
+	return ok
+}
+
+// IsRequired reports whether the named property is listed as required
+func (s *Schema) IsRequired(name string) bool {
+	if s.schema == nil {
+		return false
+	}
+	for _, required := range s.schema.Required {
+		if required == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Property returns the schema of the named property
+func (s *Schema) Property(name string) *Schema {
+	if s.schema == nil {
+		return &Schema{}
+	}
+	return &Schema{schema: s.schema.Properties[name]}
+}
+
+// Items returns the item schema of an array schema
+func (s *Schema) Items() *Schema {
+	if s.schema == nil {
+		return &Schema{}
+	}
+	return &Schema{schema: s.schema.Items}
+}
@@ -0,0 +1,118 @@
+package operationstest
+
+import (
+	"testing"
+
+	"github.com/picogrid/go-op/operations"
+	"github.com/picogrid/go-op/validators"
+)
+
+func TestFakeGenerator(t *testing.T) {
+	fake := NewFakeGenerator()
+	router := operations.NewRouter(fake)
+
+	createOrder := operations.NewSimple().
+		POST("/orders").
+		OperationID("createOrder").
+		Tags("orders").
+		RequireBearer("bearerAuth").
+		WithBody(validators.Object(map[string]interface{}{
+			"email": validators.String().Email().Required(),
+			"note":  validators.String().Optional(),
+		}).Required()).
+		WithCreatedResponse(validators.Object(map[string]interface{}{
+			"id": validators.String().Required(),
+		}).Required()).
+		WithBadRequestError(operations.BadRequestErrorSchema).
+		Handler(nil)
+
+	if err := router.Register(createOrder); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	t.Run("Records processed operations", func(t *testing.T) {
+		if len(fake.Processed) != 1 {
+			t.Fatalf("Expected 1 processed operation, got %d", len(fake.Processed))
+		}
+		if fake.Processed[0].Path != "/orders" {
+			t.Errorf("Expected path '/orders', got '%s'", fake.Processed[0].Path)
+		}
+	})
+
+	t.Run("Queries operations by ID", func(t *testing.T) {
+		op := fake.OperationByID("createOrder")
+		if !op.Exists() {
+			t.Fatal("Expected createOrder to exist")
+		}
+		if op.Method != "POST" || op.Path != "/orders" {
+			t.Errorf("Expected POST /orders, got %s %s", op.Method, op.Path)
+		}
+		if !op.HasResponse(201) || !op.HasResponse(400) {
+			t.Error("Expected 201 and 400 responses")
+		}
+		if op.HasResponse(404) {
+			t.Error("Expected no 404 response")
+		}
+		if !op.HasTag("orders") {
+			t.Error("Expected orders tag")
+		}
+		if !op.RequiresScheme("bearerAuth") {
+			t.Error("Expected bearerAuth security requirement")
+		}
+	})
+
+	t.Run("Queries operations by method and path", func(t *testing.T) {
+		if !fake.Operation("post", "/orders").Exists() {
+			t.Error("Expected POST /orders to exist")
+		}
+		if fake.Operation("GET", "/orders").Exists() {
+			t.Error("Expected GET /orders to not exist")
+		}
+	})
+
+	t.Run("Queries request and response schemas", func(t *testing.T) {
+		op := fake.OperationByID("createOrder")
+
+		body := op.RequestSchema()
+		if !body.HasProperty("email") || !body.IsRequired("email") {
+			t.Error("Expected required email property in request body")
+		}
+		if body.IsRequired("note") {
+			t.Error("Expected note to be optional")
+		}
+		if body.Property("email").Type() != "string" {
+			t.Errorf("Expected email type 'string', got '%s'", body.Property("email").Type())
+		}
+
+		if !op.ResponseSchema(201).HasProperty("id") {
+			t.Error("Expected id property in 201 response")
+		}
+	})
+
+	t.Run("Queries merge patch request schemas", func(t *testing.T) {
+		patchOrder := operations.NewSimple().
+			PATCH("/orders/{id}").
+			OperationID("patchOrder").
+			WithPatchBody(validators.Object(map[string]interface{}{
+				"note": validators.String().Required(),
+			}).Required()).
+			Handler(nil)
+		if err := router.Register(patchOrder); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if !fake.OperationByID("patchOrder").RequestSchema().HasProperty("note") {
+			t.Error("Expected note property in merge patch request body")
+		}
+	})
+
+	t.Run("Missing lookups are safe to chain", func(t *testing.T) {
+		missing := fake.OperationByID("deleteOrder")
+		if missing.Exists() || missing.HasResponse(200) || missing.RequestSchema().HasProperty("id") {
+			t.Error("Expected missing operation queries to report false")
+		}
+		if fake.SchemaFor("User").HasProperty("email") {
+			t.Error("Expected missing component schema to have no properties")
+		}
+	})
+}
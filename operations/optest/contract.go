@@ -0,0 +1,246 @@
+package optest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	goop "github.com/picogrid/go-op"
+	"github.com/picogrid/go-op/operations"
+	"github.com/picogrid/go-op/validators"
+)
+
+// VerifyContract replays the documented examples of every operation in spec against the
+// service at baseURL and checks that each response uses a status code the operation declares
+// and that JSON bodies validate against the declared response schema.
+//
+// Requests are built from parameter and request body examples. Operations whose required
+// parameters or body have no example are skipped, since no valid request can be formed.
+// Each operation runs as a subtest named after its method and path.
+//
+//	func TestStagingContract(t *testing.T) {
+//	    spec := loadPublishedSpec(t)
+//	    optest.VerifyContract(t, "https://staging.example.com", spec,
+//	        optest.WithHeader("Authorization", "Bearer "+os.Getenv("STAGING_TOKEN")))
+//	}
+func VerifyContract(t *testing.T, baseURL string, spec *operations.OpenAPISpec, opts ...Option) {
+	t.Helper()
+	c := newConfig(opts)
+
+	var components map[string]*goop.OpenAPISchema
+	if spec.Components != nil {
+		components = spec.Components.Schemas
+	}
+
+	paths := make([]string, 0, len(spec.Paths))
+	for path := range spec.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		methods := make([]string, 0, len(spec.Paths[path]))
+		for method := range spec.Paths[path] {
+			methods = append(methods, method)
+		}
+		sort.Strings(methods)
+		for _, method := range methods {
+			v := &contractVerifier{
+				baseURL:    strings.TrimSuffix(baseURL, "/"),
+				method:     strings.ToUpper(method),
+				path:       path,
+				op:         spec.Components.Dereference(spec.Paths[path][method]),
+				components: components,
+				config:     c,
+			}
+			t.Run(v.method+" "+path, func(t *testing.T) {
+				v.verify(t)
+			})
+		}
+	}
+}
+
+// contractVerifier replays one documented operation
+type contractVerifier struct {
+	baseURL    string
+	method     string
+	path       string
+	op         operations.OpenAPIOperation
+	components map[string]*goop.OpenAPISchema
+	config     *config
+}
+
+func (v *contractVerifier) verify(t testing.TB) {
+	req, skipReason, err := v.request()
+	if err != nil {
+		t.Fatalf("cannot build request: %v", err)
+		return
+	}
+	if skipReason != "" {
+		t.Skip(skipReason)
+		return
+	}
+
+	resp, err := v.config.client.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+		return
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("cannot read response: %v", err)
+		return
+	}
+
+	response, declared := v.op.Responses[strconv.Itoa(resp.StatusCode)]
+	if !declared {
+		response, declared = v.op.Responses["default"]
+	}
+	if !declared {
+		t.Errorf("status %d is not declared; declared statuses are %s", resp.StatusCode, v.declaredStatuses())
+		return
+	}
+
+	mediaType, ok := response.Content["application/json"]
+	if !ok || mediaType.Schema == nil || len(data) == 0 {
+		return
+	}
+	schema, err := validators.FromOpenAPISchema(mediaType.Schema, v.components)
+	if err != nil {
+		t.Fatalf("cannot build validator for the %d response: %v", resp.StatusCode, err)
+		return
+	}
+	var body interface{}
+	if err := json.Unmarshal(data, &body); err != nil {
+		t.Errorf("status %d returned a non-JSON body: %v", resp.StatusCode, err)
+		return
+	}
+	if err := schema.Validate(body); err != nil {
+		t.Errorf("status %d returned a body that violates the declared schema: %v", resp.StatusCode, err)
+	}
+}
+
+// request builds the request from documented examples
+// A non-empty skip reason means a required value has no example
+func (v *contractVerifier) request() (*http.Request, string, error) {
+	path := v.path
+	query := url.Values{}
+	headers := http.Header{}
+	for _, param := range v.op.Parameters {
+		example, ok := parameterExample(param)
+		if !ok {
+			if param.Required {
+				return nil, fmt.Sprintf("no example for required %s parameter %q", param.In, param.Name), nil
+			}
+			continue
+		}
+		switch param.In {
+		case "path":
+			path = strings.ReplaceAll(path, "{"+param.Name+"}", url.PathEscape(formatValue(example)))
+		case "query":
+			if items, ok := example.([]interface{}); ok {
+				for _, item := range items {
+					query.Add(param.Name, formatValue(item))
+				}
+			} else {
+				query.Set(param.Name, formatValue(example))
+			}
+		case "header":
+			headers.Set(param.Name, formatValue(example))
+		}
+	}
+	if encoded := query.Encode(); encoded != "" {
+		path += "?" + encoded
+	}
+
+	var body io.Reader
+	if v.op.RequestBody != nil {
+		example, ok := bodyExample(v.op.RequestBody)
+		if ok {
+			data, err := json.Marshal(example)
+			if err != nil {
+				return nil, "", fmt.Errorf("invalid request body example: %w", err)
+			}
+			body = bytes.NewReader(data)
+			headers.Set("Content-Type", "application/json")
+		} else if v.op.RequestBody.Required {
+			return nil, "no example for the required request body", nil
+		}
+	}
+
+	req, err := http.NewRequest(v.method, v.baseURL+path, body)
+	if err != nil {
+		return nil, "", err
+	}
+	for name, values := range headers {
+		req.Header[name] = values
+	}
+	for name, values := range v.config.headers {
+		req.Header[name] = values
+	}
+	return req, "", nil
+}
+
+// declaredStatuses lists the documented response statuses in order
+func (v *contractVerifier) declaredStatuses() string {
+	statuses := make([]string, 0, len(v.op.Responses))
+	for status := range v.op.Responses {
+		statuses = append(statuses, status)
+	}
+	sort.Strings(statuses)
+	return strings.Join(statuses, ", ")
+}
+
+// parameterExample returns the documented example for a parameter
+func parameterExample(param operations.OpenAPIParameter) (interface{}, bool) {
+	if param.Example != nil {
+		return param.Example, true
+	}
+	if example, ok := firstExample(param.Examples); ok {
+		return example, true
+	}
+	if param.Schema != nil && param.Schema.Example != nil {
+		return param.Schema.Example, true
+	}
+	return nil, false
+}
+
+// bodyExample returns the documented JSON request body example
+func bodyExample(body *operations.OpenAPIRequestBody) (interface{}, bool) {
+	mediaType, ok := body.Content["application/json"]
+	if !ok {
+		return nil, false
+	}
+	if mediaType.Example != nil {
+		return mediaType.Example, true
+	}
+	if example, ok := firstExample(mediaType.Examples); ok {
+		return example, true
+	}
+	if mediaType.Schema != nil && mediaType.Schema.Example != nil {
+		return mediaType.Schema.Example, true
+	}
+	return nil, false
+}
+
+// firstExample returns the value of the alphabetically first named example
+func firstExample(examples map[string]operations.OpenAPIExample) (interface{}, bool) {
+	names := make([]string, 0, len(examples))
+	for name, example := range examples {
+		if example.Value != nil {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil, false
+	}
+	sort.Strings(names)
+	return examples[names[0]].Value, true
+}
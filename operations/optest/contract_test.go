@@ -0,0 +1,108 @@
+package optest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/picogrid/go-op/operations"
+	ginadapter "github.com/picogrid/go-op/operations/adapters/gin"
+	"github.com/picogrid/go-op/validators"
+)
+
+// newContractServer serves a documented API; brokenResponse makes getUser return an invalid email
+func newContractServer(t *testing.T, brokenResponse bool) (*httptest.Server, *operations.OpenAPISpec) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	generator := operations.NewOpenAPIGenerator("Users", "1.0.0")
+	router := ginadapter.NewGinRouter(engine, generator)
+	router.SetResponseValidation(operations.Never)
+
+	engine.Use(func(c *gin.Context) {
+		if c.GetHeader("Authorization") != "Bearer token" {
+			c.AbortWithStatus(http.StatusUnauthorized)
+		}
+	})
+
+	paramsSchema := validators.Object(map[string]interface{}{
+		"id": validators.String().Pattern("^[a-z0-9]+$").Example("abc123").Required(),
+	}).Required()
+	bodySchema := validators.Object(map[string]interface{}{
+		"email": validators.String().Email().Required(),
+	}).Example(map[string]interface{}{"email": "ada@example.com"}).Required()
+
+	getUser := operations.NewSimple().
+		GET("/users/{id}").
+		WithParams(paramsSchema).
+		WithResponse(userSchema).
+		Handler(ginadapter.CreateValidatedHandler(
+			func(ctx context.Context, params userParams, query struct{}, body struct{}) (user, error) {
+				if brokenResponse {
+					return user{ID: params.ID, Email: "broken"}, nil
+				}
+				return user{ID: params.ID, Email: params.ID + "@example.com"}, nil
+			},
+			paramsSchema, nil, nil, nil,
+		))
+
+	createUser := operations.NewSimple().
+		POST("/users").
+		WithBody(bodySchema).
+		WithResponse(userSchema).
+		Handler(ginadapter.CreateValidatedHandler(
+			func(ctx context.Context, params struct{}, query struct{}, body createUserBody) (user, error) {
+				return user{ID: "u1", Email: body.Email}, nil
+			},
+			nil, nil, bodySchema, nil,
+		))
+
+	if err := router.Register(getUser, createUser); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	server := httptest.NewServer(engine)
+	t.Cleanup(server.Close)
+	return server, generator.Spec
+}
+
+func TestVerifyContract(t *testing.T) {
+	server, spec := newContractServer(t, false)
+	VerifyContract(t, server.URL, spec, WithHeader("Authorization", "Bearer token"))
+}
+
+func TestVerifyContractDetectsViolations(t *testing.T) {
+	verify := func(t *testing.T, baseURL string, spec *operations.OpenAPISpec, method, path string, opts ...Option) []string {
+		t.Helper()
+		v := &contractVerifier{
+			baseURL: baseURL,
+			method:  method,
+			path:    path,
+			op:      spec.Paths[path][strings.ToLower(method)],
+			config:  newConfig(opts),
+		}
+		rec := &recordingTB{}
+		v.verify(rec)
+		return rec.failures
+	}
+
+	t.Run("Response violating the declared schema", func(t *testing.T) {
+		server, spec := newContractServer(t, true)
+		failures := verify(t, server.URL, spec, http.MethodGet, "/users/{id}", WithHeader("Authorization", "Bearer token"))
+		if len(failures) != 1 || !strings.Contains(failures[0], "violates the declared schema") {
+			t.Errorf("Expected a schema violation, got %v", failures)
+		}
+	})
+
+	t.Run("Undeclared status code", func(t *testing.T) {
+		server, spec := newContractServer(t, false)
+		failures := verify(t, server.URL, spec, http.MethodPost, "/users")
+		if len(failures) != 1 || !strings.Contains(failures[0], "status 401 is not declared") {
+			t.Errorf("Expected an undeclared status, got %v", failures)
+		}
+	})
+}
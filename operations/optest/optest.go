@@ -0,0 +1,459 @@
+// Package optest provides contract-level fuzz testing for registered operations.
+//
+// Run generates valid and boundary-invalid requests for every operation from its
+// schemas, sends them to an http.Handler, and checks the responses:
+//
+//	engine := gin.New()
+//	router := ginadapter.NewGinRouter(engine)
+//	router.Register(createUser, getUser)
+//
+//	func TestUserAPIContract(t *testing.T) {
+//	    optest.Run(t, engine, router)
+//	}
+//
+// Valid requests must not be rejected with 400 or fail with a server error, and
+// responses with a declared schema must validate against it. Invalid requests,
+// which miss a required field, use the wrong type, or cross a declared bound,
+// must be rejected with a 4xx status.
+package optest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// OperationSource provides the operations to test
+// Both operations.Router and the Gin adapter's GinRouter implement it
+type OperationSource interface {
+	GetOperations() []goop.CompiledOperation
+}
+
+// Option configures Run
+type Option func(*config)
+
+type config struct {
+	seed       int64
+	iterations int
+	hook       func(op goop.CompiledOperation, req *http.Request)
+	skip       func(op goop.CompiledOperation) bool
+	headers    http.Header
+	client     *http.Client
+}
+
+func newConfig(opts []Option) *config {
+	c := &config{seed: 1, iterations: 10, headers: http.Header{}, client: http.DefaultClient}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// WithSeed sets the seed for request generation, so failures can be reproduced
+func WithSeed(seed int64) Option {
+	return func(c *config) {
+		c.seed = seed
+	}
+}
+
+// WithIterations sets how many valid requests are sent per operation (default 10)
+func WithIterations(n int) Option {
+	return func(c *config) {
+		c.iterations = n
+	}
+}
+
+// WithRequestHook calls fn on every request before it is sent, for example to add credentials
+func WithRequestHook(fn func(op goop.CompiledOperation, req *http.Request)) Option {
+	return func(c *config) {
+		c.hook = fn
+	}
+}
+
+// WithHeader sets a header on every request, for example credentials for VerifyContract
+func WithHeader(name, value string) Option {
+	return func(c *config) {
+		c.headers.Set(name, value)
+	}
+}
+
+// WithHTTPClient sets the client VerifyContract uses to reach the service (default http.DefaultClient)
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *config) {
+		c.client = client
+	}
+}
+
+// Skip excludes operations for which fn returns true when fuzzing with Run
+func Skip(fn func(op goop.CompiledOperation) bool) Option {
+	return func(c *config) {
+		c.skip = fn
+	}
+}
+
+// Run fuzzes every operation of source against handler, reporting contract violations
+// as test failures. Each operation runs as a subtest named after its method and path.
+func Run(t *testing.T, handler http.Handler, source OperationSource, opts ...Option) {
+	t.Helper()
+	c := newConfig(opts)
+	r := newRand(c.seed)
+	for _, op := range source.GetOperations() {
+		if c.skip != nil && c.skip(op) {
+			continue
+		}
+		op := op
+		t.Run(op.Method+" "+op.Path, func(t *testing.T) {
+			f := &fuzzer{t: t, handler: handler, op: op, config: c, rand: r}
+			f.run()
+		})
+	}
+}
+
+func newRand(seed int64) *rand.Rand {
+	return rand.New(rand.NewSource(seed))
+}
+
+// fuzzer sends generated requests for one operation
+type fuzzer struct {
+	t       testing.TB
+	handler http.Handler
+	op      goop.CompiledOperation
+	config  *config
+	rand    *rand.Rand
+}
+
+// requestValues holds the generated parts of a request
+type requestValues struct {
+	params  map[string]interface{}
+	query   map[string]interface{}
+	headers map[string]interface{}
+	body    interface{}
+}
+
+func (f *fuzzer) run() {
+	var sample *requestValues
+	for i := 0; i < f.config.iterations; i++ {
+		values, err := f.generate()
+		if err != nil {
+			f.t.Fatalf("cannot generate request: %v", err)
+		}
+		if sample == nil {
+			sample = values
+		}
+		f.checkValid(values)
+	}
+	if sample == nil {
+		return
+	}
+
+	for _, m := range f.mutations(sample) {
+		f.checkInvalid(m.name, m.values)
+	}
+}
+
+// generate builds a valid request from the operation schemas
+func (f *fuzzer) generate() (*requestValues, error) {
+	values := &requestValues{}
+	var err error
+	if values.params, err = f.generateObject(f.op.ParamsSchema); err != nil {
+		return nil, fmt.Errorf("path parameters: %w", err)
+	}
+	if values.query, err = f.generateObject(f.op.QuerySchema); err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+	if values.headers, err = f.generateObject(f.op.HeaderSchema); err != nil {
+		return nil, fmt.Errorf("headers: %w", err)
+	}
+	if f.op.BodySchema != nil {
+		if values.body, err = goop.Generate(f.op.BodySchema, goop.WithRand(f.rand)); err != nil {
+			return nil, fmt.Errorf("body: %w", err)
+		}
+	}
+	return values, nil
+}
+
+func (f *fuzzer) generateObject(schema goop.Schema) (map[string]interface{}, error) {
+	if schema == nil {
+		return nil, nil
+	}
+	value, err := goop.Generate(schema, goop.WithRand(f.rand))
+	if err != nil {
+		return nil, err
+	}
+	obj, _ := value.(map[string]interface{})
+	return obj, nil
+}
+
+// checkValid sends a valid request and checks the response against the contract
+func (f *fuzzer) checkValid(values *requestValues) {
+	rec := f.send(values)
+	label := fmt.Sprintf("valid request %s", describe(values))
+
+	if rec.Code == http.StatusBadRequest || rec.Code == http.StatusUnprocessableEntity {
+		f.t.Errorf("%s was rejected with %d: %s", label, rec.Code, rec.Body.String())
+		return
+	}
+	if rec.Code >= 500 {
+		f.t.Errorf("%s failed with %d: %s", label, rec.Code, rec.Body.String())
+		return
+	}
+
+	schema := f.responseSchema(rec.Code)
+	if schema == nil || rec.Body.Len() == 0 {
+		return
+	}
+	var body interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		f.t.Errorf("%s returned %d with a non-JSON body: %v", label, rec.Code, err)
+		return
+	}
+	if err := schema.Validate(body); err != nil {
+		f.t.Errorf("%s returned %d with a body that violates the declared schema: %v", label, rec.Code, err)
+	}
+}
+
+// checkInvalid sends an invalid request and expects it to be rejected
+func (f *fuzzer) checkInvalid(name string, values *requestValues) {
+	rec := f.send(values)
+	if rec.Code < 400 || rec.Code >= 500 {
+		f.t.Errorf("invalid request (%s) %s: expected a 4xx status, got %d", name, describe(values), rec.Code)
+	}
+}
+
+// responseSchema returns the declared schema for a response status, or nil
+func (f *fuzzer) responseSchema(status int) goop.Schema {
+	if response, ok := f.op.Responses[status]; ok && response.Schema != nil {
+		return response.Schema
+	}
+	if status >= 200 && status < 300 {
+		return f.op.ResponseSchema
+	}
+	return nil
+}
+
+// send performs the request against the handler
+func (f *fuzzer) send(values *requestValues) *httptest.ResponseRecorder {
+	path := f.op.Path
+	for name, value := range values.params {
+		path = strings.ReplaceAll(path, "{"+name+"}", url.PathEscape(formatValue(value)))
+	}
+
+	query := url.Values{}
+	for name, value := range values.query {
+		if items, ok := value.([]interface{}); ok {
+			for _, item := range items {
+				query.Add(name, formatValue(item))
+			}
+			continue
+		}
+		query.Set(name, formatValue(value))
+	}
+	if encoded := query.Encode(); encoded != "" {
+		path += "?" + encoded
+	}
+
+	var body *bytes.Reader
+	if values.body != nil {
+		data, _ := json.Marshal(values.body)
+		body = bytes.NewReader(data)
+	} else {
+		body = bytes.NewReader(nil)
+	}
+
+	req := httptest.NewRequest(f.op.Method, path, body)
+	if values.body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for name, value := range values.headers {
+		req.Header.Set(name, formatValue(value))
+	}
+	for name, value := range f.config.headers {
+		req.Header[name] = value
+	}
+	if f.config.hook != nil {
+		f.config.hook(f.op, req)
+	}
+
+	rec := httptest.NewRecorder()
+	f.handler.ServeHTTP(rec, req)
+	return rec
+}
+
+// mutation is an invalid variant of a valid request
+type mutation struct {
+	name   string
+	values *requestValues
+}
+
+// mutations derives boundary-invalid requests from a valid one
+// Body fields are dropped, retyped, and pushed past their bounds; query parameters are
+// dropped and pushed past their bounds, since every query value arrives as a string
+func (f *fuzzer) mutations(valid *requestValues) []mutation {
+	var result []mutation
+	if spec := openAPISchema(f.op.BodySchema); spec != nil {
+		if body, ok := valid.body.(map[string]interface{}); ok {
+			for _, m := range objectMutations(spec, body, true) {
+				values := *valid
+				values.body = m.value
+				result = append(result, mutation{name: "body " + m.name, values: &values})
+			}
+		}
+	}
+	if spec := openAPISchema(f.op.QuerySchema); spec != nil && valid.query != nil {
+		for _, m := range objectMutations(spec, valid.query, false) {
+			values := *valid
+			values.query = m.value
+			result = append(result, mutation{name: "query " + m.name, values: &values})
+		}
+	}
+	return result
+}
+
+// objectMutation is an invalid variant of an object
+type objectMutation struct {
+	name  string
+	value map[string]interface{}
+}
+
+func objectMutations(spec *goop.OpenAPISchema, valid map[string]interface{}, retype bool) []objectMutation {
+	var result []objectMutation
+	with := func(name string, change func(map[string]interface{})) {
+		obj := make(map[string]interface{}, len(valid))
+		for key, value := range valid {
+			obj[key] = value
+		}
+		change(obj)
+		result = append(result, objectMutation{name: name, value: obj})
+	}
+
+	required := append([]string{}, spec.Required...)
+	sort.Strings(required)
+	for _, name := range required {
+		name := name
+		with("missing "+name, func(obj map[string]interface{}) { delete(obj, name) })
+	}
+
+	names := make([]string, 0, len(spec.Properties))
+	for name := range spec.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		name := name
+		property := spec.Properties[name]
+		if retype {
+			if wrong, ok := wrongType(property); ok {
+				with(name+" with wrong type", func(obj map[string]interface{}) { obj[name] = wrong })
+			}
+		}
+		for _, b := range boundaryViolations(property) {
+			b := b
+			with(name+" "+b.name, func(obj map[string]interface{}) { obj[name] = b.value })
+		}
+	}
+	return result
+}
+
+// wrongType returns a value of a different JSON type than the property declares
+func wrongType(property *goop.OpenAPISchema) (interface{}, bool) {
+	switch property.Type {
+	case "string":
+		return 12345.0, true
+	case "number", "integer":
+		return "not-a-number", true
+	case "boolean":
+		return "not-a-boolean", true
+	case "array", "object":
+		return "not-a-" + property.Type, true
+	}
+	return nil, false
+}
+
+// boundary is a value just outside a declared bound
+type boundary struct {
+	name  string
+	value interface{}
+}
+
+func boundaryViolations(property *goop.OpenAPISchema) []boundary {
+	var result []boundary
+	switch property.Type {
+	case "string":
+		if property.MaxLength != nil {
+			result = append(result, boundary{"longer than maxLength", strings.Repeat("a", *property.MaxLength+1)})
+		}
+		if property.MaxBytes != nil {
+			result = append(result, boundary{"larger than x-max-bytes", strings.Repeat("a", *property.MaxBytes+1)})
+		}
+		// An empty string counts as missing, so only lengths of at least one are used
+		if property.MinLength != nil && *property.MinLength > 1 {
+			result = append(result, boundary{"shorter than minLength", strings.Repeat("a", *property.MinLength-1)})
+		}
+		if property.MinBytes != nil && *property.MinBytes > 1 {
+			result = append(result, boundary{"smaller than x-min-bytes", strings.Repeat("a", *property.MinBytes-1)})
+		}
+	case "number", "integer":
+		if property.Maximum != nil {
+			result = append(result, boundary{"above maximum", *property.Maximum + 1})
+		}
+		if property.Minimum != nil {
+			result = append(result, boundary{"below minimum", *property.Minimum - 1})
+		}
+		if property.ExclusiveMaximum != nil {
+			result = append(result, boundary{"at exclusiveMaximum", *property.ExclusiveMaximum})
+		}
+		if property.ExclusiveMinimum != nil {
+			result = append(result, boundary{"at exclusiveMinimum", *property.ExclusiveMinimum})
+		}
+	}
+	return result
+}
+
+// openAPISchema returns the OpenAPI definition of schema, or nil
+func openAPISchema(schema goop.Schema) *goop.OpenAPISchema {
+	if generator, ok := schema.(goop.OpenAPIGenerator); ok {
+		return generator.ToOpenAPISchema()
+	}
+	return nil
+}
+
+// formatValue renders a generated value for a path, query, or header
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		if v == float64(int64(v)) {
+			return strconv.FormatInt(int64(v), 10)
+		}
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case nil:
+		return ""
+	default:
+		data, _ := json.Marshal(v)
+		return string(data)
+	}
+}
+
+// describe summarizes request values for failure messages
+func describe(values *requestValues) string {
+	data, _ := json.Marshal(map[string]interface{}{
+		"params":  values.params,
+		"query":   values.query,
+		"headers": values.headers,
+		"body":    values.body,
+	})
+	return string(data)
+}
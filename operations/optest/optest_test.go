@@ -0,0 +1,182 @@
+package optest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/picogrid/go-op/operations"
+	ginadapter "github.com/picogrid/go-op/operations/adapters/gin"
+	"github.com/picogrid/go-op/validators"
+)
+
+type userParams struct {
+	ID string `uri:"id" json:"id"`
+}
+
+type listQuery struct {
+	Limit *int `form:"limit" json:"limit,omitempty"`
+}
+
+type createUserBody struct {
+	Email string `json:"email"`
+	Name  string `json:"name"`
+	Age   *int   `json:"age"`
+}
+
+type user struct {
+	ID    string `json:"id"`
+	Email string `json:"email"`
+}
+
+var userSchema = validators.Object(map[string]interface{}{
+	"id":    validators.String().Min(1).Required(),
+	"email": validators.String().Email().Required(),
+}).Required()
+
+// newTestAPI builds a small API; brokenResponse makes createUser return an invalid email
+func newTestAPI(t *testing.T, brokenResponse bool) (*gin.Engine, *ginadapter.GinRouter) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := ginadapter.NewGinRouter(engine)
+	router.SetResponseValidation(operations.Never)
+
+	createUser := operations.NewSimple().
+		POST("/users").
+		WithBody(validators.Object(map[string]interface{}{
+			"email": validators.String().Email().Required(),
+			"name":  validators.String().Min(2).Max(20).Required(),
+			"age":   validators.Number().Integer().Min(18).Max(120).Required(),
+		}).Required()).
+		WithResponse(userSchema).
+		Handler(ginadapter.CreateValidatedHandler(
+			func(ctx context.Context, params struct{}, query struct{}, body createUserBody) (user, error) {
+				if brokenResponse {
+					return user{ID: "u1", Email: "not-an-email"}, nil
+				}
+				return user{ID: "u1", Email: body.Email}, nil
+			},
+			nil, nil, validators.Object(map[string]interface{}{
+				"email": validators.String().Email().Required(),
+				"name":  validators.String().Min(2).Max(20).Required(),
+				"age":   validators.Number().Integer().Min(18).Max(120).Required(),
+			}).Required(), nil,
+		))
+
+	getUser := operations.NewSimple().
+		GET("/users/{id}").
+		WithParams(validators.Object(map[string]interface{}{
+			"id": validators.String().Pattern("^[a-z0-9]{4,8}$").Required(),
+		}).Required()).
+		WithResponse(userSchema).
+		Handler(ginadapter.CreateValidatedHandler(
+			func(ctx context.Context, params userParams, query struct{}, body struct{}) (user, error) {
+				return user{ID: params.ID, Email: params.ID + "@example.com"}, nil
+			},
+			validators.Object(map[string]interface{}{
+				"id": validators.String().Pattern("^[a-z0-9]{4,8}$").Required(),
+			}).Required(), nil, nil, nil,
+		))
+
+	listUsers := operations.NewSimple().
+		GET("/users").
+		WithQuery(validators.Object(map[string]interface{}{
+			"limit": validators.Number().Integer().Min(1).Max(50).Optional(),
+		}).Optional()).
+		Handler(ginadapter.CreateValidatedHandler(
+			func(ctx context.Context, params struct{}, query listQuery, body struct{}) ([]user, error) {
+				return []user{}, nil
+			},
+			nil, validators.Object(map[string]interface{}{
+				"limit": validators.Number().Integer().Min(1).Max(50).Optional(),
+			}).Optional(), nil, nil,
+		))
+
+	if err := router.Register(createUser, getUser, listUsers); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return engine, router
+}
+
+func TestRun(t *testing.T) {
+	engine, router := newTestAPI(t, false)
+
+	hooked := 0
+	Run(t, engine, router,
+		WithSeed(7),
+		WithIterations(5),
+		WithRequestHook(func(op operations.CompiledOperation, req *http.Request) {
+			hooked++
+			req.Header.Set("Authorization", "Bearer test")
+		}),
+	)
+
+	if hooked == 0 {
+		t.Error("Expected the request hook to run")
+	}
+}
+
+// recordingTB captures failures instead of failing the test
+type recordingTB struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) Errorf(format string, args ...interface{}) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func (r *recordingTB) Fatalf(format string, args ...interface{}) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func TestRunDetectsViolations(t *testing.T) {
+	t.Run("Response violating the declared schema", func(t *testing.T) {
+		engine, router := newTestAPI(t, true)
+		rec := &recordingTB{}
+		for _, op := range router.GetOperations() {
+			if op.Path != "/users" || op.Method != http.MethodPost {
+				continue
+			}
+			f := &fuzzer{t: rec, handler: engine, op: op, config: newConfig([]Option{WithIterations(1)}), rand: newRand(1)}
+			f.run()
+		}
+		if len(rec.failures) == 0 {
+			t.Error("Expected a response schema violation to be reported")
+		}
+	})
+
+	t.Run("Handler accepting invalid input", func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		engine := gin.New()
+		router := ginadapter.NewGinRouter(engine)
+		// The schema is documented but the handler never validates it
+		op := operations.NewSimple().
+			POST("/notes").
+			WithBody(validators.Object(map[string]interface{}{
+				"text": validators.String().Max(5).Required(),
+			}).Required()).
+			Handler(ginadapter.CreateValidatedHandler(
+				func(ctx context.Context, params struct{}, query struct{}, body struct{}) (struct{}, error) {
+					return struct{}{}, nil
+				},
+				nil, nil, nil, nil,
+			))
+		if err := router.Register(op); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		rec := &recordingTB{}
+		f := &fuzzer{t: rec, handler: engine, op: router.GetOperations()[0], config: newConfig([]Option{WithIterations(1)}), rand: newRand(1)}
+		f.run()
+		if len(rec.failures) != 3 {
+			t.Errorf("Expected missing, wrong type, and too long to be reported, got %v", rec.failures)
+		}
+	})
+}
@@ -0,0 +1,143 @@
+package pagination
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"time"
+
+	goop "github.com/picogrid/go-op"
+	"github.com/picogrid/go-op/validators"
+)
+
+// Errors returned when a cursor token cannot be resumed
+var (
+	ErrInvalidCursor  = errors.New("invalid cursor")
+	ErrCursorExpired  = errors.New("cursor has expired")
+	ErrCursorMismatch = errors.New("cursor was issued for a different sort or filter")
+)
+
+// Cursor is the position in a list that a cursor token resumes from
+type Cursor struct {
+	// After holds the sort key values of the last item on the previous page, by field name
+	// Numbers are decoded as json.Number so large IDs keep their precision.
+	After map[string]interface{} `json:"after"`
+	// Sort and Conditions the list was requested with; the next page must use the same
+	Sort       []goop.SortField       `json:"sort,omitempty"`
+	Conditions []goop.FilterCondition `json:"filters,omitempty"`
+	// IssuedAt is when the token was issued
+	IssuedAt time.Time `json:"issuedAt"`
+}
+
+// CursorSigner issues opaque cursor tokens for list operations and verifies them on later requests
+// Tokens are signed with HMAC-SHA256, so clients cannot forge positions or change the sort and
+// filters of a listing halfway through. Every instance of a service must share the key.
+type CursorSigner struct {
+	key []byte
+	ttl time.Duration
+	now func() time.Time
+}
+
+// NewCursorSigner creates a signer using key; tokens older than ttl are rejected, unless ttl is zero
+func NewCursorSigner(key []byte, ttl time.Duration) *CursorSigner {
+	return &CursorSigner{key: key, ttl: ttl, now: time.Now}
+}
+
+// Issue returns a token resuming the listing after the item with the given sort key values
+// The token records filters so the next page is only served for the same sort and filters.
+func (s *CursorSigner) Issue(after map[string]interface{}, filters goop.Filters) (string, error) {
+	payload, err := json.Marshal(Cursor{
+		After:      after,
+		Sort:       filters.Sort,
+		Conditions: filters.Conditions,
+		IssuedAt:   s.now().UTC(),
+	})
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(s.sign(payload)), nil
+}
+
+// Decode verifies the signature and age of token and returns its cursor
+func (s *CursorSigner) Decode(token string) (Cursor, error) {
+	encodedPayload, encodedSignature, ok := strings.Cut(token, ".")
+	if !ok {
+		return Cursor{}, ErrInvalidCursor
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil || !hmac.Equal(signature, s.sign(payload)) {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	var cursor Cursor
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+	if err := decoder.Decode(&cursor); err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	if s.ttl > 0 && s.now().Sub(cursor.IssuedAt) > s.ttl {
+		return Cursor{}, ErrCursorExpired
+	}
+	return cursor, nil
+}
+
+// Resume decodes token and checks it was issued for the same sort and filters as the current request
+func (s *CursorSigner) Resume(token string, filters goop.Filters) (Cursor, error) {
+	cursor, err := s.Decode(token)
+	if err != nil {
+		return Cursor{}, err
+	}
+	if !sameFilters(cursor, filters) {
+		return Cursor{}, ErrCursorMismatch
+	}
+	return cursor, nil
+}
+
+// CursorQueryFields returns the cursor/limit field validators with cursor tokens verified by s
+// Forged, expired, and mismatched cursors fail query validation, so handlers can Resume them safely.
+// The filters are compared when the operation declares query filters.
+func (s *CursorSigner) CursorQueryFields(maxLimit int) map[string]interface{} {
+	fields := CursorQueryFields(maxLimit)
+	fields["cursor"] = validators.String().
+		Example("eyJhZnRlciI6eyJpZCI6MTAwfX0.c2lnbmF0dXJl").
+		CustomCtx(func(ctx context.Context, token string) error {
+			if filters, ok := goop.FiltersFromContext(ctx); ok {
+				_, err := s.Resume(token, filters)
+				return err
+			}
+			_, err := s.Decode(token)
+			return err
+		}).
+		Optional()
+	return fields
+}
+
+// CursorQuerySchema returns the cursor/limit query schema with cursor tokens verified by s
+func (s *CursorSigner) CursorQuerySchema(maxLimit int) goop.Schema {
+	return validators.Object(s.CursorQueryFields(maxLimit)).Required()
+}
+
+func (s *CursorSigner) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// sameFilters reports whether cursor was issued for the sort keys and conditions of filters
+func sameFilters(cursor Cursor, filters goop.Filters) bool {
+	if len(cursor.Sort) != len(filters.Sort) || len(cursor.Conditions) != len(filters.Conditions) {
+		return false
+	}
+	return (len(cursor.Sort) == 0 || reflect.DeepEqual(cursor.Sort, filters.Sort)) &&
+		(len(cursor.Conditions) == 0 || reflect.DeepEqual(cursor.Conditions, filters.Conditions))
+}
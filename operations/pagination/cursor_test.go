@@ -0,0 +1,80 @@
+package pagination
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	goop "github.com/picogrid/go-op"
+)
+
+func TestCursorSigner(t *testing.T) {
+	signer := NewCursorSigner([]byte("secret"), time.Hour)
+	filters := goop.Filters{
+		Sort:       []goop.SortField{{Field: "created_at", Descending: true}},
+		Conditions: []goop.FilterCondition{{Field: "status", Operator: goop.FilterEq, Values: []string{"sent"}}},
+	}
+
+	token, err := signer.Issue(map[string]interface{}{"created_at": "2024-06-01T00:00:00Z", "id": 9007199254740993}, filters)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	t.Run("Resumes with the same filters", func(t *testing.T) {
+		cursor, err := signer.Resume(token, filters)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if cursor.After["created_at"] != "2024-06-01T00:00:00Z" || cursor.After["id"] != json.Number("9007199254740993") {
+			t.Errorf("Unexpected position: %v", cursor.After)
+		}
+	})
+
+	t.Run("Rejects other filters", func(t *testing.T) {
+		other := goop.Filters{Sort: filters.Sort}
+		if _, err := signer.Resume(token, other); !errors.Is(err, ErrCursorMismatch) {
+			t.Errorf("Expected ErrCursorMismatch, got: %v", err)
+		}
+	})
+
+	t.Run("Rejects tampered and foreign tokens", func(t *testing.T) {
+		foreign, _ := NewCursorSigner([]byte("other"), time.Hour).Issue(map[string]interface{}{"id": 1}, goop.Filters{})
+		for _, bad := range []string{"", "garbage", token + "x", "e30." + token[len(token)-10:], foreign} {
+			if _, err := signer.Decode(bad); !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("Expected ErrInvalidCursor for %q, got: %v", bad, err)
+			}
+		}
+	})
+
+	t.Run("Rejects expired tokens", func(t *testing.T) {
+		expiring := NewCursorSigner([]byte("secret"), time.Minute)
+		expiring.now = func() time.Time { return time.Now().Add(-2 * time.Minute) }
+		old, _ := expiring.Issue(map[string]interface{}{"id": 1}, goop.Filters{})
+		expiring.now = time.Now
+		if _, err := expiring.Decode(old); !errors.Is(err, ErrCursorExpired) {
+			t.Errorf("Expected ErrCursorExpired, got: %v", err)
+		}
+	})
+}
+
+func TestCursorQuerySchema(t *testing.T) {
+	signer := NewCursorSigner([]byte("secret"), 0)
+	schema := signer.CursorQuerySchema(DefaultMaxPageSize)
+	filters := goop.Filters{Sort: []goop.SortField{{Field: "name"}}}
+	token, _ := signer.Issue(map[string]interface{}{"name": "b"}, filters)
+
+	if err := goop.ValidateCtx(context.Background(), schema, map[string]interface{}{}); err != nil {
+		t.Errorf("Expected the first page without a cursor to be valid, got: %v", err)
+	}
+	if err := goop.ValidateCtx(goop.WithFilters(context.Background(), filters), schema, map[string]interface{}{"cursor": token}); err != nil {
+		t.Errorf("Expected a matching cursor to be valid, got: %v", err)
+	}
+	if err := goop.ValidateCtx(context.Background(), schema, map[string]interface{}{"cursor": "forged"}); err == nil {
+		t.Error("Expected a forged cursor to be invalid")
+	}
+	if err := goop.ValidateCtx(goop.WithFilters(context.Background(), goop.Filters{}), schema, map[string]interface{}{"cursor": token}); err == nil {
+		t.Error("Expected a cursor for other filters to be invalid")
+	}
+}
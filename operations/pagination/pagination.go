@@ -0,0 +1,156 @@
+// Package pagination provides standard query schemas and response envelopes for paginated operations.
+//
+// Offset-based pagination uses page/page_size query parameters and the Page[T] envelope.
+// Cursor-based pagination uses cursor/limit query parameters and the CursorPage[T] envelope.
+// Both envelopes expose has_next so clients can iterate consistently.
+// CursorSigner issues signed, opaque cursor tokens and verifies them when clients request the next page.
+package pagination
+
+import (
+	goop "github.com/picogrid/go-op"
+	"github.com/picogrid/go-op/validators"
+)
+
+const (
+	// DefaultPageSize is used when a request does not specify a page size
+	DefaultPageSize = 20
+	// DefaultMaxPageSize is the upper bound used by the standard query schemas
+	DefaultMaxPageSize = 100
+)
+
+// PageQuery binds page/page_size query parameters
+type PageQuery struct {
+	Page     int `json:"page,omitempty" form:"page"`
+	PageSize int `json:"page_size,omitempty" form:"page_size"`
+}
+
+// CurrentPage returns the requested page, defaulting to 1
+func (q PageQuery) CurrentPage() int {
+	if q.Page < 1 {
+		return 1
+	}
+	return q.Page
+}
+
+// Limit returns the requested page size, defaulting to DefaultPageSize
+func (q PageQuery) Limit() int {
+	if q.PageSize < 1 {
+		return DefaultPageSize
+	}
+	return q.PageSize
+}
+
+// Offset returns the number of items to skip for the requested page
+func (q PageQuery) Offset() int {
+	return (q.CurrentPage() - 1) * q.Limit()
+}
+
+// CursorQuery binds cursor/limit query parameters
+type CursorQuery struct {
+	Cursor string `json:"cursor,omitempty" form:"cursor"`
+	Limit  int    `json:"limit,omitempty" form:"limit"`
+}
+
+// PageSize returns the requested limit, defaulting to DefaultPageSize
+func (q CursorQuery) PageSize() int {
+	if q.Limit < 1 {
+		return DefaultPageSize
+	}
+	return q.Limit
+}
+
+// Page is the response envelope for offset-based pagination
+type Page[T any] struct {
+	Items      []T  `json:"items"`
+	Page       int  `json:"page"`
+	PageSize   int  `json:"page_size"`
+	TotalCount int  `json:"total_count"`
+	HasNext    bool `json:"has_next"`
+}
+
+// NewPage builds a page envelope, deriving has_next from the total count
+func NewPage[T any](items []T, query PageQuery, totalCount int) Page[T] {
+	if items == nil {
+		items = []T{}
+	}
+	return Page[T]{
+		Items:      items,
+		Page:       query.CurrentPage(),
+		PageSize:   query.Limit(),
+		TotalCount: totalCount,
+		HasNext:    query.Offset()+len(items) < totalCount,
+	}
+}
+
+// CursorPage is the response envelope for cursor-based pagination
+type CursorPage[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasNext    bool   `json:"has_next"`
+}
+
+// NewCursorPage builds a cursor page envelope; an empty next cursor marks the last page
+func NewCursorPage[T any](items []T, nextCursor string) CursorPage[T] {
+	if items == nil {
+		items = []T{}
+	}
+	return CursorPage[T]{
+		Items:      items,
+		NextCursor: nextCursor,
+		HasNext:    nextCursor != "",
+	}
+}
+
+// PageQueryFields returns the page/page_size field validators for merging into a larger query schema
+func PageQueryFields(maxPageSize int) map[string]interface{} {
+	return map[string]interface{}{
+		"page": validators.Number().Integer().Min(1).
+			Example(1).
+			Optional().Default(1),
+		"page_size": validators.Number().Integer().Min(1).Max(float64(maxPageSize)).
+			Example(DefaultPageSize).
+			Optional().Default(DefaultPageSize),
+	}
+}
+
+// CursorQueryFields returns the cursor/limit field validators for merging into a larger query schema
+func CursorQueryFields(maxLimit int) map[string]interface{} {
+	return map[string]interface{}{
+		"cursor": validators.String().
+			Example("eyJpZCI6MTAwfQ").
+			Optional(),
+		"limit": validators.Number().Integer().Min(1).Max(float64(maxLimit)).
+			Example(DefaultPageSize).
+			Optional().Default(DefaultPageSize),
+	}
+}
+
+// PageQuerySchema returns the standard page/page_size query schema
+func PageQuerySchema(maxPageSize int) goop.Schema {
+	return validators.Object(PageQueryFields(maxPageSize)).Required()
+}
+
+// CursorQuerySchema returns the standard cursor/limit query schema
+func CursorQuerySchema(maxLimit int) goop.Schema {
+	return validators.Object(CursorQueryFields(maxLimit)).Required()
+}
+
+// PageSchema returns the response schema for Page[T] with the given item schema
+func PageSchema(itemSchema interface{}) goop.Schema {
+	return validators.Object(map[string]interface{}{
+		"items":       validators.Array(itemSchema).Required(),
+		"page":        validators.Number().Integer().Min(1).Required(),
+		"page_size":   validators.Number().Integer().Min(1).Required(),
+		"total_count": validators.Number().Integer().Min(0).Required(),
+		"has_next":    validators.Bool().Required(),
+	}).Required()
+}
+
+// CursorPageSchema returns the response schema for CursorPage[T] with the given item schema
+func CursorPageSchema(itemSchema interface{}) goop.Schema {
+	return validators.Object(map[string]interface{}{
+		"items":       validators.Array(itemSchema).Required(),
+		"next_cursor": validators.String().Optional(),
+		"has_next":    validators.Bool().Required(),
+	}).Required()
+}
@@ -0,0 +1,118 @@
+package pagination
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/picogrid/go-op/validators"
+)
+
+func TestPageQuery(t *testing.T) {
+	t.Run("Applies defaults", func(t *testing.T) {
+		q := PageQuery{}
+		if q.CurrentPage() != 1 || q.Limit() != DefaultPageSize || q.Offset() != 0 {
+			t.Errorf("Expected page 1, size %d, offset 0, got %d, %d, %d", DefaultPageSize, q.CurrentPage(), q.Limit(), q.Offset())
+		}
+	})
+
+	t.Run("Computes offset", func(t *testing.T) {
+		q := PageQuery{Page: 3, PageSize: 10}
+		if q.Offset() != 20 {
+			t.Errorf("Expected offset 20, got %d", q.Offset())
+		}
+	})
+
+	t.Run("Cursor query defaults limit", func(t *testing.T) {
+		if (CursorQuery{}).PageSize() != DefaultPageSize {
+			t.Errorf("Expected default limit %d", DefaultPageSize)
+		}
+	})
+}
+
+func TestNewPage(t *testing.T) {
+	t.Run("Has next when more items remain", func(t *testing.T) {
+		page := NewPage([]string{"a", "b"}, PageQuery{Page: 1, PageSize: 2}, 5)
+		if !page.HasNext {
+			t.Error("Expected has_next to be true")
+		}
+	})
+
+	t.Run("Last page has no next", func(t *testing.T) {
+		page := NewPage([]string{"e"}, PageQuery{Page: 3, PageSize: 2}, 5)
+		if page.HasNext {
+			t.Error("Expected has_next to be false")
+		}
+	})
+
+	t.Run("Nil items serialize as empty array", func(t *testing.T) {
+		data, err := json.Marshal(NewPage[string](nil, PageQuery{}, 0))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		expected := `{"items":[],"page":1,"page_size":20,"total_count":0,"has_next":false}`
+		if string(data) != expected {
+			t.Errorf("Expected %s, got %s", expected, data)
+		}
+	})
+
+	t.Run("Cursor page derives has_next from cursor", func(t *testing.T) {
+		if !NewCursorPage([]int{1}, "next").HasNext {
+			t.Error("Expected has_next with cursor")
+		}
+		if NewCursorPage([]int{1}, "").HasNext {
+			t.Error("Expected no has_next without cursor")
+		}
+	})
+}
+
+func TestSchemas(t *testing.T) {
+	toMap := func(t *testing.T, v interface{}) map[string]interface{} {
+		t.Helper()
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		var m map[string]interface{}
+		if err := json.Unmarshal(data, &m); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return m
+	}
+
+	t.Run("Page query schema enforces bounds", func(t *testing.T) {
+		schema := PageQuerySchema(50)
+		if err := schema.Validate(toMap(t, PageQuery{Page: 2, PageSize: 50})); err != nil {
+			t.Errorf("Expected valid query, got %v", err)
+		}
+		if err := schema.Validate(toMap(t, PageQuery{})); err != nil {
+			t.Errorf("Expected empty query to be valid, got %v", err)
+		}
+		if err := schema.Validate(toMap(t, PageQuery{PageSize: 51})); err == nil {
+			t.Error("Expected error for page size above maximum")
+		}
+	})
+
+	t.Run("Cursor query schema enforces bounds", func(t *testing.T) {
+		schema := CursorQuerySchema(10)
+		if err := schema.Validate(toMap(t, CursorQuery{Cursor: "abc", Limit: 10})); err != nil {
+			t.Errorf("Expected valid query, got %v", err)
+		}
+		if err := schema.Validate(toMap(t, CursorQuery{Limit: 11})); err == nil {
+			t.Error("Expected error for limit above maximum")
+		}
+	})
+
+	t.Run("Page schemas validate envelopes", func(t *testing.T) {
+		item := validators.String().Required()
+
+		if err := PageSchema(item).Validate(toMap(t, NewPage([]string{"a"}, PageQuery{}, 1))); err != nil {
+			t.Errorf("Expected valid page, got %v", err)
+		}
+		if err := CursorPageSchema(item).Validate(toMap(t, NewCursorPage([]string{"a"}, "next"))); err != nil {
+			t.Errorf("Expected valid cursor page, got %v", err)
+		}
+		if err := CursorPageSchema(item).Validate(map[string]interface{}{"items": []interface{}{"a"}}); err == nil {
+			t.Error("Expected error for missing has_next")
+		}
+	})
+}
@@ -0,0 +1,83 @@
+package operations
+
+import (
+	"fmt"
+	"strings"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// QueryFilters declares the fields an operation can be filtered and sorted by
+// Pass the result to WithQueryFilters; handlers receive the parsed expressions by embedding Filters in their query struct.
+func QueryFilters(fields ...FilterField) QueryFilterSet {
+	return QueryFilterSet{Fields: fields}
+}
+
+// Filter declares a field that can be filtered with the given operators (eq when none are given)
+func Filter(name string, operators ...FilterOperator) FilterField {
+	if len(operators) == 0 {
+		operators = []FilterOperator{FilterEq}
+	}
+	return FilterField{Name: name, Operators: operators}
+}
+
+// Sortable declares a field that can be used as a sort key and, when operators are given, filtered
+func Sortable(name string, operators ...FilterOperator) FilterField {
+	return FilterField{Name: name, Operators: operators, Sortable: true}
+}
+
+// filterParameters documents the sort parameter and a parameter per filterable field
+// Parameters already documented by the query schema are skipped.
+func filterParameters(set goop.QueryFilterSet, existing []OpenAPIParameter) []OpenAPIParameter {
+	documented := make(map[string]bool, len(existing))
+	for _, param := range existing {
+		if param.In == "query" {
+			documented[param.Name] = true
+		}
+	}
+
+	var parameters []OpenAPIParameter
+
+	var sortKeys []interface{}
+	var sortable []string
+	for _, field := range set.Fields {
+		if field.Sortable {
+			sortKeys = append(sortKeys, field.Name, "-"+field.Name)
+			sortable = append(sortable, field.Name)
+		}
+	}
+	if len(sortKeys) > 0 && !documented[goop.SortQueryParameter] {
+		explode := false
+		parameters = append(parameters, OpenAPIParameter{
+			Name:        goop.SortQueryParameter,
+			In:          "query",
+			Description: fmt.Sprintf("Comma-separated sort keys, descending when prefixed with \"-\". Sortable fields: %s", strings.Join(sortable, ", ")),
+			Schema: &goop.OpenAPISchema{
+				Type:  "array",
+				Items: &goop.OpenAPISchema{Type: "string", Enum: sortKeys},
+			},
+			Style:   string(goop.StyleForm),
+			Explode: &explode,
+			Example: "-" + sortable[0],
+		})
+	}
+
+	for _, field := range set.Fields {
+		if len(field.Operators) == 0 || documented[field.Name] {
+			continue
+		}
+		operators := make([]string, len(field.Operators))
+		for i, operator := range field.Operators {
+			operators[i] = string(operator)
+		}
+		parameters = append(parameters, OpenAPIParameter{
+			Name:        field.Name,
+			In:          "query",
+			Description: fmt.Sprintf("Filter expression operator:value; eq is assumed without an operator and in takes comma-separated values. Operators: %s", strings.Join(operators, ", ")),
+			Schema:      &goop.OpenAPISchema{Type: "string"},
+			Example:     operators[0] + ":value",
+		})
+	}
+
+	return parameters
+}
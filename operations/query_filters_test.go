@@ -0,0 +1,67 @@
+package operations
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/picogrid/go-op/validators"
+)
+
+func TestQueryFiltersSpec(t *testing.T) {
+	querySchema := validators.Object(map[string]interface{}{
+		"status": validators.String().Optional(),
+		"limit":  validators.Number().Integer().Optional(),
+	}).Optional()
+
+	op := NewSimple().
+		GET("/messages").
+		WithQuery(querySchema).
+		WithQueryFilters(QueryFilters(
+			Filter("status", FilterEq, FilterIn),
+			Sortable("created_at", FilterGte, FilterLt),
+			Sortable("name"),
+			Filter("channel"),
+		)).
+		Handler(nil)
+
+	generator := NewOpenAPIGenerator("Test API", "1.0.0")
+	if err := generator.Process(OperationInfo{Method: op.Method, Path: op.Path, Operation: &op}); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	params := make(map[string][]OpenAPIParameter)
+	for _, param := range generator.Spec.Paths["/messages"]["get"].Parameters {
+		params[param.Name] = append(params[param.Name], param)
+	}
+
+	t.Run("Documents sort keys", func(t *testing.T) {
+		sort := params["sort"]
+		if len(sort) != 1 {
+			t.Fatalf("Expected one sort parameter, got %v", sort)
+		}
+		expected := []interface{}{"created_at", "-created_at", "name", "-name"}
+		if !reflect.DeepEqual(sort[0].Schema.Items.Enum, expected) {
+			t.Errorf("Expected sort keys %v, got %v", expected, sort[0].Schema.Items.Enum)
+		}
+	})
+
+	t.Run("Documents filter operators", func(t *testing.T) {
+		created := params["created_at"]
+		if len(created) != 1 || !strings.HasSuffix(created[0].Description, "Operators: gte, lt") {
+			t.Errorf("Unexpected created_at parameter %+v", created)
+		}
+		if channel := params["channel"]; len(channel) != 1 || !strings.HasSuffix(channel[0].Description, "Operators: eq") {
+			t.Errorf("Expected channel to default to eq, got %+v", channel)
+		}
+		if _, ok := params["name"]; ok {
+			t.Error("Expected no filter parameter for a sort-only field")
+		}
+	})
+
+	t.Run("Keeps parameters documented by the query schema", func(t *testing.T) {
+		if len(params["status"]) != 1 {
+			t.Errorf("Expected status to be documented once, got %d", len(params["status"]))
+		}
+	})
+}
@@ -0,0 +1,90 @@
+package operations
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// PerSecond returns a rate limit of n requests per second
+func PerSecond(n int) goop.RateLimit {
+	return goop.RateLimit{Requests: n, Window: time.Second}
+}
+
+// PerMinute returns a rate limit of n requests per minute
+func PerMinute(n int) goop.RateLimit {
+	return goop.RateLimit{Requests: n, Window: time.Minute}
+}
+
+// PerHour returns a rate limit of n requests per hour
+func PerHour(n int) goop.RateLimit {
+	return goop.RateLimit{Requests: n, Window: time.Hour}
+}
+
+// MemoryRateLimiter is an in-process fixed-window RateLimiter
+// Elapsed windows are evicted as requests arrive, so clients that stop calling do not hold memory.
+// Use a shared limiter such as Redis when running multiple replicas
+type MemoryRateLimiter struct {
+	mu        sync.Mutex
+	windows   map[string]*rateWindow
+	now       func() time.Time
+	nextSweep time.Time
+}
+
+type rateWindow struct {
+	start time.Time
+	end   time.Time
+	count int
+}
+
+// NewMemoryRateLimiter creates an in-memory fixed-window rate limiter
+func NewMemoryRateLimiter() *MemoryRateLimiter {
+	return &MemoryRateLimiter{
+		windows: make(map[string]*rateWindow),
+		now:     time.Now,
+	}
+}
+
+// Allow records a request for key and reports whether it is within limit
+func (l *MemoryRateLimiter) Allow(ctx context.Context, key string, limit goop.RateLimit) (goop.RateLimitResult, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.prune(now, limit.Window)
+	window, ok := l.windows[key]
+	if !ok || now.Sub(window.start) >= limit.Window {
+		window = &rateWindow{start: now, end: now.Add(limit.Window)}
+		l.windows[key] = window
+	}
+
+	resetAfter := limit.Window - now.Sub(window.start)
+	if window.count >= limit.Requests {
+		return goop.RateLimitResult{Allowed: false, Remaining: 0, ResetAfter: resetAfter}, nil
+	}
+
+	window.count++
+	return goop.RateLimitResult{
+		Allowed:    true,
+		Remaining:  limit.Requests - window.count,
+		ResetAfter: resetAfter,
+	}, nil
+}
+
+// prune evicts elapsed windows, sweeping the map at most once per window so requests stay cheap
+func (l *MemoryRateLimiter) prune(now time.Time, window time.Duration) {
+	if now.Before(l.nextSweep) {
+		return
+	}
+	for key, w := range l.windows {
+		if !now.Before(w.end) {
+			delete(l.windows, key)
+		}
+	}
+	l.nextSweep = now.Add(window)
+}
+
+// Compile-time check that MemoryRateLimiter implements RateLimiter
+var _ goop.RateLimiter = (*MemoryRateLimiter)(nil)
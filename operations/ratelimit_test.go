@@ -0,0 +1,95 @@
+package operations
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	goop "github.com/picogrid/go-op"
+)
+
+func TestMemoryRateLimiter(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Allows requests up to the limit", func(t *testing.T) {
+		limiter := NewMemoryRateLimiter()
+		limit := PerMinute(2)
+
+		for i, expected := range []int{1, 0} {
+			result, err := limiter.Allow(ctx, "client", limit)
+			if err != nil || !result.Allowed || result.Remaining != expected {
+				t.Errorf("Request %d: expected allowed with %d remaining, got %+v (err=%v)", i+1, expected, result, err)
+			}
+		}
+
+		result, _ := limiter.Allow(ctx, "client", limit)
+		if result.Allowed {
+			t.Error("Expected request over the limit to be rejected")
+		}
+
+		if result, _ := limiter.Allow(ctx, "other", limit); !result.Allowed {
+			t.Error("Expected a different key to have its own window")
+		}
+	})
+
+	t.Run("Window resets after it elapses", func(t *testing.T) {
+		limiter := NewMemoryRateLimiter()
+		now := time.Now()
+		limiter.now = func() time.Time { return now }
+
+		limiter.Allow(ctx, "client", PerSecond(1))
+		if result, _ := limiter.Allow(ctx, "client", PerSecond(1)); result.Allowed {
+			t.Fatal("Expected second request in the window to be rejected")
+		}
+
+		now = now.Add(time.Second)
+		if result, _ := limiter.Allow(ctx, "client", PerSecond(1)); !result.Allowed {
+			t.Error("Expected request in a new window to be allowed")
+		}
+	})
+
+	t.Run("Elapsed windows are evicted", func(t *testing.T) {
+		limiter := NewMemoryRateLimiter()
+		now := time.Now()
+		limiter.now = func() time.Time { return now }
+
+		for _, client := range []string{"a", "b", "c"} {
+			limiter.Allow(ctx, client, PerSecond(5))
+		}
+		limiter.Allow(ctx, "hourly", PerHour(5))
+
+		now = now.Add(2 * time.Second)
+		limiter.Allow(ctx, "d", PerSecond(5))
+		if len(limiter.windows) != 2 {
+			t.Errorf("Expected only the open windows to remain, got %d", len(limiter.windows))
+		}
+	})
+}
+
+func TestRateLimitSpec(t *testing.T) {
+	generator := NewOpenAPIGenerator("Test API", "1.0.0")
+
+	op := NewSimple().
+		GET("/search").
+		RateLimit(PerMinute(100)).
+		Handler(func(c *gin.Context) {})
+
+	if err := generator.Process(OperationInfo{Method: op.Method, Path: op.Path, Operation: &op}); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	operation := generator.Spec.Paths["/search"]["get"]
+	extension, ok := operation.Extensions[RateLimitExtension].(map[string]interface{})
+	if !ok || extension["requests"] != 100 || extension["windowMs"] != int64(60000) {
+		t.Errorf("Expected x-ratelimit of 100 per 60000ms, got %v", operation.Extensions[RateLimitExtension])
+	}
+
+	if _, exists := operation.Responses["200"].Headers[goop.RateLimitRemainingHeader]; !exists {
+		t.Error("Expected success response to document rate limit headers")
+	}
+	if _, exists := operation.Responses["429"]; !exists {
+		t.Error("Expected 429 response to be documented")
+	}
+}
@@ -0,0 +1,35 @@
+package operations
+
+import (
+	goop "github.com/picogrid/go-op"
+)
+
+// resolvedSpec returns the OpenAPI schema of schema with a resolver for the components it references,
+// or a nil schema when it is not documented
+func resolvedSpec(schema goop.Schema) (*goop.OpenAPISchema, func(ref string) *goop.OpenAPISchema) {
+	enhanced, ok := schema.(goop.EnhancedSchema)
+	if !ok {
+		return nil, nil
+	}
+	lookup := lookupComponent
+	if ref, ok := schema.(*componentRef); ok {
+		lookup = ref.registry.resolve
+	}
+	return enhanced.ToOpenAPISchema(), func(ref string) *goop.OpenAPISchema {
+		resolved := resolveComponent(&goop.OpenAPISchema{Ref: ref}, lookup)
+		if resolved.Ref != "" {
+			return nil
+		}
+		return resolved
+	}
+}
+
+// redactorFor returns the redactor for the classified fields of schema, or nil when it has none
+func redactorFor(schema goop.Schema) *goop.Redactor {
+	return goop.NewRedactor(resolvedSpec(schema))
+}
+
+// visibilityFor returns the visibility of the scoped fields of schema, or nil when it has none
+func visibilityFor(schema goop.Schema) *goop.FieldVisibility {
+	return goop.NewFieldVisibility(resolvedSpec(schema))
+}
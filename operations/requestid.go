@@ -0,0 +1,30 @@
+package operations
+
+import (
+	goop "github.com/picogrid/go-op"
+)
+
+// requestIDParameter documents the request header clients can set to correlate a request
+func requestIDParameter(header string) OpenAPIParameter {
+	maxLength := goop.MaxRequestIDLength
+	return OpenAPIParameter{
+		Name:        header,
+		In:          "header",
+		Description: "Correlation ID for the request; one is generated when omitted",
+		Schema:      &goop.OpenAPISchema{Type: "string", MaxLength: &maxLength},
+	}
+}
+
+// documentRequestIDHeader adds the echoed request ID header to every response of an operation
+func documentRequestIDHeader(operation *OpenAPIOperation, header string) {
+	for code, response := range operation.Responses {
+		if response.Headers == nil {
+			response.Headers = make(map[string]OpenAPIHeader)
+		}
+		response.Headers[header] = OpenAPIHeader{
+			Description: "Correlation ID of the request, to quote when reporting a failure",
+			Schema:      &goop.OpenAPISchema{Type: "string"},
+		}
+		operation.Responses[code] = response
+	}
+}
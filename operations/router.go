@@ -2,6 +2,7 @@ package operations
 
 import (
 	"fmt"
+	"strings"
 
 	goop "github.com/picogrid/go-op"
 )
@@ -12,6 +13,9 @@ import (
 type Router struct {
 	generators []Generator
 	operations []CompiledOperation
+
+	// Pass operations behind disabled feature flags to generators
+	documentDisabled bool
 }
 
 // NewRouter creates a new framework-agnostic router with the specified generators
@@ -25,8 +29,16 @@ func NewRouter(generators ...Generator) *Router {
 // Register registers a compiled operation with the router
 // This method performs zero reflection and maximum performance registration
 func (r *Router) Register(op CompiledOperation) error {
+	// Operations behind disabled feature flags are skipped unless they are documented
+	enabled := op.Enabled()
+	if !enabled && !r.documentDisabled {
+		return nil
+	}
+
 	// Store the operation for generator processing
-	r.operations = append(r.operations, op)
+	if enabled {
+		r.operations = append(r.operations, op)
+	}
 
 	// Process with all generators (build-time analysis)
 	info := OperationInfo{
@@ -73,6 +85,64 @@ func (r *Router) Register(op CompiledOperation) error {
 		}
 	}
 
+	// Async operations bring their job status operation, registered once per path
+	if op.JobStatus != nil && enabled && !r.hasOperation(op.JobStatus.Method, op.JobStatus.Path) {
+		if err := r.Register(*op.JobStatus); err != nil {
+			return err
+		}
+	}
+
+	// Generators exposing build info bring their meta operation, registered once
+	for _, generator := range r.generators {
+		if provider, ok := generator.(goop.MetaOperationProvider); ok {
+			if meta := provider.MetaOperation(); meta != nil && !r.hasOperation(meta.Method, meta.Path) {
+				if err := r.Register(*meta); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// hasOperation reports whether an operation with method and path is registered
+func (r *Router) hasOperation(method, path string) bool {
+	for _, op := range r.operations {
+		if op.Method == method && op.Path == path {
+			return true
+		}
+	}
+	return false
+}
+
+// DocumentDisabledOperations passes operations registered afterwards to the generators even when their
+// feature flag is off, without storing them for mounting. Generators mark them as disabled, so a
+// documentation build can publish the spec of a fully flagged deployment.
+func (r *Router) DocumentDisabledOperations() {
+	r.documentDisabled = true
+}
+
+// RegisterGroup registers operations with the group's defaults applied
+func (r *Router) RegisterGroup(group *Group, ops ...CompiledOperation) error {
+	for _, op := range group.Operations(ops...) {
+		if err := r.Register(op); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Mount registers all operations from child under the given path prefix
+// Child routers are typically built without generators in their own packages,
+// so the parent's generators produce a single combined specification
+func (r *Router) Mount(prefix string, child *Router) error {
+	for _, op := range child.GetOperations() {
+		op.Path = joinPath(strings.TrimSuffix(prefix, "/"), op.Path)
+		if err := r.Register(op); err != nil {
+			return fmt.Errorf("failed to mount operation %s %s: %w", op.Method, op.Path, err)
+		}
+	}
 	return nil
 }
 
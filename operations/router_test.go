@@ -959,6 +959,48 @@ func TestServeSpec(t *testing.T) {
 		}
 	})
 
+	t.Run("Serve the generator's cached document", func(t *testing.T) {
+		engine := createTestEngine()
+		generator := NewOpenAPIGenerator("Spec API", "1.0.0")
+		router := ginadapter.NewGinRouter(engine, generator)
+		engine.GET("/openapi.json", router.ServeSpec(generator))
+
+		register := func(path string) {
+			op := NewSimple().GET(path).Handler(gin.HandlerFunc(func(c *gin.Context) {}))
+			if err := router.Register(op); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+		}
+		get := func(etag string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest(http.MethodGet, "/openapi.json", nil)
+			if etag != "" {
+				req.Header.Set("If-None-Match", etag)
+			}
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+			return w
+		}
+
+		register("/users")
+		first := get("")
+		var spec OpenAPISpec
+		if err := json.Unmarshal(first.Body.Bytes(), &spec); err != nil {
+			t.Fatalf("Failed to parse spec JSON: %v", err)
+		}
+		if spec.Info.Title != "Spec API" || spec.Paths["/users"] == nil {
+			t.Errorf("Expected the generator's document, got %s", first.Body.String())
+		}
+
+		etag := first.Header().Get("ETag")
+		if code := get(etag).Code; code != http.StatusNotModified {
+			t.Errorf("Expected 304 from the cached document, got %d", code)
+		}
+		register("/orders")
+		if code := get(etag).Code; code != http.StatusOK {
+			t.Errorf("Expected the document to change after registration, got %d", code)
+		}
+	})
+
 	t.Run("Serve spec with empty operations", func(t *testing.T) {
 		engine := createTestEngine()
 		generator := &mockGenerator{}
@@ -1462,3 +1504,178 @@ func TestVariadicRegisterWithErrors(t *testing.T) {
 		}
 	})
 }
+
+// TestRouterMount tests composing routers built separately under a path prefix
+func TestRouterMount(t *testing.T) {
+	handler := gin.HandlerFunc(func(c *gin.Context) {
+		c.JSON(200, gin.H{"path": c.FullPath()})
+	})
+
+	newUsersRouter := func() *Router {
+		users := NewRouter()
+		users.Register(CompiledOperation{Method: "GET", Path: "/users", Handler: handler})
+		users.Register(CompiledOperation{Method: "GET", Path: "/users/{id}", Handler: handler})
+		return users
+	}
+
+	t.Run("Mount framework-agnostic router into parent router", func(t *testing.T) {
+		generator := &mockGenerator{}
+		parent := NewRouter(generator)
+
+		if err := parent.Mount("/api/v1/", newUsersRouter()); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		ops := parent.GetOperations()
+		if len(ops) != 2 {
+			t.Fatalf("Expected 2 operations, got %d", len(ops))
+		}
+		if ops[1].Path != "/api/v1/users/{id}" {
+			t.Errorf("Expected path '/api/v1/users/{id}', got '%s'", ops[1].Path)
+		}
+		if len(generator.processedOps) != 2 || generator.processedOps[0].Path != "/api/v1/users" {
+			t.Errorf("Expected parent generator to process mounted operations, got %+v", generator.processedOps)
+		}
+	})
+
+	t.Run("Nested mounts accumulate prefixes", func(t *testing.T) {
+		v1 := NewRouter()
+		if err := v1.Mount("/v1", newUsersRouter()); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		parent := NewRouter()
+		if err := parent.Mount("/api", v1); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if path := parent.GetOperations()[0].Path; path != "/api/v1/users" {
+			t.Errorf("Expected path '/api/v1/users', got '%s'", path)
+		}
+	})
+
+	t.Run("Mount propagates generator errors", func(t *testing.T) {
+		parent := NewRouter(&mockGenerator{shouldError: true, errorMsg: "boom"})
+		if err := parent.Mount("/api", newUsersRouter()); err == nil {
+			t.Error("Expected error from failing generator")
+		}
+	})
+
+	t.Run("Mount into Gin router serves prefixed routes", func(t *testing.T) {
+		engine := createTestEngine()
+		generator := &mockGenerator{}
+		router := ginadapter.NewGinRouter(engine, generator)
+
+		if err := router.Mount("/api", newUsersRouter()); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/api/users/42", nil)
+		engine.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("Expected status 200, got %d", w.Code)
+		}
+		if !strings.Contains(w.Body.String(), "/api/users/:id") {
+			t.Errorf("Expected mounted route path in response, got %s", w.Body.String())
+		}
+		if len(generator.processedOps) != 2 {
+			t.Errorf("Expected 2 processed operations, got %d", len(generator.processedOps))
+		}
+	})
+}
+
+func TestFeatureFlags(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := gin.HandlerFunc(func(c *gin.Context) { c.Status(http.StatusNoContent) })
+	newOps := func(exportsEnabled bool) []CompiledOperation {
+		return []CompiledOperation{
+			NewSimple().GET("/reports").OperationID("listReports").Handler(handler),
+			NewSimple().POST("/reports/export").OperationID("exportReports").
+				EnabledWhen("bulk-export", func() bool { return exportsEnabled }).
+				Handler(handler),
+		}
+	}
+	serve := func(engine *gin.Engine, method, path string) int {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, path, nil)
+		engine.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	t.Run("Serves and documents enabled operations", func(t *testing.T) {
+		engine := gin.New()
+		generator := NewOpenAPIGenerator("Reports API", "1.0.0")
+		router := ginadapter.NewGinRouter(engine, generator)
+		if err := router.Register(newOps(true)...); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		if code := serve(engine, "POST", "/reports/export"); code != http.StatusNoContent {
+			t.Errorf("Expected flagged operation to be served, got %d", code)
+		}
+		flag := generator.Spec.Paths["/reports/export"]["post"].Extensions[FeatureFlagExtension]
+		if flag == nil || flag.(map[string]interface{})["name"] != "bulk-export" || flag.(map[string]interface{})["enabled"] != true {
+			t.Errorf("Expected enabled feature flag extension, got %v", flag)
+		}
+	})
+
+	t.Run("Skips disabled operations", func(t *testing.T) {
+		engine := gin.New()
+		generator := NewOpenAPIGenerator("Reports API", "1.0.0")
+		router := ginadapter.NewGinRouter(engine, generator)
+		if err := router.Register(newOps(false)...); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		if code := serve(engine, "POST", "/reports/export"); code != http.StatusNotFound {
+			t.Errorf("Expected disabled operation not to be served, got %d", code)
+		}
+		if _, exists := generator.Spec.Paths["/reports/export"]; exists {
+			t.Error("Expected disabled operation to be left out of the spec")
+		}
+		if len(router.GetOperations()) != 1 {
+			t.Errorf("Expected only the enabled operation, got %d", len(router.GetOperations()))
+		}
+	})
+
+	t.Run("Documents disabled operations on request", func(t *testing.T) {
+		engine := gin.New()
+		generator := NewOpenAPIGenerator("Reports API", "1.0.0")
+		router := ginadapter.NewGinRouter(engine, generator)
+		router.DocumentDisabledOperations()
+		if err := router.Register(newOps(false)...); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		if code := serve(engine, "POST", "/reports/export"); code != http.StatusNotFound {
+			t.Errorf("Expected disabled operation not to be served, got %d", code)
+		}
+		flag := generator.Spec.Paths["/reports/export"]["post"].Extensions[FeatureFlagExtension]
+		if flag == nil || flag.(map[string]interface{})["enabled"] != false {
+			t.Errorf("Expected disabled feature flag extension, got %v", flag)
+		}
+	})
+
+	t.Run("Framework-agnostic router", func(t *testing.T) {
+		generator := &mockGenerator{}
+		router := NewRouter(generator)
+		for _, op := range newOps(false) {
+			if err := router.Register(op); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+		}
+		if len(generator.processedOps) != 1 || len(router.GetOperations()) != 1 {
+			t.Errorf("Expected the disabled operation to be skipped, got %d processed", len(generator.processedOps))
+		}
+
+		router.DocumentDisabledOperations()
+		if err := router.Register(newOps(false)[1]); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if len(generator.processedOps) != 2 || len(router.GetOperations()) != 1 {
+			t.Errorf("Expected the disabled operation to be documented but not stored")
+		}
+	})
+}
@@ -0,0 +1,127 @@
+package operations
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// ServeOptions configures Serve
+type ServeOptions struct {
+	// Addr is the TCP address to listen on (default ":8080")
+	Addr string
+
+	// Listener serves on an existing listener instead of listening on Addr
+	Listener net.Listener
+
+	// TLS serves HTTPS with the given configuration, which must provide certificates (nil serves plain HTTP)
+	TLS *tls.Config
+
+	// ShutdownTimeout bounds how long in-flight requests and shutdown hooks may take (default 30 seconds)
+	ShutdownTimeout time.Duration
+
+	// ReadHeaderTimeout bounds how long clients may take to send request headers (default 10 seconds)
+	ReadHeaderTimeout time.Duration
+
+	// Signals trigger graceful shutdown (default SIGINT and SIGTERM)
+	Signals []os.Signal
+
+	// OnStart hooks run in order before the server accepts connections, such as writing the
+	// spec or warming caches; the first error aborts startup
+	OnStart []func(ctx context.Context) error
+
+	// OnShutdown hooks run in order after the server stops accepting connections and in-flight
+	// requests finish, such as closing database pools
+	OnShutdown []func(ctx context.Context) error
+}
+
+// Serve runs handler, typically a router, until ctx is canceled or a shutdown signal arrives,
+// then shuts down gracefully: the server stops accepting connections, waits for in-flight
+// requests, and runs the shutdown hooks, all within ShutdownTimeout.
+// It returns nil after a graceful shutdown.
+//
+// Example:
+//
+//	err := operations.Serve(context.Background(), router, operations.ServeOptions{
+//	    Addr: ":8080",
+//	    OnStart: []func(context.Context) error{
+//	        func(ctx context.Context) error { return openAPIGen.WriteToFile("openapi.yaml") },
+//	    },
+//	    OnShutdown: []func(context.Context) error{
+//	        func(ctx context.Context) error { return db.Close() },
+//	    },
+//	})
+func Serve(ctx context.Context, handler http.Handler, opts ServeOptions) error {
+	if opts.Addr == "" {
+		opts.Addr = ":8080"
+	}
+	if opts.ShutdownTimeout <= 0 {
+		opts.ShutdownTimeout = 30 * time.Second
+	}
+	if opts.ReadHeaderTimeout <= 0 {
+		opts.ReadHeaderTimeout = 10 * time.Second
+	}
+	if len(opts.Signals) == 0 {
+		opts.Signals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, opts.Signals...)
+	defer stop()
+
+	for _, hook := range opts.OnStart {
+		if err := hook(ctx); err != nil {
+			return fmt.Errorf("start hook failed: %w", err)
+		}
+	}
+
+	listener := opts.Listener
+	if listener == nil {
+		var err error
+		if listener, err = net.Listen("tcp", opts.Addr); err != nil {
+			return fmt.Errorf("failed to listen on %s: %w", opts.Addr, err)
+		}
+	}
+	if opts.TLS != nil {
+		listener = tls.NewListener(listener, opts.TLS)
+	}
+
+	server := &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: opts.ReadHeaderTimeout,
+		TLSConfig:         opts.TLS,
+	}
+
+	served := make(chan error, 1)
+	go func() { served <- server.Serve(listener) }()
+
+	select {
+	case err := <-served:
+		return fmt.Errorf("server failed: %w", err)
+	case <-ctx.Done():
+	}
+
+	// Shutdown gets its own deadline since ctx is already canceled
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), opts.ShutdownTimeout)
+	defer cancel()
+
+	var errs []error
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		errs = append(errs, fmt.Errorf("graceful shutdown failed: %w", err))
+	}
+	if err := <-served; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		errs = append(errs, fmt.Errorf("server failed: %w", err))
+	}
+	for _, hook := range opts.OnShutdown {
+		if err := hook(shutdownCtx); err != nil {
+			errs = append(errs, fmt.Errorf("shutdown hook failed: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
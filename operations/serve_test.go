@@ -0,0 +1,107 @@
+package operations
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// TestServe tests serving with startup hooks and graceful shutdown
+func TestServe(t *testing.T) {
+	t.Run("Graceful shutdown waits for in-flight requests", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("Failed to listen: %v", err)
+		}
+
+		started := make(chan struct{})
+		release := make(chan struct{})
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(started)
+			<-release
+			_, _ = io.WriteString(w, "done")
+		})
+
+		var events []string
+		ctx, cancel := context.WithCancel(context.Background())
+		result := make(chan error, 1)
+		go func() {
+			result <- Serve(ctx, handler, ServeOptions{
+				Listener: listener,
+				OnStart: []func(context.Context) error{
+					func(ctx context.Context) error { events = append(events, "start"); return nil },
+				},
+				OnShutdown: []func(context.Context) error{
+					func(ctx context.Context) error { events = append(events, "shutdown"); return nil },
+				},
+			})
+		}()
+
+		response := make(chan string, 1)
+		go func() {
+			resp, err := http.Get("http://" + listener.Addr().String())
+			if err != nil {
+				response <- err.Error()
+				return
+			}
+			defer resp.Body.Close()
+			body, _ := io.ReadAll(resp.Body)
+			response <- string(body)
+		}()
+
+		<-started
+		cancel()
+		time.Sleep(20 * time.Millisecond)
+		close(release)
+
+		if body := <-response; body != "done" {
+			t.Errorf("Expected in-flight request to complete, got %q", body)
+		}
+		if err := <-result; err != nil {
+			t.Errorf("Expected graceful shutdown, got: %v", err)
+		}
+		if len(events) != 2 || events[0] != "start" || events[1] != "shutdown" {
+			t.Errorf("Expected start and shutdown hooks in order, got %v", events)
+		}
+		if _, err := net.Dial("tcp", listener.Addr().String()); err == nil {
+			t.Error("Expected listener to be closed")
+		}
+	})
+
+	t.Run("Start hook errors abort startup", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("Failed to listen: %v", err)
+		}
+		defer listener.Close()
+
+		err = Serve(context.Background(), http.NotFoundHandler(), ServeOptions{
+			Listener: listener,
+			OnStart: []func(context.Context) error{
+				func(ctx context.Context) error { return errors.New("spec write failed") },
+			},
+		})
+		if err == nil || err.Error() != "start hook failed: spec write failed" {
+			t.Errorf("Expected start hook error, got: %v", err)
+		}
+	})
+
+	t.Run("Shutdown hook errors are returned", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := Serve(ctx, http.NotFoundHandler(), ServeOptions{
+			Addr: "127.0.0.1:0",
+			OnShutdown: []func(context.Context) error{
+				func(ctx context.Context) error { return errors.New("pool close failed") },
+			},
+		})
+		if err == nil || err.Error() != "shutdown hook failed: pool close failed" {
+			t.Errorf("Expected shutdown hook error, got: %v", err)
+		}
+	})
+}
@@ -1,7 +1,11 @@
 package operations
 
 import (
+	"net/http"
+	"time"
+
 	goop "github.com/picogrid/go-op"
+	"github.com/picogrid/go-op/validators"
 )
 
 // ResponseDefinition represents a response with its schema, description, and optional headers
@@ -9,39 +13,127 @@ type ResponseDefinition struct {
 	Schema      goop.Schema
 	Description string
 	Headers     map[string]goop.Schema
+	ContentType string // Media type of the body (empty means application/json)
+	Ref         string // Name of the reusable response component documenting it, if any
 }
 
 // Core operation configuration struct
 // This contains all the operation metadata and schemas
 type operationConfig struct {
-	method         string
-	path           string
-	summary        string
-	description    string
-	tags           []string
-	successCode    int
-	paramsSchema   goop.Schema
-	querySchema    goop.Schema
-	bodySchema     goop.Schema
-	responseSchema goop.Schema // Keep for backward compatibility
-	headerSchema   goop.Schema
-	security       goop.SecurityRequirements
-	responses      map[int]ResponseDefinition // New: Multiple responses support
+	method           string
+	path             string
+	summary          string
+	description      string
+	descriptionFile  string
+	operationID      string
+	tags             []string
+	successCode      int
+	paramsSchema     goop.Schema
+	querySchema      goop.Schema
+	bodySchema       goop.Schema
+	responseSchema   goop.Schema // Keep for backward compatibility
+	headerSchema     goop.Schema
+	security         goop.SecurityRequirements
+	responses        map[int]ResponseDefinition // New: Multiple responses support
+	skippedDefaults  []int
+	timeout          time.Duration
+	idempotency      goop.Idempotency
+	queryStyles      map[string]goop.ParameterSerialization
+	idempotencyStore goop.IdempotencyStore
+	rateLimit        *goop.RateLimit
+	maxBodyBytes     int64
+	version          string
+	audiences        []string
+	deprecation      *goop.Deprecation
+	responsePolicy   goop.ValidationPolicy
+	sparse           bool
+	sparseFields     []string
+	queryFilters     *goop.QueryFilterSet
+	featureFlag      *goop.FeatureFlag
+	bodyContentType  string
+	tenantExempt     bool
+	cache            *goop.CachePolicy
+	fileTypes        []string
 }
 
 // Helper method to compile the final operation
 func (config *operationConfig) compile(handler HTTPHandler) CompiledOperation {
+	// Batch handlers document their array body and per-item results unless the builder set them
+	if batch, ok := handler.(batchDocumenter); ok {
+		if config.bodySchema == nil {
+			config.bodySchema = batch.bodySchema()
+		}
+		if _, exists := config.responses[http.StatusMultiStatus]; !exists {
+			config.responses[http.StatusMultiStatus] = ResponseDefinition{
+				Schema:      batch.resultSchema(),
+				Description: "Result of each item, in request order",
+			}
+		}
+		config.successCode = http.StatusMultiStatus
+	}
+
+	// Async handlers validate the body set with WithBody, accept requests with a Job, and bring their status operation
+	var jobStatus *CompiledOperation
+	if async, ok := handler.(asyncOperation); ok {
+		handler = async.prepare(config.operationID, config.bodySchema)
+		if _, exists := config.responses[http.StatusAccepted]; !exists {
+			config.responses[http.StatusAccepted] = ResponseDefinition{
+				Schema:      JobSchema(nil),
+				Description: "Job accepted; poll the URL in the Location header for its outcome",
+			}
+		}
+		config.successCode = http.StatusAccepted
+		status := async.statusOperation()
+		jobStatus = &status
+	}
+
 	op := CompiledOperation{
-		Method:      config.method,
-		Path:        config.path,
-		Summary:     config.summary,
-		Description: config.description,
-		Tags:        config.tags,
-		SuccessCode: config.successCode,
-		Handler:     handler,
-		Security:    config.security,
-		Responses:   make(map[int]goop.ResponseDefinition),
+		Method:                  config.method,
+		Path:                    config.path,
+		Summary:                 config.summary,
+		Description:             config.description,
+		DescriptionFile:         config.descriptionFile,
+		Tags:                    config.tags,
+		OperationID:             config.operationID,
+		SuccessCode:             config.successCode,
+		Handler:                 handler,
+		Security:                config.security,
+		Responses:               make(map[int]goop.ResponseDefinition),
+		SkippedDefaultResponses: config.skippedDefaults,
+		Timeout:                 config.timeout,
+		Idempotency:             config.idempotency,
+		QueryStyles:             config.queryStyles,
+		IdempotencyStore:        config.idempotencyStore,
+		RateLimit:               config.rateLimit,
+		MaxBodyBytes:            config.maxBodyBytes,
+		Version:                 config.version,
+		Audiences:               config.audiences,
+		Deprecation:             config.deprecation,
+		ResponseValidation:      config.responsePolicy,
+		QueryFilters:            config.queryFilters,
+		BodyContentType:         config.bodyContentType,
+		FeatureFlag:             config.featureFlag,
+		TenantExempt:            config.tenantExempt,
+		Cache:                   config.cache,
+		JobStatus:               jobStatus,
+		FileContentTypes:        config.fileTypes,
+	}
+
+	if config.sparse {
+		op.SparseFields = config.sparseFieldset()
+	}
+
+	// Classified fields are masked wherever adapters record or log bodies
+	op.RequestRedactor = redactorFor(config.bodySchema)
+	responseRedactors := []*goop.Redactor{redactorFor(config.responseSchema)}
+	// Fields limited to some scopes are stripped from responses to other callers
+	responseVisibility := []*goop.FieldVisibility{visibilityFor(config.responseSchema)}
+	for _, response := range config.responses {
+		responseRedactors = append(responseRedactors, redactorFor(response.Schema))
+		responseVisibility = append(responseVisibility, visibilityFor(response.Schema))
 	}
+	op.ResponseRedactor = goop.MergeRedactors(responseRedactors...)
+	op.ResponseVisibility = goop.MergeFieldVisibility(responseVisibility...)
 
 	// Copy all defined responses
 	for code, response := range config.responses {
@@ -49,6 +141,8 @@ func (config *operationConfig) compile(handler HTTPHandler) CompiledOperation {
 			Schema:      response.Schema,
 			Description: response.Description,
 			Headers:     response.Headers,
+			ContentType: response.ContentType,
+			Ref:         response.Ref,
 		}
 	}
 
@@ -145,6 +239,30 @@ func (s *SimpleOperationBuilder) Summary(summary string) *SimpleOperationBuilder
 // Description sets the operation description
 func (s *SimpleOperationBuilder) Description(description string) *SimpleOperationBuilder {
 	s.config.description = description
+	s.config.descriptionFile = ""
+	return s
+}
+
+// DescriptionFromFile documents the operation with the contents of a Markdown file, so long-form
+// documentation can live next to the rest of the docs instead of in a Go string literal. The file
+// replaces any Description and is read when the spec is generated: by the CLI relative to the
+// module root, and by OpenAPIGenerator from its DescriptionFS or the working directory.
+//
+// Example:
+//
+//	operations.NewSimple().
+//		POST("/orders").
+//		Summary("Create an order").
+//		DescriptionFromFile("docs/orders/create.md")
+func (s *SimpleOperationBuilder) DescriptionFromFile(path string) *SimpleOperationBuilder {
+	s.config.descriptionFile = path
+	s.config.description = ""
+	return s
+}
+
+// OperationID sets the unique operationId used to identify the operation in the spec
+func (s *SimpleOperationBuilder) OperationID(id string) *SimpleOperationBuilder {
+	s.config.operationID = id
 	return s
 }
 
@@ -160,6 +278,21 @@ func (s *SimpleOperationBuilder) SuccessCode(code int) *SimpleOperationBuilder {
 	return s
 }
 
+// Timeout declares the expected upper bound on the operation's latency.
+// Routers enforce it by giving the handler context a deadline and responding 504 when it is exceeded.
+// The spec documents it as x-timeout-ms, which is spec-only: generated clients do not apply it as a deadline.
+func (s *SimpleOperationBuilder) Timeout(timeout time.Duration) *SimpleOperationBuilder {
+	s.config.timeout = timeout
+	return s
+}
+
+// Idempotency declares whether the operation is safe to retry
+// The classification is surfaced in the spec for client retry policies
+func (s *SimpleOperationBuilder) Idempotency(idempotency goop.Idempotency) *SimpleOperationBuilder {
+	s.config.idempotency = idempotency
+	return s
+}
+
 // WithParams sets the parameters schema
 func (s *SimpleOperationBuilder) WithParams(schema goop.Schema) *SimpleOperationBuilder {
 	s.config.paramsSchema = schema
@@ -172,9 +305,135 @@ func (s *SimpleOperationBuilder) WithQuery(schema goop.Schema) *SimpleOperationB
 	return s
 }
 
+// WithIdempotency requires an Idempotency-Key header and replays stored responses for duplicate requests
+// The operation is classified as Conditional unless an idempotency classification was already set
+func (s *SimpleOperationBuilder) WithIdempotency(store goop.IdempotencyStore) *SimpleOperationBuilder {
+	s.config.idempotencyStore = store
+	if s.config.idempotency == "" {
+		s.config.idempotency = goop.Conditional
+	}
+	return s
+}
+
+// Cacheable lets clients cache successful responses for maxAge, and shared caches such as CDNs too when public
+// Routers set the Cache-Control and Expires headers on 2xx responses, and the headers are documented in the
+// spec. Intended for read-heavy endpoints such as catalogs and lists.
+func (s *SimpleOperationBuilder) Cacheable(maxAge time.Duration, public bool) *SimpleOperationBuilder {
+	s.config.cache = &goop.CachePolicy{MaxAge: maxAge, Public: public}
+	return s
+}
+
+// RateLimit declares the request rate limit for the operation
+// The limit is documented in the spec and enforced by routers configured with a RateLimiter
+func (s *SimpleOperationBuilder) RateLimit(limit goop.RateLimit) *SimpleOperationBuilder {
+	s.config.rateLimit = &limit
+	return s
+}
+
+// Version assigns the operation to an API version, such as "v2" or "2024-06-01"
+// Routers can serve several versions of the same method and path, selected by header or path segment
+func (s *SimpleOperationBuilder) Version(version string) *SimpleOperationBuilder {
+	s.config.version = version
+	return s
+}
+
+// Audience restricts the documented visibility of the operation to the given audiences, such as "internal" or "partner"
+// Specs filtered with FilterByAudience include the operation only for those audiences; routing is unaffected
+func (s *SimpleOperationBuilder) Audience(audiences ...string) *SimpleOperationBuilder {
+	s.config.audiences = append(s.config.audiences, audiences...)
+	return s
+}
+
+// WithoutTenant opts the operation out of the tenant resolution of routers configured with a tenant
+// resolver, for endpoints that do not act for a tenant such as health checks or tenant sign-up
+func (s *SimpleOperationBuilder) WithoutTenant() *SimpleOperationBuilder {
+	s.config.tenantExempt = true
+	return s
+}
+
+// EnabledWhen registers the operation only when enabled reports true at registration, for experimental endpoints
+// Routers skip disabled operations, so they are neither served nor documented unless the router documents
+// disabled operations. The spec marks the operation with the flag name.
+func (s *SimpleOperationBuilder) EnabledWhen(flag string, enabled func() bool) *SimpleOperationBuilder {
+	s.config.featureFlag = &goop.FeatureFlag{Name: flag, Enabled: enabled}
+	return s
+}
+
+// WithQueryFilters lets callers sort and filter with query expressions such as ?sort=-created_at&status=eq:sent
+// Routers reject undeclared sort keys and operators, and handlers receive the parsed expressions through query
+// structs that embed Filters. The sort and filter parameters are documented in the spec.
+func (s *SimpleOperationBuilder) WithQueryFilters(set goop.QueryFilterSet) *SimpleOperationBuilder {
+	s.config.queryFilters = &set
+	return s
+}
+
+// SparseFields lets callers select response fields with the fields query parameter, such as ?fields=id,name
+// With no field names, the properties of the success response resource are selectable. Routers prune
+// the validated response to the requested fields and reject unknown names; the parameter is documented in the spec.
+func (s *SimpleOperationBuilder) SparseFields(fields ...string) *SimpleOperationBuilder {
+	s.config.sparse = true
+	s.config.sparseFields = append(s.config.sparseFields, fields...)
+	return s
+}
+
+// WithResponseValidation controls when the response schema is validated at runtime
+// Use OnlyInDev or Sampled to avoid the cost in production; request validation is unaffected
+func (s *SimpleOperationBuilder) WithResponseValidation(policy goop.ValidationPolicy) *SimpleOperationBuilder {
+	s.config.responsePolicy = policy
+	return s
+}
+
+// Deprecated marks the operation as deprecated since the given time
+// sunset is when the operation will be removed and successorOperationID names its replacement; either may be left zero.
+// The spec marks the operation deprecated and routers emit Deprecation and Sunset response headers.
+func (s *SimpleOperationBuilder) Deprecated(since, sunset time.Time, successorOperationID string) *SimpleOperationBuilder {
+	s.config.deprecation = &goop.Deprecation{
+		Since:     since,
+		Sunset:    sunset,
+		Successor: successorOperationID,
+	}
+	return s
+}
+
+// MaxBodyBytes limits the request body size for the operation
+// Routers reject larger bodies with 413 before decoding, and the limit is documented in the spec
+func (s *SimpleOperationBuilder) MaxBodyBytes(limit int64) *SimpleOperationBuilder {
+	s.config.maxBodyBytes = limit
+	return s
+}
+
+// QueryStyle sets the serialization style for a query parameter
+// Use StyleDeepObject for filter[status]=active, or StyleForm with explode for repeated tags= keys
+func (s *SimpleOperationBuilder) QueryStyle(name string, style goop.ParameterStyle, explode bool) *SimpleOperationBuilder {
+	if s.config.queryStyles == nil {
+		s.config.queryStyles = make(map[string]goop.ParameterSerialization)
+	}
+	s.config.queryStyles[name] = goop.ParameterSerialization{Style: style, Explode: explode}
+	return s
+}
+
 // WithBody sets the request body schema
 func (s *SimpleOperationBuilder) WithBody(schema goop.Schema) *SimpleOperationBuilder {
 	s.config.bodySchema = schema
+	s.config.bodyContentType = ""
+	return s
+}
+
+// WithPatchBody accepts a JSON Merge Patch (RFC 7396) of the object schema as the request body
+// Only the fields the client sends are validated, and an explicit null removes a field, which is
+// rejected for required fields. The body is documented as application/merge-patch+json; bind it
+// as goop.MergePatch to tell absent fields from nulls.
+//
+// Example:
+//
+//	operations.NewSimple().
+//	    PATCH("/users/{id}").
+//	    WithParams(userParams).
+//	    WithPatchBody(userSchema).
+//	    Handler(ginadapter.CreateValidatedHandler(patchUser, userParams, nil, validators.MergePatch(userSchema), userSchema))
+func (s *SimpleOperationBuilder) WithPatchBody(schema goop.Schema) *SimpleOperationBuilder {
+	s.config.bodySchema = validators.MergePatch(schema)
+	s.config.bodyContentType = goop.MergePatchContentType
 	return s
 }
 
@@ -198,6 +457,13 @@ func (s *SimpleOperationBuilder) WithResponseCode(code int, schema goop.Schema,
 	return s
 }
 
+// WithResponseRef documents the response for a status code as a reference to a reusable response
+// registered with OpenAPIGenerator.AddResponse, such as a StandardError shared by every operation
+func (s *SimpleOperationBuilder) WithResponseRef(code int, name string) *SimpleOperationBuilder {
+	s.config.responses[code] = ResponseDefinition{Ref: name}
+	return s
+}
+
 // WithSuccessResponse sets a success response (2xx range)
 func (s *SimpleOperationBuilder) WithSuccessResponse(code int, schema goop.Schema, description string) *SimpleOperationBuilder {
 	if code < 200 || code >= 300 {
@@ -369,6 +635,49 @@ func (s *SimpleOperationBuilder) WithCreateErrors() *SimpleOperationBuilder {
 		WithServerError(InternalServerErrorSchema)
 }
 
+// WithoutDefaultResponses excludes the generator's default responses for the given status codes
+// Use it for operations that cannot fail that way, such as 401 on a public health check
+func (s *SimpleOperationBuilder) WithoutDefaultResponses(codes ...int) *SimpleOperationBuilder {
+	s.config.skippedDefaults = append(s.config.skippedDefaults, codes...)
+	return s
+}
+
+// WithJSONAPIResponse adds a success response documented as a JSON:API document served as application/vnd.api+json
+// Use JSONAPIDocumentSchema or JSONAPIListSchema to describe the document
+func (s *SimpleOperationBuilder) WithJSONAPIResponse(code int, documentSchema goop.Schema, description string) *SimpleOperationBuilder {
+	if code < 200 || code >= 300 {
+		panic("Success response codes must be in the 2xx range")
+	}
+	s.config.responses[code] = ResponseDefinition{
+		Schema:      documentSchema,
+		Description: description,
+		ContentType: JSONAPIContentType,
+	}
+	return s
+}
+
+// WithFileResponse documents the success response as a binary file of the given media types
+// (application/octet-stream when none are given), for handlers returning a goop.FileResult.
+// The spec also documents partial content for range requests and the Content-Disposition header.
+func (s *SimpleOperationBuilder) WithFileResponse(contentTypes ...string) *SimpleOperationBuilder {
+	if len(contentTypes) == 0 {
+		contentTypes = []string{goop.DefaultFileContentType}
+	}
+	s.config.fileTypes = contentTypes
+	return s
+}
+
+// WithErrorSchemas adds error responses for the given status codes built by factory
+func (s *SimpleOperationBuilder) WithErrorSchemas(factory *ErrorSchemaFactory, codes ...int) *SimpleOperationBuilder {
+	for _, code := range codes {
+		if code < 400 {
+			panic("Error response codes must be in the 4xx or 5xx range")
+		}
+		s.config.responses[code] = factory.Response(code)
+	}
+	return s
+}
+
 // WithStandardErrorsByCode allows adding multiple standard error responses by status codes
 func (s *SimpleOperationBuilder) WithStandardErrorsByCode(codes ...int) *SimpleOperationBuilder {
 	for _, code := range codes {
@@ -394,12 +703,16 @@ func getStandardErrorDescription(code int) string {
 		return "Unprocessable Entity - Request contains semantic errors"
 	case 429:
 		return "Too Many Requests - Rate limit exceeded"
+	case 413:
+		return "Payload Too Large - The request body exceeds the size limit"
 	case 500:
 		return "Internal Server Error - An unexpected error occurred"
 	case 502:
 		return "Bad Gateway - Upstream service unavailable"
 	case 503:
 		return "Service Unavailable - Service temporarily unavailable"
+	case 504:
+		return "Gateway Timeout - The request exceeded its deadline"
 	default:
 		return "Error"
 	}
@@ -0,0 +1,128 @@
+package operations
+
+import (
+	"sort"
+	"strings"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// sparseFieldset resolves the fields callers can select from the operation's success response
+// Resources are the response object, the items of an array response, or the "data" or "items" member of an envelope.
+// Explicit fields take precedence over the properties of the response schema.
+func (config *operationConfig) sparseFieldset() *goop.SparseFieldset {
+	spec, lookup := config.successResponseSpec()
+	schema := resolveComponent(spec, lookup)
+	fieldset := &goop.SparseFieldset{Fields: config.sparseFields}
+
+	if member := envelopeMember(schema); member != "" {
+		fieldset.Envelope = member
+		schema = resolveComponent(schema.Properties[member], lookup)
+	}
+	if schema != nil && schema.Type == "array" {
+		schema = resolveComponent(schema.Items, lookup)
+	}
+
+	if len(fieldset.Fields) == 0 {
+		if schema == nil || len(schema.Properties) == 0 {
+			panic("SparseFields requires field names or an object response schema")
+		}
+		for name := range schema.Properties {
+			fieldset.Fields = append(fieldset.Fields, name)
+		}
+		sort.Strings(fieldset.Fields)
+	}
+
+	return fieldset
+}
+
+// envelopeMembers are the top-level members each envelope carries, keyed by the member holding its resources
+// "data" covers Envelope and JSON:API documents, and "items" the pagination Page and CursorPage envelopes.
+var envelopeMembers = map[string]map[string]bool{
+	"data":  {"data": true, "meta": true, "errors": true, "warnings": true, "links": true, "included": true, "jsonapi": true},
+	"items": {"items": true, "page": true, "page_size": true, "total_count": true, "has_next": true, "next_cursor": true},
+}
+
+// envelopeMember returns the member an envelope schema wraps its resources in, or "" if schema is not an envelope
+func envelopeMember(schema *goop.OpenAPISchema) string {
+	if schema == nil {
+		return ""
+	}
+	for member, members := range envelopeMembers {
+		if schema.Properties[member] == nil {
+			continue
+		}
+		envelope := true
+		for name := range schema.Properties {
+			if !members[name] {
+				envelope = false
+				break
+			}
+		}
+		if envelope {
+			return member
+		}
+	}
+	return ""
+}
+
+// successResponseSpec returns the OpenAPI schema of the lowest 2xx response
+// Components it references are resolved with the returned lookup, in the namespace of the response component if it is one.
+func (config *operationConfig) successResponseSpec() (*goop.OpenAPISchema, func(name string) (goop.Schema, bool)) {
+	codes := make([]int, 0, len(config.responses))
+	for code := range config.responses {
+		if code >= 200 && code < 300 {
+			codes = append(codes, code)
+		}
+	}
+	sort.Ints(codes)
+
+	schema := config.responseSchema
+	if len(codes) > 0 {
+		schema = config.responses[codes[0]].Schema
+	}
+
+	lookup := lookupComponent
+	if ref, ok := schema.(*componentRef); ok {
+		lookup = ref.registry.resolve
+	}
+	if enhanced, ok := schema.(goop.EnhancedSchema); ok {
+		return enhanced.ToOpenAPISchema(), lookup
+	}
+	return nil, lookup
+}
+
+// resolveComponent follows a reference to a shared component schema
+func resolveComponent(schema *goop.OpenAPISchema, lookup func(name string) (goop.Schema, bool)) *goop.OpenAPISchema {
+	if schema == nil || !strings.HasPrefix(schema.Ref, componentSchemaPrefix) {
+		return schema
+	}
+	component, ok := lookup(strings.TrimPrefix(schema.Ref, componentSchemaPrefix))
+	if !ok {
+		return schema
+	}
+	if enhanced, ok := component.(goop.EnhancedSchema); ok {
+		return resolveComponent(enhanced.ToOpenAPISchema(), lookup)
+	}
+	return schema
+}
+
+// fieldsParameter documents the fields query parameter of an operation with sparse fieldsets
+func fieldsParameter(fieldset goop.SparseFieldset) OpenAPIParameter {
+	enum := make([]interface{}, len(fieldset.Fields))
+	for i, field := range fieldset.Fields {
+		enum[i] = field
+	}
+	explode := false
+	return OpenAPIParameter{
+		Name:        goop.FieldsQueryParameter,
+		In:          "query",
+		Description: "Comma-separated response fields to return; all fields are returned when omitted",
+		Schema: &goop.OpenAPISchema{
+			Type:  "array",
+			Items: &goop.OpenAPISchema{Type: "string", Enum: enum},
+		},
+		Style:   string(goop.StyleForm),
+		Explode: &explode,
+	}
+}
@@ -0,0 +1,107 @@
+package operations
+
+import (
+	"reflect"
+	"testing"
+
+	goop "github.com/picogrid/go-op"
+	"github.com/picogrid/go-op/operations/pagination"
+	"github.com/picogrid/go-op/validators"
+)
+
+func TestSparseFieldset(t *testing.T) {
+	userSchema := validators.Object(map[string]interface{}{
+		"id":    validators.String().Required(),
+		"name":  validators.String().Required(),
+		"email": validators.String().Email().Required(),
+	}).Required()
+
+	t.Run("Derives fields from the response object", func(t *testing.T) {
+		op := NewSimple().GET("/users/{id}").WithResponse(userSchema).SparseFields().Handler(nil)
+		if op.SparseFields == nil {
+			t.Fatal("Expected a sparse fieldset")
+		}
+		if !reflect.DeepEqual(op.SparseFields.Fields, []string{"email", "id", "name"}) {
+			t.Errorf("Unexpected fields %v", op.SparseFields.Fields)
+		}
+		if op.SparseFields.Envelope != "" {
+			t.Error("Expected a plain object response")
+		}
+	})
+
+	t.Run("Resolves components, arrays, and envelopes", func(t *testing.T) {
+		component := Component("SparseFieldsUser", userSchema)
+		op := NewSimple().
+			GET("/users").
+			WithSuccessResponse(200, ListResponse(component), "Users").
+			SparseFields().
+			Handler(nil)
+		if op.SparseFields.Envelope != "data" {
+			t.Errorf("Expected a data envelope, got %q", op.SparseFields.Envelope)
+		}
+		if !reflect.DeepEqual(op.SparseFields.Fields, []string{"email", "id", "name"}) {
+			t.Errorf("Unexpected fields %v", op.SparseFields.Fields)
+		}
+	})
+
+	t.Run("Resolves pagination envelopes", func(t *testing.T) {
+		for name, schema := range map[string]goop.Schema{
+			"page":        pagination.PageSchema(userSchema),
+			"cursor page": pagination.CursorPageSchema(userSchema),
+		} {
+			op := NewSimple().GET("/users").WithResponse(schema).SparseFields().Handler(nil)
+			if op.SparseFields.Envelope != "items" {
+				t.Errorf("Expected an items envelope for the %s schema, got %q", name, op.SparseFields.Envelope)
+			}
+			if !reflect.DeepEqual(op.SparseFields.Fields, []string{"email", "id", "name"}) {
+				t.Errorf("Expected the item fields for the %s schema, got %v", name, op.SparseFields.Fields)
+			}
+		}
+	})
+
+	t.Run("Uses explicit fields", func(t *testing.T) {
+		op := NewSimple().GET("/users/{id}").WithResponse(userSchema).SparseFields("id", "name").Handler(nil)
+		if !reflect.DeepEqual(op.SparseFields.Fields, []string{"id", "name"}) {
+			t.Errorf("Unexpected fields %v", op.SparseFields.Fields)
+		}
+	})
+
+	t.Run("Disabled by default", func(t *testing.T) {
+		if op := NewSimple().GET("/users/{id}").WithResponse(userSchema).Handler(nil); op.SparseFields != nil {
+			t.Error("Expected no sparse fieldset")
+		}
+	})
+
+	t.Run("Requires fields", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected panic without fields or an object response")
+			}
+		}()
+		NewSimple().GET("/health").SparseFields().Handler(nil)
+	})
+
+	t.Run("Documents the fields parameter", func(t *testing.T) {
+		generator := NewOpenAPIGenerator("Test API", "1.0.0")
+		op := NewSimple().GET("/users/{id}").WithResponse(userSchema).SparseFields("id", "name").Handler(nil)
+		if err := generator.Process(OperationInfo{Method: op.Method, Path: op.Path, Operation: &op}); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		var fields *OpenAPIParameter
+		for _, param := range generator.Spec.Paths["/users/{id}"]["get"].Parameters {
+			if param.Name == "fields" {
+				fields = &param
+			}
+		}
+		if fields == nil {
+			t.Fatal("Expected fields query parameter")
+		}
+		if fields.In != "query" || fields.Style != "form" || fields.Explode == nil || *fields.Explode {
+			t.Errorf("Expected comma-separated query parameter, got %+v", fields)
+		}
+		if !reflect.DeepEqual(fields.Schema.Items.Enum, []interface{}{"id", "name"}) {
+			t.Errorf("Unexpected enum %v", fields.Schema.Items.Enum)
+		}
+	})
+}
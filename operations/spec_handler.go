@@ -0,0 +1,169 @@
+package operations
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// SpecHandler serves the specification containing only operations accepted by every filter as JSON
+// The rendered document is cached until an operation is registered or InvalidateSpecCache is called,
+// so frequent polling is cheap. Responses carry ETag and Last-Modified validators, conditional
+// requests are answered with 304 Not Modified, and clients accepting gzip receive a compressed document.
+// Mount one handler per audience to serve separate documentation:
+//
+//	engine.GET("/partner/openapi.json", gin.WrapH(generator.SpecHandler(operations.FilterByAudience("partner"))))
+func (g *OpenAPIGenerator) SpecHandler(filters ...OperationFilter) http.Handler {
+	cache := &specCache{generator: g, filters: filters}
+	return http.HandlerFunc(cache.serveHTTP)
+}
+
+// ServeHTTP serves the complete specification through a SpecHandler created on first use, so the
+// generator can be mounted directly or passed to a router's ServeSpec.
+func (g *OpenAPIGenerator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	g.specOnce.Do(func() { g.specHandler = g.SpecHandler() })
+	g.specHandler.ServeHTTP(w, r)
+}
+
+// InvalidateSpecCache makes spec handlers render the document again on their next request
+// Registering operations invalidates the cache. Changes made to Spec directly once handlers are
+// serving, such as adding servers at runtime, should go through UpdateSpec, which invalidates it too.
+func (g *OpenAPIGenerator) InvalidateSpecCache() {
+	atomic.AddUint64(&g.specRevision, 1)
+}
+
+// UpdateSpec applies change to Spec while no spec handler is rendering it, then invalidates the cache
+func (g *OpenAPIGenerator) UpdateSpec(change func(spec *OpenAPISpec)) {
+	g.specMu.Lock()
+	defer g.specMu.Unlock()
+
+	change(g.Spec)
+	g.InvalidateSpecCache()
+}
+
+// specCache holds the rendered document served by one spec handler
+type specCache struct {
+	generator *OpenAPIGenerator
+	filters   []OperationFilter
+
+	mu       sync.Mutex
+	rendered bool
+	revision uint64
+	doc      specDocument
+}
+
+// specDocument is a rendered document and its validators
+type specDocument struct {
+	body         []byte
+	gzipped      []byte
+	etag         string
+	lastModified time.Time
+}
+
+// document returns the cached document, rendering it again if the spec changed
+func (c *specCache) document() (specDocument, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// Registration holds the write lock while it changes Spec and the revision
+	c.generator.specMu.RLock()
+	defer c.generator.specMu.RUnlock()
+
+	revision := atomic.LoadUint64(&c.generator.specRevision)
+	if !c.rendered || revision != c.revision {
+		var body bytes.Buffer
+		if err := c.generator.WriteFiltered(&body, c.filters...); err != nil {
+			return specDocument{}, err
+		}
+
+		sum := sha256.Sum256(body.Bytes())
+		etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+		// Keep the modification time when an invalidation did not change the document
+		if etag != c.doc.etag {
+			var gzipped bytes.Buffer
+			zw := gzip.NewWriter(&gzipped)
+			if _, err := zw.Write(body.Bytes()); err != nil {
+				return specDocument{}, err
+			}
+			if err := zw.Close(); err != nil {
+				return specDocument{}, err
+			}
+
+			c.doc = specDocument{
+				body:         body.Bytes(),
+				gzipped:      gzipped.Bytes(),
+				etag:         etag,
+				lastModified: time.Now().UTC().Truncate(time.Second),
+			}
+		}
+		c.rendered = true
+		c.revision = revision
+	}
+
+	return c.doc, nil
+}
+
+func (c *specCache) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	doc, err := c.document()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	header := w.Header()
+	header.Set("Content-Type", "application/json")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("ETag", doc.etag)
+	header.Set("Last-Modified", doc.lastModified.Format(http.TimeFormat))
+	header.Add("Vary", "Accept-Encoding")
+
+	if notModified(r, doc) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	body := doc.body
+	if acceptsGzip(r) {
+		header.Set("Content-Encoding", "gzip")
+		body = doc.gzipped
+	}
+	header.Set("Content-Length", strconv.Itoa(len(body)))
+	w.WriteHeader(http.StatusOK)
+	if r.Method != http.MethodHead {
+		_, _ = w.Write(body)
+	}
+}
+
+// notModified evaluates If-None-Match, or If-Modified-Since when no entity tags are given, as in RFC 9110
+func notModified(r *http.Request, doc specDocument) bool {
+	if match := r.Header.Get("If-None-Match"); match != "" {
+		for _, tag := range strings.Split(match, ",") {
+			tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+			if tag == "*" || tag == doc.etag {
+				return true
+			}
+		}
+		return false
+	}
+	if since, err := http.ParseTime(r.Header.Get("If-Modified-Since")); err == nil {
+		return !doc.lastModified.After(since)
+	}
+	return false
+}
+
+// acceptsGzip reports whether the request accepts a gzip-encoded response
+func acceptsGzip(r *http.Request) bool {
+	for _, coding := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(coding), ";")
+		if strings.EqualFold(strings.TrimSpace(name), "gzip") {
+			return strings.ReplaceAll(strings.TrimSpace(params), " ", "") != "q=0"
+		}
+	}
+	return false
+}
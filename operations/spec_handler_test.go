@@ -0,0 +1,156 @@
+package operations
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestSpecHandler(t *testing.T) {
+	generator := NewOpenAPIGenerator("Spec API", "1.0.0")
+	register := func(t *testing.T, path string) {
+		t.Helper()
+		op := NewSimple().GET(path).Handler(func(c *gin.Context) {})
+		if err := generator.Process(OperationInfo{Method: op.Method, Path: op.Path, Operation: &op}); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+	}
+	register(t, "/users")
+
+	handler := generator.SpecHandler()
+	get := func(headers map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/openapi.json", nil)
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	first := get(nil)
+	etag := first.Header().Get("ETag")
+	if first.Code != http.StatusOK || etag == "" || first.Header().Get("Last-Modified") == "" {
+		t.Fatalf("Expected a document with validators, got %d %v", first.Code, first.Header())
+	}
+
+	t.Run("Answers conditional requests", func(t *testing.T) {
+		if code := get(map[string]string{"If-None-Match": etag}).Code; code != http.StatusNotModified {
+			t.Errorf("Expected 304 for matching ETag, got %d", code)
+		}
+		if code := get(map[string]string{"If-None-Match": `"other"`}).Code; code != http.StatusOK {
+			t.Errorf("Expected 200 for a different ETag, got %d", code)
+		}
+		lastModified := first.Header().Get("Last-Modified")
+		if code := get(map[string]string{"If-Modified-Since": lastModified}).Code; code != http.StatusNotModified {
+			t.Errorf("Expected 304 when unmodified since Last-Modified, got %d", code)
+		}
+	})
+
+	t.Run("Compresses for gzip clients", func(t *testing.T) {
+		response := get(map[string]string{"Accept-Encoding": "gzip, deflate"})
+		if response.Header().Get("Content-Encoding") != "gzip" {
+			t.Fatalf("Expected gzip encoding, got %v", response.Header())
+		}
+		reader, err := gzip.NewReader(response.Body)
+		if err != nil {
+			t.Fatalf("Expected gzip body, got: %v", err)
+		}
+		body, err := io.ReadAll(reader)
+		if err != nil || string(body) != first.Body.String() {
+			t.Errorf("Expected the uncompressed document after decompression")
+		}
+		if get(map[string]string{"Accept-Encoding": "gzip;q=0"}).Header().Get("Content-Encoding") != "" {
+			t.Error("Expected no compression when gzip is refused")
+		}
+	})
+
+	t.Run("Renders again after registration", func(t *testing.T) {
+		register(t, "/orders")
+		response := get(map[string]string{"If-None-Match": etag})
+		if response.Code != http.StatusOK || response.Header().Get("ETag") == etag {
+			t.Fatalf("Expected an updated document, got %d", response.Code)
+		}
+		var spec OpenAPISpec
+		if err := json.Unmarshal(response.Body.Bytes(), &spec); err != nil {
+			t.Fatalf("Expected valid JSON, got: %v", err)
+		}
+		if _, ok := spec.Paths["/orders"]; !ok {
+			t.Error("Expected the new operation to be served")
+		}
+	})
+
+	t.Run("Renders again after an update", func(t *testing.T) {
+		etag := get(nil).Header().Get("ETag")
+		generator.UpdateSpec(func(spec *OpenAPISpec) {
+			spec.Servers = append(spec.Servers, OpenAPIServer{URL: "https://api.example.com"})
+		})
+		if get(nil).Header().Get("ETag") == etag {
+			t.Error("Expected the update to render the changed document")
+		}
+	})
+
+	t.Run("Renders again after invalidation", func(t *testing.T) {
+		generator.Spec.Info.Description = "Changed at runtime"
+		etag := get(nil).Header().Get("ETag")
+		generator.InvalidateSpecCache()
+		if get(nil).Header().Get("ETag") == etag {
+			t.Error("Expected invalidation to render the changed document")
+		}
+	})
+}
+
+// TestSpecHandlerConcurrentRegistration serves the spec while operations are registered; run it with -race
+func TestSpecHandlerConcurrentRegistration(t *testing.T) {
+	generator := NewOpenAPIGenerator("Spec API", "1.0.0")
+	handlers := []http.Handler{generator, generator.SpecHandler(), generator.SpecHandler(FilterByAudience("partner"))}
+
+	// Readers keep serving until every operation is registered, so rendering overlaps registration
+	registered := make(chan struct{})
+	var wg sync.WaitGroup
+	for _, handler := range handlers {
+		wg.Add(1)
+		go func(handler http.Handler) {
+			defer wg.Done()
+			for {
+				recorder := httptest.NewRecorder()
+				handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+				if recorder.Code != http.StatusOK {
+					t.Errorf("Expected 200, got %d", recorder.Code)
+				}
+				select {
+				case <-registered:
+					return
+				default:
+				}
+			}
+		}(handler)
+	}
+	for i := 0; i < 20; i++ {
+		op := NewSimple().GET("/items/" + strconv.Itoa(i)).Handler(func(c *gin.Context) {})
+		if err := generator.Process(OperationInfo{Method: op.Method, Path: op.Path, Operation: &op}); err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		runtime.Gosched()
+	}
+	close(registered)
+	wg.Wait()
+
+	recorder := httptest.NewRecorder()
+	generator.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	var spec OpenAPISpec
+	if err := json.Unmarshal(recorder.Body.Bytes(), &spec); err != nil {
+		t.Fatalf("Expected valid JSON, got: %v", err)
+	}
+	if len(spec.Paths) != 20 {
+		t.Errorf("Expected every registered operation to be served, got %d paths", len(spec.Paths))
+	}
+}
@@ -0,0 +1,32 @@
+package operations
+
+import (
+	"strings"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// tenantParameter documents the header or path parameter carrying the tenant ID
+func tenantParameter(resolver goop.TenantResolver) OpenAPIParameter {
+	return OpenAPIParameter{
+		Name:        resolver.Name,
+		In:          resolver.In,
+		Description: resolver.Description,
+		Required:    true,
+		Schema:      &goop.OpenAPISchema{Type: "string"},
+	}
+}
+
+// hasParameter reports whether parameters already documents the named parameter
+// Header names are compared case-insensitively, as HTTP treats them.
+func hasParameter(parameters []OpenAPIParameter, name, in string) bool {
+	for _, parameter := range parameters {
+		if parameter.In != in {
+			continue
+		}
+		if parameter.Name == name || (in == "header" && strings.EqualFold(parameter.Name, name)) {
+			return true
+		}
+	}
+	return false
+}
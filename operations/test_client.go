@@ -0,0 +1,269 @@
+package operations
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+)
+
+// TestRouter is a router that serves its registered operations over HTTP
+// The Gin adapter's GinRouter implements it
+type TestRouter interface {
+	http.Handler
+	GetOperations() []CompiledOperation
+}
+
+// TestClient calls registered operations in-process through httptest
+// Typed calls are made through endpoints returned by TestEndpointFor:
+//
+//	client := operations.NewTestClient(router)
+//	createUser := operations.TestEndpointFor[struct{}, struct{}, CreateUserBody, User](client, "createUser")
+//	user, err := createUser.Call(ctx, struct{}{}, struct{}{}, CreateUserBody{Email: "ada@example.com"})
+type TestClient struct {
+	router  TestRouter
+	headers http.Header
+}
+
+// NewTestClient creates a test client for the operations registered with router
+func NewTestClient(router TestRouter) *TestClient {
+	return &TestClient{
+		router:  router,
+		headers: http.Header{},
+	}
+}
+
+// SetHeader sets a header sent with every request, for example credentials
+func (c *TestClient) SetHeader(name, value string) {
+	c.headers.Set(name, value)
+}
+
+// operation finds a registered operation by operationId or by "METHOD /path"
+func (c *TestClient) operation(name string) (CompiledOperation, bool) {
+	for _, op := range c.router.GetOperations() {
+		if op.OperationID == name || strings.EqualFold(op.Method+" "+op.Path, name) {
+			return op, true
+		}
+	}
+	return CompiledOperation{}, false
+}
+
+// TestResponse is the decoded response of a TestEndpoint call
+type TestResponse[R any] struct {
+	StatusCode int
+	Header     http.Header
+	Body       R
+
+	// Raw holds the undecoded response body
+	Raw []byte
+}
+
+// TestResponseError is returned by TestEndpoint.Call when the operation responds with a 4xx or 5xx status
+type TestResponseError struct {
+	Method     string
+	Path       string
+	StatusCode int
+	Body       []byte
+}
+
+func (e *TestResponseError) Error() string {
+	return fmt.Sprintf("%s %s returned status %d: %s", e.Method, e.Path, e.StatusCode, strings.TrimSpace(string(e.Body)))
+}
+
+// TestEndpoint calls one registered operation with the same typed params, query, body,
+// and response as its Handler
+type TestEndpoint[P, Q, B, R any] struct {
+	client *TestClient
+	op     CompiledOperation
+	err    error
+}
+
+// TestEndpointFor returns a typed endpoint for the operation registered under name,
+// which is either its operationId or its method and path (e.g. "GET /users/{id}")
+// If no such operation is registered, calls on the endpoint return an error
+func TestEndpointFor[P, Q, B, R any](client *TestClient, name string) *TestEndpoint[P, Q, B, R] {
+	op, ok := client.operation(name)
+	if !ok {
+		return &TestEndpoint[P, Q, B, R]{client: client, err: fmt.Errorf("operation %q is not registered", name)}
+	}
+	return &TestEndpoint[P, Q, B, R]{client: client, op: op}
+}
+
+// Call sends the request and decodes the response body into R
+// Responses with a 4xx or 5xx status are returned as a *TestResponseError
+func (e *TestEndpoint[P, Q, B, R]) Call(ctx context.Context, params P, query Q, body B) (R, error) {
+	resp, err := e.Do(ctx, params, query, body)
+	if err != nil {
+		var zero R
+		return zero, err
+	}
+	return resp.Body, nil
+}
+
+// Do sends the request and returns the full response regardless of its status
+// The body is decoded into R only for 2xx responses
+func (e *TestEndpoint[P, Q, B, R]) Do(ctx context.Context, params P, query Q, body B) (*TestResponse[R], error) {
+	if e.err != nil {
+		return nil, e.err
+	}
+
+	req, err := e.request(ctx, params, query, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request for %s %s: %w", e.op.Method, e.op.Path, err)
+	}
+	recorder := httptest.NewRecorder()
+	e.client.router.ServeHTTP(recorder, req)
+
+	resp := &TestResponse[R]{
+		StatusCode: recorder.Code,
+		Header:     recorder.Header(),
+		Raw:        recorder.Body.Bytes(),
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return resp, &TestResponseError{Method: e.op.Method, Path: e.op.Path, StatusCode: resp.StatusCode, Body: resp.Raw}
+	}
+	if len(resp.Raw) > 0 {
+		if err := json.Unmarshal(resp.Raw, &resp.Body); err != nil {
+			return resp, fmt.Errorf("failed to decode response from %s %s: %w", e.op.Method, e.op.Path, err)
+		}
+	}
+	return resp, nil
+}
+
+// request encodes params into the path, query into the query string, and body as JSON
+// Values are named by their JSON field names, matching how schemas validate them
+func (e *TestEndpoint[P, Q, B, R]) request(ctx context.Context, params P, query Q, body B) (*http.Request, error) {
+	path, err := expandPath(e.op.Path, params)
+	if err != nil {
+		return nil, err
+	}
+	values, err := encodeQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	if encoded := values.Encode(); encoded != "" {
+		path += "?" + encoded
+	}
+
+	var reader io.Reader
+	if e.op.BodySchema != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("invalid body: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, e.op.Method, path, reader)
+	if err != nil {
+		return nil, err
+	}
+	if reader != nil {
+		contentType := e.op.BodyContentType
+		if contentType == "" {
+			contentType = "application/json"
+		}
+		req.Header.Set("Content-Type", contentType)
+	}
+	for name, values := range e.client.headers {
+		req.Header[name] = values
+	}
+	return req, nil
+}
+
+// expandPath substitutes {name} placeholders with the matching fields of params
+func expandPath(path string, params interface{}) (string, error) {
+	if !strings.Contains(path, "{") {
+		return path, nil
+	}
+	fields, err := fieldsOf(params)
+	if err != nil {
+		return "", fmt.Errorf("invalid params: %w", err)
+	}
+	var result strings.Builder
+	for {
+		start := strings.Index(path, "{")
+		if start == -1 {
+			break
+		}
+		end := strings.Index(path[start:], "}")
+		if end == -1 {
+			break
+		}
+		end += start
+
+		name := path[start+1 : end]
+		value, ok := fields[name]
+		if !ok || value == nil {
+			return "", fmt.Errorf("missing path parameter %q", name)
+		}
+		result.WriteString(path[:start])
+		result.WriteString(url.PathEscape(formatField(value)))
+		path = path[end+1:]
+	}
+	result.WriteString(path)
+	return result.String(), nil
+}
+
+// encodeQuery converts query fields to URL values, repeating the key for array fields
+func encodeQuery(query interface{}) (url.Values, error) {
+	fields, err := fieldsOf(query)
+	if err != nil {
+		return nil, fmt.Errorf("invalid query: %w", err)
+	}
+	values := url.Values{}
+	for name, value := range fields {
+		switch v := value.(type) {
+		case nil:
+		case []interface{}:
+			for _, item := range v {
+				values.Add(name, formatField(item))
+			}
+		default:
+			values.Set(name, formatField(v))
+		}
+	}
+	return values, nil
+}
+
+// fieldsOf converts a struct or map to its JSON fields
+// Numbers are kept as json.Number so they format without loss
+func fieldsOf(v interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]interface{}{}
+	if string(data) == "null" {
+		return fields, nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// formatField formats a JSON field value as a path or query string
+func formatField(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		if v {
+			return "true"
+		}
+		return "false"
+	default:
+		data, _ := json.Marshal(v)
+		return string(data)
+	}
+}
@@ -0,0 +1,140 @@
+package operations
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	ginadapter "github.com/picogrid/go-op/operations/adapters/gin"
+	"github.com/picogrid/go-op/validators"
+)
+
+type testClientUser struct {
+	ID    string `json:"id"`
+	Email string `json:"email"`
+}
+
+type testClientParams struct {
+	ID string `uri:"id" json:"id"`
+}
+
+type testClientQuery struct {
+	Tags  []string `form:"tags" json:"tags,omitempty"`
+	Limit int      `form:"limit" json:"limit,omitempty"`
+}
+
+type testClientBody struct {
+	Email string `json:"email"`
+}
+
+func newTestClientRouter(t *testing.T) *ginadapter.GinRouter {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	router := ginadapter.NewGinRouter(gin.New())
+
+	userSchema := validators.Object(map[string]interface{}{
+		"id":    validators.String().Required(),
+		"email": validators.String().Email().Required(),
+	}).Required()
+	paramsSchema := validators.Object(map[string]interface{}{
+		"id": validators.String().Min(1).Required(),
+	}).Required()
+	querySchema := validators.Object(map[string]interface{}{
+		"tags":  validators.Array(validators.String()).Optional(),
+		"limit": validators.Number().Integer().Optional(),
+	}).Optional()
+	bodySchema := validators.Object(map[string]interface{}{
+		"email": validators.String().Email().Required(),
+	}).Required()
+
+	getUser := NewSimple().
+		GET("/users/{id}").
+		OperationID("getUser").
+		WithParams(paramsSchema).
+		WithQuery(querySchema).
+		WithResponse(userSchema).
+		Handler(ginadapter.CreateValidatedHandler(
+			func(ctx context.Context, params testClientParams, query testClientQuery, body struct{}) (testClientUser, error) {
+				email := params.ID + "@example.com"
+				if len(query.Tags) > 0 {
+					email = strings.Join(query.Tags, ".") + "@example.com"
+				}
+				return testClientUser{ID: params.ID, Email: email}, nil
+			},
+			paramsSchema, querySchema, nil, userSchema,
+		))
+
+	createUser := NewSimple().
+		POST("/users").
+		OperationID("createUser").
+		WithBody(bodySchema).
+		WithResponse(userSchema).
+		Handler(ginadapter.CreateValidatedHandler(
+			func(ctx context.Context, params struct{}, query struct{}, body testClientBody) (testClientUser, error) {
+				return testClientUser{ID: "u1", Email: body.Email}, nil
+			},
+			nil, nil, bodySchema, userSchema,
+		))
+
+	if err := router.Register(getUser, createUser); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return router
+}
+
+func TestTestClient(t *testing.T) {
+	client := NewTestClient(newTestClientRouter(t))
+	ctx := context.Background()
+
+	t.Run("Encodes path parameters and query", func(t *testing.T) {
+		getUser := TestEndpointFor[testClientParams, testClientQuery, struct{}, testClientUser](client, "getUser")
+		user, err := getUser.Call(ctx, testClientParams{ID: "ada"}, testClientQuery{Tags: []string{"a", "b"}, Limit: 5}, struct{}{})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if user.ID != "ada" || user.Email != "a.b@example.com" {
+			t.Errorf("Unexpected user %+v", user)
+		}
+	})
+
+	t.Run("Encodes the request body", func(t *testing.T) {
+		createUser := TestEndpointFor[struct{}, struct{}, testClientBody, testClientUser](client, "POST /users")
+		resp, err := createUser.Do(ctx, struct{}{}, struct{}{}, testClientBody{Email: "ada@example.com"})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if resp.StatusCode != http.StatusOK || resp.Body.Email != "ada@example.com" {
+			t.Errorf("Unexpected response %d %+v", resp.StatusCode, resp.Body)
+		}
+	})
+
+	t.Run("Returns error responses", func(t *testing.T) {
+		createUser := TestEndpointFor[struct{}, struct{}, testClientBody, testClientUser](client, "createUser")
+		_, err := createUser.Call(ctx, struct{}{}, struct{}{}, testClientBody{Email: "invalid"})
+		var respErr *TestResponseError
+		if !errors.As(err, &respErr) {
+			t.Fatalf("Expected TestResponseError, got %v", err)
+		}
+		if respErr.StatusCode != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", respErr.StatusCode)
+		}
+	})
+
+	t.Run("Reports unknown operations", func(t *testing.T) {
+		missing := TestEndpointFor[struct{}, struct{}, struct{}, struct{}](client, "deleteUser")
+		if _, err := missing.Call(ctx, struct{}{}, struct{}{}, struct{}{}); err == nil {
+			t.Error("Expected error for unregistered operation")
+		}
+	})
+
+	t.Run("Reports missing path parameters", func(t *testing.T) {
+		getUser := TestEndpointFor[struct{}, struct{}, struct{}, testClientUser](client, "getUser")
+		if _, err := getUser.Call(ctx, struct{}{}, struct{}{}, struct{}{}); err == nil || !strings.Contains(err.Error(), `"id"`) {
+			t.Errorf("Expected missing path parameter error, got %v", err)
+		}
+	})
+}
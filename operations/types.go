@@ -1,6 +1,8 @@
 package operations
 
 import (
+	"context"
+
 	goop "github.com/picogrid/go-op"
 )
 
@@ -10,6 +12,9 @@ import (
 // R represents the Response type
 type Handler[P, Q, B, R any] = goop.Handler[P, Q, B, R]
 
+// HandlerWithHeaders is a Handler that also receives typed request headers
+type HandlerWithHeaders[P, Q, B, H, R any] = goop.HandlerWithHeaders[P, Q, B, H, R]
+
 // HTTPHandler represents a generic HTTP handler function
 // This is framework-agnostic and can be adapted to any HTTP framework
 type HTTPHandler = goop.HTTPHandler
@@ -36,3 +41,110 @@ const (
 	HEAD    = goop.HEAD
 	OPTIONS = goop.OPTIONS
 )
+
+// Idempotency classifies whether an operation is safe to retry
+type Idempotency = goop.Idempotency
+
+// Idempotency classifications for retry policies
+const (
+	Idempotent    = goop.Idempotent
+	NonIdempotent = goop.NonIdempotent
+	Conditional   = goop.Conditional
+)
+
+// ParameterStyle describes how a parameter value is serialized
+type ParameterStyle = goop.ParameterStyle
+
+// ParameterSerialization pairs a parameter style with its explode setting
+type ParameterSerialization = goop.ParameterSerialization
+
+// Parameter serialization styles
+const (
+	StyleForm           = goop.StyleForm
+	StyleSpaceDelimited = goop.StyleSpaceDelimited
+	StylePipeDelimited  = goop.StylePipeDelimited
+	StyleDeepObject     = goop.StyleDeepObject
+)
+
+// IdempotencyStore persists responses keyed by idempotency key
+type IdempotencyStore = goop.IdempotencyStore
+
+// IdempotencyRecord is a stored response replayed for duplicate requests
+type IdempotencyRecord = goop.IdempotencyRecord
+
+// IdempotencyKeyHeader is the request header carrying the client-supplied idempotency key
+const IdempotencyKeyHeader = goop.IdempotencyKeyHeader
+
+// RateLimit declares how many requests are allowed per time window
+type RateLimit = goop.RateLimit
+
+// RateLimiter enforces rate limits for a caller key
+type RateLimiter = goop.RateLimiter
+
+// RateLimitResult reports the outcome of a rate limit check
+type RateLimitResult = goop.RateLimitResult
+
+// ValidationPolicy decides, per request, whether an optional validation step runs
+type ValidationPolicy = goop.ValidationPolicy
+
+// Deprecation describes when an operation was deprecated and when it will be removed
+type Deprecation = goop.Deprecation
+
+// FeatureFlag gates an operation behind a flag evaluated when the operation is registered
+type FeatureFlag = goop.FeatureFlag
+
+// Response headers announcing deprecation
+const (
+	DeprecationHeader = goop.DeprecationHeader
+	SunsetHeader      = goop.SunsetHeader
+)
+
+// CachePolicy describes how long successful responses of an operation may be cached, and by whom
+type CachePolicy = goop.CachePolicy
+
+// Response headers controlling HTTP caching
+const (
+	CacheControlHeader = goop.CacheControlHeader
+	ExpiresHeader      = goop.ExpiresHeader
+)
+
+// FilterOperator compares a field with a value in a filter expression such as status=eq:sent
+type FilterOperator = goop.FilterOperator
+
+// Filter operators accepted in filter expressions
+const (
+	FilterEq       = goop.FilterEq
+	FilterNe       = goop.FilterNe
+	FilterGt       = goop.FilterGt
+	FilterGte      = goop.FilterGte
+	FilterLt       = goop.FilterLt
+	FilterLte      = goop.FilterLte
+	FilterIn       = goop.FilterIn
+	FilterContains = goop.FilterContains
+)
+
+// FilterField declares a field callers can filter or sort by
+type FilterField = goop.FilterField
+
+// QueryFilterSet declares the fields an operation can be filtered and sorted by
+type QueryFilterSet = goop.QueryFilterSet
+
+// Filters holds the sort keys and filter conditions parsed from a request
+type Filters = goop.Filters
+
+// SortField is a sort key parsed from the sort query parameter
+type SortField = goop.SortField
+
+// FilterCondition is a filter expression parsed from a query parameter
+type FilterCondition = goop.FilterCondition
+
+// AuthFromContext returns the authenticated principal stored in ctx as type T
+// Handlers use this to access the caller identity without untyped context lookups
+func AuthFromContext[T any](ctx context.Context) (T, bool) {
+	return goop.AuthFromContext[T](ctx)
+}
+
+// WithAuth returns a copy of ctx carrying the authenticated principal
+func WithAuth(ctx context.Context, principal interface{}) context.Context {
+	return goop.WithAuth(ctx, principal)
+}
@@ -0,0 +1,39 @@
+package operations
+
+import (
+	"math/rand"
+	"os"
+	"strings"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// EnvironmentVariable names the environment variable OnlyInDev reads
+const EnvironmentVariable = "GOOP_ENV"
+
+var (
+	// Always runs validation on every request
+	Always goop.ValidationPolicy = func() bool { return true }
+
+	// Never skips validation
+	Never goop.ValidationPolicy = func() bool { return false }
+
+	// OnlyInDev runs validation unless GOOP_ENV is "production" or "prod"
+	// Unset or any other value is treated as development so validation stays on by default
+	OnlyInDev goop.ValidationPolicy = func() bool { return !isProduction() }
+)
+
+// Sampled runs validation on approximately the given fraction of requests (0.0 to 1.0)
+func Sampled(rate float64) goop.ValidationPolicy {
+	return func() bool {
+		return rand.Float64() < rate //nolint:gosec // sampling does not need a cryptographic source
+	}
+}
+
+func isProduction() bool {
+	switch strings.ToLower(os.Getenv(EnvironmentVariable)) {
+	case "production", "prod":
+		return true
+	}
+	return false
+}
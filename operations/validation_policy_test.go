@@ -0,0 +1,36 @@
+package operations
+
+import "testing"
+
+func TestValidationPolicies(t *testing.T) {
+	t.Run("Always and Never", func(t *testing.T) {
+		if !Always() || Never() {
+			t.Error("Expected Always to validate and Never to skip")
+		}
+	})
+
+	t.Run("OnlyInDev follows GOOP_ENV", func(t *testing.T) {
+		tests := map[string]bool{
+			"":            true,
+			"development": true,
+			"staging":     true,
+			"production":  false,
+			"PROD":        false,
+		}
+		for env, expected := range tests {
+			t.Setenv(EnvironmentVariable, env)
+			if got := OnlyInDev(); got != expected {
+				t.Errorf("GOOP_ENV=%q: expected %v, got %v", env, expected, got)
+			}
+		}
+	})
+
+	t.Run("Sampled bounds", func(t *testing.T) {
+		all, none := Sampled(1), Sampled(0)
+		for i := 0; i < 100; i++ {
+			if !all() || none() {
+				t.Fatal("Expected rate 1 to always validate and rate 0 to never validate")
+			}
+		}
+	})
+}
@@ -0,0 +1,208 @@
+// Package plugin defines the protocol between `goop generate` and custom generator plugins.
+//
+// Plugins are executables that read a JSON Request describing the scanned operations and the
+// generated OpenAPI spec from stdin, and write a JSON Response listing the files to create to
+// stdout. Anything written to stderr is shown to the user. Plugins named goop-gen-<name> on the
+// PATH are run with `goop generate --plugin <name>`.
+//
+// Go plugins can use Run to handle the protocol:
+//
+//	func main() {
+//	    plugin.Run(func(req *plugin.Request) (*plugin.Response, error) {
+//	        var routes bytes.Buffer
+//	        for _, op := range req.Operations {
+//	            fmt.Fprintf(&routes, "%s %s -> %s\n", op.Method, op.Path, req.Options["upstream"])
+//	        }
+//	        return &plugin.Response{Files: []plugin.File{{Name: "routes.txt", Content: routes.String()}}}, nil
+//	    })
+//	}
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	goop "github.com/picogrid/go-op"
+	"github.com/picogrid/go-op/operations"
+)
+
+// ProtocolVersion is the version of the request and response format
+const ProtocolVersion = "1"
+
+// ExecutablePrefix prefixes the names of plugin executables looked up on the PATH
+const ExecutablePrefix = "goop-gen-"
+
+// Request is sent to a plugin on stdin
+type Request struct {
+	ProtocolVersion string `json:"protocolVersion"`
+
+	// Options are the plugin-specific key=value options given with --plugin-opt
+	Options map[string]string `json:"options,omitempty"`
+
+	// Operations are the operations found by goop generate, in source order
+	Operations []Operation `json:"operations"`
+
+	// Spec is the generated OpenAPI specification
+	Spec *operations.OpenAPISpec `json:"spec,omitempty"`
+}
+
+// Operation describes an operation found in source code
+// Schemas use the same OpenAPI representation as the generated spec.
+type Operation struct {
+	Method      string   `json:"method"`
+	Path        string   `json:"path"`
+	OperationID string   `json:"operationId,omitempty"`
+	Summary     string   `json:"summary,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+
+	Params  *goop.OpenAPISchema `json:"params,omitempty"`
+	Query   *goop.OpenAPISchema `json:"query,omitempty"`
+	Body    *goop.OpenAPISchema `json:"body,omitempty"`
+	Headers *goop.OpenAPISchema `json:"headers,omitempty"`
+
+	// Responses are keyed by status code
+	Responses map[string]OperationResponse `json:"responses,omitempty"`
+
+	TimeoutMs    int64      `json:"timeoutMs,omitempty"`
+	Idempotency  string     `json:"idempotency,omitempty"`
+	RateLimit    *RateLimit `json:"rateLimit,omitempty"`
+	MaxBodyBytes int64      `json:"maxBodyBytes,omitempty"`
+	Version      string     `json:"version,omitempty"`
+	Audiences    []string   `json:"audiences,omitempty"`
+	Deprecated   bool       `json:"deprecated,omitempty"`
+	Successor    string     `json:"successor,omitempty"`
+
+	SourceFile string `json:"sourceFile,omitempty"`
+	LineNumber int    `json:"lineNumber,omitempty"`
+}
+
+// OperationResponse describes a declared response
+type OperationResponse struct {
+	Description string              `json:"description,omitempty"`
+	Schema      *goop.OpenAPISchema `json:"schema,omitempty"`
+}
+
+// RateLimit describes a declared request rate limit
+type RateLimit struct {
+	Requests int   `json:"requests"`
+	WindowMs int64 `json:"windowMs"`
+}
+
+// Response is written by a plugin to stdout
+type Response struct {
+	// Files are created relative to the plugin output directory
+	Files []File `json:"files,omitempty"`
+
+	// Error reports a generation failure; files are not written when it is set
+	Error string `json:"error,omitempty"`
+}
+
+// File is a generated file
+type File struct {
+	// Name is a slash-separated path relative to the output directory
+	Name    string `json:"name"`
+	Content string `json:"content"`
+}
+
+// Run reads a request from stdin, calls generate, and writes its response to stdout
+// Errors from generate are reported in the response; Run exits with status 1 if the protocol fails.
+func Run(generate func(req *Request) (*Response, error)) {
+	if err := Serve(os.Stdin, os.Stdout, generate); err != nil {
+		fmt.Fprintf(os.Stderr, "plugin: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// Serve reads a request from r, calls generate, and writes its response to w
+func Serve(r io.Reader, w io.Writer, generate func(req *Request) (*Response, error)) error {
+	var req Request
+	if err := json.NewDecoder(r).Decode(&req); err != nil {
+		return fmt.Errorf("failed to decode request: %w", err)
+	}
+	if req.ProtocolVersion != ProtocolVersion {
+		return fmt.Errorf("unsupported protocol version %q (supported: %s)", req.ProtocolVersion, ProtocolVersion)
+	}
+
+	resp, err := generate(&req)
+	if err != nil {
+		resp = &Response{Error: err.Error()}
+	} else if resp == nil {
+		resp = &Response{}
+	}
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		return fmt.Errorf("failed to encode response: %w", err)
+	}
+	return nil
+}
+
+// Lookup resolves a plugin name to an executable path
+// Names containing a path separator are used as paths; other names are looked up on the PATH
+// as goop-gen-<name>.
+func Lookup(name string) (string, error) {
+	if strings.ContainsRune(name, '/') || strings.ContainsRune(name, filepath.Separator) {
+		return filepath.Abs(name)
+	}
+	path, err := exec.LookPath(ExecutablePrefix + name)
+	if err != nil {
+		return "", fmt.Errorf("plugin %s not found: install %s%s on your PATH", name, ExecutablePrefix, name)
+	}
+	return path, nil
+}
+
+// Invoke runs the plugin executable at path with req and returns its response
+// Plugin stderr is forwarded to stderr. A response reporting an error is returned as an error.
+func Invoke(ctx context.Context, path string, req *Request, stderr io.Writer) (*Response, error) {
+	input, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	var output bytes.Buffer
+	cmd := exec.CommandContext(ctx, path) //nolint:gosec // plugins are chosen by the user running goop
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &output
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("plugin %s failed: %w", filepath.Base(path), err)
+	}
+
+	var resp Response
+	if err := json.Unmarshal(output.Bytes(), &resp); err != nil {
+		return nil, fmt.Errorf("plugin %s wrote an invalid response: %w", filepath.Base(path), err)
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("plugin %s: %s", filepath.Base(path), resp.Error)
+	}
+	return &resp, nil
+}
+
+// WriteFiles writes the response files under dir and returns their paths
+// File names must be relative paths that stay inside dir.
+func (r *Response) WriteFiles(dir string) ([]string, error) {
+	paths := make([]string, 0, len(r.Files))
+	for _, file := range r.Files {
+		name := filepath.FromSlash(file.Name)
+		if file.Name == "" || filepath.IsAbs(name) || !filepath.IsLocal(name) {
+			return paths, fmt.Errorf("invalid file name %q: must be a relative path inside the output directory", file.Name)
+		}
+
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			return paths, fmt.Errorf("failed to create directory for %s: %w", file.Name, err)
+		}
+		if err := os.WriteFile(path, []byte(file.Content), 0o644); err != nil {
+			return paths, fmt.Errorf("failed to write %s: %w", file.Name, err)
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
@@ -0,0 +1,108 @@
+package plugin_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/picogrid/go-op/plugin"
+)
+
+// TestMain runs the test binary as a plugin when GOOP_TEST_PLUGIN is set
+func TestMain(m *testing.M) {
+	if os.Getenv("GOOP_TEST_PLUGIN") == "1" {
+		plugin.Run(routes)
+		return
+	}
+	os.Exit(m.Run())
+}
+
+func routes(req *plugin.Request) (*plugin.Response, error) {
+	if req.Options["fail"] != "" {
+		return nil, errors.New(req.Options["fail"])
+	}
+	var out strings.Builder
+	for _, op := range req.Operations {
+		out.WriteString(op.Method + " " + op.Path + "\n")
+	}
+	return &plugin.Response{Files: []plugin.File{{Name: "gateway/routes.txt", Content: out.String()}}}, nil
+}
+
+// TestServe tests the plugin side of the protocol
+func TestServe(t *testing.T) {
+	t.Run("writes the generated files", func(t *testing.T) {
+		var out bytes.Buffer
+		in := `{"protocolVersion":"1","operations":[{"method":"GET","path":"/orders"}]}`
+		require.NoError(t, plugin.Serve(strings.NewReader(in), &out, routes))
+		assert.JSONEq(t, `{"files":[{"name":"gateway/routes.txt","content":"GET /orders\n"}]}`, out.String())
+	})
+
+	t.Run("reports generation errors in the response", func(t *testing.T) {
+		var out bytes.Buffer
+		in := `{"protocolVersion":"1","options":{"fail":"missing upstream"},"operations":[]}`
+		require.NoError(t, plugin.Serve(strings.NewReader(in), &out, routes))
+		assert.JSONEq(t, `{"error":"missing upstream"}`, out.String())
+	})
+
+	t.Run("rejects unsupported protocol versions", func(t *testing.T) {
+		err := plugin.Serve(strings.NewReader(`{"protocolVersion":"2"}`), &bytes.Buffer{}, routes)
+		assert.ErrorContains(t, err, "unsupported protocol version")
+	})
+}
+
+// TestInvoke tests running a plugin executable
+func TestInvoke(t *testing.T) {
+	executable, err := os.Executable()
+	require.NoError(t, err)
+	t.Setenv("GOOP_TEST_PLUGIN", "1")
+
+	req := &plugin.Request{
+		ProtocolVersion: plugin.ProtocolVersion,
+		Operations:      []plugin.Operation{{Method: "POST", Path: "/orders"}},
+	}
+	resp, err := plugin.Invoke(context.Background(), executable, req, os.Stderr)
+	require.NoError(t, err)
+	require.Len(t, resp.Files, 1)
+	assert.Equal(t, "POST /orders\n", resp.Files[0].Content)
+
+	req.Options = map[string]string{"fail": "missing upstream"}
+	_, err = plugin.Invoke(context.Background(), executable, req, os.Stderr)
+	assert.ErrorContains(t, err, "missing upstream")
+}
+
+// TestLookup tests resolving plugin names
+func TestLookup(t *testing.T) {
+	path, err := plugin.Lookup("./bin/routes")
+	require.NoError(t, err)
+	assert.True(t, filepath.IsAbs(path))
+
+	t.Setenv("PATH", t.TempDir())
+	_, err = plugin.Lookup("gateway")
+	assert.ErrorContains(t, err, "goop-gen-gateway")
+}
+
+// TestWriteFiles tests writing plugin output
+func TestWriteFiles(t *testing.T) {
+	dir := t.TempDir()
+	resp := &plugin.Response{Files: []plugin.File{{Name: "gateway/routes.txt", Content: "GET /orders\n"}}}
+	paths, err := resp.WriteFiles(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "gateway", "routes.txt")}, paths)
+
+	content, err := os.ReadFile(paths[0])
+	require.NoError(t, err)
+	assert.Equal(t, "GET /orders\n", string(content))
+
+	for _, name := range []string{"", "../routes.txt", "/etc/routes.txt"} {
+		resp := &plugin.Response{Files: []plugin.File{{Name: name}}}
+		_, err := resp.WriteFiles(dir)
+		assert.Error(t, err, name)
+	}
+}
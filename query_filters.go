@@ -0,0 +1,183 @@
+package goop
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// SortQueryParameter is the query parameter listing sort keys, such as sort=-created_at,name
+const SortQueryParameter = "sort"
+
+// FilterOperator compares a field with a value in a filter expression such as status=eq:sent
+type FilterOperator string
+
+// Filter operators accepted in filter expressions
+const (
+	FilterEq       FilterOperator = "eq"
+	FilterNe       FilterOperator = "ne"
+	FilterGt       FilterOperator = "gt"
+	FilterGte      FilterOperator = "gte"
+	FilterLt       FilterOperator = "lt"
+	FilterLte      FilterOperator = "lte"
+	FilterIn       FilterOperator = "in"
+	FilterContains FilterOperator = "contains"
+)
+
+// filterOperators lists every operator so expression prefixes can be recognized
+var filterOperators = map[FilterOperator]bool{
+	FilterEq: true, FilterNe: true, FilterGt: true, FilterGte: true,
+	FilterLt: true, FilterLte: true, FilterIn: true, FilterContains: true,
+}
+
+// FilterField declares a field callers can filter or sort by
+type FilterField struct {
+	Name string
+	// Operators accepted in filter expressions on the field (empty means the field cannot be filtered)
+	Operators []FilterOperator
+	// Sortable reports whether the field can be used as a sort key
+	Sortable bool
+}
+
+// QueryFilterSet declares the fields an operation can be filtered and sorted by
+type QueryFilterSet struct {
+	Fields []FilterField
+}
+
+// SortField is a sort key parsed from the sort query parameter
+type SortField struct {
+	Field      string
+	Descending bool
+}
+
+// FilterCondition is a filter expression parsed from a query parameter
+type FilterCondition struct {
+	Field    string
+	Operator FilterOperator
+	// Values holds the comparison value, or every value of an in expression
+	Values []string
+}
+
+// Value returns the comparison value, or the first value of an in expression
+func (c FilterCondition) Value() string {
+	if len(c.Values) == 0 {
+		return ""
+	}
+	return c.Values[0]
+}
+
+// Filters holds the sort keys and filter conditions parsed from a request
+// Embed Filters in a query struct to receive them in handlers.
+type Filters struct {
+	Sort       []SortField       `json:"-" form:"-"`
+	Conditions []FilterCondition `json:"-" form:"-"`
+}
+
+// SetFilters stores parsed filters, implementing FilterReceiver
+func (f *Filters) SetFilters(filters Filters) {
+	*f = filters
+}
+
+// Where returns the conditions on field in request order
+func (f Filters) Where(field string) []FilterCondition {
+	var conditions []FilterCondition
+	for _, condition := range f.Conditions {
+		if condition.Field == field {
+			conditions = append(conditions, condition)
+		}
+	}
+	return conditions
+}
+
+// filtersContextKey is the unexported context key for the filters parsed from a request
+type filtersContextKey struct{}
+
+// WithFilters returns a copy of ctx carrying the filters parsed from the request
+// Framework adapters call this so validation and handlers can read them, e.g. to check cursor tokens
+func WithFilters(ctx context.Context, filters Filters) context.Context {
+	return context.WithValue(ctx, filtersContextKey{}, filters)
+}
+
+// FiltersFromContext returns the filters stored in ctx by WithFilters
+// The boolean is false when the operation does not declare query filters.
+func FiltersFromContext(ctx context.Context) (Filters, bool) {
+	filters, ok := ctx.Value(filtersContextKey{}).(Filters)
+	return filters, ok
+}
+
+// FilterReceiver is implemented by query structs that receive parsed filters, typically by embedding Filters
+type FilterReceiver interface {
+	SetFilters(filters Filters)
+}
+
+// Parse parses sort keys and filter expressions from query values
+// Sort keys are comma-separated field names, descending when prefixed with "-". Filter expressions
+// take the form field=operator:value, where eq is the default operator and in takes comma-separated values.
+// Query parameters that are not declared fields are ignored.
+func (s QueryFilterSet) Parse(query url.Values) (Filters, error) {
+	var filters Filters
+
+	for _, value := range query[SortQueryParameter] {
+		for _, key := range strings.Split(value, ",") {
+			key = strings.TrimSpace(key)
+			if key == "" {
+				continue
+			}
+			sort := SortField{Field: strings.TrimPrefix(key, "-"), Descending: strings.HasPrefix(key, "-")}
+			if field, ok := s.field(sort.Field); !ok || !field.Sortable {
+				return Filters{}, fmt.Errorf("cannot sort by %q", sort.Field)
+			}
+			filters.Sort = append(filters.Sort, sort)
+		}
+	}
+
+	for _, field := range s.Fields {
+		if len(field.Operators) == 0 {
+			continue
+		}
+		for _, expression := range query[field.Name] {
+			condition := parseFilterExpression(field.Name, expression)
+			if !field.allows(condition.Operator) {
+				return Filters{}, fmt.Errorf("operator %q is not supported for %q", condition.Operator, field.Name)
+			}
+			filters.Conditions = append(filters.Conditions, condition)
+		}
+	}
+
+	return filters, nil
+}
+
+// field returns the declared field named name
+func (s QueryFilterSet) field(name string) (FilterField, bool) {
+	for _, field := range s.Fields {
+		if field.Name == name {
+			return field, true
+		}
+	}
+	return FilterField{}, false
+}
+
+// allows reports whether operator is accepted for the field
+func (f FilterField) allows(operator FilterOperator) bool {
+	for _, allowed := range f.Operators {
+		if allowed == operator {
+			return true
+		}
+	}
+	return false
+}
+
+// parseFilterExpression splits an operator prefix from a filter value
+// Values without a recognized operator prefix, such as a time like 10:30, compare with eq.
+func parseFilterExpression(field, expression string) FilterCondition {
+	condition := FilterCondition{Field: field, Operator: FilterEq, Values: []string{expression}}
+	if prefix, value, found := strings.Cut(expression, ":"); found && filterOperators[FilterOperator(prefix)] {
+		condition.Operator = FilterOperator(prefix)
+		condition.Values = []string{value}
+		if condition.Operator == FilterIn {
+			condition.Values = strings.Split(value, ",")
+		}
+	}
+	return condition
+}
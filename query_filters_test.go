@@ -0,0 +1,108 @@
+package goop
+
+import (
+	"context"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+// TestQueryFilterSetParse tests parsing sort keys and filter expressions
+func TestQueryFilterSetParse(t *testing.T) {
+	set := QueryFilterSet{Fields: []FilterField{
+		{Name: "status", Operators: []FilterOperator{FilterEq, FilterNe, FilterIn}},
+		{Name: "created_at", Operators: []FilterOperator{FilterGte, FilterLt}, Sortable: true},
+		{Name: "name", Sortable: true},
+		{Name: "starts_at", Operators: []FilterOperator{FilterEq}},
+	}}
+
+	parse := func(t *testing.T, query string) (Filters, error) {
+		t.Helper()
+		values, err := url.ParseQuery(query)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return set.Parse(values)
+	}
+
+	t.Run("Parses sort keys", func(t *testing.T) {
+		filters, err := parse(t, "sort=-created_at,name")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		expected := []SortField{{Field: "created_at", Descending: true}, {Field: "name"}}
+		if !reflect.DeepEqual(filters.Sort, expected) {
+			t.Errorf("Expected %v, got %v", expected, filters.Sort)
+		}
+	})
+
+	t.Run("Parses filter expressions", func(t *testing.T) {
+		filters, err := parse(t, "status=in:sent,queued&created_at=gte:2024-01-01&status=ne:failed&page=2")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		status := filters.Where("status")
+		if len(status) != 2 {
+			t.Fatalf("Expected 2 status conditions, got %v", status)
+		}
+		if status[0].Operator != FilterIn || !reflect.DeepEqual(status[0].Values, []string{"sent", "queued"}) {
+			t.Errorf("Unexpected in condition %+v", status[0])
+		}
+		if status[1].Operator != FilterNe || status[1].Value() != "failed" {
+			t.Errorf("Unexpected ne condition %+v", status[1])
+		}
+		if created := filters.Where("created_at"); len(created) != 1 || created[0].Operator != FilterGte || created[0].Value() != "2024-01-01" {
+			t.Errorf("Unexpected created_at conditions %v", created)
+		}
+	})
+
+	t.Run("Defaults to eq", func(t *testing.T) {
+		filters, err := parse(t, "status=sent&starts_at=10:30")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if condition := filters.Where("status")[0]; condition.Operator != FilterEq || condition.Value() != "sent" {
+			t.Errorf("Unexpected condition %+v", condition)
+		}
+		if condition := filters.Where("starts_at")[0]; condition.Operator != FilterEq || condition.Value() != "10:30" {
+			t.Errorf("Expected value with a colon to compare with eq, got %+v", condition)
+		}
+	})
+
+	t.Run("Rejects undeclared sort keys", func(t *testing.T) {
+		if _, err := parse(t, "sort=status"); err == nil {
+			t.Error("Expected error sorting by a field that is not sortable")
+		}
+		if _, err := parse(t, "sort=-password"); err == nil {
+			t.Error("Expected error sorting by an unknown field")
+		}
+	})
+
+	t.Run("Rejects unsupported operators", func(t *testing.T) {
+		if _, err := parse(t, "created_at=eq:2024-01-01"); err == nil {
+			t.Error("Expected error for an operator the field does not allow")
+		}
+	})
+
+	t.Run("Ignores sort-only fields as filters", func(t *testing.T) {
+		filters, err := parse(t, "name=eq:ada")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(filters.Conditions) != 0 {
+			t.Errorf("Expected no conditions, got %v", filters.Conditions)
+		}
+	})
+}
+
+// TestFiltersContext tests storing parsed filters in a context
+func TestFiltersContext(t *testing.T) {
+	if _, ok := FiltersFromContext(context.Background()); ok {
+		t.Error("Expected no filters in an empty context")
+	}
+	filters := Filters{Sort: []SortField{{Field: "name", Descending: true}}}
+	got, ok := FiltersFromContext(WithFilters(context.Background(), filters))
+	if !ok || !reflect.DeepEqual(got, filters) {
+		t.Errorf("Expected %v, got %v", filters, got)
+	}
+}
@@ -0,0 +1,33 @@
+package goop
+
+import (
+	"context"
+	"time"
+)
+
+// Rate limit response headers set by enforcing adapters and documented in the spec
+const (
+	RateLimitLimitHeader     = "X-RateLimit-Limit"
+	RateLimitRemainingHeader = "X-RateLimit-Remaining"
+	RateLimitResetHeader     = "X-RateLimit-Reset"
+)
+
+// RateLimit declares how many requests are allowed per time window
+type RateLimit struct {
+	Requests int
+	Window   time.Duration
+}
+
+// RateLimitResult reports the outcome of a rate limit check
+type RateLimitResult struct {
+	Allowed   bool
+	Remaining int
+	// ResetAfter is the time until the current window resets
+	ResetAfter time.Duration
+}
+
+// RateLimiter enforces rate limits for a caller key
+// Implementations may be in-memory or backed by a shared store such as Redis
+type RateLimiter interface {
+	Allow(ctx context.Context, key string, limit RateLimit) (RateLimitResult, error)
+}
@@ -0,0 +1,30 @@
+package goop
+
+import (
+	"net/http"
+	"time"
+)
+
+// RecordedExchange is a request/response pair captured from a served operation
+type RecordedExchange struct {
+	OperationID string
+	Method      string
+	// Path is the operation's path template, e.g. /users/{id}
+	Path string
+	// URL is the full request URL including the query string
+	URL       string
+	StartedAt time.Time
+	Duration  time.Duration
+
+	RequestHeaders  http.Header
+	RequestBody     []byte
+	StatusCode      int
+	ResponseHeaders http.Header
+	ResponseBody    []byte
+}
+
+// ExchangeRecorder receives exchanges captured by adapters in development
+// Implementations must be safe for concurrent use
+type ExchangeRecorder interface {
+	Record(exchange RecordedExchange)
+}
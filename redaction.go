@@ -0,0 +1,189 @@
+package goop
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// DataClassificationExtension is the schema extension classifying the data a field holds, for
+// compliance tooling and redaction
+const DataClassificationExtension = "x-data-classification"
+
+// PIICategoryExtension is the schema extension naming the kind of personal data a field holds,
+// such as "email" or "phone"
+const PIICategoryExtension = "x-pii-category"
+
+// Data classifications whose values are masked by a Redactor
+const (
+	ClassificationSensitive = "sensitive"
+	ClassificationPII       = "pii"
+)
+
+// RedactedValue replaces the values of classified fields
+const RedactedValue = "[REDACTED]"
+
+// IsClassified reports whether values described by schema are masked by a Redactor: sensitive
+// and personal data, and passwords
+func IsClassified(schema *OpenAPISchema) bool {
+	if schema.Format == "password" {
+		return true
+	}
+	switch schema.Extensions[DataClassificationExtension] {
+	case ClassificationSensitive, ClassificationPII:
+		return true
+	}
+	return false
+}
+
+// Redactor masks the values of classified fields in documents described by a schema
+// A nil Redactor has nothing to mask and returns documents unchanged.
+type Redactor struct {
+	mask       bool
+	properties map[string]*Redactor
+	items      *Redactor
+	additional *Redactor
+}
+
+// NewRedactor returns a Redactor for documents described by schema, or nil when the schema has
+// no classified fields. resolve returns the schema a $ref points to, or nil if it is unknown;
+// with a nil resolve, referenced schemas are not inspected.
+func NewRedactor(schema *OpenAPISchema, resolve func(ref string) *OpenAPISchema) *Redactor {
+	return buildRedactor(schema, resolve, make(map[string]bool))
+}
+
+func buildRedactor(schema *OpenAPISchema, resolve func(ref string) *OpenAPISchema, visiting map[string]bool) *Redactor {
+	if schema == nil {
+		return nil
+	}
+	if schema.Ref != "" {
+		// Recursive references are inspected once
+		if resolve == nil || visiting[schema.Ref] {
+			return nil
+		}
+		visiting[schema.Ref] = true
+		defer delete(visiting, schema.Ref)
+		return buildRedactor(resolve(schema.Ref), resolve, visiting)
+	}
+	if IsClassified(schema) {
+		return &Redactor{mask: true}
+	}
+
+	r := &Redactor{}
+	for name, property := range schema.Properties {
+		if child := buildRedactor(property, resolve, visiting); child != nil {
+			if r.properties == nil {
+				r.properties = make(map[string]*Redactor)
+			}
+			r.properties[name] = child
+		}
+	}
+	r.items = buildRedactor(schema.Items, resolve, visiting)
+	if schema.AdditionalProperties != nil {
+		r.additional = buildRedactor(schema.AdditionalProperties.Schema, resolve, visiting)
+	}
+	// Members of compositions may describe the same document, so their fields are combined
+	for _, members := range [][]*OpenAPISchema{schema.AllOf, schema.AnyOf, schema.OneOf} {
+		for _, member := range members {
+			r.merge(buildRedactor(member, resolve, visiting))
+		}
+	}
+
+	if !r.mask && r.properties == nil && r.items == nil && r.additional == nil {
+		return nil
+	}
+	return r
+}
+
+// merge adds the fields masked by other to r
+func (r *Redactor) merge(other *Redactor) {
+	if other == nil {
+		return
+	}
+	r.mask = r.mask || other.mask
+	for name, child := range other.properties {
+		if r.properties == nil {
+			r.properties = make(map[string]*Redactor)
+		}
+		r.properties[name] = mergeRedactors(r.properties[name], child)
+	}
+	r.items = mergeRedactors(r.items, other.items)
+	r.additional = mergeRedactors(r.additional, other.additional)
+}
+
+func mergeRedactors(a, b *Redactor) *Redactor {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	// Merge into a copy so the redactors of other schemas are left unchanged
+	merged := &Redactor{mask: a.mask, items: a.items, additional: a.additional}
+	if a.properties != nil {
+		merged.properties = make(map[string]*Redactor, len(a.properties))
+		for name, child := range a.properties {
+			merged.properties[name] = child
+		}
+	}
+	merged.merge(b)
+	return merged
+}
+
+// MergeRedactors returns a Redactor masking the fields of every given Redactor, or nil if all are nil
+// It is used for operations whose responses are described by several schemas.
+func MergeRedactors(redactors ...*Redactor) *Redactor {
+	var merged *Redactor
+	for _, r := range redactors {
+		merged = mergeRedactors(merged, r)
+	}
+	return merged
+}
+
+// Redact returns a copy of value, a decoded JSON document, with classified fields replaced by
+// RedactedValue. Null values are kept.
+func (r *Redactor) Redact(value interface{}) interface{} {
+	if r == nil || value == nil {
+		return value
+	}
+	if r.mask {
+		return RedactedValue
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for name, field := range v {
+			child, ok := r.properties[name]
+			if !ok {
+				child = r.additional
+			}
+			result[name] = child.Redact(field)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			result[i] = r.items.Redact(item)
+		}
+		return result
+	}
+	return value
+}
+
+// RedactJSON returns body with classified fields replaced by RedactedValue
+// Bodies that are not valid JSON are returned unchanged.
+func (r *Redactor) RedactJSON(body []byte) []byte {
+	if r == nil || len(body) == 0 {
+		return body
+	}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return body
+	}
+	redacted, err := json.Marshal(r.Redact(value))
+	if err != nil {
+		return body
+	}
+	return redacted
+}
@@ -0,0 +1,84 @@
+package goop
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestRedactor tests masking classified fields in decoded and encoded documents
+func TestRedactor(t *testing.T) {
+	pii := &OpenAPISchema{Type: "string", Extensions: map[string]interface{}{DataClassificationExtension: ClassificationPII}}
+	contact := &OpenAPISchema{
+		Type: "object",
+		Properties: map[string]*OpenAPISchema{
+			"name":     {Type: "string"},
+			"email":    pii,
+			"password": {Type: "string", Format: "password"},
+		},
+	}
+	schema := &OpenAPISchema{
+		Type: "object",
+		Properties: map[string]*OpenAPISchema{
+			"owner":    {Ref: "#/components/schemas/Contact"},
+			"contacts": {Type: "array", Items: &OpenAPISchema{Ref: "#/components/schemas/Contact"}},
+			"labels":   {Type: "object", AdditionalProperties: &OpenAPISchemaOrBool{Schema: pii}},
+		},
+	}
+	resolve := func(ref string) *OpenAPISchema {
+		if ref == "#/components/schemas/Contact" {
+			return contact
+		}
+		return nil
+	}
+
+	redactor := NewRedactor(schema, resolve)
+	document := map[string]interface{}{
+		"owner":    map[string]interface{}{"name": "Ada", "email": "ada@example.com", "password": "secret"},
+		"contacts": []interface{}{map[string]interface{}{"name": "Grace", "email": nil}},
+		"labels":   map[string]interface{}{"home": "12 Main St"},
+	}
+	expected := map[string]interface{}{
+		"owner":    map[string]interface{}{"name": "Ada", "email": RedactedValue, "password": RedactedValue},
+		"contacts": []interface{}{map[string]interface{}{"name": "Grace", "email": nil}},
+		"labels":   map[string]interface{}{"home": RedactedValue},
+	}
+	if redacted := redactor.Redact(document); !reflect.DeepEqual(redacted, expected) {
+		t.Errorf("Expected %v, got %v", expected, redacted)
+	}
+	if document["owner"].(map[string]interface{})["email"] != "ada@example.com" {
+		t.Error("Expected the document to be left unchanged")
+	}
+
+	t.Run("JSON", func(t *testing.T) {
+		body := redactor.RedactJSON([]byte(`{"owner":{"name":"Ada","email":"ada@example.com"},"count":12345678901234567890}`))
+		if string(body) != `{"count":12345678901234567890,"owner":{"email":"[REDACTED]","name":"Ada"}}` {
+			t.Errorf("Unexpected redacted body: %s", body)
+		}
+		if invalid := redactor.RedactJSON([]byte("not json")); string(invalid) != "not json" {
+			t.Errorf("Expected invalid JSON to be returned unchanged, got %s", invalid)
+		}
+	})
+
+	t.Run("Nothing classified", func(t *testing.T) {
+		if r := NewRedactor(&OpenAPISchema{Type: "object", Properties: map[string]*OpenAPISchema{"name": {Type: "string"}}}, nil); r != nil {
+			t.Error("Expected a nil redactor")
+		}
+		var r *Redactor
+		if body := r.RedactJSON([]byte(`{"email":"ada@example.com"}`)); string(body) != `{"email":"ada@example.com"}` {
+			t.Errorf("Expected a nil redactor to return the body unchanged, got %s", body)
+		}
+	})
+
+	t.Run("Merge", func(t *testing.T) {
+		first := NewRedactor(&OpenAPISchema{Properties: map[string]*OpenAPISchema{"email": pii}}, nil)
+		second := NewRedactor(&OpenAPISchema{Properties: map[string]*OpenAPISchema{"phone": pii}}, nil)
+		merged := MergeRedactors(nil, first, second)
+		redacted := merged.Redact(map[string]interface{}{"email": "a", "phone": "b"}).(map[string]interface{})
+		if redacted["email"] != RedactedValue || redacted["phone"] != RedactedValue {
+			t.Errorf("Expected both fields to be masked, got %v", redacted)
+		}
+		if first.Redact(map[string]interface{}{"phone": "b"}).(map[string]interface{})["phone"] != "b" {
+			t.Error("Expected merging to leave the original redactors unchanged")
+		}
+	})
+}
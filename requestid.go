@@ -0,0 +1,49 @@
+package goop
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// RequestIDHeader is the header carrying the correlation ID of a request and its response
+const RequestIDHeader = "X-Request-ID"
+
+// MaxRequestIDLength is the longest request ID accepted from clients
+const MaxRequestIDLength = 128
+
+// requestIDContextKey is the unexported context key for the request ID
+type requestIDContextKey struct{}
+
+// WithRequestID returns a copy of ctx carrying the request ID
+// Framework adapters call this once the ID is accepted from the client or generated
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey{}, id)
+}
+
+// RequestIDFromContext returns the request ID stored in ctx, or "" if there is none
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDContextKey{}).(string)
+	return id
+}
+
+// NewRequestID returns a random 128-bit request ID in hex
+func NewRequestID() string {
+	var id [16]byte
+	_, _ = rand.Read(id[:])
+	return hex.EncodeToString(id[:])
+}
+
+// ValidRequestID reports whether a client-supplied request ID can be used as is: non-empty, at
+// most MaxRequestIDLength characters, and printable ASCII without spaces, so it is safe to log
+func ValidRequestID(id string) bool {
+	if id == "" || len(id) > MaxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,44 @@
+package goop
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// TestRequestID tests generating, validating, and storing request IDs
+func TestRequestID(t *testing.T) {
+	t.Run("Generates distinct IDs", func(t *testing.T) {
+		first, second := NewRequestID(), NewRequestID()
+		if len(first) != 32 || first == second {
+			t.Errorf("Expected distinct 32 character IDs, got %q and %q", first, second)
+		}
+		if !ValidRequestID(first) {
+			t.Errorf("Expected generated ID %q to be valid", first)
+		}
+	})
+
+	t.Run("Validates client IDs", func(t *testing.T) {
+		valid := []string{"abc-123", "4bf92f35-77b3-4da6", strings.Repeat("a", MaxRequestIDLength)}
+		for _, id := range valid {
+			if !ValidRequestID(id) {
+				t.Errorf("Expected %q to be valid", id)
+			}
+		}
+		invalid := []string{"", "has space", "line\nbreak", "café", strings.Repeat("a", MaxRequestIDLength+1)}
+		for _, id := range invalid {
+			if ValidRequestID(id) {
+				t.Errorf("Expected %q to be invalid", id)
+			}
+		}
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		if id := RequestIDFromContext(context.Background()); id != "" {
+			t.Errorf("Expected no request ID, got %q", id)
+		}
+		if id := RequestIDFromContext(WithRequestID(context.Background(), "req-1")); id != "req-1" {
+			t.Errorf("Expected req-1, got %q", id)
+		}
+	})
+}
@@ -15,6 +15,65 @@ type Schema interface {
 // R represents the Response type
 type Handler[P, Q, B, R any] func(ctx context.Context, params P, query Q, body B) (R, error)
 
+// HandlerWithHeaders is a Handler that also receives typed request headers
+// H represents the Headers type, bound from the request's header values
+type HandlerWithHeaders[P, Q, B, H, R any] func(ctx context.Context, params P, query Q, body B, headers H) (R, error)
+
+// Compiler is implemented by schemas that can precompute a faster representation for repeated validation
+type Compiler interface {
+	Compile() Schema
+}
+
+// CompileSchema returns the compiled form of schema when it implements Compiler, and schema unchanged otherwise
+func CompileSchema(schema Schema) Schema {
+	if compiler, ok := schema.(Compiler); ok {
+		return compiler.Compile()
+	}
+	return schema
+}
+
+// Validatable is implemented by types that validate themselves without a runtime schema,
+// such as the structs emitted by goop codegen-validators
+type Validatable interface {
+	Validate() error
+}
+
+// GeneratedValidator is implemented by the structs emitted by goop codegen-validators, whose
+// Validate method checks everything the operation's schema expresses. Adapters call it instead of
+// running the schema. Only types embedding Generated implement it, so a hand-written Validate
+// method on a request type never replaces schema validation.
+type GeneratedValidator interface {
+	Validatable
+	generatedValidator()
+}
+
+// Generated marks a struct emitted by goop codegen-validators as a GeneratedValidator
+// It has no fields and is not meant to be embedded by hand.
+type Generated struct{}
+
+func (Generated) generatedValidator() {}
+
+// ContentTyper is implemented by response bodies served with a media type other than application/json,
+// such as JSON:API documents
+type ContentTyper interface {
+	ContentType() string
+}
+
+// ContextSchema is implemented by schemas whose checks can consult a request context, such as
+// tenant settings, feature flags, or the authenticated principal
+type ContextSchema interface {
+	Schema
+	ValidateCtx(ctx context.Context, data interface{}) error
+}
+
+// ValidateCtx validates data, passing ctx to schemas that implement ContextSchema
+func ValidateCtx(ctx context.Context, schema Schema, data interface{}) error {
+	if contextual, ok := schema.(ContextSchema); ok {
+		return contextual.ValidateCtx(ctx, data)
+	}
+	return schema.Validate(data)
+}
+
 func ValidateSchema(schema Schema, data interface{}) error {
 	return schema.Validate(data)
 }
@@ -0,0 +1,88 @@
+package goop
+
+import (
+	"context"
+	"strings"
+)
+
+// TenantLimitExtension is the schema extension naming the per-tenant limit that bounds a value
+const TenantLimitExtension = "x-tenant-limit"
+
+// tenantContextKey is the unexported context key for the resolved tenant
+type tenantContextKey struct{}
+
+// TenantResolver identifies the tenant of each request from a header or path parameter
+// Routers resolve the tenant before the handler runs, document the parameter on every operation
+// registered afterwards, and expose the tenant to handlers through TenantFromContext.
+type TenantResolver struct {
+	// Name of the header or path parameter carrying the tenant ID
+	Name string
+	// Location of the tenant ID, "header" or "path"
+	In string
+	// Description of the parameter in the spec
+	Description string
+	// Resolve returns the tenant with id, or an error if the caller cannot act for it
+	// Returning a *ValidationError rejects the ID as malformed rather than forbidden.
+	Resolve func(ctx context.Context, id string) (interface{}, error)
+}
+
+// TenantHeader resolves the tenant from the ID in the named request header, such as X-Company-ID
+func TenantHeader(name string, resolve func(ctx context.Context, id string) (interface{}, error)) TenantResolver {
+	return TenantResolver{
+		Name:        name,
+		In:          "header",
+		Description: "ID of the tenant the request acts for",
+		Resolve:     resolve,
+	}
+}
+
+// TenantPathParam resolves the tenant from the ID in the named path parameter, such as orgId in
+// /orgs/{orgId}/users. Only operations whose path has the parameter resolve a tenant.
+func TenantPathParam(name string, resolve func(ctx context.Context, id string) (interface{}, error)) TenantResolver {
+	return TenantResolver{
+		Name:        name,
+		In:          "path",
+		Description: "ID of the tenant the request acts for",
+		Resolve:     resolve,
+	}
+}
+
+// AppliesTo reports whether requests to op act for a tenant: exempt operations do not, and neither
+// do operations without the parameter in their path when the tenant is resolved from the path
+func (r TenantResolver) AppliesTo(op *CompiledOperation) bool {
+	if op.TenantExempt {
+		return false
+	}
+	if r.In == "path" {
+		return strings.Contains(op.Path, "{"+r.Name+"}")
+	}
+	return true
+}
+
+// WithTenant returns a copy of ctx carrying the resolved tenant
+// Framework adapters call this after tenant resolution succeeds
+func WithTenant(ctx context.Context, tenant interface{}) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, tenant)
+}
+
+// TenantFromContext returns the tenant stored in ctx as type T
+// The boolean is false when no tenant is present or it is not of type T
+func TenantFromContext[T any](ctx context.Context) (T, bool) {
+	tenant, ok := ctx.Value(tenantContextKey{}).(T)
+	return tenant, ok
+}
+
+// TenantLimits is implemented by tenants with plan limits that schemas can reference, such as
+// the number of seats. TenantLimit returns false when the tenant has no limit with that name.
+type TenantLimits interface {
+	TenantLimit(name string) (int64, bool)
+}
+
+// TenantLimit returns the named limit of the tenant stored in ctx
+// The boolean is false when there is no tenant, it does not implement TenantLimits, or it has no such limit.
+func TenantLimit(ctx context.Context, name string) (int64, bool) {
+	if limits, ok := ctx.Value(tenantContextKey{}).(TenantLimits); ok {
+		return limits.TenantLimit(name)
+	}
+	return 0, false
+}
@@ -0,0 +1,76 @@
+package goop
+
+import (
+	"context"
+	"testing"
+)
+
+type testTenant struct {
+	ID     string
+	limits map[string]int64
+}
+
+func (t testTenant) TenantLimit(name string) (int64, bool) {
+	limit, ok := t.limits[name]
+	return limit, ok
+}
+
+// TestTenantFromContext tests typed retrieval of the resolved tenant and its limits
+func TestTenantFromContext(t *testing.T) {
+	ctx := WithTenant(context.Background(), testTenant{ID: "acme", limits: map[string]int64{"seats": 5}})
+
+	t.Run("Returns tenant of the requested type", func(t *testing.T) {
+		tenant, ok := TenantFromContext[testTenant](ctx)
+		if !ok || tenant.ID != "acme" {
+			t.Errorf("Expected tenant 'acme', got %v", tenant)
+		}
+		if _, ok := TenantFromContext[*testTenant](ctx); ok {
+			t.Error("Expected pointer lookup to fail for value tenant")
+		}
+	})
+
+	t.Run("Does not collide with the principal", func(t *testing.T) {
+		authCtx := WithAuth(ctx, testPrincipal{UserID: "usr_123"})
+		if _, ok := TenantFromContext[testTenant](authCtx); !ok {
+			t.Error("Expected tenant to survive storing a principal")
+		}
+		if _, ok := AuthFromContext[testTenant](authCtx); ok {
+			t.Error("Expected the tenant not to be returned as the principal")
+		}
+	})
+
+	t.Run("Limits", func(t *testing.T) {
+		if limit, ok := TenantLimit(ctx, "seats"); !ok || limit != 5 {
+			t.Errorf("Expected seats limit 5, got %d (%v)", limit, ok)
+		}
+		if _, ok := TenantLimit(ctx, "projects"); ok {
+			t.Error("Expected no limit the tenant does not define")
+		}
+		if _, ok := TenantLimit(context.Background(), "seats"); ok {
+			t.Error("Expected no limit without a tenant")
+		}
+	})
+}
+
+// TestTenantResolverAppliesTo tests which operations resolve a tenant
+func TestTenantResolverAppliesTo(t *testing.T) {
+	header := TenantHeader("X-Tenant-ID", nil)
+	path := TenantPathParam("orgId", nil)
+
+	users := &CompiledOperation{Path: "/orgs/{orgId}/users"}
+	health := &CompiledOperation{Path: "/healthz", TenantExempt: true}
+	profile := &CompiledOperation{Path: "/me"}
+
+	if !header.AppliesTo(users) || !header.AppliesTo(profile) {
+		t.Error("Expected header tenants to apply to every operation")
+	}
+	if header.AppliesTo(health) || path.AppliesTo(health) {
+		t.Error("Expected exempt operations to be skipped")
+	}
+	if !path.AppliesTo(users) {
+		t.Error("Expected path tenants to apply to operations with the parameter")
+	}
+	if path.AppliesTo(profile) {
+		t.Error("Expected path tenants to skip operations without the parameter")
+	}
+}
@@ -1,5 +1,7 @@
 package goop
 
+import "time"
+
 // HTTPHandler represents a generic HTTP handler function
 // This is framework-agnostic and can be adapted to any HTTP framework
 type HTTPHandler interface{}
@@ -9,6 +11,8 @@ type ResponseDefinition struct {
 	Schema      Schema
 	Description string
 	Headers     map[string]Schema
+	ContentType string // Media type of the body (empty means application/json)
+	Ref         string // Name of the reusable response component documenting it, if any
 }
 
 // CompiledOperation represents a fully compiled operation with all metadata
@@ -20,6 +24,10 @@ type CompiledOperation struct {
 	Summary     string
 	Description string
 	Tags        []string
+	OperationID string
+
+	// Markdown file documenting the operation in place of Description, read by generators
+	DescriptionFile string
 
 	// Schema specifications (pre-computed at build time)
 	ParamsSpec   *OpenAPISchema
@@ -38,6 +46,9 @@ type CompiledOperation struct {
 	// Multiple responses support
 	Responses map[int]ResponseDefinition
 
+	// Status codes of generator default responses not documented for this operation
+	SkippedDefaultResponses []int
+
 	// Security requirements for this operation
 	Security SecurityRequirements
 
@@ -47,6 +58,79 @@ type CompiledOperation struct {
 
 	// Success HTTP status code (backward compatibility)
 	SuccessCode int
+
+	// Declared latency expectation for the operation (zero means no deadline)
+	Timeout time.Duration
+
+	// Retry-safety classification (empty means unclassified)
+	Idempotency Idempotency
+
+	// Serialization styles for query parameters, keyed by parameter name
+	QueryStyles map[string]ParameterSerialization
+
+	// Store used to deduplicate requests by Idempotency-Key (nil disables enforcement)
+	IdempotencyStore IdempotencyStore
+
+	// Declared request rate limit (nil means unlimited)
+	RateLimit *RateLimit
+
+	// Maximum accepted request body size in bytes (zero means no limit)
+	MaxBodyBytes int64
+
+	// API version the operation belongs to (empty means the operation is unversioned)
+	Version string
+
+	// Audiences the operation is documented for, such as "internal" or "partner" (empty means every audience)
+	Audiences []string
+
+	// Deprecation details (nil means the operation is not deprecated)
+	Deprecation *Deprecation
+
+	// Policy controlling response schema validation (nil uses the router default)
+	ResponseValidation ValidationPolicy
+
+	// Response fields callers can select with the fields query parameter (nil disables sparse fieldsets)
+	SparseFields *SparseFieldset
+
+	// Fields callers can filter and sort by with query expressions (nil disables query filters)
+	QueryFilters *QueryFilterSet
+
+	// Feature flag gating registration of the operation (nil means always registered)
+	FeatureFlag *FeatureFlag
+
+	// Media type of the request body (empty means application/json)
+	BodyContentType string
+
+	// Maskers for the classified fields of request and response bodies (nil when no field is classified)
+	RequestRedactor  *Redactor
+	ResponseRedactor *Redactor
+
+	// Response fields limited to callers with certain scopes (nil when no field is scoped)
+	ResponseVisibility *FieldVisibility
+
+	// Resolver for the tenant the request acts for, set by routers configured with one (nil means no tenant)
+	Tenant *TenantResolver
+
+	// Opts the operation out of tenant resolution, for endpoints such as health checks
+	TenantExempt bool
+
+	// Header correlating the request and its response, set by routers that propagate request IDs (empty means none)
+	RequestIDHeader string
+
+	// Caching allowed for successful responses (nil means no caching headers are set)
+	Cache *CachePolicy
+
+	// Media types of the file served by download operations (empty when the operation responds with JSON)
+	FileContentTypes []string
+
+	// Status endpoint of asynchronous operations, registered by routers along with the operation
+	// Operations sharing a status path share one endpoint, so they must share its job store.
+	JobStatus *CompiledOperation
+}
+
+// Enabled reports whether the operation's feature flag, if any, is on
+func (op CompiledOperation) Enabled() bool {
+	return op.FeatureFlag == nil || op.FeatureFlag.Enabled == nil || op.FeatureFlag.Enabled()
 }
 
 // OperationInfo contains metadata about an operation for build-time analysis
@@ -82,3 +166,74 @@ const (
 	HEAD    = "HEAD"
 	OPTIONS = "OPTIONS"
 )
+
+// FieldsQueryParameter is the query parameter selecting a sparse fieldset, such as ?fields=id,name
+const FieldsQueryParameter = "fields"
+
+// SparseFieldset describes the response fields callers can select with FieldsQueryParameter
+type SparseFieldset struct {
+	// Fields lists the selectable top-level fields of the response resource
+	Fields []string
+	// Envelope names the response member holding the resources, "data" or "items", or is empty
+	// when the response is the resources themselves. The other envelope members are always returned.
+	Envelope string
+}
+
+// APIVersionHeader is the request header used to select an API version
+const APIVersionHeader = "API-Version"
+
+// Response headers announcing deprecation, as defined in RFC 9745 and RFC 8594
+const (
+	DeprecationHeader = "Deprecation"
+	SunsetHeader      = "Sunset"
+)
+
+// FeatureFlag gates an operation behind a flag evaluated when the operation is registered
+type FeatureFlag struct {
+	// Name identifies the flag in the spec, such as "bulk-export"
+	Name string
+	// Enabled reports whether the flag is on
+	Enabled func() bool
+}
+
+// Deprecation describes when an operation was deprecated and when it will be removed
+type Deprecation struct {
+	// Since is when the operation was deprecated
+	Since time.Time
+	// Sunset is when the operation will stop being served (zero means no date is set)
+	Sunset time.Time
+	// Successor is the operation ID clients should migrate to (empty means none)
+	Successor string
+}
+
+// Idempotency classifies whether an operation is safe to retry
+type Idempotency string
+
+const (
+	// Idempotent operations can be retried safely; repeated calls have the same effect as one
+	Idempotent Idempotency = "idempotent"
+	// NonIdempotent operations must not be retried automatically
+	NonIdempotent Idempotency = "non-idempotent"
+	// Conditional operations are retry-safe only when the request carries an idempotency key or precondition
+	Conditional Idempotency = "conditional"
+)
+
+// ParameterStyle describes how a parameter value is serialized, as defined in OpenAPI 3.1
+type ParameterStyle string
+
+const (
+	// StyleForm serializes arrays as repeated keys (explode) or comma-separated values
+	StyleForm ParameterStyle = "form"
+	// StyleSpaceDelimited serializes arrays as space-separated values
+	StyleSpaceDelimited ParameterStyle = "spaceDelimited"
+	// StylePipeDelimited serializes arrays as pipe-separated values
+	StylePipeDelimited ParameterStyle = "pipeDelimited"
+	// StyleDeepObject serializes objects as key[property]=value pairs
+	StyleDeepObject ParameterStyle = "deepObject"
+)
+
+// ParameterSerialization pairs a parameter style with its explode setting
+type ParameterSerialization struct {
+	Style   ParameterStyle
+	Explode bool
+}
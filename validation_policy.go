@@ -0,0 +1,5 @@
+package goop
+
+// ValidationPolicy decides, per request, whether an optional validation step runs
+// It is used to disable or sample response validation outside development
+type ValidationPolicy func() bool
@@ -0,0 +1,114 @@
+package validators
+
+import (
+	"testing"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// successPathCases are schemas and valid inputs whose validation should not allocate
+// URL validation is excluded because net/url allocates while parsing
+func successPathCases() map[string]struct {
+	schema goop.Schema
+	data   interface{}
+} {
+	return map[string]struct {
+		schema goop.Schema
+		data   interface{}
+	}{
+		"String":         {String().Min(1).Max(50).Pattern(`^[a-z]+$`).Required(), "hello"},
+		"Email":          {String().Email().Required(), "user@example.com"},
+		"Const":          {String().Const("fixed").Required(), "fixed"},
+		"Email options":  {String().Email(EmailLowercase(), EmailRequireTLD()).Required(), "user@example.com"},
+		"String runes":   {String().MinRunes(1).MaxRunes(10).MaxBytes(40).Required(), "café"},
+		"String default": {String().Optional().Default("fallback"), nil},
+		"Number":         {Number().Min(0).Max(100).MultipleOf(5).Required(), float64(10)},
+		"Integer":        {Number().Integer().Positive().Required(), 42},
+		"Number default": {Number().Optional().Default(5), nil},
+		"Int":            {Int32().Min(1).Max(100).Required(), float64(42)},
+		"Uint":           {Uint().Max(100).Required(), 42},
+		"Bool":           {Bool().Required(), true},
+		"Bool default":   {Bool().Optional().Default(true), nil},
+		"Array":          {Array(String().Min(1).Required()).UniqueItems().Required(), []interface{}{"a", "b"}},
+	}
+}
+
+func TestSuccessPathAllocations(t *testing.T) {
+	for name, tc := range successPathCases() {
+		t.Run(name, func(t *testing.T) {
+			allocs := testing.AllocsPerRun(100, func() {
+				if err := tc.schema.Validate(tc.data); err != nil {
+					t.Fatalf("Expected valid input, got %v", err)
+				}
+			})
+			if allocs != 0 {
+				t.Errorf("Expected no allocations on the success path, got %v", allocs)
+			}
+		})
+	}
+}
+
+func TestUniqueItemsKeys(t *testing.T) {
+	schema := Array(nil).UniqueItems().Required()
+
+	t.Run("Same value with different types is unique", func(t *testing.T) {
+		if err := schema.Validate([]interface{}{1, float64(1), "1"}); err != nil {
+			t.Errorf("Expected values of different types to be unique, got %v", err)
+		}
+	})
+
+	t.Run("Duplicate unhashable items", func(t *testing.T) {
+		data := []interface{}{
+			map[string]interface{}{"a": float64(1)},
+			map[string]interface{}{"a": float64(1)},
+		}
+		if err := schema.Validate(data); err == nil {
+			t.Error("Expected duplicate objects to fail")
+		}
+	})
+}
+
+func TestEmptyStringDefault(t *testing.T) {
+	if err := String().Optional().Default("").Validate(""); err != nil {
+		t.Errorf("Expected empty string with empty default to pass, got %v", err)
+	}
+}
+
+func TestErrorCollectorReuse(t *testing.T) {
+	schema := Array(String().Min(3).Required()).Required()
+
+	first := schema.Validate([]interface{}{"a", "b"})
+	second := schema.Validate([]interface{}{"abc", "d"})
+
+	firstErr, ok := first.(*goop.ValidationError)
+	if !ok || len(firstErr.Details) != 2 {
+		t.Fatalf("Expected two details in first error, got %v", first)
+	}
+	secondErr, ok := second.(*goop.ValidationError)
+	if !ok || len(secondErr.Details) != 1 {
+		t.Fatalf("Expected one detail in second error, got %v", second)
+	}
+	if firstErr.Details[0].Field != "[0]" || firstErr.Details[1].Field != "[1]" {
+		t.Errorf("Expected pooled storage not to overwrite returned details, got %v", firstErr.Details)
+	}
+}
+
+func BenchmarkPrimitiveSuccessPath(b *testing.B) {
+	for name, tc := range successPathCases() {
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_ = tc.schema.Validate(tc.data)
+			}
+		})
+	}
+}
+
+func BenchmarkPrimitiveFailurePath(b *testing.B) {
+	schema := Array(String().Min(3).Required()).Required()
+	data := []interface{}{"a", "b", "c", "d"}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = schema.Validate(data)
+	}
+}
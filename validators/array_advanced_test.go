@@ -461,3 +461,90 @@ func TestArrayTypeValidation(t *testing.T) {
 		}
 	})
 }
+
+// TestArrayParallel tests fanning element validation out across goroutines
+func TestArrayParallel(t *testing.T) {
+	items := make([]interface{}, 1000)
+	for i := range items {
+		items[i] = map[string]interface{}{"id": "item", "qty": float64(i)}
+	}
+	// Every 100th item is invalid
+	for i := 0; i < len(items); i += 100 {
+		items[i] = map[string]interface{}{"id": ""}
+	}
+
+	element := Object(map[string]interface{}{
+		"id":  String().Min(1).Required(),
+		"qty": Number().Min(0).Required(),
+	}).Required()
+
+	t.Run("Errors match serial validation in index order", func(t *testing.T) {
+		serial := Array(element).Required().Validate(items)
+		parallel := Array(element).Parallel(8).Required().Validate(items)
+
+		serialErr, ok := serial.(*goop.ValidationError)
+		if !ok {
+			t.Fatalf("Expected ValidationError from serial validation, got %v", serial)
+		}
+		parallelErr, ok := parallel.(*goop.ValidationError)
+		if !ok {
+			t.Fatalf("Expected ValidationError from parallel validation, got %v", parallel)
+		}
+
+		if len(parallelErr.Details) != 10 || len(parallelErr.Details) != len(serialErr.Details) {
+			t.Fatalf("Expected 10 details from both, got serial %d, parallel %d", len(serialErr.Details), len(parallelErr.Details))
+		}
+		for i, detail := range parallelErr.Details {
+			if detail.Field != serialErr.Details[i].Field {
+				t.Errorf("Expected detail %d to be %s, got %s", i, serialErr.Details[i].Field, detail.Field)
+			}
+		}
+	})
+
+	t.Run("Valid arrays pass", func(t *testing.T) {
+		schema := Array(String().Min(1)).Parallel(4).Optional()
+		if err := schema.Validate([]interface{}{"a", "b", "c"}); err != nil {
+			t.Errorf("Expected valid array to pass, got %v", err)
+		}
+	})
+
+	t.Run("Non-positive workers use GOMAXPROCS", func(t *testing.T) {
+		schema := Array(String()).Parallel(0).(*arraySchema)
+		if schema.workers < 1 {
+			t.Errorf("Expected at least one worker, got %d", schema.workers)
+		}
+	})
+
+	t.Run("More workers than items", func(t *testing.T) {
+		err := Array(Number().Min(10)).Parallel(16).Required().Validate([]interface{}{1, 20})
+		validationErr, ok := err.(*goop.ValidationError)
+		if !ok || len(validationErr.Details) != 1 || validationErr.Details[0].Field != "[0]" {
+			t.Errorf("Expected a single failure at [0], got %v", err)
+		}
+	})
+}
+
+func BenchmarkArrayParallel(b *testing.B) {
+	items := make([]interface{}, 5000)
+	for i := range items {
+		items[i] = map[string]interface{}{"email": "user@example.com", "name": "name"}
+	}
+	element := Object(map[string]interface{}{
+		"email": String().Email().Required(),
+		"name":  String().Min(1).Required(),
+	}).Required()
+
+	b.Run("Serial", func(b *testing.B) {
+		schema := Array(element).Required()
+		for i := 0; i < b.N; i++ {
+			_ = schema.Validate(items)
+		}
+	})
+
+	b.Run("Parallel", func(b *testing.B) {
+		schema := Array(element).Parallel(0).Required()
+		for i := 0; i < b.N; i++ {
+			_ = schema.Validate(items)
+		}
+	})
+}
@@ -0,0 +1,182 @@
+package validators
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// setContains records a Contains value and its bounds on the number of matching items
+func (a *arraySchema) setContains(value interface{}, bounds []int) {
+	a.contains = value
+	a.containsMin, a.containsMax = 1, 0
+	if len(bounds) > 0 {
+		a.containsMin = bounds[0]
+	}
+	if len(bounds) > 1 {
+		a.containsMax = bounds[1]
+	}
+}
+
+// containsSchema reports whether the Contains value is a schema rather than a literal value
+func (a *arraySchema) containsSchema() bool {
+	switch a.contains.(type) {
+	case goop.Schema, goop.OpenAPIGenerator:
+		return true
+	default:
+		return false
+	}
+}
+
+// matchesContains reports whether item matches the Contains value; literal numbers match
+// decoded numbers of the same value
+func (a *arraySchema) matchesContains(item interface{}) bool {
+	if a.containsSchema() {
+		return validateItem(a.contains, item) == nil
+	}
+	return sameValue(item, a.contains)
+}
+
+// contentCheck applies the Contains and uniqueness rules as items are visited in order,
+// so the same checks serve Validate and ValidateStream
+type contentCheck struct {
+	matches int
+
+	duplicateIndex int
+	duplicate      interface{}
+
+	duplicateByIndex int
+	duplicateBy      interface{}
+}
+
+// seenItems holds the uniqueness keys visited so far
+// It is kept apart from contentCheck, whose reported values escape, so that on the success path
+// its maps can stay on the stack.
+type seenItems struct {
+	items map[interface{}]bool
+	by    map[interface{}]bool
+}
+
+func (a *arraySchema) newContentCheck() (contentCheck, seenItems) {
+	var seen seenItems
+	if a.uniqueItems {
+		seen.items = make(map[interface{}]bool)
+	}
+	if len(a.uniqueBy) > 0 {
+		seen.by = make(map[interface{}]bool)
+	}
+	return contentCheck{duplicateIndex: -1, duplicateByIndex: -1}, seen
+}
+
+// needsItems reports whether later items can still change the outcome of the checks
+func (c *contentCheck) needsItems(a *arraySchema, seen *seenItems) bool {
+	counting := a.contains != nil && (c.matches < a.containsMin || a.containsMax > 0)
+	return counting || seen.items != nil || seen.by != nil
+}
+
+// visit applies the checks to the item at index
+func (c *contentCheck) visit(a *arraySchema, seen *seenItems, index int, item interface{}) {
+	if a.contains != nil && (c.matches < a.containsMin || a.containsMax > 0) && a.matchesContains(item) {
+		c.matches++
+	}
+	if seen.items != nil && c.duplicateIndex < 0 {
+		key := uniqueKey(item)
+		if seen.items[key] {
+			c.duplicateIndex, c.duplicate = index, item
+		}
+		seen.items[key] = true
+	}
+	if seen.by != nil && c.duplicateByIndex < 0 {
+		if values, ok := propertyValues(item, a.uniqueBy); ok {
+			key := uniqueByKey(values)
+			if seen.by[key] {
+				c.duplicateByIndex = index
+				c.duplicateBy = values[0]
+				if len(values) > 1 {
+					c.duplicateBy = values
+				}
+			}
+			seen.by[key] = true
+		}
+	}
+}
+
+// err returns the first failed check: Contains, then uniqueness of whole items, then of keys
+// items is nil when they were streamed rather than collected.
+func (c *contentCheck) err(a *arraySchema, items []interface{}) error {
+	var key, message string
+	switch {
+	case a.contains != nil && c.matches < a.containsMin:
+		key = errorKeys.Contains
+		message = fmt.Sprintf("array must contain at least %d matching items, got %d", a.containsMin, c.matches)
+		if a.containsMin == 1 && !a.containsSchema() {
+			message = fmt.Sprintf("array must contain value: %v", a.contains)
+		}
+	case a.contains != nil && a.containsMax > 0 && c.matches > a.containsMax:
+		key = errorKeys.Contains
+		message = fmt.Sprintf("array must contain at most %d matching items, got %d", a.containsMax, c.matches)
+	case c.duplicateIndex >= 0:
+		key = errorKeys.UniqueItems
+		message = fmt.Sprintf("array contains duplicate item at index %d: %v", c.duplicateIndex, c.duplicate)
+	case c.duplicateByIndex >= 0:
+		key = errorKeys.UniqueBy
+		message = fmt.Sprintf("array contains duplicate %s at index %d: %v", strings.Join(a.uniqueBy, ", "), c.duplicateByIndex, c.duplicateBy)
+	default:
+		return nil
+	}
+
+	if items == nil {
+		return goop.NewValidationError("", nil, a.getErrorMessage(key, message))
+	}
+	return goop.NewValidationError(fmt.Sprintf("%v", items), items, a.getErrorMessage(key, message))
+}
+
+// propertyValues returns the values of keys in an object item, and false when the item is not
+// an object or lacks one of the keys
+func propertyValues(item interface{}, keys []string) ([]interface{}, bool) {
+	obj, ok := item.(map[string]interface{})
+	if !ok {
+		val := reflect.ValueOf(item)
+		for val.Kind() == reflect.Ptr && !val.IsNil() {
+			val = val.Elem()
+		}
+		switch {
+		case val.Kind() == reflect.Map && val.Type().Key().Kind() == reflect.String:
+			obj = make(map[string]interface{}, val.Len())
+			for _, key := range val.MapKeys() {
+				obj[key.String()] = val.MapIndex(key).Interface()
+			}
+		case val.Kind() == reflect.Struct:
+			// Struct items are compared by their JSON property names
+			if obj = (&objectSchema{}).convertStructToMap(val.Interface()); obj == nil {
+				return nil, false
+			}
+		default:
+			return nil, false
+		}
+	}
+
+	values := make([]interface{}, len(keys))
+	for i, key := range keys {
+		value, exists := obj[key]
+		if !exists {
+			return nil, false
+		}
+		values[i] = value
+	}
+	return values, true
+}
+
+// uniqueByKey returns a map key identifying a combination of property values
+func uniqueByKey(values []interface{}) interface{} {
+	if len(values) == 1 {
+		return uniqueKey(values[0])
+	}
+	parts := make([]string, len(values))
+	for i, value := range values {
+		parts[i] = fmt.Sprintf("%T:%v", value, value)
+	}
+	return formattedKey(strings.Join(parts, "\x00"))
+}
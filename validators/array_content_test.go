@@ -0,0 +1,174 @@
+package validators
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	goop "github.com/picogrid/go-op"
+)
+
+func recipientSchema() RequiredObjectBuilder {
+	return Object(map[string]interface{}{
+		"email": String().Email().Required(),
+		"role":  String().Required(),
+	}).Required()
+}
+
+func recipient(email, role string) map[string]interface{} {
+	return map[string]interface{}{"email": email, "role": role}
+}
+
+// TestArrayContainsSchema tests Contains with schemas and match counts
+func TestArrayContainsSchema(t *testing.T) {
+	admin := Object(map[string]interface{}{"role": String().Const("admin").Required()}).Required()
+	schema := Array(recipientSchema()).Contains(admin, 1, 2).Required()
+
+	valid := [][]interface{}{
+		{recipient("a@example.com", "admin")},
+		{recipient("a@example.com", "viewer"), recipient("b@example.com", "admin"), recipient("c@example.com", "admin")},
+	}
+	invalid := map[string][]interface{}{
+		"at least 1 matching items, got 0": {recipient("a@example.com", "viewer")},
+		"at most 2 matching items, got 3": {
+			recipient("a@example.com", "admin"), recipient("b@example.com", "admin"), recipient("c@example.com", "admin"),
+		},
+	}
+
+	for _, data := range valid {
+		if err := schema.Validate(data); err != nil {
+			t.Errorf("Expected %v to be valid, got: %v", data, err)
+		}
+		body, _ := json.Marshal(data)
+		if err := ValidateStream(schema, bytes.NewReader(body)); err != nil {
+			t.Errorf("Expected streamed %s to be valid, got: %v", body, err)
+		}
+	}
+	for message, data := range invalid {
+		if err := schema.Validate(data); err == nil || !strings.Contains(err.Error(), message) {
+			t.Errorf("Expected %v to fail with %q, got: %v", data, message, err)
+		}
+		body, _ := json.Marshal(data)
+		if err := ValidateStream(schema, bytes.NewReader(body)); err == nil || !strings.Contains(err.Error(), message) {
+			t.Errorf("Expected streamed %s to fail with %q, got: %v", body, message, err)
+		}
+	}
+
+	t.Run("Literal values", func(t *testing.T) {
+		tags := Array(String()).Contains("urgent").Required()
+		if err := tags.Validate([]interface{}{"urgent", "billing"}); err != nil {
+			t.Errorf("Expected tags with urgent to be valid, got: %v", err)
+		}
+		if err := tags.Validate([]interface{}{"billing"}); err == nil || !strings.Contains(err.Error(), "array must contain value: urgent") {
+			t.Errorf("Expected contains error, got: %v", err)
+		}
+		atMostOne := Array(Number()).Contains(0, 0, 1).Required()
+		if err := atMostOne.Validate([]interface{}{1.0, 2.0}); err != nil {
+			t.Errorf("Expected a minimum of 0 to accept no matches, got: %v", err)
+		}
+		if err := atMostOne.Validate([]interface{}{0.0, 0.0}); err == nil {
+			t.Error("Expected two zeros to exceed the maximum")
+		}
+	})
+
+	t.Run("OpenAPI", func(t *testing.T) {
+		spec := schema.(goop.EnhancedSchema).ToOpenAPISchema()
+		if spec.Contains == nil || spec.Contains.Properties["role"].Const != "admin" {
+			t.Fatalf("Expected contains schema with role const admin, got %+v", spec.Contains)
+		}
+		if spec.MinContains != nil || spec.MaxContains == nil || *spec.MaxContains != 2 {
+			t.Errorf("Expected only maxContains 2, got min %v max %v", spec.MinContains, spec.MaxContains)
+		}
+		literal := Array(String()).Contains("urgent", 2).Required().(goop.EnhancedSchema).ToOpenAPISchema()
+		if literal.Contains.Const != "urgent" || *literal.MinContains != 2 {
+			t.Errorf("Expected const contains with minContains 2, got %+v", literal)
+		}
+	})
+
+	t.Run("Generated values", func(t *testing.T) {
+		for seed := int64(0); seed < 30; seed++ {
+			value, err := goop.Generate(Array(recipientSchema()).Contains(admin).Required(), goop.WithSeed(seed))
+			if err != nil {
+				t.Fatalf("Seed %d: expected no error, got %v", seed, err)
+			}
+			if err := Array(recipientSchema()).Contains(admin).Required().Validate(value); err != nil {
+				t.Errorf("Seed %d: expected generated value %v to be valid, got %v", seed, value, err)
+			}
+		}
+	})
+
+	t.Run("Imported", func(t *testing.T) {
+		imported, err := FromJSONSchema([]byte(`{"type": "array", "contains": {"const": 1}, "minContains": 2, "maxContains": 3}`))
+		if err != nil {
+			t.Fatalf("FromJSONSchema failed: %v", err)
+		}
+		if err := imported.Validate([]interface{}{1.0, 2.0, 1.0}); err != nil {
+			t.Errorf("Expected two matches to be valid, got: %v", err)
+		}
+		if err := imported.Validate([]interface{}{1.0, 2.0}); err == nil {
+			t.Error("Expected one match to be rejected")
+		}
+		if _, err := FromJSONSchema([]byte(`{"type": "array", "contains": {"const": 1}, "maxContains": 0}`)); err == nil {
+			t.Error("Expected maxContains of 0 to be reported")
+		}
+	})
+}
+
+// TestArrayUniqueBy tests uniqueness by object properties
+func TestArrayUniqueBy(t *testing.T) {
+	lineItem := Object(map[string]interface{}{
+		"product_id": String().Required(),
+		"warehouse":  String().Optional(),
+		"quantity":   Number().Min(1).Required(),
+	}).Required()
+
+	byProduct := Array(lineItem).UniqueBy("product_id").Required()
+	items := []interface{}{
+		map[string]interface{}{"product_id": "sku-1", "quantity": 1.0},
+		map[string]interface{}{"product_id": "sku-2", "quantity": 1.0},
+		map[string]interface{}{"product_id": "sku-1", "quantity": 5.0},
+	}
+	if err := byProduct.Validate(items[:2]); err != nil {
+		t.Errorf("Expected distinct products to be valid, got: %v", err)
+	}
+	message := "array contains duplicate product_id at index 2: sku-1"
+	if err := byProduct.Validate(items); err == nil || !strings.Contains(err.Error(), message) {
+		t.Errorf("Expected %q, got: %v", message, err)
+	}
+	body, _ := json.Marshal(items)
+	if err := ValidateStream(byProduct, bytes.NewReader(body)); err == nil || !strings.Contains(err.Error(), message) {
+		t.Errorf("Expected streamed %q, got: %v", message, err)
+	}
+	if err := Compile(byProduct).Validate(items); err == nil || !strings.Contains(err.Error(), message) {
+		t.Errorf("Expected compiled %q, got: %v", message, err)
+	}
+
+	t.Run("Composite keys", func(t *testing.T) {
+		byStock := Array(lineItem).UniqueBy("product_id", "warehouse").Required()
+		stock := []interface{}{
+			map[string]interface{}{"product_id": "sku-1", "warehouse": "east", "quantity": 1.0},
+			map[string]interface{}{"product_id": "sku-1", "warehouse": "west", "quantity": 1.0},
+			map[string]interface{}{"product_id": "sku-1", "quantity": 1.0},
+			map[string]interface{}{"product_id": "sku-1", "quantity": 1.0},
+		}
+		if err := byStock.Validate(stock); err != nil {
+			t.Errorf("Expected items without every key to be skipped, got: %v", err)
+		}
+		stock = append(stock, map[string]interface{}{"product_id": "sku-1", "warehouse": "west", "quantity": 2.0})
+		if err := byStock.Validate(stock); err == nil || !strings.Contains(err.Error(), "product_id, warehouse at index 4") {
+			t.Errorf("Expected composite duplicate, got: %v", err)
+		}
+	})
+
+	t.Run("Struct items and custom messages", func(t *testing.T) {
+		type line struct {
+			ProductID string `json:"product_id"`
+		}
+		schema := Array(nil).UniqueBy("product_id").WithMessage(ErrUniqueBy, "each product may appear once").Required()
+		err := schema.Validate([]line{{"sku-1"}, {"sku-1"}})
+		if err == nil || !strings.Contains(err.Error(), "each product may appear once") {
+			t.Errorf("Expected custom message for struct items, got: %v", err)
+		}
+	})
+}
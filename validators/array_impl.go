@@ -1,8 +1,12 @@
 package validators
 
 import (
+	"context"
 	"fmt"
 	"reflect"
+	"runtime"
+	"sync"
+	"sync/atomic"
 
 	goop "github.com/picogrid/go-op"
 )
@@ -14,8 +18,15 @@ type arraySchema struct {
 	minItems      int
 	maxItems      int
 	contains      interface{}
+	containsMin   int
+	containsMax   int
 	uniqueItems   bool
+	uniqueBy      []string
 	customFunc    func([]interface{}) error
+	contextFunc   func(context.Context, []interface{}) error
+	tenantLimit   string
+	extensions    []goop.SchemaExtension
+	workers       int
 	required      bool
 	optional      bool
 	defaultValue  []interface{}
@@ -23,6 +34,7 @@ type arraySchema struct {
 	example       interface{}
 	examples      map[string]ExampleObject
 	externalValue string
+	docs          schemaDocs
 }
 
 // State wrapper types for compile-time safety
@@ -47,8 +59,16 @@ func (a *arraySchema) MaxItems(count int) ArrayBuilder {
 	return a
 }
 
-func (a *arraySchema) Contains(value interface{}) ArrayBuilder {
-	a.contains = value
+// Contains requires the array to contain value, either a literal compared for equality or a schema
+// items are validated against. The optional bounds set the minimum and maximum number of matching
+// items; the minimum defaults to 1 and a maximum of 0 means no limit.
+//
+// Example:
+//
+//	// At least one admin recipient, and no more than three
+//	validators.Array(recipientSchema).Contains(adminRecipient, 1, 3)
+func (a *arraySchema) Contains(value interface{}, bounds ...int) ArrayBuilder {
+	a.setContains(value, bounds)
 	return a
 }
 
@@ -57,11 +77,31 @@ func (a *arraySchema) UniqueItems() ArrayBuilder {
 	return a
 }
 
-func (a *arraySchema) Custom(fn func([]interface{}) error) ArrayBuilder {
+// UniqueBy rejects object items that repeat the values of the given properties, such as line
+// items with the same product ID; items without all of the properties are not compared
+func (a *arraySchema) UniqueBy(keys ...string) ArrayBuilder {
+	a.uniqueBy = keys
+	return a
+}
+
+func (a *arraySchema) Custom(fn func([]interface{}) error, extensions ...goop.SchemaExtension) ArrayBuilder {
+	a.extensions = append(a.extensions, extensions...)
 	a.customFunc = fn
 	return a
 }
 
+// CustomCtx adds a check that runs after validation succeeds, with the context passed to
+// ValidateCtx, such as the request context in validated handlers
+func (a *arraySchema) CustomCtx(fn func(context.Context, []interface{}) error) ArrayBuilder {
+	a.contextFunc = fn
+	return a
+}
+
+func (a *arraySchema) Parallel(workers int) ArrayBuilder {
+	a.workers = parallelWorkers(workers)
+	return a
+}
+
 // State transition methods - these change the return type to enforce compile-time safety
 func (a *arraySchema) Required() RequiredArrayBuilder {
 	a.required = true
@@ -109,8 +149,8 @@ func (r *requiredArraySchema) MaxItems(count int) RequiredArrayBuilder {
 	return r
 }
 
-func (r *requiredArraySchema) Contains(value interface{}) RequiredArrayBuilder {
-	r.contains = value
+func (r *requiredArraySchema) Contains(value interface{}, bounds ...int) RequiredArrayBuilder {
+	r.setContains(value, bounds)
 	return r
 }
 
@@ -119,11 +159,27 @@ func (r *requiredArraySchema) UniqueItems() RequiredArrayBuilder {
 	return r
 }
 
-func (r *requiredArraySchema) Custom(fn func([]interface{}) error) RequiredArrayBuilder {
+func (r *requiredArraySchema) UniqueBy(keys ...string) RequiredArrayBuilder {
+	r.uniqueBy = keys
+	return r
+}
+
+func (r *requiredArraySchema) Custom(fn func([]interface{}) error, extensions ...goop.SchemaExtension) RequiredArrayBuilder {
+	r.extensions = append(r.extensions, extensions...)
 	r.customFunc = fn
 	return r
 }
 
+func (r *requiredArraySchema) CustomCtx(fn func(context.Context, []interface{}) error) RequiredArrayBuilder {
+	r.contextFunc = fn
+	return r
+}
+
+func (r *requiredArraySchema) Parallel(workers int) RequiredArrayBuilder {
+	r.workers = parallelWorkers(workers)
+	return r
+}
+
 // Error message methods for RequiredArrayBuilder
 func (r *requiredArraySchema) WithMessage(validationType, message string) RequiredArrayBuilder {
 	if r.customError == nil {
@@ -162,8 +218,8 @@ func (o *optionalArraySchema) MaxItems(count int) OptionalArrayBuilder {
 	return o
 }
 
-func (o *optionalArraySchema) Contains(value interface{}) OptionalArrayBuilder {
-	o.contains = value
+func (o *optionalArraySchema) Contains(value interface{}, bounds ...int) OptionalArrayBuilder {
+	o.setContains(value, bounds)
 	return o
 }
 
@@ -172,11 +228,27 @@ func (o *optionalArraySchema) UniqueItems() OptionalArrayBuilder {
 	return o
 }
 
-func (o *optionalArraySchema) Custom(fn func([]interface{}) error) OptionalArrayBuilder {
+func (o *optionalArraySchema) UniqueBy(keys ...string) OptionalArrayBuilder {
+	o.uniqueBy = keys
+	return o
+}
+
+func (o *optionalArraySchema) Custom(fn func([]interface{}) error, extensions ...goop.SchemaExtension) OptionalArrayBuilder {
+	o.extensions = append(o.extensions, extensions...)
 	o.customFunc = fn
 	return o
 }
 
+func (o *optionalArraySchema) CustomCtx(fn func(context.Context, []interface{}) error) OptionalArrayBuilder {
+	o.contextFunc = fn
+	return o
+}
+
+func (o *optionalArraySchema) Parallel(workers int) OptionalArrayBuilder {
+	o.workers = parallelWorkers(workers)
+	return o
+}
+
 // Default is only available on optional builders - this is the key DX improvement!
 func (o *optionalArraySchema) Default(value []interface{}) OptionalArrayBuilder {
 	o.defaultValue = value
@@ -209,10 +281,24 @@ func (r *requiredArraySchema) Validate(data interface{}) error {
 	return r.validate(data)
 }
 
+func (r *requiredArraySchema) ValidateCtx(ctx context.Context, data interface{}) error {
+	if err := r.validate(data); err != nil {
+		return err
+	}
+	return runContextChecks(ctx, r, data)
+}
+
 func (o *optionalArraySchema) Validate(data interface{}) error {
 	return o.validate(data)
 }
 
+func (o *optionalArraySchema) ValidateCtx(ctx context.Context, data interface{}) error {
+	if err := o.validate(data); err != nil {
+		return err
+	}
+	return runContextChecks(ctx, o, data)
+}
+
 // Core validation logic (shared between required and optional)
 func (a *arraySchema) validate(data interface{}) error {
 	// Handle nil values
@@ -230,20 +316,22 @@ func (a *arraySchema) validate(data interface{}) error {
 	}
 
 	// Type check - convert to []interface{} if possible
-	var arr []interface{}
-
-	// Use reflection to handle different slice types
-	val := reflect.ValueOf(data)
-	if val.Kind() != reflect.Slice && val.Kind() != reflect.Array {
-		return goop.NewValidationError(fmt.Sprintf("%v", data), data,
-			a.getErrorMessage(errorKeys.Type, "invalid type, expected array"))
-	}
+	// JSON-decoded arrays are used directly without copying
+	arr, ok := data.([]interface{})
+	if !ok {
+		// Use reflection to handle different slice types
+		val := reflect.ValueOf(data)
+		if val.Kind() != reflect.Slice && val.Kind() != reflect.Array {
+			return goop.NewValidationError(fmt.Sprintf("%v", data), data,
+				a.getErrorMessage(errorKeys.Type, "invalid type, expected array"))
+		}
 
-	// Convert to []interface{}
-	length := val.Len()
-	arr = make([]interface{}, length)
-	for i := 0; i < length; i++ {
-		arr[i] = val.Index(i).Interface()
+		// Convert to []interface{}
+		length := val.Len()
+		arr = make([]interface{}, length)
+		for i := 0; i < length; i++ {
+			arr[i] = val.Index(i).Interface()
+		}
 	}
 
 	// Length validations
@@ -261,52 +349,37 @@ func (a *arraySchema) validate(data interface{}) error {
 
 	// Element validation
 	if a.elementSchema != nil {
-		var details []goop.ValidationError
-		for i, item := range arr {
-			if err := a.validateElement(item); err != nil {
-				if validationErr, ok := err.(*goop.ValidationError); ok {
-					// Add index information to the error
-					indexedErr := *validationErr
-					indexedErr.Field = fmt.Sprintf("[%d]", i)
-					details = append(details, indexedErr)
-				} else {
-					details = append(details, *goop.NewValidationError(fmt.Sprintf("[%d]", i), item, err.Error()))
+		collector := acquireErrorCollector()
+		if a.workers > 1 && len(arr) > 1 {
+			for i, err := range a.validateElementsParallel(arr) {
+				if err != nil {
+					addElementError(collector, i, arr[i], err)
+				}
+			}
+		} else {
+			for i, item := range arr {
+				if err := a.validateElement(item); err != nil {
+					addElementError(collector, i, item, err)
 				}
 			}
 		}
+		details := collector.release()
 		if len(details) > 0 {
 			return goop.NewNestedValidationError("", arr, "array contains invalid items", details)
 		}
 	}
 
-	// Contains validation
-	if a.contains != nil {
-		found := false
-		for _, item := range arr {
-			if reflect.DeepEqual(item, a.contains) {
-				found = true
+	// Contains and uniqueness validation
+	if a.contains != nil || a.uniqueItems || len(a.uniqueBy) > 0 {
+		check, seen := a.newContentCheck()
+		for i, item := range arr {
+			if !check.needsItems(a, &seen) {
 				break
 			}
+			check.visit(a, &seen, i, item)
 		}
-		if !found {
-			return goop.NewValidationError(fmt.Sprintf("%v", arr), arr,
-				a.getErrorMessage(errorKeys.Contains,
-					fmt.Sprintf("array must contain value: %v", a.contains)))
-		}
-	}
-
-	// Unique items validation
-	if a.uniqueItems {
-		seen := make(map[string]bool)
-		for i, item := range arr {
-			// Create a string representation for comparison
-			key := fmt.Sprintf("%T:%v", item, item)
-			if seen[key] {
-				return goop.NewValidationError(fmt.Sprintf("%v", arr), arr,
-					a.getErrorMessage(errorKeys.UniqueItems,
-						fmt.Sprintf("array contains duplicate item at index %d: %v", i, item)))
-			}
-			seen[key] = true
+		if err := check.err(a, arr); err != nil {
+			return err
 		}
 	}
 
@@ -320,16 +393,84 @@ func (a *arraySchema) validate(data interface{}) error {
 	return nil
 }
 
+// parallelWorkers resolves a requested worker count, using GOMAXPROCS when it is not positive
+func parallelWorkers(workers int) int {
+	if workers <= 0 {
+		return runtime.GOMAXPROCS(0)
+	}
+	return workers
+}
+
+// validateElementsParallel validates elements across the configured number of goroutines
+// Errors are stored by element index so reported details keep the array order
+// Element schemas, including custom validators, must be safe for concurrent use
+func (a *arraySchema) validateElementsParallel(arr []interface{}) []error {
+	workers := a.workers
+	if workers > len(arr) {
+		workers = len(arr)
+	}
+
+	errs := make([]error, len(arr))
+	var next int64 = -1
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(atomic.AddInt64(&next, 1))
+				if i >= len(arr) {
+					return
+				}
+				errs[i] = a.validateElement(arr[i])
+			}
+		}()
+	}
+	wg.Wait()
+	return errs
+}
+
+// addElementError records an element failure with its index as the field name
+func addElementError(collector *errorCollector, index int, item interface{}, err error) {
+	if validationErr, ok := err.(*goop.ValidationError); ok {
+		// Add index information to the error
+		indexedErr := *validationErr
+		indexedErr.Field = fmt.Sprintf("[%d]", index)
+		collector.add(indexedErr)
+		return
+	}
+	collector.add(*goop.NewValidationError(fmt.Sprintf("[%d]", index), item, err.Error()))
+}
+
+// formattedKey is the uniqueness key of an array item that cannot be used as a map key directly
+type formattedKey string
+
+// uniqueKey returns a map key identifying item by type and value
+// Hashable primitives are used as-is; other values fall back to their formatted representation
+func uniqueKey(item interface{}) interface{} {
+	switch item.(type) {
+	case nil, string, bool, float64, float32, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return item
+	default:
+		return formattedKey(fmt.Sprintf("%T:%v", item, item))
+	}
+}
+
 // validateElement validates a single array element against the element schema
 func (a *arraySchema) validateElement(item interface{}) error {
+	return validateItem(a.elementSchema, item)
+}
+
+// validateItem validates an array item against schema, which may be an unfinalized builder
+func validateItem(itemSchema, item interface{}) error {
 	// First, try the standard Validate method (for finalized schemas)
-	if validator, ok := a.elementSchema.(interface{ Validate(interface{}) error }); ok {
+	if validator, ok := itemSchema.(interface{ Validate(interface{}) error }); ok {
 		return validator.Validate(item)
 	}
 
 	// Handle unfinalized schemas by type - automatically treat them as required
 	// IMPORTANT: Create COPIES to avoid data races in concurrent usage
-	switch schema := a.elementSchema.(type) {
+	switch schema := itemSchema.(type) {
 	case *stringSchema:
 		// Create a COPY of the string schema to avoid race conditions
 		schemaCopy := *schema // This creates a copy of the struct
@@ -372,7 +513,7 @@ func (a *arraySchema) validateElement(item interface{}) error {
 	}
 
 	// Try reflection as a fallback for other types
-	val := reflect.ValueOf(a.elementSchema)
+	val := reflect.ValueOf(itemSchema)
 	if val.Kind() == reflect.Ptr {
 		val = val.Elem()
 	}
@@ -391,7 +532,7 @@ func (a *arraySchema) validateElement(item interface{}) error {
 	}
 
 	// If we can't find a way to validate, that's an error in the schema definition
-	return fmt.Errorf("element schema does not implement validation interface: %T", a.elementSchema)
+	return fmt.Errorf("element schema does not implement validation interface: %T", itemSchema)
 }
 
 // Example methods for ArrayBuilder
@@ -1,5 +1,11 @@
 package validators
 
+import (
+	"context"
+
+	goop "github.com/picogrid/go-op"
+)
+
 // ArrayBuilder represents the initial array builder state.
 // From this state, you can configure validation rules and then transition to
 // either a required or optional state. This prevents invalid method chaining.
@@ -7,14 +13,24 @@ type ArrayBuilder interface {
 	// Configuration methods - these return ArrayBuilder to allow chaining
 	MinItems(count int) ArrayBuilder
 	MaxItems(count int) ArrayBuilder
-	Contains(value interface{}) ArrayBuilder
+	Contains(value interface{}, bounds ...int) ArrayBuilder // A value or schema; optional min and max matching items
 	UniqueItems() ArrayBuilder
-	Custom(fn func([]interface{}) error) ArrayBuilder
+	UniqueBy(keys ...string) ArrayBuilder // Object items must differ in the given properties
+	Custom(fn func([]interface{}) error, extensions ...goop.SchemaExtension) ArrayBuilder
+	// CustomCtx runs after validation with the request context
+	CustomCtx(fn func(context.Context, []interface{}) error) ArrayBuilder
+	// VisibleToScopes strips the field from responses to callers without any of scopes
+	VisibleToScopes(scopes ...string) ArrayBuilder
+	// MaxItemsFromTenant bounds the number of items by a limit of the request's tenant
+	MaxItemsFromTenant(limit string) ArrayBuilder
+	Parallel(workers int) ArrayBuilder // Validates elements across goroutines; workers <= 0 uses GOMAXPROCS
 
-	// Example methods for OpenAPI documentation
+	// Example and documentation methods for OpenAPI
 	Example(value interface{}) ArrayBuilder
 	Examples(examples map[string]ExampleObject) ArrayBuilder
 	ExampleFromFile(path string) ArrayBuilder
+	Title(title string) ArrayBuilder
+	Description(description string) ArrayBuilder
 
 	// State transition methods - these change the type to prevent invalid chaining
 	Required() RequiredArrayBuilder // Transitions to required state
@@ -39,14 +55,21 @@ type RequiredArrayBuilder interface {
 	// Configuration methods - these return RequiredArrayBuilder to maintain state
 	MinItems(count int) RequiredArrayBuilder
 	MaxItems(count int) RequiredArrayBuilder
-	Contains(value interface{}) RequiredArrayBuilder
+	Contains(value interface{}, bounds ...int) RequiredArrayBuilder // A value or schema; optional min and max matching items
 	UniqueItems() RequiredArrayBuilder
-	Custom(fn func([]interface{}) error) RequiredArrayBuilder
+	UniqueBy(keys ...string) RequiredArrayBuilder // Object items must differ in the given properties
+	Custom(fn func([]interface{}) error, extensions ...goop.SchemaExtension) RequiredArrayBuilder
+	CustomCtx(fn func(context.Context, []interface{}) error) RequiredArrayBuilder
+	VisibleToScopes(scopes ...string) RequiredArrayBuilder
+	MaxItemsFromTenant(limit string) RequiredArrayBuilder
+	Parallel(workers int) RequiredArrayBuilder // Validates elements across goroutines; workers <= 0 uses GOMAXPROCS
 
-	// Example methods for OpenAPI documentation
+	// Example and documentation methods for OpenAPI
 	Example(value interface{}) RequiredArrayBuilder
 	Examples(examples map[string]ExampleObject) RequiredArrayBuilder
 	ExampleFromFile(path string) RequiredArrayBuilder
+	Title(title string) RequiredArrayBuilder
+	Description(description string) RequiredArrayBuilder
 
 	// Error message configuration methods
 	WithMessage(validationType, message string) RequiredArrayBuilder
@@ -55,8 +78,12 @@ type RequiredArrayBuilder interface {
 	WithContainsMessage(message string) RequiredArrayBuilder
 	WithRequiredMessage(message string) RequiredArrayBuilder
 
+	// JSON Schema export
+	ToJSONSchema() ([]byte, error)
+
 	// Validation method - final step in the builder chain
 	Validate(data interface{}) error
+	ValidateCtx(ctx context.Context, data interface{}) error
 }
 
 // OptionalArrayBuilder represents an array builder in the optional state.
@@ -69,15 +96,22 @@ type OptionalArrayBuilder interface {
 	// Configuration methods - these return OptionalArrayBuilder to maintain state
 	MinItems(count int) OptionalArrayBuilder
 	MaxItems(count int) OptionalArrayBuilder
-	Contains(value interface{}) OptionalArrayBuilder
+	Contains(value interface{}, bounds ...int) OptionalArrayBuilder // A value or schema; optional min and max matching items
 	UniqueItems() OptionalArrayBuilder
-	Custom(fn func([]interface{}) error) OptionalArrayBuilder
+	UniqueBy(keys ...string) OptionalArrayBuilder // Object items must differ in the given properties
+	Custom(fn func([]interface{}) error, extensions ...goop.SchemaExtension) OptionalArrayBuilder
+	CustomCtx(fn func(context.Context, []interface{}) error) OptionalArrayBuilder
+	VisibleToScopes(scopes ...string) OptionalArrayBuilder
+	MaxItemsFromTenant(limit string) OptionalArrayBuilder
+	Parallel(workers int) OptionalArrayBuilder        // Validates elements across goroutines; workers <= 0 uses GOMAXPROCS
 	Default(value []interface{}) OptionalArrayBuilder // Only available on optional builders!
 
-	// Example methods for OpenAPI documentation
+	// Example and documentation methods for OpenAPI
 	Example(value interface{}) OptionalArrayBuilder
 	Examples(examples map[string]ExampleObject) OptionalArrayBuilder
 	ExampleFromFile(path string) OptionalArrayBuilder
+	Title(title string) OptionalArrayBuilder
+	Description(description string) OptionalArrayBuilder
 
 	// Error message configuration methods
 	WithMessage(validationType, message string) OptionalArrayBuilder
@@ -85,6 +119,10 @@ type OptionalArrayBuilder interface {
 	WithMaxItemsMessage(message string) OptionalArrayBuilder
 	WithContainsMessage(message string) OptionalArrayBuilder
 
+	// JSON Schema export
+	ToJSONSchema() ([]byte, error)
+
 	// Validation method - final step in the builder chain
 	Validate(data interface{}) error
+	ValidateCtx(ctx context.Context, data interface{}) error
 }
@@ -0,0 +1,143 @@
+package validators
+
+import (
+	goop "github.com/picogrid/go-op"
+)
+
+// classify records the data classification of a schema as specification extensions
+// Classified values are masked by goop.Redactor, for example in recorded exchanges and logs.
+func classify(extensions []goop.SchemaExtension, classification, category string) []goop.SchemaExtension {
+	extensions = append(extensions, goop.SchemaExtension{Name: goop.DataClassificationExtension, Value: classification})
+	if category != "" {
+		extensions = append(extensions, goop.SchemaExtension{Name: goop.PIICategoryExtension, Value: category})
+	}
+	return extensions
+}
+
+// Sensitive marks the value as sensitive, such as a token or an internal note. It is documented
+// with x-data-classification: sensitive and masked wherever requests are recorded or logged.
+func (s *stringSchema) Sensitive() StringBuilder {
+	s.extensions = classify(s.extensions, goop.ClassificationSensitive, "")
+	return s
+}
+
+// PII marks the value as personal data of category, such as "email" or "phone". It is documented
+// with x-data-classification: pii and x-pii-category, and masked like Sensitive values.
+//
+// Example:
+//
+//	"email": validators.String().Email().PII("email").Required(),
+func (s *stringSchema) PII(category string) StringBuilder {
+	s.extensions = classify(s.extensions, goop.ClassificationPII, category)
+	return s
+}
+
+func (r *requiredStringSchema) Sensitive() RequiredStringBuilder {
+	r.extensions = classify(r.extensions, goop.ClassificationSensitive, "")
+	return r
+}
+
+func (r *requiredStringSchema) PII(category string) RequiredStringBuilder {
+	r.extensions = classify(r.extensions, goop.ClassificationPII, category)
+	return r
+}
+
+func (o *optionalStringSchema) Sensitive() OptionalStringBuilder {
+	o.extensions = classify(o.extensions, goop.ClassificationSensitive, "")
+	return o
+}
+
+func (o *optionalStringSchema) PII(category string) OptionalStringBuilder {
+	o.extensions = classify(o.extensions, goop.ClassificationPII, category)
+	return o
+}
+
+func (n *numberSchema) Sensitive() NumberBuilder {
+	n.extensions = classify(n.extensions, goop.ClassificationSensitive, "")
+	return n
+}
+
+func (n *numberSchema) PII(category string) NumberBuilder {
+	n.extensions = classify(n.extensions, goop.ClassificationPII, category)
+	return n
+}
+
+func (r *requiredNumberSchema) Sensitive() RequiredNumberBuilder {
+	r.extensions = classify(r.extensions, goop.ClassificationSensitive, "")
+	return r
+}
+
+func (r *requiredNumberSchema) PII(category string) RequiredNumberBuilder {
+	r.extensions = classify(r.extensions, goop.ClassificationPII, category)
+	return r
+}
+
+func (o *optionalNumberSchema) Sensitive() OptionalNumberBuilder {
+	o.extensions = classify(o.extensions, goop.ClassificationSensitive, "")
+	return o
+}
+
+func (o *optionalNumberSchema) PII(category string) OptionalNumberBuilder {
+	o.extensions = classify(o.extensions, goop.ClassificationPII, category)
+	return o
+}
+
+func (i *intSchema) Sensitive() IntBuilder {
+	i.extensions = classify(i.extensions, goop.ClassificationSensitive, "")
+	return i
+}
+
+func (i *intSchema) PII(category string) IntBuilder {
+	i.extensions = classify(i.extensions, goop.ClassificationPII, category)
+	return i
+}
+
+func (r *requiredIntSchema) Sensitive() RequiredIntBuilder {
+	r.extensions = classify(r.extensions, goop.ClassificationSensitive, "")
+	return r
+}
+
+func (r *requiredIntSchema) PII(category string) RequiredIntBuilder {
+	r.extensions = classify(r.extensions, goop.ClassificationPII, category)
+	return r
+}
+
+func (o *optionalIntSchema) Sensitive() OptionalIntBuilder {
+	o.extensions = classify(o.extensions, goop.ClassificationSensitive, "")
+	return o
+}
+
+func (o *optionalIntSchema) PII(category string) OptionalIntBuilder {
+	o.extensions = classify(o.extensions, goop.ClassificationPII, category)
+	return o
+}
+
+func (u *uintSchema) Sensitive() UintBuilder {
+	u.extensions = classify(u.extensions, goop.ClassificationSensitive, "")
+	return u
+}
+
+func (u *uintSchema) PII(category string) UintBuilder {
+	u.extensions = classify(u.extensions, goop.ClassificationPII, category)
+	return u
+}
+
+func (r *requiredUintSchema) Sensitive() RequiredUintBuilder {
+	r.extensions = classify(r.extensions, goop.ClassificationSensitive, "")
+	return r
+}
+
+func (r *requiredUintSchema) PII(category string) RequiredUintBuilder {
+	r.extensions = classify(r.extensions, goop.ClassificationPII, category)
+	return r
+}
+
+func (o *optionalUintSchema) Sensitive() OptionalUintBuilder {
+	o.extensions = classify(o.extensions, goop.ClassificationSensitive, "")
+	return o
+}
+
+func (o *optionalUintSchema) PII(category string) OptionalUintBuilder {
+	o.extensions = classify(o.extensions, goop.ClassificationPII, category)
+	return o
+}
@@ -0,0 +1,58 @@
+package validators
+
+import (
+	"testing"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// TestDataClassification tests documenting sensitive and personal data
+func TestDataClassification(t *testing.T) {
+	schema := Object(map[string]interface{}{
+		"email":  String().Email().PII("email").Required(),
+		"token":  String().Sensitive().Optional(),
+		"salary": Number().Min(0).Sensitive().Required(),
+		"age":    Int().Min(0).PII("age").Optional(),
+		"visits": Uint().Sensitive().Optional(),
+		"name":   String().Required(),
+	}).Required()
+
+	spec := schema.(goop.EnhancedSchema).ToOpenAPISchema()
+	expected := map[string][2]interface{}{
+		"email":  {goop.ClassificationPII, "email"},
+		"token":  {goop.ClassificationSensitive, nil},
+		"salary": {goop.ClassificationSensitive, nil},
+		"age":    {goop.ClassificationPII, "age"},
+		"visits": {goop.ClassificationSensitive, nil},
+		"name":   {nil, nil},
+	}
+	for field, want := range expected {
+		extensions := spec.Properties[field].Extensions
+		if got := extensions[goop.DataClassificationExtension]; got != want[0] {
+			t.Errorf("%s: expected classification %v, got %v", field, want[0], got)
+		}
+		if got := extensions[goop.PIICategoryExtension]; got != want[1] {
+			t.Errorf("%s: expected category %v, got %v", field, want[1], got)
+		}
+	}
+
+	t.Run("Import", func(t *testing.T) {
+		imported, err := FromJSONSchema([]byte(`{
+			"type": "object",
+			"properties": {
+				"email": {"type": "string", "x-data-classification": "pii", "x-pii-category": "email"},
+				"score": {"type": "number", "x-data-classification": "sensitive"}
+			}
+		}`))
+		if err != nil {
+			t.Fatalf("Unexpected import error: %v", err)
+		}
+		spec := imported.(goop.EnhancedSchema).ToOpenAPISchema()
+		if !goop.IsClassified(spec.Properties["email"]) || spec.Properties["email"].Extensions[goop.PIICategoryExtension] != "email" {
+			t.Errorf("Expected email to stay classified, got %v", spec.Properties["email"].Extensions)
+		}
+		if !goop.IsClassified(spec.Properties["score"]) {
+			t.Errorf("Expected score to stay classified, got %v", spec.Properties["score"].Extensions)
+		}
+	})
+}
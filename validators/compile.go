@@ -0,0 +1,286 @@
+package validators
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// Compile returns an equivalent schema optimized for repeated validation.
+// Object properties are flattened into a slice with their validators resolved up front, so
+// validation avoids per-request map traversal, reflection-based schema lookup, and re-wrapping
+// of unfinalized field schemas. Nested objects and array elements are compiled recursively.
+// Object schemas build the same field list lazily on first validation; Compile builds it eagerly.
+// Compile after the schema is fully configured; fields added later are not seen by the compiled form.
+// Schemas that have no compiled form are returned unchanged.
+func Compile(schema goop.Schema) goop.Schema {
+	switch s := schema.(type) {
+	case *compiledObjectSchema:
+		return s
+	case *requiredObjectSchema:
+		return compileObject(s.objectSchema, s)
+	case *optionalObjectSchema:
+		return compileObject(s.objectSchema, s)
+	case *requiredArraySchema:
+		return &requiredArraySchema{compileArray(s.arraySchema)}
+	case *optionalArraySchema:
+		return &optionalArraySchema{compileArray(s.arraySchema)}
+	default:
+		return schema
+	}
+}
+
+// Compile returns the compiled form of the object schema
+func (r *requiredObjectSchema) Compile() goop.Schema {
+	return Compile(r)
+}
+
+// Compile returns the compiled form of the object schema
+func (o *optionalObjectSchema) Compile() goop.Schema {
+	return Compile(o)
+}
+
+// Compile returns the array schema with its element schema compiled
+func (r *requiredArraySchema) Compile() goop.Schema {
+	return Compile(r)
+}
+
+// Compile returns the array schema with its element schema compiled
+func (o *optionalArraySchema) Compile() goop.Schema {
+	return Compile(o)
+}
+
+// compiledObjectSchema validates objects using a precomputed field list
+// OpenAPI generation is delegated to the original schema
+type compiledObjectSchema struct {
+	goop.EnhancedSchema
+	object *objectSchema
+	fields []compiledField
+}
+
+// compiledField is an object property with its validator resolved at compile time
+type compiledField struct {
+	name string
+	// schema validates the property; nil means the property falls back to objectSchema.validateField
+	schema goop.Schema
+	raw    interface{}
+	// required is true when a missing value fails validation
+	required bool
+}
+
+func compileObject(o *objectSchema, original goop.EnhancedSchema) *compiledObjectSchema {
+	return &compiledObjectSchema{EnhancedSchema: original, object: o, fields: o.compiledFields()}
+}
+
+// objectFields caches the compiled property list of an object schema
+// Clones of an unfinalized builder share it, since they share the property map
+type objectFields struct {
+	once   sync.Once
+	fields []compiledField
+}
+
+// compiledFields returns the object's properties with their validators resolved, compiling them on first use
+// The plain, compiled, and streaming validators all validate properties from this list
+func (o *objectSchema) compiledFields() []compiledField {
+	if o.fields == nil {
+		return compileFields(o)
+	}
+	o.fields.once.Do(func() { o.fields.fields = compileFields(o) })
+	return o.fields.fields
+}
+
+func compileFields(o *objectSchema) []compiledField {
+	names := make([]string, 0, len(o.schema))
+	for name := range o.schema {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fields := make([]compiledField, 0, len(names))
+	for _, name := range names {
+		field := compiledField{name: name, raw: o.schema[name], schema: compileFieldSchema(o.schema[name])}
+		field.required = o.validateCompiledField(field, nil) != nil
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// findField returns the compiled property with the given name
+func findField(fields []compiledField, name string) (compiledField, bool) {
+	i := sort.Search(len(fields), func(i int) bool { return fields[i].name >= name })
+	if i < len(fields) && fields[i].name == name {
+		return fields[i], true
+	}
+	return compiledField{}, false
+}
+
+// compileFieldSchema resolves a property schema to a compiled validator
+// Unfinalized builders are treated as required, matching objectSchema.validateField, without modifying the builder
+func compileFieldSchema(fieldSchema interface{}) goop.Schema {
+	switch s := fieldSchema.(type) {
+	case *stringSchema:
+		clone := *s
+		clone.required, clone.optional = true, false
+		return &requiredStringSchema{&clone}
+	case *numberSchema:
+		clone := *s
+		clone.required, clone.optional = true, false
+		return &requiredNumberSchema{&clone}
+	case *objectSchema:
+		clone := *s
+		clone.required, clone.optional = true, false
+		return Compile(&requiredObjectSchema{&clone})
+	case *boolSchema:
+		clone := *s
+		clone.required, clone.optional = true, false
+		return &requiredBoolSchema{&clone}
+	case *arraySchema:
+		clone := *s
+		clone.required, clone.optional = true, false
+		return Compile(&requiredArraySchema{&clone})
+	case goop.Schema:
+		return Compile(s)
+	default:
+		return nil
+	}
+}
+
+func compileArray(a *arraySchema) *arraySchema {
+	clone := *a
+	if element, ok := a.elementSchema.(goop.Schema); ok {
+		clone.elementSchema = Compile(element)
+	}
+	return &clone
+}
+
+// Validate validates data against the compiled object schema
+func (c *compiledObjectSchema) Validate(data interface{}) error {
+	return c.object.validateFields(c.fields, data)
+}
+
+// validateFields validates data as an object whose properties are fields
+func (o *objectSchema) validateFields(fields []compiledField, data interface{}) error {
+	// Handle nil values
+	if data == nil {
+		if o.required {
+			return goop.NewValidationError("", nil, o.getErrorMessage(errorKeys.Required, "field is required"))
+		}
+		if o.defaultValue != nil {
+			return o.validateFields(fields, o.defaultValue)
+		}
+		if o.optional {
+			return nil
+		}
+		return goop.NewValidationError("", nil, o.getErrorMessage(errorKeys.Required, "field is required"))
+	}
+
+	// JSON-decoded objects are used directly; other map types are converted
+	obj, ok := data.(map[string]interface{})
+	if !ok {
+		val := reflect.ValueOf(data)
+		if val.Kind() != reflect.Map {
+			return goop.NewValidationError(fmt.Sprintf("%v", data), data,
+				o.getErrorMessage(errorKeys.Type, "invalid type, expected object"))
+		}
+		obj = make(map[string]interface{}, val.Len())
+		for _, key := range val.MapKeys() {
+			obj[fmt.Sprintf("%v", key.Interface())] = val.MapIndex(key).Interface()
+		}
+	}
+
+	// Const validation
+	if o.constValue != nil && !sameValue(obj, o.constValue) {
+		return goop.NewValidationError(fmt.Sprintf("%v", obj), obj,
+			o.getErrorMessage(errorKeys.Const, fmt.Sprintf("value must be exactly %v", o.constValue)))
+	}
+
+	// Properties count validation
+	propCount := len(obj)
+	if o.minProperties > 0 && propCount < o.minProperties {
+		return goop.NewValidationError(fmt.Sprintf("%v", obj), obj,
+			o.getErrorMessage(errorKeys.MinProperties,
+				fmt.Sprintf("object has too few properties, minimum is %d but got %d", o.minProperties, propCount)))
+	}
+
+	if o.maxProperties > 0 && propCount > o.maxProperties {
+		return goop.NewValidationError(fmt.Sprintf("%v", obj), obj,
+			o.getErrorMessage(errorKeys.MaxProperties,
+				fmt.Sprintf("object has too many properties, maximum is %d but got %d", o.maxProperties, propCount)))
+	}
+
+	// Strict mode: check for unknown keys
+	if o.strictMode {
+		for key := range obj {
+			if _, exists := o.schema[key]; !exists {
+				return goop.NewValidationError(key, obj[key],
+					o.getErrorMessage(errorKeys.UnknownKey,
+						fmt.Sprintf("unknown key: %s", key)))
+			}
+		}
+	}
+
+	// Validate each field in declaration-independent, sorted order
+	collector := acquireErrorCollector()
+	for _, field := range fields {
+		value, exists := obj[field.name]
+
+		// Handle missing fields
+		if !exists {
+			if !o.partialMode && field.required {
+				collector.add(*goop.NewValidationError(field.name, nil,
+					fmt.Sprintf("missing required field: %s", field.name)))
+			}
+			continue
+		}
+
+		if err := o.validateCompiledField(field, value); err != nil {
+			if validationErr, ok := err.(*goop.ValidationError); ok {
+				validationErr.Field = field.name
+				collector.add(*validationErr)
+			} else {
+				collector.add(*goop.NewValidationError(field.name, value, err.Error()))
+			}
+		}
+	}
+	o.checkRequiredConditions(func(name string) (interface{}, bool) {
+		value, exists := obj[name]
+		return value, exists
+	}, collector)
+	details := collector.release()
+
+	if len(details) > 0 {
+		return goop.NewNestedValidationError("", obj, "object validation failed", details)
+	}
+
+	// Custom validation
+	if o.customFunc != nil {
+		if err := o.customFunc(obj); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Compile returns the schema itself; it is already compiled
+func (c *compiledObjectSchema) Compile() goop.Schema {
+	return c
+}
+
+// validateCompiledField validates one property value against its compiled validator
+func (o *objectSchema) validateCompiledField(field compiledField, value interface{}) error {
+	if field.schema == nil {
+		return o.validateField(field.raw, value)
+	}
+
+	// Values decoded from JSON need no normalization; skip the reflection path for them
+	switch value.(type) {
+	case nil, string, float64, bool, map[string]interface{}, []interface{}:
+		return field.schema.Validate(value)
+	default:
+		return field.schema.Validate(o.normalizeFieldValue(value))
+	}
+}
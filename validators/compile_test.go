@@ -0,0 +1,175 @@
+package validators
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	goop "github.com/picogrid/go-op"
+)
+
+func TestCompile(t *testing.T) {
+	schema := Object(map[string]interface{}{
+		"email": String().Email().Required(),
+		"age":   Number().Min(0).Max(150).Optional(),
+		"role":  String(),
+		"address": Object(map[string]interface{}{
+			"city": String().Min(1).Required(),
+		}).Required(),
+		"tags": Array(Object(map[string]interface{}{
+			"name": String().Required(),
+		}).Required()).Optional(),
+	}).Strict().Required()
+
+	compiled := Compile(schema)
+
+	cases := map[string]map[string]interface{}{
+		"valid": {
+			"email": "user@example.com", "role": "admin",
+			"address": map[string]interface{}{"city": "Berlin"},
+			"tags":    []interface{}{map[string]interface{}{"name": "a"}},
+		},
+		"missing required field": {
+			"email": "user@example.com", "address": map[string]interface{}{"city": "Berlin"},
+		},
+		"invalid email": {
+			"email": "nope", "role": "admin", "address": map[string]interface{}{"city": "Berlin"},
+		},
+		"invalid nested object": {
+			"email": "user@example.com", "role": "admin", "address": map[string]interface{}{"city": ""},
+		},
+		"invalid array element": {
+			"email": "user@example.com", "role": "admin",
+			"address": map[string]interface{}{"city": "Berlin"},
+			"tags":    []interface{}{map[string]interface{}{}},
+		},
+		"unknown key": {
+			"email": "user@example.com", "role": "admin",
+			"address": map[string]interface{}{"city": "Berlin"}, "extra": true,
+		},
+	}
+
+	for name, data := range cases {
+		t.Run(name, func(t *testing.T) {
+			expected := schema.Validate(data)
+			got := compiled.Validate(data)
+			if (expected == nil) != (got == nil) {
+				t.Fatalf("Expected compiled result %v to match original %v", got, expected)
+			}
+			if expected != nil && !sameFields(expected, got) {
+				t.Errorf("Expected failing fields to match, original %v, compiled %v", expected, got)
+			}
+		})
+	}
+
+	t.Run("Nil and wrong type", func(t *testing.T) {
+		if compiled.Validate(nil) == nil {
+			t.Error("Expected nil to fail for required object")
+		}
+		if compiled.Validate("not an object") == nil {
+			t.Error("Expected non-object to fail")
+		}
+		if err := Compile(Object(map[string]interface{}{}).Optional()).Validate(nil); err != nil {
+			t.Errorf("Expected nil to pass for optional object, got %v", err)
+		}
+	})
+
+	t.Run("OpenAPI generation is preserved", func(t *testing.T) {
+		enhanced, ok := compiled.(goop.EnhancedSchema)
+		if !ok {
+			t.Fatal("Expected compiled schema to implement EnhancedSchema")
+		}
+		got, expected := enhanced.ToOpenAPISchema(), schema.(goop.EnhancedSchema).ToOpenAPISchema()
+		if got.Type != expected.Type || !reflect.DeepEqual(got.Properties, expected.Properties) {
+			t.Error("Expected compiled schema to generate the same OpenAPI schema")
+		}
+	})
+
+	t.Run("Compile via schema method and CompileSchema", func(t *testing.T) {
+		if _, ok := goop.CompileSchema(schema).(*compiledObjectSchema); !ok {
+			t.Error("Expected CompileSchema to compile object schemas")
+		}
+		if Compile(compiled) != compiled {
+			t.Error("Expected compiling a compiled schema to return it unchanged")
+		}
+		str := String().Required()
+		if Compile(str) != str {
+			t.Error("Expected schemas without a compiled form to be returned unchanged")
+		}
+	})
+	t.Run("Builders share the compiled field list", func(t *testing.T) {
+		object := schema.(*requiredObjectSchema).objectSchema
+		fields := object.compiledFields()
+		if len(fields) != 5 || &object.compiledFields()[0] != &fields[0] {
+			t.Fatalf("Expected the five fields to be compiled once, got %d", len(fields))
+		}
+		if &compiled.(*compiledObjectSchema).fields[0] != &fields[0] {
+			t.Error("Expected Compile to reuse the builder's compiled fields")
+		}
+	})
+}
+
+// sameFields reports whether two validation errors report failures on the same fields
+func sameFields(a, b error) bool {
+	fields := func(err error) map[string]bool {
+		result := map[string]bool{}
+		if validationErr, ok := err.(*goop.ValidationError); ok {
+			for _, detail := range validationErr.Details {
+				result[detail.Field] = true
+			}
+			result[validationErr.Field] = true
+		}
+		return result
+	}
+	return reflect.DeepEqual(fields(a), fields(b))
+}
+
+func largeObjectSchema() goop.Schema {
+	properties := make(map[string]interface{}, 50)
+	for i := 0; i < 50; i++ {
+		switch i % 3 {
+		case 0:
+			properties[fmt.Sprintf("field%d", i)] = String().Min(1).Pattern(`^[a-z0-9]+$`).Required()
+		case 1:
+			properties[fmt.Sprintf("field%d", i)] = Number().Min(0).Required()
+		default:
+			properties[fmt.Sprintf("field%d", i)] = String().Email().Optional()
+		}
+	}
+	return Object(properties).Required()
+}
+
+func largeObjectData() map[string]interface{} {
+	data := make(map[string]interface{}, 50)
+	for i := 0; i < 50; i++ {
+		switch i % 3 {
+		case 0:
+			data[fmt.Sprintf("field%d", i)] = "value"
+		case 1:
+			data[fmt.Sprintf("field%d", i)] = float64(i)
+		default:
+			data[fmt.Sprintf("field%d", i)] = "user@example.com"
+		}
+	}
+	return data
+}
+
+func BenchmarkLargeObjectValidation(b *testing.B) {
+	data := largeObjectData()
+
+	b.Run("Builder", func(b *testing.B) {
+		schema := largeObjectSchema()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = schema.Validate(data)
+		}
+	})
+
+	b.Run("Compiled", func(b *testing.B) {
+		schema := Compile(largeObjectSchema())
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = schema.Validate(data)
+		}
+	})
+}
@@ -3,6 +3,7 @@ package validators
 import (
 	"fmt"
 	"reflect"
+	"sort"
 
 	goop "github.com/picogrid/go-op"
 )
@@ -13,7 +14,7 @@ type compositionSchema struct {
 	schemas         []interface{}
 	defaultValue    interface{}
 	hasDefault      bool
-	description     string
+	docs            schemaDocs
 }
 
 // OneOf creates a schema that validates against exactly one of the provided schemas
@@ -92,7 +93,20 @@ func (c *compositionSchema) Validate(data interface{}) error {
 }
 
 // validateOneOf ensures exactly one schema matches
+// When every branch is an object with a distinct Const on the same property, the branch is chosen
+// by that property and only it is validated, so its errors are reported instead of a bare mismatch.
 func (c *compositionSchema) validateOneOf(data interface{}) error {
+	if obj, ok := data.(map[string]interface{}); ok {
+		if property, values, ok := c.discriminator(obj); ok {
+			for i, value := range values {
+				if sameValue(obj[property], value) {
+					return c.schemas[i].(goop.Schema).Validate(data)
+				}
+			}
+			return goop.NewValidationError(property, obj[property], fmt.Sprintf("%s must be one of %v", property, values))
+		}
+	}
+
 	var matchCount int
 
 	for i, schema := range c.schemas {
@@ -116,6 +130,100 @@ func (c *compositionSchema) validateOneOf(data interface{}) error {
 	return nil
 }
 
+// discriminator returns a property of obj that every branch constrains with a different Const,
+// along with each branch's value; ok is false when there is none
+func (c *compositionSchema) discriminator(obj map[string]interface{}) (property string, values []interface{}, ok bool) {
+	branches := make([]*objectSchema, len(c.schemas))
+	for i, schema := range c.schemas {
+		if _, isSchema := schema.(goop.Schema); !isSchema {
+			return "", nil, false
+		}
+		if branches[i] = objectOf(schema); branches[i] == nil {
+			return "", nil, false
+		}
+	}
+	if len(branches) < 2 {
+		return "", nil, false
+	}
+
+	names := make([]string, 0, len(branches[0].schema))
+	for name := range branches[0].schema {
+		if value, present := obj[name]; present && value != nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+candidates:
+	for _, name := range names {
+		values = make([]interface{}, len(branches))
+		for i, branch := range branches {
+			value, hasConst := constOf(branch.schema[name])
+			if !hasConst {
+				continue candidates
+			}
+			for _, previous := range values[:i] {
+				if sameValue(value, previous) {
+					continue candidates
+				}
+			}
+			values[i] = value
+		}
+		return name, values, true
+	}
+	return "", nil, false
+}
+
+// objectOf returns the object schema behind schema, or nil when it is not an object schema
+func objectOf(schema interface{}) *objectSchema {
+	switch s := schema.(type) {
+	case *objectSchema:
+		return s
+	case *requiredObjectSchema:
+		return s.objectSchema
+	case *optionalObjectSchema:
+		return s.objectSchema
+	case *compiledObjectSchema:
+		return s.object
+	}
+	return nil
+}
+
+// constOf returns the value a schema's Const requires
+func constOf(schema interface{}) (interface{}, bool) {
+	switch s := schema.(type) {
+	case *requiredStringSchema:
+		return constOf(s.stringSchema)
+	case *optionalStringSchema:
+		return constOf(s.stringSchema)
+	case *stringSchema:
+		if s.constValue != nil {
+			return *s.constValue, true
+		}
+	case *requiredNumberSchema:
+		return constOf(s.numberSchema)
+	case *optionalNumberSchema:
+		return constOf(s.numberSchema)
+	case *numberSchema:
+		if s.constValue != nil {
+			return *s.constValue, true
+		}
+	case *requiredBoolSchema:
+		return constOf(s.boolSchema)
+	case *optionalBoolSchema:
+		return constOf(s.boolSchema)
+	case *boolSchema:
+		if s.constValue != nil {
+			return *s.constValue, true
+		}
+	default:
+		if o := objectOf(schema); o != nil && o.constValue != nil {
+			return o.constValue, true
+		}
+	}
+	return nil, false
+}
+
 // validateAllOf ensures all schemas match
 func (c *compositionSchema) validateAllOf(data interface{}) error {
 	var errors []error
@@ -170,9 +278,8 @@ func (c *compositionSchema) validateNot(data interface{}) error {
 
 // ToOpenAPISchema generates OpenAPI schema for composition
 func (c *compositionSchema) ToOpenAPISchema() *goop.OpenAPISchema {
-	schema := &goop.OpenAPISchema{
-		Description: c.description,
-	}
+	schema := &goop.OpenAPISchema{}
+	c.docs.apply(schema)
 
 	// Convert child schemas to OpenAPI schemas
 	var childSchemas []*goop.OpenAPISchema
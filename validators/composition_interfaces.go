@@ -4,6 +4,10 @@ import goop "github.com/picogrid/go-op"
 
 // CompositionBuilder provides the base interface for schema composition validators
 type CompositionBuilder interface {
+	// Title and Description document the composition in generated specifications
+	Title(title string) CompositionBuilder
+	Description(description string) CompositionBuilder
+
 	Required() RequiredCompositionBuilder
 	Optional() OptionalCompositionBuilder
 }
@@ -12,6 +16,7 @@ type CompositionBuilder interface {
 type RequiredCompositionBuilder interface {
 	CompositionBuilder
 	goop.EnhancedSchema
+	goop.JSONSchemaExporter
 	goop.Schema
 }
 
@@ -19,6 +24,7 @@ type RequiredCompositionBuilder interface {
 type OptionalCompositionBuilder interface {
 	CompositionBuilder
 	goop.EnhancedSchema
+	goop.JSONSchemaExporter
 	goop.Schema
 	Default(value interface{}) OptionalCompositionBuilder
 }
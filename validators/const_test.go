@@ -0,0 +1,114 @@
+package validators
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// TestConst tests Const on number, boolean, and object schemas
+func TestConst(t *testing.T) {
+	version := Number().Const(2).Required()
+	enabled := Bool().Const(true).Required()
+	origin := Object(map[string]interface{}{
+		"x": Number().Required(),
+		"y": Number().Required(),
+	}).Const(map[string]interface{}{"x": 0, "y": 0}).Required()
+
+	cases := []struct {
+		name    string
+		schema  goop.Schema
+		valid   []interface{}
+		invalid []interface{}
+		message string
+	}{
+		{"number", version, []interface{}{2, 2.0, json.Number("2")}, []interface{}{3, "2"}, "value must be exactly 2"},
+		{"bool", enabled, []interface{}{true}, []interface{}{false}, "value must be exactly true"},
+		{"object", origin, []interface{}{map[string]interface{}{"x": 0.0, "y": 0.0}}, []interface{}{map[string]interface{}{"x": 1.0, "y": 0.0}}, "value must be exactly"},
+	}
+	for _, tc := range cases {
+		for _, data := range tc.valid {
+			if err := tc.schema.Validate(data); err != nil {
+				t.Errorf("%s: expected %v to be valid, got: %v", tc.name, data, err)
+			}
+			if err := Compile(tc.schema).Validate(data); err != nil {
+				t.Errorf("%s: expected compiled schema to accept %v, got: %v", tc.name, data, err)
+			}
+		}
+		for _, data := range tc.invalid {
+			if err := tc.schema.Validate(data); err == nil {
+				t.Errorf("%s: expected %v to be rejected, got: %v", tc.name, data, err)
+			}
+			if err := Compile(tc.schema).Validate(data); err == nil {
+				t.Errorf("%s: expected compiled schema to reject %v", tc.name, data)
+			}
+		}
+		if err := tc.schema.Validate(tc.invalid[0]); err == nil || !strings.Contains(err.Error(), tc.message) {
+			t.Errorf("%s: expected message %q, got: %v", tc.name, tc.message, err)
+		}
+	}
+
+	t.Run("OpenAPI", func(t *testing.T) {
+		if c := version.(goop.EnhancedSchema).ToOpenAPISchema().Const; c != 2.0 {
+			t.Errorf("Expected number const 2, got %v", c)
+		}
+		if c := Bool().Const(false).Required().(goop.EnhancedSchema).ToOpenAPISchema().Const; c != false {
+			t.Errorf("Expected bool const false, got %v", c)
+		}
+		data, err := origin.ToJSONSchema()
+		if err != nil {
+			t.Fatalf("ToJSONSchema failed: %v", err)
+		}
+		var doc map[string]interface{}
+		if err := json.Unmarshal(data, &doc); err != nil {
+			t.Fatalf("Invalid JSON Schema: %v", err)
+		}
+		if c, _ := doc["const"].(map[string]interface{}); len(c) != 2 {
+			t.Errorf("Expected object const in JSON Schema, got %s", data)
+		}
+
+		imported, err := FromJSONSchema(data)
+		if err != nil {
+			t.Fatalf("FromJSONSchema failed: %v", err)
+		}
+		if err := imported.Validate(map[string]interface{}{"x": 1.0, "y": 0.0}); err == nil {
+			t.Error("Expected imported schema to enforce the object const")
+		}
+	})
+}
+
+// TestOneOfDiscriminator tests that OneOf picks the branch by a shared Const property
+func TestOneOfDiscriminator(t *testing.T) {
+	event := OneOf(
+		Object(map[string]interface{}{
+			"version": Number().Const(1).Required(),
+			"name":    String().Required(),
+		}).Required(),
+		Object(map[string]interface{}{
+			"version": Number().Const(2).Required(),
+			"name":    String().Required(),
+			"labels":  Array(String()).Required(),
+		}).Required(),
+	).Required()
+
+	if err := event.Validate(map[string]interface{}{"version": 1.0, "name": "created"}); err != nil {
+		t.Errorf("Expected version 1 event to be valid, got: %v", err)
+	}
+	if err := event.Validate(map[string]interface{}{"version": 2.0, "name": "created", "labels": []interface{}{"a"}}); err != nil {
+		t.Errorf("Expected version 2 event to be valid, got: %v", err)
+	}
+
+	err := event.Validate(map[string]interface{}{"version": 2.0, "name": "created"})
+	if err == nil || !strings.Contains(err.Error(), "missing required field: labels") {
+		t.Errorf("Expected the version 2 branch's error, got: %v", err)
+	}
+	err = event.Validate(map[string]interface{}{"version": 3.0, "name": "created"})
+	if err == nil || !strings.Contains(err.Error(), "version must be one of [1 2]") {
+		t.Errorf("Expected an unknown version error, got: %v", err)
+	}
+	if err := event.Validate(map[string]interface{}{"name": "created"}); err == nil {
+		t.Error("Expected an event without a version to match no branch")
+	}
+}
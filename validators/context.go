@@ -0,0 +1,228 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// contextChecker is implemented by schemas that run CustomCtx checks, in themselves or in the
+// schemas they contain. Checks run after Validate succeeds, so they only see well-formed data.
+type contextChecker interface {
+	checkContext(ctx context.Context, data interface{}) error
+}
+
+// checkItemContext runs the context checks of itemSchema on item, if it has any
+// Schemas from other packages, such as component references, are validated again with ctx.
+func checkItemContext(ctx context.Context, itemSchema, item interface{}) error {
+	switch schema := itemSchema.(type) {
+	case contextChecker:
+		return schema.checkContext(ctx, item)
+	case goop.ContextSchema:
+		return schema.ValidateCtx(ctx, item)
+	}
+	return nil
+}
+
+// hasContextChecks reports whether checkItemContext can do anything for itemSchema
+func hasContextChecks(itemSchema interface{}) bool {
+	switch itemSchema.(type) {
+	case contextChecker, goop.ContextSchema:
+		return true
+	}
+	return false
+}
+
+func (s *stringSchema) checkContext(ctx context.Context, data interface{}) error {
+	if s.contextFunc == nil && s.remote == nil {
+		return nil
+	}
+	str, _ := data.(string)
+	if str == "" && s.defaultValue != nil {
+		str = *s.defaultValue
+	}
+	// Empty strings are missing values, which only required schemas check
+	if str == "" && !s.required {
+		return nil
+	}
+	// A warning from the CustomCtx check still lets the value be queued for the remote check
+	var warning error
+	if s.contextFunc != nil && !remoteOnly(ctx) {
+		if err := s.contextFunc(ctx, str); err != nil {
+			if !isWarning(err) {
+				return err
+			}
+			warning = err
+		}
+	}
+	if s.remote != nil {
+		if err := checkRemote(ctx, s.remote, str); err != nil {
+			return err
+		}
+	}
+	return warning
+}
+
+func (n *numberSchema) checkContext(ctx context.Context, data interface{}) error {
+	if n.contextFunc == nil || remoteOnly(ctx) {
+		return nil
+	}
+	if data == nil {
+		if n.defaultValue == nil {
+			return nil
+		}
+		return n.contextFunc(ctx, *n.defaultValue)
+	}
+	num, _ := numericValue(data)
+	return n.contextFunc(ctx, num)
+}
+
+func (s *integerSchema) checkContext(ctx context.Context, data interface{}) error {
+	if (s.contextFunc == nil && s.tenantLimit == "") || remoteOnly(ctx) {
+		return nil
+	}
+	if data == nil {
+		if s.defaultValue == nil {
+			return nil
+		}
+		data = s.defaultValue
+	}
+	value, err := s.convert(data)
+	if err != nil {
+		return err
+	}
+	if s.tenantLimit != "" {
+		if err := s.checkTenantLimit(ctx, value, data); err != nil {
+			return err
+		}
+	}
+	if s.contextFunc == nil {
+		return nil
+	}
+	return s.contextFunc(ctx, value)
+}
+
+func (a *arraySchema) checkContext(ctx context.Context, data interface{}) error {
+	if !hasContextChecks(a.elementSchema) && a.contextFunc == nil && a.tenantLimit == "" {
+		return nil
+	}
+	if data == nil {
+		if a.defaultValue == nil {
+			return nil
+		}
+		data = a.defaultValue
+	}
+	arr, ok := data.([]interface{})
+	if !ok {
+		val := reflect.ValueOf(data)
+		arr = make([]interface{}, val.Len())
+		for i := range arr {
+			arr[i] = val.Index(i).Interface()
+		}
+	}
+	if a.tenantLimit != "" && !remoteOnly(ctx) {
+		if err := a.checkTenantLimit(ctx, arr); err != nil {
+			return err
+		}
+	}
+
+	// Element warnings do not stop the CustomCtx check of the array
+	var details []goop.ValidationError
+	if hasContextChecks(a.elementSchema) {
+		collector := acquireErrorCollector()
+		for i, item := range arr {
+			if err := checkItemContext(ctx, a.elementSchema, item); err != nil {
+				addElementError(collector, i, item, err)
+			}
+		}
+		details = collector.release()
+		if hasFailures(details) {
+			return goop.NewNestedValidationError("", arr, "array validation failed", details)
+		}
+	}
+
+	if a.contextFunc != nil && !remoteOnly(ctx) {
+		if err := a.contextFunc(ctx, arr); err != nil {
+			if len(details) == 0 {
+				return err
+			}
+			details = append(details, *asValidationError(err, arr))
+		}
+	}
+	if len(details) > 0 {
+		return goop.NewNestedValidationError("", arr, "array validation failed", details)
+	}
+	return nil
+}
+
+func (o *objectSchema) checkContext(ctx context.Context, data interface{}) error {
+	if data == nil {
+		if o.defaultValue == nil {
+			return nil
+		}
+		data = o.defaultValue
+	}
+	obj, ok := data.(map[string]interface{})
+	if !ok {
+		val := reflect.ValueOf(data)
+		obj = make(map[string]interface{}, val.Len())
+		for _, key := range val.MapKeys() {
+			obj[fmt.Sprintf("%v", key.Interface())] = val.MapIndex(key).Interface()
+		}
+	}
+
+	collector := acquireErrorCollector()
+	for fieldName, fieldSchema := range o.schema {
+		value := obj[fieldName]
+		if value != nil {
+			value = o.normalizeFieldValue(value)
+		}
+		if err := checkItemContext(ctx, fieldSchema, value); err != nil {
+			if validationErr, ok := err.(*goop.ValidationError); ok {
+				validationErr.Field = fieldName
+				collector.add(*validationErr)
+			} else {
+				collector.add(*goop.NewValidationError(fieldName, value, err.Error()))
+			}
+		}
+	}
+	if !remoteOnly(ctx) {
+		o.checkDeprecatedFields(obj, collector)
+	}
+	// Field warnings do not stop the CustomCtx check of the object
+	details := collector.release()
+	if hasFailures(details) {
+		return goop.NewNestedValidationError("", obj, "object validation failed", details)
+	}
+
+	if o.contextFunc != nil && !remoteOnly(ctx) {
+		if err := o.contextFunc(ctx, obj); err != nil {
+			if len(details) == 0 {
+				return err
+			}
+			details = append(details, *asValidationError(err, obj))
+		}
+	}
+	if len(details) > 0 {
+		return goop.NewNestedValidationError("", obj, "object validation failed", details)
+	}
+	return nil
+}
+
+// ValidateCtx validates data, then runs the CustomCtx and RemoteCheck checks of the object and its fields
+func (c *compiledObjectSchema) ValidateCtx(ctx context.Context, data interface{}) error {
+	if err := c.Validate(data); err != nil {
+		return err
+	}
+	return runContextChecks(ctx, c.object, data)
+}
+
+// ValidateCtx validates data as an exact integer, then runs the CustomCtx check with ctx
+func (s *integerSchema) ValidateCtx(ctx context.Context, data interface{}) error {
+	if err := s.Validate(data); err != nil {
+		return err
+	}
+	return runContextChecks(ctx, s, data)
+}
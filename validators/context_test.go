@@ -0,0 +1,75 @@
+package validators
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	goop "github.com/picogrid/go-op"
+)
+
+type tenantKey struct{}
+
+// TestValidateCtx tests checks that consult the context passed to ValidateCtx
+func TestValidateCtx(t *testing.T) {
+	allowedRegion := func(ctx context.Context, region string) error {
+		if tenant, _ := ctx.Value(tenantKey{}).(string); tenant == "eu-only" && !strings.HasPrefix(region, "eu-") {
+			return errors.New("region is not available for this tenant")
+		}
+		return nil
+	}
+	schema := Object(map[string]interface{}{
+		"region": String().CustomCtx(allowedRegion).Required(),
+		"tags":   Array(String().CustomCtx(allowedRegion)).Optional(),
+	}).Required()
+
+	euTenant := context.WithValue(context.Background(), tenantKey{}, "eu-only")
+	data := map[string]interface{}{"region": "us-east-1"}
+
+	if err := schema.Validate(data); err != nil {
+		t.Errorf("Expected Validate to skip context checks, got: %v", err)
+	}
+	if err := schema.ValidateCtx(context.Background(), data); err != nil {
+		t.Errorf("Expected an unrestricted tenant to pass, got: %v", err)
+	}
+	err := goop.ValidateCtx(euTenant, schema, data)
+	if err == nil || !strings.Contains(err.Error(), "region is not available") {
+		t.Errorf("Expected the tenant restriction to fail, got: %v", err)
+	}
+
+	t.Run("Array items", func(t *testing.T) {
+		data := map[string]interface{}{"region": "eu-west-1", "tags": []interface{}{"eu-central-1", "ap-south-1"}}
+		if err := schema.ValidateCtx(euTenant, data); err == nil || !strings.Contains(err.Error(), "[1]") {
+			t.Errorf("Expected the second tag to fail, got: %v", err)
+		}
+	})
+
+	t.Run("Runs only after validation succeeds", func(t *testing.T) {
+		calls := 0
+		counted := Number().Max(10).CustomCtx(func(ctx context.Context, value float64) error {
+			calls++
+			return nil
+		}).Required()
+		if err := counted.ValidateCtx(euTenant, 20.0); err == nil {
+			t.Error("Expected the maximum to fail")
+		}
+		if calls != 0 {
+			t.Errorf("Expected the context check not to run, got %d calls", calls)
+		}
+	})
+
+	t.Run("Compiled objects", func(t *testing.T) {
+		compiled := goop.CompileSchema(schema)
+		if err := goop.ValidateCtx(euTenant, compiled, data); err == nil {
+			t.Error("Expected the compiled schema to run context checks")
+		}
+	})
+
+	t.Run("Optional default", func(t *testing.T) {
+		region := String().CustomCtx(allowedRegion).Optional().Default("us-west-2")
+		if err := region.ValidateCtx(euTenant, nil); err == nil {
+			t.Error("Expected the default value to be checked")
+		}
+	})
+}
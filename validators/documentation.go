@@ -0,0 +1,404 @@
+package validators
+
+import (
+	goop "github.com/picogrid/go-op"
+)
+
+// schemaDocs holds the title and description documenting a schema
+// They only appear in generated specifications and never affect validation.
+type schemaDocs struct {
+	title       string
+	description string
+}
+
+// apply documents schema with the title and description that were set
+func (d schemaDocs) apply(schema *goop.OpenAPISchema) {
+	if d.title != "" {
+		schema.Title = d.title
+	}
+	if d.description != "" {
+		schema.Description = d.description
+	}
+}
+
+// Title sets a short title for the value, documented as the schema's title
+func (s *stringSchema) Title(title string) StringBuilder {
+	s.docs.title = title
+	return s
+}
+
+// Description explains what the value means, documented as the schema's description.
+// Unlike the constraints, it tells readers of the specification what the value is for.
+//
+// Example:
+//
+//	"sku": validators.String().Pattern(`^[A-Z]{3}-\d{4}$`).
+//		Title("SKU").
+//		Description("Stock keeping unit assigned by the warehouse").
+//		Required(),
+func (s *stringSchema) Description(description string) StringBuilder {
+	s.docs.description = description
+	return s
+}
+
+func (r *requiredStringSchema) Title(title string) RequiredStringBuilder {
+	r.docs.title = title
+	return r
+}
+
+func (r *requiredStringSchema) Description(description string) RequiredStringBuilder {
+	r.docs.description = description
+	return r
+}
+
+func (o *optionalStringSchema) Title(title string) OptionalStringBuilder {
+	o.docs.title = title
+	return o
+}
+
+func (o *optionalStringSchema) Description(description string) OptionalStringBuilder {
+	o.docs.description = description
+	return o
+}
+
+func (n *numberSchema) Title(title string) NumberBuilder {
+	n.docs.title = title
+	return n
+}
+
+func (n *numberSchema) Description(description string) NumberBuilder {
+	n.docs.description = description
+	return n
+}
+
+func (r *requiredNumberSchema) Title(title string) RequiredNumberBuilder {
+	r.docs.title = title
+	return r
+}
+
+func (r *requiredNumberSchema) Description(description string) RequiredNumberBuilder {
+	r.docs.description = description
+	return r
+}
+
+func (o *optionalNumberSchema) Title(title string) OptionalNumberBuilder {
+	o.docs.title = title
+	return o
+}
+
+func (o *optionalNumberSchema) Description(description string) OptionalNumberBuilder {
+	o.docs.description = description
+	return o
+}
+
+func (i *intSchema) Title(title string) IntBuilder {
+	i.docs.title = title
+	return i
+}
+
+func (i *intSchema) Description(description string) IntBuilder {
+	i.docs.description = description
+	return i
+}
+
+func (r *requiredIntSchema) Title(title string) RequiredIntBuilder {
+	r.docs.title = title
+	return r
+}
+
+func (r *requiredIntSchema) Description(description string) RequiredIntBuilder {
+	r.docs.description = description
+	return r
+}
+
+func (o *optionalIntSchema) Title(title string) OptionalIntBuilder {
+	o.docs.title = title
+	return o
+}
+
+func (o *optionalIntSchema) Description(description string) OptionalIntBuilder {
+	o.docs.description = description
+	return o
+}
+
+func (u *uintSchema) Title(title string) UintBuilder {
+	u.docs.title = title
+	return u
+}
+
+func (u *uintSchema) Description(description string) UintBuilder {
+	u.docs.description = description
+	return u
+}
+
+func (r *requiredUintSchema) Title(title string) RequiredUintBuilder {
+	r.docs.title = title
+	return r
+}
+
+func (r *requiredUintSchema) Description(description string) RequiredUintBuilder {
+	r.docs.description = description
+	return r
+}
+
+func (o *optionalUintSchema) Title(title string) OptionalUintBuilder {
+	o.docs.title = title
+	return o
+}
+
+func (o *optionalUintSchema) Description(description string) OptionalUintBuilder {
+	o.docs.description = description
+	return o
+}
+
+func (i *integer64Schema) Title(title string) Integer64Builder {
+	i.docs.title = title
+	return i
+}
+
+func (i *integer64Schema) Description(description string) Integer64Builder {
+	i.docs.description = description
+	return i
+}
+
+func (r *requiredInteger64Schema) Title(title string) RequiredInteger64Builder {
+	r.docs.title = title
+	return r
+}
+
+func (r *requiredInteger64Schema) Description(description string) RequiredInteger64Builder {
+	r.docs.description = description
+	return r
+}
+
+func (o *optionalInteger64Schema) Title(title string) OptionalInteger64Builder {
+	o.docs.title = title
+	return o
+}
+
+func (o *optionalInteger64Schema) Description(description string) OptionalInteger64Builder {
+	o.docs.description = description
+	return o
+}
+
+func (b *bigIntSchema) Title(title string) BigIntBuilder {
+	b.docs.title = title
+	return b
+}
+
+func (b *bigIntSchema) Description(description string) BigIntBuilder {
+	b.docs.description = description
+	return b
+}
+
+func (r *requiredBigIntSchema) Title(title string) RequiredBigIntBuilder {
+	r.docs.title = title
+	return r
+}
+
+func (r *requiredBigIntSchema) Description(description string) RequiredBigIntBuilder {
+	r.docs.description = description
+	return r
+}
+
+func (o *optionalBigIntSchema) Title(title string) OptionalBigIntBuilder {
+	o.docs.title = title
+	return o
+}
+
+func (o *optionalBigIntSchema) Description(description string) OptionalBigIntBuilder {
+	o.docs.description = description
+	return o
+}
+
+func (d *decimalSchema) Title(title string) DecimalBuilder {
+	d.docs.title = title
+	return d
+}
+
+func (d *decimalSchema) Description(description string) DecimalBuilder {
+	d.docs.description = description
+	return d
+}
+
+func (r *requiredDecimalSchema) Title(title string) RequiredDecimalBuilder {
+	r.docs.title = title
+	return r
+}
+
+func (r *requiredDecimalSchema) Description(description string) RequiredDecimalBuilder {
+	r.docs.description = description
+	return r
+}
+
+func (o *optionalDecimalSchema) Title(title string) OptionalDecimalBuilder {
+	o.docs.title = title
+	return o
+}
+
+func (o *optionalDecimalSchema) Description(description string) OptionalDecimalBuilder {
+	o.docs.description = description
+	return o
+}
+
+func (b *boolSchema) Title(title string) BoolBuilder {
+	b.docs.title = title
+	return b
+}
+
+func (b *boolSchema) Description(description string) BoolBuilder {
+	b.docs.description = description
+	return b
+}
+
+func (r *requiredBoolSchema) Title(title string) RequiredBoolBuilder {
+	r.docs.title = title
+	return r
+}
+
+func (r *requiredBoolSchema) Description(description string) RequiredBoolBuilder {
+	r.docs.description = description
+	return r
+}
+
+func (o *optionalBoolSchema) Title(title string) OptionalBoolBuilder {
+	o.docs.title = title
+	return o
+}
+
+func (o *optionalBoolSchema) Description(description string) OptionalBoolBuilder {
+	o.docs.description = description
+	return o
+}
+
+func (o *objectSchema) Title(title string) ObjectBuilder {
+	o.docs.title = title
+	return o
+}
+
+func (o *objectSchema) Description(description string) ObjectBuilder {
+	o.docs.description = description
+	return o
+}
+
+func (r *requiredObjectSchema) Title(title string) RequiredObjectBuilder {
+	r.docs.title = title
+	return r
+}
+
+func (r *requiredObjectSchema) Description(description string) RequiredObjectBuilder {
+	r.docs.description = description
+	return r
+}
+
+func (o *optionalObjectSchema) Title(title string) OptionalObjectBuilder {
+	o.docs.title = title
+	return o
+}
+
+func (o *optionalObjectSchema) Description(description string) OptionalObjectBuilder {
+	o.docs.description = description
+	return o
+}
+
+func (a *arraySchema) Title(title string) ArrayBuilder {
+	a.docs.title = title
+	return a
+}
+
+func (a *arraySchema) Description(description string) ArrayBuilder {
+	a.docs.description = description
+	return a
+}
+
+func (r *requiredArraySchema) Title(title string) RequiredArrayBuilder {
+	r.docs.title = title
+	return r
+}
+
+func (r *requiredArraySchema) Description(description string) RequiredArrayBuilder {
+	r.docs.description = description
+	return r
+}
+
+func (o *optionalArraySchema) Title(title string) OptionalArrayBuilder {
+	o.docs.title = title
+	return o
+}
+
+func (o *optionalArraySchema) Description(description string) OptionalArrayBuilder {
+	o.docs.description = description
+	return o
+}
+
+func (t *tupleSchema) Title(title string) TupleBuilder {
+	t.docs.title = title
+	return t
+}
+
+func (t *tupleSchema) Description(description string) TupleBuilder {
+	t.docs.description = description
+	return t
+}
+
+func (r *requiredTupleSchema) Title(title string) RequiredTupleBuilder {
+	r.docs.title = title
+	return r
+}
+
+func (r *requiredTupleSchema) Description(description string) RequiredTupleBuilder {
+	r.docs.description = description
+	return r
+}
+
+func (o *optionalTupleSchema) Title(title string) OptionalTupleBuilder {
+	o.docs.title = title
+	return o
+}
+
+func (o *optionalTupleSchema) Description(description string) OptionalTupleBuilder {
+	o.docs.description = description
+	return o
+}
+
+func (p *passwordSchema) Title(title string) PasswordBuilder {
+	p.docs.title = title
+	return p
+}
+
+func (p *passwordSchema) Description(description string) PasswordBuilder {
+	p.docs.description = description
+	return p
+}
+
+func (r *requiredPasswordSchema) Title(title string) RequiredPasswordBuilder {
+	r.docs.title = title
+	return r
+}
+
+func (r *requiredPasswordSchema) Description(description string) RequiredPasswordBuilder {
+	r.docs.description = description
+	return r
+}
+
+func (o *optionalPasswordSchema) Title(title string) OptionalPasswordBuilder {
+	o.docs.title = title
+	return o
+}
+
+func (o *optionalPasswordSchema) Description(description string) OptionalPasswordBuilder {
+	o.docs.description = description
+	return o
+}
+
+// Title sets a short title for the composition, documented as the schema's title
+func (c *compositionSchema) Title(title string) CompositionBuilder {
+	c.docs.title = title
+	return c
+}
+
+// Description explains what the composition represents, documented as the schema's description
+func (c *compositionSchema) Description(description string) CompositionBuilder {
+	c.docs.description = description
+	return c
+}
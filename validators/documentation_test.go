@@ -0,0 +1,96 @@
+package validators
+
+import (
+	"encoding/json"
+	"testing"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// TestSchemaDocumentation tests documenting schemas with a title and description
+func TestSchemaDocumentation(t *testing.T) {
+	schema := Object(map[string]interface{}{
+		"sku":      String().Title("SKU").Description("Stock keeping unit").Required(),
+		"price":    Number().Min(0).Description("Unit price in euros").Required(),
+		"quantity": Int().Min(1).Required().Title("Quantity"),
+		"id":       Integer64().Optional().Description("Catalog ID"),
+		"total":    Decimal().Description("Order total").Required(),
+		"gift":     Bool().Title("Gift wrapping").Optional(),
+		"tags":     Array(String().Required()).Description("Search tags").Optional(),
+		"size":     Tuple(Int().Required(), Int().Required()).Title("Width and height").Optional(),
+		"secret":   Password().Description("Account password").Optional(),
+		"payment": OneOf(
+			String().Required(),
+			Int().Required(),
+		).Title("Payment").Description("Card token or voucher number").Required(),
+		"plain": String().Required(),
+	}).Title("Order line").Description("A product in an order").Required()
+
+	spec := schema.(goop.EnhancedSchema).ToOpenAPISchema()
+	if spec.Title != "Order line" || spec.Description != "A product in an order" {
+		t.Errorf("Expected object title and description, got %q and %q", spec.Title, spec.Description)
+	}
+
+	expected := map[string][2]string{
+		"sku":      {"SKU", "Stock keeping unit"},
+		"price":    {"", "Unit price in euros"},
+		"quantity": {"Quantity", ""},
+		"id":       {"", "Catalog ID"},
+		"total":    {"", "Order total"},
+		"gift":     {"Gift wrapping", ""},
+		"tags":     {"", "Search tags"},
+		"size":     {"Width and height", ""},
+		"secret":   {"", "Account password"},
+		"payment":  {"Payment", "Card token or voucher number"},
+		"plain":    {"", ""},
+	}
+	for field, want := range expected {
+		property := spec.Properties[field]
+		if property == nil {
+			t.Errorf("%s: missing from schema", field)
+			continue
+		}
+		if property.Title != want[0] {
+			t.Errorf("%s: expected title %q, got %q", field, want[0], property.Title)
+		}
+		if property.Description != want[1] {
+			t.Errorf("%s: expected description %q, got %q", field, want[1], property.Description)
+		}
+	}
+
+	// Documentation never affects validation
+	if err := schema.Validate(map[string]interface{}{
+		"sku": "ABC-1", "price": 9.5, "quantity": 2, "total": "19.00", "payment": "tok_1", "plain": "x",
+	}); err != nil {
+		t.Errorf("Unexpected validation error: %v", err)
+	}
+
+	t.Run("TypedObject", func(t *testing.T) {
+		type line struct {
+			SKU string `json:"sku"`
+		}
+		typed := ObjectOf[line]().
+			Field("sku", String().Required()).
+			Title("Line").
+			Description("A typed order line").
+			Required()
+		spec := typed.(goop.EnhancedSchema).ToOpenAPISchema()
+		if spec.Title != "Line" || spec.Description != "A typed order line" {
+			t.Errorf("Expected typed object title and description, got %q and %q", spec.Title, spec.Description)
+		}
+	})
+
+	t.Run("JSONSchema", func(t *testing.T) {
+		data, err := String().Title("Nickname").Description("Shown to other users").Required().ToJSONSchema()
+		if err != nil {
+			t.Fatalf("Unexpected export error: %v", err)
+		}
+		var exported map[string]interface{}
+		if err := json.Unmarshal(data, &exported); err != nil {
+			t.Fatalf("Unexpected decode error: %v", err)
+		}
+		if exported["title"] != "Nickname" || exported["description"] != "Shown to other users" {
+			t.Errorf("Expected title and description in JSON Schema, got %s", data)
+		}
+	})
+}
@@ -0,0 +1,108 @@
+package validators
+
+import (
+	"strings"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// EmailOption configures the additional checks applied by Email
+type EmailOption func(*emailRules)
+
+// MXChecker reports whether a domain can receive mail, typically by looking up its MX records
+// Implementations are called during validation, so they should cache results and bound their
+// lookups with a timeout.
+type MXChecker interface {
+	HasMX(domain string) bool
+}
+
+// MXCheckerFunc adapts a function to the MXChecker interface
+type MXCheckerFunc func(domain string) bool
+
+// HasMX calls f(domain)
+func (f MXCheckerFunc) HasMX(domain string) bool {
+	return f(domain)
+}
+
+// emailRules are the checks configured with EmailOption, applied after the format check
+type emailRules struct {
+	lowercase  bool
+	requireTLD bool
+	denyDomain func(domain string) bool
+	mx         MXChecker
+}
+
+// EmailLowercase requires the address to be in the lowercase form returned by NormalizeEmail,
+// so the same mailbox cannot be registered twice with different capitalization
+func EmailLowercase() EmailOption {
+	return func(r *emailRules) { r.lowercase = true }
+}
+
+// EmailRequireTLD rejects domains under top-level names reserved for testing and local
+// networks, such as .test, .example, .invalid, .localhost, and .local
+func EmailRequireTLD() EmailOption {
+	return func(r *emailRules) { r.requireTLD = true }
+}
+
+// EmailDenyDomain rejects addresses whose domain deny reports as true, for example to block
+// disposable mail providers. deny receives the lowercased domain.
+func EmailDenyDomain(deny func(domain string) bool) EmailOption {
+	return func(r *emailRules) { r.denyDomain = deny }
+}
+
+// EmailMXCheck rejects addresses whose domain checker reports cannot receive mail
+// checker receives the lowercased domain.
+func EmailMXCheck(checker MXChecker) EmailOption {
+	return func(r *emailRules) { r.mx = checker }
+}
+
+// NormalizeEmail returns the canonical form of an email address: trimmed and lowercased
+// Handlers should store addresses in this form when validating them with EmailLowercase.
+func NormalizeEmail(address string) string {
+	return strings.ToLower(strings.TrimSpace(address))
+}
+
+// reservedTLDs are the special-use top-level names from RFC 2606 and RFC 6761 that never
+// belong to a deliverable public address
+var reservedTLDs = map[string]bool{
+	"test": true, "example": true, "invalid": true, "localhost": true, "local": true,
+}
+
+// setEmail enables the email format and records the options, if any
+func (s *stringSchema) setEmail(options []EmailOption) {
+	s.emailFormat = true
+	if len(options) == 0 {
+		return
+	}
+	if s.emailRules == nil {
+		s.emailRules = &emailRules{}
+	}
+	for _, option := range options {
+		option(s.emailRules)
+	}
+}
+
+// validateEmailRules applies the configured email options to an address with a valid format
+// Addresses that are already lowercase are checked without allocating.
+func (s *stringSchema) validateEmailRules(str string) error {
+	rules := s.emailRules
+	if rules.lowercase && strings.ToLower(str) != str {
+		return goop.NewValidationError(str, str,
+			s.getErrorMessage(errorKeys.Email, "email must be lowercase"))
+	}
+
+	domain := strings.ToLower(str[strings.LastIndexByte(str, '@')+1:])
+	if rules.requireTLD && reservedTLDs[domain[strings.LastIndexByte(domain, '.')+1:]] {
+		return goop.NewValidationError(str, str,
+			s.getErrorMessage(errorKeys.EmailDomain, "email domain must use a public top-level domain"))
+	}
+	if rules.denyDomain != nil && rules.denyDomain(domain) {
+		return goop.NewValidationError(str, str,
+			s.getErrorMessage(errorKeys.EmailDomain, "email domain is not allowed"))
+	}
+	if rules.mx != nil && !rules.mx.HasMX(domain) {
+		return goop.NewValidationError(str, str,
+			s.getErrorMessage(errorKeys.EmailDomain, "email domain cannot receive mail"))
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package validators
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestEmailOptions tests normalization and domain checks on email addresses
+func TestEmailOptions(t *testing.T) {
+	disposable := map[string]bool{"mailinator.com": true}
+	lookups := 0
+	schema := Email(
+		EmailLowercase(),
+		EmailRequireTLD(),
+		EmailDenyDomain(func(domain string) bool { return disposable[domain] }),
+		EmailMXCheck(MXCheckerFunc(func(domain string) bool {
+			lookups++
+			return domain != "no-mail.io"
+		})),
+	)
+
+	if err := schema.Validate("jane@example.com"); err != nil {
+		t.Errorf("Expected a normalized public address to be valid, got: %v", err)
+	}
+
+	invalid := map[string]string{
+		"Jane@example.com":      "email must be lowercase",
+		"jane@server.local":     "public top-level domain",
+		"jane@mailinator.com":   "email domain is not allowed",
+		"jane@no-mail.io":       "cannot receive mail",
+		"not-an-email":          "invalid email format",
+		"jane@sub.example.test": "public top-level domain",
+	}
+	for value, message := range invalid {
+		if err := schema.Validate(value); err == nil || !strings.Contains(err.Error(), message) {
+			t.Errorf("Expected %q to fail with %q, got: %v", value, message, err)
+		}
+	}
+
+	t.Run("Domain checks see the lowercased domain", func(t *testing.T) {
+		deny := String().Email(EmailDenyDomain(func(domain string) bool { return disposable[domain] })).Required()
+		if err := deny.Validate("Jane@Mailinator.COM"); err == nil {
+			t.Error("Expected the deny hook to match regardless of case")
+		}
+	})
+
+	t.Run("Custom message", func(t *testing.T) {
+		custom := String().Email(EmailRequireTLD()).WithMessage(Errors.EmailDomain(), "use a real address").Required()
+		if err := custom.Validate("jane@localhost.test"); err == nil || !strings.Contains(err.Error(), "use a real address") {
+			t.Errorf("Expected custom emailDomain message, got: %v", err)
+		}
+	})
+
+	t.Run("NormalizeEmail", func(t *testing.T) {
+		if got := NormalizeEmail("  Jane.Doe@Example.COM "); got != "jane.doe@example.com" {
+			t.Errorf("Expected normalized address, got %q", got)
+		}
+	})
+
+	if lookups == 0 {
+		t.Error("Expected the MX checker to be called")
+	}
+}
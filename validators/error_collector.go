@@ -0,0 +1,57 @@
+package validators
+
+import (
+	"sync"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// errorCollector accumulates validation error details during a single validation pass
+// Collectors are pooled so failing validations reuse their backing storage across calls
+type errorCollector struct {
+	details []goop.ValidationError
+}
+
+var errorCollectorPool = sync.Pool{
+	New: func() interface{} {
+		return &errorCollector{details: make([]goop.ValidationError, 0, 8)}
+	},
+}
+
+// acquireErrorCollector returns an empty collector from the pool
+func acquireErrorCollector() *errorCollector {
+	return errorCollectorPool.Get().(*errorCollector)
+}
+
+// add records a validation error detail
+func (c *errorCollector) add(detail goop.ValidationError) {
+	c.details = append(c.details, detail)
+}
+
+// has reports whether a detail was recorded for field
+func (c *errorCollector) has(field string) bool {
+	for i := range c.details {
+		if c.details[i].Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+// release returns the collector to the pool and yields a copy of the collected details
+// It returns nil without allocating when nothing was collected
+func (c *errorCollector) release() []goop.ValidationError {
+	var details []goop.ValidationError
+	if len(c.details) > 0 {
+		details = make([]goop.ValidationError, len(c.details))
+		copy(details, c.details)
+	}
+
+	// Clear references so pooled storage does not retain error values
+	for i := range c.details {
+		c.details[i] = goop.ValidationError{}
+	}
+	c.details = c.details[:0]
+	errorCollectorPool.Put(c)
+	return details
+}
@@ -33,8 +33,8 @@ func TestStringExampleFunctionality(t *testing.T) {
 		t.Errorf("Expected type to be 'string', got %s", openAPISchema.Type)
 	}
 
-	if openAPISchema.MinLength == nil || *openAPISchema.MinLength != 3 {
-		t.Error("Expected minLength to be 3")
+	if openAPISchema.MinBytes == nil || *openAPISchema.MinBytes != 3 {
+		t.Error("Expected x-min-bytes to be 3")
 	}
 }
 
@@ -0,0 +1,170 @@
+package validators
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// formatCheck validates a string against a named format
+type formatCheck func(string) error
+
+var (
+	formatsMu sync.RWMutex
+	formats   = map[string]formatCheck{
+		"email": boolFormat(isValidEmail),
+		"uri":   boolFormat(isValidURL),
+		"date-time": boolFormat(func(s string) bool {
+			_, err := time.Parse(time.RFC3339, s)
+			return err == nil
+		}),
+		"date": boolFormat(func(s string) bool {
+			_, err := time.Parse(time.DateOnly, s)
+			return err == nil
+		}),
+		"uuid": boolFormat(func(s string) bool {
+			_, err := uuid.Parse(s)
+			return err == nil
+		}),
+		"ipv4":     boolFormat(isIPv4),
+		"ipv6":     boolFormat(isIPv6),
+		"cidr":     boolFormat(isCIDR),
+		"hostname": boolFormat(isHostname),
+		"port":     boolFormat(isPort),
+		"mac":      boolFormat(isMAC),
+		"bigint":   patternFormat(integerStringRegex),
+		"decimal":  patternFormat(decimalStringRegex),
+	}
+)
+
+// RegisterFormat defines a named string format usable with Format on any string schema
+// The format is a regular expression, given as a string or *regexp.Regexp, or a
+// func(string) bool or func(string) error check. Registering a name again replaces its check,
+// including the built-in email, uri, date-time, date, uuid, ipv4, ipv6, cidr, hostname, port,
+// mac, bigint, and decimal formats.
+// It panics on an empty name, an invalid pattern, or an unsupported format type.
+//
+// Example:
+//
+//	func init() {
+//	    validators.RegisterFormat("ksuid", `^[0-9A-Za-z]{27}$`)
+//	}
+//
+//	idSchema := validators.String().Format("ksuid").Required()
+func RegisterFormat(name string, format interface{}) {
+	if name == "" {
+		panic("validators: RegisterFormat: empty format name")
+	}
+
+	var check formatCheck
+	switch f := format.(type) {
+	case string:
+		check = patternFormat(regexp.MustCompile(f))
+	case *regexp.Regexp:
+		check = patternFormat(f)
+	case func(string) bool:
+		check = boolFormat(f)
+	case func(string) error:
+		check = f
+	default:
+		panic(fmt.Sprintf("validators: RegisterFormat: unsupported format %T for %q", format, name))
+	}
+
+	formatsMu.Lock()
+	defer formatsMu.Unlock()
+	formats[name] = check
+}
+
+// IsRegisteredFormat reports whether a string format with the given name is registered
+func IsRegisteredFormat(name string) bool {
+	_, ok := lookupFormat(name)
+	return ok
+}
+
+// lookupFormat returns the check registered for a format name
+func lookupFormat(name string) (formatCheck, bool) {
+	formatsMu.RLock()
+	defer formatsMu.RUnlock()
+	check, ok := formats[name]
+	return check, ok
+}
+
+// isIPv4 reports whether s is a dotted-decimal IPv4 address
+func isIPv4(s string) bool {
+	addr, err := netip.ParseAddr(s)
+	return err == nil && addr.Is4()
+}
+
+// isIPv6 reports whether s is an IPv6 address without a zone
+func isIPv6(s string) bool {
+	addr, err := netip.ParseAddr(s)
+	return err == nil && addr.Is6() && addr.Zone() == ""
+}
+
+// isCIDR reports whether s is an IPv4 or IPv6 prefix in CIDR notation, such as 10.0.0.0/8
+func isCIDR(s string) bool {
+	_, err := netip.ParsePrefix(s)
+	return err == nil
+}
+
+// isHostname reports whether s is an RFC 1123 hostname
+func isHostname(s string) bool {
+	if s == "" || len(s) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(s, ".") {
+		if label == "" || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, c := range label {
+			if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// isPort reports whether s is a decimal TCP or UDP port from 1 to 65535
+func isPort(s string) bool {
+	if s == "" || s[0] == '0' {
+		return false
+	}
+	port, err := strconv.ParseUint(s, 10, 16)
+	return err == nil && port > 0
+}
+
+// isMAC reports whether s is a colon- or hyphen-separated EUI-48 or EUI-64 hardware address
+func isMAC(s string) bool {
+	if strings.Contains(s, ".") {
+		// net.ParseMAC also accepts the dotted Cisco form, which APIs rarely expect
+		return false
+	}
+	hw, err := net.ParseMAC(s)
+	return err == nil && (len(hw) == 6 || len(hw) == 8)
+}
+
+// boolFormat adapts a predicate to a format check
+func boolFormat(valid func(string) bool) formatCheck {
+	return func(s string) error {
+		if !valid(s) {
+			return errFormatMismatch
+		}
+		return nil
+	}
+}
+
+// patternFormat checks strings against a regular expression
+func patternFormat(pattern *regexp.Regexp) formatCheck {
+	return boolFormat(pattern.MatchString)
+}
+
+// errFormatMismatch is returned by predicate and pattern checks, whose failures need no detail
+var errFormatMismatch = fmt.Errorf("format mismatch")
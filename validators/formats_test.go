@@ -0,0 +1,167 @@
+package validators
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// TestStringFormats tests registering named string formats and using them with Format
+func TestStringFormats(t *testing.T) {
+	RegisterFormat("test-ksuid", `^[0-9A-Za-z]{27}$`)
+	RegisterFormat("test-sku", regexp.MustCompile(`^SKU-\d+$`))
+	RegisterFormat("test-even", func(s string) bool { return len(s)%2 == 0 })
+	RegisterFormat("test-lower", func(s string) error {
+		if strings.ToLower(s) != s {
+			return errors.New("must be lowercase")
+		}
+		return nil
+	})
+
+	t.Run("Registered formats are validated", func(t *testing.T) {
+		cases := []struct {
+			format  string
+			valid   string
+			invalid string
+		}{
+			{"test-ksuid", "0ujtsYcgvSTl8PAuAdqWYSMnLOv", "short"},
+			{"test-sku", "SKU-42", "sku-42"},
+			{"test-even", "ab", "abc"},
+			{"test-lower", "abc", "ABC"},
+			{"uuid", "550e8400-e29b-41d4-a716-446655440000", "not-a-uuid"},
+			{"date", "2024-06-01", "06/01/2024"},
+		}
+		for _, tc := range cases {
+			schema := String().Format(tc.format).Required()
+			if err := schema.Validate(tc.valid); err != nil {
+				t.Errorf("%s: expected %q to be valid, got: %v", tc.format, tc.valid, err)
+			}
+			if err := schema.Validate(tc.invalid); err == nil {
+				t.Errorf("%s: expected %q to be invalid", tc.format, tc.invalid)
+			}
+		}
+	})
+
+	t.Run("Error messages name the format", func(t *testing.T) {
+		err := String().Format("test-sku").Required().Validate("sku-42")
+		if err == nil || !strings.Contains(err.Error(), "invalid test-sku format") {
+			t.Errorf("Expected format error, got: %v", err)
+		}
+		err = String().Format("test-lower").Optional().Validate("ABC")
+		if err == nil || !strings.Contains(err.Error(), "must be lowercase") {
+			t.Errorf("Expected check error detail, got: %v", err)
+		}
+		err = String().Format("test-sku").WithMessage(ErrFormat, "not a SKU").Required().Validate("x")
+		if err == nil || !strings.Contains(err.Error(), "not a SKU") {
+			t.Errorf("Expected custom message, got: %v", err)
+		}
+	})
+
+	t.Run("Unknown formats fail validation", func(t *testing.T) {
+		if err := String().Format("test-missing").Required().Validate("value"); err == nil {
+			t.Error("Expected unknown format to fail validation")
+		}
+		if IsRegisteredFormat("test-missing") || !IsRegisteredFormat("test-ksuid") {
+			t.Error("Expected IsRegisteredFormat to report registrations")
+		}
+	})
+
+	t.Run("Format is emitted into the spec", func(t *testing.T) {
+		schema := String().Format("test-ksuid").Required().(goop.EnhancedSchema)
+		if format := schema.ToOpenAPISchema().Format; format != "test-ksuid" {
+			t.Errorf("Expected format test-ksuid, got %q", format)
+		}
+		if format := schema.GetValidationInfo().Constraints["format"]; format != "test-ksuid" {
+			t.Errorf("Expected format constraint test-ksuid, got %v", format)
+		}
+	})
+
+	t.Run("Imported schemas use registered formats", func(t *testing.T) {
+		schema, err := FromJSONSchema([]byte(`{"type": "string", "format": "test-sku"}`))
+		if err != nil {
+			t.Fatalf("Failed to import schema: %v", err)
+		}
+		if err := schema.Validate("SKU-1"); err != nil {
+			t.Errorf("Expected valid SKU, got: %v", err)
+		}
+		if err := schema.Validate("nope"); err == nil {
+			t.Error("Expected registered format to be enforced")
+		}
+	})
+
+	t.Run("Invalid registrations panic", func(t *testing.T) {
+		for name, format := range map[string]interface{}{"": `^a$`, "test-bad-pattern": `(`, "test-bad-type": 42} {
+			func() {
+				defer func() {
+					if recover() == nil {
+						t.Errorf("Expected RegisterFormat(%q, %v) to panic", name, format)
+					}
+				}()
+				RegisterFormat(name, format)
+			}()
+		}
+	})
+}
+
+// TestNetworkFormats tests the built-in IP, CIDR, hostname, port, and MAC formats
+func TestNetworkFormats(t *testing.T) {
+	cases := []struct {
+		name    string
+		schema  RequiredStringBuilder
+		format  string
+		valid   []string
+		invalid []string
+	}{
+		{
+			name: "IPv4", schema: String().IPv4().Required(), format: "ipv4",
+			valid:   []string{"192.168.0.1", "0.0.0.0", "255.255.255.255"},
+			invalid: []string{"256.0.0.1", "192.168.0", "01.2.3.4", "::1", "192.168.0.1/24"},
+		},
+		{
+			name: "IPv6", schema: String().IPv6().Required(), format: "ipv6",
+			valid:   []string{"::1", "2001:db8::8a2e:370:7334", "::ffff:192.0.2.1"},
+			invalid: []string{"192.168.0.1", "2001:db8::g", "fe80::1%eth0", "2001:db8::/32"},
+		},
+		{
+			name: "CIDR", schema: String().CIDR().Required(), format: "cidr",
+			valid:   []string{"10.0.0.0/8", "192.168.1.0/24", "2001:db8::/32"},
+			invalid: []string{"10.0.0.0", "10.0.0.0/33", "2001:db8::/129", "10.0.0/8"},
+		},
+		{
+			name: "Hostname", schema: String().Hostname().Required(), format: "hostname",
+			valid:   []string{"localhost", "api.example.com", "node-1.internal", "1.example"},
+			invalid: []string{"-leading.example.com", "trailing-.example.com", "a..b", "under_score.com", strings.Repeat("a", 64) + ".com"},
+		},
+		{
+			name: "Port", schema: String().Port().Required(), format: "port",
+			valid:   []string{"1", "80", "8080", "65535"},
+			invalid: []string{"0", "65536", "080", "-1", "http"},
+		},
+		{
+			name: "MAC", schema: String().MAC().Required(), format: "mac",
+			valid:   []string{"00:1a:2b:3c:4d:5e", "00-1A-2B-3C-4D-5E", "02:00:5e:10:00:00:00:01"},
+			invalid: []string{"00:1a:2b:3c:4d", "0000.5e00.5301", "00:1a:2b:3c:4d:zz"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, value := range tc.valid {
+				if err := tc.schema.Validate(value); err != nil {
+					t.Errorf("Expected %q to be valid, got: %v", value, err)
+				}
+			}
+			for _, value := range tc.invalid {
+				if err := tc.schema.Validate(value); err == nil {
+					t.Errorf("Expected %q to be invalid", value)
+				}
+			}
+			if format := tc.schema.(goop.EnhancedSchema).ToOpenAPISchema().Format; format != tc.format {
+				t.Errorf("Expected format %q, got %q", tc.format, format)
+			}
+		})
+	}
+}
@@ -0,0 +1,947 @@
+package validators
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// FromJSONSchema builds a validator from an existing JSON Schema document, given as JSON or YAML.
+// It supports the validation keywords that have go-op equivalents:
+//   - type (including "null" and type lists), enum, const, and OpenAPI 3.0 nullable
+//   - minLength and maxLength (counted in characters), pattern, the x-max-bytes extension, and
+//     the email, uri, date-time, date, and uuid formats, plus formats added with RegisterFormat
+//   - minimum, maximum, exclusiveMinimum, exclusiveMaximum, and multipleOf
+//   - items, minItems, maxItems, uniqueItems, contains, minContains, and maxContains
+//   - properties, required, additionalProperties, minProperties, maxProperties, and dependentRequired
+//   - allOf, oneOf, anyOf, not, and $ref to local definitions, including recursive ones
+//   - the x-data-classification and x-pii-category extensions on strings and numbers
+//
+// Keywords that cannot be enforced, such as patternProperties or if/then/else, are reported as
+// errors rather than silently ignored. Annotations like title, description, and unknown formats
+// are ignored. As elsewhere in this package, empty strings are treated as missing values.
+//
+// Example:
+//
+//	schema, err := FromJSONSchema(legacyUserSchema)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	err = schema.Validate(requestData)
+func FromJSONSchema(doc []byte) (goop.Schema, error) {
+	root, err := decodeSchemaDocument(doc)
+	if err != nil {
+		return nil, err
+	}
+	importer := newSchemaImporter(root)
+	return importer.build(root, true, "#")
+}
+
+// FromOpenAPIComponents builds a validator for each schema in the components section of an
+// OpenAPI document, given as JSON or YAML. References between components are resolved.
+// See FromJSONSchema for the supported keywords.
+func FromOpenAPIComponents(doc []byte) (map[string]goop.Schema, error) {
+	root, err := decodeSchemaDocument(doc)
+	if err != nil {
+		return nil, err
+	}
+	rootMap, _ := root.(map[string]interface{})
+	components, _ := rootMap["components"].(map[string]interface{})
+	schemas, ok := components["schemas"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("document has no components.schemas section")
+	}
+
+	importer := newSchemaImporter(root)
+	result := make(map[string]goop.Schema, len(schemas))
+	for name := range schemas {
+		schema, err := importer.ref("#/components/schemas/"+escapePointerToken(name), true, "#/components/schemas/"+name)
+		if err != nil {
+			return nil, err
+		}
+		result[name] = schema
+	}
+	return result, nil
+}
+
+// decodeSchemaDocument parses a JSON or YAML document into generic JSON values
+func decodeSchemaDocument(doc []byte) (interface{}, error) {
+	var root interface{}
+	if json.Valid(doc) {
+		if err := json.Unmarshal(doc, &root); err != nil {
+			return nil, fmt.Errorf("invalid JSON schema document: %w", err)
+		}
+		return root, nil
+	}
+
+	if err := yaml.Unmarshal(doc, &root); err != nil {
+		return nil, fmt.Errorf("invalid schema document: %w", err)
+	}
+	// Round-trip through JSON so numbers and maps have the same types as JSON input
+	data, err := json.Marshal(root)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema document: %w", err)
+	}
+	root = nil
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("invalid schema document: %w", err)
+	}
+	return root, nil
+}
+
+// annotationKeywords carry no validation and are ignored on import
+var annotationKeywords = map[string]bool{
+	"$schema": true, "$id": true, "$comment": true, "$defs": true, "definitions": true,
+	"title": true, "description": true, "default": true, "examples": true, "example": true,
+	"deprecated": true, "readOnly": true, "writeOnly": true, "format": true, "nullable": true,
+	"discriminator": true, "xml": true, "externalDocs": true, "contentMediaType": true,
+	"contentEncoding": true,
+}
+
+// supportedKeywords are the validation keywords FromJSONSchema enforces
+var supportedKeywords = map[string]bool{
+	"$ref": true, "type": true, "enum": true, "const": true,
+	"minLength": true, "maxLength": true, "pattern": true,
+	"minimum": true, "maximum": true, "exclusiveMinimum": true, "exclusiveMaximum": true, "multipleOf": true,
+	"items": true, "prefixItems": true, "minItems": true, "maxItems": true, "uniqueItems": true, "contains": true,
+	"minContains": true, "maxContains": true,
+	"properties": true, "required": true, "additionalProperties": true, "minProperties": true, "maxProperties": true,
+	"dependentRequired": true,
+	"allOf":             true, "oneOf": true, "anyOf": true, "not": true,
+}
+
+// schemaImporter converts JSON Schema nodes into validators
+// Referenced schemas are built once per required state; references reached while their target
+// is still being built become lazy references, which lets recursive schemas terminate
+type schemaImporter struct {
+	root     interface{}
+	built    map[string]goop.Schema
+	building map[string]bool
+}
+
+func newSchemaImporter(root interface{}) *schemaImporter {
+	return &schemaImporter{
+		root:     root,
+		built:    make(map[string]goop.Schema),
+		building: make(map[string]bool),
+	}
+}
+
+// build converts a schema node; required controls whether missing values are rejected
+func (im *schemaImporter) build(node interface{}, required bool, path string) (goop.Schema, error) {
+	switch n := node.(type) {
+	case bool:
+		if n {
+			return &anySchema{required: required}, nil
+		}
+		return &falseSchema{}, nil
+	case map[string]interface{}:
+		return im.buildObject(n, required, path)
+	default:
+		return nil, fmt.Errorf("%s: schema must be an object or boolean", path)
+	}
+}
+
+func (im *schemaImporter) buildObject(node map[string]interface{}, required bool, path string) (goop.Schema, error) {
+	for keyword := range node {
+		if !supportedKeywords[keyword] && !annotationKeywords[keyword] && !strings.HasPrefix(keyword, "x-") {
+			return nil, fmt.Errorf("%s: unsupported keyword %q", path, keyword)
+		}
+	}
+
+	if ref, ok := node["$ref"].(string); ok {
+		return im.ref(ref, required, path)
+	}
+
+	types, err := schemaTypes(node, path)
+	if err != nil {
+		return nil, err
+	}
+	if nullable, _ := node["nullable"].(bool); nullable {
+		types = append(types, "null")
+	}
+	nonNull := make([]string, 0, len(types))
+	for _, t := range types {
+		if t == "null" {
+			required = false
+		} else {
+			nonNull = append(nonNull, t)
+		}
+	}
+
+	var parts []interface{}
+	switch {
+	case len(nonNull) == 1:
+		part, err := im.buildTyped(nonNull[0], node, required, path)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, part)
+	case len(nonNull) > 1:
+		alternatives := make([]interface{}, len(nonNull))
+		for i, t := range nonNull {
+			if alternatives[i], err = im.buildTyped(t, node, true, path); err != nil {
+				return nil, err
+			}
+		}
+		parts = append(parts, finalizeComposition(AnyOf(alternatives...), required))
+	case len(types) == 0:
+		if inferred := inferSchemaType(node); inferred != "" {
+			part, err := im.buildTyped(inferred, node, required, path)
+			if err != nil {
+				return nil, err
+			}
+			parts = append(parts, part)
+		}
+	}
+
+	for _, keyword := range []string{"allOf", "oneOf", "anyOf", "not"} {
+		value, ok := node[keyword]
+		if !ok {
+			continue
+		}
+		part, err := im.buildComposition(keyword, value, required, path+"/"+keyword)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, part)
+	}
+
+	switch len(parts) {
+	case 0:
+		if len(types) > 0 {
+			// Only "null" was allowed
+			return &nullSchema{}, nil
+		}
+		return &anySchema{required: required}, nil
+	case 1:
+		return parts[0].(goop.Schema), nil
+	default:
+		return finalizeComposition(AllOf(parts...), required), nil
+	}
+}
+
+// ref builds the schema a local JSON pointer reference points to
+func (im *schemaImporter) ref(ref string, required bool, path string) (goop.Schema, error) {
+	key := ref + "|" + strconv.FormatBool(required)
+	if schema, ok := im.built[key]; ok {
+		return schema, nil
+	}
+	if im.building[key] {
+		return &lazyRefSchema{importer: im, key: key, ref: ref, required: required}, nil
+	}
+
+	target, err := im.resolvePointer(ref)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	im.building[key] = true
+	schema, err := im.build(target, required, ref)
+	delete(im.building, key)
+	if err != nil {
+		return nil, err
+	}
+	im.built[key] = schema
+	return schema, nil
+}
+
+// resolvePointer looks up a local reference such as #/$defs/User
+func (im *schemaImporter) resolvePointer(ref string) (interface{}, error) {
+	if !strings.HasPrefix(ref, "#") {
+		return nil, fmt.Errorf("only local references are supported, got %q", ref)
+	}
+	node := im.root
+	pointer := strings.TrimPrefix(ref, "#")
+	if pointer == "" {
+		return node, nil
+	}
+	for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		switch n := node.(type) {
+		case map[string]interface{}:
+			next, ok := n[token]
+			if !ok {
+				return nil, fmt.Errorf("unresolved reference %q", ref)
+			}
+			node = next
+		case []interface{}:
+			index, err := strconv.Atoi(token)
+			if err != nil || index < 0 || index >= len(n) {
+				return nil, fmt.Errorf("unresolved reference %q", ref)
+			}
+			node = n[index]
+		default:
+			return nil, fmt.Errorf("unresolved reference %q", ref)
+		}
+	}
+	return node, nil
+}
+
+// buildComposition converts allOf, oneOf, anyOf, or not
+func (im *schemaImporter) buildComposition(keyword string, value interface{}, required bool, path string) (goop.Schema, error) {
+	if keyword == "not" {
+		schema, err := im.build(value, true, path)
+		if err != nil {
+			return nil, err
+		}
+		return finalizeComposition(Not(schema), required), nil
+	}
+
+	members, ok := value.([]interface{})
+	if !ok || len(members) == 0 {
+		return nil, fmt.Errorf("%s: must be a non-empty array", path)
+	}
+	schemas := make([]interface{}, len(members))
+	for i, member := range members {
+		schema, err := im.build(member, true, path+"/"+strconv.Itoa(i))
+		if err != nil {
+			return nil, err
+		}
+		schemas[i] = schema
+	}
+
+	switch keyword {
+	case "allOf":
+		return finalizeComposition(AllOf(schemas...), required), nil
+	case "oneOf":
+		return finalizeComposition(OneOf(schemas...), required), nil
+	default:
+		return finalizeComposition(AnyOf(schemas...), required), nil
+	}
+}
+
+// buildTyped converts the keywords that apply to a single JSON type
+func (im *schemaImporter) buildTyped(schemaType string, node map[string]interface{}, required bool, path string) (goop.Schema, error) {
+	switch schemaType {
+	case "string":
+		return im.buildString(node, required, path)
+	case "number", "integer":
+		return im.buildNumber(node, schemaType == "integer", required, path)
+	case "boolean":
+		return im.buildBool(node, required, path)
+	case "array":
+		return im.buildArray(node, required, path)
+	case "object":
+		return im.buildObjectType(node, required, path)
+	default:
+		return nil, fmt.Errorf("%s: unsupported type %q", path, schemaType)
+	}
+}
+
+func (im *schemaImporter) buildString(node map[string]interface{}, required bool, path string) (goop.Schema, error) {
+	builder := String()
+	// JSON Schema lengths count characters rather than bytes
+	if n, ok, err := intKeyword(node, "minLength", path); err != nil {
+		return nil, err
+	} else if ok {
+		builder = builder.MinRunes(n)
+	}
+	if n, ok, err := intKeyword(node, "maxLength", path); err != nil {
+		return nil, err
+	} else if ok {
+		builder = builder.MaxRunes(n)
+	}
+	// Min counts bytes, matching the x-min-bytes extension
+	if n, ok, err := intKeyword(node, "x-min-bytes", path); err != nil {
+		return nil, err
+	} else if ok {
+		builder = builder.Min(n)
+	}
+	if n, ok, err := intKeyword(node, "x-max-bytes", path); err != nil {
+		return nil, err
+	} else if ok {
+		builder = builder.MaxBytes(n)
+	}
+	if pattern, ok := node["pattern"].(string); ok {
+		builder = builder.Pattern(pattern)
+	}
+	switch classification, _ := node[goop.DataClassificationExtension].(string); classification {
+	case goop.ClassificationSensitive:
+		builder = builder.Sensitive()
+	case goop.ClassificationPII:
+		category, _ := node[goop.PIICategoryExtension].(string)
+		builder = builder.PII(category)
+	}
+
+	var checks []func(string) error
+	switch format, _ := node["format"].(string); format {
+	case "email":
+		builder = builder.Email()
+	case "uri", "url":
+		builder = builder.URL()
+	default:
+		// Built-in formats such as date-time and uuid, and formats added with RegisterFormat
+		if format != "" && IsRegisteredFormat(format) {
+			builder = builder.Format(format)
+		}
+	}
+
+	if c, ok := node["const"]; ok {
+		s, isString := c.(string)
+		if !isString {
+			return nil, fmt.Errorf("%s: const %v is not a string", path, c)
+		}
+		builder = builder.Const(s)
+	}
+	if check := enumCheck(node); check != nil {
+		checks = append(checks, func(s string) error { return check(s) })
+	}
+	if len(checks) > 0 {
+		builder = builder.Custom(func(s string) error {
+			for _, check := range checks {
+				if err := check(s); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+
+	if required {
+		return builder.Required(), nil
+	}
+	optional := builder.Optional()
+	if def, ok := node["default"].(string); ok {
+		optional = optional.Default(def)
+	}
+	return optional, nil
+}
+
+func (im *schemaImporter) buildNumber(node map[string]interface{}, integer, required bool, path string) (goop.Schema, error) {
+	builder := Number()
+	if integer {
+		builder = builder.Integer()
+	}
+
+	// Draft 4 expresses exclusive bounds as booleans modifying minimum and maximum
+	exclusiveMin, _ := node["exclusiveMinimum"].(bool)
+	exclusiveMax, _ := node["exclusiveMaximum"].(bool)
+	if v, ok, err := numberKeyword(node, "minimum", path); err != nil {
+		return nil, err
+	} else if ok && exclusiveMin {
+		builder = builder.ExclusiveMin(v)
+	} else if ok {
+		builder = builder.Min(v)
+	}
+	if v, ok, err := numberKeyword(node, "maximum", path); err != nil {
+		return nil, err
+	} else if ok && exclusiveMax {
+		builder = builder.ExclusiveMax(v)
+	} else if ok {
+		builder = builder.Max(v)
+	}
+	if v, ok := node["exclusiveMinimum"].(float64); ok {
+		builder = builder.ExclusiveMin(v)
+	}
+	if v, ok := node["exclusiveMaximum"].(float64); ok {
+		builder = builder.ExclusiveMax(v)
+	}
+	if v, ok, err := numberKeyword(node, "multipleOf", path); err != nil {
+		return nil, err
+	} else if ok {
+		builder = builder.MultipleOf(v)
+	}
+	if c, ok := node["const"]; ok {
+		f, isNumber := c.(float64)
+		if !isNumber {
+			return nil, fmt.Errorf("%s: const %v is not a number", path, c)
+		}
+		builder = builder.Const(f)
+	}
+	if check := enumCheck(node); check != nil {
+		builder = builder.Custom(func(f float64) error { return check(f) })
+	}
+	switch classification, _ := node[goop.DataClassificationExtension].(string); classification {
+	case goop.ClassificationSensitive:
+		builder = builder.Sensitive()
+	case goop.ClassificationPII:
+		category, _ := node[goop.PIICategoryExtension].(string)
+		builder = builder.PII(category)
+	}
+
+	if required {
+		return builder.Required(), nil
+	}
+	optional := builder.Optional()
+	if def, ok := node["default"].(float64); ok {
+		optional = optional.Default(def)
+	}
+	return optional, nil
+}
+
+func (im *schemaImporter) buildBool(node map[string]interface{}, required bool, path string) (goop.Schema, error) {
+	builder := Bool()
+	if c, ok := node["const"]; ok {
+		b, isBool := c.(bool)
+		if !isBool {
+			return nil, fmt.Errorf("%s: const %v is not a boolean", path, c)
+		}
+		builder = builder.Const(b)
+	}
+	if check := enumCheck(node); check != nil {
+		builder = builder.Custom(func(b bool) error { return check(b) })
+	}
+
+	if required {
+		return builder.Required(), nil
+	}
+	optional := builder.Optional()
+	if def, ok := node["default"].(bool); ok {
+		optional = optional.Default(def)
+	}
+	return optional, nil
+}
+
+func (im *schemaImporter) buildArray(node map[string]interface{}, required bool, path string) (goop.Schema, error) {
+	if err := rejectEnum(node, path, "array"); err != nil {
+		return nil, err
+	}
+
+	if _, ok := node["prefixItems"]; ok {
+		return im.buildTuple(node, required, path)
+	}
+
+	var items interface{}
+	if itemsNode, ok := node["items"]; ok {
+		if _, tuple := itemsNode.([]interface{}); tuple {
+			return nil, fmt.Errorf("%s/items: tuple validation is not supported", path)
+		}
+		schema, err := im.build(itemsNode, true, path+"/items")
+		if err != nil {
+			return nil, err
+		}
+		items = schema
+	}
+
+	builder := Array(items)
+	if n, ok, err := intKeyword(node, "minItems", path); err != nil {
+		return nil, err
+	} else if ok {
+		builder = builder.MinItems(n)
+	}
+	if n, ok, err := intKeyword(node, "maxItems", path); err != nil {
+		return nil, err
+	} else if ok {
+		builder = builder.MaxItems(n)
+	}
+	if unique, _ := node["uniqueItems"].(bool); unique {
+		builder = builder.UniqueItems()
+	}
+	if containsNode, ok := node["contains"]; ok {
+		contains, err := im.build(containsNode, true, path+"/contains")
+		if err != nil {
+			return nil, err
+		}
+		minContains, maxContains := 1, 0
+		if n, ok, err := intKeyword(node, "minContains", path); err != nil {
+			return nil, err
+		} else if ok {
+			minContains = n
+		}
+		if n, ok, err := intKeyword(node, "maxContains", path); err != nil {
+			return nil, err
+		} else if ok {
+			if n == 0 {
+				return nil, fmt.Errorf("%s: maxContains of 0 is not supported, use not with contains instead", path)
+			}
+			maxContains = n
+		}
+		builder = builder.Contains(contains, minContains, maxContains)
+	}
+
+	if required {
+		return builder.Required(), nil
+	}
+	return builder.Optional(), nil
+}
+
+// buildTuple converts prefixItems; positions before minItems are required, and items, which
+// JSON Schema allows by default, validates the items after them
+func (im *schemaImporter) buildTuple(node map[string]interface{}, required bool, path string) (goop.Schema, error) {
+	members, ok := node["prefixItems"].([]interface{})
+	if !ok || len(members) == 0 {
+		return nil, fmt.Errorf("%s/prefixItems: must be a non-empty array", path)
+	}
+	for _, keyword := range []string{"contains", "uniqueItems"} {
+		if _, ok := node[keyword]; ok {
+			return nil, fmt.Errorf("%s: %s is not supported with prefixItems", path, keyword)
+		}
+	}
+	minItems, _, err := intKeyword(node, "minItems", path)
+	if err != nil {
+		return nil, err
+	}
+	if minItems > len(members) {
+		return nil, fmt.Errorf("%s: minItems greater than the number of prefixItems is not supported", path)
+	}
+
+	positions := make([]interface{}, len(members))
+	for i, member := range members {
+		if positions[i], err = im.build(member, i < minItems, path+"/prefixItems/"+strconv.Itoa(i)); err != nil {
+			return nil, err
+		}
+	}
+
+	var rest interface{} = &anySchema{required: true}
+	if itemsNode, ok := node["items"]; ok {
+		if allowed, isBool := itemsNode.(bool); isBool && !allowed {
+			rest = nil
+		} else if rest, err = im.build(itemsNode, true, path+"/items"); err != nil {
+			return nil, err
+		}
+	}
+	if n, ok, err := intKeyword(node, "maxItems", path); err != nil {
+		return nil, err
+	} else if ok {
+		if n != len(members) {
+			return nil, fmt.Errorf("%s: maxItems with prefixItems is only supported when equal to the number of prefixItems", path)
+		}
+		rest = nil
+	}
+
+	builder := Tuple(positions...)
+	if rest != nil {
+		builder = builder.Rest(rest)
+	}
+	if required {
+		return builder.Required(), nil
+	}
+	return builder.Optional(), nil
+}
+
+func (im *schemaImporter) buildObjectType(node map[string]interface{}, required bool, path string) (goop.Schema, error) {
+	if _, ok := node["enum"]; ok {
+		return nil, fmt.Errorf("%s: enum is not supported for object schemas", path)
+	}
+
+	requiredFields := make(map[string]bool)
+	if list, ok := node["required"].([]interface{}); ok {
+		for _, name := range list {
+			if s, ok := name.(string); ok {
+				requiredFields[s] = true
+			}
+		}
+	}
+
+	fields := make(map[string]interface{})
+	properties, _ := node["properties"].(map[string]interface{})
+	for name, property := range properties {
+		schema, err := im.build(property, requiredFields[name], path+"/properties/"+escapePointerToken(name))
+		if err != nil {
+			return nil, err
+		}
+		fields[name] = schema
+	}
+	// Required names without a property definition only need to be present
+	for name := range requiredFields {
+		if _, ok := fields[name]; !ok {
+			fields[name] = &anySchema{required: true}
+		}
+	}
+	// dependentRequired names only need to be declared; their values are unconstrained
+	dependentRequired, _ := node["dependentRequired"].(map[string]interface{})
+	dependencies := make([]string, 0, len(dependentRequired))
+	for name := range dependentRequired {
+		dependencies = append(dependencies, name)
+	}
+	sort.Strings(dependencies)
+	dependents := make(map[string][]string, len(dependencies))
+	for _, name := range dependencies {
+		list, _ := dependentRequired[name].([]interface{})
+		for _, dependent := range list {
+			if s, ok := dependent.(string); ok {
+				dependents[name] = append(dependents[name], s)
+			}
+		}
+		for _, field := range append([]string{name}, dependents[name]...) {
+			if _, declared := fields[field]; !declared {
+				fields[field] = &anySchema{}
+			}
+		}
+	}
+
+	builder := Object(fields)
+	if c, ok := node["const"]; ok {
+		obj, isObject := c.(map[string]interface{})
+		if !isObject {
+			return nil, fmt.Errorf("%s: const %v is not an object", path, c)
+		}
+		builder = builder.Const(obj)
+	}
+	for _, name := range dependencies {
+		for _, dependent := range dependents[name] {
+			builder = builder.RequiredIf(dependent, name)
+		}
+	}
+	if n, ok, err := intKeyword(node, "minProperties", path); err != nil {
+		return nil, err
+	} else if ok {
+		builder = builder.MinProperties(n)
+	}
+	if n, ok, err := intKeyword(node, "maxProperties", path); err != nil {
+		return nil, err
+	} else if ok {
+		builder = builder.MaxProperties(n)
+	}
+
+	switch additional := node["additionalProperties"].(type) {
+	case bool:
+		if !additional {
+			builder = builder.Strict()
+		}
+	case map[string]interface{}:
+		schema, err := im.build(additional, true, path+"/additionalProperties")
+		if err != nil {
+			return nil, err
+		}
+		builder = builder.Custom(func(obj map[string]interface{}) error {
+			keys := make([]string, 0, len(obj))
+			for key := range obj {
+				if _, declared := fields[key]; !declared {
+					keys = append(keys, key)
+				}
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				if err := schema.Validate(obj[key]); err != nil {
+					fieldErr := goop.WithField(key, err)
+					return &fieldErr
+				}
+			}
+			return nil
+		})
+	}
+
+	if required {
+		return builder.Required(), nil
+	}
+	return builder.Optional(), nil
+}
+
+// schemaTypes returns the types listed by the type keyword
+func schemaTypes(node map[string]interface{}, path string) ([]string, error) {
+	switch t := node["type"].(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []string{t}, nil
+	case []interface{}:
+		types := make([]string, 0, len(t))
+		for _, item := range t {
+			s, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s/type: must contain strings", path)
+			}
+			types = append(types, s)
+		}
+		return types, nil
+	default:
+		return nil, fmt.Errorf("%s/type: must be a string or array", path)
+	}
+}
+
+// inferSchemaType guesses the type of an untyped schema from its keywords or enum values
+func inferSchemaType(node map[string]interface{}) string {
+	for _, keyword := range []string{"properties", "required", "additionalProperties", "minProperties", "maxProperties", "dependentRequired"} {
+		if _, ok := node[keyword]; ok {
+			return "object"
+		}
+	}
+	for _, keyword := range []string{"items", "prefixItems", "minItems", "maxItems", "uniqueItems", "contains", "minContains", "maxContains"} {
+		if _, ok := node[keyword]; ok {
+			return "array"
+		}
+	}
+	for _, keyword := range []string{"minLength", "maxLength", "pattern"} {
+		if _, ok := node[keyword]; ok {
+			return "string"
+		}
+	}
+	for _, keyword := range []string{"minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum", "multipleOf"} {
+		if _, ok := node[keyword]; ok {
+			return "number"
+		}
+	}
+
+	values, _ := node["enum"].([]interface{})
+	if c, ok := node["const"]; ok {
+		values = append(values, c)
+	}
+	inferred := ""
+	for _, value := range values {
+		var t string
+		switch value.(type) {
+		case string:
+			t = "string"
+		case float64:
+			t = "number"
+		case bool:
+			t = "boolean"
+		default:
+			return ""
+		}
+		if inferred != "" && inferred != t {
+			return ""
+		}
+		inferred = t
+	}
+	return inferred
+}
+
+// enumCheck returns a check for the enum and const keywords, or nil when neither is present
+func enumCheck(node map[string]interface{}) func(value interface{}) error {
+	values, hasEnum := node["enum"].([]interface{})
+	constValue, hasConst := node["const"]
+	if !hasEnum && !hasConst {
+		return nil
+	}
+	return func(value interface{}) error {
+		if hasConst && !reflect.DeepEqual(value, constValue) {
+			return goop.NewValidationError("", value, fmt.Sprintf("value must be exactly %v", constValue))
+		}
+		if hasEnum {
+			for _, allowed := range values {
+				if reflect.DeepEqual(value, allowed) {
+					return nil
+				}
+			}
+			return goop.NewValidationError("", value, fmt.Sprintf("value must be one of %v", values))
+		}
+		return nil
+	}
+}
+
+// rejectEnum reports enum and const on types where they cannot be enforced
+func rejectEnum(node map[string]interface{}, path, schemaType string) error {
+	for _, keyword := range []string{"enum", "const"} {
+		if _, ok := node[keyword]; ok {
+			return fmt.Errorf("%s: %s is not supported for %s schemas", path, keyword, schemaType)
+		}
+	}
+	return nil
+}
+
+// intKeyword reads a non-negative integer keyword
+func intKeyword(node map[string]interface{}, keyword, path string) (int, bool, error) {
+	value, ok := node[keyword]
+	if !ok {
+		return 0, false, nil
+	}
+	f, isNumber := value.(float64)
+	if !isNumber || f < 0 || f != float64(int(f)) {
+		return 0, false, fmt.Errorf("%s/%s: must be a non-negative integer", path, keyword)
+	}
+	return int(f), true, nil
+}
+
+// numberKeyword reads a numeric keyword
+func numberKeyword(node map[string]interface{}, keyword, path string) (float64, bool, error) {
+	value, ok := node[keyword]
+	if !ok {
+		return 0, false, nil
+	}
+	f, isNumber := value.(float64)
+	if !isNumber {
+		return 0, false, fmt.Errorf("%s/%s: must be a number", path, keyword)
+	}
+	return f, true, nil
+}
+
+// escapePointerToken escapes a name for use in a JSON pointer
+func escapePointerToken(name string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(name, "~", "~0"), "/", "~1")
+}
+
+// finalizeComposition transitions a composition builder to its required or optional state
+func finalizeComposition(builder CompositionBuilder, required bool) goop.Schema {
+	if required {
+		return builder.Required()
+	}
+	return builder.Optional()
+}
+
+// lazyRefSchema is a recursive reference resolved when it is first used
+type lazyRefSchema struct {
+	importer *schemaImporter
+	key      string
+	ref      string
+	required bool
+}
+
+func (l *lazyRefSchema) Validate(data interface{}) error {
+	return l.importer.built[l.key].Validate(data)
+}
+
+func (l *lazyRefSchema) ToOpenAPISchema() *goop.OpenAPISchema {
+	return &goop.OpenAPISchema{Ref: l.ref}
+}
+
+func (l *lazyRefSchema) GetValidationInfo() *goop.ValidationInfo {
+	return &goop.ValidationInfo{
+		Required:    l.required,
+		Optional:    !l.required,
+		Constraints: make(map[string]interface{}),
+	}
+}
+
+// falseSchema is the boolean schema false, which rejects every value
+type falseSchema struct{}
+
+func (f *falseSchema) Validate(data interface{}) error {
+	if data == nil {
+		return nil
+	}
+	return goop.NewValidationError("", data, "no value is allowed")
+}
+
+func (f *falseSchema) ToOpenAPISchema() *goop.OpenAPISchema {
+	return &goop.OpenAPISchema{Not: &goop.OpenAPISchema{}}
+}
+
+func (f *falseSchema) GetValidationInfo() *goop.ValidationInfo {
+	return &goop.ValidationInfo{Optional: true, Constraints: make(map[string]interface{})}
+}
+
+// nullSchema accepts only null
+type nullSchema struct{}
+
+func (n *nullSchema) Validate(data interface{}) error {
+	if data != nil {
+		return goop.NewValidationError("", data, "value must be null")
+	}
+	return nil
+}
+
+func (n *nullSchema) ToOpenAPISchema() *goop.OpenAPISchema {
+	return &goop.OpenAPISchema{Type: "null"}
+}
+
+func (n *nullSchema) GetValidationInfo() *goop.ValidationInfo {
+	return &goop.ValidationInfo{Optional: true, Constraints: make(map[string]interface{})}
+}
+
+// FromOpenAPISchema builds a validator from a schema taken from an OpenAPI document.
+// References to #/components/schemas are resolved against components, which may be nil.
+// See FromJSONSchema for the supported keywords.
+func FromOpenAPISchema(schema *goop.OpenAPISchema, components map[string]*goop.OpenAPISchema) (goop.Schema, error) {
+	data, err := json.Marshal(map[string]interface{}{
+		"schema":     schema,
+		"components": map[string]interface{}{"schemas": components},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI schema: %w", err)
+	}
+	root, err := decodeSchemaDocument(data)
+	if err != nil {
+		return nil, err
+	}
+	importer := newSchemaImporter(root)
+	return importer.build(root.(map[string]interface{})["schema"], true, "#")
+}
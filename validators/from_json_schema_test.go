@@ -0,0 +1,232 @@
+package validators
+
+import (
+	"strings"
+	"testing"
+
+	goop "github.com/picogrid/go-op"
+)
+
+func TestFromJSONSchema(t *testing.T) {
+	t.Run("Object with typed properties", func(t *testing.T) {
+		schema, err := FromJSONSchema([]byte(`{
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"type": "object",
+			"properties": {
+				"email": {"type": "string", "format": "email"},
+				"age": {"type": "integer", "minimum": 18},
+				"role": {"type": "string", "enum": ["admin", "member"]},
+				"tags": {"type": "array", "items": {"type": "string"}, "maxItems": 2},
+				"active": {"type": "boolean"}
+			},
+			"required": ["email", "age"],
+			"additionalProperties": false
+		}`))
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		valid := map[string]interface{}{"email": "ada@example.com", "age": 30.0, "role": "admin", "tags": []interface{}{"a"}, "active": true}
+		if err := schema.Validate(valid); err != nil {
+			t.Errorf("Expected valid data to pass, got %v", err)
+		}
+
+		invalid := map[string]map[string]interface{}{
+			"missing required field": {"email": "ada@example.com"},
+			"bad email":              {"email": "nope", "age": 30.0},
+			"non-integer":            {"email": "ada@example.com", "age": 30.5},
+			"below minimum":          {"email": "ada@example.com", "age": 17.0},
+			"not in enum":            {"email": "ada@example.com", "age": 30.0, "role": "owner"},
+			"too many items":         {"email": "ada@example.com", "age": 30.0, "tags": []interface{}{"a", "b", "c"}},
+			"unknown property":       {"email": "ada@example.com", "age": 30.0, "extra": 1.0},
+		}
+		for name, data := range invalid {
+			if err := schema.Validate(data); err == nil {
+				t.Errorf("Expected error for %s", name)
+			}
+		}
+	})
+
+	t.Run("YAML documents", func(t *testing.T) {
+		schema, err := FromJSONSchema([]byte("type: string\nminLength: 3\npattern: '^[a-z]+$'\n"))
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if err := schema.Validate("abc"); err != nil {
+			t.Errorf("Expected valid string, got %v", err)
+		}
+		if err := schema.Validate("ab"); err == nil {
+			t.Error("Expected error for short string")
+		}
+		if err := schema.Validate("ABC"); err == nil {
+			t.Error("Expected error for pattern mismatch")
+		}
+	})
+
+	t.Run("Nullable and type lists", func(t *testing.T) {
+		schema, err := FromJSONSchema([]byte(`{
+			"type": "object",
+			"properties": {
+				"nickname": {"type": ["string", "null"]},
+				"id": {"type": ["string", "integer"]}
+			},
+			"required": ["nickname", "id"]
+		}`))
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if err := schema.Validate(map[string]interface{}{"nickname": nil, "id": 7.0}); err != nil {
+			t.Errorf("Expected null nickname and integer id to pass, got %v", err)
+		}
+		if err := schema.Validate(map[string]interface{}{"nickname": "ada", "id": true}); err == nil {
+			t.Error("Expected error for id of the wrong type")
+		}
+	})
+
+	t.Run("Local and recursive references", func(t *testing.T) {
+		schema, err := FromJSONSchema([]byte(`{
+			"$ref": "#/$defs/node",
+			"$defs": {
+				"node": {
+					"type": "object",
+					"properties": {
+						"name": {"type": "string"},
+						"children": {"type": "array", "items": {"$ref": "#/$defs/node"}}
+					},
+					"required": ["name"]
+				}
+			}
+		}`))
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		tree := map[string]interface{}{
+			"name": "root",
+			"children": []interface{}{
+				map[string]interface{}{"name": "child", "children": []interface{}{}},
+			},
+		}
+		if err := schema.Validate(tree); err != nil {
+			t.Errorf("Expected valid tree, got %v", err)
+		}
+
+		tree["children"] = []interface{}{map[string]interface{}{"children": []interface{}{}}}
+		if err := schema.Validate(tree); err == nil {
+			t.Error("Expected error for nested node without a name")
+		}
+	})
+
+	t.Run("Composition", func(t *testing.T) {
+		schema, err := FromJSONSchema([]byte(`{
+			"oneOf": [
+				{"type": "string", "format": "uuid"},
+				{"type": "integer", "exclusiveMinimum": 0}
+			]
+		}`))
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if err := schema.Validate("6ba7b810-9dad-11d1-80b4-00c04fd430c8"); err != nil {
+			t.Errorf("Expected uuid to pass, got %v", err)
+		}
+		if err := schema.Validate(5.0); err != nil {
+			t.Errorf("Expected positive integer to pass, got %v", err)
+		}
+		if err := schema.Validate(0.0); err == nil {
+			t.Error("Expected error for zero")
+		}
+	})
+
+	t.Run("Schema-valued additionalProperties", func(t *testing.T) {
+		schema, err := FromJSONSchema([]byte(`{"type": "object", "additionalProperties": {"type": "number"}}`))
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if err := schema.Validate(map[string]interface{}{"a": 1.0}); err != nil {
+			t.Errorf("Expected numeric values to pass, got %v", err)
+		}
+		if err := schema.Validate(map[string]interface{}{"a": "x"}); err == nil {
+			t.Error("Expected error for non-numeric value")
+		}
+	})
+
+	t.Run("Rejects unsupported keywords", func(t *testing.T) {
+		_, err := FromJSONSchema([]byte(`{"type": "object", "patternProperties": {"^x": {"type": "string"}}}`))
+		if err == nil || !strings.Contains(err.Error(), "patternProperties") {
+			t.Errorf("Expected unsupported keyword error, got %v", err)
+		}
+	})
+
+	t.Run("Rejects unresolved references", func(t *testing.T) {
+		if _, err := FromJSONSchema([]byte(`{"$ref": "#/$defs/missing"}`)); err == nil {
+			t.Error("Expected error for unresolved reference")
+		}
+	})
+}
+
+func TestFromOpenAPIComponents(t *testing.T) {
+	components, err := FromOpenAPIComponents([]byte(`
+openapi: 3.0.3
+info:
+  title: Legacy
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Address:
+      type: object
+      properties:
+        city:
+          type: string
+      required: [city]
+    User:
+      type: object
+      properties:
+        name:
+          type: string
+          nullable: true
+        address:
+          $ref: '#/components/schemas/Address'
+      required: [name, address]
+`))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(components) != 2 {
+		t.Fatalf("Expected 2 components, got %d", len(components))
+	}
+
+	user := components["User"]
+	if err := user.Validate(map[string]interface{}{"name": nil, "address": map[string]interface{}{"city": "Paris"}}); err != nil {
+		t.Errorf("Expected valid user, got %v", err)
+	}
+	if err := user.Validate(map[string]interface{}{"name": "Ada", "address": map[string]interface{}{}}); err == nil {
+		t.Error("Expected error for address without city")
+	}
+
+	if _, err := FromOpenAPIComponents([]byte(`{"openapi": "3.1.0"}`)); err == nil {
+		t.Error("Expected error for document without components")
+	}
+}
+
+func TestFromOpenAPISchema(t *testing.T) {
+	address := Object(map[string]interface{}{
+		"city": String().Required(),
+	}).Required().(goop.EnhancedSchema).ToOpenAPISchema()
+	schema, err := FromOpenAPISchema(&goop.OpenAPISchema{
+		Type:       "object",
+		Properties: map[string]*goop.OpenAPISchema{"address": {Ref: "#/components/schemas/Address"}},
+		Required:   []string{"address"},
+	}, map[string]*goop.OpenAPISchema{"Address": address})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if err := schema.Validate(map[string]interface{}{"address": map[string]interface{}{"city": "Paris"}}); err != nil {
+		t.Errorf("Expected valid data, got %v", err)
+	}
+	if err := schema.Validate(map[string]interface{}{"address": map[string]interface{}{}}); err == nil {
+		t.Error("Expected error for address without city")
+	}
+}
@@ -0,0 +1,41 @@
+package validators
+
+import (
+	"testing"
+
+	goop "github.com/picogrid/go-op"
+)
+
+func TestGenerateFromValidators(t *testing.T) {
+	schema := Object(map[string]interface{}{
+		"username": String().Min(3).Max(20).Pattern("^[a-z][a-z0-9_]*$").Required(),
+		"email":    Email(),
+		"website":  String().URL().Optional(),
+		"age":      Number().Integer().Min(18).Max(120).Required(),
+		"score":    Number().ExclusiveMin(0).ExclusiveMax(1).Required(),
+		"tags":     Array(String().Min(1).Required()).MinItems(1).MaxItems(3).UniqueItems().Required(),
+		"status":   OneOf(String().Const("active").Required(), String().Const("banned").Required()).Required(),
+		"address": Object(map[string]interface{}{
+			"city": String().Min(2).Required(),
+		}).Optional(),
+		"ip":     String().IPv4().Required(),
+		"ip6":    String().IPv6().Required(),
+		"subnet": String().CIDR().Required(),
+		"host":   String().Hostname().Required(),
+		"port":   String().Port().Required(),
+		"mac":    String().MAC().Required(),
+		"avatar": String().Base64().MaxDecodedBytes(32).Required(),
+		"digest": String().Hex().MinDecodedBytes(16).MaxDecodedBytes(16).Required(),
+		"token":  String().JWTFormat().Required(),
+	}).Strict().Required()
+
+	for seed := int64(0); seed < 50; seed++ {
+		value, err := goop.Generate(schema, goop.WithSeed(seed))
+		if err != nil {
+			t.Fatalf("Seed %d: expected no error, got %v", seed, err)
+		}
+		if err := schema.Validate(value); err != nil {
+			t.Errorf("Seed %d: expected generated value %v to be valid, got %v", seed, value, err)
+		}
+	}
+}
@@ -0,0 +1,661 @@
+package validators
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// Core integer schema struct (unexported)
+// Values are checked as exact integers of a bit size rather than as float64, and the struct is
+// wrapped by sign- and state-specific types
+type integerSchema struct {
+	bits         int
+	unsigned     bool
+	minValue     *integerValue
+	maxValue     *integerValue
+	customFunc   func(integerValue) error
+	contextFunc  func(context.Context, integerValue) error
+	tenantLimit  string
+	extensions   []goop.SchemaExtension
+	required     bool
+	optional     bool
+	defaultValue interface{}
+	customError  map[string]string
+	example      interface{}
+	docs         schemaDocs
+}
+
+// Int creates a validator for Go int values, documented as type integer with format int64 on
+// 64-bit platforms. Whole float64 values, json.Number, and Go integer types are accepted when
+// they fit; fractions and out of range values are rejected.
+//
+// Example:
+//
+//	"quantity": validators.Int().Min(1).Max(1000).Required()
+func Int() IntBuilder {
+	return &intSchema{newInteger(strconv.IntSize, false)}
+}
+
+// Int32 creates a validator for 32-bit signed integers, documented with format int32
+func Int32() IntBuilder {
+	return &intSchema{newInteger(32, false)}
+}
+
+// Int64 creates a validator for 64-bit signed integers, documented with format int64.
+// json.Number values above 2^53 are checked exactly.
+func Int64() IntBuilder {
+	return &intSchema{newInteger(64, false)}
+}
+
+// Uint creates a validator for Go uint values, rejecting negative numbers
+// It is documented as type integer with minimum 0 and format uint64 on 64-bit platforms.
+func Uint() UintBuilder {
+	return &uintSchema{newInteger(strconv.IntSize, true)}
+}
+
+func newInteger(bits int, unsigned bool) *integerSchema {
+	return &integerSchema{
+		bits:        bits,
+		unsigned:    unsigned,
+		customError: make(map[string]string),
+	}
+}
+
+// State wrapper types for compile-time safety
+
+type intSchema struct {
+	*integerSchema
+}
+
+type requiredIntSchema struct {
+	*integerSchema
+}
+
+type optionalIntSchema struct {
+	*integerSchema
+}
+
+type uintSchema struct {
+	*integerSchema
+}
+
+type requiredUintSchema struct {
+	*integerSchema
+}
+
+type optionalUintSchema struct {
+	*integerSchema
+}
+
+// IntBuilder implementation
+
+func (i *intSchema) Min(value int64) IntBuilder {
+	i.setMin(signedValue(value))
+	return i
+}
+
+func (i *intSchema) Max(value int64) IntBuilder {
+	i.setMax(signedValue(value))
+	return i
+}
+
+func (i *intSchema) Custom(fn func(int64) error, extensions ...goop.SchemaExtension) IntBuilder {
+	i.extensions = append(i.extensions, extensions...)
+	i.setSignedCustom(fn)
+	return i
+}
+
+func (i *intSchema) CustomCtx(fn func(context.Context, int64) error) IntBuilder {
+	i.setSignedContext(fn)
+	return i
+}
+
+func (i *intSchema) Example(value interface{}) IntBuilder {
+	i.example = value
+	return i
+}
+
+func (i *intSchema) Required() RequiredIntBuilder {
+	i.required, i.optional = true, false
+	return &requiredIntSchema{i.integerSchema}
+}
+
+func (i *intSchema) Optional() OptionalIntBuilder {
+	i.optional, i.required = true, false
+	return &optionalIntSchema{i.integerSchema}
+}
+
+func (i *intSchema) WithMessage(validationType, message string) IntBuilder {
+	i.customError[validationType] = message
+	return i
+}
+
+func (i *intSchema) WithMinMessage(message string) IntBuilder {
+	return i.WithMessage(errorKeys.Min, message)
+}
+
+func (i *intSchema) WithMaxMessage(message string) IntBuilder {
+	return i.WithMessage(errorKeys.Max, message)
+}
+
+// RequiredIntBuilder implementation
+
+func (r *requiredIntSchema) Min(value int64) RequiredIntBuilder {
+	r.setMin(signedValue(value))
+	return r
+}
+
+func (r *requiredIntSchema) Max(value int64) RequiredIntBuilder {
+	r.setMax(signedValue(value))
+	return r
+}
+
+func (r *requiredIntSchema) Custom(fn func(int64) error, extensions ...goop.SchemaExtension) RequiredIntBuilder {
+	r.extensions = append(r.extensions, extensions...)
+	r.setSignedCustom(fn)
+	return r
+}
+
+func (r *requiredIntSchema) CustomCtx(fn func(context.Context, int64) error) RequiredIntBuilder {
+	r.setSignedContext(fn)
+	return r
+}
+
+func (r *requiredIntSchema) Example(value interface{}) RequiredIntBuilder {
+	r.example = value
+	return r
+}
+
+func (r *requiredIntSchema) WithMessage(validationType, message string) RequiredIntBuilder {
+	r.customError[validationType] = message
+	return r
+}
+
+func (r *requiredIntSchema) WithMinMessage(message string) RequiredIntBuilder {
+	return r.WithMessage(errorKeys.Min, message)
+}
+
+func (r *requiredIntSchema) WithMaxMessage(message string) RequiredIntBuilder {
+	return r.WithMessage(errorKeys.Max, message)
+}
+
+func (r *requiredIntSchema) WithRequiredMessage(message string) RequiredIntBuilder {
+	return r.WithMessage(errorKeys.Required, message)
+}
+
+// OptionalIntBuilder implementation
+
+func (o *optionalIntSchema) Min(value int64) OptionalIntBuilder {
+	o.setMin(signedValue(value))
+	return o
+}
+
+func (o *optionalIntSchema) Max(value int64) OptionalIntBuilder {
+	o.setMax(signedValue(value))
+	return o
+}
+
+func (o *optionalIntSchema) Custom(fn func(int64) error, extensions ...goop.SchemaExtension) OptionalIntBuilder {
+	o.extensions = append(o.extensions, extensions...)
+	o.setSignedCustom(fn)
+	return o
+}
+
+func (o *optionalIntSchema) CustomCtx(fn func(context.Context, int64) error) OptionalIntBuilder {
+	o.setSignedContext(fn)
+	return o
+}
+
+func (o *optionalIntSchema) Default(value int64) OptionalIntBuilder {
+	o.defaultValue = value
+	return o
+}
+
+func (o *optionalIntSchema) Example(value interface{}) OptionalIntBuilder {
+	o.example = value
+	return o
+}
+
+func (o *optionalIntSchema) WithMessage(validationType, message string) OptionalIntBuilder {
+	o.customError[validationType] = message
+	return o
+}
+
+func (o *optionalIntSchema) WithMinMessage(message string) OptionalIntBuilder {
+	return o.WithMessage(errorKeys.Min, message)
+}
+
+func (o *optionalIntSchema) WithMaxMessage(message string) OptionalIntBuilder {
+	return o.WithMessage(errorKeys.Max, message)
+}
+
+// UintBuilder implementation
+
+func (u *uintSchema) Min(value uint64) UintBuilder {
+	u.setMin(integerValue{magnitude: value})
+	return u
+}
+
+func (u *uintSchema) Max(value uint64) UintBuilder {
+	u.setMax(integerValue{magnitude: value})
+	return u
+}
+
+func (u *uintSchema) Custom(fn func(uint64) error, extensions ...goop.SchemaExtension) UintBuilder {
+	u.extensions = append(u.extensions, extensions...)
+	u.setUnsignedCustom(fn)
+	return u
+}
+
+func (u *uintSchema) CustomCtx(fn func(context.Context, uint64) error) UintBuilder {
+	u.setUnsignedContext(fn)
+	return u
+}
+
+func (u *uintSchema) Example(value interface{}) UintBuilder {
+	u.example = value
+	return u
+}
+
+func (u *uintSchema) Required() RequiredUintBuilder {
+	u.required, u.optional = true, false
+	return &requiredUintSchema{u.integerSchema}
+}
+
+func (u *uintSchema) Optional() OptionalUintBuilder {
+	u.optional, u.required = true, false
+	return &optionalUintSchema{u.integerSchema}
+}
+
+func (u *uintSchema) WithMessage(validationType, message string) UintBuilder {
+	u.customError[validationType] = message
+	return u
+}
+
+func (u *uintSchema) WithMinMessage(message string) UintBuilder {
+	return u.WithMessage(errorKeys.Min, message)
+}
+
+func (u *uintSchema) WithMaxMessage(message string) UintBuilder {
+	return u.WithMessage(errorKeys.Max, message)
+}
+
+// RequiredUintBuilder implementation
+
+func (r *requiredUintSchema) Min(value uint64) RequiredUintBuilder {
+	r.setMin(integerValue{magnitude: value})
+	return r
+}
+
+func (r *requiredUintSchema) Max(value uint64) RequiredUintBuilder {
+	r.setMax(integerValue{magnitude: value})
+	return r
+}
+
+func (r *requiredUintSchema) Custom(fn func(uint64) error, extensions ...goop.SchemaExtension) RequiredUintBuilder {
+	r.extensions = append(r.extensions, extensions...)
+	r.setUnsignedCustom(fn)
+	return r
+}
+
+func (r *requiredUintSchema) CustomCtx(fn func(context.Context, uint64) error) RequiredUintBuilder {
+	r.setUnsignedContext(fn)
+	return r
+}
+
+func (r *requiredUintSchema) Example(value interface{}) RequiredUintBuilder {
+	r.example = value
+	return r
+}
+
+func (r *requiredUintSchema) WithMessage(validationType, message string) RequiredUintBuilder {
+	r.customError[validationType] = message
+	return r
+}
+
+func (r *requiredUintSchema) WithMinMessage(message string) RequiredUintBuilder {
+	return r.WithMessage(errorKeys.Min, message)
+}
+
+func (r *requiredUintSchema) WithMaxMessage(message string) RequiredUintBuilder {
+	return r.WithMessage(errorKeys.Max, message)
+}
+
+func (r *requiredUintSchema) WithRequiredMessage(message string) RequiredUintBuilder {
+	return r.WithMessage(errorKeys.Required, message)
+}
+
+// OptionalUintBuilder implementation
+
+func (o *optionalUintSchema) Min(value uint64) OptionalUintBuilder {
+	o.setMin(integerValue{magnitude: value})
+	return o
+}
+
+func (o *optionalUintSchema) Max(value uint64) OptionalUintBuilder {
+	o.setMax(integerValue{magnitude: value})
+	return o
+}
+
+func (o *optionalUintSchema) Custom(fn func(uint64) error, extensions ...goop.SchemaExtension) OptionalUintBuilder {
+	o.extensions = append(o.extensions, extensions...)
+	o.setUnsignedCustom(fn)
+	return o
+}
+
+func (o *optionalUintSchema) CustomCtx(fn func(context.Context, uint64) error) OptionalUintBuilder {
+	o.setUnsignedContext(fn)
+	return o
+}
+
+func (o *optionalUintSchema) Default(value uint64) OptionalUintBuilder {
+	o.defaultValue = value
+	return o
+}
+
+func (o *optionalUintSchema) Example(value interface{}) OptionalUintBuilder {
+	o.example = value
+	return o
+}
+
+func (o *optionalUintSchema) WithMessage(validationType, message string) OptionalUintBuilder {
+	o.customError[validationType] = message
+	return o
+}
+
+func (o *optionalUintSchema) WithMinMessage(message string) OptionalUintBuilder {
+	return o.WithMessage(errorKeys.Min, message)
+}
+
+func (o *optionalUintSchema) WithMaxMessage(message string) OptionalUintBuilder {
+	return o.WithMessage(errorKeys.Max, message)
+}
+
+// Configuration helpers shared by the signed and unsigned builders
+
+func (s *integerSchema) setMin(value integerValue) {
+	s.minValue = &value
+}
+
+func (s *integerSchema) setMax(value integerValue) {
+	s.maxValue = &value
+}
+
+func (s *integerSchema) setSignedCustom(fn func(int64) error) {
+	s.customFunc = func(value integerValue) error { return fn(value.int64()) }
+}
+
+func (s *integerSchema) setUnsignedCustom(fn func(uint64) error) {
+	s.customFunc = func(value integerValue) error { return fn(value.magnitude) }
+}
+
+func (s *integerSchema) setSignedContext(fn func(context.Context, int64) error) {
+	s.contextFunc = func(ctx context.Context, value integerValue) error { return fn(ctx, value.int64()) }
+}
+
+func (s *integerSchema) setUnsignedContext(fn func(context.Context, uint64) error) {
+	s.contextFunc = func(ctx context.Context, value integerValue) error { return fn(ctx, value.magnitude) }
+}
+
+// typeName returns the Go type the schema's values fit, such as int32 or uint64
+func (s *integerSchema) typeName() string {
+	if s.unsigned {
+		return "uint" + strconv.Itoa(s.bits)
+	}
+	return "int" + strconv.Itoa(s.bits)
+}
+
+// limits returns the smallest and largest values of the schema's bit size
+func (s *integerSchema) limits() (integerValue, integerValue) {
+	if s.unsigned {
+		return integerValue{}, integerValue{magnitude: math.MaxUint64 >> (64 - s.bits)}
+	}
+	return integerValue{negative: true, magnitude: 1 << (s.bits - 1)}, integerValue{magnitude: 1<<(s.bits-1) - 1}
+}
+
+// Validate checks data as an exact integer; it is shared by every state so unfinalized builders validate as required
+// The success path performs no allocations; messages are only formatted once a rule fails
+func (s *integerSchema) Validate(data interface{}) error {
+	if data == nil {
+		if s.required {
+			return goop.NewValidationError("", nil, s.getErrorMessage(errorKeys.Required, "field is required"))
+		}
+		if s.defaultValue != nil {
+			return s.Validate(s.defaultValue)
+		}
+		if s.optional {
+			return nil
+		}
+		return goop.NewValidationError("", nil, s.getErrorMessage(errorKeys.Required, "field is required"))
+	}
+
+	value, err := s.convert(data)
+	if err != nil {
+		return err
+	}
+
+	if lowest, highest := s.limits(); value.cmp(lowest) < 0 || value.cmp(highest) > 0 {
+		return s.rangeError(data)
+	}
+	if s.minValue != nil && value.cmp(*s.minValue) < 0 {
+		return goop.NewValidationError(fmt.Sprintf("%v", data), data, s.getErrorMessage(errorKeys.Min,
+			fmt.Sprintf("value is too small, minimum is %s", s.minValue)))
+	}
+	if s.maxValue != nil && value.cmp(*s.maxValue) > 0 {
+		return goop.NewValidationError(fmt.Sprintf("%v", data), data, s.getErrorMessage(errorKeys.Max,
+			fmt.Sprintf("value is too large, maximum is %s", s.maxValue)))
+	}
+
+	if s.customFunc != nil {
+		if err := s.customFunc(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// convert reads data as an exact integer
+func (s *integerSchema) convert(data interface{}) (integerValue, error) {
+	switch v := data.(type) {
+	case int:
+		return signedValue(int64(v)), nil
+	case int8:
+		return signedValue(int64(v)), nil
+	case int16:
+		return signedValue(int64(v)), nil
+	case int32:
+		return signedValue(int64(v)), nil
+	case int64:
+		return signedValue(v), nil
+	case uint:
+		return integerValue{magnitude: uint64(v)}, nil
+	case uint8:
+		return integerValue{magnitude: uint64(v)}, nil
+	case uint16:
+		return integerValue{magnitude: uint64(v)}, nil
+	case uint32:
+		return integerValue{magnitude: uint64(v)}, nil
+	case uint64:
+		return integerValue{magnitude: v}, nil
+	case float32:
+		return s.fromFloat(float64(v), data)
+	case float64:
+		return s.fromFloat(v, data)
+	case json.Number:
+		if i, err := strconv.ParseInt(string(v), 10, 64); err == nil {
+			return signedValue(i), nil
+		}
+		if u, err := strconv.ParseUint(string(v), 10, 64); err == nil {
+			return integerValue{magnitude: u}, nil
+		}
+		// Fractions, exponents, and integers beyond 64 bits
+		if f, err := strconv.ParseFloat(string(v), 64); err == nil || math.IsInf(f, 0) {
+			return s.fromFloat(f, data)
+		}
+	}
+	return integerValue{}, goop.NewValidationError(fmt.Sprintf("%v", data), data,
+		s.getErrorMessage(errorKeys.Type, "invalid type, expected integer"))
+}
+
+// fromFloat converts a whole float, rejecting fractions and values beyond 64 bits
+func (s *integerSchema) fromFloat(f float64, data interface{}) (integerValue, error) {
+	switch {
+	case math.IsNaN(f):
+		return integerValue{}, goop.NewValidationError(fmt.Sprintf("%v", data), data,
+			s.getErrorMessage(errorKeys.Type, "invalid type, expected integer"))
+	case f != math.Trunc(f):
+		return integerValue{}, goop.NewValidationError(fmt.Sprintf("%v", data), data,
+			s.getErrorMessage(errorKeys.Integer, "value must be an integer"))
+	case math.Abs(f) >= 1<<64:
+		return integerValue{}, s.rangeError(data)
+	case f < 0:
+		return integerValue{negative: true, magnitude: uint64(-f)}, nil
+	default:
+		return integerValue{magnitude: uint64(f)}, nil
+	}
+}
+
+func (s *integerSchema) rangeError(data interface{}) error {
+	return goop.NewValidationError(fmt.Sprintf("%v", data), data,
+		s.getErrorMessage(errorKeys.Integer, fmt.Sprintf("value is out of range for %s", s.typeName())))
+}
+
+// Helper methods (unexported)
+func (s *integerSchema) getErrorMessage(validationType, defaultMessage string) string {
+	if msg, exists := s.customError[validationType]; exists {
+		return msg
+	}
+	return defaultMessage
+}
+
+// ToOpenAPISchema documents the schema as an integer with the format of its bit size
+// Ranges that the format does not imply, such as the minimum of unsigned integers, are added as bounds.
+func (s *integerSchema) ToOpenAPISchema() *goop.OpenAPISchema {
+	schema := &goop.OpenAPISchema{Type: "integer", Format: "int64"}
+	switch {
+	case s.unsigned && s.bits == 64:
+		schema.Format = "uint64"
+	case s.bits < 32 || (s.bits == 32 && !s.unsigned):
+		schema.Format = "int32"
+	}
+
+	lowest, highest := s.limits()
+	minValue, maxValue := s.minValue, s.maxValue
+	if minValue == nil && (s.unsigned || s.bits < 32) {
+		minValue = &lowest
+	}
+	if maxValue == nil && s.bits < 64 && (s.unsigned || s.bits < 32) {
+		maxValue = &highest
+	}
+	if minValue != nil {
+		minimum := minValue.float64()
+		schema.Minimum = &minimum
+	}
+	if maxValue != nil {
+		maximum := maxValue.float64()
+		schema.Maximum = &maximum
+	}
+
+	if s.defaultValue != nil {
+		schema.Default = s.defaultValue
+	}
+	if s.example != nil {
+		schema.Example = s.example
+	}
+	s.docs.apply(schema)
+	// Add extensions given to Custom
+	addSchemaExtensions(schema, s.extensions)
+
+	return schema
+}
+
+// GetValidationInfo returns metadata about the integer validation configuration
+func (s *integerSchema) GetValidationInfo() *goop.ValidationInfo {
+	info := &goop.ValidationInfo{
+		Required:     s.required,
+		Optional:     s.optional,
+		HasDefault:   s.defaultValue != nil,
+		DefaultValue: s.defaultValue,
+		Constraints:  map[string]interface{}{"type": s.typeName()},
+	}
+	if s.minValue != nil {
+		info.Constraints["minimum"] = s.minValue.String()
+	}
+	if s.maxValue != nil {
+		info.Constraints["maximum"] = s.maxValue.String()
+	}
+	return info
+}
+
+// integerValue is an integer of up to 64 bits of either sign, held as a sign and magnitude
+type integerValue struct {
+	negative  bool
+	magnitude uint64
+}
+
+func signedValue(i int64) integerValue {
+	if i < 0 {
+		// Computed as -(i+1)+1 so that math.MinInt64 does not overflow
+		return integerValue{negative: true, magnitude: uint64(-(i + 1)) + 1}
+	}
+	return integerValue{magnitude: uint64(i)}
+}
+
+// cmp returns -1, 0, or 1 as v is less than, equal to, or greater than other
+func (v integerValue) cmp(other integerValue) int {
+	if v.negative != other.negative && (v.magnitude != 0 || other.magnitude != 0) {
+		if v.negative {
+			return -1
+		}
+		return 1
+	}
+	switch {
+	case v.magnitude == other.magnitude:
+		return 0
+	case (v.magnitude < other.magnitude) != v.negative:
+		return -1
+	default:
+		return 1
+	}
+}
+
+// int64 returns the value as an int64; it is only called for values within the signed limits
+func (v integerValue) int64() int64 {
+	if v.negative {
+		return -int64(v.magnitude-1) - 1
+	}
+	return int64(v.magnitude)
+}
+
+func (v integerValue) float64() float64 {
+	if v.negative {
+		return -float64(v.magnitude)
+	}
+	return float64(v.magnitude)
+}
+
+func (v integerValue) String() string {
+	if v.negative && v.magnitude != 0 {
+		return "-" + strconv.FormatUint(v.magnitude, 10)
+	}
+	return strconv.FormatUint(v.magnitude, 10)
+}
+
+// Interface compliance checks at compile time
+var (
+	_ IntBuilder          = (*intSchema)(nil)
+	_ RequiredIntBuilder  = (*requiredIntSchema)(nil)
+	_ OptionalIntBuilder  = (*optionalIntSchema)(nil)
+	_ UintBuilder         = (*uintSchema)(nil)
+	_ RequiredUintBuilder = (*requiredUintSchema)(nil)
+	_ OptionalUintBuilder = (*optionalUintSchema)(nil)
+	_ goop.EnhancedSchema = (*requiredIntSchema)(nil)
+	_ goop.EnhancedSchema = (*optionalIntSchema)(nil)
+	_ goop.EnhancedSchema = (*requiredUintSchema)(nil)
+	_ goop.EnhancedSchema = (*optionalUintSchema)(nil)
+)
@@ -0,0 +1,177 @@
+package validators
+
+import (
+	"context"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// IntBuilder represents the initial signed integer builder state, shared by Int, Int32, and Int64.
+// Values must be whole numbers that fit the builder's bit size.
+type IntBuilder interface {
+	// Configuration methods - these return IntBuilder to allow chaining
+	Min(value int64) IntBuilder
+	Max(value int64) IntBuilder
+	Custom(fn func(int64) error, extensions ...goop.SchemaExtension) IntBuilder
+	CustomCtx(fn func(context.Context, int64) error) IntBuilder // Runs after validation with the request context
+	// Sensitive and PII classify the value, which is then masked in recordings and logs
+	Sensitive() IntBuilder
+	PII(category string) IntBuilder
+	// VisibleToScopes strips the field from responses to callers without any of scopes
+	VisibleToScopes(scopes ...string) IntBuilder
+	// MaxFromTenant bounds the value by a limit of the request's tenant
+	MaxFromTenant(limit string) IntBuilder
+
+	// Example and documentation methods for OpenAPI
+	Example(value interface{}) IntBuilder
+	Title(title string) IntBuilder
+	Description(description string) IntBuilder
+
+	// State transition methods - these change the type to prevent invalid chaining
+	Required() RequiredIntBuilder
+	Optional() OptionalIntBuilder
+
+	// Error message configuration methods
+	WithMessage(validationType, message string) IntBuilder
+	WithMinMessage(message string) IntBuilder
+	WithMaxMessage(message string) IntBuilder
+}
+
+// RequiredIntBuilder represents a signed integer builder in the required state.
+type RequiredIntBuilder interface {
+	Min(value int64) RequiredIntBuilder
+	Max(value int64) RequiredIntBuilder
+	Custom(fn func(int64) error, extensions ...goop.SchemaExtension) RequiredIntBuilder
+	CustomCtx(fn func(context.Context, int64) error) RequiredIntBuilder
+	Sensitive() RequiredIntBuilder
+	PII(category string) RequiredIntBuilder
+	VisibleToScopes(scopes ...string) RequiredIntBuilder
+	MaxFromTenant(limit string) RequiredIntBuilder
+	Example(value interface{}) RequiredIntBuilder
+	Title(title string) RequiredIntBuilder
+	Description(description string) RequiredIntBuilder
+
+	WithMessage(validationType, message string) RequiredIntBuilder
+	WithMinMessage(message string) RequiredIntBuilder
+	WithMaxMessage(message string) RequiredIntBuilder
+	WithRequiredMessage(message string) RequiredIntBuilder
+
+	// JSON Schema export
+	ToJSONSchema() ([]byte, error)
+
+	// Validation method - final step in the builder chain
+	Validate(data interface{}) error
+	ValidateCtx(ctx context.Context, data interface{}) error
+}
+
+// OptionalIntBuilder represents a signed integer builder in the optional state.
+type OptionalIntBuilder interface {
+	Min(value int64) OptionalIntBuilder
+	Max(value int64) OptionalIntBuilder
+	Custom(fn func(int64) error, extensions ...goop.SchemaExtension) OptionalIntBuilder
+	CustomCtx(fn func(context.Context, int64) error) OptionalIntBuilder
+	Sensitive() OptionalIntBuilder
+	PII(category string) OptionalIntBuilder
+	VisibleToScopes(scopes ...string) OptionalIntBuilder
+	MaxFromTenant(limit string) OptionalIntBuilder
+	Default(value int64) OptionalIntBuilder
+	Example(value interface{}) OptionalIntBuilder
+	Title(title string) OptionalIntBuilder
+	Description(description string) OptionalIntBuilder
+
+	WithMessage(validationType, message string) OptionalIntBuilder
+	WithMinMessage(message string) OptionalIntBuilder
+	WithMaxMessage(message string) OptionalIntBuilder
+
+	// JSON Schema export
+	ToJSONSchema() ([]byte, error)
+
+	// Validation method - final step in the builder chain
+	Validate(data interface{}) error
+	ValidateCtx(ctx context.Context, data interface{}) error
+}
+
+// UintBuilder represents the initial unsigned integer builder state.
+// Values must be whole numbers from 0 up to the builder's bit size.
+type UintBuilder interface {
+	// Configuration methods - these return UintBuilder to allow chaining
+	Min(value uint64) UintBuilder
+	Max(value uint64) UintBuilder
+	Custom(fn func(uint64) error, extensions ...goop.SchemaExtension) UintBuilder
+	CustomCtx(fn func(context.Context, uint64) error) UintBuilder // Runs after validation with the request context
+	// Sensitive and PII classify the value, which is then masked in recordings and logs
+	Sensitive() UintBuilder
+	PII(category string) UintBuilder
+	// VisibleToScopes strips the field from responses to callers without any of scopes
+	VisibleToScopes(scopes ...string) UintBuilder
+	// MaxFromTenant bounds the value by a limit of the request's tenant
+	MaxFromTenant(limit string) UintBuilder
+
+	// Example and documentation methods for OpenAPI
+	Example(value interface{}) UintBuilder
+	Title(title string) UintBuilder
+	Description(description string) UintBuilder
+
+	// State transition methods - these change the type to prevent invalid chaining
+	Required() RequiredUintBuilder
+	Optional() OptionalUintBuilder
+
+	// Error message configuration methods
+	WithMessage(validationType, message string) UintBuilder
+	WithMinMessage(message string) UintBuilder
+	WithMaxMessage(message string) UintBuilder
+}
+
+// RequiredUintBuilder represents an unsigned integer builder in the required state.
+type RequiredUintBuilder interface {
+	Min(value uint64) RequiredUintBuilder
+	Max(value uint64) RequiredUintBuilder
+	Custom(fn func(uint64) error, extensions ...goop.SchemaExtension) RequiredUintBuilder
+	CustomCtx(fn func(context.Context, uint64) error) RequiredUintBuilder
+	Sensitive() RequiredUintBuilder
+	PII(category string) RequiredUintBuilder
+	VisibleToScopes(scopes ...string) RequiredUintBuilder
+	MaxFromTenant(limit string) RequiredUintBuilder
+	Example(value interface{}) RequiredUintBuilder
+	Title(title string) RequiredUintBuilder
+	Description(description string) RequiredUintBuilder
+
+	WithMessage(validationType, message string) RequiredUintBuilder
+	WithMinMessage(message string) RequiredUintBuilder
+	WithMaxMessage(message string) RequiredUintBuilder
+	WithRequiredMessage(message string) RequiredUintBuilder
+
+	// JSON Schema export
+	ToJSONSchema() ([]byte, error)
+
+	// Validation method - final step in the builder chain
+	Validate(data interface{}) error
+	ValidateCtx(ctx context.Context, data interface{}) error
+}
+
+// OptionalUintBuilder represents an unsigned integer builder in the optional state.
+type OptionalUintBuilder interface {
+	Min(value uint64) OptionalUintBuilder
+	Max(value uint64) OptionalUintBuilder
+	Custom(fn func(uint64) error, extensions ...goop.SchemaExtension) OptionalUintBuilder
+	CustomCtx(fn func(context.Context, uint64) error) OptionalUintBuilder
+	Sensitive() OptionalUintBuilder
+	PII(category string) OptionalUintBuilder
+	VisibleToScopes(scopes ...string) OptionalUintBuilder
+	MaxFromTenant(limit string) OptionalUintBuilder
+	Default(value uint64) OptionalUintBuilder
+	Example(value interface{}) OptionalUintBuilder
+	Title(title string) OptionalUintBuilder
+	Description(description string) OptionalUintBuilder
+
+	WithMessage(validationType, message string) OptionalUintBuilder
+	WithMinMessage(message string) OptionalUintBuilder
+	WithMaxMessage(message string) OptionalUintBuilder
+
+	// JSON Schema export
+	ToJSONSchema() ([]byte, error)
+
+	// Validation method - final step in the builder chain
+	Validate(data interface{}) error
+	ValidateCtx(ctx context.Context, data interface{}) error
+}
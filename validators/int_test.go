@@ -0,0 +1,121 @@
+package validators
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// TestIntegerBuilders tests bit-size and sign limits of Int, Int32, Int64, and Uint
+func TestIntegerBuilders(t *testing.T) {
+	cases := []struct {
+		name    string
+		schema  goop.Schema
+		valid   []interface{}
+		invalid map[string]interface{}
+	}{
+		{
+			name:   "Int32",
+			schema: Int32().Required(),
+			valid:  []interface{}{0, int64(math.MaxInt32), float64(math.MinInt32), json.Number("-7")},
+			invalid: map[string]interface{}{
+				"out of range for int32":         float64(math.MaxInt32 + 1),
+				"value must be an integer":       1.5,
+				"invalid type, expected integer": "12",
+			},
+		},
+		{
+			name:   "Int64",
+			schema: Int64().Required(),
+			valid:  []interface{}{json.Number("9223372036854775807"), int64(math.MinInt64)},
+			invalid: map[string]interface{}{
+				"out of range for int64":   json.Number("9223372036854775808"),
+				"value must be an integer": json.Number("1.25"),
+			},
+		},
+		{
+			name:   "Uint",
+			schema: Uint().Required(),
+			valid:  []interface{}{0, uint64(math.MaxUint64), json.Number("18446744073709551615")},
+			invalid: map[string]interface{}{
+				"out of range for uint64": -1,
+				"field is required":       nil,
+			},
+		},
+		{
+			name:   "Bounds",
+			schema: Int().Min(-5).Max(5).Required(),
+			valid:  []interface{}{-5, 5.0},
+			invalid: map[string]interface{}{
+				"value is too small, minimum is -5": -6,
+				"value is too large, maximum is 5":  json.Number("6"),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		for _, data := range tc.valid {
+			if err := tc.schema.Validate(data); err != nil {
+				t.Errorf("%s: expected %v to be valid, got: %v", tc.name, data, err)
+			}
+		}
+		for message, data := range tc.invalid {
+			if err := tc.schema.Validate(data); err == nil || !strings.Contains(err.Error(), message) {
+				t.Errorf("%s: expected %v to fail with %q, got: %v", tc.name, data, message, err)
+			}
+		}
+	}
+
+	t.Run("Custom and default", func(t *testing.T) {
+		even := Int().Custom(func(n int64) error {
+			if n%2 != 0 {
+				return goop.NewValidationError("", n, "must be even")
+			}
+			return nil
+		}).Optional().Default(4)
+		if err := even.Validate(nil); err != nil {
+			t.Errorf("Expected the default to be valid, got: %v", err)
+		}
+		if err := even.Validate(3.0); err == nil || !strings.Contains(err.Error(), "must be even") {
+			t.Errorf("Expected custom error, got: %v", err)
+		}
+	})
+
+	t.Run("OpenAPI", func(t *testing.T) {
+		spec := Int32().Required().(goop.EnhancedSchema).ToOpenAPISchema()
+		if spec.Type != "integer" || spec.Format != "int32" || spec.Minimum != nil {
+			t.Errorf("Expected an int32 integer without bounds, got %+v", spec)
+		}
+		spec = Uint().Max(10).Required().(goop.EnhancedSchema).ToOpenAPISchema()
+		if spec.Format != "uint64" || spec.Minimum == nil || *spec.Minimum != 0 || *spec.Maximum != 10 {
+			t.Errorf("Expected a uint64 integer from 0 to 10, got %+v", spec)
+		}
+	})
+}
+
+// TestAutoFieldsIntegers tests that derived integer fields reject values their Go type cannot hold
+func TestAutoFieldsIntegers(t *testing.T) {
+	type reading struct {
+		Level int8   `json:"level"`
+		Count uint32 `json:"count"`
+	}
+	schema := ForStruct[reading]().AutoFields().Required().Build()
+
+	result, err := ValidateStruct[reading](schema, map[string]interface{}{"level": -3.0, "count": 7.0})
+	if err != nil {
+		t.Fatalf("Expected reading to be valid, got: %v", err)
+	}
+	if result.Level != -3 || result.Count != 7 {
+		t.Errorf("Expected typed values, got %+v", result)
+	}
+
+	if _, err := ValidateStruct[reading](schema, map[string]interface{}{"level": 200.0, "count": 7.0}); err == nil || !strings.Contains(err.Error(), "out of range for int8") {
+		t.Errorf("Expected an int8 range error, got: %v", err)
+	}
+	if _, err := ValidateStruct[reading](schema, map[string]interface{}{"level": 1.0, "count": -1.0}); err == nil || !strings.Contains(err.Error(), "out of range for uint32") {
+		t.Errorf("Expected a uint32 range error, got: %v", err)
+	}
+}
@@ -9,12 +9,18 @@ var errorKeys = struct {
 	Custom   string
 
 	// String validation errors
-	MinLength string
-	MaxLength string
-	Pattern   string
-	Email     string
-	URL       string
-	Const     string
+	MinLength   string
+	MaxLength   string
+	Pattern     string
+	Email       string
+	EmailDomain string
+	URL         string
+	Format      string
+	Const       string
+	Enum        string
+	Encoding    string
+	DecodedSize string
+	MaxBytes    string
 
 	// Number validation errors
 	Min          string
@@ -25,12 +31,14 @@ var errorKeys = struct {
 	Integer      string
 	Positive     string
 	Negative     string
+	Precision    string
 
 	// Array validation errors
 	MinItems    string
 	MaxItems    string
 	Contains    string
 	UniqueItems string
+	UniqueBy    string
 
 	// Object validation errors
 	UnknownKey    string
@@ -38,9 +46,15 @@ var errorKeys = struct {
 	InvalidShape  string
 	MinProperties string
 	MaxProperties string
+	RequiredIf    string
 
 	// Boolean validation errors
 	InvalidBoolean string
+
+	// Password validation errors
+	Entropy  string
+	Classes  string
+	DenyList string
 }{
 	// Common
 	Required: "required",
@@ -48,12 +62,18 @@ var errorKeys = struct {
 	Custom:   "custom",
 
 	// String
-	MinLength: "minLength",
-	MaxLength: "maxLength",
-	Pattern:   "pattern",
-	Email:     "email",
-	URL:       "url",
-	Const:     "const",
+	MinLength:   "minLength",
+	MaxLength:   "maxLength",
+	Pattern:     "pattern",
+	Email:       "email",
+	EmailDomain: "emailDomain",
+	URL:         "url",
+	Format:      "format",
+	Const:       "const",
+	Enum:        "enum",
+	Encoding:    "encoding",
+	DecodedSize: "decodedSize",
+	MaxBytes:    "maxBytes",
 
 	// Number
 	Min:          "min",
@@ -64,12 +84,14 @@ var errorKeys = struct {
 	Integer:      "integer",
 	Positive:     "positive",
 	Negative:     "negative",
+	Precision:    "precision",
 
 	// Array
 	MinItems:    "minItems",
 	MaxItems:    "maxItems",
 	Contains:    "contains",
 	UniqueItems: "uniqueItems",
+	UniqueBy:    "uniqueBy",
 
 	// Object
 	UnknownKey:    "unknownKey",
@@ -77,9 +99,15 @@ var errorKeys = struct {
 	InvalidShape:  "invalidShape",
 	MinProperties: "minProperties",
 	MaxProperties: "maxProperties",
+	RequiredIf:    "requiredIf",
 
 	// Boolean
 	InvalidBoolean: "invalidBoolean",
+
+	// Password
+	Entropy:  "entropy",
+	Classes:  "classes",
+	DenyList: "denyList",
 }
 
 // ErrorKeys provides autocompletion for error keys.
@@ -92,12 +120,18 @@ func (ErrorKeys) Type() string     { return errorKeys.Type }
 func (ErrorKeys) Custom() string   { return errorKeys.Custom }
 
 // String-specific error keys
-func (ErrorKeys) MinLength() string { return errorKeys.MinLength }
-func (ErrorKeys) MaxLength() string { return errorKeys.MaxLength }
-func (ErrorKeys) Pattern() string   { return errorKeys.Pattern }
-func (ErrorKeys) Email() string     { return errorKeys.Email }
-func (ErrorKeys) URL() string       { return errorKeys.URL }
-func (ErrorKeys) Const() string     { return errorKeys.Const }
+func (ErrorKeys) MinLength() string   { return errorKeys.MinLength }
+func (ErrorKeys) MaxLength() string   { return errorKeys.MaxLength }
+func (ErrorKeys) Pattern() string     { return errorKeys.Pattern }
+func (ErrorKeys) Email() string       { return errorKeys.Email }
+func (ErrorKeys) EmailDomain() string { return errorKeys.EmailDomain }
+func (ErrorKeys) URL() string         { return errorKeys.URL }
+func (ErrorKeys) Format() string      { return errorKeys.Format }
+func (ErrorKeys) Const() string       { return errorKeys.Const }
+func (ErrorKeys) Enum() string        { return errorKeys.Enum }
+func (ErrorKeys) Encoding() string    { return errorKeys.Encoding }
+func (ErrorKeys) DecodedSize() string { return errorKeys.DecodedSize }
+func (ErrorKeys) MaxBytes() string    { return errorKeys.MaxBytes }
 
 // Number-specific error keys
 func (ErrorKeys) Min() string          { return errorKeys.Min }
@@ -108,12 +142,14 @@ func (ErrorKeys) MultipleOf() string   { return errorKeys.MultipleOf }
 func (ErrorKeys) Integer() string      { return errorKeys.Integer }
 func (ErrorKeys) Positive() string     { return errorKeys.Positive }
 func (ErrorKeys) Negative() string     { return errorKeys.Negative }
+func (ErrorKeys) Precision() string    { return errorKeys.Precision }
 
 // Array-specific error keys
 func (ErrorKeys) MinItems() string    { return errorKeys.MinItems }
 func (ErrorKeys) MaxItems() string    { return errorKeys.MaxItems }
 func (ErrorKeys) Contains() string    { return errorKeys.Contains }
 func (ErrorKeys) UniqueItems() string { return errorKeys.UniqueItems }
+func (ErrorKeys) UniqueBy() string    { return errorKeys.UniqueBy }
 
 // Object-specific error keys
 func (ErrorKeys) UnknownKey() string    { return errorKeys.UnknownKey }
@@ -121,10 +157,16 @@ func (ErrorKeys) MissingKey() string    { return errorKeys.MissingKey }
 func (ErrorKeys) InvalidShape() string  { return errorKeys.InvalidShape }
 func (ErrorKeys) MinProperties() string { return errorKeys.MinProperties }
 func (ErrorKeys) MaxProperties() string { return errorKeys.MaxProperties }
+func (ErrorKeys) RequiredIf() string    { return errorKeys.RequiredIf }
 
 // Boolean-specific error keys
 func (ErrorKeys) InvalidBoolean() string { return errorKeys.InvalidBoolean }
 
+// Password-specific error keys
+func (ErrorKeys) Entropy() string  { return errorKeys.Entropy }
+func (ErrorKeys) Classes() string  { return errorKeys.Classes }
+func (ErrorKeys) DenyList() string { return errorKeys.DenyList }
+
 // Errors provides a global instance for accessing error keys with autocompletion.
 // Usage: validators.Errors.MinLength(), validators.Errors.Required(), etc.
 var Errors ErrorKeys
@@ -138,12 +180,18 @@ const (
 	ErrCustom   = "custom"
 
 	// String error constants
-	ErrMinLength = "minLength"
-	ErrMaxLength = "maxLength"
-	ErrPattern   = "pattern"
-	ErrEmail     = "email"
-	ErrURL       = "url"
-	ErrConst     = "const"
+	ErrMinLength   = "minLength"
+	ErrMaxLength   = "maxLength"
+	ErrPattern     = "pattern"
+	ErrEmail       = "email"
+	ErrEmailDomain = "emailDomain"
+	ErrURL         = "url"
+	ErrFormat      = "format"
+	ErrConst       = "const"
+	ErrEnum        = "enum"
+	ErrEncoding    = "encoding"
+	ErrDecodedSize = "decodedSize"
+	ErrMaxBytes    = "maxBytes"
 
 	// Number error constants
 	ErrMin          = "min"
@@ -154,12 +202,14 @@ const (
 	ErrInteger      = "integer"
 	ErrPositive     = "positive"
 	ErrNegative     = "negative"
+	ErrPrecision    = "precision"
 
 	// Array error constants
 	ErrMinItems    = "minItems"
 	ErrMaxItems    = "maxItems"
 	ErrContains    = "contains"
 	ErrUniqueItems = "uniqueItems"
+	ErrUniqueBy    = "uniqueBy"
 
 	// Object error constants
 	ErrUnknownKey    = "unknownKey"
@@ -167,7 +217,13 @@ const (
 	ErrInvalidShape  = "invalidShape"
 	ErrMinProperties = "minProperties"
 	ErrMaxProperties = "maxProperties"
+	ErrRequiredIf    = "requiredIf"
 
 	// Boolean error constants
 	ErrInvalidBoolean = "invalidBoolean"
+
+	// Password error constants
+	ErrEntropy  = "entropy"
+	ErrClasses  = "classes"
+	ErrDenyList = "denyList"
 )
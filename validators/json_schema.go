@@ -0,0 +1,30 @@
+package validators
+
+import (
+	goop "github.com/picogrid/go-op"
+)
+
+// JSON Schema export methods
+// Each finalized schema exports a standalone draft 2020-12 document via goop.ToJSONSchema.
+// Schemas that reference shared components need operations.ToJSONSchema to resolve them.
+
+func (r *requiredStringSchema) ToJSONSchema() ([]byte, error)   { return goop.ToJSONSchema(r) }
+func (o *optionalStringSchema) ToJSONSchema() ([]byte, error)   { return goop.ToJSONSchema(o) }
+func (r *requiredNumberSchema) ToJSONSchema() ([]byte, error)   { return goop.ToJSONSchema(r) }
+func (o *optionalNumberSchema) ToJSONSchema() ([]byte, error)   { return goop.ToJSONSchema(o) }
+func (r *requiredBoolSchema) ToJSONSchema() ([]byte, error)     { return goop.ToJSONSchema(r) }
+func (o *optionalBoolSchema) ToJSONSchema() ([]byte, error)     { return goop.ToJSONSchema(o) }
+func (r *requiredArraySchema) ToJSONSchema() ([]byte, error)    { return goop.ToJSONSchema(r) }
+func (o *optionalArraySchema) ToJSONSchema() ([]byte, error)    { return goop.ToJSONSchema(o) }
+func (r *requiredObjectSchema) ToJSONSchema() ([]byte, error)   { return goop.ToJSONSchema(r) }
+func (o *optionalObjectSchema) ToJSONSchema() ([]byte, error)   { return goop.ToJSONSchema(o) }
+func (c *compositionSchema) ToJSONSchema() ([]byte, error)      { return goop.ToJSONSchema(c) }
+func (r *requiredPasswordSchema) ToJSONSchema() ([]byte, error) { return goop.ToJSONSchema(r) }
+func (o *optionalPasswordSchema) ToJSONSchema() ([]byte, error) { return goop.ToJSONSchema(o) }
+func (p *preciseNumberSchema) ToJSONSchema() ([]byte, error)    { return goop.ToJSONSchema(p) }
+func (r *requiredTupleSchema) ToJSONSchema() ([]byte, error)    { return goop.ToJSONSchema(r) }
+func (o *optionalTupleSchema) ToJSONSchema() ([]byte, error)    { return goop.ToJSONSchema(o) }
+func (r *requiredIntSchema) ToJSONSchema() ([]byte, error)      { return goop.ToJSONSchema(r) }
+func (o *optionalIntSchema) ToJSONSchema() ([]byte, error)      { return goop.ToJSONSchema(o) }
+func (r *requiredUintSchema) ToJSONSchema() ([]byte, error)     { return goop.ToJSONSchema(r) }
+func (o *optionalUintSchema) ToJSONSchema() ([]byte, error)     { return goop.ToJSONSchema(o) }
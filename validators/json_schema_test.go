@@ -0,0 +1,69 @@
+package validators
+
+import (
+	"encoding/json"
+	"testing"
+
+	goop "github.com/picogrid/go-op"
+)
+
+func TestToJSONSchema(t *testing.T) {
+	schema := Object(map[string]interface{}{
+		"email": String().Email().Example("ada@example.com").Required(),
+		"age":   Number().Integer().Min(18).Optional(),
+		"tags":  Array(String().Required()).MaxItems(5).Optional(),
+	}).Required()
+
+	data, err := schema.ToJSONSchema()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("Expected valid JSON, got %v", err)
+	}
+
+	t.Run("Declares the draft 2020-12 dialect", func(t *testing.T) {
+		if doc["$schema"] != goop.JSONSchemaDialect {
+			t.Errorf("Expected $schema %s, got %v", goop.JSONSchemaDialect, doc["$schema"])
+		}
+	})
+
+	t.Run("Carries validator constraints", func(t *testing.T) {
+		properties := doc["properties"].(map[string]interface{})
+		email := properties["email"].(map[string]interface{})
+		if email["format"] != "email" {
+			t.Errorf("Expected email format, got %v", email["format"])
+		}
+		if examples, ok := email["examples"].([]interface{}); !ok || examples[0] != "ada@example.com" {
+			t.Errorf("Expected examples, got %v", email["examples"])
+		}
+		age := properties["age"].(map[string]interface{})
+		if age["minimum"] != float64(18) {
+			t.Errorf("Expected minimum 18, got %v", age["minimum"])
+		}
+		tags := properties["tags"].(map[string]interface{})
+		if tags["maxItems"] != float64(5) {
+			t.Errorf("Expected maxItems 5, got %v", tags["maxItems"])
+		}
+		required, _ := doc["required"].([]interface{})
+		if len(required) != 1 || required[0] != "email" {
+			t.Errorf("Expected required [email], got %v", doc["required"])
+		}
+	})
+
+	t.Run("Every finalized schema exports", func(t *testing.T) {
+		exporters := []goop.JSONSchemaExporter{
+			String().Optional(),
+			Number().Required(),
+			Bool().Optional(),
+			Array(String().Required()).Required(),
+			OneOf(String().Required(), Number().Required()).Required(),
+		}
+		for _, exporter := range exporters {
+			if _, err := exporter.ToJSONSchema(); err != nil {
+				t.Errorf("Expected %T to export, got %v", exporter, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,134 @@
+package validators
+
+import (
+	"fmt"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// mergePatchSchema validates JSON Merge Patch documents (RFC 7396) against an object schema
+type mergePatchSchema struct {
+	object *objectSchema
+}
+
+// MergePatch validates JSON Merge Patch documents (RFC 7396) for the given object schema
+// Only the fields present in a patch are validated, so required fields may be omitted, but they
+// cannot be removed with an explicit null. Nested object fields are validated as patches too.
+// It panics if object was not built with Object.
+//
+// Example:
+//
+//	userPatch := validators.MergePatch(userSchema)
+//	err := userPatch.Validate(map[string]interface{}{"nickname": nil, "email": "new@example.com"})
+func MergePatch(object goop.Schema) goop.EnhancedSchema {
+	schema, ok := asObjectSchema(object)
+	if !ok {
+		panic(fmt.Sprintf("validators: MergePatch: %T is not an object schema", object))
+	}
+	return &mergePatchSchema{object: schema}
+}
+
+// Validate checks the fields present in the patch
+func (m *mergePatchSchema) Validate(data interface{}) error {
+	patch, ok := data.(map[string]interface{})
+	if !ok {
+		if typed, isPatch := data.(goop.MergePatch); isPatch {
+			patch = typed
+		} else {
+			return goop.NewValidationError("", data, "invalid type, expected merge patch object")
+		}
+	}
+
+	o := m.object
+	if o.strictMode {
+		for key, value := range patch {
+			if _, exists := o.schema[key]; !exists {
+				return goop.NewValidationError(key, value,
+					o.getErrorMessage(errorKeys.UnknownKey, fmt.Sprintf("unknown key: %s", key)))
+			}
+		}
+	}
+
+	collector := acquireErrorCollector()
+	for fieldName, value := range patch {
+		fieldSchema, exists := o.schema[fieldName]
+		if !exists {
+			continue
+		}
+
+		var err error
+		switch nested, isObject := asObjectSchema(fieldSchema); {
+		case value == nil:
+			// null removes the field, which is only allowed when the field may be absent
+			if o.validateField(fieldSchema, nil) != nil {
+				err = goop.NewValidationError(fieldName, nil, fmt.Sprintf("required field cannot be removed: %s", fieldName))
+			}
+		case isObject:
+			if _, isMap := value.(map[string]interface{}); isMap {
+				err = (&mergePatchSchema{object: nested}).Validate(value)
+			} else {
+				err = o.validateField(fieldSchema, value)
+			}
+		default:
+			err = o.validateField(fieldSchema, value)
+		}
+
+		if err != nil {
+			if validationErr, ok := err.(*goop.ValidationError); ok {
+				validationErr.Field = fieldName
+				collector.add(*validationErr)
+			} else {
+				collector.add(*goop.NewValidationError(fieldName, value, err.Error()))
+			}
+		}
+	}
+	details := collector.release()
+
+	if len(details) > 0 {
+		return goop.NewNestedValidationError("", patch, "merge patch validation failed", details)
+	}
+	return nil
+}
+
+// ToOpenAPISchema documents the patch: every field is optional, and fields that may be
+// absent from the resource accept null to remove them
+func (m *mergePatchSchema) ToOpenAPISchema() *goop.OpenAPISchema {
+	o := m.object
+	schema := &goop.OpenAPISchema{
+		Type:       "object",
+		Properties: make(map[string]*goop.OpenAPISchema, len(o.schema)),
+	}
+
+	for fieldName, fieldSchema := range o.schema {
+		var property *goop.OpenAPISchema
+		if nested, ok := asObjectSchema(fieldSchema); ok {
+			property = (&mergePatchSchema{object: nested}).ToOpenAPISchema()
+		} else if enhanced, ok := fieldSchema.(goop.EnhancedSchema); ok {
+			property = enhanced.ToOpenAPISchema()
+		} else {
+			property = &goop.OpenAPISchema{Type: "string"}
+		}
+
+		if o.validateField(fieldSchema, nil) == nil {
+			property = &goop.OpenAPISchema{AnyOf: []*goop.OpenAPISchema{property, {Type: "null"}}}
+		}
+		schema.Properties[fieldName] = property
+	}
+
+	if o.example != nil {
+		schema.Example = o.example
+	}
+	o.docs.apply(schema)
+	return schema
+}
+
+// GetValidationInfo reports the patch document itself as required
+func (m *mergePatchSchema) GetValidationInfo() *goop.ValidationInfo {
+	return &goop.ValidationInfo{
+		Required: true,
+		Constraints: map[string]interface{}{
+			"properties": len(m.object.schema),
+			"mergePatch": true,
+		},
+	}
+}
@@ -0,0 +1,74 @@
+package validators
+
+import (
+	"testing"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// TestMergePatch tests validating and documenting JSON Merge Patch bodies
+func TestMergePatch(t *testing.T) {
+	user := Object(map[string]interface{}{
+		"name":     String().Min(1).Required(),
+		"nickname": String().Optional(),
+		"address": Object(map[string]interface{}{
+			"city": String().Required(),
+			"zip":  String().Optional(),
+		}).Optional(),
+	}).Strict().Required()
+	patch := MergePatch(user)
+
+	t.Run("Only supplied fields are validated", func(t *testing.T) {
+		if err := patch.Validate(map[string]interface{}{"nickname": "ada"}); err != nil {
+			t.Errorf("Expected patch without required fields to be valid, got: %v", err)
+		}
+		if err := patch.Validate(map[string]interface{}{"name": ""}); err == nil {
+			t.Error("Expected invalid supplied field to be rejected")
+		}
+	})
+
+	t.Run("Null removes optional fields only", func(t *testing.T) {
+		if err := patch.Validate(map[string]interface{}{"nickname": nil}); err != nil {
+			t.Errorf("Expected optional field removal to be valid, got: %v", err)
+		}
+		if err := patch.Validate(goop.MergePatch{"name": nil}); err == nil {
+			t.Error("Expected required field removal to be rejected")
+		}
+	})
+
+	t.Run("Nested objects are patches", func(t *testing.T) {
+		if err := patch.Validate(map[string]interface{}{"address": map[string]interface{}{"zip": nil}}); err != nil {
+			t.Errorf("Expected nested patch to be valid, got: %v", err)
+		}
+		if err := patch.Validate(map[string]interface{}{"address": map[string]interface{}{"city": nil}}); err == nil {
+			t.Error("Expected nested required field removal to be rejected")
+		}
+	})
+
+	t.Run("Strict mode rejects unknown fields", func(t *testing.T) {
+		if err := patch.Validate(map[string]interface{}{"role": "admin"}); err == nil {
+			t.Error("Expected unknown field to be rejected")
+		}
+	})
+
+	t.Run("Spec makes fields optional and nullable", func(t *testing.T) {
+		spec := patch.ToOpenAPISchema()
+		if len(spec.Required) != 0 {
+			t.Errorf("Expected no required fields, got %v", spec.Required)
+		}
+		if spec.Properties["name"].Type != "string" {
+			t.Errorf("Expected required field to stay non-nullable, got %+v", spec.Properties["name"])
+		}
+		nickname := spec.Properties["nickname"]
+		if len(nickname.AnyOf) != 2 || nickname.AnyOf[1].Type != "null" {
+			t.Errorf("Expected optional field to accept null, got %+v", nickname)
+		}
+		address := spec.Properties["address"].AnyOf[0]
+		if len(address.Required) != 0 || len(address.Properties["zip"].AnyOf) != 2 {
+			t.Errorf("Expected nested object to be documented as a patch, got %+v", address)
+		}
+		if !patch.GetValidationInfo().Required {
+			t.Error("Expected the patch body to be required")
+		}
+	})
+}
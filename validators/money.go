@@ -0,0 +1,104 @@
+package validators
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// currencyMinorUnits maps active ISO 4217 currency codes to their number of decimal places
+var currencyMinorUnits = func() map[string]int {
+	units := make(map[string]int)
+	for _, code := range strings.Fields(`
+		AED AFN ALL AMD ANG AOA ARS AUD AWG AZN BAM BBD BDT BGN BMD BND BOB BOV BRL BSD BTN BWP
+		BYN BZD CAD CDF CHE CHF CHW CNY COP COU CRC CUP CVE CZK DKK DOP DZD EGP ERN ETB EUR FJD
+		FKP GBP GEL GHS GIP GMD GTQ GYD HKD HNL HTG HUF IDR ILS INR IRR JMD KES KGS KHR KPW KYD
+		KZT LAK LBP LKR LRD LSL MAD MDL MGA MKD MMK MNT MOP MRU MUR MVR MWK MXN MXV MYR MZN NAD
+		NGN NIO NOK NPR NZD PAB PEN PGK PHP PKR PLN QAR RON RSD RUB SAR SBD SCR SDG SEK SGD SHP
+		SLE SOS SRD SSP STN SVC SYP SZL THB TJS TMT TOP TRY TTD TWD TZS UAH USD USN UYU UZS VED
+		VES WST XCD XCG YER ZAR ZMW ZWG ZWL`) {
+		units[code] = 2
+	}
+	for _, code := range strings.Fields("BIF CLP DJF GNF ISK JPY KMF KRW PYG RWF UGX UYI VND VUV XAF XOF XPF") {
+		units[code] = 0
+	}
+	for _, code := range strings.Fields("BHD IQD JOD KWD LYD OMR TND") {
+		units[code] = 3
+	}
+	for _, code := range strings.Fields("CLF UYW") {
+		units[code] = 4
+	}
+	return units
+}()
+
+// currencyEnum holds the currency codes in alphabetical order
+var currencyEnum = func() *stringEnum {
+	codes := make([]string, 0, len(currencyMinorUnits))
+	for code := range currencyMinorUnits {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return newStringEnum(codes)
+}()
+
+// Currency creates a string validator accepting active ISO 4217 currency codes, such as "USD"
+// The codes are documented as an enum. Precious metal and testing codes like XAU and XTS are excluded.
+//
+// Example:
+//
+//	"currency": validators.Currency().Optional().Default("USD")
+func Currency() StringBuilder {
+	s := &stringSchema{customError: make(map[string]string), enum: currencyEnum}
+	s.customError[errorKeys.Enum] = "must be an ISO 4217 currency code"
+	return s
+}
+
+// Money creates an object validator for amounts with a currency, with the amount as a decimal string:
+//
+//	{"amount": "12.50", "currency": "USD"}
+//
+// Amounts may not have more decimal places than the currency has minor units, so "1.5" is
+// rejected for JPY and "1.005" for USD. Decimal strings avoid floating point rounding; use
+// MoneyMinorUnits for integer amounts. Custom replaces the precision check.
+func Money() ObjectBuilder {
+	return Object(map[string]interface{}{
+		"amount": String().
+			Pattern(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?$`).
+			WithPatternMessage("amount must be a decimal number such as 12.50").
+			Example("12.50").
+			Required(),
+		"currency": Currency().Example("USD").Required(),
+	}).Custom(validateMoneyPrecision)
+}
+
+// MoneyMinorUnits creates an object validator for amounts with a currency, with the amount as an
+// integer count of the currency's minor units, such as cents:
+//
+//	{"amount": 1250, "currency": "USD"} // $12.50
+//	{"amount": 1250, "currency": "JPY"} // ¥1250
+//
+// Amounts above 2^53 lose precision when decoded as JSON numbers.
+func MoneyMinorUnits() ObjectBuilder {
+	return Object(map[string]interface{}{
+		"amount":   Number().Integer().Example(1250).Required(),
+		"currency": Currency().Example("USD").Required(),
+	})
+}
+
+// validateMoneyPrecision checks that a decimal amount fits the currency's minor units
+func validateMoneyPrecision(money map[string]interface{}) error {
+	amount, _ := money["amount"].(string)
+	currency, _ := money["currency"].(string)
+
+	_, fraction, _ := strings.Cut(amount, ".")
+	units := currencyMinorUnits[currency]
+	if len(fraction) <= units {
+		return nil
+	}
+	if units == 0 {
+		return goop.NewValidationError("amount", amount, fmt.Sprintf("%s amounts cannot have decimal places", currency))
+	}
+	return goop.NewValidationError("amount", amount, fmt.Sprintf("%s amounts allow at most %d decimal places", currency, units))
+}
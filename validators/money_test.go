@@ -0,0 +1,103 @@
+package validators
+
+import (
+	"strings"
+	"testing"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// TestCurrency tests ISO 4217 currency code validation
+func TestCurrency(t *testing.T) {
+	schema := Currency().Required()
+	for _, code := range []string{"USD", "EUR", "JPY", "KWD", "CLF"} {
+		if err := schema.Validate(code); err != nil {
+			t.Errorf("Expected %s to be valid, got: %v", code, err)
+		}
+	}
+	for _, code := range []string{"usd", "US", "ABC", "XAU", "XTS"} {
+		err := schema.Validate(code)
+		if err == nil || !strings.Contains(err.Error(), "ISO 4217") {
+			t.Errorf("Expected %s to be rejected as a currency code, got: %v", code, err)
+		}
+	}
+
+	if err := Currency().Optional().Default("USD").Validate(""); err != nil {
+		t.Errorf("Expected optional currency to accept empty value, got: %v", err)
+	}
+
+	spec := schema.(goop.EnhancedSchema).ToOpenAPISchema()
+	if len(spec.Enum) != len(currencyMinorUnits) || spec.Enum[0] != "AED" {
+		t.Errorf("Expected sorted enum of %d codes, got %d starting with %v", len(currencyMinorUnits), len(spec.Enum), spec.Enum[0])
+	}
+}
+
+// TestMoney tests money amounts with per-currency precision
+func TestMoney(t *testing.T) {
+	t.Run("Decimal amounts respect currency minor units", func(t *testing.T) {
+		schema := Money().Required()
+		valid := []map[string]interface{}{
+			{"amount": "12.50", "currency": "USD"},
+			{"amount": "12", "currency": "USD"},
+			{"amount": "-3.1", "currency": "EUR"},
+			{"amount": "1500", "currency": "JPY"},
+			{"amount": "1.234", "currency": "KWD"},
+		}
+		for _, money := range valid {
+			if err := schema.Validate(money); err != nil {
+				t.Errorf("Expected %v to be valid, got: %v", money, err)
+			}
+		}
+
+		invalid := map[string]map[string]interface{}{
+			"cannot have decimal places":   {"amount": "1500.5", "currency": "JPY"},
+			"allow at most 2 decimal":      {"amount": "1.005", "currency": "USD"},
+			"decimal number such as 12.50": {"amount": "1e3", "currency": "USD"},
+			"ISO 4217":                     {"amount": "1.00", "currency": "XYZ"},
+			"missing required field":       {"amount": "1.00"},
+		}
+		for message, money := range invalid {
+			err := schema.Validate(money)
+			if err == nil || !strings.Contains(err.Error(), message) {
+				t.Errorf("Expected %v to fail with %q, got: %v", money, message, err)
+			}
+		}
+	})
+
+	t.Run("Minor unit amounts are integers", func(t *testing.T) {
+		schema := MoneyMinorUnits().Required()
+		if err := schema.Validate(map[string]interface{}{"amount": 1250, "currency": "JPY"}); err != nil {
+			t.Errorf("Expected integer amount to be valid, got: %v", err)
+		}
+		if err := schema.Validate(map[string]interface{}{"amount": 12.5, "currency": "USD"}); err == nil {
+			t.Error("Expected fractional minor units to be rejected")
+		}
+	})
+
+	t.Run("Schemas document both representations", func(t *testing.T) {
+		decimal := Money().Required().(goop.EnhancedSchema).ToOpenAPISchema()
+		if decimal.Properties["amount"].Type != "string" || decimal.Properties["amount"].Pattern == "" {
+			t.Errorf("Expected decimal string amount, got %+v", decimal.Properties["amount"])
+		}
+		if len(decimal.Properties["currency"].Enum) == 0 {
+			t.Error("Expected currency enum")
+		}
+		minor := MoneyMinorUnits().Required().(goop.EnhancedSchema).ToOpenAPISchema()
+		if minor.Properties["amount"].Type != "integer" {
+			t.Errorf("Expected integer amount, got %+v", minor.Properties["amount"])
+		}
+	})
+
+	t.Run("Generated values are valid", func(t *testing.T) {
+		schema := Money().Required()
+		for seed := int64(0); seed < 20; seed++ {
+			value, err := goop.Generate(schema, goop.WithSeed(seed))
+			if err != nil {
+				t.Fatalf("Seed %d: expected no error, got %v", seed, err)
+			}
+			if err := schema.Validate(value); err != nil {
+				t.Errorf("Seed %d: expected generated value %v to be valid, got %v", seed, value, err)
+			}
+		}
+	})
+}
@@ -1,6 +1,8 @@
 package validators
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"math"
 
@@ -15,10 +17,13 @@ type numberSchema struct {
 	exclusiveMinValue *float64
 	exclusiveMaxValue *float64
 	multipleOfValue   *float64
+	constValue        *float64
 	integerOnly       bool
 	positiveOnly      bool
 	negativeOnly      bool
 	customFunc        func(float64) error
+	contextFunc       func(context.Context, float64) error
+	extensions        []goop.SchemaExtension
 	required          bool
 	optional          bool
 	defaultValue      *float64
@@ -26,6 +31,7 @@ type numberSchema struct {
 	example           interface{}
 	examples          map[string]ExampleObject
 	externalValue     string
+	docs              schemaDocs
 }
 
 // State wrapper types for compile-time safety
@@ -65,6 +71,12 @@ func (n *numberSchema) MultipleOf(value float64) NumberBuilder {
 	return n
 }
 
+// Const requires the number to equal value, such as a version or type code used as a discriminator
+func (n *numberSchema) Const(value float64) NumberBuilder {
+	n.constValue = &value
+	return n
+}
+
 func (n *numberSchema) Integer() NumberBuilder {
 	n.integerOnly = true
 	return n
@@ -80,11 +92,19 @@ func (n *numberSchema) Negative() NumberBuilder {
 	return n
 }
 
-func (n *numberSchema) Custom(fn func(float64) error) NumberBuilder {
+func (n *numberSchema) Custom(fn func(float64) error, extensions ...goop.SchemaExtension) NumberBuilder {
+	n.extensions = append(n.extensions, extensions...)
 	n.customFunc = fn
 	return n
 }
 
+// CustomCtx adds a check that runs after validation succeeds, with the context passed to
+// ValidateCtx, such as the request context in validated handlers
+func (n *numberSchema) CustomCtx(fn func(context.Context, float64) error) NumberBuilder {
+	n.contextFunc = fn
+	return n
+}
+
 // State transition methods - these change the return type to enforce compile-time safety
 func (n *numberSchema) Required() RequiredNumberBuilder {
 	n.required = true
@@ -152,6 +172,11 @@ func (r *requiredNumberSchema) MultipleOf(value float64) RequiredNumberBuilder {
 	return r
 }
 
+func (r *requiredNumberSchema) Const(value float64) RequiredNumberBuilder {
+	r.constValue = &value
+	return r
+}
+
 func (r *requiredNumberSchema) Integer() RequiredNumberBuilder {
 	r.integerOnly = true
 	return r
@@ -167,11 +192,17 @@ func (r *requiredNumberSchema) Negative() RequiredNumberBuilder {
 	return r
 }
 
-func (r *requiredNumberSchema) Custom(fn func(float64) error) RequiredNumberBuilder {
+func (r *requiredNumberSchema) Custom(fn func(float64) error, extensions ...goop.SchemaExtension) RequiredNumberBuilder {
+	r.extensions = append(r.extensions, extensions...)
 	r.customFunc = fn
 	return r
 }
 
+func (r *requiredNumberSchema) CustomCtx(fn func(context.Context, float64) error) RequiredNumberBuilder {
+	r.contextFunc = fn
+	return r
+}
+
 // Error message methods for RequiredNumberBuilder
 func (r *requiredNumberSchema) WithMessage(validationType, message string) RequiredNumberBuilder {
 	r.customError[validationType] = message
@@ -230,6 +261,11 @@ func (o *optionalNumberSchema) MultipleOf(value float64) OptionalNumberBuilder {
 	return o
 }
 
+func (o *optionalNumberSchema) Const(value float64) OptionalNumberBuilder {
+	o.constValue = &value
+	return o
+}
+
 func (o *optionalNumberSchema) Integer() OptionalNumberBuilder {
 	o.integerOnly = true
 	return o
@@ -245,11 +281,17 @@ func (o *optionalNumberSchema) Negative() OptionalNumberBuilder {
 	return o
 }
 
-func (o *optionalNumberSchema) Custom(fn func(float64) error) OptionalNumberBuilder {
+func (o *optionalNumberSchema) Custom(fn func(float64) error, extensions ...goop.SchemaExtension) OptionalNumberBuilder {
+	o.extensions = append(o.extensions, extensions...)
 	o.customFunc = fn
 	return o
 }
 
+func (o *optionalNumberSchema) CustomCtx(fn func(context.Context, float64) error) OptionalNumberBuilder {
+	o.contextFunc = fn
+	return o
+}
+
 // Default is only available on optional builders - this is the key DX improvement!
 func (o *optionalNumberSchema) Default(value float64) OptionalNumberBuilder {
 	o.defaultValue = &value
@@ -287,10 +329,24 @@ func (r *requiredNumberSchema) Validate(data interface{}) error {
 	return r.validate(data)
 }
 
+func (r *requiredNumberSchema) ValidateCtx(ctx context.Context, data interface{}) error {
+	if err := r.validate(data); err != nil {
+		return err
+	}
+	return runContextChecks(ctx, r, data)
+}
+
 func (o *optionalNumberSchema) Validate(data interface{}) error {
 	return o.validate(data)
 }
 
+func (o *optionalNumberSchema) ValidateCtx(ctx context.Context, data interface{}) error {
+	if err := o.validate(data); err != nil {
+		return err
+	}
+	return runContextChecks(ctx, o, data)
+}
+
 // Core validation logic (shared between required and optional)
 func (n *numberSchema) validate(data interface{}) error {
 	// Handle nil values
@@ -299,7 +355,7 @@ func (n *numberSchema) validate(data interface{}) error {
 			return goop.NewValidationError("", nil, n.getErrorMessage(errorKeys.Required, "field is required"))
 		}
 		if n.defaultValue != nil {
-			return n.validate(*n.defaultValue)
+			return n.validateNumber(*n.defaultValue)
 		}
 		if n.optional {
 			return nil
@@ -334,17 +390,37 @@ func (n *numberSchema) validate(data interface{}) error {
 		num = float64(v)
 	case float64:
 		num = v
+	case json.Number:
+		// Decoded numbers too precise for float64 are compared at float64 precision
+		f, err := v.Float64()
+		if err != nil {
+			return goop.NewValidationError(v.String(), data,
+				n.getErrorMessage(errorKeys.Type, "invalid type, expected number"))
+		}
+		num = f
 	default:
 		return goop.NewValidationError(fmt.Sprintf("%v", data), data,
 			n.getErrorMessage(errorKeys.Type, "invalid type, expected number"))
 	}
 
+	return n.validateNumber(num)
+}
+
+// validateNumber applies the number rules to an already converted value
+// The success path performs no allocations; messages are only formatted once a rule fails
+func (n *numberSchema) validateNumber(num float64) error {
 	// Integer validation
 	if n.integerOnly && num != math.Trunc(num) {
 		return goop.NewValidationError(fmt.Sprintf("%v", num), num,
 			n.getErrorMessage(errorKeys.Integer, "value must be an integer"))
 	}
 
+	// Const validation
+	if n.constValue != nil && num != *n.constValue {
+		return goop.NewValidationError(fmt.Sprintf("%v", num), num,
+			n.getErrorMessage(errorKeys.Const, fmt.Sprintf("value must be exactly %g", *n.constValue)))
+	}
+
 	// Range validations
 	if n.minValue != nil && num < *n.minValue {
 		return goop.NewValidationError(fmt.Sprintf("%v", num), num,
@@ -1,5 +1,11 @@
 package validators
 
+import (
+	"context"
+
+	goop "github.com/picogrid/go-op"
+)
+
 // NumberBuilder represents the initial number builder state.
 // From this state, you can configure validation rules and then transition to
 // either a required or optional state. This prevents invalid method chaining.
@@ -10,15 +16,24 @@ type NumberBuilder interface {
 	ExclusiveMin(value float64) NumberBuilder
 	ExclusiveMax(value float64) NumberBuilder
 	MultipleOf(value float64) NumberBuilder
+	Const(value float64) NumberBuilder
 	Integer() NumberBuilder
 	Positive() NumberBuilder
 	Negative() NumberBuilder
-	Custom(fn func(float64) error) NumberBuilder
+	Custom(fn func(float64) error, extensions ...goop.SchemaExtension) NumberBuilder
+	CustomCtx(fn func(context.Context, float64) error) NumberBuilder // Runs after validation with the request context
+	// Sensitive and PII classify the value, which is then masked in recordings and logs
+	Sensitive() NumberBuilder
+	PII(category string) NumberBuilder
+	// VisibleToScopes strips the field from responses to callers without any of scopes
+	VisibleToScopes(scopes ...string) NumberBuilder
 
-	// Example methods for OpenAPI documentation
+	// Example and documentation methods for OpenAPI
 	Example(value interface{}) NumberBuilder
 	Examples(examples map[string]ExampleObject) NumberBuilder
 	ExampleFromFile(path string) NumberBuilder
+	Title(title string) NumberBuilder
+	Description(description string) NumberBuilder
 
 	// State transition methods - these change the type to prevent invalid chaining
 	Required() RequiredNumberBuilder // Transitions to required state
@@ -45,15 +60,22 @@ type RequiredNumberBuilder interface {
 	ExclusiveMin(value float64) RequiredNumberBuilder
 	ExclusiveMax(value float64) RequiredNumberBuilder
 	MultipleOf(value float64) RequiredNumberBuilder
+	Const(value float64) RequiredNumberBuilder
 	Integer() RequiredNumberBuilder
 	Positive() RequiredNumberBuilder
 	Negative() RequiredNumberBuilder
-	Custom(fn func(float64) error) RequiredNumberBuilder
+	Custom(fn func(float64) error, extensions ...goop.SchemaExtension) RequiredNumberBuilder
+	CustomCtx(fn func(context.Context, float64) error) RequiredNumberBuilder
+	Sensitive() RequiredNumberBuilder
+	PII(category string) RequiredNumberBuilder
+	VisibleToScopes(scopes ...string) RequiredNumberBuilder
 
-	// Example methods for OpenAPI documentation
+	// Example and documentation methods for OpenAPI
 	Example(value interface{}) RequiredNumberBuilder
 	Examples(examples map[string]ExampleObject) RequiredNumberBuilder
 	ExampleFromFile(path string) RequiredNumberBuilder
+	Title(title string) RequiredNumberBuilder
+	Description(description string) RequiredNumberBuilder
 
 	// Error message configuration methods
 	WithMessage(validationType, message string) RequiredNumberBuilder
@@ -64,8 +86,12 @@ type RequiredNumberBuilder interface {
 	WithNegativeMessage(message string) RequiredNumberBuilder
 	WithRequiredMessage(message string) RequiredNumberBuilder
 
+	// JSON Schema export
+	ToJSONSchema() ([]byte, error)
+
 	// Validation method - final step in the builder chain
 	Validate(data interface{}) error
+	ValidateCtx(ctx context.Context, data interface{}) error
 }
 
 // OptionalNumberBuilder represents a number builder in the optional state.
@@ -81,16 +107,23 @@ type OptionalNumberBuilder interface {
 	ExclusiveMin(value float64) OptionalNumberBuilder
 	ExclusiveMax(value float64) OptionalNumberBuilder
 	MultipleOf(value float64) OptionalNumberBuilder
+	Const(value float64) OptionalNumberBuilder
 	Integer() OptionalNumberBuilder
 	Positive() OptionalNumberBuilder
 	Negative() OptionalNumberBuilder
-	Custom(fn func(float64) error) OptionalNumberBuilder
+	Custom(fn func(float64) error, extensions ...goop.SchemaExtension) OptionalNumberBuilder
+	CustomCtx(fn func(context.Context, float64) error) OptionalNumberBuilder
+	Sensitive() OptionalNumberBuilder
+	PII(category string) OptionalNumberBuilder
+	VisibleToScopes(scopes ...string) OptionalNumberBuilder
 	Default(value float64) OptionalNumberBuilder // Only available on optional builders!
 
-	// Example methods for OpenAPI documentation
+	// Example and documentation methods for OpenAPI
 	Example(value interface{}) OptionalNumberBuilder
 	Examples(examples map[string]ExampleObject) OptionalNumberBuilder
 	ExampleFromFile(path string) OptionalNumberBuilder
+	Title(title string) OptionalNumberBuilder
+	Description(description string) OptionalNumberBuilder
 
 	// Error message configuration methods
 	WithMessage(validationType, message string) OptionalNumberBuilder
@@ -100,6 +133,10 @@ type OptionalNumberBuilder interface {
 	WithPositiveMessage(message string) OptionalNumberBuilder
 	WithNegativeMessage(message string) OptionalNumberBuilder
 
+	// JSON Schema export
+	ToJSONSchema() ([]byte, error)
+
 	// Validation method - final step in the builder chain
 	Validate(data interface{}) error
+	ValidateCtx(ctx context.Context, data interface{}) error
 }
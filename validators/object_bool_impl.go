@@ -1,6 +1,7 @@
 package validators
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"reflect"
@@ -15,7 +16,12 @@ type objectSchema struct {
 	partialMode   bool
 	minProperties int
 	maxProperties int
+	constValue    map[string]interface{}
 	customFunc    func(map[string]interface{}) error
+	contextFunc   func(context.Context, map[string]interface{}) error
+	extensions    []goop.SchemaExtension
+	conditions    []requiredCondition
+	deprecated    map[string]string
 	required      bool
 	optional      bool
 	defaultValue  map[string]interface{}
@@ -23,11 +29,15 @@ type objectSchema struct {
 	example       interface{}
 	examples      map[string]ExampleObject
 	externalValue string
+	docs          schemaDocs
+	fields        *objectFields
 }
 
 // Core bool schema struct (unexported)
 type boolSchema struct {
+	constValue    *bool
 	customFunc    func(bool) error
+	extensions    []goop.SchemaExtension
 	required      bool
 	optional      bool
 	defaultValue  *bool
@@ -35,6 +45,7 @@ type boolSchema struct {
 	example       interface{}
 	examples      map[string]ExampleObject
 	externalValue string
+	docs          schemaDocs
 }
 
 // State wrapper types for objects
@@ -76,11 +87,25 @@ func (o *objectSchema) MaxProperties(count int) ObjectBuilder {
 	return o
 }
 
-func (o *objectSchema) Custom(fn func(map[string]interface{}) error) ObjectBuilder {
+// Const requires the object to equal value, comparing numbers by value
+func (o *objectSchema) Const(value map[string]interface{}) ObjectBuilder {
+	o.constValue = value
+	return o
+}
+
+func (o *objectSchema) Custom(fn func(map[string]interface{}) error, extensions ...goop.SchemaExtension) ObjectBuilder {
+	o.extensions = append(o.extensions, extensions...)
 	o.customFunc = fn
 	return o
 }
 
+// CustomCtx adds a check that runs after validation succeeds, with the context passed to
+// ValidateCtx, such as the request context in validated handlers
+func (o *objectSchema) CustomCtx(fn func(context.Context, map[string]interface{}) error) ObjectBuilder {
+	o.contextFunc = fn
+	return o
+}
+
 func (o *objectSchema) Required() RequiredObjectBuilder {
 	o.required = true
 	o.optional = false
@@ -122,11 +147,22 @@ func (r *requiredObjectSchema) MaxProperties(count int) RequiredObjectBuilder {
 	return r
 }
 
-func (r *requiredObjectSchema) Custom(fn func(map[string]interface{}) error) RequiredObjectBuilder {
+func (r *requiredObjectSchema) Const(value map[string]interface{}) RequiredObjectBuilder {
+	r.constValue = value
+	return r
+}
+
+func (r *requiredObjectSchema) Custom(fn func(map[string]interface{}) error, extensions ...goop.SchemaExtension) RequiredObjectBuilder {
+	r.extensions = append(r.extensions, extensions...)
 	r.customFunc = fn
 	return r
 }
 
+func (r *requiredObjectSchema) CustomCtx(fn func(context.Context, map[string]interface{}) error) RequiredObjectBuilder {
+	r.contextFunc = fn
+	return r
+}
+
 func (r *requiredObjectSchema) WithMessage(validationType, message string) RequiredObjectBuilder {
 	if r.customError == nil {
 		r.customError = make(map[string]string)
@@ -143,6 +179,13 @@ func (r *requiredObjectSchema) Validate(data interface{}) error {
 	return r.validate(data)
 }
 
+func (r *requiredObjectSchema) ValidateCtx(ctx context.Context, data interface{}) error {
+	if err := r.validate(data); err != nil {
+		return err
+	}
+	return runContextChecks(ctx, r, data)
+}
+
 // OptionalObjectBuilder implementation
 func (o *optionalObjectSchema) Strict() OptionalObjectBuilder {
 	o.strictMode = true
@@ -164,11 +207,22 @@ func (o *optionalObjectSchema) MaxProperties(count int) OptionalObjectBuilder {
 	return o
 }
 
-func (o *optionalObjectSchema) Custom(fn func(map[string]interface{}) error) OptionalObjectBuilder {
+func (o *optionalObjectSchema) Const(value map[string]interface{}) OptionalObjectBuilder {
+	o.constValue = value
+	return o
+}
+
+func (o *optionalObjectSchema) Custom(fn func(map[string]interface{}) error, extensions ...goop.SchemaExtension) OptionalObjectBuilder {
+	o.extensions = append(o.extensions, extensions...)
 	o.customFunc = fn
 	return o
 }
 
+func (o *optionalObjectSchema) CustomCtx(fn func(context.Context, map[string]interface{}) error) OptionalObjectBuilder {
+	o.contextFunc = fn
+	return o
+}
+
 func (o *optionalObjectSchema) Default(value map[string]interface{}) OptionalObjectBuilder {
 	o.defaultValue = value
 	return o
@@ -186,104 +240,17 @@ func (o *optionalObjectSchema) Validate(data interface{}) error {
 	return o.validate(data)
 }
 
-// Object validation logic
-func (o *objectSchema) validate(data interface{}) error {
-	// Handle nil values
-	if data == nil {
-		if o.required {
-			return goop.NewValidationError("", nil, o.getErrorMessage(errorKeys.Required, "field is required"))
-		}
-		if o.defaultValue != nil {
-			return o.validate(o.defaultValue)
-		}
-		if o.optional {
-			return nil
-		}
-		return goop.NewValidationError("", nil, o.getErrorMessage(errorKeys.Required, "field is required"))
-	}
-
-	// Type check - convert to map[string]interface{}
-	var obj map[string]interface{}
-
-	// Use reflection to handle different map types
-	val := reflect.ValueOf(data)
-	if val.Kind() != reflect.Map {
-		return goop.NewValidationError(fmt.Sprintf("%v", data), data,
-			o.getErrorMessage(errorKeys.Type, "invalid type, expected object"))
-	}
-
-	// Convert to map[string]interface{}
-	obj = make(map[string]interface{})
-	for _, key := range val.MapKeys() {
-		keyStr := fmt.Sprintf("%v", key.Interface())
-		obj[keyStr] = val.MapIndex(key).Interface()
-	}
-
-	// Properties count validation
-	propCount := len(obj)
-	if o.minProperties > 0 && propCount < o.minProperties {
-		return goop.NewValidationError(fmt.Sprintf("%v", obj), obj,
-			o.getErrorMessage(errorKeys.MinProperties,
-				fmt.Sprintf("object has too few properties, minimum is %d but got %d", o.minProperties, propCount)))
-	}
-
-	if o.maxProperties > 0 && propCount > o.maxProperties {
-		return goop.NewValidationError(fmt.Sprintf("%v", obj), obj,
-			o.getErrorMessage(errorKeys.MaxProperties,
-				fmt.Sprintf("object has too many properties, maximum is %d but got %d", o.maxProperties, propCount)))
-	}
-
-	// Strict mode: check for unknown keys
-	if o.strictMode {
-		for key := range obj {
-			if _, exists := o.schema[key]; !exists {
-				return goop.NewValidationError(key, obj[key],
-					o.getErrorMessage(errorKeys.UnknownKey,
-						fmt.Sprintf("unknown key: %s", key)))
-			}
-		}
-	}
-
-	// Validate each field in the schema
-	var details []goop.ValidationError
-	for fieldName, fieldSchema := range o.schema {
-		value, exists := obj[fieldName]
-
-		// Handle missing fields
-		if !exists {
-			if !o.partialMode {
-				// Check if field is required by trying to validate nil
-				if err := o.validateField(fieldSchema, nil); err != nil {
-					details = append(details, *goop.NewValidationError(fieldName, nil,
-						fmt.Sprintf("missing required field: %s", fieldName)))
-				}
-			}
-			continue
-		}
-
-		// Validate field
-		if err := o.validateField(fieldSchema, value); err != nil {
-			if validationErr, ok := err.(*goop.ValidationError); ok {
-				validationErr.Field = fieldName
-				details = append(details, *validationErr)
-			} else {
-				details = append(details, *goop.NewValidationError(fieldName, value, err.Error()))
-			}
-		}
-	}
-
-	if len(details) > 0 {
-		return goop.NewNestedValidationError("", obj, "object validation failed", details)
-	}
-
-	// Custom validation
-	if o.customFunc != nil {
-		if err := o.customFunc(obj); err != nil {
-			return err
-		}
+func (o *optionalObjectSchema) ValidateCtx(ctx context.Context, data interface{}) error {
+	if err := o.validate(data); err != nil {
+		return err
 	}
+	return runContextChecks(ctx, o, data)
+}
 
-	return nil
+// Object validation logic
+// Properties are validated from the compiled field list, built on first validation
+func (o *objectSchema) validate(data interface{}) error {
+	return o.validateFields(o.compiledFields(), data)
 }
 
 func (o *objectSchema) validateField(fieldSchema, value interface{}) error {
@@ -452,16 +419,24 @@ func (o *objectSchema) convertStructToMap(value interface{}) map[string]interfac
 		return nil // Conversion failed, return nil to use original value
 	}
 
-	var result map[string]interface{}
-	if err := json.Unmarshal(jsonData, &result); err != nil {
+	decoded, err := goop.DecodeJSON(jsonData)
+	if err != nil {
 		return nil // Conversion failed, return nil to use original value
 	}
 
+	result, _ := decoded.(map[string]interface{})
 	return result
 }
 
 // BoolBuilder implementation (initial state)
-func (b *boolSchema) Custom(fn func(bool) error) BoolBuilder {
+// Const requires the boolean to equal value, such as a flag used as a discriminator
+func (b *boolSchema) Const(value bool) BoolBuilder {
+	b.constValue = &value
+	return b
+}
+
+func (b *boolSchema) Custom(fn func(bool) error, extensions ...goop.SchemaExtension) BoolBuilder {
+	b.extensions = append(b.extensions, extensions...)
 	b.customFunc = fn
 	return b
 }
@@ -487,7 +462,13 @@ func (b *boolSchema) WithMessage(validationType, message string) BoolBuilder {
 }
 
 // RequiredBoolBuilder implementation
-func (r *requiredBoolSchema) Custom(fn func(bool) error) RequiredBoolBuilder {
+func (r *requiredBoolSchema) Const(value bool) RequiredBoolBuilder {
+	r.constValue = &value
+	return r
+}
+
+func (r *requiredBoolSchema) Custom(fn func(bool) error, extensions ...goop.SchemaExtension) RequiredBoolBuilder {
+	r.extensions = append(r.extensions, extensions...)
 	r.customFunc = fn
 	return r
 }
@@ -509,7 +490,13 @@ func (r *requiredBoolSchema) Validate(data interface{}) error {
 }
 
 // OptionalBoolBuilder implementation
-func (o *optionalBoolSchema) Custom(fn func(bool) error) OptionalBoolBuilder {
+func (o *optionalBoolSchema) Const(value bool) OptionalBoolBuilder {
+	o.constValue = &value
+	return o
+}
+
+func (o *optionalBoolSchema) Custom(fn func(bool) error, extensions ...goop.SchemaExtension) OptionalBoolBuilder {
+	o.extensions = append(o.extensions, extensions...)
 	o.customFunc = fn
 	return o
 }
@@ -539,7 +526,7 @@ func (b *boolSchema) validate(data interface{}) error {
 			return goop.NewValidationError("", nil, b.getErrorMessage(errorKeys.Required, "field is required"))
 		}
 		if b.defaultValue != nil {
-			return b.validate(*b.defaultValue)
+			return b.validateBool(*b.defaultValue)
 		}
 		if b.optional {
 			return nil
@@ -554,6 +541,17 @@ func (b *boolSchema) validate(data interface{}) error {
 			b.getErrorMessage(errorKeys.Type, "invalid type, expected boolean"))
 	}
 
+	return b.validateBool(boolVal)
+}
+
+// validateBool applies the boolean rules to an already type-checked value
+func (b *boolSchema) validateBool(boolVal bool) error {
+	// Const validation
+	if b.constValue != nil && boolVal != *b.constValue {
+		return goop.NewValidationError(fmt.Sprintf("%v", boolVal), boolVal,
+			b.getErrorMessage(errorKeys.Const, fmt.Sprintf("value must be exactly %t", *b.constValue)))
+	}
+
 	// Custom validation
 	if b.customFunc != nil {
 		if err := b.customFunc(boolVal); err != nil {
@@ -1,5 +1,11 @@
 package validators
 
+import (
+	"context"
+
+	goop "github.com/picogrid/go-op"
+)
+
 // ObjectBuilder represents the initial object builder state.
 // From this state, you can configure validation rules and then transition to
 // either a required or optional state. This prevents invalid method chaining.
@@ -9,12 +15,28 @@ type ObjectBuilder interface {
 	Partial() ObjectBuilder // All keys become optional
 	MinProperties(count int) ObjectBuilder
 	MaxProperties(count int) ObjectBuilder
-	Custom(fn func(map[string]interface{}) error) ObjectBuilder
-
-	// Example methods for OpenAPI documentation
+	Const(value map[string]interface{}) ObjectBuilder
+	Custom(fn func(map[string]interface{}) error, extensions ...goop.SchemaExtension) ObjectBuilder
+	// CustomCtx runs after validation with the request context
+	CustomCtx(fn func(context.Context, map[string]interface{}) error) ObjectBuilder
+	// VisibleToScopes strips the field from responses to callers without any of scopes
+	VisibleToScopes(scopes ...string) ObjectBuilder
+	RequiredIf(field, dependsOn string, values ...interface{}) ObjectBuilder     // field is required when dependsOn matches
+	RequiredUnless(field, dependsOn string, values ...interface{}) ObjectBuilder // field is required unless dependsOn matches
+	DeprecatedField(field, message string) ObjectBuilder                         // field passes with a warning
+
+	// Derivation methods - these return a new ObjectBuilder and leave the original unchanged
+	Extend(fields map[string]interface{}) ObjectBuilder // Add or replace fields
+	Merge(other goop.Schema) ObjectBuilder              // Add or replace the fields of another object schema
+	Pick(keys ...string) ObjectBuilder                  // Keep only the given fields
+	Omit(keys ...string) ObjectBuilder                  // Drop the given fields
+
+	// Example and documentation methods for OpenAPI
 	Example(value interface{}) ObjectBuilder
 	Examples(examples map[string]ExampleObject) ObjectBuilder
 	ExampleFromFile(path string) ObjectBuilder
+	Title(title string) ObjectBuilder
+	Description(description string) ObjectBuilder
 
 	// State transition methods - these change the type to prevent invalid chaining
 	Required() RequiredObjectBuilder // Transitions to required state
@@ -35,19 +57,37 @@ type RequiredObjectBuilder interface {
 	Partial() RequiredObjectBuilder
 	MinProperties(count int) RequiredObjectBuilder
 	MaxProperties(count int) RequiredObjectBuilder
-	Custom(fn func(map[string]interface{}) error) RequiredObjectBuilder
-
-	// Example methods for OpenAPI documentation
+	Const(value map[string]interface{}) RequiredObjectBuilder
+	Custom(fn func(map[string]interface{}) error, extensions ...goop.SchemaExtension) RequiredObjectBuilder
+	CustomCtx(fn func(context.Context, map[string]interface{}) error) RequiredObjectBuilder
+	VisibleToScopes(scopes ...string) RequiredObjectBuilder
+	RequiredIf(field, dependsOn string, values ...interface{}) RequiredObjectBuilder     // field is required when dependsOn matches
+	RequiredUnless(field, dependsOn string, values ...interface{}) RequiredObjectBuilder // field is required unless dependsOn matches
+	DeprecatedField(field, message string) RequiredObjectBuilder                         // field passes with a warning
+
+	// Derivation methods - these return a new ObjectBuilder and leave the original unchanged
+	Extend(fields map[string]interface{}) ObjectBuilder
+	Merge(other goop.Schema) ObjectBuilder
+	Pick(keys ...string) ObjectBuilder
+	Omit(keys ...string) ObjectBuilder
+
+	// Example and documentation methods for OpenAPI
 	Example(value interface{}) RequiredObjectBuilder
 	Examples(examples map[string]ExampleObject) RequiredObjectBuilder
 	ExampleFromFile(path string) RequiredObjectBuilder
+	Title(title string) RequiredObjectBuilder
+	Description(description string) RequiredObjectBuilder
 
 	// Error message configuration methods
 	WithMessage(validationType, message string) RequiredObjectBuilder
 	WithRequiredMessage(message string) RequiredObjectBuilder
 
+	// JSON Schema export
+	ToJSONSchema() ([]byte, error)
+
 	// Validation method - final step in the builder chain
 	Validate(data interface{}) error
+	ValidateCtx(ctx context.Context, data interface{}) error
 }
 
 // OptionalObjectBuilder represents an object builder in the optional state.
@@ -62,19 +102,37 @@ type OptionalObjectBuilder interface {
 	Partial() OptionalObjectBuilder
 	MinProperties(count int) OptionalObjectBuilder
 	MaxProperties(count int) OptionalObjectBuilder
-	Custom(fn func(map[string]interface{}) error) OptionalObjectBuilder
+	Const(value map[string]interface{}) OptionalObjectBuilder
+	Custom(fn func(map[string]interface{}) error, extensions ...goop.SchemaExtension) OptionalObjectBuilder
+	CustomCtx(fn func(context.Context, map[string]interface{}) error) OptionalObjectBuilder
+	VisibleToScopes(scopes ...string) OptionalObjectBuilder
+	RequiredIf(field, dependsOn string, values ...interface{}) OptionalObjectBuilder     // field is required when dependsOn matches
+	RequiredUnless(field, dependsOn string, values ...interface{}) OptionalObjectBuilder // field is required unless dependsOn matches
+	DeprecatedField(field, message string) OptionalObjectBuilder                         // field passes with a warning
+
+	// Derivation methods - these return a new ObjectBuilder and leave the original unchanged
+	Extend(fields map[string]interface{}) ObjectBuilder
+	Merge(other goop.Schema) ObjectBuilder
+	Pick(keys ...string) ObjectBuilder
+	Omit(keys ...string) ObjectBuilder
 	Default(value map[string]interface{}) OptionalObjectBuilder // Only available on optional builders!
 
-	// Example methods for OpenAPI documentation
+	// Example and documentation methods for OpenAPI
 	Example(value interface{}) OptionalObjectBuilder
 	Examples(examples map[string]ExampleObject) OptionalObjectBuilder
 	ExampleFromFile(path string) OptionalObjectBuilder
+	Title(title string) OptionalObjectBuilder
+	Description(description string) OptionalObjectBuilder
 
 	// Error message configuration methods
 	WithMessage(validationType, message string) OptionalObjectBuilder
 
+	// JSON Schema export
+	ToJSONSchema() ([]byte, error)
+
 	// Validation method - final step in the builder chain
 	Validate(data interface{}) error
+	ValidateCtx(ctx context.Context, data interface{}) error
 }
 
 // BoolBuilder represents the initial bool builder state.
@@ -82,12 +140,17 @@ type OptionalObjectBuilder interface {
 // either a required or optional state. This prevents invalid method chaining.
 type BoolBuilder interface {
 	// Configuration methods - these return BoolBuilder to allow chaining
-	Custom(fn func(bool) error) BoolBuilder
+	Const(value bool) BoolBuilder
+	Custom(fn func(bool) error, extensions ...goop.SchemaExtension) BoolBuilder
+	// VisibleToScopes strips the field from responses to callers without any of scopes
+	VisibleToScopes(scopes ...string) BoolBuilder
 
-	// Example methods for OpenAPI documentation
+	// Example and documentation methods for OpenAPI
 	Example(value interface{}) BoolBuilder
 	Examples(examples map[string]ExampleObject) BoolBuilder
 	ExampleFromFile(path string) BoolBuilder
+	Title(title string) BoolBuilder
+	Description(description string) BoolBuilder
 
 	// State transition methods - these change the type to prevent invalid chaining
 	Required() RequiredBoolBuilder // Transitions to required state
@@ -104,17 +167,24 @@ type BoolBuilder interface {
 // This enforces logical validation rules at compile time.
 type RequiredBoolBuilder interface {
 	// Configuration methods - these return RequiredBoolBuilder to maintain state
-	Custom(fn func(bool) error) RequiredBoolBuilder
+	Const(value bool) RequiredBoolBuilder
+	Custom(fn func(bool) error, extensions ...goop.SchemaExtension) RequiredBoolBuilder
+	VisibleToScopes(scopes ...string) RequiredBoolBuilder
 
-	// Example methods for OpenAPI documentation
+	// Example and documentation methods for OpenAPI
 	Example(value interface{}) RequiredBoolBuilder
 	Examples(examples map[string]ExampleObject) RequiredBoolBuilder
 	ExampleFromFile(path string) RequiredBoolBuilder
+	Title(title string) RequiredBoolBuilder
+	Description(description string) RequiredBoolBuilder
 
 	// Error message configuration methods
 	WithMessage(validationType, message string) RequiredBoolBuilder
 	WithRequiredMessage(message string) RequiredBoolBuilder
 
+	// JSON Schema export
+	ToJSONSchema() ([]byte, error)
+
 	// Validation method - final step in the builder chain
 	Validate(data interface{}) error
 }
@@ -127,17 +197,24 @@ type RequiredBoolBuilder interface {
 // This enforces logical validation rules at compile time.
 type OptionalBoolBuilder interface {
 	// Configuration methods - these return OptionalBoolBuilder to maintain state
-	Custom(fn func(bool) error) OptionalBoolBuilder
+	Const(value bool) OptionalBoolBuilder
+	Custom(fn func(bool) error, extensions ...goop.SchemaExtension) OptionalBoolBuilder
+	VisibleToScopes(scopes ...string) OptionalBoolBuilder
 	Default(value bool) OptionalBoolBuilder // Only available on optional builders!
 
-	// Example methods for OpenAPI documentation
+	// Example and documentation methods for OpenAPI
 	Example(value interface{}) OptionalBoolBuilder
 	Examples(examples map[string]ExampleObject) OptionalBoolBuilder
 	ExampleFromFile(path string) OptionalBoolBuilder
+	Title(title string) OptionalBoolBuilder
+	Description(description string) OptionalBoolBuilder
 
 	// Error message configuration methods
 	WithMessage(validationType, message string) OptionalBoolBuilder
 
+	// JSON Schema export
+	ToJSONSchema() ([]byte, error)
+
 	// Validation method - final step in the builder chain
 	Validate(data interface{}) error
 }
@@ -0,0 +1,228 @@
+package validators
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// requiredCondition makes an object field required depending on another field
+type requiredCondition struct {
+	field     string
+	dependsOn string
+	// values are the values of dependsOn the condition matches; none means any value, i.e. presence
+	values []interface{}
+	unless bool
+}
+
+// requires reports whether the field is required, given the value of dependsOn and whether it is present
+func (c requiredCondition) requires(value interface{}, present bool) bool {
+	matched := present && (len(c.values) == 0 || c.matches(value))
+	return matched != c.unless
+}
+
+func (c requiredCondition) matches(value interface{}) bool {
+	for _, candidate := range c.values {
+		if sameValue(value, candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+// message describes the condition for an error about the missing field
+func (c requiredCondition) message() string {
+	var when string
+	switch {
+	case len(c.values) == 0 && c.unless:
+		when = fmt.Sprintf("when %s is absent", c.dependsOn)
+	case len(c.values) == 0:
+		when = fmt.Sprintf("when %s is present", c.dependsOn)
+	default:
+		verb := "when"
+		if c.unless {
+			verb = "unless"
+		}
+		if len(c.values) == 1 {
+			when = fmt.Sprintf("%s %s is %v", verb, c.dependsOn, c.values[0])
+		} else {
+			when = fmt.Sprintf("%s %s is one of %v", verb, c.dependsOn, c.values)
+		}
+	}
+	return fmt.Sprintf("missing required field: %s (required %s)", c.field, when)
+}
+
+// openAPISchema expresses a value condition as an if/then or if/else subschema
+func (c requiredCondition) openAPISchema() *goop.OpenAPISchema {
+	condition := &goop.OpenAPISchema{Required: []string{c.dependsOn}}
+	switch len(c.values) {
+	case 0:
+	case 1:
+		condition.Properties = map[string]*goop.OpenAPISchema{c.dependsOn: {Const: c.values[0]}}
+	default:
+		condition.Properties = map[string]*goop.OpenAPISchema{c.dependsOn: {Enum: c.values}}
+	}
+
+	consequence := &goop.OpenAPISchema{Required: []string{c.field}}
+	if c.unless {
+		return &goop.OpenAPISchema{If: condition, Else: consequence}
+	}
+	return &goop.OpenAPISchema{If: condition, Then: consequence}
+}
+
+// sameValue compares a decoded value with a condition value, treating numbers of any Go type as equal
+// when they have the same value, including inside objects and arrays
+func sameValue(value, candidate interface{}) bool {
+	if a, ok := numericValue(value); ok {
+		b, ok := numericValue(candidate)
+		return ok && a == b
+	}
+
+	switch a := value.(type) {
+	case map[string]interface{}:
+		b, ok := candidate.(map[string]interface{})
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for key, item := range a {
+			other, exists := b[key]
+			if !exists || !sameValue(item, other) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		b, ok := candidate.([]interface{})
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if !sameValue(a[i], b[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(value, candidate)
+}
+
+func numericValue(v interface{}) (float64, bool) {
+	if n, ok := v.(json.Number); ok {
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	rv := reflect.ValueOf(v)
+	switch {
+	case !rv.IsValid():
+		return 0, false
+	case rv.CanInt():
+		return float64(rv.Int()), true
+	case rv.CanUint():
+		return float64(rv.Uint()), true
+	case rv.CanFloat():
+		return rv.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+// addRequiredCondition records a condition, panicking on names that are not fields of the object
+// so a typo cannot silently disable the rule
+func (o *objectSchema) addRequiredCondition(method, field, dependsOn string, values []interface{}, unless bool) {
+	for _, name := range []string{field, dependsOn} {
+		if _, ok := o.schema[name]; !ok {
+			panic(fmt.Sprintf("validators: %s: object has no field %q", method, name))
+		}
+	}
+	o.conditions = append(o.conditions, requiredCondition{
+		field:     field,
+		dependsOn: dependsOn,
+		values:    values,
+		unless:    unless,
+	})
+}
+
+// checkRequiredConditions reports missing fields that a condition makes required
+// lookup returns the value of a property and whether it is present. Fields already reported
+// as missing are skipped, and partial objects have no required fields.
+func (o *objectSchema) checkRequiredConditions(lookup func(name string) (interface{}, bool), collector *errorCollector) {
+	if o.partialMode {
+		return
+	}
+	for _, condition := range o.conditions {
+		if _, exists := lookup(condition.field); exists || collector.has(condition.field) {
+			continue
+		}
+		if condition.requires(lookup(condition.dependsOn)) {
+			collector.add(*goop.NewValidationError(condition.field, nil,
+				o.getErrorMessage(errorKeys.RequiredIf, condition.message())))
+		}
+	}
+}
+
+// addRequiredConditions documents the conditions: presence conditions as dependentRequired,
+// and value conditions as if/then subschemas under allOf
+func (o *objectSchema) addRequiredConditions(schema *goop.OpenAPISchema) {
+	if o.partialMode {
+		return
+	}
+	for _, condition := range o.conditions {
+		if len(condition.values) == 0 && !condition.unless {
+			if schema.DependentRequired == nil {
+				schema.DependentRequired = make(map[string][]string)
+			}
+			schema.DependentRequired[condition.dependsOn] = append(schema.DependentRequired[condition.dependsOn], condition.field)
+			continue
+		}
+		schema.AllOf = append(schema.AllOf, condition.openAPISchema())
+	}
+}
+
+// RequiredIf makes field required when dependsOn is present and, if values are given, equal to one of them.
+// Without values the rule is documented as dependentRequired; with values it is documented as an
+// if/then subschema. RequiredIf panics if either name is not a field of the object.
+//
+// Example:
+//
+//	delivery := validators.Object(map[string]interface{}{
+//	    "method":  validators.String().Required(),
+//	    "send_at": validators.String().Format("date-time").Optional(),
+//	}).RequiredIf("send_at", "method", "scheduled").Required()
+func (o *objectSchema) RequiredIf(field, dependsOn string, values ...interface{}) ObjectBuilder {
+	o.addRequiredCondition("RequiredIf", field, dependsOn, values, false)
+	return o
+}
+
+// RequiredUnless makes field required unless dependsOn is present and, if values are given, equal to
+// one of them. RequiredUnless panics if either name is not a field of the object.
+//
+// Example:
+//
+//	// A shipping address is needed for everything but digital goods
+//	order.RequiredUnless("shipping_address", "kind", "digital")
+func (o *objectSchema) RequiredUnless(field, dependsOn string, values ...interface{}) ObjectBuilder {
+	o.addRequiredCondition("RequiredUnless", field, dependsOn, values, true)
+	return o
+}
+
+func (r *requiredObjectSchema) RequiredIf(field, dependsOn string, values ...interface{}) RequiredObjectBuilder {
+	r.addRequiredCondition("RequiredIf", field, dependsOn, values, false)
+	return r
+}
+
+func (r *requiredObjectSchema) RequiredUnless(field, dependsOn string, values ...interface{}) RequiredObjectBuilder {
+	r.addRequiredCondition("RequiredUnless", field, dependsOn, values, true)
+	return r
+}
+
+func (o *optionalObjectSchema) RequiredIf(field, dependsOn string, values ...interface{}) OptionalObjectBuilder {
+	o.addRequiredCondition("RequiredIf", field, dependsOn, values, false)
+	return o
+}
+
+func (o *optionalObjectSchema) RequiredUnless(field, dependsOn string, values ...interface{}) OptionalObjectBuilder {
+	o.addRequiredCondition("RequiredUnless", field, dependsOn, values, true)
+	return o
+}
@@ -0,0 +1,175 @@
+package validators
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	goop "github.com/picogrid/go-op"
+)
+
+func deliverySchema() RequiredObjectBuilder {
+	return Object(map[string]interface{}{
+		"method":   String().Required(),
+		"send_at":  String().Optional(),
+		"channel":  String().Optional(),
+		"address":  String().Optional(),
+		"priority": Number().Optional(),
+		"escalate": Bool().Optional(),
+	}).
+		RequiredIf("send_at", "method", "scheduled").
+		RequiredIf("escalate", "priority", 1, 2).
+		RequiredIf("address", "channel").
+		RequiredUnless("channel", "method", "now").
+		Required()
+}
+
+// TestRequiredConditions tests RequiredIf and RequiredUnless across the validation paths
+func TestRequiredConditions(t *testing.T) {
+	valid := []map[string]interface{}{
+		{"method": "now"},
+		{"method": "scheduled", "send_at": "2025-01-01T09:00:00Z", "channel": "sms", "address": "+15550100"},
+		{"method": "now", "priority": 3.0},
+		{"method": "now", "priority": 1.0, "escalate": true},
+	}
+	invalid := map[string][]map[string]interface{}{
+		"send_at":  {{"method": "scheduled", "channel": "sms", "address": "+15550100"}},
+		"escalate": {{"method": "now", "priority": 2.0}, {"method": "now", "priority": json.Number("1")}},
+		"address":  {{"method": "now", "channel": "email"}},
+		"channel":  {{"method": "later"}},
+	}
+
+	schema := deliverySchema()
+	validators := map[string]goop.Schema{"Validate": schema, "Compile": Compile(schema)}
+	for name, validator := range validators {
+		t.Run(name, func(t *testing.T) {
+			for _, data := range valid {
+				if err := validator.Validate(data); err != nil {
+					t.Errorf("Expected %v to be valid, got: %v", data, err)
+				}
+			}
+			for field, cases := range invalid {
+				for _, data := range cases {
+					err := validator.Validate(data)
+					if err == nil || !strings.Contains(err.Error(), "missing required field: "+field) {
+						t.Errorf("Expected %v to require %s, got: %v", data, field, err)
+					}
+				}
+			}
+		})
+	}
+
+	t.Run("ValidateStream", func(t *testing.T) {
+		for _, data := range valid {
+			body, _ := json.Marshal(data)
+			if err := ValidateStream(schema, bytes.NewReader(body)); err != nil {
+				t.Errorf("Expected %s to be valid, got: %v", body, err)
+			}
+		}
+		for field, cases := range invalid {
+			for _, data := range cases {
+				body, _ := json.Marshal(data)
+				err := ValidateStream(schema, bytes.NewReader(body))
+				if err == nil || !strings.Contains(err.Error(), "missing required field: "+field) {
+					t.Errorf("Expected %s to require %s, got: %v", body, field, err)
+				}
+			}
+		}
+	})
+
+	t.Run("Messages", func(t *testing.T) {
+		err := schema.Validate(map[string]interface{}{"method": "scheduled", "channel": "sms", "address": "x"})
+		if err == nil || !strings.Contains(err.Error(), "required when method is scheduled") {
+			t.Errorf("Expected the condition in the message, got: %v", err)
+		}
+		custom := Object(map[string]interface{}{
+			"method":  String().Required(),
+			"send_at": String().Optional(),
+		}).RequiredIf("send_at", "method", "scheduled").WithMessage(ErrRequiredIf, "pick a delivery time").Required()
+		err = custom.Validate(map[string]interface{}{"method": "scheduled"})
+		if err == nil || !strings.Contains(err.Error(), "pick a delivery time") {
+			t.Errorf("Expected custom message, got: %v", err)
+		}
+	})
+
+	t.Run("Partial objects and derived schemas", func(t *testing.T) {
+		if err := Object(map[string]interface{}{
+			"method":  String().Required(),
+			"send_at": String().Optional(),
+		}).RequiredIf("send_at", "method", "scheduled").Partial().Required().Validate(map[string]interface{}{"method": "scheduled"}); err != nil {
+			t.Errorf("Expected partial object to skip conditions, got: %v", err)
+		}
+
+		withoutAddress := schema.Omit("address").Required()
+		if err := withoutAddress.Validate(map[string]interface{}{"method": "now", "channel": "email"}); err != nil {
+			t.Errorf("Expected conditions on omitted fields to be dropped, got: %v", err)
+		}
+		if err := withoutAddress.Validate(map[string]interface{}{"method": "scheduled", "channel": "sms"}); err == nil {
+			t.Error("Expected remaining conditions to carry over")
+		}
+	})
+
+	t.Run("Unknown fields panic", func(t *testing.T) {
+		assertPanics(t, "RequiredIf", func() {
+			Object(map[string]interface{}{"method": String()}).RequiredIf("send_at", "method", "scheduled")
+		})
+		assertPanics(t, "RequiredUnless", func() {
+			Object(map[string]interface{}{"send_at": String()}).RequiredUnless("send_at", "method")
+		})
+	})
+}
+
+func TestRequiredConditionsOpenAPI(t *testing.T) {
+	spec := deliverySchema().(goop.EnhancedSchema).ToOpenAPISchema()
+
+	if got := spec.DependentRequired["channel"]; len(got) != 1 || got[0] != "address" {
+		t.Errorf("Expected dependentRequired channel: [address], got %v", spec.DependentRequired)
+	}
+	if len(spec.AllOf) != 3 {
+		t.Fatalf("Expected 3 conditional subschemas, got %d", len(spec.AllOf))
+	}
+
+	scheduled := spec.AllOf[0]
+	if scheduled.If.Properties["method"].Const != "scheduled" || scheduled.Then.Required[0] != "send_at" {
+		t.Errorf("Expected if method const scheduled then send_at, got %+v", scheduled)
+	}
+	if priority := spec.AllOf[1].If.Properties["priority"]; len(priority.Enum) != 2 {
+		t.Errorf("Expected enum for multiple values, got %+v", priority)
+	}
+	unless := spec.AllOf[2]
+	if unless.Then != nil || unless.Else == nil || unless.Else.Required[0] != "channel" {
+		t.Errorf("Expected if/else for RequiredUnless, got %+v", unless)
+	}
+
+	t.Run("Generated values satisfy conditions", func(t *testing.T) {
+		schema := deliverySchema()
+		for seed := int64(0); seed < 50; seed++ {
+			value, err := goop.Generate(schema, goop.WithSeed(seed))
+			if err != nil {
+				t.Fatalf("Seed %d: expected no error, got %v", seed, err)
+			}
+			if err := schema.Validate(value); err != nil {
+				t.Errorf("Seed %d: expected generated value %v to be valid, got %v", seed, value, err)
+			}
+		}
+	})
+
+	t.Run("Imported dependentRequired", func(t *testing.T) {
+		imported, err := FromJSONSchema([]byte(`{
+			"type": "object",
+			"properties": {"card": {"type": "string"}, "cvc": {"type": "string"}},
+			"dependentRequired": {"card": ["cvc", "billing_zip"]}
+		}`))
+		if err != nil {
+			t.Fatalf("FromJSONSchema failed: %v", err)
+		}
+		if err := imported.Validate(map[string]interface{}{"cvc": "123"}); err != nil {
+			t.Errorf("Expected object without card to be valid, got: %v", err)
+		}
+		err = imported.Validate(map[string]interface{}{"card": "4242", "cvc": "123"})
+		if err == nil || !strings.Contains(err.Error(), "billing_zip") {
+			t.Errorf("Expected billing_zip to be required with card, got: %v", err)
+		}
+	})
+}
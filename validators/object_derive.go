@@ -0,0 +1,123 @@
+package validators
+
+import (
+	"fmt"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// derive copies the object's fields, field-level settings, deprecated fields, and RequiredIf/RequiredUnless
+// conditions between remaining fields into a new schema in the initial state
+// Rules on the whole object, such as Custom functions, property counts, defaults, and examples,
+// describe the original shape and are not copied.
+func (o *objectSchema) derive(fields map[string]interface{}) *objectSchema {
+	customError := make(map[string]string, len(o.customError))
+	for validationType, message := range o.customError {
+		customError[validationType] = message
+	}
+	// Conditions carry over while both of their fields do
+	var conditions []requiredCondition
+	for _, condition := range o.conditions {
+		_, hasField := fields[condition.field]
+		_, hasDependency := fields[condition.dependsOn]
+		if hasField && hasDependency {
+			conditions = append(conditions, condition)
+		}
+	}
+	var deprecated map[string]string
+	for field, message := range o.deprecated {
+		if _, ok := fields[field]; ok {
+			if deprecated == nil {
+				deprecated = make(map[string]string)
+			}
+			deprecated[field] = message
+		}
+	}
+	return &objectSchema{
+		schema:      fields,
+		strictMode:  o.strictMode,
+		partialMode: o.partialMode,
+		conditions:  conditions,
+		deprecated:  deprecated,
+		customError: customError,
+		fields:      &objectFields{},
+	}
+}
+
+// Extend returns a copy of the object with fields added, replacing fields with the same name
+//
+// Example:
+//
+//	userResponse := userSchema.Extend(map[string]interface{}{
+//	    "id":         validators.String().Required(),
+//	    "created_at": validators.String().Format("date-time").Required(),
+//	}).Required()
+func (o *objectSchema) Extend(fields map[string]interface{}) ObjectBuilder {
+	schema := make(map[string]interface{}, len(o.schema)+len(fields))
+	for name, field := range o.schema {
+		schema[name] = field
+	}
+	for name, field := range fields {
+		schema[name] = field
+	}
+	return o.derive(schema)
+}
+
+// Merge returns a copy of the object with the fields of other, an object schema built with Object
+// Fields of other replace fields with the same name. Merge panics if other is not an object schema.
+func (o *objectSchema) Merge(other goop.Schema) ObjectBuilder {
+	object, ok := asObjectSchema(other)
+	if !ok {
+		panic(fmt.Sprintf("validators: Merge: %T is not an object schema", other))
+	}
+	return o.Extend(object.schema)
+}
+
+// Pick returns a copy of the object with only the given fields
+// Pick panics if a key is not a field of the object, so derived schemas cannot silently drift.
+func (o *objectSchema) Pick(keys ...string) ObjectBuilder {
+	schema := make(map[string]interface{}, len(keys))
+	for _, key := range keys {
+		field, ok := o.schema[key]
+		if !ok {
+			panic(fmt.Sprintf("validators: Pick: object has no field %q", key))
+		}
+		schema[key] = field
+	}
+	return o.derive(schema)
+}
+
+// Omit returns a copy of the object without the given fields
+// Omit panics if a key is not a field of the object, so derived schemas cannot silently drift.
+//
+// Example:
+//
+//	createUser := userSchema.Omit("id", "created_at").Required()
+//	updateUser := userSchema.Omit("id", "created_at").Partial().Required()
+func (o *objectSchema) Omit(keys ...string) ObjectBuilder {
+	schema := make(map[string]interface{}, len(o.schema))
+	for name, field := range o.schema {
+		schema[name] = field
+	}
+	for _, key := range keys {
+		if _, ok := schema[key]; !ok {
+			panic(fmt.Sprintf("validators: Omit: object has no field %q", key))
+		}
+		delete(schema, key)
+	}
+	return o.derive(schema)
+}
+
+// asObjectSchema returns the object schema behind a schema built with Object
+func asObjectSchema(schema interface{}) (*objectSchema, bool) {
+	switch s := schema.(type) {
+	case *objectSchema:
+		return s, true
+	case *requiredObjectSchema:
+		return s.objectSchema, true
+	case *optionalObjectSchema:
+		return s.objectSchema, true
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,87 @@
+package validators
+
+import (
+	"testing"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// TestObjectDerivation tests deriving object schemas with Extend, Merge, Pick, Omit, and Partial
+func TestObjectDerivation(t *testing.T) {
+	user := Object(map[string]interface{}{
+		"id":       String().Required(),
+		"name":     String().Min(1).Required(),
+		"email":    String().Email().Required(),
+		"password": String().Min(8).Required(),
+	}).Strict().Required()
+
+	t.Run("Omit drops fields", func(t *testing.T) {
+		response := user.Omit("password").Required()
+		if err := response.Validate(map[string]interface{}{"id": "u1", "name": "Ada", "email": "ada@example.com"}); err != nil {
+			t.Errorf("Expected valid response, got: %v", err)
+		}
+		// Strict mode carries over, so the omitted field is rejected
+		if err := response.Validate(map[string]interface{}{"id": "u1", "name": "Ada", "email": "ada@example.com", "password": "secret123"}); err == nil {
+			t.Error("Expected omitted field to be rejected")
+		}
+	})
+
+	t.Run("Pick keeps fields", func(t *testing.T) {
+		login := user.Pick("email", "password").Required()
+		spec := login.(goop.EnhancedSchema).ToOpenAPISchema()
+		if len(spec.Properties) != 2 || spec.Properties["email"] == nil || spec.Properties["password"] == nil {
+			t.Errorf("Expected email and password properties, got %v", spec.Properties)
+		}
+	})
+
+	t.Run("Partial makes derived fields optional", func(t *testing.T) {
+		update := user.Omit("id").Partial().Required()
+		if err := update.Validate(map[string]interface{}{"name": "Grace"}); err != nil {
+			t.Errorf("Expected partial update to be valid, got: %v", err)
+		}
+		if err := update.Validate(map[string]interface{}{"name": ""}); err == nil {
+			t.Error("Expected field rules to still apply")
+		}
+		if required := update.(goop.EnhancedSchema).ToOpenAPISchema().Required; len(required) != 0 {
+			t.Errorf("Expected no required fields in the spec, got %v", required)
+		}
+	})
+
+	t.Run("Extend and Merge add fields", func(t *testing.T) {
+		audit := Object(map[string]interface{}{
+			"created_at": String().Required(),
+		}).Required()
+		admin := user.Extend(map[string]interface{}{"role": String().Required()}).Merge(audit).Required()
+		spec := admin.(goop.EnhancedSchema).ToOpenAPISchema()
+		for _, name := range []string{"id", "role", "created_at"} {
+			if spec.Properties[name] == nil {
+				t.Errorf("Expected %s property, got %v", name, spec.Properties)
+			}
+		}
+	})
+
+	t.Run("Derivation leaves the original unchanged", func(t *testing.T) {
+		user.Omit("password").Partial()
+		spec := user.(goop.EnhancedSchema).ToOpenAPISchema()
+		if len(spec.Properties) != 4 || len(spec.Required) != 4 {
+			t.Errorf("Expected the canonical schema to be unchanged, got %v", spec)
+		}
+	})
+
+	t.Run("Unknown fields panic", func(t *testing.T) {
+		for name, derive := range map[string]func(){
+			"Pick":  func() { user.Pick("nickname") },
+			"Omit":  func() { user.Omit("nickname") },
+			"Merge": func() { user.Merge(String().Required()) },
+		} {
+			t.Run(name, func(t *testing.T) {
+				defer func() {
+					if recover() == nil {
+						t.Error("Expected panic")
+					}
+				}()
+				derive()
+			})
+		}
+	})
+}
@@ -0,0 +1,113 @@
+package validators
+
+import (
+	"context"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// TypedObjectBuilder builds an object schema for struct type T with typed field schemas.
+// Unlike Object(map[string]interface{}{...}), fields only accept finalized schemas, so passing
+// a builder that has not been made Required() or Optional() is a compile error.
+// Field names are checked against the JSON names of T's fields when the builder is configured.
+type TypedObjectBuilder[T any] struct {
+	structBuilder *StructSchemaBuilder[T]
+	options       []func(ObjectBuilder) ObjectBuilder
+}
+
+// ObjectOf creates a typed object builder for struct type T.
+//
+// Example:
+//
+//	userSchema := ObjectOf[User]().
+//	    Field("email", String().Email().Required()).
+//	    Field("age", Number().Min(18).Optional()).
+//	    Required()
+func ObjectOf[T any]() *TypedObjectBuilder[T] {
+	return &TypedObjectBuilder[T]{structBuilder: ForStruct[T]()}
+}
+
+// Field sets the schema for the JSON field name of T.
+// It panics when T is a struct without a field of that JSON name.
+func (b *TypedObjectBuilder[T]) Field(name string, schema goop.Schema) *TypedObjectBuilder[T] {
+	b.structBuilder.Field(name, schema)
+	return b
+}
+
+// Embed validates a nested struct field with a typed builder, as StructSchemaBuilder.Embed does
+func (b *TypedObjectBuilder[T]) Embed(name string, nested NestedStruct) *TypedObjectBuilder[T] {
+	b.structBuilder.Embed(name, nested)
+	return b
+}
+
+// SliceField validates each element of a slice field with a typed builder, as StructSchemaBuilder.SliceField does
+func (b *TypedObjectBuilder[T]) SliceField(name string, item NestedStruct) *TypedObjectBuilder[T] {
+	b.structBuilder.SliceField(name, item)
+	return b
+}
+
+// AutoFields derives schemas for fields that are not set explicitly, as StructSchemaBuilder.AutoFields does
+func (b *TypedObjectBuilder[T]) AutoFields() *TypedObjectBuilder[T] {
+	b.structBuilder.AutoFields()
+	return b
+}
+
+// Strict rejects properties that are not fields of the schema
+func (b *TypedObjectBuilder[T]) Strict() *TypedObjectBuilder[T] {
+	b.options = append(b.options, func(o ObjectBuilder) ObjectBuilder { return o.Strict() })
+	return b
+}
+
+// Custom adds a validation function that runs on the whole object after its fields pass
+func (b *TypedObjectBuilder[T]) Custom(fn func(map[string]interface{}) error, extensions ...goop.SchemaExtension) *TypedObjectBuilder[T] {
+	b.options = append(b.options, func(o ObjectBuilder) ObjectBuilder { return o.Custom(fn, extensions...) })
+	return b
+}
+
+// CustomCtx adds a validation function that runs on the whole object with the context passed to ValidateCtx
+func (b *TypedObjectBuilder[T]) CustomCtx(fn func(context.Context, map[string]interface{}) error) *TypedObjectBuilder[T] {
+	b.options = append(b.options, func(o ObjectBuilder) ObjectBuilder { return o.CustomCtx(fn) })
+	return b
+}
+
+// Example sets an example value for OpenAPI documentation
+func (b *TypedObjectBuilder[T]) Example(value interface{}) *TypedObjectBuilder[T] {
+	b.options = append(b.options, func(o ObjectBuilder) ObjectBuilder { return o.Example(value) })
+	return b
+}
+
+// Title sets a title for OpenAPI documentation
+func (b *TypedObjectBuilder[T]) Title(title string) *TypedObjectBuilder[T] {
+	b.options = append(b.options, func(o ObjectBuilder) ObjectBuilder { return o.Title(title) })
+	return b
+}
+
+// Description sets a description for OpenAPI documentation
+func (b *TypedObjectBuilder[T]) Description(description string) *TypedObjectBuilder[T] {
+	b.options = append(b.options, func(o ObjectBuilder) ObjectBuilder { return o.Description(description) })
+	return b
+}
+
+// Required finalizes the builder as a required object schema
+func (b *TypedObjectBuilder[T]) Required() RequiredObjectBuilder {
+	return b.object().Required()
+}
+
+// Optional finalizes the builder as an optional object schema
+func (b *TypedObjectBuilder[T]) Optional() OptionalObjectBuilder {
+	return b.object().Optional()
+}
+
+// object returns the configured ObjectBuilder
+func (b *TypedObjectBuilder[T]) object() ObjectBuilder {
+	fields := b.structBuilder.fields
+	if b.structBuilder.auto {
+		fields = b.structBuilder.mergedFields()
+	}
+
+	builder := Object(fields)
+	for _, option := range b.options {
+		builder = option(builder)
+	}
+	return builder
+}
@@ -0,0 +1,86 @@
+package validators
+
+import (
+	"testing"
+
+	goop "github.com/picogrid/go-op"
+)
+
+type objectOfUser struct {
+	Email   string        `json:"email"`
+	Age     int           `json:"age,omitempty"`
+	Address nestedAddress `json:"address"`
+}
+
+func TestObjectOf(t *testing.T) {
+	schema := ObjectOf[objectOfUser]().
+		Field("email", String().Email().Required()).
+		Field("age", Number().Min(18).Optional()).
+		Embed("address", ForStruct[nestedAddress]().Field("city", String().Required())).
+		Strict().
+		Required()
+
+	valid := map[string]interface{}{
+		"email":   "user@example.com",
+		"address": map[string]interface{}{"city": "Berlin"},
+	}
+
+	t.Run("Valid object", func(t *testing.T) {
+		if err := schema.Validate(valid); err != nil {
+			t.Errorf("Expected valid object to pass, got %v", err)
+		}
+	})
+
+	t.Run("Field validation", func(t *testing.T) {
+		if err := schema.Validate(withField(valid, "age", float64(10))); err == nil {
+			t.Error("Expected age below minimum to fail")
+		}
+		if err := schema.Validate(withField(valid, "email", "nope")); err == nil {
+			t.Error("Expected invalid email to fail")
+		}
+		if err := schema.Validate(withField(valid, "address", map[string]interface{}{})); err == nil {
+			t.Error("Expected invalid embedded struct to fail")
+		}
+	})
+
+	t.Run("Strict mode", func(t *testing.T) {
+		if err := schema.Validate(withField(valid, "unknown", true)); err == nil {
+			t.Error("Expected unknown key to fail in strict mode")
+		}
+	})
+
+	t.Run("Optional object", func(t *testing.T) {
+		optional := ObjectOf[objectOfUser]().Field("email", String().Required()).Optional()
+		if err := optional.Validate(nil); err != nil {
+			t.Errorf("Expected nil to pass for optional object, got %v", err)
+		}
+	})
+
+	t.Run("AutoFields with override", func(t *testing.T) {
+		auto := ObjectOf[objectOfUser]().AutoFields().
+			Field("email", String().Email().Required()).
+			Required()
+		if err := auto.Validate(withField(valid, "address", map[string]interface{}{"city": "Berlin", "zip": "10115"})); err != nil {
+			t.Errorf("Expected valid object to pass, got %v", err)
+		}
+		if err := auto.Validate(withField(valid, "age", "old")); err == nil {
+			t.Error("Expected derived age validator to reject strings")
+		}
+	})
+
+	t.Run("OpenAPI generation", func(t *testing.T) {
+		openAPI := schema.(goop.EnhancedSchema).ToOpenAPISchema()
+		if openAPI.Properties["email"] == nil || openAPI.Properties["email"].Format != "email" {
+			t.Errorf("Expected email property with email format, got %+v", openAPI.Properties)
+		}
+	})
+
+	t.Run("Unknown field panics", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected panic for unknown field name")
+			}
+		}()
+		ObjectOf[objectOfUser]().Field("mail", String().Required())
+	})
+}
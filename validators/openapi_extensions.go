@@ -14,18 +14,29 @@ func (s *stringSchema) ToOpenAPISchema() *goop.OpenAPISchema {
 	}
 
 	// Add format constraints
-	if s.emailFormat {
+	switch {
+	case s.format != "":
+		schema.Format = s.format
+	case s.emailFormat:
 		schema.Format = "email"
-	} else if s.urlFormat {
+	case s.urlFormat:
 		schema.Format = "uri"
 	}
 
 	// Add length constraints
+	// JSON Schema lengths count characters, so only rune limits are minLength and maxLength;
+	// Min and Max count bytes and are documented with the byte limit extensions
+	if s.minRunes > 0 {
+		schema.MinLength = &s.minRunes
+	}
+	if s.maxRunes > 0 {
+		schema.MaxLength = &s.maxRunes
+	}
 	if s.minLength > 0 {
-		schema.MinLength = &s.minLength
+		schema.MinBytes = &s.minLength
 	}
-	if s.maxLength > 0 {
-		schema.MaxLength = &s.maxLength
+	if maxBytes := stricterLimit(s.maxLength, s.maxBytes); maxBytes > 0 {
+		schema.MaxBytes = &maxBytes
 	}
 
 	// Add pattern constraint
@@ -33,11 +44,24 @@ func (s *stringSchema) ToOpenAPISchema() *goop.OpenAPISchema {
 		schema.Pattern = s.pattern.String()
 	}
 
+	// Add encoded content keywords
+	if s.encoding != "" {
+		s.addEncodingSchema(schema)
+	}
+
 	// Add const constraint
 	if s.constValue != nil {
 		schema.Const = *s.constValue
 	}
 
+	// Add enum constraint
+	if s.enum != nil {
+		schema.Enum = make([]interface{}, len(s.enum.values))
+		for i, value := range s.enum.values {
+			schema.Enum[i] = value
+		}
+	}
+
 	// Add default value for optional schemas
 	if s.defaultValue != nil {
 		schema.Default = *s.defaultValue
@@ -47,6 +71,10 @@ func (s *stringSchema) ToOpenAPISchema() *goop.OpenAPISchema {
 	if s.example != nil {
 		schema.Example = s.example
 	}
+	s.docs.apply(schema)
+
+	// Add extensions given to Custom
+	addSchemaExtensions(schema, s.extensions)
 
 	return schema
 }
@@ -71,6 +99,15 @@ func (s *stringSchema) GetValidationInfo() *goop.ValidationInfo {
 	if s.maxLength > 0 {
 		info.Constraints["maxLength"] = s.maxLength
 	}
+	if s.minRunes > 0 {
+		info.Constraints["minRunes"] = s.minRunes
+	}
+	if s.maxRunes > 0 {
+		info.Constraints["maxRunes"] = s.maxRunes
+	}
+	if s.maxBytes > 0 {
+		info.Constraints["maxBytes"] = s.maxBytes
+	}
 	if s.pattern != nil {
 		info.Constraints["pattern"] = s.pattern.String()
 	}
@@ -80,6 +117,12 @@ func (s *stringSchema) GetValidationInfo() *goop.ValidationInfo {
 	if s.urlFormat {
 		info.Constraints["format"] = "uri"
 	}
+	if s.format != "" {
+		info.Constraints["format"] = s.format
+	}
+	if s.encoding != "" {
+		info.Constraints["encoding"] = s.encoding
+	}
 
 	return info
 }
@@ -136,6 +179,11 @@ func (n *numberSchema) ToOpenAPISchema() *goop.OpenAPISchema {
 		schema.MultipleOf = n.multipleOfValue
 	}
 
+	// Add const constraint
+	if n.constValue != nil {
+		schema.Const = *n.constValue
+	}
+
 	// Handle positive/negative constraints
 	if n.positiveOnly && (n.minValue == nil || *n.minValue <= 0) {
 		zero := 0.0
@@ -155,6 +203,10 @@ func (n *numberSchema) ToOpenAPISchema() *goop.OpenAPISchema {
 	if n.example != nil {
 		schema.Example = n.example
 	}
+	n.docs.apply(schema)
+
+	// Add extensions given to Custom
+	addSchemaExtensions(schema, n.extensions)
 
 	return schema
 }
@@ -231,6 +283,21 @@ func (a *arraySchema) ToOpenAPISchema() *goop.OpenAPISchema {
 		schema.UniqueItems = &a.uniqueItems
 	}
 
+	// Add contains constraints; literal values are matched with const
+	if a.contains != nil {
+		if generator, ok := a.contains.(goop.OpenAPIGenerator); ok {
+			schema.Contains = generator.ToOpenAPISchema()
+		} else if !a.containsSchema() {
+			schema.Contains = &goop.OpenAPISchema{Const: a.contains}
+		}
+		if schema.Contains != nil && a.containsMin != 1 {
+			schema.MinContains = &a.containsMin
+		}
+		if schema.Contains != nil && a.containsMax > 0 {
+			schema.MaxContains = &a.containsMax
+		}
+	}
+
 	// Generate schema for array items
 	if a.elementSchema != nil {
 		if enhancedElement, ok := a.elementSchema.(goop.EnhancedSchema); ok {
@@ -250,6 +317,10 @@ func (a *arraySchema) ToOpenAPISchema() *goop.OpenAPISchema {
 	if a.example != nil {
 		schema.Example = a.example
 	}
+	a.docs.apply(schema)
+
+	// Add extensions given to Custom
+	addSchemaExtensions(schema, a.extensions)
 
 	return schema
 }
@@ -277,6 +348,9 @@ func (a *arraySchema) GetValidationInfo() *goop.ValidationInfo {
 	if a.contains != nil {
 		info.Constraints["contains"] = true
 	}
+	if len(a.uniqueBy) > 0 {
+		info.Constraints["uniqueBy"] = a.uniqueBy
+	}
 
 	return info
 }
@@ -313,11 +387,15 @@ func (obj *objectSchema) ToOpenAPISchema() *goop.OpenAPISchema {
 	for fieldName, fieldSchema := range obj.schema {
 		if enhancedField, ok := fieldSchema.(goop.EnhancedSchema); ok {
 			propertySchema := enhancedField.ToOpenAPISchema()
+			if _, ok := obj.deprecated[fieldName]; ok {
+				deprecated := true
+				propertySchema.Deprecated = &deprecated
+			}
 			schema.Properties[fieldName] = propertySchema
 
-			// Check if this field is required
+			// Check if this field is required; partial objects make every field optional
 			validationInfo := enhancedField.GetValidationInfo()
-			if validationInfo.Required {
+			if validationInfo.Required && !obj.partialMode {
 				schema.Required = append(schema.Required, fieldName)
 			}
 		} else {
@@ -326,6 +404,11 @@ func (obj *objectSchema) ToOpenAPISchema() *goop.OpenAPISchema {
 		}
 	}
 
+	// Add const constraint
+	if obj.constValue != nil {
+		schema.Const = obj.constValue
+	}
+
 	// Add property count constraints
 	if obj.minProperties > 0 {
 		schema.MinProperties = &obj.minProperties
@@ -333,11 +416,16 @@ func (obj *objectSchema) ToOpenAPISchema() *goop.OpenAPISchema {
 	if obj.maxProperties > 0 {
 		schema.MaxProperties = &obj.maxProperties
 	}
+	obj.addRequiredConditions(schema)
 
 	// Add example information
 	if obj.example != nil {
 		schema.Example = obj.example
 	}
+	obj.docs.apply(schema)
+
+	// Add extensions given to Custom
+	addSchemaExtensions(schema, obj.extensions)
 
 	return schema
 }
@@ -383,6 +471,11 @@ func (b *boolSchema) ToOpenAPISchema() *goop.OpenAPISchema {
 		Type: "boolean",
 	}
 
+	// Add const constraint
+	if b.constValue != nil {
+		schema.Const = *b.constValue
+	}
+
 	// Add default value for optional schemas
 	if b.defaultValue != nil {
 		schema.Default = *b.defaultValue
@@ -392,6 +485,10 @@ func (b *boolSchema) ToOpenAPISchema() *goop.OpenAPISchema {
 	if b.example != nil {
 		schema.Example = b.example
 	}
+	b.docs.apply(schema)
+
+	// Add extensions given to Custom
+	addSchemaExtensions(schema, b.extensions)
 
 	return schema
 }
@@ -496,3 +593,18 @@ var (
 	_ EnhancedRequiredBoolBuilder   = (*requiredBoolSchema)(nil)
 	_ EnhancedOptionalBoolBuilder   = (*optionalBoolSchema)(nil)
 )
+
+// addSchemaExtensions sets the specification extensions given to Custom on a generated schema
+func addSchemaExtensions(schema *goop.OpenAPISchema, extensions []goop.SchemaExtension) {
+	for _, extension := range extensions {
+		schema.SetExtension(extension.Name, extension.Value)
+	}
+}
+
+// stricterLimit returns the smaller of two upper limits, where zero means unlimited
+func stricterLimit(a, b int) int {
+	if a == 0 || (b != 0 && b < a) {
+		return b
+	}
+	return a
+}
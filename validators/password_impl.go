@@ -0,0 +1,406 @@
+package validators
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// defaultPasswordMinLength follows the NIST SP 800-63B minimum for user-chosen passwords
+const defaultPasswordMinLength = 8
+
+// Character class pool sizes used to estimate entropy
+const (
+	lowercasePool = 26
+	uppercasePool = 26
+	digitPool     = 10
+	symbolPool    = 33
+)
+
+// Core password schema struct (unexported)
+// This contains the strength policy and is wrapped by state-specific types
+type passwordSchema struct {
+	minLength   int
+	maxLength   int
+	minEntropy  float64
+	minClasses  int
+	denyList    map[string]struct{}
+	customFunc  func(string) error
+	extensions  []goop.SchemaExtension
+	required    bool
+	optional    bool
+	customError map[string]string
+	docs        schemaDocs
+}
+
+// State wrapper types for compile-time safety
+type requiredPasswordSchema struct {
+	*passwordSchema
+}
+
+type optionalPasswordSchema struct {
+	*passwordSchema
+}
+
+// Password creates a string validator enforcing a password strength policy.
+// Passwords must have at least 8 characters unless Min says otherwise. The schema is documented
+// with format password and writeOnly, so documentation tools mask it and omit it from responses,
+// and validation errors never include the password.
+//
+// Example:
+//
+//	"password": validators.Password().
+//	    MinEntropy(60).
+//	    RequireClasses(3).
+//	    DenyList(commonPasswords).
+//	    Required()
+func Password() PasswordBuilder {
+	return &passwordSchema{
+		minLength:   defaultPasswordMinLength,
+		customError: make(map[string]string),
+	}
+}
+
+// PasswordBuilder implementation (initial state)
+
+// Min sets the minimum length in characters
+func (p *passwordSchema) Min(length int) PasswordBuilder {
+	p.minLength = length
+	return p
+}
+
+// Max sets the maximum length in characters, such as 72 for bcrypt
+func (p *passwordSchema) Max(length int) PasswordBuilder {
+	p.maxLength = length
+	return p
+}
+
+// MinEntropy requires an estimated strength of at least bits, computed as the length times
+// log2 of the size of the character classes used; "Tr0ub4dor&3" scores about 72 bits
+func (p *passwordSchema) MinEntropy(bits float64) PasswordBuilder {
+	p.minEntropy = bits
+	return p
+}
+
+// RequireClasses requires characters from at least count of the four classes: lowercase letters,
+// uppercase letters, digits, and symbols
+func (p *passwordSchema) RequireClasses(count int) PasswordBuilder {
+	p.minClasses = count
+	return p
+}
+
+// DenyList rejects the given passwords, compared case-insensitively; calls add to the list
+func (p *passwordSchema) DenyList(passwords []string) PasswordBuilder {
+	p.addDenyList(passwords)
+	return p
+}
+
+func (p *passwordSchema) Custom(fn func(string) error, extensions ...goop.SchemaExtension) PasswordBuilder {
+	p.extensions = append(p.extensions, extensions...)
+	p.customFunc = fn
+	return p
+}
+
+// State transition methods - these change the return type to enforce compile-time safety
+func (p *passwordSchema) Required() RequiredPasswordBuilder {
+	p.required = true
+	p.optional = false
+	return &requiredPasswordSchema{p}
+}
+
+func (p *passwordSchema) Optional() OptionalPasswordBuilder {
+	p.optional = true
+	p.required = false
+	return &optionalPasswordSchema{p}
+}
+
+// Error message methods for PasswordBuilder
+func (p *passwordSchema) WithMessage(validationType, message string) PasswordBuilder {
+	p.customError[validationType] = message
+	return p
+}
+
+func (p *passwordSchema) WithMinMessage(message string) PasswordBuilder {
+	return p.WithMessage(errorKeys.MinLength, message)
+}
+
+func (p *passwordSchema) WithMaxMessage(message string) PasswordBuilder {
+	return p.WithMessage(errorKeys.MaxLength, message)
+}
+
+// RequiredPasswordBuilder implementation
+
+func (r *requiredPasswordSchema) Min(length int) RequiredPasswordBuilder {
+	r.minLength = length
+	return r
+}
+
+func (r *requiredPasswordSchema) Max(length int) RequiredPasswordBuilder {
+	r.maxLength = length
+	return r
+}
+
+func (r *requiredPasswordSchema) MinEntropy(bits float64) RequiredPasswordBuilder {
+	r.minEntropy = bits
+	return r
+}
+
+func (r *requiredPasswordSchema) RequireClasses(count int) RequiredPasswordBuilder {
+	r.minClasses = count
+	return r
+}
+
+func (r *requiredPasswordSchema) DenyList(passwords []string) RequiredPasswordBuilder {
+	r.addDenyList(passwords)
+	return r
+}
+
+func (r *requiredPasswordSchema) Custom(fn func(string) error, extensions ...goop.SchemaExtension) RequiredPasswordBuilder {
+	r.extensions = append(r.extensions, extensions...)
+	r.customFunc = fn
+	return r
+}
+
+func (r *requiredPasswordSchema) WithMessage(validationType, message string) RequiredPasswordBuilder {
+	r.customError[validationType] = message
+	return r
+}
+
+func (r *requiredPasswordSchema) WithMinMessage(message string) RequiredPasswordBuilder {
+	return r.WithMessage(errorKeys.MinLength, message)
+}
+
+func (r *requiredPasswordSchema) WithMaxMessage(message string) RequiredPasswordBuilder {
+	return r.WithMessage(errorKeys.MaxLength, message)
+}
+
+func (r *requiredPasswordSchema) WithRequiredMessage(message string) RequiredPasswordBuilder {
+	return r.WithMessage(errorKeys.Required, message)
+}
+
+// OptionalPasswordBuilder implementation
+
+func (o *optionalPasswordSchema) Min(length int) OptionalPasswordBuilder {
+	o.minLength = length
+	return o
+}
+
+func (o *optionalPasswordSchema) Max(length int) OptionalPasswordBuilder {
+	o.maxLength = length
+	return o
+}
+
+func (o *optionalPasswordSchema) MinEntropy(bits float64) OptionalPasswordBuilder {
+	o.minEntropy = bits
+	return o
+}
+
+func (o *optionalPasswordSchema) RequireClasses(count int) OptionalPasswordBuilder {
+	o.minClasses = count
+	return o
+}
+
+func (o *optionalPasswordSchema) DenyList(passwords []string) OptionalPasswordBuilder {
+	o.addDenyList(passwords)
+	return o
+}
+
+func (o *optionalPasswordSchema) Custom(fn func(string) error, extensions ...goop.SchemaExtension) OptionalPasswordBuilder {
+	o.extensions = append(o.extensions, extensions...)
+	o.customFunc = fn
+	return o
+}
+
+func (o *optionalPasswordSchema) WithMessage(validationType, message string) OptionalPasswordBuilder {
+	o.customError[validationType] = message
+	return o
+}
+
+func (o *optionalPasswordSchema) WithMinMessage(message string) OptionalPasswordBuilder {
+	return o.WithMessage(errorKeys.MinLength, message)
+}
+
+func (o *optionalPasswordSchema) WithMaxMessage(message string) OptionalPasswordBuilder {
+	return o.WithMessage(errorKeys.MaxLength, message)
+}
+
+// Validation methods - these are the final methods in the builder chain
+func (r *requiredPasswordSchema) Validate(data interface{}) error {
+	return r.validate(data)
+}
+
+func (o *optionalPasswordSchema) Validate(data interface{}) error {
+	return o.validate(data)
+}
+
+// Core validation logic (shared between required and optional)
+// Errors report neither the password nor which characters it contains.
+func (p *passwordSchema) validate(data interface{}) error {
+	if data == nil || data == "" {
+		if p.optional {
+			return nil
+		}
+		return goop.NewValidationError("", nil, p.getErrorMessage(errorKeys.Required, "field is required"))
+	}
+
+	password, ok := data.(string)
+	if !ok {
+		return goop.NewValidationError("", nil, p.getErrorMessage(errorKeys.Type, "invalid type, expected string"))
+	}
+
+	length := utf8.RuneCountInString(password)
+	if length < p.minLength {
+		return goop.NewValidationError("", nil, p.getErrorMessage(errorKeys.MinLength,
+			fmt.Sprintf("password must be at least %d characters", p.minLength)))
+	}
+	if p.maxLength > 0 && length > p.maxLength {
+		return goop.NewValidationError("", nil, p.getErrorMessage(errorKeys.MaxLength,
+			fmt.Sprintf("password must be at most %d characters", p.maxLength)))
+	}
+
+	if _, denied := p.denyList[strings.ToLower(password)]; denied {
+		return goop.NewValidationError("", nil, p.getErrorMessage(errorKeys.DenyList,
+			"password is too common, choose a different one"))
+	}
+
+	classes, pool := passwordClasses(password)
+	if classes < p.minClasses {
+		return goop.NewValidationError("", nil, p.getErrorMessage(errorKeys.Classes,
+			fmt.Sprintf("password must contain at least %d of: lowercase letters, uppercase letters, digits, symbols", p.minClasses)))
+	}
+	if p.minEntropy > 0 && float64(length)*math.Log2(float64(pool)) < p.minEntropy {
+		return goop.NewValidationError("", nil, p.getErrorMessage(errorKeys.Entropy,
+			"password is too weak, use a longer password or more kinds of characters"))
+	}
+
+	if p.customFunc != nil {
+		if err := p.customFunc(password); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// passwordClasses counts the character classes in password and the size of the character pool they span
+func passwordClasses(password string) (classes, pool int) {
+	var lower, upper, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			symbol = true
+		}
+	}
+	for _, class := range []struct {
+		present bool
+		size    int
+	}{{lower, lowercasePool}, {upper, uppercasePool}, {digit, digitPool}, {symbol, symbolPool}} {
+		if class.present {
+			classes++
+			pool += class.size
+		}
+	}
+	return classes, pool
+}
+
+func (p *passwordSchema) addDenyList(passwords []string) {
+	if p.denyList == nil {
+		p.denyList = make(map[string]struct{}, len(passwords))
+	}
+	for _, password := range passwords {
+		p.denyList[strings.ToLower(password)] = struct{}{}
+	}
+}
+
+// Helper methods (unexported)
+func (p *passwordSchema) getErrorMessage(validationType, defaultMessage string) string {
+	if msg, exists := p.customError[validationType]; exists {
+		return msg
+	}
+	return defaultMessage
+}
+
+// ToOpenAPISchema documents the password as a masked, write-only string with its length limits
+// The entropy, class, and deny list rules are enforced but not expressible in the schema.
+func (p *passwordSchema) ToOpenAPISchema() *goop.OpenAPISchema {
+	writeOnly := true
+	schema := &goop.OpenAPISchema{
+		Type:      "string",
+		Format:    "password",
+		WriteOnly: &writeOnly,
+	}
+	if p.minLength > 0 {
+		schema.MinLength = &p.minLength
+	}
+	if p.maxLength > 0 {
+		schema.MaxLength = &p.maxLength
+	}
+	p.docs.apply(schema)
+	// Add extensions given to Custom
+	addSchemaExtensions(schema, p.extensions)
+
+	return schema
+}
+
+// GetValidationInfo returns metadata about the password policy
+func (p *passwordSchema) GetValidationInfo() *goop.ValidationInfo {
+	info := &goop.ValidationInfo{
+		Required:    p.required,
+		Optional:    p.optional,
+		Constraints: map[string]interface{}{"format": "password"},
+	}
+
+	if p.minLength > 0 {
+		info.Constraints["minLength"] = p.minLength
+	}
+	if p.maxLength > 0 {
+		info.Constraints["maxLength"] = p.maxLength
+	}
+	if p.minEntropy > 0 {
+		info.Constraints["minEntropy"] = p.minEntropy
+	}
+	if p.minClasses > 0 {
+		info.Constraints["requireClasses"] = p.minClasses
+	}
+	if len(p.denyList) > 0 {
+		info.Constraints["denyList"] = len(p.denyList)
+	}
+
+	return info
+}
+
+// OpenAPI generation methods for the state wrappers
+func (r *requiredPasswordSchema) ToOpenAPISchema() *goop.OpenAPISchema {
+	return r.passwordSchema.ToOpenAPISchema()
+}
+
+func (r *requiredPasswordSchema) GetValidationInfo() *goop.ValidationInfo {
+	return r.passwordSchema.GetValidationInfo()
+}
+
+func (o *optionalPasswordSchema) ToOpenAPISchema() *goop.OpenAPISchema {
+	return o.passwordSchema.ToOpenAPISchema()
+}
+
+func (o *optionalPasswordSchema) GetValidationInfo() *goop.ValidationInfo {
+	return o.passwordSchema.GetValidationInfo()
+}
+
+// Interface compliance checks at compile time
+var (
+	_ PasswordBuilder         = (*passwordSchema)(nil)
+	_ RequiredPasswordBuilder = (*requiredPasswordSchema)(nil)
+	_ OptionalPasswordBuilder = (*optionalPasswordSchema)(nil)
+	_ goop.EnhancedSchema     = (*requiredPasswordSchema)(nil)
+	_ goop.EnhancedSchema     = (*optionalPasswordSchema)(nil)
+)
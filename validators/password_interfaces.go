@@ -0,0 +1,75 @@
+package validators
+
+import goop "github.com/picogrid/go-op"
+
+// PasswordBuilder represents the initial password builder state.
+// From this state, you can configure the strength policy and then transition to
+// either a required or optional state.
+type PasswordBuilder interface {
+	// Policy methods - these return PasswordBuilder to allow chaining
+	Min(length int) PasswordBuilder
+	Max(length int) PasswordBuilder
+	MinEntropy(bits float64) PasswordBuilder
+	RequireClasses(count int) PasswordBuilder
+	DenyList(passwords []string) PasswordBuilder
+	Custom(fn func(string) error, extensions ...goop.SchemaExtension) PasswordBuilder
+
+	// Title and Description document the value in generated specifications
+	Title(title string) PasswordBuilder
+	Description(description string) PasswordBuilder
+
+	// State transition methods - these change the type to prevent invalid chaining
+	Required() RequiredPasswordBuilder
+	Optional() OptionalPasswordBuilder
+
+	// Error message configuration methods
+	WithMessage(validationType, message string) PasswordBuilder
+	WithMinMessage(message string) PasswordBuilder
+	WithMaxMessage(message string) PasswordBuilder
+}
+
+// RequiredPasswordBuilder represents a password builder in the required state.
+type RequiredPasswordBuilder interface {
+	Min(length int) RequiredPasswordBuilder
+	Max(length int) RequiredPasswordBuilder
+	MinEntropy(bits float64) RequiredPasswordBuilder
+	RequireClasses(count int) RequiredPasswordBuilder
+	DenyList(passwords []string) RequiredPasswordBuilder
+	Custom(fn func(string) error, extensions ...goop.SchemaExtension) RequiredPasswordBuilder
+	Title(title string) RequiredPasswordBuilder
+	Description(description string) RequiredPasswordBuilder
+
+	WithMessage(validationType, message string) RequiredPasswordBuilder
+	WithMinMessage(message string) RequiredPasswordBuilder
+	WithMaxMessage(message string) RequiredPasswordBuilder
+	WithRequiredMessage(message string) RequiredPasswordBuilder
+
+	// JSON Schema export
+	ToJSONSchema() ([]byte, error)
+
+	// Validation method - final step in the builder chain
+	Validate(data interface{}) error
+}
+
+// OptionalPasswordBuilder represents a password builder in the optional state.
+// Passwords have no Default, as a shared default password would defeat the policy.
+type OptionalPasswordBuilder interface {
+	Min(length int) OptionalPasswordBuilder
+	Max(length int) OptionalPasswordBuilder
+	MinEntropy(bits float64) OptionalPasswordBuilder
+	RequireClasses(count int) OptionalPasswordBuilder
+	DenyList(passwords []string) OptionalPasswordBuilder
+	Custom(fn func(string) error, extensions ...goop.SchemaExtension) OptionalPasswordBuilder
+	Title(title string) OptionalPasswordBuilder
+	Description(description string) OptionalPasswordBuilder
+
+	WithMessage(validationType, message string) OptionalPasswordBuilder
+	WithMinMessage(message string) OptionalPasswordBuilder
+	WithMaxMessage(message string) OptionalPasswordBuilder
+
+	// JSON Schema export
+	ToJSONSchema() ([]byte, error)
+
+	// Validation method - final step in the builder chain
+	Validate(data interface{}) error
+}
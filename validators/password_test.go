@@ -0,0 +1,90 @@
+package validators
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// TestPassword tests password strength policies
+func TestPassword(t *testing.T) {
+	commonPasswords := []string{"Password123!", "Qwerty123!"}
+	schema := Password().MinEntropy(60).RequireClasses(3).DenyList(commonPasswords).Required()
+
+	for _, password := range []string{"Tr0ub4dor&3", "Gl4ss-Ele7ant", "WELCOMEWELCOME!1", "correct horse battery staple 9"} {
+		if err := schema.Validate(password); err != nil {
+			t.Errorf("Expected %q to be strong enough, got: %v", password, err)
+		}
+	}
+
+	invalid := map[string]string{
+		"Sh0rt!":          "at least 8 characters",
+		"welcomewelcome1": "at least 3 of",
+		"WELCOMEWELCOME1": "at least 3 of",
+		"PASSWORD123!":    "too common",
+		"Aa1!Aa1!":        "too weak",
+		"abcdefghijklmn":  "at least 3 of",
+	}
+	for password, message := range invalid {
+		err := schema.Validate(password)
+		if err == nil || !strings.Contains(err.Error(), message) {
+			t.Errorf("Expected %q to fail with %q, got: %v", password, message, err)
+			continue
+		}
+		if strings.Contains(err.Error(), password) {
+			t.Errorf("Expected error for %q not to include the password: %v", password, err)
+		}
+	}
+
+	t.Run("Nil, empty, and non-string values", func(t *testing.T) {
+		if err := schema.Validate(nil); err == nil {
+			t.Error("Expected required password to reject nil")
+		}
+		if err := Password().Optional().Validate(""); err != nil {
+			t.Errorf("Expected optional password to accept empty value, got: %v", err)
+		}
+		if err := schema.Validate(12345678); err == nil || !strings.Contains(err.Error(), "expected string") {
+			t.Errorf("Expected type error, got: %v", err)
+		}
+	})
+
+	t.Run("Length limits and custom messages", func(t *testing.T) {
+		bcrypt := Password().Min(12).Max(72).WithMinMessage("use 12 or more characters").Required()
+		if err := bcrypt.Validate("elevenchars"); err == nil || !strings.Contains(err.Error(), "use 12 or more characters") {
+			t.Errorf("Expected custom minimum message, got: %v", err)
+		}
+		if err := bcrypt.Validate(strings.Repeat("a", 73)); err == nil || !strings.Contains(err.Error(), "at most 72") {
+			t.Errorf("Expected maximum length error, got: %v", err)
+		}
+		weak := Password().MinEntropy(100).WithMessage(ErrEntropy, "pick a passphrase").Required()
+		if err := weak.Validate("Tr0ub4dor&3"); err == nil || !strings.Contains(err.Error(), "pick a passphrase") {
+			t.Errorf("Expected custom entropy message, got: %v", err)
+		}
+	})
+
+	t.Run("Errors inside objects do not leak the password", func(t *testing.T) {
+		user := Object(map[string]interface{}{
+			"email":    Email(),
+			"password": Password().RequireClasses(4).Required(),
+		}).Required()
+		err := user.Validate(map[string]interface{}{"email": "a@example.com", "password": "hunter2hunter2"})
+		if err == nil || strings.Contains(err.Error(), "hunter2") {
+			t.Errorf("Expected a class error without the password, got: %v", err)
+		}
+	})
+
+	spec := schema.(goop.EnhancedSchema).ToOpenAPISchema()
+	if spec.Type != "string" || spec.Format != "password" || spec.WriteOnly == nil || !*spec.WriteOnly || *spec.MinLength != 8 {
+		t.Errorf("Expected write-only password schema with minLength 8, got %+v", spec)
+	}
+	doc, err := schema.ToJSONSchema()
+	if err != nil {
+		t.Fatalf("ToJSONSchema failed: %v", err)
+	}
+	var exported map[string]interface{}
+	if err := json.Unmarshal(doc, &exported); err != nil || exported["writeOnly"] != true {
+		t.Errorf("Expected exported JSON Schema to be writeOnly, got %s", doc)
+	}
+}
@@ -0,0 +1,820 @@
+package validators
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// preciseKind selects how a precise number is accepted and documented
+type preciseKind int
+
+const (
+	preciseInteger64 preciseKind = iota
+	preciseBigInt
+	preciseDecimal
+)
+
+// String patterns accepted by BigInt and Decimal, and documented in their schemas
+const (
+	integerStringPattern = `^-?(0|[1-9][0-9]*)$`
+	decimalStringPattern = `^-?(0|[1-9][0-9]*)(\.[0-9]+)?$`
+)
+
+var (
+	integerStringRegex = regexp.MustCompile(integerStringPattern)
+	decimalStringRegex = regexp.MustCompile(decimalStringPattern)
+
+	// jsonNumberRegex splits a JSON number into sign, integer digits, fraction digits, and exponent
+	jsonNumberRegex = regexp.MustCompile(`^(-?)(0|[1-9][0-9]*)(?:\.([0-9]+))?(?:[eE]([+-]?[0-9]+))?$`)
+)
+
+// maxDecimalExponent bounds exponents in json.Number values, which would otherwise expand to arbitrarily many digits
+const maxDecimalExponent = 1000
+
+// Core precise number schema struct (unexported)
+// Values are compared as exact decimals rather than float64, and the struct is wrapped by
+// kind- and state-specific types
+type preciseNumberSchema struct {
+	kind         preciseKind
+	minValue     *decimalValue
+	maxValue     *decimalValue
+	minText      string
+	maxText      string
+	digits       int // Total digits allowed by Precision, 0 when unlimited
+	scale        int // Decimal places allowed by Precision
+	required     bool
+	optional     bool
+	defaultValue interface{}
+	customError  map[string]string
+	example      interface{}
+	docs         schemaDocs
+}
+
+// Integer64 creates a validator for 64-bit integers, documented as type integer with format int64.
+// Unlike Number, values are checked without a float64 round trip, so IDs and counters above 2^53
+// decoded as json.Number are validated exactly. Go integer types and whole float64 values are also accepted.
+//
+// Example:
+//
+//	"id": validators.Integer64().Min(1).Required()
+func Integer64() Integer64Builder {
+	return &integer64Schema{newPreciseNumber(preciseInteger64)}
+}
+
+// BigInt creates a validator for integers of any size, exchanged as decimal strings such as
+// "340282366920938463463374607431768211456" so clients that decode JSON numbers as doubles
+// cannot round them. It also accepts json.Number, *big.Int, and Go integer values, and is
+// documented as a string with format bigint and an integer pattern.
+//
+// Example:
+//
+//	"balance": validators.BigInt().Min("0").Required()
+func BigInt() BigIntBuilder {
+	return &bigIntSchema{newPreciseNumber(preciseBigInt)}
+}
+
+// Decimal creates a validator for exact decimal numbers exchanged as strings such as "12.50".
+// It also accepts json.Number and float64 values, comparing their decimal digits exactly, and is
+// documented as a string with format decimal and a pattern reflecting Precision.
+//
+// Example:
+//
+//	// Fits a NUMERIC(10, 2) column
+//	"price": validators.Decimal().Min("0").Precision(10, 2).Required()
+func Decimal() DecimalBuilder {
+	return &decimalSchema{newPreciseNumber(preciseDecimal)}
+}
+
+func newPreciseNumber(kind preciseKind) *preciseNumberSchema {
+	return &preciseNumberSchema{
+		kind:        kind,
+		customError: make(map[string]string),
+	}
+}
+
+// State wrapper types for compile-time safety
+
+type integer64Schema struct {
+	*preciseNumberSchema
+}
+
+type requiredInteger64Schema struct {
+	*preciseNumberSchema
+}
+
+type optionalInteger64Schema struct {
+	*preciseNumberSchema
+}
+
+type bigIntSchema struct {
+	*preciseNumberSchema
+}
+
+type requiredBigIntSchema struct {
+	*preciseNumberSchema
+}
+
+type optionalBigIntSchema struct {
+	*preciseNumberSchema
+}
+
+type decimalSchema struct {
+	*preciseNumberSchema
+}
+
+type requiredDecimalSchema struct {
+	*preciseNumberSchema
+}
+
+type optionalDecimalSchema struct {
+	*preciseNumberSchema
+}
+
+// Integer64Builder implementation
+
+func (i *integer64Schema) Min(value int64) Integer64Builder {
+	i.setMin(strconv.FormatInt(value, 10))
+	return i
+}
+
+func (i *integer64Schema) Max(value int64) Integer64Builder {
+	i.setMax(strconv.FormatInt(value, 10))
+	return i
+}
+
+func (i *integer64Schema) Example(value interface{}) Integer64Builder {
+	i.example = value
+	return i
+}
+
+func (i *integer64Schema) Required() RequiredInteger64Builder {
+	i.required, i.optional = true, false
+	return &requiredInteger64Schema{i.preciseNumberSchema}
+}
+
+func (i *integer64Schema) Optional() OptionalInteger64Builder {
+	i.optional, i.required = true, false
+	return &optionalInteger64Schema{i.preciseNumberSchema}
+}
+
+func (i *integer64Schema) WithMessage(validationType, message string) Integer64Builder {
+	i.customError[validationType] = message
+	return i
+}
+
+func (i *integer64Schema) WithMinMessage(message string) Integer64Builder {
+	return i.WithMessage(errorKeys.Min, message)
+}
+
+func (i *integer64Schema) WithMaxMessage(message string) Integer64Builder {
+	return i.WithMessage(errorKeys.Max, message)
+}
+
+// RequiredInteger64Builder implementation
+
+func (r *requiredInteger64Schema) Min(value int64) RequiredInteger64Builder {
+	r.setMin(strconv.FormatInt(value, 10))
+	return r
+}
+
+func (r *requiredInteger64Schema) Max(value int64) RequiredInteger64Builder {
+	r.setMax(strconv.FormatInt(value, 10))
+	return r
+}
+
+func (r *requiredInteger64Schema) Example(value interface{}) RequiredInteger64Builder {
+	r.example = value
+	return r
+}
+
+func (r *requiredInteger64Schema) WithMessage(validationType, message string) RequiredInteger64Builder {
+	r.customError[validationType] = message
+	return r
+}
+
+func (r *requiredInteger64Schema) WithMinMessage(message string) RequiredInteger64Builder {
+	return r.WithMessage(errorKeys.Min, message)
+}
+
+func (r *requiredInteger64Schema) WithMaxMessage(message string) RequiredInteger64Builder {
+	return r.WithMessage(errorKeys.Max, message)
+}
+
+func (r *requiredInteger64Schema) WithRequiredMessage(message string) RequiredInteger64Builder {
+	return r.WithMessage(errorKeys.Required, message)
+}
+
+// OptionalInteger64Builder implementation
+
+func (o *optionalInteger64Schema) Min(value int64) OptionalInteger64Builder {
+	o.setMin(strconv.FormatInt(value, 10))
+	return o
+}
+
+func (o *optionalInteger64Schema) Max(value int64) OptionalInteger64Builder {
+	o.setMax(strconv.FormatInt(value, 10))
+	return o
+}
+
+func (o *optionalInteger64Schema) Default(value int64) OptionalInteger64Builder {
+	o.defaultValue = value
+	return o
+}
+
+func (o *optionalInteger64Schema) Example(value interface{}) OptionalInteger64Builder {
+	o.example = value
+	return o
+}
+
+func (o *optionalInteger64Schema) WithMessage(validationType, message string) OptionalInteger64Builder {
+	o.customError[validationType] = message
+	return o
+}
+
+func (o *optionalInteger64Schema) WithMinMessage(message string) OptionalInteger64Builder {
+	return o.WithMessage(errorKeys.Min, message)
+}
+
+func (o *optionalInteger64Schema) WithMaxMessage(message string) OptionalInteger64Builder {
+	return o.WithMessage(errorKeys.Max, message)
+}
+
+// BigIntBuilder implementation
+
+func (b *bigIntSchema) Min(value string) BigIntBuilder {
+	b.setMin(value)
+	return b
+}
+
+func (b *bigIntSchema) Max(value string) BigIntBuilder {
+	b.setMax(value)
+	return b
+}
+
+func (b *bigIntSchema) Example(value interface{}) BigIntBuilder {
+	b.example = value
+	return b
+}
+
+func (b *bigIntSchema) Required() RequiredBigIntBuilder {
+	b.required, b.optional = true, false
+	return &requiredBigIntSchema{b.preciseNumberSchema}
+}
+
+func (b *bigIntSchema) Optional() OptionalBigIntBuilder {
+	b.optional, b.required = true, false
+	return &optionalBigIntSchema{b.preciseNumberSchema}
+}
+
+func (b *bigIntSchema) WithMessage(validationType, message string) BigIntBuilder {
+	b.customError[validationType] = message
+	return b
+}
+
+func (b *bigIntSchema) WithMinMessage(message string) BigIntBuilder {
+	return b.WithMessage(errorKeys.Min, message)
+}
+
+func (b *bigIntSchema) WithMaxMessage(message string) BigIntBuilder {
+	return b.WithMessage(errorKeys.Max, message)
+}
+
+// RequiredBigIntBuilder implementation
+
+func (r *requiredBigIntSchema) Min(value string) RequiredBigIntBuilder {
+	r.setMin(value)
+	return r
+}
+
+func (r *requiredBigIntSchema) Max(value string) RequiredBigIntBuilder {
+	r.setMax(value)
+	return r
+}
+
+func (r *requiredBigIntSchema) Example(value interface{}) RequiredBigIntBuilder {
+	r.example = value
+	return r
+}
+
+func (r *requiredBigIntSchema) WithMessage(validationType, message string) RequiredBigIntBuilder {
+	r.customError[validationType] = message
+	return r
+}
+
+func (r *requiredBigIntSchema) WithMinMessage(message string) RequiredBigIntBuilder {
+	return r.WithMessage(errorKeys.Min, message)
+}
+
+func (r *requiredBigIntSchema) WithMaxMessage(message string) RequiredBigIntBuilder {
+	return r.WithMessage(errorKeys.Max, message)
+}
+
+func (r *requiredBigIntSchema) WithRequiredMessage(message string) RequiredBigIntBuilder {
+	return r.WithMessage(errorKeys.Required, message)
+}
+
+// OptionalBigIntBuilder implementation
+
+func (o *optionalBigIntSchema) Min(value string) OptionalBigIntBuilder {
+	o.setMin(value)
+	return o
+}
+
+func (o *optionalBigIntSchema) Max(value string) OptionalBigIntBuilder {
+	o.setMax(value)
+	return o
+}
+
+func (o *optionalBigIntSchema) Default(value string) OptionalBigIntBuilder {
+	o.defaultValue = value
+	return o
+}
+
+func (o *optionalBigIntSchema) Example(value interface{}) OptionalBigIntBuilder {
+	o.example = value
+	return o
+}
+
+func (o *optionalBigIntSchema) WithMessage(validationType, message string) OptionalBigIntBuilder {
+	o.customError[validationType] = message
+	return o
+}
+
+func (o *optionalBigIntSchema) WithMinMessage(message string) OptionalBigIntBuilder {
+	return o.WithMessage(errorKeys.Min, message)
+}
+
+func (o *optionalBigIntSchema) WithMaxMessage(message string) OptionalBigIntBuilder {
+	return o.WithMessage(errorKeys.Max, message)
+}
+
+// DecimalBuilder implementation
+
+func (d *decimalSchema) Min(value string) DecimalBuilder {
+	d.setMin(value)
+	return d
+}
+
+func (d *decimalSchema) Max(value string) DecimalBuilder {
+	d.setMax(value)
+	return d
+}
+
+// Precision limits values to digits significant digits, scale of them after the decimal point,
+// like a SQL NUMERIC(digits, scale) column. It panics unless 0 <= scale <= digits and digits > 0.
+func (d *decimalSchema) Precision(digits, scale int) DecimalBuilder {
+	d.setPrecision(digits, scale)
+	return d
+}
+
+func (d *decimalSchema) Example(value interface{}) DecimalBuilder {
+	d.example = value
+	return d
+}
+
+func (d *decimalSchema) Required() RequiredDecimalBuilder {
+	d.required, d.optional = true, false
+	return &requiredDecimalSchema{d.preciseNumberSchema}
+}
+
+func (d *decimalSchema) Optional() OptionalDecimalBuilder {
+	d.optional, d.required = true, false
+	return &optionalDecimalSchema{d.preciseNumberSchema}
+}
+
+func (d *decimalSchema) WithMessage(validationType, message string) DecimalBuilder {
+	d.customError[validationType] = message
+	return d
+}
+
+func (d *decimalSchema) WithMinMessage(message string) DecimalBuilder {
+	return d.WithMessage(errorKeys.Min, message)
+}
+
+func (d *decimalSchema) WithMaxMessage(message string) DecimalBuilder {
+	return d.WithMessage(errorKeys.Max, message)
+}
+
+func (d *decimalSchema) WithPrecisionMessage(message string) DecimalBuilder {
+	return d.WithMessage(errorKeys.Precision, message)
+}
+
+// RequiredDecimalBuilder implementation
+
+func (r *requiredDecimalSchema) Min(value string) RequiredDecimalBuilder {
+	r.setMin(value)
+	return r
+}
+
+func (r *requiredDecimalSchema) Max(value string) RequiredDecimalBuilder {
+	r.setMax(value)
+	return r
+}
+
+func (r *requiredDecimalSchema) Precision(digits, scale int) RequiredDecimalBuilder {
+	r.setPrecision(digits, scale)
+	return r
+}
+
+func (r *requiredDecimalSchema) Example(value interface{}) RequiredDecimalBuilder {
+	r.example = value
+	return r
+}
+
+func (r *requiredDecimalSchema) WithMessage(validationType, message string) RequiredDecimalBuilder {
+	r.customError[validationType] = message
+	return r
+}
+
+func (r *requiredDecimalSchema) WithMinMessage(message string) RequiredDecimalBuilder {
+	return r.WithMessage(errorKeys.Min, message)
+}
+
+func (r *requiredDecimalSchema) WithMaxMessage(message string) RequiredDecimalBuilder {
+	return r.WithMessage(errorKeys.Max, message)
+}
+
+func (r *requiredDecimalSchema) WithPrecisionMessage(message string) RequiredDecimalBuilder {
+	return r.WithMessage(errorKeys.Precision, message)
+}
+
+func (r *requiredDecimalSchema) WithRequiredMessage(message string) RequiredDecimalBuilder {
+	return r.WithMessage(errorKeys.Required, message)
+}
+
+// OptionalDecimalBuilder implementation
+
+func (o *optionalDecimalSchema) Min(value string) OptionalDecimalBuilder {
+	o.setMin(value)
+	return o
+}
+
+func (o *optionalDecimalSchema) Max(value string) OptionalDecimalBuilder {
+	o.setMax(value)
+	return o
+}
+
+func (o *optionalDecimalSchema) Precision(digits, scale int) OptionalDecimalBuilder {
+	o.setPrecision(digits, scale)
+	return o
+}
+
+func (o *optionalDecimalSchema) Default(value string) OptionalDecimalBuilder {
+	o.defaultValue = value
+	return o
+}
+
+func (o *optionalDecimalSchema) Example(value interface{}) OptionalDecimalBuilder {
+	o.example = value
+	return o
+}
+
+func (o *optionalDecimalSchema) WithMessage(validationType, message string) OptionalDecimalBuilder {
+	o.customError[validationType] = message
+	return o
+}
+
+func (o *optionalDecimalSchema) WithMinMessage(message string) OptionalDecimalBuilder {
+	return o.WithMessage(errorKeys.Min, message)
+}
+
+func (o *optionalDecimalSchema) WithMaxMessage(message string) OptionalDecimalBuilder {
+	return o.WithMessage(errorKeys.Max, message)
+}
+
+func (o *optionalDecimalSchema) WithPrecisionMessage(message string) OptionalDecimalBuilder {
+	return o.WithMessage(errorKeys.Precision, message)
+}
+
+// Configuration helpers shared by all precise number builders
+
+func (p *preciseNumberSchema) setMin(text string) {
+	value := p.bound("Min", text)
+	p.minValue, p.minText = &value, text
+}
+
+func (p *preciseNumberSchema) setMax(text string) {
+	value := p.bound("Max", text)
+	p.maxValue, p.maxText = &value, text
+}
+
+// bound parses a Min or Max argument, panicking when it is not a number of the schema's kind
+func (p *preciseNumberSchema) bound(method, text string) decimalValue {
+	value, ok := parseDecimal(text, false)
+	if !ok || (p.kind != preciseDecimal && value.scale > 0) {
+		panic(fmt.Sprintf("validators: %s(%q) is not a valid %s", method, text, p.kindName()))
+	}
+	return value
+}
+
+func (p *preciseNumberSchema) setPrecision(digits, scale int) {
+	if digits <= 0 || scale < 0 || scale > digits {
+		panic(fmt.Sprintf("validators: Precision(%d, %d) needs digits > 0 and 0 <= scale <= digits", digits, scale))
+	}
+	p.digits, p.scale = digits, scale
+}
+
+func (p *preciseNumberSchema) kindName() string {
+	if p.kind == preciseDecimal {
+		return "decimal number"
+	}
+	return "integer"
+}
+
+// Validate checks data exactly; it is shared by every state so unfinalized builders validate as required
+func (p *preciseNumberSchema) Validate(data interface{}) error {
+	// Empty strings are missing values, as for string schemas
+	if s, ok := data.(string); ok && s == "" {
+		data = nil
+	}
+	if data == nil {
+		if p.required {
+			return goop.NewValidationError("", nil, p.getErrorMessage(errorKeys.Required, "field is required"))
+		}
+		if p.defaultValue != nil {
+			return p.Validate(p.defaultValue)
+		}
+		if p.optional {
+			return nil
+		}
+		return goop.NewValidationError("", nil, p.getErrorMessage(errorKeys.Required, "field is required"))
+	}
+
+	value, err := p.convert(data)
+	if err != nil {
+		return err
+	}
+	field := fmt.Sprintf("%v", data)
+
+	if p.kind != preciseDecimal && value.scale > 0 {
+		return goop.NewValidationError(field, data, p.getErrorMessage(errorKeys.Integer, "value must be an integer"))
+	}
+	if p.kind == preciseInteger64 && !value.unscaled.IsInt64() {
+		return goop.NewValidationError(field, data, p.getErrorMessage(errorKeys.Integer, "value must be a 64-bit integer"))
+	}
+
+	if p.minValue != nil && value.cmp(*p.minValue) < 0 {
+		return goop.NewValidationError(field, data, p.getErrorMessage(errorKeys.Min,
+			fmt.Sprintf("value is too small, minimum is %s", p.minText)))
+	}
+	if p.maxValue != nil && value.cmp(*p.maxValue) > 0 {
+		return goop.NewValidationError(field, data, p.getErrorMessage(errorKeys.Max,
+			fmt.Sprintf("value is too large, maximum is %s", p.maxText)))
+	}
+
+	if p.digits > 0 {
+		if value.scale > p.scale {
+			message := fmt.Sprintf("value allows at most %d decimal places", p.scale)
+			if p.scale == 0 {
+				message = "value cannot have decimal places"
+			}
+			return goop.NewValidationError(field, data, p.getErrorMessage(errorKeys.Precision, message))
+		}
+		if value.integerDigits() > p.digits-p.scale {
+			return goop.NewValidationError(field, data, p.getErrorMessage(errorKeys.Precision,
+				fmt.Sprintf("value allows at most %d digits before the decimal point", p.digits-p.scale)))
+		}
+	}
+
+	return nil
+}
+
+// convert reads data as an exact decimal
+// Floats are read from their shortest decimal form, which is what clients sent for values goop.DecodeJSON converted
+func (p *preciseNumberSchema) convert(data interface{}) (decimalValue, error) {
+	switch v := data.(type) {
+	case int:
+		return decimalValue{unscaled: big.NewInt(int64(v))}, nil
+	case int8:
+		return decimalValue{unscaled: big.NewInt(int64(v))}, nil
+	case int16:
+		return decimalValue{unscaled: big.NewInt(int64(v))}, nil
+	case int32:
+		return decimalValue{unscaled: big.NewInt(int64(v))}, nil
+	case int64:
+		return decimalValue{unscaled: big.NewInt(v)}, nil
+	case uint:
+		return decimalValue{unscaled: new(big.Int).SetUint64(uint64(v))}, nil
+	case uint8:
+		return decimalValue{unscaled: new(big.Int).SetUint64(uint64(v))}, nil
+	case uint16:
+		return decimalValue{unscaled: new(big.Int).SetUint64(uint64(v))}, nil
+	case uint32:
+		return decimalValue{unscaled: new(big.Int).SetUint64(uint64(v))}, nil
+	case uint64:
+		return decimalValue{unscaled: new(big.Int).SetUint64(v)}, nil
+	case float32:
+		if value, ok := parseDecimal(strconv.FormatFloat(float64(v), 'f', -1, 32), false); ok {
+			return value, nil
+		}
+	case float64:
+		if !math.IsNaN(v) && !math.IsInf(v, 0) {
+			if value, ok := parseDecimal(strconv.FormatFloat(v, 'f', -1, 64), false); ok {
+				return value, nil
+			}
+		}
+	case json.Number:
+		if value, ok := parseDecimal(string(v), true); ok {
+			return value, nil
+		}
+	case *big.Int:
+		if v != nil {
+			return decimalValue{unscaled: new(big.Int).Set(v)}, nil
+		}
+	case big.Int:
+		return decimalValue{unscaled: new(big.Int).Set(&v)}, nil
+	case string:
+		switch p.kind {
+		case preciseBigInt:
+			if integerStringRegex.MatchString(v) {
+				value, _ := parseDecimal(v, false)
+				return value, nil
+			}
+			return decimalValue{}, goop.NewValidationError(v, v,
+				p.getErrorMessage(errorKeys.Format, `value must be an integer string such as "42"`))
+		case preciseDecimal:
+			if decimalStringRegex.MatchString(v) {
+				value, _ := parseDecimal(v, false)
+				return value, nil
+			}
+			return decimalValue{}, goop.NewValidationError(v, v,
+				p.getErrorMessage(errorKeys.Format, `value must be a decimal string such as "12.50"`))
+		}
+	}
+
+	var message string
+	switch p.kind {
+	case preciseInteger64:
+		message = "invalid type, expected integer"
+	case preciseBigInt:
+		message = "invalid type, expected integer string"
+	default:
+		message = "invalid type, expected decimal string"
+	}
+	return decimalValue{}, goop.NewValidationError(fmt.Sprintf("%v", data), data, p.getErrorMessage(errorKeys.Type, message))
+}
+
+// Helper methods (unexported)
+func (p *preciseNumberSchema) getErrorMessage(validationType, defaultMessage string) string {
+	if msg, exists := p.customError[validationType]; exists {
+		return msg
+	}
+	return defaultMessage
+}
+
+// ToOpenAPISchema documents Integer64 as an int64 integer, and BigInt and Decimal as patterned strings
+// BigInt and Decimal bounds cannot be expressed on strings and are only enforced.
+func (p *preciseNumberSchema) ToOpenAPISchema() *goop.OpenAPISchema {
+	var schema *goop.OpenAPISchema
+	switch p.kind {
+	case preciseInteger64:
+		schema = &goop.OpenAPISchema{Type: "integer", Format: "int64"}
+		if p.minValue != nil {
+			minimum, _ := strconv.ParseFloat(p.minText, 64)
+			schema.Minimum = &minimum
+		}
+		if p.maxValue != nil {
+			maximum, _ := strconv.ParseFloat(p.maxText, 64)
+			schema.Maximum = &maximum
+		}
+	case preciseBigInt:
+		schema = &goop.OpenAPISchema{Type: "string", Format: "bigint", Pattern: integerStringPattern}
+	default:
+		schema = &goop.OpenAPISchema{Type: "string", Format: "decimal", Pattern: p.decimalPattern()}
+	}
+
+	if p.defaultValue != nil {
+		schema.Default = p.defaultValue
+	}
+	if p.example != nil {
+		schema.Example = p.example
+	}
+	p.docs.apply(schema)
+	return schema
+}
+
+// decimalPattern returns the string pattern for decimals, limiting digits when Precision is set
+func (p *preciseNumberSchema) decimalPattern() string {
+	if p.digits == 0 {
+		return decimalStringPattern
+	}
+	integer := "0"
+	if n := p.digits - p.scale; n > 0 {
+		integer = fmt.Sprintf("(0|[1-9][0-9]{0,%d})", n-1)
+	}
+	if p.scale == 0 {
+		return "^-?" + integer + "$"
+	}
+	return fmt.Sprintf(`^-?%s(\.[0-9]{1,%d})?$`, integer, p.scale)
+}
+
+// GetValidationInfo returns metadata about the precise number validation configuration
+func (p *preciseNumberSchema) GetValidationInfo() *goop.ValidationInfo {
+	info := &goop.ValidationInfo{
+		Required:     p.required,
+		Optional:     p.optional,
+		HasDefault:   p.defaultValue != nil,
+		DefaultValue: p.defaultValue,
+		Constraints:  make(map[string]interface{}),
+	}
+
+	info.Constraints["format"] = p.ToOpenAPISchema().Format
+	if p.minValue != nil {
+		info.Constraints["minimum"] = p.minText
+	}
+	if p.maxValue != nil {
+		info.Constraints["maximum"] = p.maxText
+	}
+	if p.digits > 0 {
+		info.Constraints["precision"] = p.digits
+		info.Constraints["scale"] = p.scale
+	}
+
+	return info
+}
+
+// decimalValue is an exact decimal number equal to unscaled × 10^-scale, with no trailing fraction zeros
+type decimalValue struct {
+	unscaled *big.Int
+	scale    int
+}
+
+// parseDecimal reads a JSON number, rejecting exponent notation unless allowExponent is set
+func parseDecimal(s string, allowExponent bool) (decimalValue, bool) {
+	m := jsonNumberRegex.FindStringSubmatch(s)
+	if m == nil || (m[4] != "" && !allowExponent) {
+		return decimalValue{}, false
+	}
+
+	digits, scale := m[2]+m[3], len(m[3])
+	if m[4] != "" {
+		exponent, err := strconv.Atoi(m[4])
+		if err != nil || exponent > maxDecimalExponent || exponent < -maxDecimalExponent {
+			return decimalValue{}, false
+		}
+		scale -= exponent
+	}
+	for scale > 0 && len(digits) > 1 && digits[len(digits)-1] == '0' {
+		digits, scale = digits[:len(digits)-1], scale-1
+	}
+	if scale < 0 {
+		digits, scale = digits+strings.Repeat("0", -scale), 0
+	}
+
+	unscaled, ok := new(big.Int).SetString(m[1]+digits, 10)
+	if !ok {
+		return decimalValue{}, false
+	}
+	if unscaled.Sign() == 0 {
+		scale = 0
+	}
+	return decimalValue{unscaled: unscaled, scale: scale}, true
+}
+
+// cmp compares d and other, returning -1, 0, or +1
+func (d decimalValue) cmp(other decimalValue) int {
+	a, b := d.unscaled, other.unscaled
+	if d.scale < other.scale {
+		a = new(big.Int).Mul(a, pow10(other.scale-d.scale))
+	} else if d.scale > other.scale {
+		b = new(big.Int).Mul(b, pow10(d.scale-other.scale))
+	}
+	return a.Cmp(b)
+}
+
+// integerDigits counts the digits before the decimal point, not counting a lone zero
+func (d decimalValue) integerDigits() int {
+	if d.unscaled.Sign() == 0 {
+		return 0
+	}
+	n := len(new(big.Int).Abs(d.unscaled).String()) - d.scale
+	if n < 0 {
+		return 0
+	}
+	return n
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// Interface compliance checks at compile time
+var (
+	_ Integer64Builder         = (*integer64Schema)(nil)
+	_ RequiredInteger64Builder = (*requiredInteger64Schema)(nil)
+	_ OptionalInteger64Builder = (*optionalInteger64Schema)(nil)
+	_ BigIntBuilder            = (*bigIntSchema)(nil)
+	_ RequiredBigIntBuilder    = (*requiredBigIntSchema)(nil)
+	_ OptionalBigIntBuilder    = (*optionalBigIntSchema)(nil)
+	_ DecimalBuilder           = (*decimalSchema)(nil)
+	_ RequiredDecimalBuilder   = (*requiredDecimalSchema)(nil)
+	_ OptionalDecimalBuilder   = (*optionalDecimalSchema)(nil)
+	_ goop.EnhancedSchema      = (*preciseNumberSchema)(nil)
+)
@@ -0,0 +1,193 @@
+package validators
+
+// Integer64Builder represents the initial 64-bit integer builder state.
+// Values are checked exactly, including integers above 2^53 decoded as json.Number.
+type Integer64Builder interface {
+	// Configuration methods - these return Integer64Builder to allow chaining
+	Min(value int64) Integer64Builder
+	Max(value int64) Integer64Builder
+
+	// Example and documentation methods for OpenAPI
+	Example(value interface{}) Integer64Builder
+	Title(title string) Integer64Builder
+	Description(description string) Integer64Builder
+
+	// State transition methods - these change the type to prevent invalid chaining
+	Required() RequiredInteger64Builder
+	Optional() OptionalInteger64Builder
+
+	// Error message configuration methods
+	WithMessage(validationType, message string) Integer64Builder
+	WithMinMessage(message string) Integer64Builder
+	WithMaxMessage(message string) Integer64Builder
+}
+
+// RequiredInteger64Builder represents a 64-bit integer builder in the required state.
+type RequiredInteger64Builder interface {
+	Min(value int64) RequiredInteger64Builder
+	Max(value int64) RequiredInteger64Builder
+	Example(value interface{}) RequiredInteger64Builder
+	Title(title string) RequiredInteger64Builder
+	Description(description string) RequiredInteger64Builder
+
+	WithMessage(validationType, message string) RequiredInteger64Builder
+	WithMinMessage(message string) RequiredInteger64Builder
+	WithMaxMessage(message string) RequiredInteger64Builder
+	WithRequiredMessage(message string) RequiredInteger64Builder
+
+	// JSON Schema export
+	ToJSONSchema() ([]byte, error)
+
+	// Validation method - final step in the builder chain
+	Validate(data interface{}) error
+}
+
+// OptionalInteger64Builder represents a 64-bit integer builder in the optional state.
+type OptionalInteger64Builder interface {
+	Min(value int64) OptionalInteger64Builder
+	Max(value int64) OptionalInteger64Builder
+	Default(value int64) OptionalInteger64Builder
+	Example(value interface{}) OptionalInteger64Builder
+	Title(title string) OptionalInteger64Builder
+	Description(description string) OptionalInteger64Builder
+
+	WithMessage(validationType, message string) OptionalInteger64Builder
+	WithMinMessage(message string) OptionalInteger64Builder
+	WithMaxMessage(message string) OptionalInteger64Builder
+
+	// JSON Schema export
+	ToJSONSchema() ([]byte, error)
+
+	// Validation method - final step in the builder chain
+	Validate(data interface{}) error
+}
+
+// BigIntBuilder represents the initial arbitrary-precision integer builder state.
+// Bounds are decimal integer strings such as "18446744073709551615".
+type BigIntBuilder interface {
+	// Configuration methods - these return BigIntBuilder to allow chaining
+	Min(value string) BigIntBuilder
+	Max(value string) BigIntBuilder
+
+	// Example and documentation methods for OpenAPI
+	Example(value interface{}) BigIntBuilder
+	Title(title string) BigIntBuilder
+	Description(description string) BigIntBuilder
+
+	// State transition methods - these change the type to prevent invalid chaining
+	Required() RequiredBigIntBuilder
+	Optional() OptionalBigIntBuilder
+
+	// Error message configuration methods
+	WithMessage(validationType, message string) BigIntBuilder
+	WithMinMessage(message string) BigIntBuilder
+	WithMaxMessage(message string) BigIntBuilder
+}
+
+// RequiredBigIntBuilder represents an arbitrary-precision integer builder in the required state.
+type RequiredBigIntBuilder interface {
+	Min(value string) RequiredBigIntBuilder
+	Max(value string) RequiredBigIntBuilder
+	Example(value interface{}) RequiredBigIntBuilder
+	Title(title string) RequiredBigIntBuilder
+	Description(description string) RequiredBigIntBuilder
+
+	WithMessage(validationType, message string) RequiredBigIntBuilder
+	WithMinMessage(message string) RequiredBigIntBuilder
+	WithMaxMessage(message string) RequiredBigIntBuilder
+	WithRequiredMessage(message string) RequiredBigIntBuilder
+
+	// JSON Schema export
+	ToJSONSchema() ([]byte, error)
+
+	// Validation method - final step in the builder chain
+	Validate(data interface{}) error
+}
+
+// OptionalBigIntBuilder represents an arbitrary-precision integer builder in the optional state.
+type OptionalBigIntBuilder interface {
+	Min(value string) OptionalBigIntBuilder
+	Max(value string) OptionalBigIntBuilder
+	Default(value string) OptionalBigIntBuilder
+	Example(value interface{}) OptionalBigIntBuilder
+	Title(title string) OptionalBigIntBuilder
+	Description(description string) OptionalBigIntBuilder
+
+	WithMessage(validationType, message string) OptionalBigIntBuilder
+	WithMinMessage(message string) OptionalBigIntBuilder
+	WithMaxMessage(message string) OptionalBigIntBuilder
+
+	// JSON Schema export
+	ToJSONSchema() ([]byte, error)
+
+	// Validation method - final step in the builder chain
+	Validate(data interface{}) error
+}
+
+// DecimalBuilder represents the initial exact decimal builder state.
+// Bounds are decimal strings such as "0.01".
+type DecimalBuilder interface {
+	// Configuration methods - these return DecimalBuilder to allow chaining
+	Min(value string) DecimalBuilder
+	Max(value string) DecimalBuilder
+	Precision(digits, scale int) DecimalBuilder
+
+	// Example and documentation methods for OpenAPI
+	Example(value interface{}) DecimalBuilder
+	Title(title string) DecimalBuilder
+	Description(description string) DecimalBuilder
+
+	// State transition methods - these change the type to prevent invalid chaining
+	Required() RequiredDecimalBuilder
+	Optional() OptionalDecimalBuilder
+
+	// Error message configuration methods
+	WithMessage(validationType, message string) DecimalBuilder
+	WithMinMessage(message string) DecimalBuilder
+	WithMaxMessage(message string) DecimalBuilder
+	WithPrecisionMessage(message string) DecimalBuilder
+}
+
+// RequiredDecimalBuilder represents an exact decimal builder in the required state.
+type RequiredDecimalBuilder interface {
+	Min(value string) RequiredDecimalBuilder
+	Max(value string) RequiredDecimalBuilder
+	Precision(digits, scale int) RequiredDecimalBuilder
+	Example(value interface{}) RequiredDecimalBuilder
+	Title(title string) RequiredDecimalBuilder
+	Description(description string) RequiredDecimalBuilder
+
+	WithMessage(validationType, message string) RequiredDecimalBuilder
+	WithMinMessage(message string) RequiredDecimalBuilder
+	WithMaxMessage(message string) RequiredDecimalBuilder
+	WithPrecisionMessage(message string) RequiredDecimalBuilder
+	WithRequiredMessage(message string) RequiredDecimalBuilder
+
+	// JSON Schema export
+	ToJSONSchema() ([]byte, error)
+
+	// Validation method - final step in the builder chain
+	Validate(data interface{}) error
+}
+
+// OptionalDecimalBuilder represents an exact decimal builder in the optional state.
+type OptionalDecimalBuilder interface {
+	Min(value string) OptionalDecimalBuilder
+	Max(value string) OptionalDecimalBuilder
+	Precision(digits, scale int) OptionalDecimalBuilder
+	Default(value string) OptionalDecimalBuilder
+	Example(value interface{}) OptionalDecimalBuilder
+	Title(title string) OptionalDecimalBuilder
+	Description(description string) OptionalDecimalBuilder
+
+	WithMessage(validationType, message string) OptionalDecimalBuilder
+	WithMinMessage(message string) OptionalDecimalBuilder
+	WithMaxMessage(message string) OptionalDecimalBuilder
+	WithPrecisionMessage(message string) OptionalDecimalBuilder
+
+	// JSON Schema export
+	ToJSONSchema() ([]byte, error)
+
+	// Validation method - final step in the builder chain
+	Validate(data interface{}) error
+}
@@ -0,0 +1,221 @@
+package validators
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// TestInteger64 tests exact 64-bit integer validation
+func TestInteger64(t *testing.T) {
+	schema := Integer64().Min(1).Required()
+
+	valid := []interface{}{
+		int64(9007199254740993),
+		json.Number("9223372036854775807"),
+		json.Number("1e3"),
+		42,
+		uint32(7),
+		float64(12),
+	}
+	for _, value := range valid {
+		if err := schema.Validate(value); err != nil {
+			t.Errorf("Expected %v (%T) to be valid, got: %v", value, value, err)
+		}
+	}
+
+	invalid := map[interface{}]string{
+		json.Number("9223372036854775808"): "64-bit integer",
+		json.Number("1.5"):                 "must be an integer",
+		float64(2.5):                       "must be an integer",
+		"42":                               "expected integer",
+		int64(0):                           "minimum is 1",
+		true:                               "expected integer",
+	}
+	for value, message := range invalid {
+		err := schema.Validate(value)
+		if err == nil || !strings.Contains(err.Error(), message) {
+			t.Errorf("Expected %v (%T) to fail with %q, got: %v", value, value, message, err)
+		}
+	}
+
+	// Bounds beyond 2^53 are compared exactly
+	bounded := Integer64().Max(9007199254740992).Required()
+	if err := bounded.Validate(json.Number("9007199254740993")); err == nil {
+		t.Error("Expected 9007199254740993 to exceed maximum 9007199254740992")
+	}
+
+	if err := Integer64().Optional().Default(5).Validate(nil); err != nil {
+		t.Errorf("Expected default to be valid, got: %v", err)
+	}
+	if err := schema.Validate(nil); err == nil {
+		t.Error("Expected required integer to reject nil")
+	}
+
+	spec := schema.(goop.EnhancedSchema).ToOpenAPISchema()
+	if spec.Type != "integer" || spec.Format != "int64" || spec.Minimum == nil || *spec.Minimum != 1 {
+		t.Errorf("Expected integer int64 schema with minimum 1, got %+v", spec)
+	}
+}
+
+// TestBigInt tests arbitrary-precision integer validation
+func TestBigInt(t *testing.T) {
+	schema := BigInt().Min("0").Max("340282366920938463463374607431768211456").Required()
+
+	huge, _ := new(big.Int).SetString("340282366920938463463374607431768211455", 10)
+	valid := []interface{}{
+		"340282366920938463463374607431768211456",
+		json.Number("18446744073709551616"),
+		huge,
+		*huge,
+		"0",
+		7,
+	}
+	for _, value := range valid {
+		if err := schema.Validate(value); err != nil {
+			t.Errorf("Expected %v (%T) to be valid, got: %v", value, value, err)
+		}
+	}
+
+	invalid := map[interface{}]string{
+		"340282366920938463463374607431768211457": "maximum is",
+		"-1":                  "minimum is 0",
+		"1.0":                 "integer string",
+		"0x10":                "integer string",
+		"007":                 "integer string",
+		json.Number("1.5"):    "must be an integer",
+		json.Number("1e9999"): "expected integer string",
+		false:                 "expected integer string",
+	}
+	for value, message := range invalid {
+		err := schema.Validate(value)
+		if err == nil || !strings.Contains(err.Error(), message) {
+			t.Errorf("Expected %v (%T) to fail with %q, got: %v", value, value, message, err)
+		}
+	}
+
+	if err := BigInt().Optional().Validate(""); err != nil {
+		t.Errorf("Expected optional big integer to accept empty value, got: %v", err)
+	}
+
+	spec := schema.(goop.EnhancedSchema).ToOpenAPISchema()
+	if spec.Type != "string" || spec.Format != "bigint" || spec.Pattern != integerStringPattern {
+		t.Errorf("Expected integer string schema, got %+v", spec)
+	}
+
+	assertPanics(t, "non-integer bound", func() { BigInt().Min("1.5") })
+}
+
+// TestDecimal tests exact decimal validation
+func TestDecimal(t *testing.T) {
+	schema := Decimal().Min("0.01").Max("99999999.99").Precision(10, 2).Required()
+
+	valid := []interface{}{"0.01", "12.5", "12.50", "99999999.99", json.Number("19.99"), json.Number("1.2e1"), 19.99}
+	for _, value := range valid {
+		if err := schema.Validate(value); err != nil {
+			t.Errorf("Expected %v (%T) to be valid, got: %v", value, value, err)
+		}
+	}
+
+	invalid := map[interface{}]string{
+		"12.345":                          "at most 2 decimal places",
+		"0.125":                           "at most 2 decimal places",
+		"0":                               "minimum is 0.01",
+		"100000000":                       "maximum is 99999999.99",
+		json.Number("0.0100000000000001"): "decimal places",
+		"1e2":                             "decimal string",
+		"12.":                             "decimal string",
+		".5":                              "decimal string",
+		true:                              "expected decimal string",
+	}
+	for value, message := range invalid {
+		err := schema.Validate(value)
+		if err == nil || !strings.Contains(err.Error(), message) {
+			t.Errorf("Expected %v (%T) to fail with %q, got: %v", value, value, message, err)
+		}
+	}
+
+	t.Run("Integer digits are limited by precision", func(t *testing.T) {
+		small := Decimal().Precision(3, 1).Required()
+		if err := small.Validate("99.9"); err != nil {
+			t.Errorf("Expected 99.9 to fit NUMERIC(3, 1), got: %v", err)
+		}
+		err := small.Validate("100")
+		if err == nil || !strings.Contains(err.Error(), "2 digits before the decimal point") {
+			t.Errorf("Expected 100 to exceed NUMERIC(3, 1), got: %v", err)
+		}
+	})
+
+	t.Run("Custom messages", func(t *testing.T) {
+		err := Decimal().Precision(4, 0).WithPrecisionMessage("whole units only").Required().Validate("1.5")
+		if err == nil || !strings.Contains(err.Error(), "whole units only") {
+			t.Errorf("Expected custom precision message, got: %v", err)
+		}
+	})
+
+	spec := schema.(goop.EnhancedSchema).ToOpenAPISchema()
+	if spec.Type != "string" || spec.Format != "decimal" || spec.Pattern != `^-?(0|[1-9][0-9]{0,7})(\.[0-9]{1,2})?$` {
+		t.Errorf("Expected decimal string schema limited to NUMERIC(10, 2), got %+v", spec)
+	}
+	if !IsRegisteredFormat("decimal") || !IsRegisteredFormat("bigint") {
+		t.Error("Expected decimal and bigint string formats to be registered")
+	}
+
+	assertPanics(t, "invalid precision", func() { Decimal().Precision(2, 3) })
+	assertPanics(t, "invalid bound", func() { Decimal().Max("ten") })
+}
+
+// TestPreciseNumbersInObjects tests precise numbers decoded from JSON inside object schemas
+func TestPreciseNumbersInObjects(t *testing.T) {
+	schema := Object(map[string]interface{}{
+		"id":    Integer64().Max(9007199254740993),
+		"price": Decimal().Precision(6, 2).Required(),
+		"note":  String().Optional(),
+	}).Required()
+
+	data, err := goop.DecodeJSON([]byte(`{"id": 9007199254740993, "price": "12.50"}`))
+	if err != nil {
+		t.Fatalf("DecodeJSON failed: %v", err)
+	}
+	if err := schema.Validate(data); err != nil {
+		t.Errorf("Expected decoded object to be valid, got: %v", err)
+	}
+
+	data, _ = goop.DecodeJSON([]byte(`{"id": 9007199254740994, "price": "12.50"}`))
+	if err := schema.Validate(data); err == nil {
+		t.Error("Expected 9007199254740994 to exceed the maximum, which float64 cannot distinguish")
+	}
+
+	if err := schema.Validate(map[string]interface{}{"price": "1.00"}); err == nil {
+		t.Error("Expected unfinalized Integer64 field to be required")
+	}
+
+	spec := schema.(goop.EnhancedSchema).ToOpenAPISchema()
+	if spec.Properties["id"].Format != "int64" || spec.Properties["price"].Format != "decimal" {
+		t.Errorf("Expected int64 id and decimal price properties, got %+v", spec)
+	}
+}
+
+// TestNumberAcceptsJSONNumber tests that Number validates decoded json.Number values
+func TestNumberAcceptsJSONNumber(t *testing.T) {
+	schema := Number().Max(10).Required()
+	if err := schema.Validate(json.Number("9.5")); err != nil {
+		t.Errorf("Expected json.Number 9.5 to be valid, got: %v", err)
+	}
+	if err := schema.Validate(json.Number("10.5")); err == nil {
+		t.Error("Expected json.Number 10.5 to exceed the maximum")
+	}
+}
+
+func assertPanics(t *testing.T, name string, fn func()) {
+	t.Helper()
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected %s to panic", name)
+		}
+	}()
+	fn()
+}
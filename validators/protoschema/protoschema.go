@@ -0,0 +1,296 @@
+// Package protoschema bridges protobuf message descriptors and go-op schemas, so services
+// exposing both REST and gRPC can keep a single canonical model.
+//
+// FromMessageDescriptor and ForMessage build validators for the protobuf JSON mapping of a
+// message, and ToFileDescriptor builds protobuf message descriptors from go-op schemas.
+package protoschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	goop "github.com/picogrid/go-op"
+	"github.com/picogrid/go-op/validators"
+)
+
+// componentPrefix is the reference prefix for message schemas, which are named by full message name
+const componentPrefix = "#/components/schemas/"
+
+// Option configures how message descriptors are converted
+type Option func(*converter)
+
+// WithProtoNames names properties after protobuf field names, such as display_name, instead of
+// their lowerCamelCase JSON names. Use it when messages are encoded with protojson's UseProtoNames
+// or decoded with encoding/json, whose tags on generated structs use the protobuf field names.
+func WithProtoNames() Option {
+	return func(c *converter) {
+		c.protoNames = true
+	}
+}
+
+// FromMessageDescriptor builds a validator for the protobuf JSON mapping of a message.
+// Fields map to properties named by their JSON names:
+//   - 32-bit integers are integers within their range; 64-bit integers are decimal strings or integers
+//   - enums are the names of their values, and bytes are base64 strings
+//   - repeated fields are arrays and map fields are objects validating each value
+//   - well-known types use their JSON forms, such as RFC 3339 strings for google.protobuf.Timestamp
+//   - at most one field of each oneof may be set, and proto2 required fields must be present
+//
+// Every other field is optional, as protobuf fields are. Recursive messages are documented with
+// references to #/components/schemas/<full message name>.
+func FromMessageDescriptor(desc protoreflect.MessageDescriptor, opts ...Option) (goop.Schema, error) {
+	c := newConverter(opts)
+	name := string(desc.FullName())
+	if root := c.message(desc); c.components[name] == nil {
+		// Well-known types convert to inline schemas rather than components
+		c.components[name] = root
+	}
+
+	doc, err := json.Marshal(map[string]interface{}{
+		"components": map[string]interface{}{"schemas": c.components},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("converting %s: %w", desc.FullName(), err)
+	}
+	schemas, err := validators.FromOpenAPIComponents(doc)
+	if err != nil {
+		return nil, fmt.Errorf("converting %s: %w", desc.FullName(), err)
+	}
+	return schemas[name], nil
+}
+
+// ForMessage builds a validator for generated protobuf message type T.
+// Besides decoded JSON, the validator accepts T values, which are validated in their protobuf JSON form.
+// It panics if the message cannot be converted.
+//
+// Example:
+//
+//	userSchema := protoschema.ForMessage[*userv1.User]()
+//	err := userSchema.Validate(&userv1.User{Email: "ada@example.com"})
+func ForMessage[T proto.Message](opts ...Option) goop.Schema {
+	var message T
+	schema, err := FromMessageDescriptor(message.ProtoReflect().Descriptor(), opts...)
+	if err != nil {
+		panic(err)
+	}
+	return &messageSchema{schema: schema.(goop.EnhancedSchema), marshal: protojson.MarshalOptions{UseProtoNames: newConverter(opts).protoNames}}
+}
+
+// messageSchema validates protobuf messages in their JSON form
+type messageSchema struct {
+	schema  goop.EnhancedSchema
+	marshal protojson.MarshalOptions
+}
+
+func (m *messageSchema) Validate(data interface{}) error {
+	if message, ok := data.(proto.Message); ok {
+		encoded, err := m.marshal.Marshal(message)
+		if err != nil {
+			return goop.NewValidationError("", data, fmt.Sprintf("cannot encode message: %v", err))
+		}
+		var decoded interface{}
+		if err := json.Unmarshal(encoded, &decoded); err != nil {
+			return goop.NewValidationError("", data, fmt.Sprintf("cannot decode message: %v", err))
+		}
+		data = decoded
+	}
+	return m.schema.Validate(data)
+}
+
+func (m *messageSchema) ToOpenAPISchema() *goop.OpenAPISchema {
+	return m.schema.ToOpenAPISchema()
+}
+
+func (m *messageSchema) GetValidationInfo() *goop.ValidationInfo {
+	return m.schema.GetValidationInfo()
+}
+
+// converter builds an OpenAPI components document for a message and the messages it references
+type converter struct {
+	protoNames bool
+	components map[string]interface{}
+}
+
+func newConverter(opts []Option) *converter {
+	c := &converter{components: make(map[string]interface{})}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// message returns the schema for a message: an inline schema for well-known types, otherwise a
+// reference to its component, which is added on first use
+func (c *converter) message(desc protoreflect.MessageDescriptor) map[string]interface{} {
+	if schema := wellKnownSchema(desc); schema != nil {
+		return schema
+	}
+
+	name := string(desc.FullName())
+	ref := map[string]interface{}{"$ref": componentPrefix + name}
+	if _, exists := c.components[name]; exists {
+		return ref
+	}
+	// Reserve the name before converting fields so recursive messages terminate
+	c.components[name] = nil
+
+	properties := make(map[string]interface{})
+	var required []interface{}
+	fields := desc.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		name := c.propertyName(field)
+		properties[name] = c.field(field)
+		if field.Cardinality() == protoreflect.Required {
+			required = append(required, name)
+		}
+	}
+
+	schema := map[string]interface{}{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	if exclusions := c.oneofExclusions(desc); len(exclusions) > 0 {
+		schema["allOf"] = exclusions
+	}
+	c.components[name] = schema
+	return ref
+}
+
+// oneofExclusions rejects objects that set more than one field of a oneof
+func (c *converter) oneofExclusions(desc protoreflect.MessageDescriptor) []interface{} {
+	var exclusions []interface{}
+	oneofs := desc.Oneofs()
+	for i := 0; i < oneofs.Len(); i++ {
+		oneof := oneofs.Get(i)
+		if oneof.IsSynthetic() {
+			continue
+		}
+		fields := oneof.Fields()
+		for a := 0; a < fields.Len(); a++ {
+			for b := a + 1; b < fields.Len(); b++ {
+				exclusions = append(exclusions, map[string]interface{}{
+					"not": map[string]interface{}{
+						"required": []interface{}{c.propertyName(fields.Get(a)), c.propertyName(fields.Get(b))},
+					},
+				})
+			}
+		}
+	}
+	return exclusions
+}
+
+func (c *converter) propertyName(field protoreflect.FieldDescriptor) string {
+	if c.protoNames {
+		return string(field.Name())
+	}
+	return field.JSONName()
+}
+
+// field returns the schema for a field, including repeated and map fields
+func (c *converter) field(field protoreflect.FieldDescriptor) map[string]interface{} {
+	switch {
+	case field.IsMap():
+		return map[string]interface{}{"type": "object", "additionalProperties": c.singular(field.MapValue())}
+	case field.IsList():
+		return map[string]interface{}{"type": "array", "items": c.singular(field)}
+	default:
+		return c.singular(field)
+	}
+}
+
+// singular returns the schema for a single value of a field
+func (c *converter) singular(field protoreflect.FieldDescriptor) map[string]interface{} {
+	switch field.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return c.message(field.Message())
+	case protoreflect.EnumKind:
+		return enumSchema(field.Enum())
+	default:
+		return scalarSchema(field.Kind())
+	}
+}
+
+// scalarSchema returns the JSON form of a scalar kind
+func scalarSchema(kind protoreflect.Kind) map[string]interface{} {
+	switch kind {
+	case protoreflect.BoolKind:
+		return map[string]interface{}{"type": "boolean"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return map[string]interface{}{"type": "integer", "minimum": math.MinInt32, "maximum": math.MaxInt32}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return map[string]interface{}{"type": "integer", "minimum": 0, "maximum": float64(math.MaxUint32)}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		// 64-bit integers are encoded as strings, and integers are accepted when decoding
+		return map[string]interface{}{"type": []interface{}{"string", "integer"}, "pattern": `^-?[0-9]+$`}
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return map[string]interface{}{"type": []interface{}{"string", "integer"}, "pattern": `^[0-9]+$`, "minimum": 0}
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return map[string]interface{}{"type": "number"}
+	case protoreflect.BytesKind:
+		// Standard or URL-safe base64, with or without padding
+		return map[string]interface{}{"type": "string", "format": "byte", "pattern": `^[A-Za-z0-9+/_-]*={0,2}$`}
+	default:
+		return map[string]interface{}{"type": "string"}
+	}
+}
+
+// enumSchema returns the value names of an enum
+func enumSchema(desc protoreflect.EnumDescriptor) map[string]interface{} {
+	if desc.FullName() == "google.protobuf.NullValue" {
+		return map[string]interface{}{"type": "null"}
+	}
+	values := desc.Values()
+	names := make([]interface{}, values.Len())
+	for i := range names {
+		names[i] = string(values.Get(i).Name())
+	}
+	return map[string]interface{}{"type": "string", "enum": names}
+}
+
+// wellKnownSchema returns the JSON form of a well-known type, or nil for other messages
+func wellKnownSchema(desc protoreflect.MessageDescriptor) map[string]interface{} {
+	switch desc.FullName() {
+	case "google.protobuf.Timestamp":
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	case "google.protobuf.Duration":
+		return map[string]interface{}{"type": "string", "pattern": `^-?[0-9]+(\.[0-9]{1,9})?s$`}
+	case "google.protobuf.FieldMask":
+		return map[string]interface{}{"type": "string"}
+	case "google.protobuf.Struct", "google.protobuf.Empty":
+		return map[string]interface{}{"type": "object"}
+	case "google.protobuf.ListValue":
+		return map[string]interface{}{"type": "array"}
+	case "google.protobuf.Value":
+		return map[string]interface{}{}
+	case "google.protobuf.Any":
+		return map[string]interface{}{
+			"type":       "object",
+			"properties": map[string]interface{}{"@type": map[string]interface{}{"type": "string"}},
+			"required":   []interface{}{"@type"},
+		}
+	case "google.protobuf.DoubleValue", "google.protobuf.FloatValue":
+		return scalarSchema(protoreflect.DoubleKind)
+	case "google.protobuf.Int64Value":
+		return scalarSchema(protoreflect.Int64Kind)
+	case "google.protobuf.UInt64Value":
+		return scalarSchema(protoreflect.Uint64Kind)
+	case "google.protobuf.Int32Value":
+		return scalarSchema(protoreflect.Int32Kind)
+	case "google.protobuf.UInt32Value":
+		return scalarSchema(protoreflect.Uint32Kind)
+	case "google.protobuf.BoolValue":
+		return scalarSchema(protoreflect.BoolKind)
+	case "google.protobuf.StringValue":
+		return scalarSchema(protoreflect.StringKind)
+	case "google.protobuf.BytesValue":
+		return scalarSchema(protoreflect.BytesKind)
+	default:
+		return nil
+	}
+}
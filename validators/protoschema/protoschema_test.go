@@ -0,0 +1,306 @@
+package protoschema
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/typepb"
+
+	goop "github.com/picogrid/go-op"
+)
+
+func TestFromMessageDescriptor(t *testing.T) {
+	schema, err := FromMessageDescriptor((&typepb.Field{}).ProtoReflect().Descriptor())
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		data    map[string]interface{}
+		wantErr bool
+	}{
+		{"Valid message", map[string]interface{}{
+			"kind": "TYPE_STRING", "cardinality": "CARDINALITY_OPTIONAL", "number": float64(1),
+			"name": "email", "jsonName": "email", "options": []interface{}{map[string]interface{}{"name": "deprecated"}},
+		}, false},
+		{"Empty message", map[string]interface{}{}, false},
+		{"Unknown enum value", map[string]interface{}{"kind": "TYPE_UUID"}, true},
+		{"Integer out of range", map[string]interface{}{"number": float64(1 << 32)}, true},
+		{"Wrong scalar type", map[string]interface{}{"packed": "yes"}, true},
+		{"Invalid nested message", map[string]interface{}{"options": []interface{}{map[string]interface{}{"name": 1}}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := schema.Validate(tt.data); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("Proto names", func(t *testing.T) {
+		schema, err := FromMessageDescriptor((&typepb.Field{}).ProtoReflect().Descriptor(), WithProtoNames())
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		properties := schema.(goop.EnhancedSchema).ToOpenAPISchema().Properties
+		if _, ok := properties["json_name"]; !ok {
+			t.Errorf("Expected json_name property, got %v", properties)
+		}
+	})
+}
+
+func TestFromMessageDescriptorRecursive(t *testing.T) {
+	schema, err := FromMessageDescriptor((&descriptorpb.DescriptorProto{}).ProtoReflect().Descriptor())
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	valid := map[string]interface{}{
+		"name": "Outer",
+		"nestedType": []interface{}{
+			map[string]interface{}{"name": "Inner", "nestedType": []interface{}{map[string]interface{}{"name": "Innermost"}}},
+		},
+	}
+	if err := schema.Validate(valid); err != nil {
+		t.Errorf("Expected valid nested messages, got: %v", err)
+	}
+
+	invalid := map[string]interface{}{
+		"nestedType": []interface{}{
+			map[string]interface{}{"nestedType": []interface{}{map[string]interface{}{"name": 1}}},
+		},
+	}
+	if err := schema.Validate(invalid); err == nil {
+		t.Error("Expected error for an invalid deeply nested message")
+	}
+}
+
+func TestFromMessageDescriptorFieldTypes(t *testing.T) {
+	desc := eventDescriptor(t)
+	schema, err := FromMessageDescriptor(desc)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		data    map[string]interface{}
+		wantErr bool
+	}{
+		{"Valid event", map[string]interface{}{
+			"id": "evt_1", "occurredAt": "2024-01-02T03:04:05Z", "count": "9007199254740993",
+			"tags": map[string]interface{}{"env": float64(1)}, "email": "ada@example.com", "payload": "aGVsbG8=",
+		}, false},
+		{"64-bit integer as number", map[string]interface{}{"count": float64(42)}, false},
+		{"Invalid 64-bit integer string", map[string]interface{}{"count": "forty-two"}, true},
+		{"Invalid timestamp", map[string]interface{}{"occurredAt": "yesterday"}, true},
+		{"Invalid map value", map[string]interface{}{"tags": map[string]interface{}{"env": "prod"}}, true},
+		{"Invalid base64", map[string]interface{}{"payload": "not base64!"}, true},
+		{"Two oneof fields", map[string]interface{}{"email": "ada@example.com", "phone": "+15555550100"}, true},
+		{"Single oneof field", map[string]interface{}{"email": "ada@example.com"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := schema.Validate(tt.data); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestForMessage(t *testing.T) {
+	schema := ForMessage[*typepb.Field]()
+
+	t.Run("Validates messages", func(t *testing.T) {
+		field := &typepb.Field{Kind: typepb.Field_TYPE_INT64, Number: 3, Name: "count", JsonName: "count"}
+		if err := schema.Validate(field); err != nil {
+			t.Errorf("Expected valid message, got: %v", err)
+		}
+	})
+
+	t.Run("Validates decoded JSON", func(t *testing.T) {
+		if err := schema.Validate(map[string]interface{}{"number": "three"}); err == nil {
+			t.Error("Expected error for a string number")
+		}
+	})
+
+	t.Run("Documents the message", func(t *testing.T) {
+		openAPI := schema.(goop.EnhancedSchema).ToOpenAPISchema()
+		if openAPI.Type != "object" || openAPI.Properties["jsonName"] == nil {
+			t.Errorf("Unexpected schema %+v", openAPI)
+		}
+	})
+}
+
+func TestToFileDescriptor(t *testing.T) {
+	order := &staticSchema{schema: &goop.OpenAPISchema{
+		Type: "object",
+		Properties: map[string]*goop.OpenAPISchema{
+			"id":         {Type: "string"},
+			"quantity":   {Type: "integer"},
+			"total":      {Type: "number"},
+			"paid":       {Type: "boolean"},
+			"created_at": {Type: "string", Format: "date-time"},
+			"notes":      {Type: "array", Items: &goop.OpenAPISchema{Type: "string"}},
+			"customer":   {Ref: "#/components/schemas/Customer"},
+			"shipping": {Type: "object", Properties: map[string]*goop.OpenAPISchema{
+				"city": {Type: "string"},
+			}},
+			"labels":   {Type: "object", AdditionalProperties: &goop.OpenAPISchemaOrBool{Schema: &goop.OpenAPISchema{Type: "string"}}},
+			"metadata": {Type: "object"},
+			"extra":    {OneOf: []*goop.OpenAPISchema{{Type: "string"}, {Type: "integer"}}},
+		},
+	}}
+	customer := &staticSchema{schema: &goop.OpenAPISchema{
+		Type:       "object",
+		Properties: map[string]*goop.OpenAPISchema{"name": {Type: "string"}},
+	}}
+
+	file, err := ToFileDescriptor("shop/v1/shop.proto", "shop.v1", map[string]goop.Schema{"Order": order, "Customer": customer})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	message := file.Messages().ByName("Order")
+	if message == nil {
+		t.Fatal("Expected Order message")
+	}
+
+	kinds := map[string]string{
+		"id":         "string",
+		"quantity":   "int64",
+		"total":      "double",
+		"paid":       "bool",
+		"created_at": "google.protobuf.Timestamp",
+		"notes":      "string",
+		"customer":   "shop.v1.Customer",
+		"shipping":   "shop.v1.Order.Shipping",
+		"metadata":   "google.protobuf.Struct",
+		"extra":      "google.protobuf.Value",
+	}
+	for name, want := range kinds {
+		field := message.Fields().ByName(protoreflect.Name(name))
+		if field == nil {
+			t.Errorf("Expected field %s", name)
+			continue
+		}
+		got := field.Kind().String()
+		if field.Message() != nil {
+			got = string(field.Message().FullName())
+		}
+		if got != want {
+			t.Errorf("Field %s: expected %s, got %s", name, want, got)
+		}
+	}
+	if !message.Fields().ByName("notes").IsList() {
+		t.Error("Expected notes to be repeated")
+	}
+	if labels := message.Fields().ByName("labels"); !labels.IsMap() || labels.MapValue().Kind() != protoreflect.StringKind {
+		t.Error("Expected labels to be a map of strings")
+	}
+
+	t.Run("Round trips JSON", func(t *testing.T) {
+		data := []byte(`{"id":"ord_1","quantity":"2","created_at":"2024-01-02T03:04:05Z","labels":{"channel":"web"},"customer":{"name":"Ada"}}`)
+		if err := protojson.Unmarshal(data, dynamicpb.NewMessage(message)); err != nil {
+			t.Fatalf("Expected JSON to decode into the message, got: %v", err)
+		}
+
+		schema, err := FromMessageDescriptor(message)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if err := schema.Validate(map[string]interface{}{"id": "ord_1", "created_at": "2024-01-02T03:04:05Z"}); err != nil {
+			t.Errorf("Expected valid order, got: %v", err)
+		}
+		if err := schema.Validate(map[string]interface{}{"quantity": "two"}); err == nil {
+			t.Error("Expected error for an invalid quantity")
+		}
+	})
+
+	t.Run("Rejects unknown references", func(t *testing.T) {
+		_, err := ToFileDescriptorProto("shop.proto", "shop", map[string]goop.Schema{"Order": order})
+		if err == nil {
+			t.Error("Expected error for a reference to an unconverted schema")
+		}
+	})
+
+	t.Run("Rejects non-object schemas", func(t *testing.T) {
+		_, err := ToFileDescriptorProto("shop.proto", "shop", map[string]goop.Schema{
+			"Name": &staticSchema{schema: &goop.OpenAPISchema{Type: "string"}},
+		})
+		if err == nil {
+			t.Error("Expected error for a string schema")
+		}
+	})
+}
+
+// staticSchema documents a fixed OpenAPI schema and accepts any value
+type staticSchema struct {
+	schema *goop.OpenAPISchema
+}
+
+func (s *staticSchema) Validate(data interface{}) error      { return nil }
+func (s *staticSchema) ToOpenAPISchema() *goop.OpenAPISchema { return s.schema }
+func (s *staticSchema) GetValidationInfo() *goop.ValidationInfo {
+	return &goop.ValidationInfo{Required: true}
+}
+
+// eventDescriptor builds a message covering 64-bit integers, timestamps, maps, bytes, and oneofs
+func eventDescriptor(t *testing.T) protoreflect.MessageDescriptor {
+	t.Helper()
+	optional := descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()
+	field := func(name, jsonName string, number int32, kind descriptorpb.FieldDescriptorProto_Type) *descriptorpb.FieldDescriptorProto {
+		return &descriptorpb.FieldDescriptorProto{
+			Name: proto.String(name), JsonName: proto.String(jsonName), Number: proto.Int32(number),
+			Label: optional, Type: kind.Enum(),
+		}
+	}
+
+	occurredAt := field("occurred_at", "occurredAt", 2, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE)
+	occurredAt.TypeName = proto.String(".google.protobuf.Timestamp")
+	tags := field("tags", "tags", 4, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE)
+	tags.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+	tags.TypeName = proto.String(".events.v1.Event.TagsEntry")
+	email := field("email", "email", 5, descriptorpb.FieldDescriptorProto_TYPE_STRING)
+	email.OneofIndex = proto.Int32(0)
+	phone := field("phone", "phone", 6, descriptorpb.FieldDescriptorProto_TYPE_STRING)
+	phone.OneofIndex = proto.Int32(0)
+
+	file, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:       proto.String("events/v1/events.proto"),
+		Package:    proto.String("events.v1"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"google/protobuf/timestamp.proto"},
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("Event"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				field("id", "id", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+				occurredAt,
+				field("count", "count", 3, descriptorpb.FieldDescriptorProto_TYPE_INT64),
+				tags,
+				email,
+				phone,
+				field("payload", "payload", 7, descriptorpb.FieldDescriptorProto_TYPE_BYTES),
+			},
+			NestedType: []*descriptorpb.DescriptorProto{{
+				Name:    proto.String("TagsEntry"),
+				Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("key", "key", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+					field("value", "value", 2, descriptorpb.FieldDescriptorProto_TYPE_INT32),
+				},
+			}},
+			OneofDecl: []*descriptorpb.OneofDescriptorProto{{Name: proto.String("contact")}},
+		}},
+	}, protoregistry.GlobalFiles)
+	if err != nil {
+		t.Fatalf("Failed to build descriptor: %v", err)
+	}
+	return file.Messages().ByName("Event")
+}
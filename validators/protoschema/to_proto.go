@@ -0,0 +1,268 @@
+package protoschema
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	// Register the well-known types ToFileDescriptor references
+	_ "google.golang.org/protobuf/types/known/structpb"
+	_ "google.golang.org/protobuf/types/known/timestamppb"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// Well-known files imported by generated descriptors
+const (
+	timestampFile = "google/protobuf/timestamp.proto"
+	structFile    = "google/protobuf/struct.proto"
+)
+
+// ToFileDescriptor builds a proto3 file descriptor with a message for each named object schema.
+// See ToFileDescriptorProto for how schemas map to messages.
+//
+// Example:
+//
+//	file, err := protoschema.ToFileDescriptor("users/v1/users.proto", "users.v1", map[string]goop.Schema{
+//	    "User": userSchema,
+//	})
+//	user := dynamicpb.NewMessage(file.Messages().ByName("User"))
+func ToFileDescriptor(path, pkg string, schemas map[string]goop.Schema) (protoreflect.FileDescriptor, error) {
+	file, err := ToFileDescriptorProto(path, pkg, schemas)
+	if err != nil {
+		return nil, err
+	}
+	return protodesc.NewFile(file, protoregistry.GlobalFiles)
+}
+
+// ToFileDescriptorProto builds a proto3 file with a message for each named object schema, which
+// can be written out as a .proto file or passed to other protobuf tooling.
+// Properties map to fields whose JSON names are the property names:
+//   - strings, integers, numbers, and booleans map to string, int64, double, and bool
+//   - date-time strings map to google.protobuf.Timestamp and byte strings to bytes
+//   - arrays map to repeated fields, and objects with only additionalProperties map to map fields
+//   - nested objects map to nested messages, and references to other named schemas map to their messages
+//   - free-form objects map to google.protobuf.Struct, and other schemas to google.protobuf.Value
+//
+// Fields are numbered in property name order, so adding a property renumbers the fields after it.
+// Pin field numbers by maintaining the generated .proto file once it is published.
+func ToFileDescriptorProto(path, pkg string, schemas map[string]goop.Schema) (*descriptorpb.FileDescriptorProto, error) {
+	b := &descriptorBuilder{pkg: pkg, names: make(map[string]bool), imports: make(map[string]bool)}
+	for name := range schemas {
+		b.names[name] = true
+	}
+
+	file := &descriptorpb.FileDescriptorProto{
+		Name:   proto.String(path),
+		Syntax: proto.String("proto3"),
+	}
+	if pkg != "" {
+		file.Package = proto.String(pkg)
+	}
+
+	names := make([]string, 0, len(schemas))
+	for name := range schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		generator, ok := schemas[name].(goop.OpenAPIGenerator)
+		if !ok {
+			return nil, fmt.Errorf("%s: schema does not generate an OpenAPI schema", name)
+		}
+		message, err := b.message(identifier(name), b.typeName(name), generator.ToOpenAPISchema(), name)
+		if err != nil {
+			return nil, err
+		}
+		file.MessageType = append(file.MessageType, message)
+	}
+
+	for dependency := range b.imports {
+		file.Dependency = append(file.Dependency, dependency)
+	}
+	sort.Strings(file.Dependency)
+	return file, nil
+}
+
+// descriptorBuilder converts OpenAPI schemas into message descriptors
+type descriptorBuilder struct {
+	pkg     string
+	names   map[string]bool
+	imports map[string]bool
+}
+
+// typeName returns the fully-qualified type name of a top-level message
+func (b *descriptorBuilder) typeName(name string) string {
+	if b.pkg == "" {
+		return "." + identifier(name)
+	}
+	return "." + b.pkg + "." + identifier(name)
+}
+
+// message converts an object schema; fullName is the message's fully-qualified type name
+func (b *descriptorBuilder) message(name, fullName string, schema *goop.OpenAPISchema, path string) (*descriptorpb.DescriptorProto, error) {
+	if schema.Ref != "" || (schema.Type != "object" && schema.Properties == nil) {
+		return nil, fmt.Errorf("%s: only object schemas can be converted to messages", path)
+	}
+
+	message := &descriptorpb.DescriptorProto{Name: proto.String(name)}
+	for i, property := range sortedKeys(schema.Properties) {
+		field := &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(identifier(property)),
+			JsonName: proto.String(property),
+			Number:   proto.Int32(int32(i + 1)),
+			Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		}
+		if err := b.field(message, fullName, field, schema.Properties[property], path+"."+property); err != nil {
+			return nil, err
+		}
+		message.Field = append(message.Field, field)
+	}
+	return message, nil
+}
+
+// field sets the type of a field, adding nested messages and map entries to parent
+func (b *descriptorBuilder) field(parent *descriptorpb.DescriptorProto, parentName string, field *descriptorpb.FieldDescriptorProto, schema *goop.OpenAPISchema, path string) error {
+	switch {
+	case schema.Type == "array" && schema.Items != nil:
+		field.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+		return b.singular(parent, parentName, field, camelCase(field.GetName()), schema.Items, path)
+	case schema.Type == "object" && len(schema.Properties) == 0 && schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil:
+		entryName := camelCase(field.GetName()) + "Entry"
+		entry := &descriptorpb.DescriptorProto{
+			Name:    proto.String(entryName),
+			Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+			Field: []*descriptorpb.FieldDescriptorProto{
+				{
+					Name:     proto.String("key"),
+					JsonName: proto.String("key"),
+					Number:   proto.Int32(1),
+					Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+					Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+				},
+				{
+					Name:     proto.String("value"),
+					JsonName: proto.String("value"),
+					Number:   proto.Int32(2),
+					Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+				},
+			},
+		}
+		// Map entries cannot declare nested messages, so object values are declared in parent
+		if err := b.singular(parent, parentName, entry.Field[1], camelCase(field.GetName())+"Value", schema.AdditionalProperties.Schema, path); err != nil {
+			return err
+		}
+		parent.NestedType = append(parent.NestedType, entry)
+		field.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+		field.Type = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+		field.TypeName = proto.String(parentName + "." + entryName)
+		return nil
+	default:
+		return b.singular(parent, parentName, field, camelCase(field.GetName()), schema, path)
+	}
+}
+
+// singular sets the type of a single value; nestedName names the message declared for an object value
+func (b *descriptorBuilder) singular(parent *descriptorpb.DescriptorProto, parentName string, field *descriptorpb.FieldDescriptorProto, nestedName string, schema *goop.OpenAPISchema, path string) error {
+	scalar := func(t descriptorpb.FieldDescriptorProto_Type) error {
+		field.Type = t.Enum()
+		return nil
+	}
+	message := func(typeName string) error {
+		field.Type = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+		field.TypeName = proto.String(typeName)
+		return nil
+	}
+
+	switch {
+	case schema.Ref != "":
+		name := strings.TrimPrefix(schema.Ref, componentPrefix)
+		if !b.names[name] {
+			return fmt.Errorf("%s: reference %q is not one of the converted schemas", path, schema.Ref)
+		}
+		return message(b.typeName(name))
+	case schema.Type == "string" && schema.Format == "date-time":
+		b.imports[timestampFile] = true
+		return message(".google.protobuf.Timestamp")
+	case schema.Type == "string" && schema.Format == "byte":
+		return scalar(descriptorpb.FieldDescriptorProto_TYPE_BYTES)
+	case schema.Type == "string":
+		return scalar(descriptorpb.FieldDescriptorProto_TYPE_STRING)
+	case schema.Type == "integer":
+		return scalar(descriptorpb.FieldDescriptorProto_TYPE_INT64)
+	case schema.Type == "number":
+		return scalar(descriptorpb.FieldDescriptorProto_TYPE_DOUBLE)
+	case schema.Type == "boolean":
+		return scalar(descriptorpb.FieldDescriptorProto_TYPE_BOOL)
+	case schema.Type == "object" && len(schema.Properties) > 0:
+		nested, err := b.message(nestedName, parentName+"."+nestedName, schema, path)
+		if err != nil {
+			return err
+		}
+		parent.NestedType = append(parent.NestedType, nested)
+		return message(parentName + "." + nestedName)
+	case schema.Type == "object":
+		b.imports[structFile] = true
+		return message(".google.protobuf.Struct")
+	case schema.Type == "array":
+		// Arrays of arrays and arrays in maps cannot be repeated fields
+		b.imports[structFile] = true
+		return message(".google.protobuf.ListValue")
+	default:
+		b.imports[structFile] = true
+		return message(".google.protobuf.Value")
+	}
+}
+
+// sortedKeys returns the keys of properties in name order
+func sortedKeys(properties map[string]*goop.OpenAPISchema) []string {
+	keys := make([]string, 0, len(properties))
+	for key := range properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// identifier replaces characters that cannot appear in protobuf identifiers with underscores
+func identifier(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		switch {
+		case r == '_' || (r < unicode.MaxASCII && (unicode.IsLetter(r) || (i > 0 && unicode.IsDigit(r)))):
+			b.WriteRune(r)
+		case i == 0 && unicode.IsDigit(r):
+			b.WriteRune('_')
+			b.WriteRune(r)
+		default:
+			b.WriteRune('_')
+		}
+	}
+	return b.String()
+}
+
+// camelCase converts a field name such as shipping_address into a message name such as ShippingAddress,
+// as protoc does for map entries
+func camelCase(name string) string {
+	var b strings.Builder
+	upperNext := true
+	for _, r := range name {
+		switch {
+		case r == '_':
+			upperNext = true
+		case upperNext:
+			b.WriteRune(unicode.ToUpper(r))
+			upperNext = false
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,103 @@
+package validators
+
+import (
+	"context"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// RemoteLookup checks values that need I/O to validate, such as IDs that must exist in a database
+// It returns an error for each invalid value; values missing from the map are valid.
+type RemoteLookup func(ctx context.Context, values []string) map[string]error
+
+// remoteCheck is a RemoteLookup attached to a string schema
+// Copies of the schema share the pointer, so every value checked by it joins one batch.
+type remoteCheck struct {
+	lookup RemoteLookup
+}
+
+// remoteBatchKey is the context key of the remoteBatch for the current validation
+type remoteBatchKey struct{}
+
+// remoteBatch collects the values of every remote check in a validation so each lookup runs once
+// The first context pass collects values; once resolved, the second pass reports the results.
+type remoteBatch struct {
+	pending  map[*remoteCheck][]string
+	seen     map[*remoteCheck]map[string]bool
+	results  map[*remoteCheck]map[string]error
+	resolved bool
+}
+
+// add queues value for check, once per distinct value
+func (b *remoteBatch) add(check *remoteCheck, value string) {
+	if b.pending == nil {
+		b.pending = make(map[*remoteCheck][]string)
+		b.seen = make(map[*remoteCheck]map[string]bool)
+	}
+	if b.seen[check] == nil {
+		b.seen[check] = make(map[string]bool)
+	}
+	if !b.seen[check][value] {
+		b.seen[check][value] = true
+		b.pending[check] = append(b.pending[check], value)
+	}
+}
+
+// resolve calls each check once with its queued values
+func (b *remoteBatch) resolve(ctx context.Context) {
+	b.results = make(map[*remoteCheck]map[string]error, len(b.pending))
+	for check, values := range b.pending {
+		b.results[check] = check.lookup(ctx, values)
+	}
+	b.resolved = true
+}
+
+// remoteBatchFrom returns the batch of the validation running with ctx, if any
+func remoteBatchFrom(ctx context.Context) *remoteBatch {
+	batch, _ := ctx.Value(remoteBatchKey{}).(*remoteBatch)
+	return batch
+}
+
+// remoteOnly reports whether ctx is the second context pass, which only reports remote results
+func remoteOnly(ctx context.Context) bool {
+	batch := remoteBatchFrom(ctx)
+	return batch != nil && batch.resolved
+}
+
+// checkRemote queues value for check in the first context pass and returns its result in the second
+// Outside a batch the lookup runs for the single value.
+func checkRemote(ctx context.Context, check *remoteCheck, value string) error {
+	batch := remoteBatchFrom(ctx)
+	switch {
+	case batch == nil:
+		return check.lookup(ctx, []string{value})[value]
+	case batch.resolved:
+		return batch.results[check][value]
+	default:
+		batch.add(check, value)
+		return nil
+	}
+}
+
+// runContextChecks runs the CustomCtx and RemoteCheck checks of schema on validated data
+// CustomCtx checks run first; if they pass, each remote lookup runs once with every value it
+// collected, and a second pass reports the invalid values at their fields. Warnings from both
+// passes are reported to the WarningHandler of ctx rather than returned. Schemas validated
+// inside another validation, such as component references, join the outer batch.
+func runContextChecks(ctx context.Context, schema contextChecker, data interface{}) error {
+	if remoteBatchFrom(ctx) != nil {
+		return schema.checkContext(ctx, data)
+	}
+
+	batch := &remoteBatch{}
+	batchCtx := context.WithValue(ctx, remoteBatchKey{}, batch)
+	warnings, err := goop.SplitWarnings(schema.checkContext(batchCtx, data))
+	if err == nil && len(batch.pending) > 0 {
+		batch.resolve(batchCtx)
+		var remoteWarnings []goop.ValidationError
+		remoteWarnings, err = goop.SplitWarnings(schema.checkContext(batchCtx, data))
+		warnings = append(warnings, remoteWarnings...)
+	}
+	goop.ReportWarnings(ctx, warnings)
+	return err
+}
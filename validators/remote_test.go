@@ -0,0 +1,83 @@
+package validators
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// TestRemoteCheck tests batched lookups of values that need I/O to validate
+func TestRemoteCheck(t *testing.T) {
+	templates := map[string]bool{"welcome": true, "invoice": true}
+	var calls [][]string
+	templateExists := func(ctx context.Context, ids []string) map[string]error {
+		calls = append(calls, ids)
+		errs := make(map[string]error)
+		for _, id := range ids {
+			if !templates[id] {
+				errs[id] = errors.New("template does not exist")
+			}
+		}
+		return errs
+	}
+	templateID := String().RemoteCheck(templateExists).Required()
+	schema := Object(map[string]interface{}{
+		"default_template": templateID,
+		"messages": Array(Object(map[string]interface{}{
+			"template_id": templateID,
+		}).Required()).Required(),
+	}).Required()
+
+	data := map[string]interface{}{
+		"default_template": "welcome",
+		"messages": []interface{}{
+			map[string]interface{}{"template_id": "invoice"},
+			map[string]interface{}{"template_id": "missing"},
+			map[string]interface{}{"template_id": "welcome"},
+		},
+	}
+
+	err := schema.ValidateCtx(context.Background(), data)
+	if err == nil || !strings.Contains(err.Error(), "template does not exist") || !strings.Contains(err.Error(), "[1]") {
+		t.Errorf("Expected the missing template to fail at index 1, got: %v", err)
+	}
+	if len(calls) != 1 {
+		t.Fatalf("Expected one batched lookup, got %d: %v", len(calls), calls)
+	}
+	sort.Strings(calls[0])
+	if strings.Join(calls[0], ",") != "invoice,missing,welcome" {
+		t.Errorf("Expected each distinct value once, got %v", calls[0])
+	}
+
+	t.Run("Skipped when other checks fail", func(t *testing.T) {
+		calls = nil
+		checked := String().Min(5).RemoteCheck(templateExists).Required()
+		if err := checked.ValidateCtx(context.Background(), "abc"); err == nil {
+			t.Error("Expected the minimum length to fail")
+		}
+		if len(calls) != 0 {
+			t.Errorf("Expected no lookups for invalid data, got %v", calls)
+		}
+	})
+
+	t.Run("Skipped by Validate", func(t *testing.T) {
+		calls = nil
+		if err := schema.Validate(data); err != nil {
+			t.Errorf("Expected Validate to skip remote checks, got: %v", err)
+		}
+		if len(calls) != 0 {
+			t.Errorf("Expected no lookups, got %v", calls)
+		}
+	})
+
+	t.Run("Single value", func(t *testing.T) {
+		if err := templateID.ValidateCtx(context.Background(), "welcome"); err != nil {
+			t.Errorf("Expected an existing template to be valid, got: %v", err)
+		}
+		if err := templateID.ValidateCtx(context.Background(), "missing"); err == nil {
+			t.Error("Expected a missing template to fail")
+		}
+	})
+}
@@ -0,0 +1,339 @@
+package validators
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// ValidateStream validates JSON read from r against schema without decoding the whole document.
+// Arrays and objects built with this package are validated incrementally from json.Decoder tokens,
+// so only one array element is held in memory at a time. This suits bulk endpoints whose bodies
+// are too large to materialize.
+//
+// Errors mirror Validate, except that the Value of array and object errors is nil because the
+// full value is never built. Arrays and objects with a Custom validator need the complete value,
+// so they are materialized and validated normally.
+//
+// Malformed JSON is reported as a plain error rather than a *goop.ValidationError.
+// Callers that also need the data can read r through an io.TeeReader.
+func ValidateStream(schema goop.Schema, r io.Reader) error {
+	s := &streamValidator{dec: json.NewDecoder(r)}
+
+	tok, ok := s.token()
+	if !ok {
+		return s.err
+	}
+	validationErr := s.validateValue(tok, schema, schema.Validate)
+	if s.err != nil {
+		return s.err
+	}
+
+	if _, err := s.dec.Token(); err != io.EOF {
+		return fmt.Errorf("invalid JSON: unexpected data after top-level value")
+	}
+	return validationErr
+}
+
+// streamValidator walks a JSON token stream, remembering the first decode error
+type streamValidator struct {
+	dec *json.Decoder
+	err error
+}
+
+// token reads the next token, recording decode failures
+func (s *streamValidator) token() (json.Token, bool) {
+	tok, err := s.dec.Token()
+	if err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		s.err = fmt.Errorf("invalid JSON: %w", err)
+		return nil, false
+	}
+	return tok, true
+}
+
+// validateValue validates the value starting at tok
+// Arrays and objects are streamed when the schema supports it; other values are materialized
+// and passed to validate
+func (s *streamValidator) validateValue(tok json.Token, schema interface{}, validate func(interface{}) error) error {
+	switch tok {
+	case json.Delim('['):
+		if a := streamableArray(schema); a != nil {
+			return s.validateArray(a)
+		}
+	case json.Delim('{'):
+		if o := streamableObject(schema); o != nil {
+			return s.validateObject(o)
+		}
+	}
+
+	value := s.readValue(tok)
+	if s.err != nil {
+		return nil
+	}
+	return validate(value)
+}
+
+// validateArray validates array elements one at a time
+// Checks are reported in the same order of precedence as arraySchema.validate
+func (s *streamValidator) validateArray(a *arraySchema) error {
+	collector := acquireErrorCollector()
+	defer func() {
+		if collector != nil {
+			collector.release()
+		}
+	}()
+
+	check, seen := a.newContentCheck()
+
+	count := 0
+	for s.dec.More() {
+		tok, ok := s.token()
+		if !ok {
+			return nil
+		}
+		index := count
+		count++
+
+		// Past the limit the result is decided; remaining elements are only consumed
+		if a.maxItems > 0 && count > a.maxItems {
+			s.readValue(tok)
+			if s.err != nil {
+				return nil
+			}
+			continue
+		}
+
+		// Contains and uniqueness compare whole elements, so those elements are materialized
+		if check.needsItems(a, &seen) || a.elementSchema == nil {
+			item := s.readValue(tok)
+			if s.err != nil {
+				return nil
+			}
+			if a.elementSchema != nil {
+				if err := a.validateElement(item); err != nil {
+					addElementError(collector, index, item, err)
+				}
+			}
+			check.visit(a, &seen, index, item)
+			continue
+		}
+
+		if err := s.validateValue(tok, a.elementSchema, a.validateElement); err != nil {
+			addElementError(collector, index, nil, err)
+		}
+		if s.err != nil {
+			return nil
+		}
+	}
+	if _, ok := s.token(); !ok {
+		return nil
+	}
+
+	if a.maxItems > 0 && count > a.maxItems {
+		return goop.NewValidationError("", nil,
+			a.getErrorMessage(errorKeys.MaxItems,
+				fmt.Sprintf("array has too many items, maximum is %d", a.maxItems)))
+	}
+
+	if a.minItems > 0 && count < a.minItems {
+		return goop.NewValidationError("", nil,
+			a.getErrorMessage(errorKeys.MinItems,
+				fmt.Sprintf("array has too few items, minimum is %d", a.minItems)))
+	}
+
+	details := collector.release()
+	collector = nil
+	if len(details) > 0 {
+		return goop.NewNestedValidationError("", nil, "array contains invalid items", details)
+	}
+
+	return check.err(a, nil)
+}
+
+// validateObject validates object properties as they are read, using the object's compiled fields
+// Checks are reported in the same order of precedence as objectSchema.validate
+func (s *streamValidator) validateObject(o *objectSchema) error {
+	fields := o.compiledFields()
+	collector := acquireErrorCollector()
+	defer func() {
+		if collector != nil {
+			collector.release()
+		}
+	}()
+
+	present := make(map[string]bool, len(fields))
+	// Scalar values are kept for RequiredIf and RequiredUnless; nested values only count as present
+	var values map[string]interface{}
+	if len(o.conditions) > 0 {
+		values = make(map[string]interface{})
+	}
+	unknownKey := ""
+	for s.dec.More() {
+		keyTok, ok := s.token()
+		if !ok {
+			return nil
+		}
+		key, _ := keyTok.(string)
+		present[key] = true
+
+		valueTok, ok := s.token()
+		if !ok {
+			return nil
+		}
+		if values != nil {
+			values[key] = valueTok
+		}
+
+		field, exists := findField(fields, key)
+		if !exists {
+			if o.strictMode && unknownKey == "" {
+				unknownKey = key
+			}
+			s.readValue(valueTok)
+			if s.err != nil {
+				return nil
+			}
+			continue
+		}
+
+		fieldSchema := field.raw
+		if field.schema != nil {
+			fieldSchema = field.schema
+		}
+		err := s.validateValue(valueTok, fieldSchema, func(value interface{}) error {
+			return o.validateCompiledField(field, value)
+		})
+		if s.err != nil {
+			return nil
+		}
+		if err != nil {
+			if validationErr, ok := err.(*goop.ValidationError); ok {
+				validationErr.Field = key
+				collector.add(*validationErr)
+			} else {
+				collector.add(*goop.NewValidationError(key, nil, err.Error()))
+			}
+		}
+	}
+	if _, ok := s.token(); !ok {
+		return nil
+	}
+
+	if o.minProperties > 0 && len(present) < o.minProperties {
+		return goop.NewValidationError("", nil,
+			o.getErrorMessage(errorKeys.MinProperties,
+				fmt.Sprintf("object has too few properties, minimum is %d but got %d", o.minProperties, len(present))))
+	}
+
+	if o.maxProperties > 0 && len(present) > o.maxProperties {
+		return goop.NewValidationError("", nil,
+			o.getErrorMessage(errorKeys.MaxProperties,
+				fmt.Sprintf("object has too many properties, maximum is %d but got %d", o.maxProperties, len(present))))
+	}
+
+	if unknownKey != "" {
+		return goop.NewValidationError(unknownKey, nil,
+			o.getErrorMessage(errorKeys.UnknownKey, fmt.Sprintf("unknown key: %s", unknownKey)))
+	}
+
+	// Report missing required fields in the compiled, sorted order
+	if !o.partialMode {
+		for _, field := range fields {
+			if field.required && !present[field.name] {
+				collector.add(*goop.NewValidationError(field.name, nil, fmt.Sprintf("missing required field: %s", field.name)))
+			}
+		}
+	}
+	o.checkRequiredConditions(func(name string) (interface{}, bool) {
+		return values[name], present[name]
+	}, collector)
+
+	details := collector.release()
+	collector = nil
+	if len(details) > 0 {
+		return goop.NewNestedValidationError("", nil, "object validation failed", details)
+	}
+	return nil
+}
+
+// readValue materializes the value starting at tok
+func (s *streamValidator) readValue(tok json.Token) interface{} {
+	switch tok {
+	case json.Delim('['):
+		arr := make([]interface{}, 0)
+		for s.dec.More() {
+			next, ok := s.token()
+			if !ok {
+				return nil
+			}
+			arr = append(arr, s.readValue(next))
+			if s.err != nil {
+				return nil
+			}
+		}
+		s.token()
+		return arr
+	case json.Delim('{'):
+		obj := make(map[string]interface{})
+		for s.dec.More() {
+			keyTok, ok := s.token()
+			if !ok {
+				return nil
+			}
+			valueTok, ok := s.token()
+			if !ok {
+				return nil
+			}
+			key, _ := keyTok.(string)
+			obj[key] = s.readValue(valueTok)
+			if s.err != nil {
+				return nil
+			}
+		}
+		s.token()
+		return obj
+	default:
+		return tok
+	}
+}
+
+// streamableArray returns the array schema behind schema when its elements can be streamed
+func streamableArray(schema interface{}) *arraySchema {
+	var a *arraySchema
+	switch s := schema.(type) {
+	case *requiredArraySchema:
+		a = s.arraySchema
+	case *optionalArraySchema:
+		a = s.arraySchema
+	case *arraySchema:
+		a = s
+	}
+	if a == nil || a.customFunc != nil {
+		return nil
+	}
+	return a
+}
+
+// streamableObject returns the object schema behind schema when its properties can be streamed
+func streamableObject(schema interface{}) *objectSchema {
+	var o *objectSchema
+	switch s := schema.(type) {
+	case *requiredObjectSchema:
+		o = s.objectSchema
+	case *optionalObjectSchema:
+		o = s.objectSchema
+	case *compiledObjectSchema:
+		o = s.object
+	case *objectSchema:
+		o = s
+	}
+	if o == nil || o.customFunc != nil || o.constValue != nil {
+		return nil
+	}
+	return o
+}
@@ -0,0 +1,125 @@
+package validators
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	goop "github.com/picogrid/go-op"
+)
+
+func TestValidateStream(t *testing.T) {
+	item := Object(map[string]interface{}{
+		"sku":  String().Min(3).Required(),
+		"qty":  Number().Min(1).Required(),
+		"tags": Array(String().Min(1)).Optional(),
+	}).Strict().Required()
+	schema := Object(map[string]interface{}{
+		"source": String().Required(),
+		"items":  Array(item).MinItems(1).MaxItems(5).Required(),
+	}).Required()
+
+	cases := map[string]string{
+		"valid":                   `{"source":"erp","items":[{"sku":"abc","qty":1},{"sku":"def","qty":2,"tags":["x"]}]}`,
+		"invalid element":         `{"source":"erp","items":[{"sku":"abc","qty":1},{"sku":"d","qty":0}]}`,
+		"unknown key in element":  `{"source":"erp","items":[{"sku":"abc","qty":1,"extra":true}]}`,
+		"invalid nested array":    `{"source":"erp","items":[{"sku":"abc","qty":1,"tags":[""]}]}`,
+		"too many items":          `{"source":"erp","items":[{"sku":"abc","qty":1},{"sku":"abc","qty":1},{"sku":"abc","qty":1},{"sku":"abc","qty":1},{"sku":"abc","qty":1},{"sku":"abc","qty":1}]}`,
+		"too few items":           `{"source":"erp","items":[]}`,
+		"missing required field":  `{"items":[{"sku":"abc","qty":1}]}`,
+		"wrong type for array":    `{"source":"erp","items":"nope"}`,
+		"wrong type for document": `[1,2,3]`,
+		"null document":           `null`,
+	}
+
+	for name, body := range cases {
+		t.Run(name, func(t *testing.T) {
+			var data interface{}
+			if err := json.Unmarshal([]byte(body), &data); err != nil {
+				t.Fatalf("Invalid test JSON: %v", err)
+			}
+			expected := schema.Validate(data)
+			got := ValidateStream(schema, strings.NewReader(body))
+
+			if (expected == nil) != (got == nil) {
+				t.Fatalf("Expected streaming result %v to match %v", got, expected)
+			}
+			if expected != nil {
+				if _, ok := got.(*goop.ValidationError); !ok {
+					t.Fatalf("Expected ValidationError, got %T: %v", got, got)
+				}
+				if !sameFields(expected, got) {
+					t.Errorf("Expected failing fields to match, original %v, streamed %v", expected, got)
+				}
+			}
+		})
+	}
+
+	t.Run("Contains and unique items", func(t *testing.T) {
+		arr := Array(Number()).Contains(float64(42)).UniqueItems().Required()
+		if err := ValidateStream(arr, strings.NewReader(`[1, 42, 3]`)); err != nil {
+			t.Errorf("Expected valid array to pass, got %v", err)
+		}
+		if err := ValidateStream(arr, strings.NewReader(`[1, 2]`)); err == nil {
+			t.Error("Expected array without 42 to fail")
+		}
+		if err := ValidateStream(arr, strings.NewReader(`[42, 42]`)); err == nil {
+			t.Error("Expected duplicate items to fail")
+		}
+	})
+
+	t.Run("Custom validators see the full value", func(t *testing.T) {
+		arr := Array(Number()).Custom(func(items []interface{}) error {
+			if len(items) != 2 {
+				return fmt.Errorf("expected two items")
+			}
+			return nil
+		}).Required()
+		if err := ValidateStream(arr, strings.NewReader(`[1, 2]`)); err != nil {
+			t.Errorf("Expected custom validator to pass, got %v", err)
+		}
+		if err := ValidateStream(arr, strings.NewReader(`[1]`)); err == nil {
+			t.Error("Expected custom validator to fail")
+		}
+	})
+
+	t.Run("Malformed JSON", func(t *testing.T) {
+		for _, body := range []string{`{"source":`, `[1, 2`, `{"source":"a"} trailing`, ``} {
+			err := ValidateStream(schema, strings.NewReader(body))
+			if err == nil {
+				t.Errorf("Expected error for %q", body)
+				continue
+			}
+			if _, ok := err.(*goop.ValidationError); ok {
+				t.Errorf("Expected a decode error for %q, got validation error %v", body, err)
+			}
+		}
+	})
+
+	t.Run("Large array from a stream", func(t *testing.T) {
+		pr, pw := io.Pipe()
+		go func() {
+			_, _ = pw.Write([]byte(`[`))
+			for i := 0; i < 100000; i++ {
+				if i > 0 {
+					_, _ = pw.Write([]byte(`,`))
+				}
+				qty := 1
+				if i == 99999 {
+					qty = 0
+				}
+				_, _ = fmt.Fprintf(pw, `{"sku":"sku-%d","qty":%d}`, i, qty)
+			}
+			_, _ = pw.Write([]byte(`]`))
+			_ = pw.Close()
+		}()
+
+		err := ValidateStream(Array(item).Required(), pr)
+		validationErr, ok := err.(*goop.ValidationError)
+		if !ok || len(validationErr.Details) != 1 || validationErr.Details[0].Field != "[99999]" {
+			t.Errorf("Expected a single failure at [99999], got %v", err)
+		}
+	})
+}
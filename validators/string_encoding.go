@@ -0,0 +1,125 @@
+package validators
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// Encodings for string content, set by Base64, Hex, and JWTFormat
+const (
+	encodingBase64 = "base64"
+	encodingHex    = "base16"
+	encodingJWT    = "jwt"
+)
+
+// jwtPattern documents the three base64url segments of a compact JWS
+const jwtPattern = `^[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*$`
+
+// validateEncoding checks that str is well-formed encoded content within the decoded size limits
+// Decoding base64 allocates, so only schemas using Base64 pay for it.
+func (s *stringSchema) validateEncoding(str string) error {
+	var size int
+	switch s.encoding {
+	case encodingBase64:
+		decoded, err := base64.StdEncoding.Strict().DecodeString(str)
+		if err != nil {
+			return goop.NewValidationError(str, str, s.getErrorMessage(errorKeys.Encoding, "invalid base64 encoding"))
+		}
+		size = len(decoded)
+	case encodingHex:
+		if !isHex(str) {
+			return goop.NewValidationError(str, str, s.getErrorMessage(errorKeys.Encoding, "invalid hex encoding"))
+		}
+		size = len(str) / 2
+	case encodingJWT:
+		if !isJWT(str) {
+			return goop.NewValidationError(str, str, s.getErrorMessage(errorKeys.Encoding, "invalid JWT format"))
+		}
+		return nil
+	}
+
+	if s.minDecoded > 0 && size < s.minDecoded {
+		return goop.NewValidationError(str, str, s.getErrorMessage(errorKeys.DecodedSize,
+			fmt.Sprintf("decoded content is too small, minimum is %d bytes", s.minDecoded)))
+	}
+	if s.maxDecoded > 0 && size > s.maxDecoded {
+		return goop.NewValidationError(str, str, s.getErrorMessage(errorKeys.DecodedSize,
+			fmt.Sprintf("decoded content is too large, maximum is %d bytes", s.maxDecoded)))
+	}
+	return nil
+}
+
+// addEncodingSchema documents the encoding, expressing decoded size limits as encoded lengths
+// when no explicit length limits are set
+func (s *stringSchema) addEncodingSchema(schema *goop.OpenAPISchema) {
+	encodedLength := func(n int) int { return 2 * n }
+	switch s.encoding {
+	case encodingBase64:
+		schema.ContentEncoding = encodingBase64
+		encodedLength = func(n int) int { return 4 * ((n + 2) / 3) }
+	case encodingHex:
+		schema.ContentEncoding = encodingHex
+	case encodingJWT:
+		schema.ContentMediaType = "application/jwt"
+		if schema.Pattern == "" {
+			schema.Pattern = jwtPattern
+		}
+		return
+	}
+
+	if s.minDecoded > 0 && schema.MinLength == nil {
+		minLength := encodedLength(s.minDecoded)
+		schema.MinLength = &minLength
+	}
+	if s.maxDecoded > 0 && schema.MaxLength == nil {
+		maxLength := encodedLength(s.maxDecoded)
+		schema.MaxLength = &maxLength
+	}
+}
+
+// isHex reports whether s is an even number of hexadecimal digits, in either case
+func isHex(s string) bool {
+	if len(s)%2 != 0 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F') {
+			return false
+		}
+	}
+	return true
+}
+
+// isJWT reports whether s is shaped like a compact JWS: a JSON header naming its algorithm,
+// a JSON object payload, and a signature, each base64url encoded without padding
+// The signature is not verified.
+func isJWT(s string) bool {
+	parts := strings.Split(s, ".")
+	if len(parts) != 3 {
+		return false
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if !decodeJWTSegment(parts[0], &header) || header.Alg == "" {
+		return false
+	}
+	var claims map[string]interface{}
+	if !decodeJWTSegment(parts[1], &claims) || claims == nil {
+		return false
+	}
+	_, err := base64.RawURLEncoding.Strict().DecodeString(parts[2])
+	return err == nil
+}
+
+// decodeJWTSegment decodes a base64url JSON segment into v
+func decodeJWTSegment(segment string, v interface{}) bool {
+	data, err := base64.RawURLEncoding.Strict().DecodeString(segment)
+	return err == nil && json.Unmarshal(data, v) == nil
+}
@@ -0,0 +1,99 @@
+package validators
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// TestEncodedStrings tests base64, hex, and JWT content validation
+func TestEncodedStrings(t *testing.T) {
+	t.Run("Base64", func(t *testing.T) {
+		schema := String().Base64().MaxDecodedBytes(4).Required()
+		for _, value := range []string{"AQID", "AQIDBA==", "+/8="} {
+			if err := schema.Validate(value); err != nil {
+				t.Errorf("Expected %q to be valid, got: %v", value, err)
+			}
+		}
+		invalid := map[string]string{
+			"AQIDBAU=":  "maximum is 4 bytes",
+			"AQIDBA":    "invalid base64",
+			"-_8=":      "invalid base64",
+			"AQID BA==": "invalid base64",
+			"AR==":      "invalid base64", // non-zero padding bits
+		}
+		for value, message := range invalid {
+			err := schema.Validate(value)
+			if err == nil || !strings.Contains(err.Error(), message) {
+				t.Errorf("Expected %q to fail with %q, got: %v", value, message, err)
+			}
+		}
+
+		spec := schema.(goop.EnhancedSchema).ToOpenAPISchema()
+		if spec.ContentEncoding != "base64" || spec.MaxLength == nil || *spec.MaxLength != 8 {
+			t.Errorf("Expected base64 content encoding with maxLength 8, got %+v", spec)
+		}
+	})
+
+	t.Run("Hex", func(t *testing.T) {
+		schema := String().Hex().MinDecodedBytes(32).MaxDecodedBytes(32).Required()
+		digest := strings.Repeat("aB", 32)
+		if err := schema.Validate(digest); err != nil {
+			t.Errorf("Expected 32-byte digest to be valid, got: %v", err)
+		}
+		invalid := map[string]string{
+			digest[:62]:              "minimum is 32 bytes",
+			digest + "0":             "invalid hex",
+			strings.Repeat("zz", 32): "invalid hex",
+			"0x" + digest[2:]:        "invalid hex",
+		}
+		for value, message := range invalid {
+			err := schema.Validate(value)
+			if err == nil || !strings.Contains(err.Error(), message) {
+				t.Errorf("Expected %q to fail with %q, got: %v", value, message, err)
+			}
+		}
+
+		spec := schema.(goop.EnhancedSchema).ToOpenAPISchema()
+		if spec.ContentEncoding != "base16" || *spec.MinLength != 64 || *spec.MaxLength != 64 {
+			t.Errorf("Expected base16 content encoding with length 64, got %+v", spec)
+		}
+	})
+
+	t.Run("JWT", func(t *testing.T) {
+		schema := String().JWTFormat().Required()
+		segment := func(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+		header, claims := segment(`{"alg":"RS256","typ":"JWT"}`), segment(`{"sub":"usr_123"}`)
+
+		for _, token := range []string{header + "." + claims + ".c2lnbmF0dXJl", header + "." + claims + "."} {
+			if err := schema.Validate(token); err != nil {
+				t.Errorf("Expected %q to be valid, got: %v", token, err)
+			}
+		}
+		for _, token := range []string{
+			header + "." + claims,
+			segment(`{"typ":"JWT"}`) + "." + claims + ".sig",
+			header + "." + segment(`["not","claims"]`) + ".sig",
+			header + "." + claims + ".sig+nature",
+			"not.a.jwt",
+		} {
+			if err := schema.Validate(token); err == nil || !strings.Contains(err.Error(), "invalid JWT format") {
+				t.Errorf("Expected %q to be rejected, got: %v", token, err)
+			}
+		}
+
+		spec := schema.(goop.EnhancedSchema).ToOpenAPISchema()
+		if spec.ContentMediaType != "application/jwt" || spec.Pattern != jwtPattern {
+			t.Errorf("Expected application/jwt content with a pattern, got %+v", spec)
+		}
+	})
+
+	t.Run("Custom messages", func(t *testing.T) {
+		err := String().Hex().WithMessage(ErrEncoding, "must be a hex digest").Required().Validate("xyz")
+		if err == nil || !strings.Contains(err.Error(), "must be a hex digest") {
+			t.Errorf("Expected custom encoding message, got: %v", err)
+		}
+	})
+}
@@ -1,9 +1,11 @@
 package validators
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"regexp"
+	"unicode/utf8"
 
 	goop "github.com/picogrid/go-op"
 )
@@ -13,18 +15,52 @@ import (
 type stringSchema struct {
 	minLength     int
 	maxLength     int
+	minRunes      int
+	maxRunes      int
+	maxBytes      int
 	required      bool
 	pattern       *regexp.Regexp
 	emailFormat   bool
+	emailRules    *emailRules
 	urlFormat     bool
+	format        string
+	encoding      string
+	minDecoded    int
+	maxDecoded    int
 	constValue    *string
+	enum          *stringEnum
 	customFunc    func(string) error
+	contextFunc   func(context.Context, string) error
+	remote        *remoteCheck
+	extensions    []goop.SchemaExtension
 	optional      bool
 	defaultValue  *string
 	customError   map[string]string
 	example       interface{}
 	examples      map[string]ExampleObject
 	externalValue string
+	docs          schemaDocs
+}
+
+// stringEnum is a fixed set of allowed string values, kept in order for the spec
+type stringEnum struct {
+	values  []string
+	allowed map[string]struct{}
+}
+
+// newStringEnum creates an enum of the given values
+func newStringEnum(values []string) *stringEnum {
+	enum := &stringEnum{values: values, allowed: make(map[string]struct{}, len(values))}
+	for _, value := range values {
+		enum.allowed[value] = struct{}{}
+	}
+	return enum
+}
+
+// contains reports whether value is allowed
+func (e *stringEnum) contains(value string) bool {
+	_, ok := e.allowed[value]
+	return ok
 }
 
 // ExampleObject represents an example value with metadata
@@ -57,6 +93,24 @@ func (s *stringSchema) Max(length int) StringBuilder {
 	return s
 }
 
+// MinRunes requires at least count characters, counted as Unicode code points
+func (s *stringSchema) MinRunes(count int) StringBuilder {
+	s.minRunes = count
+	return s
+}
+
+// MaxRunes allows at most count characters, counted as Unicode code points
+func (s *stringSchema) MaxRunes(count int) StringBuilder {
+	s.maxRunes = count
+	return s
+}
+
+// MaxBytes limits the UTF-8 encoded size of the string, for storage with a byte limit
+func (s *stringSchema) MaxBytes(size int) StringBuilder {
+	s.maxBytes = size
+	return s
+}
+
 func (s *stringSchema) Pattern(pattern string) StringBuilder {
 	// Handle potential regex compilation errors gracefully
 	compiled, err := regexp.Compile(pattern)
@@ -74,8 +128,9 @@ func (s *stringSchema) Pattern(pattern string) StringBuilder {
 	return s
 }
 
-func (s *stringSchema) Email() StringBuilder {
-	s.emailFormat = true
+// Email requires a valid email address, with optional normalization and domain checks
+func (s *stringSchema) Email(options ...EmailOption) StringBuilder {
+	s.setEmail(options)
 	return s
 }
 
@@ -84,16 +139,86 @@ func (s *stringSchema) URL() StringBuilder {
 	return s
 }
 
+func (s *stringSchema) Format(name string) StringBuilder {
+	s.format = name
+	return s
+}
+
+func (s *stringSchema) IPv4() StringBuilder {
+	return s.Format("ipv4")
+}
+
+func (s *stringSchema) IPv6() StringBuilder {
+	return s.Format("ipv6")
+}
+
+func (s *stringSchema) CIDR() StringBuilder {
+	return s.Format("cidr")
+}
+
+func (s *stringSchema) Hostname() StringBuilder {
+	return s.Format("hostname")
+}
+
+func (s *stringSchema) Port() StringBuilder {
+	return s.Format("port")
+}
+
+func (s *stringSchema) MAC() StringBuilder {
+	return s.Format("mac")
+}
+
+func (s *stringSchema) Base64() StringBuilder {
+	s.encoding = encodingBase64
+	return s
+}
+
+func (s *stringSchema) Hex() StringBuilder {
+	s.encoding = encodingHex
+	return s
+}
+
+func (s *stringSchema) JWTFormat() StringBuilder {
+	s.encoding = encodingJWT
+	return s
+}
+
+func (s *stringSchema) MinDecodedBytes(n int) StringBuilder {
+	s.minDecoded = n
+	return s
+}
+
+func (s *stringSchema) MaxDecodedBytes(n int) StringBuilder {
+	s.maxDecoded = n
+	return s
+}
+
 func (s *stringSchema) Const(value string) StringBuilder {
 	s.constValue = &value
 	return s
 }
 
-func (s *stringSchema) Custom(fn func(string) error) StringBuilder {
+func (s *stringSchema) Custom(fn func(string) error, extensions ...goop.SchemaExtension) StringBuilder {
+	s.extensions = append(s.extensions, extensions...)
 	s.customFunc = fn
 	return s
 }
 
+// CustomCtx adds a check that runs after validation succeeds, with the context passed to
+// ValidateCtx, such as the request context in validated handlers
+func (s *stringSchema) CustomCtx(fn func(context.Context, string) error) StringBuilder {
+	s.contextFunc = fn
+	return s
+}
+
+// RemoteCheck validates the string with a lookup that needs I/O, such as checking that an ID exists
+// Values from every item and field using this schema are looked up in one call per ValidateCtx,
+// after the other checks pass. Plain Validate skips the lookup.
+func (s *stringSchema) RemoteCheck(lookup RemoteLookup) StringBuilder {
+	s.remote = &remoteCheck{lookup: lookup}
+	return s
+}
+
 // State transition methods - these change the return type to enforce compile-time safety
 func (s *stringSchema) Required() RequiredStringBuilder {
 	s.required = true
@@ -149,6 +274,21 @@ func (r *requiredStringSchema) Max(length int) RequiredStringBuilder {
 	return r
 }
 
+func (r *requiredStringSchema) MinRunes(count int) RequiredStringBuilder {
+	r.minRunes = count
+	return r
+}
+
+func (r *requiredStringSchema) MaxRunes(count int) RequiredStringBuilder {
+	r.maxRunes = count
+	return r
+}
+
+func (r *requiredStringSchema) MaxBytes(size int) RequiredStringBuilder {
+	r.maxBytes = size
+	return r
+}
+
 func (r *requiredStringSchema) Pattern(pattern string) RequiredStringBuilder {
 	// Handle potential regex compilation errors gracefully
 	compiled, err := regexp.Compile(pattern)
@@ -164,8 +304,8 @@ func (r *requiredStringSchema) Pattern(pattern string) RequiredStringBuilder {
 	return r
 }
 
-func (r *requiredStringSchema) Email() RequiredStringBuilder {
-	r.emailFormat = true
+func (r *requiredStringSchema) Email(options ...EmailOption) RequiredStringBuilder {
+	r.setEmail(options)
 	return r
 }
 
@@ -174,16 +314,81 @@ func (r *requiredStringSchema) URL() RequiredStringBuilder {
 	return r
 }
 
+func (r *requiredStringSchema) Format(name string) RequiredStringBuilder {
+	r.format = name
+	return r
+}
+
+func (r *requiredStringSchema) IPv4() RequiredStringBuilder {
+	return r.Format("ipv4")
+}
+
+func (r *requiredStringSchema) IPv6() RequiredStringBuilder {
+	return r.Format("ipv6")
+}
+
+func (r *requiredStringSchema) CIDR() RequiredStringBuilder {
+	return r.Format("cidr")
+}
+
+func (r *requiredStringSchema) Hostname() RequiredStringBuilder {
+	return r.Format("hostname")
+}
+
+func (r *requiredStringSchema) Port() RequiredStringBuilder {
+	return r.Format("port")
+}
+
+func (r *requiredStringSchema) MAC() RequiredStringBuilder {
+	return r.Format("mac")
+}
+
+func (r *requiredStringSchema) Base64() RequiredStringBuilder {
+	r.encoding = encodingBase64
+	return r
+}
+
+func (r *requiredStringSchema) Hex() RequiredStringBuilder {
+	r.encoding = encodingHex
+	return r
+}
+
+func (r *requiredStringSchema) JWTFormat() RequiredStringBuilder {
+	r.encoding = encodingJWT
+	return r
+}
+
+func (r *requiredStringSchema) MinDecodedBytes(n int) RequiredStringBuilder {
+	r.minDecoded = n
+	return r
+}
+
+func (r *requiredStringSchema) MaxDecodedBytes(n int) RequiredStringBuilder {
+	r.maxDecoded = n
+	return r
+}
+
 func (r *requiredStringSchema) Const(value string) RequiredStringBuilder {
 	r.constValue = &value
 	return r
 }
 
-func (r *requiredStringSchema) Custom(fn func(string) error) RequiredStringBuilder {
+func (r *requiredStringSchema) Custom(fn func(string) error, extensions ...goop.SchemaExtension) RequiredStringBuilder {
+	r.extensions = append(r.extensions, extensions...)
 	r.customFunc = fn
 	return r
 }
 
+func (r *requiredStringSchema) CustomCtx(fn func(context.Context, string) error) RequiredStringBuilder {
+	r.contextFunc = fn
+	return r
+}
+
+func (r *requiredStringSchema) RemoteCheck(lookup RemoteLookup) RequiredStringBuilder {
+	r.remote = &remoteCheck{lookup: lookup}
+	return r
+}
+
 // Error message methods for RequiredStringBuilder
 func (r *requiredStringSchema) WithMessage(validationType, message string) RequiredStringBuilder {
 	if r.customError == nil {
@@ -230,6 +435,21 @@ func (o *optionalStringSchema) Max(length int) OptionalStringBuilder {
 	return o
 }
 
+func (o *optionalStringSchema) MinRunes(count int) OptionalStringBuilder {
+	o.minRunes = count
+	return o
+}
+
+func (o *optionalStringSchema) MaxRunes(count int) OptionalStringBuilder {
+	o.maxRunes = count
+	return o
+}
+
+func (o *optionalStringSchema) MaxBytes(size int) OptionalStringBuilder {
+	o.maxBytes = size
+	return o
+}
+
 func (o *optionalStringSchema) Pattern(pattern string) OptionalStringBuilder {
 	// Handle potential regex compilation errors gracefully
 	compiled, err := regexp.Compile(pattern)
@@ -245,8 +465,8 @@ func (o *optionalStringSchema) Pattern(pattern string) OptionalStringBuilder {
 	return o
 }
 
-func (o *optionalStringSchema) Email() OptionalStringBuilder {
-	o.emailFormat = true
+func (o *optionalStringSchema) Email(options ...EmailOption) OptionalStringBuilder {
+	o.setEmail(options)
 	return o
 }
 
@@ -255,16 +475,81 @@ func (o *optionalStringSchema) URL() OptionalStringBuilder {
 	return o
 }
 
+func (o *optionalStringSchema) Format(name string) OptionalStringBuilder {
+	o.format = name
+	return o
+}
+
+func (o *optionalStringSchema) IPv4() OptionalStringBuilder {
+	return o.Format("ipv4")
+}
+
+func (o *optionalStringSchema) IPv6() OptionalStringBuilder {
+	return o.Format("ipv6")
+}
+
+func (o *optionalStringSchema) CIDR() OptionalStringBuilder {
+	return o.Format("cidr")
+}
+
+func (o *optionalStringSchema) Hostname() OptionalStringBuilder {
+	return o.Format("hostname")
+}
+
+func (o *optionalStringSchema) Port() OptionalStringBuilder {
+	return o.Format("port")
+}
+
+func (o *optionalStringSchema) MAC() OptionalStringBuilder {
+	return o.Format("mac")
+}
+
+func (o *optionalStringSchema) Base64() OptionalStringBuilder {
+	o.encoding = encodingBase64
+	return o
+}
+
+func (o *optionalStringSchema) Hex() OptionalStringBuilder {
+	o.encoding = encodingHex
+	return o
+}
+
+func (o *optionalStringSchema) JWTFormat() OptionalStringBuilder {
+	o.encoding = encodingJWT
+	return o
+}
+
+func (o *optionalStringSchema) MinDecodedBytes(n int) OptionalStringBuilder {
+	o.minDecoded = n
+	return o
+}
+
+func (o *optionalStringSchema) MaxDecodedBytes(n int) OptionalStringBuilder {
+	o.maxDecoded = n
+	return o
+}
+
 func (o *optionalStringSchema) Const(value string) OptionalStringBuilder {
 	o.constValue = &value
 	return o
 }
 
-func (o *optionalStringSchema) Custom(fn func(string) error) OptionalStringBuilder {
+func (o *optionalStringSchema) Custom(fn func(string) error, extensions ...goop.SchemaExtension) OptionalStringBuilder {
+	o.extensions = append(o.extensions, extensions...)
 	o.customFunc = fn
 	return o
 }
 
+func (o *optionalStringSchema) CustomCtx(fn func(context.Context, string) error) OptionalStringBuilder {
+	o.contextFunc = fn
+	return o
+}
+
+func (o *optionalStringSchema) RemoteCheck(lookup RemoteLookup) OptionalStringBuilder {
+	o.remote = &remoteCheck{lookup: lookup}
+	return o
+}
+
 // Default is only available on optional builders - this is the key DX improvement!
 func (o *optionalStringSchema) Default(value string) OptionalStringBuilder {
 	o.defaultValue = &value
@@ -305,10 +590,24 @@ func (r *requiredStringSchema) Validate(data interface{}) error {
 	return r.validate(data)
 }
 
+func (r *requiredStringSchema) ValidateCtx(ctx context.Context, data interface{}) error {
+	if err := r.validate(data); err != nil {
+		return err
+	}
+	return runContextChecks(ctx, r, data)
+}
+
 func (o *optionalStringSchema) Validate(data interface{}) error {
 	return o.validate(data)
 }
 
+func (o *optionalStringSchema) ValidateCtx(ctx context.Context, data interface{}) error {
+	if err := o.validate(data); err != nil {
+		return err
+	}
+	return runContextChecks(ctx, o, data)
+}
+
 // Core validation logic (shared between required and optional)
 func (s *stringSchema) validate(data interface{}) error {
 	// Handle nil values
@@ -317,7 +616,7 @@ func (s *stringSchema) validate(data interface{}) error {
 			return goop.NewValidationError("", nil, s.getErrorMessage(errorKeys.Required, "field is required"))
 		}
 		if s.defaultValue != nil {
-			return s.validate(*s.defaultValue)
+			return s.validateString(*s.defaultValue)
 		}
 		if s.optional {
 			return nil
@@ -332,14 +631,20 @@ func (s *stringSchema) validate(data interface{}) error {
 			s.getErrorMessage(errorKeys.Type, "invalid type, expected string"))
 	}
 
+	return s.validateString(str)
+}
+
+// validateString applies the string rules to an already type-checked value
+// The success path performs no allocations; messages are only formatted once a rule fails
+func (s *stringSchema) validateString(str string) error {
 	// Handle empty strings
 	if str == "" {
 		if s.required {
 			return goop.NewValidationError("", str,
 				s.getErrorMessage(errorKeys.Required, "string is required"))
 		}
-		if s.defaultValue != nil {
-			return s.validate(*s.defaultValue)
+		if s.defaultValue != nil && *s.defaultValue != "" {
+			return s.validateString(*s.defaultValue)
 		}
 		if s.optional {
 			return nil
@@ -359,6 +664,26 @@ func (s *stringSchema) validate(data interface{}) error {
 				fmt.Sprintf("string is too long, maximum length is %d", s.maxLength)))
 	}
 
+	if s.maxBytes > 0 && len(str) > s.maxBytes {
+		return goop.NewValidationError(str, str,
+			s.getErrorMessage(errorKeys.MaxBytes,
+				fmt.Sprintf("string is too large, maximum size is %d bytes", s.maxBytes)))
+	}
+
+	if s.minRunes > 0 || s.maxRunes > 0 {
+		count := utf8.RuneCountInString(str)
+		if s.minRunes > 0 && count < s.minRunes {
+			return goop.NewValidationError(str, str,
+				s.getErrorMessage(errorKeys.MinLength,
+					fmt.Sprintf("string is too short, minimum length is %d characters", s.minRunes)))
+		}
+		if s.maxRunes > 0 && count > s.maxRunes {
+			return goop.NewValidationError(str, str,
+				s.getErrorMessage(errorKeys.MaxLength,
+					fmt.Sprintf("string is too long, maximum length is %d characters", s.maxRunes)))
+		}
+	}
+
 	// Pattern validation
 	if s.pattern != nil && !s.pattern.MatchString(str) {
 		return goop.NewValidationError(str, str,
@@ -370,6 +695,11 @@ func (s *stringSchema) validate(data interface{}) error {
 		return goop.NewValidationError(str, str,
 			s.getErrorMessage(errorKeys.Email, "invalid email format"))
 	}
+	if s.emailFormat && s.emailRules != nil {
+		if err := s.validateEmailRules(str); err != nil {
+			return err
+		}
+	}
 
 	// URL validation
 	if s.urlFormat && !isValidURL(str) {
@@ -377,12 +707,32 @@ func (s *stringSchema) validate(data interface{}) error {
 			s.getErrorMessage(errorKeys.URL, "invalid URL format"))
 	}
 
+	// Named format validation; formats are looked up here so they can be registered after schemas are built
+	if s.format != "" {
+		if err := s.validateFormat(str); err != nil {
+			return err
+		}
+	}
+
+	// Encoded content validation
+	if s.encoding != "" {
+		if err := s.validateEncoding(str); err != nil {
+			return err
+		}
+	}
+
 	// Const validation
 	if s.constValue != nil && str != *s.constValue {
 		return goop.NewValidationError(str, str,
 			s.getErrorMessage(errorKeys.Const, fmt.Sprintf("value must be exactly '%s'", *s.constValue)))
 	}
 
+	// Enum validation
+	if s.enum != nil && !s.enum.contains(str) {
+		return goop.NewValidationError(str, str,
+			s.getErrorMessage(errorKeys.Enum, "value is not one of the allowed values"))
+	}
+
 	// Custom validation
 	if s.customFunc != nil {
 		if err := s.customFunc(str); err != nil {
@@ -393,6 +743,22 @@ func (s *stringSchema) validate(data interface{}) error {
 	return nil
 }
 
+// validateFormat checks str against the registered format
+func (s *stringSchema) validateFormat(str string) error {
+	check, ok := lookupFormat(s.format)
+	if !ok {
+		return goop.NewValidationError(str, str, fmt.Sprintf("unknown string format %q", s.format))
+	}
+	if err := check(str); err != nil {
+		message := fmt.Sprintf("invalid %s format", s.format)
+		if err != errFormatMismatch {
+			message += ": " + err.Error()
+		}
+		return goop.NewValidationError(str, str, s.getErrorMessage(errorKeys.Format, message))
+	}
+	return nil
+}
+
 // Example methods for StringBuilder
 func (s *stringSchema) Example(value interface{}) StringBuilder {
 	s.example = value
@@ -451,8 +817,10 @@ func (s *stringSchema) getErrorMessage(validationType, defaultMessage string) st
 	return defaultMessage
 }
 
+// emailRegex is compiled once rather than on every email validation
+var emailRegex = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
+
 func isValidEmail(email string) bool {
-	emailRegex := regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
 	return emailRegex.MatchString(email) && len(email) <= 254
 }
 
@@ -1,5 +1,11 @@
 package validators
 
+import (
+	"context"
+
+	goop "github.com/picogrid/go-op"
+)
+
 // StringBuilder represents the initial string builder state.
 // From this state, you can configure validation rules and then transition to
 // either a required or optional state. This prevents invalid method chaining.
@@ -7,16 +13,40 @@ type StringBuilder interface {
 	// Configuration methods - these return StringBuilder to allow chaining
 	Min(length int) StringBuilder
 	Max(length int) StringBuilder
+	MinRunes(count int) StringBuilder // Character limits; Min and Max count bytes
+	MaxRunes(count int) StringBuilder
+	MaxBytes(size int) StringBuilder
 	Pattern(pattern string) StringBuilder
-	Email() StringBuilder
+	Email(options ...EmailOption) StringBuilder
 	URL() StringBuilder
+	Format(name string) StringBuilder // Named format from RegisterFormat
+	IPv4() StringBuilder              // Network formats, validated with net/netip and net
+	IPv6() StringBuilder
+	CIDR() StringBuilder
+	Hostname() StringBuilder
+	Port() StringBuilder
+	MAC() StringBuilder
+	Base64() StringBuilder // Encoded content, documented with contentEncoding
+	Hex() StringBuilder
+	JWTFormat() StringBuilder
+	MinDecodedBytes(n int) StringBuilder // Size limits for Base64 and Hex content
+	MaxDecodedBytes(n int) StringBuilder
 	Const(value string) StringBuilder
-	Custom(fn func(string) error) StringBuilder
+	Custom(fn func(string) error, extensions ...goop.SchemaExtension) StringBuilder
+	CustomCtx(fn func(context.Context, string) error) StringBuilder // Runs after validation with the request context
+	RemoteCheck(lookup RemoteLookup) StringBuilder                  // Batched lookups that need I/O, run by ValidateCtx
+	// Sensitive and PII classify the value, which is then masked in recordings and logs
+	Sensitive() StringBuilder
+	PII(category string) StringBuilder
+	// VisibleToScopes strips the field from responses to callers without any of scopes
+	VisibleToScopes(scopes ...string) StringBuilder
 
-	// Example methods for OpenAPI documentation
+	// Example and documentation methods for OpenAPI
 	Example(value interface{}) StringBuilder
 	Examples(examples map[string]ExampleObject) StringBuilder
 	ExampleFromFile(path string) StringBuilder
+	Title(title string) StringBuilder
+	Description(description string) StringBuilder
 
 	// State transition methods - these change the type to prevent invalid chaining
 	Required() RequiredStringBuilder // Transitions to required state
@@ -40,16 +70,38 @@ type RequiredStringBuilder interface {
 	// Configuration methods - these return RequiredStringBuilder to maintain state
 	Min(length int) RequiredStringBuilder
 	Max(length int) RequiredStringBuilder
+	MinRunes(count int) RequiredStringBuilder // Character limits; Min and Max count bytes
+	MaxRunes(count int) RequiredStringBuilder
+	MaxBytes(size int) RequiredStringBuilder
 	Pattern(pattern string) RequiredStringBuilder
-	Email() RequiredStringBuilder
+	Email(options ...EmailOption) RequiredStringBuilder
 	URL() RequiredStringBuilder
+	Format(name string) RequiredStringBuilder // Named format from RegisterFormat
+	IPv4() RequiredStringBuilder              // Network formats, validated with net/netip and net
+	IPv6() RequiredStringBuilder
+	CIDR() RequiredStringBuilder
+	Hostname() RequiredStringBuilder
+	Port() RequiredStringBuilder
+	MAC() RequiredStringBuilder
+	Base64() RequiredStringBuilder // Encoded content, documented with contentEncoding
+	Hex() RequiredStringBuilder
+	JWTFormat() RequiredStringBuilder
+	MinDecodedBytes(n int) RequiredStringBuilder // Size limits for Base64 and Hex content
+	MaxDecodedBytes(n int) RequiredStringBuilder
 	Const(value string) RequiredStringBuilder
-	Custom(fn func(string) error) RequiredStringBuilder
+	Custom(fn func(string) error, extensions ...goop.SchemaExtension) RequiredStringBuilder
+	CustomCtx(fn func(context.Context, string) error) RequiredStringBuilder
+	RemoteCheck(lookup RemoteLookup) RequiredStringBuilder
+	Sensitive() RequiredStringBuilder
+	PII(category string) RequiredStringBuilder
+	VisibleToScopes(scopes ...string) RequiredStringBuilder
 
-	// Example methods for OpenAPI documentation
+	// Example and documentation methods for OpenAPI
 	Example(value interface{}) RequiredStringBuilder
 	Examples(examples map[string]ExampleObject) RequiredStringBuilder
 	ExampleFromFile(path string) RequiredStringBuilder
+	Title(title string) RequiredStringBuilder
+	Description(description string) RequiredStringBuilder
 
 	// Error message configuration methods
 	WithMessage(validationType, message string) RequiredStringBuilder
@@ -60,8 +112,12 @@ type RequiredStringBuilder interface {
 	WithURLMessage(message string) RequiredStringBuilder
 	WithRequiredMessage(message string) RequiredStringBuilder
 
+	// JSON Schema export
+	ToJSONSchema() ([]byte, error)
+
 	// Validation method - final step in the builder chain
 	Validate(data interface{}) error
+	ValidateCtx(ctx context.Context, data interface{}) error
 }
 
 // OptionalStringBuilder represents a string builder in the optional state.
@@ -74,17 +130,39 @@ type OptionalStringBuilder interface {
 	// Configuration methods - these return OptionalStringBuilder to maintain state
 	Min(length int) OptionalStringBuilder
 	Max(length int) OptionalStringBuilder
+	MinRunes(count int) OptionalStringBuilder // Character limits; Min and Max count bytes
+	MaxRunes(count int) OptionalStringBuilder
+	MaxBytes(size int) OptionalStringBuilder
 	Pattern(pattern string) OptionalStringBuilder
-	Email() OptionalStringBuilder
+	Email(options ...EmailOption) OptionalStringBuilder
 	URL() OptionalStringBuilder
+	Format(name string) OptionalStringBuilder // Named format from RegisterFormat
+	IPv4() OptionalStringBuilder              // Network formats, validated with net/netip and net
+	IPv6() OptionalStringBuilder
+	CIDR() OptionalStringBuilder
+	Hostname() OptionalStringBuilder
+	Port() OptionalStringBuilder
+	MAC() OptionalStringBuilder
+	Base64() OptionalStringBuilder // Encoded content, documented with contentEncoding
+	Hex() OptionalStringBuilder
+	JWTFormat() OptionalStringBuilder
+	MinDecodedBytes(n int) OptionalStringBuilder // Size limits for Base64 and Hex content
+	MaxDecodedBytes(n int) OptionalStringBuilder
 	Const(value string) OptionalStringBuilder
-	Custom(fn func(string) error) OptionalStringBuilder
+	Custom(fn func(string) error, extensions ...goop.SchemaExtension) OptionalStringBuilder
+	CustomCtx(fn func(context.Context, string) error) OptionalStringBuilder
+	RemoteCheck(lookup RemoteLookup) OptionalStringBuilder
+	Sensitive() OptionalStringBuilder
+	PII(category string) OptionalStringBuilder
+	VisibleToScopes(scopes ...string) OptionalStringBuilder
 	Default(value string) OptionalStringBuilder // Only available on optional builders!
 
-	// Example methods for OpenAPI documentation
+	// Example and documentation methods for OpenAPI
 	Example(value interface{}) OptionalStringBuilder
 	Examples(examples map[string]ExampleObject) OptionalStringBuilder
 	ExampleFromFile(path string) OptionalStringBuilder
+	Title(title string) OptionalStringBuilder
+	Description(description string) OptionalStringBuilder
 
 	// Error message configuration methods
 	WithMessage(validationType, message string) OptionalStringBuilder
@@ -94,6 +172,10 @@ type OptionalStringBuilder interface {
 	WithEmailMessage(message string) OptionalStringBuilder
 	WithURLMessage(message string) OptionalStringBuilder
 
+	// JSON Schema export
+	ToJSONSchema() ([]byte, error)
+
 	// Validation method - final step in the builder chain
 	Validate(data interface{}) error
+	ValidateCtx(ctx context.Context, data interface{}) error
 }
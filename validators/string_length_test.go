@@ -0,0 +1,81 @@
+package validators
+
+import (
+	"strings"
+	"testing"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// TestStringRuneAndByteLimits tests character counts against UTF-8 encoded sizes
+func TestStringRuneAndByteLimits(t *testing.T) {
+	schema := String().MinRunes(2).MaxRunes(4).MaxBytes(8).Required()
+
+	valid := []string{"ab", "café", "日本"}
+	invalid := map[string]string{
+		"a":      "minimum length is 2 characters",
+		"cafés!": "maximum length is 4 characters",
+		"日本語":    "maximum size is 8 bytes",
+	}
+
+	for _, value := range valid {
+		if err := schema.Validate(value); err != nil {
+			t.Errorf("Expected %q to be valid, got: %v", value, err)
+		}
+	}
+	for value, message := range invalid {
+		if err := schema.Validate(value); err == nil || !strings.Contains(err.Error(), message) {
+			t.Errorf("Expected %q to fail with %q, got: %v", value, message, err)
+		}
+	}
+
+	t.Run("Min and Max count bytes", func(t *testing.T) {
+		if err := String().Max(4).Required().Validate("café"); err == nil {
+			t.Error("Expected Max to count the 5 bytes of café")
+		}
+	})
+
+	t.Run("Custom message", func(t *testing.T) {
+		err := String().MaxBytes(3).WithMessage(Errors.MaxBytes(), "too big").Required().Validate("日本")
+		if err == nil || !strings.Contains(err.Error(), "too big") {
+			t.Errorf("Expected custom maxBytes message, got: %v", err)
+		}
+	})
+
+	t.Run("OpenAPI", func(t *testing.T) {
+		spec := String().Max(100).MaxRunes(50).MaxBytes(200).Required().(goop.EnhancedSchema).ToOpenAPISchema()
+		if spec.MaxLength == nil || *spec.MaxLength != 50 {
+			t.Errorf("Expected maxLength to document the rune limit, got %v", spec.MaxLength)
+		}
+		if spec.MaxBytes == nil || *spec.MaxBytes != 100 {
+			t.Errorf("Expected x-max-bytes to document the stricter byte limit 100, got %v", spec.MaxBytes)
+		}
+
+		bytesOnly := String().Min(2).Max(3).Required().(goop.EnhancedSchema).ToOpenAPISchema()
+		if bytesOnly.MinLength != nil || bytesOnly.MaxLength != nil {
+			t.Errorf("Expected byte limits not to be documented as character counts, got %v and %v", bytesOnly.MinLength, bytesOnly.MaxLength)
+		}
+		if bytesOnly.MinBytes == nil || *bytesOnly.MinBytes != 2 || bytesOnly.MaxBytes == nil || *bytesOnly.MaxBytes != 3 {
+			t.Errorf("Expected x-min-bytes 2 and x-max-bytes 3, got %v and %v", bytesOnly.MinBytes, bytesOnly.MaxBytes)
+		}
+	})
+
+	t.Run("Import", func(t *testing.T) {
+		imported, err := FromJSONSchema([]byte(`{"type": "string", "maxLength": 4, "x-min-bytes": 3, "x-max-bytes": 8}`))
+		if err != nil {
+			t.Fatalf("FromJSONSchema failed: %v", err)
+		}
+		if err := imported.Validate("café"); err != nil {
+			t.Errorf("Expected maxLength to count characters, got: %v", err)
+		}
+		if err := imported.Validate("日本語"); err == nil {
+			t.Error("Expected x-max-bytes to be enforced")
+		}
+		if err := imported.Validate("éa"); err != nil {
+			t.Errorf("Expected the 3 bytes of éa to meet x-min-bytes, got: %v", err)
+		}
+		if err := imported.Validate("ab"); err == nil {
+			t.Error("Expected x-min-bytes to be enforced")
+		}
+	})
+}
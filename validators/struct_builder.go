@@ -3,6 +3,7 @@ package validators
 import (
 	"encoding/json"
 	"fmt"
+	"reflect"
 
 	goop "github.com/picogrid/go-op"
 )
@@ -50,8 +51,8 @@ func ValidateStruct[T any](schema goop.Schema, data interface{}) (*T, error) {
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal struct: %w", err)
 		}
-		var m map[string]interface{}
-		if err := json.Unmarshal(jsonData, &m); err != nil {
+		m, err := goop.DecodeJSON(jsonData)
+		if err != nil {
 			return nil, fmt.Errorf("failed to unmarshal struct: %w", err)
 		}
 		validateData = m
@@ -61,8 +62,8 @@ func ValidateStruct[T any](schema goop.Schema, data interface{}) (*T, error) {
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal struct: %w", err)
 		}
-		var m map[string]interface{}
-		if err := json.Unmarshal(jsonData, &m); err != nil {
+		m, err := goop.DecodeJSON(jsonData)
+		if err != nil {
 			return nil, fmt.Errorf("failed to unmarshal to map: %w", err)
 		}
 		validateData = m
@@ -72,8 +73,8 @@ func ValidateStruct[T any](schema goop.Schema, data interface{}) (*T, error) {
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal data: %w", err)
 		}
-		var m map[string]interface{}
-		if err := json.Unmarshal(jsonData, &m); err != nil {
+		m, err := goop.DecodeJSON(jsonData)
+		if err != nil {
 			return nil, fmt.Errorf("failed to unmarshal to map: %w", err)
 		}
 		validateData = m
@@ -104,6 +105,7 @@ type StructSchemaBuilder[T any] struct {
 	required    bool
 	optional    bool
 	strict      bool
+	auto        bool
 	customError map[string]string
 }
 
@@ -123,20 +125,77 @@ func ForStruct[T any]() *StructSchemaBuilder[T] {
 }
 
 // Field adds a field validator to the schema.
-// The name should match the JSON tag of the struct field.
+// The name must match the JSON name of a field of T; unknown names panic when T is a struct.
 func (b *StructSchemaBuilder[T]) Field(name string, validator interface{}) *StructSchemaBuilder[T] {
+	b.checkFieldName(name)
 	b.fields[name] = validator
 	return b
 }
 
 // Fields adds multiple field validators at once.
+// Like Field, it panics on names that do not match a JSON field of T.
 func (b *StructSchemaBuilder[T]) Fields(fields map[string]interface{}) *StructSchemaBuilder[T] {
 	for name, validator := range fields {
+		b.checkFieldName(name)
 		b.fields[name] = validator
 	}
 	return b
 }
 
+// NestedStruct is implemented by every StructSchemaBuilder, so builders for different
+// struct types can be nested with Embed and SliceField.
+type NestedStruct interface {
+	Build() goop.Schema
+	targetType() reflect.Type
+}
+
+// Embed validates the nested struct field name with the schema built by nested.
+// The field must hold the nested builder's struct type, directly or through a pointer,
+// and the nested builder's Required or Optional state applies to the field.
+//
+// Example:
+//
+//	orderSchema := ForStruct[Order]().
+//	    Embed("address", ForStruct[Address]().Field("city", String().Required()))
+func (b *StructSchemaBuilder[T]) Embed(name string, nested NestedStruct) *StructSchemaBuilder[T] {
+	b.checkFieldName(name)
+	if fieldType := b.fieldType(name); fieldType != nil && derefType(fieldType) != nested.targetType() {
+		panic(fmt.Sprintf("validators: ForStruct[%s] field %q has type %s, not %s", b.targetType(), name, fieldType, nested.targetType()))
+	}
+	b.fields[name] = nested.Build()
+	return b
+}
+
+// SliceField validates each element of the slice field name with the schema built by item.
+// The field must be a slice or array of the item builder's struct type or pointers to it.
+// Like AutoFields, the slice is required unless the field is a pointer or tagged omitempty.
+//
+// Example:
+//
+//	orderSchema := ForStruct[Order]().
+//	    SliceField("items", ForStruct[OrderItem]().Field("sku", String().Required()))
+func (b *StructSchemaBuilder[T]) SliceField(name string, item NestedStruct) *StructSchemaBuilder[T] {
+	b.checkFieldName(name)
+	required := true
+	if fieldType := b.fieldType(name); fieldType != nil {
+		sliceType := derefType(fieldType)
+		if (sliceType.Kind() != reflect.Slice && sliceType.Kind() != reflect.Array) ||
+			derefType(sliceType.Elem()) != item.targetType() {
+			panic(fmt.Sprintf("validators: ForStruct[%s] field %q has type %s, not a slice of %s", b.targetType(), name, fieldType, item.targetType()))
+		}
+		field, _ := b.jsonField(name)
+		required = fieldType.Kind() != reflect.Ptr && !field.omitempty
+	}
+
+	array := Array(item.Build())
+	if required {
+		b.fields[name] = array.Required()
+	} else {
+		b.fields[name] = array.Optional()
+	}
+	return b
+}
+
 // Required makes the entire struct required (cannot be nil).
 func (b *StructSchemaBuilder[T]) Required() *StructSchemaBuilder[T] {
 	b.required = true
@@ -165,7 +224,11 @@ func (b *StructSchemaBuilder[T]) CustomError(key, message string) *StructSchemaB
 
 // Build creates the final Schema from the builder configuration.
 func (b *StructSchemaBuilder[T]) Build() goop.Schema {
-	builder := Object(b.fields)
+	fields := b.fields
+	if b.auto {
+		fields = b.mergedFields()
+	}
+	builder := Object(fields)
 
 	// Apply modifiers
 	if b.strict {
@@ -1,7 +1,9 @@
 package validators
 
 import (
+	"encoding/json"
 	"testing"
+	"time"
 )
 
 type TestUser struct {
@@ -56,3 +58,236 @@ func TestForStructBuilder_Strict(t *testing.T) {
 		t.Errorf("Expected an error for unknown field in strict mode, but got nil")
 	}
 }
+
+type autoAddress struct {
+	City string `json:"city"`
+}
+
+type autoBase struct {
+	ID string `json:"id"`
+}
+
+type autoUser struct {
+	autoBase
+	Name      string            `json:"name"`
+	Nickname  *string           `json:"nickname,omitempty"`
+	Age       int               `json:"age"`
+	Score     float64           `json:"score,omitempty"`
+	Active    bool              `json:"active"`
+	Tags      []string          `json:"tags"`
+	Address   autoAddress       `json:"address"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+	Extra     interface{}       `json:"extra,omitempty"`
+	Parent    *autoUser         `json:"parent,omitempty"`
+	Internal  string            `json:"-"`
+	secret    string
+}
+
+func TestForStructAutoFields(t *testing.T) {
+	valid := map[string]interface{}{
+		"id":         "u1",
+		"name":       "Ada",
+		"age":        float64(36),
+		"active":     true,
+		"tags":       []interface{}{"a"},
+		"address":    map[string]interface{}{"city": "London"},
+		"created_at": "2024-01-01T00:00:00Z",
+	}
+
+	t.Run("Derives validators from field types", func(t *testing.T) {
+		schema := ForStruct[autoUser]().AutoFields().Strict().Build()
+		if err := schema.Validate(valid); err != nil {
+			t.Fatalf("Expected valid data to pass, got %v", err)
+		}
+
+		cases := map[string]map[string]interface{}{
+			"missing required field":   {"name": "Ada"},
+			"wrong type for integer":   withField(valid, "age", "old"),
+			"non-integer for int":      withField(valid, "age", 1.5),
+			"wrong type for bool":      withField(valid, "active", "yes"),
+			"wrong element type":       withField(valid, "tags", []interface{}{1}),
+			"invalid nested struct":    withField(valid, "address", map[string]interface{}{}),
+			"unknown key in strict":    withField(valid, "Internal", "x"),
+			"wrong type for recursion": withField(valid, "parent", "nope"),
+		}
+		for name, data := range cases {
+			if err := schema.Validate(data); err == nil {
+				t.Errorf("%s: expected validation to fail", name)
+			}
+		}
+	})
+
+	t.Run("Optional fields", func(t *testing.T) {
+		schema := ForStruct[autoUser]().AutoFields().Build()
+		data := withField(valid, "nickname", "ada")
+		data["score"] = float64(1)
+		data["labels"] = map[string]interface{}{"k": "v"}
+		data["extra"] = []interface{}{1, "two"}
+		data["parent"] = map[string]interface{}{"anything": true}
+		if err := schema.Validate(data); err != nil {
+			t.Errorf("Expected optional fields to pass, got %v", err)
+		}
+	})
+
+	t.Run("Nil slices and maps marshal as null", func(t *testing.T) {
+		schema := ForStruct[autoUser]().AutoFields().Build()
+		data, err := json.Marshal(autoUser{autoBase: autoBase{ID: "u1"}, Name: "Ada", Address: autoAddress{City: "London"}})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		var decoded map[string]interface{}
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if err := schema.Validate(decoded); err != nil {
+			t.Errorf("Expected a marshaled zero-value struct to pass, got %v", err)
+		}
+		if err := schema.Validate(withField(decoded, "tags", []interface{}{nil})); err == nil {
+			t.Error("Expected null string elements to be rejected")
+		}
+	})
+
+	t.Run("Field overrides derived validator", func(t *testing.T) {
+		schema := ForStruct[autoUser]().AutoFields().
+			Field("age", Number().Min(40).Required()).
+			Build()
+		if err := schema.Validate(valid); err == nil {
+			t.Error("Expected override to reject age 36")
+		}
+	})
+
+	t.Run("OpenAPI generation", func(t *testing.T) {
+		schema := ForStruct[autoUser]().AutoFields().Build().(*requiredObjectSchema)
+		openAPI := schema.ToOpenAPISchema()
+		if openAPI.Properties["age"].Type != "integer" || openAPI.Properties["tags"].Type != "array" {
+			t.Errorf("Expected derived property types, got %+v", openAPI.Properties)
+		}
+		if _, exists := openAPI.Properties["Internal"]; exists {
+			t.Error("Expected json:\"-\" fields to be skipped")
+		}
+		if _, exists := openAPI.Properties["secret"]; exists {
+			t.Error("Expected unexported fields to be skipped")
+		}
+	})
+}
+
+func TestForStructUnknownField(t *testing.T) {
+	t.Run("Field panics on unknown names", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected panic for unknown field name")
+			}
+		}()
+		ForStruct[TestUser]().Field("user_name", String().Required())
+	})
+
+	t.Run("Fields panics on unknown names", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected panic for unknown field name")
+			}
+		}()
+		ForStruct[TestUser]().Fields(map[string]interface{}{"agee": Number()})
+	})
+
+	t.Run("Embedded fields are known", func(t *testing.T) {
+		ForStruct[autoUser]().Field("id", String().Required())
+	})
+
+	t.Run("Non-struct types are not checked", func(t *testing.T) {
+		ForStruct[map[string]interface{}]().Field("anything", String())
+	})
+}
+
+// withField returns a copy of data with key set to value
+func withField(data map[string]interface{}, key string, value interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(data)+1)
+	for k, v := range data {
+		result[k] = v
+	}
+	result[key] = value
+	return result
+}
+
+type nestedAddress struct {
+	City string `json:"city"`
+	Zip  string `json:"zip"`
+}
+
+type nestedOrderItem struct {
+	SKU string `json:"sku"`
+	Qty int    `json:"qty"`
+}
+
+type nestedOrder struct {
+	ID       string             `json:"id"`
+	Shipping nestedAddress      `json:"shipping"`
+	Billing  *nestedAddress     `json:"billing,omitempty"`
+	Items    []nestedOrderItem  `json:"items"`
+	Extras   []*nestedOrderItem `json:"extras,omitempty"`
+}
+
+func TestForStructNesting(t *testing.T) {
+	address := func() *StructSchemaBuilder[nestedAddress] {
+		return ForStruct[nestedAddress]().
+			Field("city", String().Min(1).Required()).
+			Field("zip", String().Pattern(`^\d{5}$`).Required())
+	}
+	item := ForStruct[nestedOrderItem]().
+		Field("sku", String().Min(3).Required()).
+		Field("qty", Number().Integer().Min(1).Required())
+
+	schema := ForStruct[nestedOrder]().
+		Field("id", String().Required()).
+		Embed("shipping", address()).
+		Embed("billing", address().Optional()).
+		SliceField("items", item).
+		SliceField("extras", item).
+		Build()
+
+	valid := map[string]interface{}{
+		"id":       "o1",
+		"shipping": map[string]interface{}{"city": "Berlin", "zip": "10115"},
+		"items":    []interface{}{map[string]interface{}{"sku": "abc", "qty": float64(2)}},
+	}
+
+	t.Run("Valid order", func(t *testing.T) {
+		if err := schema.Validate(valid); err != nil {
+			t.Errorf("Expected valid order to pass, got %v", err)
+		}
+	})
+
+	cases := map[string]map[string]interface{}{
+		"invalid embedded field":   withField(valid, "shipping", map[string]interface{}{"city": "Berlin", "zip": "x"}),
+		"invalid optional embed":   withField(valid, "billing", map[string]interface{}{"city": ""}),
+		"invalid slice element":    withField(valid, "items", []interface{}{map[string]interface{}{"sku": "a", "qty": float64(0)}}),
+		"missing required slice":   {"id": "o1", "shipping": map[string]interface{}{"city": "Berlin", "zip": "10115"}},
+		"invalid optional element": withField(valid, "extras", []interface{}{map[string]interface{}{}}),
+	}
+	for name, data := range cases {
+		t.Run(name, func(t *testing.T) {
+			if err := schema.Validate(data); err == nil {
+				t.Error("Expected validation to fail")
+			}
+		})
+	}
+
+	t.Run("Embed panics on mismatched type", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected panic for mismatched nested type")
+			}
+		}()
+		ForStruct[nestedOrder]().Embed("shipping", item)
+	})
+
+	t.Run("SliceField panics on non-slice field", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected panic for non-slice field")
+			}
+		}()
+		ForStruct[nestedOrder]().SliceField("shipping", address())
+	})
+}
@@ -0,0 +1,292 @@
+package validators
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	goop "github.com/picogrid/go-op"
+)
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// AutoFields derives a validator for every exported field of T from its Go type.
+// Field names follow encoding/json: the json tag name when present, otherwise the Go field name,
+// with embedded structs flattened. Validators registered with Field or Fields take precedence.
+//
+// Derived validators:
+//   - string fields use String(), and time.Time uses String()
+//   - integer fields use Int() or Uint() limited to the field's bit size, so decoded values fit the field
+//   - float fields use Number() and bool fields use Bool()
+//   - slices and arrays use Array() of the element type; []byte uses String()
+//   - nested structs use Object() of their fields; maps and recursive references use Object()
+//   - other types, including interfaces and json.Marshaler implementations, accept any value
+//
+// Fields without omitempty are required, except pointers, slices, maps, and interfaces, whose nil
+// values encoding/json marshals as null; those and omitempty fields are optional.
+// As with String().Required(), required string fields reject empty strings.
+func (b *StructSchemaBuilder[T]) AutoFields() *StructSchemaBuilder[T] {
+	b.auto = true
+	return b
+}
+
+// autoFieldValidators derives validators for the fields of T, or nil when T is not a struct
+func (b *StructSchemaBuilder[T]) autoFieldValidators() map[string]interface{} {
+	t := structType[T]()
+	if t == nil {
+		return nil
+	}
+	return deriveStructFields(t, map[reflect.Type]bool{})
+}
+
+// mergedFields returns the derived field validators with explicitly set fields taking precedence
+func (b *StructSchemaBuilder[T]) mergedFields() map[string]interface{} {
+	fields := b.autoFieldValidators()
+	if fields == nil {
+		fields = make(map[string]interface{}, len(b.fields))
+	}
+	for name, validator := range b.fields {
+		fields[name] = validator
+	}
+	return fields
+}
+
+// checkFieldName panics when name does not match a JSON field of T
+// Registering validators happens at package initialization, so a typo fails at startup
+// rather than being silently ignored. Non-struct types are not checked.
+func (b *StructSchemaBuilder[T]) checkFieldName(name string) {
+	t := structType[T]()
+	if t == nil {
+		return
+	}
+	if _, ok := b.jsonField(name); !ok {
+		panic(fmt.Sprintf("validators: ForStruct[%s] has no field with JSON name %q", t, name))
+	}
+}
+
+// targetType returns the type the builder validates
+func (b *StructSchemaBuilder[T]) targetType() reflect.Type {
+	return derefType(reflect.TypeOf((*T)(nil)).Elem())
+}
+
+// jsonField looks up the JSON field of T with the given name
+func (b *StructSchemaBuilder[T]) jsonField(name string) (jsonField, bool) {
+	t := structType[T]()
+	if t == nil {
+		return jsonField{}, false
+	}
+	for _, field := range structJSONFields(t) {
+		if field.name == name {
+			return field, true
+		}
+	}
+	return jsonField{}, false
+}
+
+// fieldType returns the Go type of the JSON field name, or nil when T is not a struct
+func (b *StructSchemaBuilder[T]) fieldType(name string) reflect.Type {
+	field, ok := b.jsonField(name)
+	if !ok {
+		return nil
+	}
+	return field.typ
+}
+
+// derefType strips pointer indirections from t
+func derefType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+// structType returns the struct type behind T, dereferencing pointers, or nil for other kinds
+func structType[T any]() reflect.Type {
+	t := derefType(reflect.TypeOf((*T)(nil)).Elem())
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	return t
+}
+
+// jsonField is a struct field as seen by encoding/json
+type jsonField struct {
+	name      string
+	typ       reflect.Type
+	omitempty bool
+}
+
+// structJSONFields lists the fields encoding/json would marshal for t
+func structJSONFields(t reflect.Type) []jsonField {
+	var fields []jsonField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+
+		// Untagged embedded structs contribute their fields directly
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				fields = append(fields, structJSONFields(embedded)...)
+				continue
+			}
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields = append(fields, jsonField{
+			name:      name,
+			typ:       field.Type,
+			omitempty: strings.Contains(","+options+",", ",omitempty,"),
+		})
+	}
+	return fields
+}
+
+// deriveStructFields derives validators for each JSON field of t
+// visiting guards against recursive types, whose recursive fields accept any object
+func deriveStructFields(t reflect.Type, visiting map[reflect.Type]bool) map[string]interface{} {
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	fields := make(map[string]interface{})
+	for _, field := range structJSONFields(t) {
+		required := !nullable(field.typ) && !field.omitempty
+		fields[field.name] = deriveValidator(field.typ, required, visiting)
+	}
+	return fields
+}
+
+// nullable reports whether encoding/json marshals the zero value of t as null
+func nullable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Interface:
+		return true
+	}
+	return false
+}
+
+// deriveValidator returns a finalized validator for values of type t
+func deriveValidator(t reflect.Type, required bool, visiting map[reflect.Type]bool) interface{} {
+	t = derefType(t)
+
+	if t == timeType {
+		return finalizeDerived(String(), required)
+	}
+	if t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType) {
+		return &anySchema{required: required}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return finalizeDerived(String(), required)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return finalizeDerived(&intSchema{newInteger(t.Bits(), false)}, required)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return finalizeDerived(&uintSchema{newInteger(t.Bits(), true)}, required)
+	case reflect.Float32, reflect.Float64:
+		return finalizeDerived(Number(), required)
+	case reflect.Bool:
+		return finalizeDerived(Bool(), required)
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// encoding/json marshals byte slices as base64 strings
+			return finalizeDerived(String(), required)
+		}
+		return finalizeDerived(Array(deriveValidator(t.Elem(), !nullable(t.Elem()), visiting)), required)
+	case reflect.Map:
+		return finalizeDerived(Object(map[string]interface{}{}), required)
+	case reflect.Struct:
+		if visiting[t] {
+			// Recursive references are checked as objects without descending further
+			return finalizeDerived(Object(map[string]interface{}{}), required)
+		}
+		return finalizeDerived(Object(deriveStructFields(t, visiting)), required)
+	default:
+		return &anySchema{required: required}
+	}
+}
+
+// finalizeDerived transitions a derived builder to its required or optional state
+func finalizeDerived(builder interface{}, required bool) interface{} {
+	switch b := builder.(type) {
+	case StringBuilder:
+		if required {
+			return b.Required()
+		}
+		return b.Optional()
+	case NumberBuilder:
+		if required {
+			return b.Required()
+		}
+		return b.Optional()
+	case IntBuilder:
+		if required {
+			return b.Required()
+		}
+		return b.Optional()
+	case UintBuilder:
+		if required {
+			return b.Required()
+		}
+		return b.Optional()
+	case BoolBuilder:
+		if required {
+			return b.Required()
+		}
+		return b.Optional()
+	case ArrayBuilder:
+		if required {
+			return b.Required()
+		}
+		return b.Optional()
+	case ObjectBuilder:
+		if required {
+			return b.Required()
+		}
+		return b.Optional()
+	}
+	return builder
+}
+
+// anySchema accepts any value, rejecting only missing values when required
+// It is used for derived fields whose JSON shape cannot be inferred from the Go type
+type anySchema struct {
+	required bool
+}
+
+func (a *anySchema) Validate(data interface{}) error {
+	if data == nil && a.required {
+		return goop.NewValidationError("", nil, "field is required")
+	}
+	return nil
+}
+
+// ToOpenAPISchema returns an empty schema, which permits any value
+func (a *anySchema) ToOpenAPISchema() *goop.OpenAPISchema {
+	return &goop.OpenAPISchema{}
+}
+
+func (a *anySchema) GetValidationInfo() *goop.ValidationInfo {
+	return &goop.ValidationInfo{
+		Required:    a.required,
+		Optional:    !a.required,
+		Constraints: make(map[string]interface{}),
+	}
+}
@@ -0,0 +1,97 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// tenantLimit documents the per-tenant limit bounding a value as a specification extension
+func tenantLimit(extensions []goop.SchemaExtension, limit string) []goop.SchemaExtension {
+	return append(extensions, goop.SchemaExtension{Name: goop.TenantLimitExtension, Value: limit})
+}
+
+// MaxFromTenant rejects values above the named limit of the request's tenant, such as the
+// seats of its plan. The check runs with ValidateCtx; without a tenant implementing
+// goop.TenantLimits, or when the tenant has no such limit, any value passes.
+//
+// Example:
+//
+//	"seats": validators.Int().Min(1).MaxFromTenant("seats").Required(),
+func (i *intSchema) MaxFromTenant(limit string) IntBuilder {
+	i.setTenantLimit(limit)
+	return i
+}
+
+func (r *requiredIntSchema) MaxFromTenant(limit string) RequiredIntBuilder {
+	r.setTenantLimit(limit)
+	return r
+}
+
+func (o *optionalIntSchema) MaxFromTenant(limit string) OptionalIntBuilder {
+	o.setTenantLimit(limit)
+	return o
+}
+
+func (u *uintSchema) MaxFromTenant(limit string) UintBuilder {
+	u.setTenantLimit(limit)
+	return u
+}
+
+func (r *requiredUintSchema) MaxFromTenant(limit string) RequiredUintBuilder {
+	r.setTenantLimit(limit)
+	return r
+}
+
+func (o *optionalUintSchema) MaxFromTenant(limit string) OptionalUintBuilder {
+	o.setTenantLimit(limit)
+	return o
+}
+
+// MaxItemsFromTenant rejects arrays with more items than the named limit of the request's tenant
+// The check runs with ValidateCtx; arrays of any length pass without a tenant limit.
+func (a *arraySchema) MaxItemsFromTenant(limit string) ArrayBuilder {
+	a.setTenantLimit(limit)
+	return a
+}
+
+func (r *requiredArraySchema) MaxItemsFromTenant(limit string) RequiredArrayBuilder {
+	r.setTenantLimit(limit)
+	return r
+}
+
+func (o *optionalArraySchema) MaxItemsFromTenant(limit string) OptionalArrayBuilder {
+	o.setTenantLimit(limit)
+	return o
+}
+
+func (s *integerSchema) setTenantLimit(limit string) {
+	s.tenantLimit = limit
+	s.extensions = tenantLimit(s.extensions, limit)
+}
+
+func (a *arraySchema) setTenantLimit(limit string) {
+	a.tenantLimit = limit
+	a.extensions = tenantLimit(a.extensions, limit)
+}
+
+// checkTenantLimit rejects value when it is above the tenant limit of the schema
+func (s *integerSchema) checkTenantLimit(ctx context.Context, value integerValue, data interface{}) error {
+	limit, ok := goop.TenantLimit(ctx, s.tenantLimit)
+	if !ok || value.cmp(signedValue(limit)) <= 0 {
+		return nil
+	}
+	return goop.NewValidationError(fmt.Sprintf("%v", data), data, s.getErrorMessage(errorKeys.Max,
+		fmt.Sprintf("value is too large, the tenant's %s limit is %d", s.tenantLimit, limit)))
+}
+
+// checkTenantLimit rejects arrays with more items than the tenant limit of the schema
+func (a *arraySchema) checkTenantLimit(ctx context.Context, arr []interface{}) error {
+	limit, ok := goop.TenantLimit(ctx, a.tenantLimit)
+	if !ok || int64(len(arr)) <= limit {
+		return nil
+	}
+	return goop.NewValidationError("", arr, a.getErrorMessage(errorKeys.MaxItems,
+		fmt.Sprintf("array has too many items, the tenant's %s limit is %d", a.tenantLimit, limit)))
+}
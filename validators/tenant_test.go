@@ -0,0 +1,64 @@
+package validators
+
+import (
+	"context"
+	"testing"
+
+	goop "github.com/picogrid/go-op"
+)
+
+type limitedTenant map[string]int64
+
+func (t limitedTenant) TenantLimit(name string) (int64, bool) {
+	limit, ok := t[name]
+	return limit, ok
+}
+
+// TestTenantLimits tests bounding values by limits of the request's tenant
+func TestTenantLimits(t *testing.T) {
+	schema := Object(map[string]interface{}{
+		"seats":   Int().Min(1).MaxFromTenant("seats").Required(),
+		"storage": Uint().MaxFromTenant("storage").Optional(),
+		"members": Array(String()).MaxItemsFromTenant("members").Optional(),
+	}).Required()
+	ctx := goop.WithTenant(context.Background(), limitedTenant{"seats": 5, "members": 2})
+
+	t.Run("Within limits", func(t *testing.T) {
+		data := map[string]interface{}{"seats": 5, "members": []interface{}{"ada", "grace"}}
+		if err := schema.ValidateCtx(ctx, data); err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+	})
+
+	t.Run("Above a limit", func(t *testing.T) {
+		if err := schema.ValidateCtx(ctx, map[string]interface{}{"seats": 6}); err == nil {
+			t.Error("Expected seats above the tenant limit to fail")
+		}
+		data := map[string]interface{}{"seats": 1, "members": []interface{}{"ada", "grace", "linus"}}
+		if err := schema.ValidateCtx(ctx, data); err == nil {
+			t.Error("Expected members above the tenant limit to fail")
+		}
+	})
+
+	t.Run("Without a limit", func(t *testing.T) {
+		data := map[string]interface{}{"seats": 100, "storage": 1 << 40}
+		if err := schema.ValidateCtx(context.Background(), data); err != nil {
+			t.Errorf("Expected no tenant to leave values unbounded, got %v", err)
+		}
+		if err := schema.ValidateCtx(ctx, map[string]interface{}{"seats": 1, "storage": 1 << 40}); err != nil {
+			t.Errorf("Expected a limit the tenant does not define to pass, got %v", err)
+		}
+		if err := schema.Validate(map[string]interface{}{"seats": 100}); err != nil {
+			t.Errorf("Expected Validate to skip tenant limits, got %v", err)
+		}
+	})
+
+	t.Run("Documents the limit", func(t *testing.T) {
+		spec := schema.(goop.EnhancedSchema).ToOpenAPISchema()
+		for field, limit := range map[string]string{"seats": "seats", "storage": "storage", "members": "members"} {
+			if got := spec.Properties[field].Extensions[goop.TenantLimitExtension]; got != limit {
+				t.Errorf("%s: expected tenant limit %s, got %v", field, limit, got)
+			}
+		}
+	})
+}
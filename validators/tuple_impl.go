@@ -0,0 +1,313 @@
+package validators
+
+import (
+	"fmt"
+	"reflect"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// Core tuple schema struct (unexported)
+// This contains the positional schemas and is wrapped by state-specific types
+type tupleSchema struct {
+	positions    []interface{}
+	rest         interface{}
+	customFunc   func([]interface{}) error
+	extensions   []goop.SchemaExtension
+	required     bool
+	optional     bool
+	defaultValue []interface{}
+	customError  map[string]string
+	example      interface{}
+	docs         schemaDocs
+}
+
+// State wrapper types for compile-time safety
+type requiredTupleSchema struct {
+	*tupleSchema
+}
+
+type optionalTupleSchema struct {
+	*tupleSchema
+}
+
+// Tuple creates a validator for fixed-position arrays whose items have different schemas,
+// such as coordinates or CSV-like rows. Item i is validated against the i-th schema, and the
+// spec lists the schemas as prefixItems. Trailing positions whose schemas are optional may be
+// left out. Items beyond the positions are rejected unless Rest gives a schema for them.
+//
+// Example:
+//
+//	"location": validators.Tuple(
+//	    validators.Number().Min(-90).Max(90).Required(),   // latitude
+//	    validators.Number().Min(-180).Max(180).Required(), // longitude
+//	).Required()
+func Tuple(positions ...interface{}) TupleBuilder {
+	return &tupleSchema{
+		positions:   positions,
+		customError: make(map[string]string),
+	}
+}
+
+// TupleBuilder implementation (initial state)
+
+// Rest validates items after the positional ones against schema instead of rejecting them
+func (t *tupleSchema) Rest(schema interface{}) TupleBuilder {
+	t.rest = schema
+	return t
+}
+
+func (t *tupleSchema) Custom(fn func([]interface{}) error, extensions ...goop.SchemaExtension) TupleBuilder {
+	t.extensions = append(t.extensions, extensions...)
+	t.customFunc = fn
+	return t
+}
+
+func (t *tupleSchema) Example(value interface{}) TupleBuilder {
+	t.example = value
+	return t
+}
+
+// State transition methods - these change the return type to enforce compile-time safety
+func (t *tupleSchema) Required() RequiredTupleBuilder {
+	t.required = true
+	t.optional = false
+	return &requiredTupleSchema{t}
+}
+
+func (t *tupleSchema) Optional() OptionalTupleBuilder {
+	t.optional = true
+	t.required = false
+	return &optionalTupleSchema{t}
+}
+
+func (t *tupleSchema) WithMessage(validationType, message string) TupleBuilder {
+	t.customError[validationType] = message
+	return t
+}
+
+// RequiredTupleBuilder implementation
+
+func (r *requiredTupleSchema) Rest(schema interface{}) RequiredTupleBuilder {
+	r.rest = schema
+	return r
+}
+
+func (r *requiredTupleSchema) Custom(fn func([]interface{}) error, extensions ...goop.SchemaExtension) RequiredTupleBuilder {
+	r.extensions = append(r.extensions, extensions...)
+	r.customFunc = fn
+	return r
+}
+
+func (r *requiredTupleSchema) Example(value interface{}) RequiredTupleBuilder {
+	r.example = value
+	return r
+}
+
+func (r *requiredTupleSchema) WithMessage(validationType, message string) RequiredTupleBuilder {
+	r.customError[validationType] = message
+	return r
+}
+
+func (r *requiredTupleSchema) WithRequiredMessage(message string) RequiredTupleBuilder {
+	return r.WithMessage(errorKeys.Required, message)
+}
+
+// OptionalTupleBuilder implementation
+
+func (o *optionalTupleSchema) Rest(schema interface{}) OptionalTupleBuilder {
+	o.rest = schema
+	return o
+}
+
+func (o *optionalTupleSchema) Custom(fn func([]interface{}) error, extensions ...goop.SchemaExtension) OptionalTupleBuilder {
+	o.extensions = append(o.extensions, extensions...)
+	o.customFunc = fn
+	return o
+}
+
+func (o *optionalTupleSchema) Example(value interface{}) OptionalTupleBuilder {
+	o.example = value
+	return o
+}
+
+func (o *optionalTupleSchema) Default(value []interface{}) OptionalTupleBuilder {
+	o.defaultValue = value
+	return o
+}
+
+func (o *optionalTupleSchema) WithMessage(validationType, message string) OptionalTupleBuilder {
+	o.customError[validationType] = message
+	return o
+}
+
+// Validation methods - these are the final methods in the builder chain
+func (r *requiredTupleSchema) Validate(data interface{}) error {
+	return r.validate(data)
+}
+
+func (o *optionalTupleSchema) Validate(data interface{}) error {
+	return o.validate(data)
+}
+
+// Core validation logic (shared between required and optional)
+func (t *tupleSchema) validate(data interface{}) error {
+	if data == nil {
+		if t.required {
+			return goop.NewValidationError("", nil, t.getErrorMessage(errorKeys.Required, "field is required"))
+		}
+		if t.defaultValue != nil {
+			return t.validate(t.defaultValue)
+		}
+		if t.optional {
+			return nil
+		}
+		return goop.NewValidationError("", nil, t.getErrorMessage(errorKeys.Required, "field is required"))
+	}
+
+	arr, ok := data.([]interface{})
+	if !ok {
+		val := reflect.ValueOf(data)
+		if val.Kind() != reflect.Slice && val.Kind() != reflect.Array {
+			return goop.NewValidationError(fmt.Sprintf("%v", data), data,
+				t.getErrorMessage(errorKeys.Type, "invalid type, expected array"))
+		}
+		arr = make([]interface{}, val.Len())
+		for i := range arr {
+			arr[i] = val.Index(i).Interface()
+		}
+	}
+
+	if minItems := t.minItems(); len(arr) < minItems {
+		return goop.NewValidationError(fmt.Sprintf("%v", arr), arr,
+			t.getErrorMessage(errorKeys.MinItems,
+				fmt.Sprintf("tuple has too few items, expected at least %d", minItems)))
+	}
+	if t.rest == nil && len(arr) > len(t.positions) {
+		return goop.NewValidationError(fmt.Sprintf("%v", arr), arr,
+			t.getErrorMessage(errorKeys.MaxItems,
+				fmt.Sprintf("tuple has too many items, expected at most %d", len(t.positions))))
+	}
+
+	collector := acquireErrorCollector()
+	for i, item := range arr {
+		itemSchema := t.rest
+		if i < len(t.positions) {
+			itemSchema = t.positions[i]
+		}
+		if err := validateItem(itemSchema, item); err != nil {
+			addElementError(collector, i, item, err)
+		}
+	}
+	if details := collector.release(); len(details) > 0 {
+		return goop.NewNestedValidationError("", arr, "tuple contains invalid items", details)
+	}
+
+	if t.customFunc != nil {
+		if err := t.customFunc(arr); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// minItems returns the number of positions up to the last one whose schema requires a value
+func (t *tupleSchema) minItems() int {
+	for i := len(t.positions) - 1; i >= 0; i-- {
+		if validateItem(t.positions[i], nil) != nil {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// Helper methods (unexported)
+func (t *tupleSchema) getErrorMessage(validationType, defaultMessage string) string {
+	if msg, exists := t.customError[validationType]; exists {
+		return msg
+	}
+	return defaultMessage
+}
+
+// ToOpenAPISchema documents the positions as prefixItems, with items for Rest
+func (t *tupleSchema) ToOpenAPISchema() *goop.OpenAPISchema {
+	schema := &goop.OpenAPISchema{
+		Type:        "array",
+		PrefixItems: make([]*goop.OpenAPISchema, len(t.positions)),
+	}
+	for i, position := range t.positions {
+		schema.PrefixItems[i] = itemOpenAPISchema(position)
+	}
+
+	if minItems := t.minItems(); minItems > 0 {
+		schema.MinItems = &minItems
+	}
+	if t.rest != nil {
+		schema.Items = itemOpenAPISchema(t.rest)
+	} else {
+		maxItems := len(t.positions)
+		schema.MaxItems = &maxItems
+	}
+
+	if t.defaultValue != nil {
+		schema.Default = t.defaultValue
+	}
+	if t.example != nil {
+		schema.Example = t.example
+	}
+	t.docs.apply(schema)
+	// Add extensions given to Custom
+	addSchemaExtensions(schema, t.extensions)
+
+	return schema
+}
+
+// itemOpenAPISchema returns the spec of an item schema; schemas without one accept any value
+func itemOpenAPISchema(itemSchema interface{}) *goop.OpenAPISchema {
+	if generator, ok := itemSchema.(goop.OpenAPIGenerator); ok {
+		return generator.ToOpenAPISchema()
+	}
+	return &goop.OpenAPISchema{}
+}
+
+// GetValidationInfo returns metadata about the tuple validation configuration
+func (t *tupleSchema) GetValidationInfo() *goop.ValidationInfo {
+	info := &goop.ValidationInfo{
+		Required:     t.required,
+		Optional:     t.optional,
+		HasDefault:   t.defaultValue != nil,
+		DefaultValue: t.defaultValue,
+		Constraints:  map[string]interface{}{"prefixItems": len(t.positions)},
+	}
+	if t.rest != nil {
+		info.Constraints["rest"] = true
+	}
+	return info
+}
+
+// OpenAPI generation methods for the state wrappers
+func (r *requiredTupleSchema) ToOpenAPISchema() *goop.OpenAPISchema {
+	return r.tupleSchema.ToOpenAPISchema()
+}
+
+func (r *requiredTupleSchema) GetValidationInfo() *goop.ValidationInfo {
+	return r.tupleSchema.GetValidationInfo()
+}
+
+func (o *optionalTupleSchema) ToOpenAPISchema() *goop.OpenAPISchema {
+	return o.tupleSchema.ToOpenAPISchema()
+}
+
+func (o *optionalTupleSchema) GetValidationInfo() *goop.ValidationInfo {
+	return o.tupleSchema.GetValidationInfo()
+}
+
+// Interface compliance checks at compile time
+var (
+	_ TupleBuilder         = (*tupleSchema)(nil)
+	_ RequiredTupleBuilder = (*requiredTupleSchema)(nil)
+	_ OptionalTupleBuilder = (*optionalTupleSchema)(nil)
+	_ goop.EnhancedSchema  = (*requiredTupleSchema)(nil)
+	_ goop.EnhancedSchema  = (*optionalTupleSchema)(nil)
+)
@@ -0,0 +1,60 @@
+package validators
+
+import goop "github.com/picogrid/go-op"
+
+// TupleBuilder represents the initial tuple builder state.
+// From this state, you can configure validation rules and then transition to
+// either a required or optional state.
+type TupleBuilder interface {
+	// Configuration methods - these return TupleBuilder to allow chaining
+	Rest(schema interface{}) TupleBuilder // Schema for items after the positional ones; without it they are rejected
+	Custom(fn func([]interface{}) error, extensions ...goop.SchemaExtension) TupleBuilder
+
+	// Example and documentation methods for OpenAPI
+	Example(value interface{}) TupleBuilder
+	Title(title string) TupleBuilder
+	Description(description string) TupleBuilder
+
+	// State transition methods - these change the type to prevent invalid chaining
+	Required() RequiredTupleBuilder
+	Optional() OptionalTupleBuilder
+
+	// Error message configuration methods
+	WithMessage(validationType, message string) TupleBuilder
+}
+
+// RequiredTupleBuilder represents a tuple builder in the required state.
+type RequiredTupleBuilder interface {
+	Rest(schema interface{}) RequiredTupleBuilder
+	Custom(fn func([]interface{}) error, extensions ...goop.SchemaExtension) RequiredTupleBuilder
+	Example(value interface{}) RequiredTupleBuilder
+	Title(title string) RequiredTupleBuilder
+	Description(description string) RequiredTupleBuilder
+
+	WithMessage(validationType, message string) RequiredTupleBuilder
+	WithRequiredMessage(message string) RequiredTupleBuilder
+
+	// JSON Schema export
+	ToJSONSchema() ([]byte, error)
+
+	// Validation method - final step in the builder chain
+	Validate(data interface{}) error
+}
+
+// OptionalTupleBuilder represents a tuple builder in the optional state.
+type OptionalTupleBuilder interface {
+	Rest(schema interface{}) OptionalTupleBuilder
+	Custom(fn func([]interface{}) error, extensions ...goop.SchemaExtension) OptionalTupleBuilder
+	Example(value interface{}) OptionalTupleBuilder
+	Title(title string) OptionalTupleBuilder
+	Description(description string) OptionalTupleBuilder
+	Default(value []interface{}) OptionalTupleBuilder // Only available on optional builders!
+
+	WithMessage(validationType, message string) OptionalTupleBuilder
+
+	// JSON Schema export
+	ToJSONSchema() ([]byte, error)
+
+	// Validation method - final step in the builder chain
+	Validate(data interface{}) error
+}
@@ -0,0 +1,141 @@
+package validators
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	goop "github.com/picogrid/go-op"
+)
+
+func coordinateSchema() RequiredTupleBuilder {
+	return Tuple(
+		Number().Min(-90).Max(90).Required(),
+		Number().Min(-180).Max(180).Required(),
+		Number().Optional(), // altitude
+	).Required()
+}
+
+// TestTuple tests positional validation of heterogeneous arrays
+func TestTuple(t *testing.T) {
+	schema := coordinateSchema()
+
+	valid := []interface{}{
+		[]interface{}{52.5, 13.4},
+		[]interface{}{52.5, 13.4, 34.0},
+		[]float64{-33.9, 151.2},
+	}
+	invalid := map[string]interface{}{
+		"too few items, expected at least 2": []interface{}{52.5},
+		"too many items, expected at most 3": []interface{}{52.5, 13.4, 34.0, 1.0},
+		"maximum is 180":                     []interface{}{52.5, 200.0},
+		"invalid type, expected number":      []interface{}{"52.5", 13.4},
+		"invalid type, expected array":       "52.5,13.4",
+		"field is required":                  nil,
+	}
+
+	for _, data := range valid {
+		if err := schema.Validate(data); err != nil {
+			t.Errorf("Expected %v to be valid, got: %v", data, err)
+		}
+	}
+	for message, data := range invalid {
+		if err := schema.Validate(data); err == nil || !strings.Contains(err.Error(), message) {
+			t.Errorf("Expected %v to fail with %q, got: %v", data, message, err)
+		}
+	}
+
+	t.Run("Rest", func(t *testing.T) {
+		row := Tuple(String().Required(), Number().Required()).Rest(String()).Required()
+		if err := row.Validate([]interface{}{"widget", 3.0, "red", "large"}); err != nil {
+			t.Errorf("Expected extra string items to be valid, got: %v", err)
+		}
+		if err := row.Validate([]interface{}{"widget", 3.0, 4.0}); err == nil || !strings.Contains(err.Error(), "[2]") {
+			t.Errorf("Expected the rest item to be rejected, got: %v", err)
+		}
+	})
+
+	t.Run("Optional with default", func(t *testing.T) {
+		origin := Tuple(Number().Required(), Number().Required()).Optional().Default([]interface{}{0.0, 0.0})
+		if err := origin.Validate(nil); err != nil {
+			t.Errorf("Expected nil to use the default, got: %v", err)
+		}
+	})
+
+	t.Run("Custom", func(t *testing.T) {
+		span := Tuple(Number().Required(), Number().Required()).Custom(func(items []interface{}) error {
+			if items[0].(float64) > items[1].(float64) {
+				return goop.NewValidationError("", items, "start must not be after end")
+			}
+			return nil
+		}).Required()
+		if err := span.Validate([]interface{}{5.0, 1.0}); err == nil || !strings.Contains(err.Error(), "start must not be after end") {
+			t.Errorf("Expected custom error, got: %v", err)
+		}
+	})
+
+	t.Run("OpenAPI", func(t *testing.T) {
+		spec := schema.(goop.EnhancedSchema).ToOpenAPISchema()
+		if spec.Type != "array" || len(spec.PrefixItems) != 3 || spec.Items != nil {
+			t.Fatalf("Expected an array with three prefixItems and no items, got %+v", spec)
+		}
+		if *spec.PrefixItems[1].Maximum != 180 {
+			t.Errorf("Expected the longitude maximum, got %+v", spec.PrefixItems[1])
+		}
+		if spec.MinItems == nil || *spec.MinItems != 2 || spec.MaxItems == nil || *spec.MaxItems != 3 {
+			t.Errorf("Expected minItems 2 and maxItems 3, got %v and %v", spec.MinItems, spec.MaxItems)
+		}
+
+		data, err := schema.ToJSONSchema()
+		if err != nil {
+			t.Fatalf("ToJSONSchema failed: %v", err)
+		}
+		var doc map[string]interface{}
+		if err := json.Unmarshal(data, &doc); err != nil {
+			t.Fatalf("Invalid JSON Schema: %v", err)
+		}
+		if positions, _ := doc["prefixItems"].([]interface{}); len(positions) != 3 {
+			t.Errorf("Expected prefixItems in JSON Schema, got %s", data)
+		}
+	})
+
+	t.Run("Import", func(t *testing.T) {
+		data, err := schema.ToJSONSchema()
+		if err != nil {
+			t.Fatalf("ToJSONSchema failed: %v", err)
+		}
+		imported, err := FromJSONSchema(data)
+		if err != nil {
+			t.Fatalf("FromJSONSchema failed: %v", err)
+		}
+		if err := imported.Validate([]interface{}{52.5, 13.4}); err != nil {
+			t.Errorf("Expected imported tuple to accept coordinates, got: %v", err)
+		}
+		if err := imported.Validate([]interface{}{52.5, 200.0}); err == nil {
+			t.Error("Expected imported tuple to reject an out of range longitude")
+		}
+		if err := imported.Validate([]interface{}{52.5, 13.4, 34.0, 1.0}); err == nil {
+			t.Error("Expected imported tuple to reject extra items")
+		}
+
+		open, err := FromJSONSchema([]byte(`{"prefixItems": [{"type": "string"}]}`))
+		if err != nil {
+			t.Fatalf("FromJSONSchema failed: %v", err)
+		}
+		if err := open.Validate([]interface{}{"a", 1.0, true}); err != nil {
+			t.Errorf("Expected prefixItems without items to allow extra items, got: %v", err)
+		}
+	})
+
+	t.Run("Generate", func(t *testing.T) {
+		for i := 0; i < 20; i++ {
+			value, err := goop.Generate(schema, goop.WithSeed(int64(i)))
+			if err != nil {
+				t.Fatalf("Generate failed: %v", err)
+			}
+			if err := schema.Validate(value); err != nil {
+				t.Errorf("Generated %v is invalid: %v", value, err)
+			}
+		}
+	})
+}
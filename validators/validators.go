@@ -31,6 +31,7 @@ func Object(schema map[string]interface{}) ObjectBuilder {
 	return &objectSchema{
 		schema:      schema,
 		customError: make(map[string]string),
+		fields:      &objectFields{},
 	}
 }
 
@@ -46,9 +47,9 @@ func Bool() BoolBuilder {
 // These are the secondary entry points that make sense at package level
 
 // Email creates a pre-configured required email string validator.
-// Equivalent to String().Email().Required()
-func Email() RequiredStringBuilder {
-	return String().Email().Required()
+// Equivalent to String().Email(options...).Required()
+func Email(options ...EmailOption) RequiredStringBuilder {
+	return String().Email(options...).Required()
 }
 
 // URL creates a pre-configured required URL string validator.
@@ -0,0 +1,122 @@
+package validators
+
+import (
+	goop "github.com/picogrid/go-op"
+)
+
+// visibleToScopes records the scopes that can see a response field as a specification extension
+func visibleToScopes(extensions []goop.SchemaExtension, scopes []string) []goop.SchemaExtension {
+	return append(extensions, goop.SchemaExtension{Name: goop.VisibleToScopesExtension, Value: scopes})
+}
+
+// VisibleToScopes limits the field to callers holding any of scopes. Validated operations strip it
+// from responses to other callers, and OpenAPIGenerator.FilteredForScopes omits it from their documentation.
+// The caller's scopes come from an authenticated principal implementing goop.ScopedPrincipal.
+//
+// Example:
+//
+//	"salary": validators.Number().Min(0).VisibleToScopes("hr:read", "admin").Optional(),
+func (s *stringSchema) VisibleToScopes(scopes ...string) StringBuilder {
+	s.extensions = visibleToScopes(s.extensions, scopes)
+	return s
+}
+
+func (r *requiredStringSchema) VisibleToScopes(scopes ...string) RequiredStringBuilder {
+	r.extensions = visibleToScopes(r.extensions, scopes)
+	return r
+}
+
+func (o *optionalStringSchema) VisibleToScopes(scopes ...string) OptionalStringBuilder {
+	o.extensions = visibleToScopes(o.extensions, scopes)
+	return o
+}
+
+func (n *numberSchema) VisibleToScopes(scopes ...string) NumberBuilder {
+	n.extensions = visibleToScopes(n.extensions, scopes)
+	return n
+}
+
+func (r *requiredNumberSchema) VisibleToScopes(scopes ...string) RequiredNumberBuilder {
+	r.extensions = visibleToScopes(r.extensions, scopes)
+	return r
+}
+
+func (o *optionalNumberSchema) VisibleToScopes(scopes ...string) OptionalNumberBuilder {
+	o.extensions = visibleToScopes(o.extensions, scopes)
+	return o
+}
+
+func (i *intSchema) VisibleToScopes(scopes ...string) IntBuilder {
+	i.extensions = visibleToScopes(i.extensions, scopes)
+	return i
+}
+
+func (r *requiredIntSchema) VisibleToScopes(scopes ...string) RequiredIntBuilder {
+	r.extensions = visibleToScopes(r.extensions, scopes)
+	return r
+}
+
+func (o *optionalIntSchema) VisibleToScopes(scopes ...string) OptionalIntBuilder {
+	o.extensions = visibleToScopes(o.extensions, scopes)
+	return o
+}
+
+func (u *uintSchema) VisibleToScopes(scopes ...string) UintBuilder {
+	u.extensions = visibleToScopes(u.extensions, scopes)
+	return u
+}
+
+func (r *requiredUintSchema) VisibleToScopes(scopes ...string) RequiredUintBuilder {
+	r.extensions = visibleToScopes(r.extensions, scopes)
+	return r
+}
+
+func (o *optionalUintSchema) VisibleToScopes(scopes ...string) OptionalUintBuilder {
+	o.extensions = visibleToScopes(o.extensions, scopes)
+	return o
+}
+
+func (b *boolSchema) VisibleToScopes(scopes ...string) BoolBuilder {
+	b.extensions = visibleToScopes(b.extensions, scopes)
+	return b
+}
+
+func (r *requiredBoolSchema) VisibleToScopes(scopes ...string) RequiredBoolBuilder {
+	r.extensions = visibleToScopes(r.extensions, scopes)
+	return r
+}
+
+func (o *optionalBoolSchema) VisibleToScopes(scopes ...string) OptionalBoolBuilder {
+	o.extensions = visibleToScopes(o.extensions, scopes)
+	return o
+}
+
+func (a *arraySchema) VisibleToScopes(scopes ...string) ArrayBuilder {
+	a.extensions = visibleToScopes(a.extensions, scopes)
+	return a
+}
+
+func (r *requiredArraySchema) VisibleToScopes(scopes ...string) RequiredArrayBuilder {
+	r.extensions = visibleToScopes(r.extensions, scopes)
+	return r
+}
+
+func (o *optionalArraySchema) VisibleToScopes(scopes ...string) OptionalArrayBuilder {
+	o.extensions = visibleToScopes(o.extensions, scopes)
+	return o
+}
+
+func (o *objectSchema) VisibleToScopes(scopes ...string) ObjectBuilder {
+	o.extensions = visibleToScopes(o.extensions, scopes)
+	return o
+}
+
+func (r *requiredObjectSchema) VisibleToScopes(scopes ...string) RequiredObjectBuilder {
+	r.extensions = visibleToScopes(r.extensions, scopes)
+	return r
+}
+
+func (o *optionalObjectSchema) VisibleToScopes(scopes ...string) OptionalObjectBuilder {
+	o.extensions = visibleToScopes(o.extensions, scopes)
+	return o
+}
@@ -0,0 +1,40 @@
+package validators
+
+import (
+	"reflect"
+	"testing"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// TestVisibleToScopes tests documenting the scopes that can see a field
+func TestVisibleToScopes(t *testing.T) {
+	schema := Object(map[string]interface{}{
+		"name":    String().Required(),
+		"email":   String().Email().VisibleToScopes("admin").Required(),
+		"salary":  Number().VisibleToScopes("admin", "payroll").Optional(),
+		"level":   Int().VisibleToScopes("admin").Optional(),
+		"visits":  Uint().VisibleToScopes("admin").Optional(),
+		"active":  Bool().VisibleToScopes("admin").Optional(),
+		"tags":    Array(String()).VisibleToScopes("admin").Optional(),
+		"address": Object(map[string]interface{}{"city": String().Required()}).VisibleToScopes("admin").Optional(),
+	}).Required()
+
+	spec := schema.(goop.EnhancedSchema).ToOpenAPISchema()
+	if scopes := goop.FieldScopes(spec.Properties["name"]); scopes != nil {
+		t.Errorf("name: expected no scopes, got %v", scopes)
+	}
+	if scopes := goop.FieldScopes(spec.Properties["salary"]); !reflect.DeepEqual(scopes, []string{"admin", "payroll"}) {
+		t.Errorf("salary: expected [admin payroll], got %v", scopes)
+	}
+	for _, field := range []string{"email", "level", "visits", "active", "tags", "address"} {
+		if scopes := goop.FieldScopes(spec.Properties[field]); !reflect.DeepEqual(scopes, []string{"admin"}) {
+			t.Errorf("%s: expected [admin], got %v", field, scopes)
+		}
+	}
+
+	// Scoped fields are still validated for every caller
+	if err := schema.Validate(map[string]interface{}{"name": "Ada", "email": "not-an-email"}); err == nil {
+		t.Error("Expected an invalid scoped field to fail validation")
+	}
+}
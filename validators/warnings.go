@@ -0,0 +1,81 @@
+package validators
+
+import (
+	"fmt"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// isWarning reports whether err is a warning created with goop.NewValidationWarning
+func isWarning(err error) bool {
+	validationErr, ok := err.(*goop.ValidationError)
+	return ok && validationErr.IsWarning()
+}
+
+// hasFailures reports whether details hold a failure rather than only warnings
+func hasFailures(details []goop.ValidationError) bool {
+	for _, detail := range details {
+		if !detail.IsWarning() && (len(detail.Details) == 0 || hasFailures(detail.Details)) {
+			return true
+		}
+	}
+	return false
+}
+
+// asValidationError returns err as a ValidationError, wrapping plain errors about value
+func asValidationError(err error, value interface{}) *goop.ValidationError {
+	if validationErr, ok := err.(*goop.ValidationError); ok {
+		return validationErr
+	}
+	return goop.NewValidationError("", value, err.Error())
+}
+
+// setDeprecatedField records a deprecated field, panicking on names that are not fields of the
+// object so a typo cannot silently drop the warning
+func (o *objectSchema) setDeprecatedField(field, message string) {
+	if _, ok := o.schema[field]; !ok {
+		panic(fmt.Sprintf("validators: DeprecatedField: object has no field %q", field))
+	}
+	if message == "" {
+		message = fmt.Sprintf("%s is deprecated", field)
+	}
+	if o.deprecated == nil {
+		o.deprecated = make(map[string]string)
+	}
+	o.deprecated[field] = message
+}
+
+// checkDeprecatedFields adds a warning for each deprecated field present in obj
+func (o *objectSchema) checkDeprecatedFields(obj map[string]interface{}, collector *errorCollector) {
+	for field, message := range o.deprecated {
+		if value := obj[field]; value != nil {
+			collector.add(*goop.NewValidationWarning(field, value, message))
+		}
+	}
+}
+
+// DeprecatedField marks field as deprecated: it is documented with deprecated: true, and requests
+// that still send it pass validation with a warning carrying message, or "<field> is deprecated"
+// when message is empty. Warnings are reported by ValidateCtx to the handler registered with
+// goop.WithWarningHandler. DeprecatedField panics if field is not a field of the object.
+//
+// Example:
+//
+//	user := validators.Object(map[string]interface{}{
+//	    "name":     validators.String().Required(),
+//	    "nickname": validators.String().Optional(),
+//	}).DeprecatedField("nickname", "nickname is replaced by display_name").Required()
+func (o *objectSchema) DeprecatedField(field, message string) ObjectBuilder {
+	o.setDeprecatedField(field, message)
+	return o
+}
+
+func (r *requiredObjectSchema) DeprecatedField(field, message string) RequiredObjectBuilder {
+	r.setDeprecatedField(field, message)
+	return r
+}
+
+func (o *optionalObjectSchema) DeprecatedField(field, message string) OptionalObjectBuilder {
+	o.setDeprecatedField(field, message)
+	return o
+}
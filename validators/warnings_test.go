@@ -0,0 +1,116 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	goop "github.com/picogrid/go-op"
+)
+
+// TestValidationWarnings tests non-fatal findings reported during ValidateCtx
+func TestValidationWarnings(t *testing.T) {
+	// Quantities close to the limit pass with a warning
+	nearLimit := func(ctx context.Context, quantity float64) error {
+		if quantity > 90 {
+			return goop.NewValidationWarning("", quantity, "quantity is close to the limit of 100")
+		}
+		return nil
+	}
+	schema := Object(map[string]interface{}{
+		"name":     String().Required(),
+		"nickname": String().Optional(),
+		"items":    Array(Number().Max(100).CustomCtx(nearLimit)).Optional(),
+	}).DeprecatedField("nickname", "nickname is replaced by display_name").Required()
+
+	collect := func() (context.Context, *[]goop.ValidationError) {
+		var warnings []goop.ValidationError
+		ctx := goop.WithWarningHandler(context.Background(), func(warning goop.ValidationError) {
+			warnings = append(warnings, warning)
+		})
+		return ctx, &warnings
+	}
+
+	t.Run("Deprecated field", func(t *testing.T) {
+		ctx, warnings := collect()
+		if err := schema.ValidateCtx(ctx, map[string]interface{}{"name": "Ada", "nickname": "ada"}); err != nil {
+			t.Errorf("Expected the deprecated field to pass, got: %v", err)
+		}
+		if len(*warnings) != 1 || (*warnings)[0].Field != "nickname" || (*warnings)[0].Message != "nickname is replaced by display_name" {
+			t.Errorf("Expected a nickname warning, got: %v", *warnings)
+		}
+
+		ctx, warnings = collect()
+		if err := schema.ValidateCtx(ctx, map[string]interface{}{"name": "Ada"}); err != nil {
+			t.Errorf("Expected validation to pass, got: %v", err)
+		}
+		if len(*warnings) != 0 {
+			t.Errorf("Expected no warnings without the deprecated field, got: %v", *warnings)
+		}
+	})
+
+	t.Run("Value near a limit", func(t *testing.T) {
+		ctx, warnings := collect()
+		data := map[string]interface{}{"name": "Ada", "items": []interface{}{10.0, 95.0}}
+		if err := schema.ValidateCtx(ctx, data); err != nil {
+			t.Errorf("Expected the quantity to pass, got: %v", err)
+		}
+		if len(*warnings) != 1 || (*warnings)[0].Field != "items[1]" {
+			t.Errorf("Expected a warning for items[1], got: %v", *warnings)
+		}
+	})
+
+	t.Run("Failures are still returned", func(t *testing.T) {
+		ctx, warnings := collect()
+		failing := Object(map[string]interface{}{
+			"nickname": String().Optional(),
+			"region": String().CustomCtx(func(ctx context.Context, region string) error {
+				return fmt.Errorf("region %s is not available", region)
+			}).Required(),
+		}).DeprecatedField("nickname", "").Required()
+
+		err := failing.ValidateCtx(ctx, map[string]interface{}{"nickname": "ada", "region": "mars"})
+		if err == nil || !strings.Contains(err.Error(), "region mars is not available") {
+			t.Errorf("Expected the region to fail, got: %v", err)
+		}
+		if strings.Contains(err.Error(), "deprecated") {
+			t.Errorf("Expected the warning to be reported separately, got: %v", err)
+		}
+		if len(*warnings) != 1 || (*warnings)[0].Message != "nickname is deprecated" {
+			t.Errorf("Expected the default deprecation warning, got: %v", *warnings)
+		}
+	})
+
+	t.Run("Remote warnings", func(t *testing.T) {
+		ctx, warnings := collect()
+		sku := String().RemoteCheck(func(ctx context.Context, values []string) map[string]error {
+			return map[string]error{"old-sku": goop.NewValidationWarning("", "old-sku", "sku is discontinued")}
+		}).Required()
+		if err := Array(sku).Required().ValidateCtx(ctx, []interface{}{"new-sku", "old-sku"}); err != nil {
+			t.Errorf("Expected the discontinued sku to pass, got: %v", err)
+		}
+		if len(*warnings) != 1 || (*warnings)[0].Field != "[1]" {
+			t.Errorf("Expected a warning for [1], got: %v", *warnings)
+		}
+	})
+
+	t.Run("OpenAPI", func(t *testing.T) {
+		spec := schema.(goop.EnhancedSchema).ToOpenAPISchema()
+		if deprecated := spec.Properties["nickname"].Deprecated; deprecated == nil || !*deprecated {
+			t.Error("Expected nickname to be documented as deprecated")
+		}
+		if spec.Properties["name"].Deprecated != nil {
+			t.Error("Expected name not to be deprecated")
+		}
+	})
+
+	t.Run("Unknown field", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected DeprecatedField to panic on an unknown field")
+			}
+		}()
+		Object(map[string]interface{}{"name": String().Required()}).DeprecatedField("nick", "")
+	})
+}
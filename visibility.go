@@ -0,0 +1,278 @@
+package goop
+
+import (
+	"context"
+	"sort"
+)
+
+// VisibleToScopesExtension is the schema extension listing the scopes that can see a response field
+// Callers holding none of the scopes receive responses without the field.
+const VisibleToScopesExtension = "x-visible-to-scopes"
+
+// ScopedPrincipal is implemented by authenticated principals that carry the scopes granted to the caller
+type ScopedPrincipal interface {
+	Scopes() []string
+}
+
+// ScopesFromContext returns the scopes of the principal stored in ctx by WithAuth
+// It returns nil when there is no principal or it does not implement ScopedPrincipal.
+func ScopesFromContext(ctx context.Context) []string {
+	if principal, ok := ctx.Value(authContextKey{}).(ScopedPrincipal); ok {
+		return principal.Scopes()
+	}
+	return nil
+}
+
+// FieldScopes returns the scopes that can see values described by schema, or nil if everyone can
+func FieldScopes(schema *OpenAPISchema) []string {
+	// The extension is a string slice when generated and a generic slice when decoded from a spec document
+	switch scopes := schema.Extensions[VisibleToScopesExtension].(type) {
+	case []string:
+		return scopes
+	case []interface{}:
+		names := make([]string, 0, len(scopes))
+		for _, scope := range scopes {
+			if name, ok := scope.(string); ok {
+				names = append(names, name)
+			}
+		}
+		return names
+	}
+	return nil
+}
+
+// hasAnyScope reports whether granted contains any of required; no required scopes means visible to all
+func hasAnyScope(granted, required []string) bool {
+	if len(required) == 0 {
+		return true
+	}
+	for _, scope := range granted {
+		for _, candidate := range required {
+			if scope == candidate {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// FieldVisibility removes the fields a caller's scopes cannot see from documents described by a schema
+// A nil FieldVisibility has no scoped fields and returns documents unchanged.
+type FieldVisibility struct {
+	scopes     []string
+	properties map[string]*FieldVisibility
+	items      *FieldVisibility
+	additional *FieldVisibility
+}
+
+// NewFieldVisibility returns the FieldVisibility of documents described by schema, or nil when the
+// schema has no scoped fields. resolve returns the schema a $ref points to, or nil if it is unknown.
+func NewFieldVisibility(schema *OpenAPISchema, resolve func(ref string) *OpenAPISchema) *FieldVisibility {
+	return buildFieldVisibility(schema, resolve, make(map[string]bool))
+}
+
+func buildFieldVisibility(schema *OpenAPISchema, resolve func(ref string) *OpenAPISchema, visiting map[string]bool) *FieldVisibility {
+	if schema == nil {
+		return nil
+	}
+	if schema.Ref != "" {
+		// Recursive references are inspected once
+		if resolve == nil || visiting[schema.Ref] {
+			return nil
+		}
+		visiting[schema.Ref] = true
+		defer delete(visiting, schema.Ref)
+		return buildFieldVisibility(resolve(schema.Ref), resolve, visiting)
+	}
+
+	v := &FieldVisibility{scopes: FieldScopes(schema)}
+	for name, property := range schema.Properties {
+		if child := buildFieldVisibility(property, resolve, visiting); child != nil {
+			if v.properties == nil {
+				v.properties = make(map[string]*FieldVisibility)
+			}
+			v.properties[name] = child
+		}
+	}
+	v.items = buildFieldVisibility(schema.Items, resolve, visiting)
+	if schema.AdditionalProperties != nil {
+		v.additional = buildFieldVisibility(schema.AdditionalProperties.Schema, resolve, visiting)
+	}
+	// Members of compositions may describe the same document, so their fields are combined
+	for _, members := range [][]*OpenAPISchema{schema.AllOf, schema.AnyOf, schema.OneOf} {
+		for _, member := range members {
+			v.merge(buildFieldVisibility(member, resolve, visiting))
+		}
+	}
+
+	if len(v.scopes) == 0 && v.properties == nil && v.items == nil && v.additional == nil {
+		return nil
+	}
+	return v
+}
+
+// merge adds the scoped fields of other to v
+// A field scoped in both is visible to either set of scopes, and to everyone if either has none.
+func (v *FieldVisibility) merge(other *FieldVisibility) {
+	if other == nil {
+		return
+	}
+	if len(v.scopes) == 0 || len(other.scopes) == 0 {
+		v.scopes = nil
+	} else {
+		v.scopes = append(v.scopes[:len(v.scopes):len(v.scopes)], other.scopes...)
+	}
+	for name, child := range other.properties {
+		if v.properties == nil {
+			v.properties = make(map[string]*FieldVisibility)
+		}
+		v.properties[name] = mergeFieldVisibility(v.properties[name], child)
+	}
+	v.items = mergeFieldVisibility(v.items, other.items)
+	v.additional = mergeFieldVisibility(v.additional, other.additional)
+}
+
+func mergeFieldVisibility(a, b *FieldVisibility) *FieldVisibility {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	// Merge into a copy so the visibility of other schemas is left unchanged
+	merged := &FieldVisibility{scopes: a.scopes, items: a.items, additional: a.additional}
+	if a.properties != nil {
+		merged.properties = make(map[string]*FieldVisibility, len(a.properties))
+		for name, child := range a.properties {
+			merged.properties[name] = child
+		}
+	}
+	merged.merge(b)
+	return merged
+}
+
+// MergeFieldVisibility returns a FieldVisibility with the scoped fields of every given one, or nil
+// if all are nil. It is used for operations whose responses are described by several schemas.
+func MergeFieldVisibility(visibilities ...*FieldVisibility) *FieldVisibility {
+	var merged *FieldVisibility
+	for _, v := range visibilities {
+		merged = mergeFieldVisibility(merged, v)
+	}
+	return merged
+}
+
+// Scopes returns every scope that can see a scoped field, sorted
+func (v *FieldVisibility) Scopes() []string {
+	seen := make(map[string]bool)
+	v.collectScopes(seen)
+	scopes := make([]string, 0, len(seen))
+	for scope := range seen {
+		scopes = append(scopes, scope)
+	}
+	sort.Strings(scopes)
+	return scopes
+}
+
+func (v *FieldVisibility) collectScopes(seen map[string]bool) {
+	if v == nil {
+		return
+	}
+	for _, scope := range v.scopes {
+		seen[scope] = true
+	}
+	for _, child := range v.properties {
+		child.collectScopes(seen)
+	}
+	v.items.collectScopes(seen)
+	v.additional.collectScopes(seen)
+}
+
+// Filter returns value, a decoded JSON document, without the fields that granted scopes cannot see
+// Objects and arrays containing removed fields are copied; value itself is left unchanged.
+func (v *FieldVisibility) Filter(value interface{}, granted []string) interface{} {
+	if v == nil {
+		return value
+	}
+	switch doc := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(doc))
+		for name, field := range doc {
+			child, ok := v.properties[name]
+			if !ok {
+				child = v.additional
+			}
+			if child != nil && !hasAnyScope(granted, child.scopes) {
+				continue
+			}
+			result[name] = child.Filter(field, granted)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(doc))
+		for i, item := range doc {
+			result[i] = v.items.Filter(item, granted)
+		}
+		return result
+	}
+	return value
+}
+
+// VisibleSchema returns schema without the properties that granted scopes cannot see, for
+// documentation filtered by audience. Unchanged schemas are returned as-is and changed ones are
+// copied, so schema itself is never modified.
+func VisibleSchema(schema *OpenAPISchema, granted []string) *OpenAPISchema {
+	if schema == nil {
+		return nil
+	}
+	visible := *schema
+	changed := false
+
+	if len(schema.Properties) > 0 {
+		properties := make(map[string]*OpenAPISchema, len(schema.Properties))
+		removed := make(map[string]bool)
+		for name, property := range schema.Properties {
+			if !hasAnyScope(granted, FieldScopes(property)) {
+				removed[name] = true
+				continue
+			}
+			properties[name] = VisibleSchema(property, granted)
+			changed = changed || properties[name] != property
+		}
+		if len(removed) > 0 {
+			changed = true
+			visible.Required = nil
+			for _, name := range schema.Required {
+				if !removed[name] {
+					visible.Required = append(visible.Required, name)
+				}
+			}
+		}
+		visible.Properties = properties
+	}
+
+	if visible.Items = VisibleSchema(schema.Items, granted); visible.Items != schema.Items {
+		changed = true
+	}
+	if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+		if additional := VisibleSchema(schema.AdditionalProperties.Schema, granted); additional != schema.AdditionalProperties.Schema {
+			visible.AdditionalProperties = &OpenAPISchemaOrBool{Schema: additional}
+			changed = true
+		}
+	}
+	for _, members := range []*[]*OpenAPISchema{&visible.AllOf, &visible.AnyOf, &visible.OneOf} {
+		if len(*members) == 0 {
+			continue
+		}
+		copied := make([]*OpenAPISchema, len(*members))
+		for i, member := range *members {
+			copied[i] = VisibleSchema(member, granted)
+			changed = changed || copied[i] != member
+		}
+		*members = copied
+	}
+
+	if !changed {
+		return schema
+	}
+	return &visible
+}
@@ -0,0 +1,131 @@
+package goop
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+type scopedPrincipal struct {
+	scopes []string
+}
+
+func (p scopedPrincipal) Scopes() []string {
+	return p.scopes
+}
+
+// TestFieldVisibility tests stripping scoped fields from decoded documents
+func TestFieldVisibility(t *testing.T) {
+	admin := map[string]interface{}{VisibleToScopesExtension: []string{"admin"}}
+	employee := &OpenAPISchema{
+		Type: "object",
+		Properties: map[string]*OpenAPISchema{
+			"name":   {Type: "string"},
+			"salary": {Type: "number", Extensions: admin},
+		},
+	}
+	schema := &OpenAPISchema{
+		Type: "object",
+		Properties: map[string]*OpenAPISchema{
+			"manager": {Ref: "#/components/schemas/Employee"},
+			"reports": {Type: "array", Items: &OpenAPISchema{Ref: "#/components/schemas/Employee"}},
+		},
+	}
+	resolve := func(ref string) *OpenAPISchema {
+		if ref == "#/components/schemas/Employee" {
+			return employee
+		}
+		return nil
+	}
+
+	visibility := NewFieldVisibility(schema, resolve)
+	document := map[string]interface{}{
+		"manager": map[string]interface{}{"name": "Ada", "salary": 100},
+		"reports": []interface{}{map[string]interface{}{"name": "Grace", "salary": 90}},
+	}
+
+	t.Run("Strips fields for callers without a scope", func(t *testing.T) {
+		expected := map[string]interface{}{
+			"manager": map[string]interface{}{"name": "Ada"},
+			"reports": []interface{}{map[string]interface{}{"name": "Grace"}},
+		}
+		if filtered := visibility.Filter(document, []string{"user"}); !reflect.DeepEqual(filtered, expected) {
+			t.Errorf("Expected %v, got %v", expected, filtered)
+		}
+		if _, ok := document["manager"].(map[string]interface{})["salary"]; !ok {
+			t.Error("Expected the document to be left unchanged")
+		}
+	})
+
+	t.Run("Keeps fields for callers with a scope", func(t *testing.T) {
+		if filtered := visibility.Filter(document, []string{"user", "admin"}); !reflect.DeepEqual(filtered, document) {
+			t.Errorf("Expected %v, got %v", document, filtered)
+		}
+	})
+
+	t.Run("Scopes", func(t *testing.T) {
+		if scopes := visibility.Scopes(); !reflect.DeepEqual(scopes, []string{"admin"}) {
+			t.Errorf("Expected [admin], got %v", scopes)
+		}
+	})
+
+	t.Run("No scoped fields", func(t *testing.T) {
+		if v := NewFieldVisibility(&OpenAPISchema{Type: "string"}, nil); v != nil {
+			t.Errorf("Expected nil visibility, got %v", v)
+		}
+	})
+
+	t.Run("Merged schemas", func(t *testing.T) {
+		audit := &OpenAPISchema{
+			Type: "object",
+			Properties: map[string]*OpenAPISchema{
+				"salary": {Type: "number", Extensions: map[string]interface{}{VisibleToScopesExtension: []interface{}{"payroll"}}},
+			},
+		}
+		merged := MergeFieldVisibility(NewFieldVisibility(employee, nil), NewFieldVisibility(audit, nil))
+		value := map[string]interface{}{"salary": 100}
+		if filtered := merged.Filter(value, []string{"payroll"}); !reflect.DeepEqual(filtered, value) {
+			t.Errorf("Expected the field to be visible to either scope, got %v", filtered)
+		}
+		if scopes := merged.Scopes(); !reflect.DeepEqual(scopes, []string{"admin", "payroll"}) {
+			t.Errorf("Expected [admin payroll], got %v", scopes)
+		}
+	})
+}
+
+// TestVisibleSchema tests removing scoped properties from documented schemas
+func TestVisibleSchema(t *testing.T) {
+	schema := &OpenAPISchema{
+		Type: "object",
+		Properties: map[string]*OpenAPISchema{
+			"name":   {Type: "string"},
+			"salary": {Type: "number", Extensions: map[string]interface{}{VisibleToScopesExtension: []string{"admin"}}},
+		},
+		Required: []string{"name", "salary"},
+	}
+
+	visible := VisibleSchema(schema, nil)
+	if _, ok := visible.Properties["salary"]; ok {
+		t.Error("Expected the scoped property to be removed")
+	}
+	if !reflect.DeepEqual(visible.Required, []string{"name"}) {
+		t.Errorf("Expected required [name], got %v", visible.Required)
+	}
+	if _, ok := schema.Properties["salary"]; !ok || len(schema.Required) != 2 {
+		t.Error("Expected the original schema to be left unchanged")
+	}
+	if VisibleSchema(schema, []string{"admin"}) != schema {
+		t.Error("Expected an unchanged schema to be returned as-is")
+	}
+}
+
+// TestScopesFromContext tests reading the scopes of the authenticated principal
+func TestScopesFromContext(t *testing.T) {
+	ctx := WithAuth(context.Background(), scopedPrincipal{scopes: []string{"admin"}})
+	if scopes := ScopesFromContext(ctx); !reflect.DeepEqual(scopes, []string{"admin"}) {
+		t.Errorf("Expected [admin], got %v", scopes)
+	}
+	if scopes := ScopesFromContext(WithAuth(context.Background(), "usr_123")); scopes != nil {
+		t.Errorf("Expected no scopes, got %v", scopes)
+	}
+}
@@ -0,0 +1,129 @@
+package goop
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// SchemaNode is a schema visited by Walk
+type SchemaNode struct {
+	// Path locates the node from the root: property names are joined with ".", array items
+	// add "[]", and nested schemas add "additionalProperties", "allOf[i]", "anyOf[i]", "oneOf[i]", or "not".
+	// The root has an empty path.
+	Path string
+	// Name is the property name for object properties and empty otherwise
+	Name string
+	// Required reports whether the parent object lists this property as required.
+	// For the root it reports the schema's own Required state.
+	Required bool
+	// Depth is 0 for the root and increases by one per level
+	Depth int
+	// Schema holds the node's type and constraints
+	Schema *OpenAPISchema
+}
+
+// Visitor is called for each schema node visited by Walk.
+// Returning false skips the node's children.
+type Visitor interface {
+	Visit(node SchemaNode) bool
+}
+
+// VisitorFunc adapts a function to the Visitor interface
+type VisitorFunc func(node SchemaNode) bool
+
+// Visit calls f(node)
+func (f VisitorFunc) Visit(node SchemaNode) bool {
+	return f(node)
+}
+
+// Walk traverses schema depth-first, calling visitor for the schema and each nested schema.
+// Object properties are visited in sorted order, followed by array items and composition members.
+// The schema must implement OpenAPIGenerator, which all validators in this module do.
+func Walk(schema Schema, visitor Visitor) error {
+	generator, ok := schema.(OpenAPIGenerator)
+	if !ok {
+		return fmt.Errorf("schema %T does not expose its constraints", schema)
+	}
+
+	required := false
+	if info := generator.GetValidationInfo(); info != nil {
+		required = info.Required
+	}
+	walkNode(SchemaNode{Required: required, Schema: generator.ToOpenAPISchema()}, visitor)
+	return nil
+}
+
+func walkNode(node SchemaNode, visitor Visitor) {
+	if node.Schema == nil || !visitor.Visit(node) {
+		return
+	}
+	schema := node.Schema
+
+	required := make(map[string]bool, len(schema.Required))
+	for _, name := range schema.Required {
+		required[name] = true
+	}
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		walkNode(SchemaNode{
+			Path:     joinSchemaPath(node.Path, name),
+			Name:     name,
+			Required: required[name],
+			Depth:    node.Depth + 1,
+			Schema:   schema.Properties[name],
+		}, visitor)
+	}
+
+	walkMembers(node, "prefixItems", schema.PrefixItems, visitor)
+	if schema.Items != nil {
+		walkNode(SchemaNode{Path: node.Path + "[]", Depth: node.Depth + 1, Schema: schema.Items}, visitor)
+	}
+	if schema.Contains != nil {
+		walkNode(SchemaNode{Path: joinSchemaPath(node.Path, "contains"), Depth: node.Depth + 1, Schema: schema.Contains}, visitor)
+	}
+
+	if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+		walkNode(SchemaNode{
+			Path:   joinSchemaPath(node.Path, "additionalProperties"),
+			Depth:  node.Depth + 1,
+			Schema: schema.AdditionalProperties.Schema,
+		}, visitor)
+	}
+
+	walkMembers(node, "allOf", schema.AllOf, visitor)
+	walkMembers(node, "anyOf", schema.AnyOf, visitor)
+	walkMembers(node, "oneOf", schema.OneOf, visitor)
+	if schema.Not != nil {
+		walkNode(SchemaNode{Path: joinSchemaPath(node.Path, "not"), Depth: node.Depth + 1, Schema: schema.Not}, visitor)
+	}
+	for _, conditional := range []struct {
+		keyword string
+		schema  *OpenAPISchema
+	}{{"if", schema.If}, {"then", schema.Then}, {"else", schema.Else}} {
+		if conditional.schema != nil {
+			walkNode(SchemaNode{Path: joinSchemaPath(node.Path, conditional.keyword), Depth: node.Depth + 1, Schema: conditional.schema}, visitor)
+		}
+	}
+}
+
+func walkMembers(node SchemaNode, keyword string, members []*OpenAPISchema, visitor Visitor) {
+	for i, member := range members {
+		walkNode(SchemaNode{
+			Path:   joinSchemaPath(node.Path, keyword+"["+strconv.Itoa(i)+"]"),
+			Depth:  node.Depth + 1,
+			Schema: member,
+		}, visitor)
+	}
+}
+
+func joinSchemaPath(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
@@ -0,0 +1,113 @@
+package goop
+
+import (
+	"reflect"
+	"testing"
+)
+
+type walkTestSchema struct {
+	schema   *OpenAPISchema
+	required bool
+	validate func(data interface{}) error
+}
+
+func (s *walkTestSchema) Validate(data interface{}) error {
+	if s.validate != nil {
+		return s.validate(data)
+	}
+	return nil
+}
+
+func (s *walkTestSchema) ToOpenAPISchema() *OpenAPISchema { return s.schema }
+
+func (s *walkTestSchema) GetValidationInfo() *ValidationInfo {
+	return &ValidationInfo{Required: s.required, Optional: !s.required}
+}
+
+type walkPlainSchema struct{}
+
+func (walkPlainSchema) Validate(data interface{}) error { return nil }
+
+// TestWalk tests schema traversal with the visitor API
+func TestWalk(t *testing.T) {
+	minLength := 3
+	schema := &walkTestSchema{
+		required: true,
+		schema: &OpenAPISchema{
+			Type: "object",
+			Properties: map[string]*OpenAPISchema{
+				"name": {Type: "string", MinLength: &minLength, Pattern: "^[a-z]+$"},
+				"tags": {Type: "array", Items: &OpenAPISchema{Type: "string"}},
+				"address": {
+					Type: "object",
+					Properties: map[string]*OpenAPISchema{
+						"city": {Type: "string"},
+					},
+					Required: []string{"city"},
+				},
+				"id": {OneOf: []*OpenAPISchema{{Type: "string"}, {Type: "integer"}}},
+			},
+			Required: []string{"name"},
+		},
+	}
+
+	t.Run("Visits every node in order", func(t *testing.T) {
+		var paths []string
+		err := Walk(schema, VisitorFunc(func(node SchemaNode) bool {
+			paths = append(paths, node.Path)
+			return true
+		}))
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		expected := []string{"", "address", "address.city", "id", "id.oneOf[0]", "id.oneOf[1]", "name", "tags", "tags[]"}
+		if !reflect.DeepEqual(paths, expected) {
+			t.Errorf("Expected paths %v, got %v", expected, paths)
+		}
+	})
+
+	t.Run("Reports required fields and constraints", func(t *testing.T) {
+		nodes := make(map[string]SchemaNode)
+		_ = Walk(schema, VisitorFunc(func(node SchemaNode) bool {
+			nodes[node.Path] = node
+			return true
+		}))
+
+		if !nodes[""].Required {
+			t.Error("Expected root to be required")
+		}
+		if !nodes["name"].Required || nodes["tags"].Required {
+			t.Error("Expected only name to be required at the top level")
+		}
+		if !nodes["address.city"].Required {
+			t.Error("Expected address.city to be required")
+		}
+		if nodes["address.city"].Depth != 2 || nodes["address.city"].Name != "city" {
+			t.Errorf("Unexpected node for address.city: %+v", nodes["address.city"])
+		}
+		if nodes["name"].Schema.Pattern != "^[a-z]+$" || *nodes["name"].Schema.MinLength != 3 {
+			t.Errorf("Expected name constraints to be exposed, got %+v", nodes["name"].Schema)
+		}
+	})
+
+	t.Run("Skips children when the visitor returns false", func(t *testing.T) {
+		var paths []string
+		_ = Walk(schema, VisitorFunc(func(node SchemaNode) bool {
+			paths = append(paths, node.Path)
+			return node.Path != "address" && node.Path != "tags"
+		}))
+
+		for _, path := range paths {
+			if path == "address.city" || path == "tags[]" {
+				t.Errorf("Expected children of skipped nodes not to be visited, got %s", path)
+			}
+		}
+	})
+
+	t.Run("Rejects schemas without OpenAPI support", func(t *testing.T) {
+		if err := Walk(walkPlainSchema{}, VisitorFunc(func(SchemaNode) bool { return true })); err == nil {
+			t.Error("Expected error for schema without OpenAPI support")
+		}
+	})
+}
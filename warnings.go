@@ -0,0 +1,113 @@
+package goop
+
+import (
+	"context"
+	"strings"
+)
+
+// SeverityWarning marks a ValidationError as a non-fatal finding, such as use of a deprecated field
+// or a value close to a limit. Warnings are reported to the WarningHandler of the validation
+// context instead of failing validation.
+const SeverityWarning = "warning"
+
+// NewValidationWarning creates a warning, returned from CustomCtx checks or remote lookups
+func NewValidationWarning(field string, value interface{}, message string) *ValidationError {
+	warning := NewValidationError(field, value, message)
+	warning.Severity = SeverityWarning
+	return warning
+}
+
+// IsWarning reports whether the error is a warning rather than a failure
+func (e *ValidationError) IsWarning() bool {
+	return e.Severity == SeverityWarning
+}
+
+// WarningHandler receives the warnings found while validating with a context
+type WarningHandler func(warning ValidationError)
+
+// warningHandlerKey is the context key of the WarningHandler
+type warningHandlerKey struct{}
+
+// WithWarningHandler returns a context whose validations report warnings to handler
+// Handlers already in ctx keep receiving warnings, before handler.
+func WithWarningHandler(ctx context.Context, handler WarningHandler) context.Context {
+	if parent, ok := ctx.Value(warningHandlerKey{}).(WarningHandler); ok {
+		next := handler
+		handler = func(warning ValidationError) {
+			parent(warning)
+			next(warning)
+		}
+	}
+	return context.WithValue(ctx, warningHandlerKey{}, handler)
+}
+
+// ReportWarnings passes warnings to the WarningHandler in ctx; without one they are dropped
+func ReportWarnings(ctx context.Context, warnings []ValidationError) {
+	handler, ok := ctx.Value(warningHandlerKey{}).(WarningHandler)
+	if !ok {
+		return
+	}
+	for _, warning := range warnings {
+		handler(warning)
+	}
+}
+
+// SplitWarnings separates the warnings in err from its failures
+// Warnings nested in objects and arrays are returned with their full field path, such as
+// items[2].quantity. The remaining error is nil when err holds only warnings.
+func SplitWarnings(err error) ([]ValidationError, error) {
+	validationErr, ok := err.(*ValidationError)
+	if !ok {
+		return nil, err
+	}
+	var warnings []ValidationError
+	if remaining := splitWarnings(*validationErr, "", &warnings); remaining != nil {
+		return warnings, remaining
+	}
+	return warnings, nil
+}
+
+func splitWarnings(err ValidationError, path string, warnings *[]ValidationError) *ValidationError {
+	path = joinFieldPath(path, err.Field)
+	if err.IsWarning() {
+		err.Field = path
+		*warnings = append(*warnings, err)
+		return nil
+	}
+	if len(err.Details) == 0 {
+		return &err
+	}
+
+	var details []ValidationError
+	for _, detail := range err.Details {
+		if remaining := splitWarnings(detail, path, warnings); remaining != nil {
+			details = append(details, *remaining)
+		}
+	}
+	// A nested error that only held warnings is not a failure
+	if len(details) == 0 {
+		return nil
+	}
+	err.Details = details
+	return &err
+}
+
+// joinFieldPath appends a field name or [index] to a path
+func joinFieldPath(path, field string) string {
+	switch {
+	case path == "":
+		return field
+	case field == "":
+		return path
+	case strings.HasPrefix(field, "["):
+		return path + field
+	default:
+		return path + "." + field
+	}
+}
+
+// WarningReceiver is implemented by response bodies that carry validation warnings, such as
+// operations.Envelope
+type WarningReceiver interface {
+	SetWarnings(warnings []ValidationError)
+}
@@ -0,0 +1,75 @@
+package goop
+
+import (
+	"context"
+	"testing"
+)
+
+// TestSplitWarnings tests separating warnings from failures in nested validation errors
+func TestSplitWarnings(t *testing.T) {
+	t.Run("Warnings only", func(t *testing.T) {
+		err := NewNestedValidationError("", nil, "object validation failed", []ValidationError{
+			*NewNestedValidationError("items", nil, "array validation failed", []ValidationError{
+				*NewValidationWarning("[2]", 95, "quantity is close to the limit of 100"),
+			}),
+			*NewValidationWarning("nickname", "ada", "nickname is deprecated"),
+		})
+
+		warnings, remaining := SplitWarnings(err)
+		if remaining != nil {
+			t.Errorf("Expected no remaining error, got: %v", remaining)
+		}
+		if len(warnings) != 2 {
+			t.Fatalf("Expected 2 warnings, got %d", len(warnings))
+		}
+		if warnings[0].Field != "items[2]" || warnings[1].Field != "nickname" {
+			t.Errorf("Expected full field paths, got %q and %q", warnings[0].Field, warnings[1].Field)
+		}
+	})
+
+	t.Run("Warnings next to failures", func(t *testing.T) {
+		err := NewNestedValidationError("", nil, "object validation failed", []ValidationError{
+			*NewValidationError("email", "ada", "invalid email"),
+			*NewValidationWarning("nickname", "ada", "nickname is deprecated"),
+		})
+
+		warnings, remaining := SplitWarnings(err)
+		if len(warnings) != 1 {
+			t.Errorf("Expected 1 warning, got %d", len(warnings))
+		}
+		validationErr, ok := remaining.(*ValidationError)
+		if !ok || len(validationErr.Details) != 1 || validationErr.Details[0].Field != "email" {
+			t.Errorf("Expected only the email failure to remain, got: %v", remaining)
+		}
+		if len(err.Details) != 2 {
+			t.Error("Expected the original error to be left unchanged")
+		}
+	})
+
+	t.Run("Other errors", func(t *testing.T) {
+		warnings, remaining := SplitWarnings(context.Canceled)
+		if warnings != nil || remaining != context.Canceled {
+			t.Errorf("Expected the error to be returned as-is, got %v and %v", warnings, remaining)
+		}
+	})
+}
+
+// TestWarningHandler tests reporting warnings through the context
+func TestWarningHandler(t *testing.T) {
+	warning := *NewValidationWarning("nickname", "ada", "nickname is deprecated")
+
+	// Without a handler warnings are dropped
+	ReportWarnings(context.Background(), []ValidationError{warning})
+
+	var outer, inner []ValidationError
+	ctx := WithWarningHandler(context.Background(), func(w ValidationError) { outer = append(outer, w) })
+	ctx = WithWarningHandler(ctx, func(w ValidationError) { inner = append(inner, w) })
+	ReportWarnings(ctx, []ValidationError{warning})
+
+	if len(outer) != 1 || len(inner) != 1 {
+		t.Errorf("Expected both handlers to receive the warning, got %d and %d", len(outer), len(inner))
+	}
+	if !outer[0].IsWarning() {
+		t.Error("Expected the reported error to be a warning")
+	}
+}